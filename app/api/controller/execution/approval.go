@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/approval"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// StageApprovalDecideInput is the input of an approve or reject request.
+type StageApprovalDecideInput struct {
+	Comment string `json:"comment"`
+}
+
+const maxApprovalCommentLength = 1000
+
+func (in *StageApprovalDecideInput) sanitize() error {
+	if len(in.Comment) > maxApprovalCommentLength {
+		return usererror.BadRequestf("Comment can be at most %d characters long", maxApprovalCommentLength)
+	}
+
+	return nil
+}
+
+// FindApproval returns the approval gate of an execution stage.
+func (c *Controller) FindApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+) (*types.StageApproval, error) {
+	execution, err := c.findExecutionForView(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	return c.approvalService.Find(ctx, stage.ID)
+}
+
+// Approve approves the approval gate of an execution stage, which lets the execution continue.
+func (c *Controller) Approve(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageApprovalDecideInput,
+) (*types.StageApproval, error) {
+	return c.decideApproval(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum, in,
+		enum.StageApprovalDecisionApproved)
+}
+
+// Reject rejects the approval gate of an execution stage, which fails the stage.
+func (c *Controller) Reject(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageApprovalDecideInput,
+) (*types.StageApproval, error) {
+	return c.decideApproval(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum, in,
+		enum.StageApprovalDecisionRejected)
+}
+
+func (c *Controller) decideApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageApprovalDecideInput,
+	decision enum.StageApprovalDecision,
+) (*types.StageApproval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	execution, err := c.findExecutionForView(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	stageApproval, err := c.approvalService.Find(ctx, stage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the approval defines the pipeline permission required to decide it.
+	repo, err := c.getRepoCheckPipelineAccess(ctx, session, repoRef, pipelineIdentifier, stageApproval.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	isApprover, err := c.approvalService.IsApprover(ctx, stageApproval, repo.ParentID, &session.Principal)
+	if err != nil {
+		return nil, err
+	}
+	if !isApprover {
+		return nil, usererror.Forbidden("Not allowed to decide on this approval")
+	}
+
+	err = c.approvalService.Decide(ctx, stageApproval, decision, &session.Principal, in.Comment)
+	if errors.Is(err, approval.ErrNotPending) || errors.Is(err, approval.ErrNotWaiting) {
+		return nil, usererror.Conflict(err.Error())
+	}
+	if errors.Is(err, approval.ErrSelfApproval) {
+		return nil, usererror.Forbidden("Not allowed to approve an execution you triggered")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide on approval: %w", err)
+	}
+
+	return stageApproval, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListArtifacts lists the artifacts published by the steps of an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	pagination types.Pagination,
+) ([]*types.ExecutionArtifact, int64, error) {
+	execution, err := c.findExecutionForView(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	artifacts, err := c.artifactStore.List(ctx, execution.ID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	count, err := c.artifactStore.Count(ctx, execution.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count artifacts: %w", err)
+	}
+
+	return artifacts, count, nil
+}
+
+// DownloadArtifact returns either a signed URL or the content of an artifact of an execution.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	artifactID int64,
+) (*types.ExecutionArtifact, string, io.ReadCloser, error) {
+	execution, err := c.findExecutionForView(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, execution.ID, artifactID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, artifact.BlobPath, time.Now().Add(1*time.Hour))
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return artifact, signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, artifact.BlobPath)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download artifact from blobstore: %w", err)
+	}
+
+	return artifact, "", file, nil
+}
+
+func (c *Controller) findExecutionForView(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) (*types.Execution, error) {
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineView,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return execution, nil
+}
+
+// deleteArtifactBlobs removes the blobs of all artifacts of an execution.
+// Failures are only logged, as the cleanup job purges the artifacts of deleted executions anyway.
+func (c *Controller) deleteArtifactBlobs(ctx context.Context, executionID int64) {
+	const pageSize = 100
+	for page := 1; ; page++ {
+		artifacts, err := c.artifactStore.List(ctx, executionID, types.Pagination{Page: page, Size: pageSize})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("execution_id", executionID).
+				Msg("failed to list execution artifacts for deletion")
+			return
+		}
+
+		for _, artifact := range artifacts {
+			// the blob is shared with the copies of the artifact made by retried executions.
+			shared, err := c.artifactStore.CountByBlobPath(ctx, artifact.BlobPath)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Str("blob_path", artifact.BlobPath).
+					Msg("failed to count execution artifacts of blob")
+				continue
+			}
+			if shared > 1 {
+				continue
+			}
+
+			if err := c.blobStore.Delete(ctx, artifact.BlobPath); err != nil && !errors.Is(err, blob.ErrNotFound) {
+				log.Ctx(ctx).Warn().Err(err).Str("blob_path", artifact.BlobPath).
+					Msg("failed to delete execution artifact blob")
+			}
+		}
+
+		if len(artifacts) < pageSize {
+			return
+		}
+	}
+}
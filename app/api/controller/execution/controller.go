@@ -21,27 +21,33 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
-	repoFinder     refcache.RepoFinder
+	tx              dbtx.Transactor
+	authorizer      authz.Authorizer
+	executionStore  store.ExecutionStore
+	checkStore      store.CheckStore
+	canceler        canceler.Canceler
+	commitService   commit.Service
+	triggerer       triggerer.Triggerer
+	stageStore      store.StageStore
+	pipelineStore   store.PipelineStore
+	repoFinder      refcache.RepoFinder
+	testCaseStore   store.TestCaseStore
+	artifactStore   store.ExecutionArtifactStore
+	blobStore       blob.Store
+	approvalService *approval.Service
 }
 
 func NewController(
@@ -55,18 +61,26 @@ func NewController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	blobStore blob.Store,
+	approvalService *approval.Service,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
-		repoFinder:     repoFinder,
+		tx:              tx,
+		authorizer:      authorizer,
+		executionStore:  executionStore,
+		checkStore:      checkStore,
+		canceler:        canceler,
+		commitService:   commitService,
+		triggerer:       triggerer,
+		stageStore:      stageStore,
+		pipelineStore:   pipelineStore,
+		repoFinder:      repoFinder,
+		testCaseStore:   testCaseStore,
+		artifactStore:   artifactStore,
+		blobStore:       blobStore,
+		approvalService: approvalService,
 	}
 }
 
@@ -45,6 +45,15 @@ func (c *Controller) Delete(
 		return fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	// free the storage of the artifacts right away, their records are purged by the cleanup job
+	// once the execution is gone.
+	c.deleteArtifactBlobs(ctx, execution.ID)
+
 	err = c.executionStore.Delete(ctx, pipeline.ID, executionNum)
 	if err != nil {
 		return fmt.Errorf("could not delete execution: %w", err)
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Retry creates a new execution that reuses the succeeded stages of a finished execution
+// and re-runs its failed or cancelled stages along with the stages depending on them.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) (*types.Execution, error) {
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineExecute,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	retried, err := c.triggerer.Retry(ctx, pipeline, execution, &session.Principal)
+	if errors.Is(err, triggerer.ErrExecutionNotDone) {
+		return nil, usererror.Conflict("Only finished executions can be retried")
+	}
+	if errors.Is(err, triggerer.ErrNothingToRetry) {
+		return nil, usererror.BadRequest("The execution has no failed or cancelled stages to retry")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry execution %d: %w", executionNum, err)
+	}
+
+	return retried, nil
+}
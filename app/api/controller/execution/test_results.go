@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListTestResults lists the test cases reported by the steps of an execution together with their summary.
+func (c *Controller) ListTestResults(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	filter *types.TestCaseFilter,
+) (*types.TestResults, int64, error) {
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineView,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	summary, err := c.testCaseStore.Summarize(ctx, execution.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to summarize test results: %w", err)
+	}
+
+	cases, err := c.testCaseStore.List(ctx, execution.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list test cases: %w", err)
+	}
+
+	count, err := c.testCaseStore.Count(ctx, execution.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count test cases: %w", err)
+	}
+
+	return &types.TestResults{
+		Summary: *summary,
+		Cases:   cases,
+	}, count, nil
+}
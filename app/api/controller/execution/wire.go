@@ -16,11 +16,13 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
@@ -42,7 +44,12 @@ func ProvideController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	blobStore blob.Store,
+	approvalService *approval.Service,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder)
+		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder, testCaseStore,
+		artifactStore, blobStore, approvalService)
 }
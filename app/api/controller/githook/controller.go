@@ -26,6 +26,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -64,6 +65,8 @@ type Controller struct {
 	auditService        audit.Service
 	userGroupService    usergroup.Service
 	mergeQueueService   *mergequeue.Service
+
+	signatureVerifyService publickey.SignatureVerifyService
 }
 
 func NewController(
@@ -86,6 +89,7 @@ func NewController(
 	auditService audit.Service,
 	userGroupService usergroup.Service,
 	mergeQueueService *mergequeue.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -107,6 +111,8 @@ func NewController(
 		auditService:        auditService,
 		userGroupService:    userGroupService,
 		mergeQueueService:   mergeQueueService,
+
+		signatureVerifyService: signatureVerifyService,
 	}
 }
 
@@ -38,4 +38,5 @@ type RestrictedGIT interface {
 		params git.ProcessPreReceiveObjectsParams,
 	) (git.ProcessPreReceiveObjectsOutput, error)
 	MergeBase(ctx context.Context, params git.MergeBaseParams) (git.MergeBaseOutput, error)
+	DiffFileNames(ctx context.Context, params *git.DiffParams) (git.DiffFileNamesOutput, error)
 }
@@ -338,6 +338,8 @@ func (c *Controller) checkPushProtection(
 		PrincipalCommitterMatch: pushVerifyOut.PrincipalCommitterMatch,
 		SecretScanningEnabled:   pushVerifyOut.SecretScanningEnabled,
 		FoundSecretsCount:       secretsCount,
+		RequireSignedCommits:    pushVerifyOut.RequireSignedCommits,
+		RestrictedPaths:         pushVerifyOut.RestrictedPaths,
 	}
 
 	var settingsViolations repoSettingsViolations
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -86,6 +88,23 @@ func (c *Controller) processObjects(
 		preReceiveObjsIn.FindLFSPointersParams = &git.FindLFSPointersParams{}
 	}
 
+	// Merges are signed by the server and never reach this point (api_refs_only),
+	// but commits created via the commit API (e.g. the web editor) are unsigned and must be verified too.
+	if violationsInput.RequireSignedCommits && (in.OperationType == enum.GitOpTypeGitPush ||
+		in.OperationType == enum.GitOpTypeAPIContent ||
+		in.OperationType == enum.GitOpTypeAPIContentBypassRules) {
+		newSHAs := make([]sha.SHA, 0, len(in.RefUpdates))
+		for _, refUpdate := range in.RefUpdates {
+			if !refUpdate.New.IsNil() {
+				newSHAs = append(newSHAs, refUpdate.New)
+			}
+		}
+
+		preReceiveObjsIn.FindNewCommitsParams = &git.FindNewCommitsParams{
+			NewSHAs: newSHAs,
+		}
+	}
+
 	preReceiveObjsOut, err := rgit.ProcessPreReceiveObjects(
 		ctx,
 		preReceiveObjsIn,
@@ -150,6 +169,35 @@ func (c *Controller) processObjects(
 		}
 	}
 
+	if out := preReceiveObjsOut.FindNewCommitsOutput; out != nil && len(out.Commits) > 0 {
+		commits := make([]*types.Commit, len(out.Commits))
+		for i := range out.Commits {
+			commits[i] = controller.MapCommit(&out.Commits[i])
+		}
+
+		// The signature results aren't stored because the push can still be rejected.
+		err = c.signatureVerifyService.NewVerifySession(repo.ID).VerifyCommits(ctx, commits)
+		if err != nil {
+			return fmt.Errorf("failed to verify commit signatures: %w", err)
+		}
+
+		unsignedCommits := protection.UnsignedCommits(commits)
+		if len(unsignedCommits) > 0 {
+			printUnsignedCommits(output, unsignedCommits)
+
+			violationsInput.UnsignedCommits = unsignedCommits
+		}
+	}
+
+	if violationsInput.RestrictedPaths {
+		changedFiles, err := findChangedFiles(ctx, rgit, repo, in)
+		if err != nil {
+			return fmt.Errorf("failed to find changed files: %w", err)
+		}
+
+		violationsInput.ChangedFiles = changedFiles
+	}
+
 	violationsInput.FindOversizeFilesOutput = preReceiveObjsOut.FindOversizeFilesOutput
 
 	return nil
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/logging"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// findChangedFiles returns the paths of all files changed by the pushed branch updates.
+// Tags and other references are ignored, because they don't change the content of any branch.
+func findChangedFiles(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+) ([]string, error) {
+	var baseRevFallBack *sha.SHA
+	changedFiles := make(map[string]struct{})
+
+	for _, refUpdate := range in.RefUpdates {
+		ctx := logging.NewContext(ctx, loggingWithRefUpdate(refUpdate))
+		log := log.Ctx(ctx)
+
+		if refUpdate.New.IsNil() || !isBranch(refUpdate.Ref) {
+			continue
+		}
+
+		baseRev := refUpdate.Old
+		if baseRev.IsNil() {
+			// in case the branch was just created - fallback to compare against latest default branch.
+			if baseRevFallBack == nil {
+				fallbackSHA, fallbackAvailable, err := GetBaseSHAForScanningChanges(
+					ctx,
+					rgit,
+					repo,
+					in.Environment,
+					in.RefUpdates,
+					refUpdate,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get fallback sha: %w", err)
+				}
+
+				if !fallbackAvailable {
+					// compare against the empty tree, so all files of the new branch are treated as changed.
+					fallbackSHA = sha.EmptyTree
+				}
+
+				baseRevFallBack = &fallbackSHA
+			}
+
+			baseRev = *baseRevFallBack
+		}
+
+		log.Debug().Msgf("find files changed since %q", baseRev)
+
+		out, err := rgit.DiffFileNames(ctx, &git.DiffParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			BaseRef:   baseRev.String(),
+			HeadRef:   refUpdate.New.String(),
+			NoRenames: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find changed files of ref %q: %w", refUpdate.Ref, err)
+		}
+
+		for _, file := range out.Files {
+			changedFiles[file] = struct{}{}
+		}
+	}
+
+	files := make([]string, 0, len(changedFiles))
+	for file := range changedFiles {
+		files = append(files, file)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
@@ -19,12 +19,16 @@ import (
 	"slices"
 	"time"
 
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 
 	"github.com/fatih/color"
 )
 
+// maxPrintedUnsignedCommits is the max number of unsigned commits listed in the push output.
+const maxPrintedUnsignedCommits = 10
+
 var (
 	colorScanHeader            = color.New(color.FgHiWhite, color.Underline)
 	colorScanSummary           = color.New(color.FgHiRed, color.Bold)
@@ -217,6 +221,44 @@ func printCommitterMismatch(
 	)
 }
 
+func printUnsignedCommits(
+	output *hook.Output,
+	commits []protection.UnsignedCommit,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprint("Push contains commits without a valid signature:"),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for i, commit := range commits {
+		if i == maxPrintedUnsignedCommits {
+			break
+		}
+
+		result := "not signed"
+		if commit.Result != "" {
+			result = string(commit.Result)
+		}
+
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  %s    Signature: %s", commit.SHA, result),
+			"", // add empty line for making it visually more consumable
+		)
+	}
+
+	total := len(commits)
+	output.Messages = append(
+		output.Messages,
+		colorScanSummary.Sprintf(
+			"%d %s found without a valid signature",
+			total, singularOrPlural("commit", total > 1),
+		),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
+
 func printLFSPointers(
 	output *hook.Output,
 	lfsInfos []git.LFSInfo,
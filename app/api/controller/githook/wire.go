@@ -21,6 +21,7 @@ import (
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -69,6 +70,7 @@ func ProvideController(
 	auditService audit.Service,
 	userGroupService usergroup.Service,
 	mergeQueueService *mergequeue.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -90,6 +92,7 @@ func ProvideController(
 		auditService,
 		userGroupService,
 		mergeQueueService,
+		signatureVerifyService,
 	)
 
 	// TODO: improve wiring if possible
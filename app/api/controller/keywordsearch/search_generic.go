@@ -93,7 +93,7 @@ func (c *Controller) Search(
 		return types.SearchResult{}, usererror.NotFound("No repositories found")
 	}
 
-	result, err := c.searcher.Search(ctx, repoIDs, in.Query, in.EnableRegex, in.MaxResultCount)
+	result, err := c.searcher.Search(ctx, repoIDs, in.Query, in.EnableRegex, in.CaseSensitive, in.MaxResultCount)
 	if err != nil {
 		return types.SearchResult{}, fmt.Errorf("failed to search: %w", err)
 	}
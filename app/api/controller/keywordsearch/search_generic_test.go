@@ -98,6 +98,7 @@ func (s *fakeSearcher) Search(
 	repoIDs []int64,
 	_ string,
 	_ bool,
+	_ bool,
 	_ int,
 ) (types.SearchResult, error) {
 	s.calledWithRepoIDs = append([]int64(nil), repoIDs...)
@@ -23,9 +23,10 @@ import (
 )
 
 type SearchRepoInput struct {
-	Query string
-	Limit int
-	Regex bool
+	Query         string
+	Limit         int
+	Regex         bool
+	CaseSensitive bool
 }
 
 func (c *Controller) SearchRepo(
@@ -48,6 +49,7 @@ func (c *Controller) SearchRepo(
 		SpacePaths:     nil,
 		MaxResultCount: in.Limit,
 		EnableRegex:    in.Regex,
+		CaseSensitive:  in.CaseSensitive,
 		Recursive:      false,
 	})
 }
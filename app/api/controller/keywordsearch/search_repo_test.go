@@ -147,16 +147,17 @@ func TestSearchRepo_RegexAndLimitForwarded(t *testing.T) {
 	}
 
 	_, err := ctrl.SearchRepo(context.Background(), &auth.Session{}, "gamma/web", SearchRepoInput{
-		Query: "match",
-		Limit: 25,
-		Regex: true,
+		Query:         "match",
+		Limit:         25,
+		Regex:         true,
+		CaseSensitive: true,
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if searcher.query != "match" || searcher.maxResultCount != 25 || !searcher.enableRegex {
-		t.Fatalf("expected query/limit/regex forwarded, got query=%q limit=%d regex=%v",
-			searcher.query, searcher.maxResultCount, searcher.enableRegex)
+	if searcher.query != "match" || searcher.maxResultCount != 25 || !searcher.enableRegex || !searcher.caseSensitive {
+		t.Fatalf("expected query/limit/regex/case forwarded, got query=%q limit=%d regex=%v case=%v",
+			searcher.query, searcher.maxResultCount, searcher.enableRegex, searcher.caseSensitive)
 	}
 }
 
@@ -166,6 +167,7 @@ type recordingSearcher struct {
 	repoIDs        []int64
 	query          string
 	enableRegex    bool
+	caseSensitive  bool
 	maxResultCount int
 }
 
@@ -174,11 +176,13 @@ func (s *recordingSearcher) Search(
 	repoIDs []int64,
 	query string,
 	enableRegex bool,
+	caseSensitive bool,
 	maxResultCount int,
 ) (types.SearchResult, error) {
 	s.repoIDs = append([]int64(nil), repoIDs...)
 	s.query = query
 	s.enableRegex = enableRegex
+	s.caseSensitive = caseSensitive
 	s.maxResultCount = maxResultCount
 	return types.SearchResult{}, nil
 }
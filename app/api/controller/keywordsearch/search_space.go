@@ -25,11 +25,12 @@ import (
 )
 
 type SearchSpaceInput struct {
-	Query     string
-	Limit     int
-	Regex     bool
-	RepoPaths []string
-	Recursive bool
+	Query         string
+	Limit         int
+	Regex         bool
+	CaseSensitive bool
+	RepoPaths     []string
+	Recursive     bool
 }
 
 func (c *Controller) SearchSpace(
@@ -76,6 +77,7 @@ func (c *Controller) SearchSpace(
 		SpacePaths:     spacePaths,
 		MaxResultCount: in.Limit,
 		EnableRegex:    in.Regex,
+		CaseSensitive:  in.CaseSensitive,
 		Recursive:      recursive,
 	})
 }
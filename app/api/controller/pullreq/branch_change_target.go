@@ -120,6 +120,7 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
 		pr.MergeTargetSHA = ptr.String(targetSHA.String())
 		pr.TargetBranch = in.BranchName
+		pr.StackParentID = nil // a stacked pull request always targets the source branch of its parent
 		pr.Stats.DiffStats = types.NewDiffStats(
 			diffStats.Commits,
 			diffStats.FilesChanged,
@@ -101,6 +101,7 @@ type Controller struct {
 	branchStore             store.BranchStore
 	userGroupResolver       usergroup.Resolver
 	signatureVerifyService  publickey.SignatureVerifyService
+	notificationWatchStore  store.NotificationWatchStore
 }
 
 func NewController(
@@ -151,6 +152,7 @@ func NewController(
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
 	signatureVerifyService publickey.SignatureVerifyService,
+	notificationWatchStore store.NotificationWatchStore,
 ) *Controller {
 	return &Controller{
 		tx:                      tx,
@@ -200,6 +202,7 @@ func NewController(
 		branchStore:             branchStore,
 		userGroupResolver:       userGroupResolver,
 		signatureVerifyService:  signatureVerifyService,
+		notificationWatchStore:  notificationWatchStore,
 	}
 }
 
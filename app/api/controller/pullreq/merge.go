@@ -304,6 +304,7 @@ func (c *Controller) Merge(
 		// We distinguish two types when checking mergeability: Rebase and Non-Rebase.
 		// * Merge methods Merge and Squash will always have the same results.
 		// * Merge method Rebase is special because it must always check all commits, one at a time.
+		// * Merge method Semi-linear has the same results as Rebase, the final merge commit can't have conflicts.
 		// * Merge method Fast-Forward can never have conflicts,
 		//   but for it the merge base SHA must be equal to target branch SHA.
 		// The result of the tests will be stored (think cached) in the database for these two types
@@ -317,7 +318,7 @@ func (c *Controller) Merge(
 			switch method {
 			case enum.MergeMethodMerge, enum.MergeMethodSquash:
 				return pr.MergeCheckStatus == enum.MergeCheckStatusUnchecked
-			case enum.MergeMethodRebase:
+			case enum.MergeMethodRebase, enum.MergeMethodSemiLinear:
 				return pr.RebaseCheckStatus == enum.MergeCheckStatusUnchecked
 			case enum.MergeMethodFastForward:
 				// Always check for ff merge. There can never be conflicts,
@@ -389,7 +390,7 @@ func (c *Controller) Merge(
 		}
 
 		var conflicts []string
-		if in.Method == enum.MergeMethodRebase {
+		if in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodSemiLinear {
 			conflicts = pr.RebaseConflicts
 		} else {
 			conflicts = pr.MergeConflicts
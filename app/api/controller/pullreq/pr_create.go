@@ -60,6 +60,9 @@ type CreateInput struct {
 
 	Labels []*types.PullReqLabelAssignInput `json:"labels"`
 
+	// StackParentNumber is the number of the pull request the new pull request depends on.
+	StackParentNumber *int64 `json:"stack_parent_number,omitempty"`
+
 	BypassRules bool `json:"bypass_rules"`
 }
 
@@ -129,6 +132,16 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	var stackParentID *int64
+	if in.StackParentNumber != nil {
+		parent, err := c.verifyStackParent(ctx, targetRepo, sourceRepo.ID, in.TargetBranch, *in.StackParentNumber, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		stackParentID = &parent.ID
+	}
+
 	targetWriteParams, err := controller.CreateRPCSystemReferencesWriteParams(
 		ctx, c.urlProvider, session, targetRepo,
 	)
@@ -349,6 +362,7 @@ func (c *Controller) Create(
 				Conversations:   0,
 				UnresolvedCount: 0,
 			},
+			StackParentID: stackParentID,
 		}
 
 		targetRepo = targetRepoFull.Core()
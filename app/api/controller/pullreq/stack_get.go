@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxStackDepth limits the number of pull requests followed when walking a stack of pull requests.
+const maxStackDepth = 64
+
+// StackGet returns the stack of pull requests the pull request belongs to. The stack is ordered from the bottom:
+// the ancestors go first (the one that should be merged first is the first), followed by the pull request itself
+// and then by the open pull requests that depend on it, in depth-first order.
+func (c *Controller) StackGet(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]types.PullReqStackEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	ancestors, err := c.stackAncestors(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := make([]types.PullReqStackEntry, 0, len(ancestors)+1)
+
+	var parentNumber *int64
+	for _, ancestor := range ancestors {
+		stack = append(stack, newStackEntry(ancestor, parentNumber, len(stack), false))
+		parentNumber = &ancestor.Number
+	}
+
+	stack = append(stack, newStackEntry(pr, parentNumber, len(stack), true))
+
+	stack, err = c.appendStackDescendants(ctx, stack, pr, len(stack))
+	if err != nil {
+		return nil, err
+	}
+
+	return stack, nil
+}
+
+// stackAncestors returns all pull requests the pull request depends on, the bottom of the stack first.
+func (c *Controller) stackAncestors(ctx context.Context, pr *types.PullReq) ([]*types.PullReq, error) {
+	var ancestors []*types.PullReq
+
+	for parentID := pr.StackParentID; parentID != nil && len(ancestors) < maxStackDepth; {
+		parent, err := c.pullreqStore.Find(ctx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find stack parent pull request: %w", err)
+		}
+
+		ancestors = append(ancestors, parent)
+		parentID = parent.StackParentID
+	}
+
+	for i, j := 0, len(ancestors)-1; i < j; i, j = i+1, j-1 {
+		ancestors[i], ancestors[j] = ancestors[j], ancestors[i]
+	}
+
+	return ancestors, nil
+}
+
+// appendStackDescendants appends the open pull requests depending on the pull request to the stack.
+func (c *Controller) appendStackDescendants(
+	ctx context.Context,
+	stack []types.PullReqStackEntry,
+	pr *types.PullReq,
+	depth int,
+) ([]types.PullReqStackEntry, error) {
+	if depth > maxStackDepth {
+		return stack, nil
+	}
+
+	children, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+		TargetRepoID:       pr.TargetRepoID,
+		StackParentID:      pr.ID,
+		States:             []enum.PullReqState{enum.PullReqStateOpen},
+		Size:               maxStackDepth,
+		Sort:               enum.PullReqSortNumber,
+		Order:              enum.OrderAsc,
+		ExcludeDescription: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests depending on pull request %d: %w", pr.Number, err)
+	}
+
+	for _, child := range children {
+		stack = append(stack, newStackEntry(child, &pr.Number, depth, false))
+
+		stack, err = c.appendStackDescendants(ctx, stack, child, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stack, nil
+}
+
+func newStackEntry(pr *types.PullReq, parentNumber *int64, depth int, current bool) types.PullReqStackEntry {
+	return types.PullReqStackEntry{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        pr.State,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		ParentNumber: parentNumber,
+		Depth:        depth,
+		Current:      current,
+	}
+}
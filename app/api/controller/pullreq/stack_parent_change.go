@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type StackParentChangeInput struct {
+	// ParentNumber is the number of the pull request this pull request depends on.
+	// If it's nil, the pull request is removed from the stack.
+	ParentNumber *int64 `json:"parent_number"`
+}
+
+// StackParentChange sets or removes the pull request this pull request depends on.
+// The pull request can't be merged before its parent and, once the parent is merged,
+// it is automatically retargeted to the parent's target branch and rebased.
+func (c *Controller) StackParentChange(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *StackParentChangeInput,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open.")
+	}
+
+	if c.mergeQueueService.IsEnqueued(pr) {
+		return nil, usererror.BadRequest(
+			"Changing the stack is not allowed, because the pull request is in the merge queue.")
+	}
+
+	var oldParentNumber *int64
+	if pr.StackParentID != nil {
+		oldParent, err := c.pullreqStore.Find(ctx, *pr.StackParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find stack parent pull request: %w", err)
+		}
+
+		oldParentNumber = &oldParent.Number
+	}
+
+	var newParentID *int64
+	if in.ParentNumber != nil {
+		if oldParentNumber != nil && *oldParentNumber == *in.ParentNumber {
+			return pr, nil
+		}
+
+		sourceRepoID := int64(0)
+		if pr.SourceRepoID != nil {
+			sourceRepoID = *pr.SourceRepoID
+		}
+
+		parent, err := c.verifyStackParent(ctx, repo, sourceRepoID, pr.TargetBranch, *in.ParentNumber, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newParentID = &parent.ID
+	} else if oldParentNumber == nil {
+		return pr, nil
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return usererror.BadRequest("Pull request must be open.")
+		}
+
+		pr.StackParentID = newParentID
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request stack parent: %w", err)
+	}
+
+	_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadStackParentChange{
+			Old: oldParentNumber,
+			New: in.ParentNumber,
+		}, nil)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after stack parent change")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return pr, nil
+}
+
+// verifyStackParent verifies that a pull request can depend on the pull request with the provided number.
+// Both pull requests must have the source branch in the target repository, and the pull request must
+// target the source branch of its parent.
+func (c *Controller) verifyStackParent(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	sourceRepoID int64,
+	targetBranch string,
+	parentNumber int64,
+	pullreqID int64,
+) (*types.PullReq, error) {
+	if sourceRepoID != repo.ID {
+		return nil, usererror.BadRequest(
+			"Only pull requests with the source branch in the target repository can be stacked.")
+	}
+
+	parent, err := c.pullreqStore.FindByNumber(ctx, repo.ID, parentNumber)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Pull request #%d doesn't exist.", parentNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stack parent pull request: %w", err)
+	}
+
+	if parent.ID == pullreqID {
+		return nil, usererror.BadRequest("A pull request can't depend on itself.")
+	}
+
+	if parent.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequestf("Pull request #%d isn't open.", parent.Number)
+	}
+
+	if parent.SourceRepoID == nil || *parent.SourceRepoID != repo.ID {
+		return nil, usererror.BadRequestf(
+			"Pull request #%d doesn't have the source branch in the target repository.", parent.Number)
+	}
+
+	if parent.SourceBranch != targetBranch {
+		return nil, usererror.BadRequestf(
+			"The target branch must be %q, the source branch of pull request #%d.",
+			parent.SourceBranch, parent.Number)
+	}
+
+	ancestors, err := c.stackAncestors(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ancestors) >= maxStackDepth-1 {
+		return nil, usererror.BadRequestf("A stack can't have more than %d pull requests.", maxStackDepth)
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.ID == pullreqID {
+			return nil, usererror.BadRequestf(
+				"Pull request #%d already depends on this pull request.", parent.Number)
+		}
+	}
+
+	return parent, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindWatch returns the notification watch of the current principal for the pull request.
+func (c *Controller) FindWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.NotificationWatch, error) {
+	pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, err := c.notificationWatchStore.Find(ctx, session.Principal.ID, pr.TargetRepoID, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// UpdateWatch watches or unwatches the pull request for the current principal.
+// The watch of the pull request takes precedence over the watch of the repository.
+func (c *Controller) UpdateWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *types.NotificationWatchInput,
+) (*types.NotificationWatch, error) {
+	pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	watch := &types.NotificationWatch{
+		PrincipalID: session.Principal.ID,
+		RepoID:      pr.TargetRepoID,
+		PullReqID:   pr.ID,
+		Watching:    in.Watching,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.notificationWatchStore.Upsert(ctx, watch); err != nil {
+		return nil, fmt.Errorf("failed to update notification watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// DeleteWatch removes the notification watch of the current principal for the pull request.
+func (c *Controller) DeleteWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return err
+	}
+
+	if err = c.notificationWatchStore.Delete(ctx, session.Principal.ID, pr.TargetRepoID, pr.ID); err != nil {
+		return fmt.Errorf("failed to delete notification watch: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) getWatchedPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return pr, nil
+}
@@ -96,6 +96,7 @@ func ProvideController(
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
 	signatureVerifyService publickey.SignatureVerifyService,
+	notificationWatchStore store.NotificationWatchStore,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -144,5 +145,6 @@ func ProvideController(
 		branchStore,
 		userGroupResolver,
 		signatureVerifyService,
+		notificationWatchStore,
 	)
 }
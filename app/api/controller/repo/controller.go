@@ -131,6 +131,7 @@ type Controller struct {
 	webhookService         importer.WebhookService
 	repoLangStore          store.RepoLangStore
 	mergeQueueService      *mergequeuesvc.Service
+	notificationWatchStore store.NotificationWatchStore
 }
 
 func NewController(
@@ -182,6 +183,7 @@ func NewController(
 	webhookService importer.WebhookService,
 	repoLangStore store.RepoLangStore,
 	mergeQueueService *mergequeuesvc.Service,
+	notificationWatchStore store.NotificationWatchStore,
 ) *Controller {
 	return &Controller{
 		defaultBranch:          config.Git.DefaultBranch,
@@ -232,6 +234,7 @@ func NewController(
 		webhookService:         webhookService,
 		repoLangStore:          repoLangStore,
 		mergeQueueService:      mergeQueueService,
+		notificationWatchStore: notificationWatchStore,
 	}
 }
 
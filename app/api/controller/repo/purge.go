@@ -104,14 +104,19 @@ func (c *Controller) PurgeNoAuth(
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
+	c.ReportDeleted(ctx, repo)
+
+	return nil
+}
+
+// ReportDeleted reports the deleted event of a purged repository.
+func (c *Controller) ReportDeleted(ctx context.Context, repo *types.Repository) {
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
 			Base: eventBase(repo.Core(), &bootstrap.NewSystemServiceSession().Principal),
 		},
 	)
-
-	return nil
 }
 
 func (c *Controller) DeleteGitRepository(
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindWatch returns the notification watch of the current principal for the repository.
+func (c *Controller) FindWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.NotificationWatch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, err := c.notificationWatchStore.Find(ctx, session.Principal.ID, repo.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// UpdateWatch watches or unwatches all pull requests of the repository for the current principal.
+func (c *Controller) UpdateWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.NotificationWatchInput,
+) (*types.NotificationWatch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	watch := &types.NotificationWatch{
+		PrincipalID: session.Principal.ID,
+		RepoID:      repo.ID,
+		Watching:    in.Watching,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.notificationWatchStore.Upsert(ctx, watch); err != nil {
+		return nil, fmt.Errorf("failed to update notification watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// DeleteWatch removes the notification watch of the current principal for the repository,
+// which restores the default behavior of getting notified only about pull requests the principal is involved in.
+func (c *Controller) DeleteWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return err
+	}
+
+	if err = c.notificationWatchStore.Delete(ctx, session.Principal.ID, repo.ID, 0); err != nil {
+		return fmt.Errorf("failed to delete notification watch: %w", err)
+	}
+
+	return nil
+}
@@ -102,6 +102,7 @@ func ProvideController(
 	webhookService importer.WebhookService,
 	repoLangStore store.RepoLangStore,
 	mergeQueueService *mergequeue.Service,
+	notificationWatchStore store.NotificationWatchStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -113,7 +114,7 @@ func ProvideController(
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
 		rulesSvc, sseStreamer, lfsCtrl, favoriteStore, signatureVerifyService,
 		autolinkSvc, dotRangeService, connectorService, webhookService,
-		repoLangStore, mergeQueueService,
+		repoLangStore, mergeQueueService, notificationWatchStore,
 	)
 }
 
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationFind returns the notification settings of a repo.
+func (c *Controller) NotificationFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*settings.NotificationSettings, error) {
+	// chat channel URLs act as credentials, hence only users that can edit the settings can see the channels.
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings.RepoMapWithDefaults(
+		ctx,
+		c.settings,
+		repo.ID,
+		settings.GetDefaultNotificationSettings,
+		settings.GetNotificationSettingsMappings,
+	)
+}
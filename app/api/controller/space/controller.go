@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/customrole"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
@@ -111,6 +112,7 @@ type Controller struct {
 	favoriteStore       store.FavoriteStore
 	autolinkSvc         *autolink.Service
 	spaceSvc            *space.Service
+	customRoleSvc       *customrole.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -126,7 +128,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider.Service, favoriteStore store.FavoriteStore, autolinkSvc *autolink.Service,
-	spaceSvc *space.Service,
+	spaceSvc *space.Service, customRoleSvc *customrole.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -164,6 +166,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		favoriteStore:       favoriteStore,
 		autolinkSvc:         autolinkSvc,
 		spaceSvc:            spaceSvc,
+		customRoleSvc:       customRoleSvc,
 	}
 }
 
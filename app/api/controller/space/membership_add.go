@@ -42,18 +42,34 @@ func (in *MembershipAddInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
+	// custom roles are validated against the space when the membership is stored.
+	if role, ok := in.Role.Sanitize(); ok {
+		in.Role = role
 	}
 
-	in.Role = role
-
 	return nil
 }
 
+// resolveMembershipRole returns the role as it should be stored in a membership of the space.
+// Custom roles must be defined in the space or in any of its ancestors.
+func (c *Controller) resolveMembershipRole(
+	ctx context.Context,
+	space *types.SpaceCore,
+	role enum.MembershipRole,
+) (enum.MembershipRole, error) {
+	resolved, err := c.customRoleSvc.Resolve(ctx, space, role)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", usererror.BadRequestf(
+			"Provided role '%s' is not supported. Valid values are: %v or a custom role of the space",
+			role, enum.MembershipRoles)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve membership role: %w", err)
+	}
+
+	return resolved, nil
+}
+
 // MembershipAdd adds a new membership to a space.
 func (c *Controller) MembershipAdd(ctx context.Context,
 	session *auth.Session,
@@ -70,6 +86,11 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
+	in.Role, err = c.resolveMembershipRole(ctx, space, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
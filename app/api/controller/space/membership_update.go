@@ -33,15 +33,11 @@ func (in *MembershipUpdateInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
+	// custom roles are validated against the space when the membership is stored.
+	if role, ok := in.Role.Sanitize(); ok {
+		in.Role = role
 	}
 
-	in.Role = role
-
 	return nil
 }
 
@@ -62,6 +58,11 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
+	in.Role, err = c.resolveMembershipRole(ctx, space, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, userUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by uid: %w", err)
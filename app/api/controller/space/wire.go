@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/customrole"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
@@ -59,7 +60,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	labelSvc *label.Service, instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider2.Service, favoriteStore store.FavoriteStore, autolinkSvc *autolink.Service,
-	spaceSvc *space.Service,
+	spaceSvc *space.Service, customRoleSvc *customrole.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		sseStreamer, identifierCheck, authorizer,
@@ -72,6 +73,6 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		auditService, gitspaceService,
 		labelSvc, instrumentation, executionStore,
 		rulesSvc, usageMetricStore, repoIdentifierCheck,
-		infraProviderSvc, favoriteStore, autolinkSvc, spaceSvc,
+		infraProviderSvc, favoriteStore, autolinkSvc, spaceSvc, customRoleSvc,
 	)
 }
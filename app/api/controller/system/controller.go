@@ -34,6 +34,10 @@ func NewController(principalStore store.PrincipalStore, config *types.Config) *C
 }
 
 func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
+	if !c.IsLocalLoginEnabled() {
+		return false, nil
+	}
+
 	usrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{})
 	if err != nil {
 		return false, err
@@ -41,3 +45,9 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 
 	return usrCount == 0 || c.config.UserSignupEnabled, nil
 }
+
+// IsLocalLoginEnabled returns false if the login and sign-up with a local password are disabled
+// in favor of single sign-on.
+func (c *Controller) IsLocalLoginEnabled() bool {
+	return !c.config.OIDC.Enabled || !c.config.OIDC.DisableLocalLogin
+}
@@ -18,8 +18,11 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/externaluser"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -42,6 +45,12 @@ type Controller struct {
 	eventReporter           *userevents.Reporter
 	repoFinder              refcache.RepoFinder
 	favoriteStore           store.FavoriteStore
+	notificationPrefStore   store.NotificationPreferenceStore
+	provisioner             *externaluser.Provisioner
+	memberSyncer            *usergroup.MemberSyncer
+	ldapSyncer              *usergroup.LDAPSyncer
+	oidcProvider            *oidc.Provider
+	config                  *types.Config
 }
 
 func NewController(
@@ -57,6 +66,12 @@ func NewController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	notificationPrefStore store.NotificationPreferenceStore,
+	provisioner *externaluser.Provisioner,
+	memberSyncer *usergroup.MemberSyncer,
+	ldapSyncer *usergroup.LDAPSyncer,
+	oidcProvider *oidc.Provider,
+	config *types.Config,
 ) *Controller {
 	return &Controller{
 		tx:                      tx,
@@ -71,11 +86,22 @@ func NewController(
 		eventReporter:           eventReporter,
 		repoFinder:              repoFinder,
 		favoriteStore:           favoriteStore,
+		notificationPrefStore:   notificationPrefStore,
+		provisioner:             provisioner,
+		memberSyncer:            memberSyncer,
+		ldapSyncer:              ldapSyncer,
+		oidcProvider:            oidcProvider,
+		config:                  config,
 	}
 }
 
 var hashPassword = bcrypt.GenerateFromPassword
 
+// localLoginDisabled returns true if the login with a local password is disabled in favor of single sign-on.
+func (c *Controller) localLoginDisabled() bool {
+	return c.config.OIDC.Enabled && c.config.OIDC.DisableLocalLogin
+}
+
 func findUserFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, userUID string,
 ) (*types.User, error) {
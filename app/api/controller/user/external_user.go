@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/externaluser"
+	"github.com/harness/gitness/types"
+)
+
+// externalUserError converts the errors of the just-in-time provisioning of external users to user errors.
+func externalUserError(err error) error {
+	switch {
+	case errors.Is(err, externaluser.ErrEmailMissing):
+		return usererror.BadRequest("The identity provider didn't provide an email address for the user")
+	case errors.Is(err, externaluser.ErrEmailConflict):
+		return usererror.Conflict("A user with the same email address already exists")
+	default:
+		return err
+	}
+}
+
+// findOrCreateExternalUser returns the user that is linked to the external identity, creating it if required.
+func (c *Controller) findOrCreateExternalUser(
+	ctx context.Context,
+	identity *externaluser.Identity,
+) (*types.User, error) {
+	user, err := c.provisioner.FindOrCreate(ctx, identity)
+	if err != nil {
+		return nil, externalUserError(err)
+	}
+
+	return user, nil
+}
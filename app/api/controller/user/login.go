@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/ldap"
 	userevents "github.com/harness/gitness/app/events/user"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store"
@@ -34,12 +35,49 @@ type LoginInput struct {
 }
 
 // Login attempts to login as a specific user - returns the session token if successful.
+// If LDAP is enabled, users that can't be authenticated with a local password are authenticated against the directory.
 func (c *Controller) Login(
 	ctx context.Context,
 	in *LoginInput,
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
+	if c.localLoginDisabled() && c.ldapSyncer == nil {
+		return nil, usererror.Forbidden("Login with a local password is disabled, use single sign-on instead")
+	}
+
+	user, err := c.loginLocal(ctx, in)
+	if errors.Is(err, usererror.ErrNotFound) && c.ldapSyncer != nil {
+		user, err = c.loginLDAP(ctx, in)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	tokenIdentifier := token.GenerateIdentifier("login")
+
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
+		Base: userevents.Base{PrincipalID: user.ID},
+	})
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// loginLocal verifies the local password of the user.
+func (c *Controller) loginLocal(ctx context.Context, in *LoginInput) (*types.User, error) {
+	if c.localLoginDisabled() {
+		return nil, usererror.ErrNotFound
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
@@ -64,16 +102,20 @@ func (c *Controller) Login(
 		return nil, usererror.ErrNotFound
 	}
 
-	tokenIdentifier := token.GenerateIdentifier("login")
+	return user, nil
+}
 
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+// loginLDAP verifies the password against the LDAP directory, the user is created on its first login.
+func (c *Controller) loginLDAP(ctx context.Context, in *LoginInput) (*types.User, error) {
+	user, err := c.ldapSyncer.Login(ctx, in.LoginIdentifier, in.Password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		log.Ctx(ctx).Debug().
+			Msgf("invalid ldap credentials for %q (returning ErrNotFound).", in.LoginIdentifier)
+		return nil, usererror.ErrNotFound
+	}
 	if err != nil {
-		return nil, err
+		return nil, externalUserError(err)
 	}
 
-	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
-		Base: userevents.Base{PrincipalID: user.ID},
-	})
-
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return user, nil
 }
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListNotificationPreferences returns the notification preferences of the user for every event.
+// Events without a stored preference are returned with the default notification mode.
+func (c *Controller) ListNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.NotificationPreference, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.notificationPrefStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	return withDefaultNotificationPreferences(user.ID, prefs), nil
+}
+
+func withDefaultNotificationPreferences(
+	principalID int64,
+	prefs []*types.NotificationPreference,
+) []*types.NotificationPreference {
+	byEvent := make(map[enum.NotificationEvent]*types.NotificationPreference, len(prefs))
+	for _, pref := range prefs {
+		byEvent[pref.Event] = pref
+	}
+
+	events, _ := enum.GetAllNotificationEvents()
+	_, defaultMode := enum.GetAllNotificationModes()
+
+	result := make([]*types.NotificationPreference, len(events))
+	for i, event := range events {
+		if pref, ok := byEvent[event]; ok {
+			result[i] = pref
+			continue
+		}
+
+		result[i] = &types.NotificationPreference{
+			PrincipalID: principalID,
+			Event:       event,
+			Mode:        defaultMode,
+		}
+	}
+
+	return result
+}
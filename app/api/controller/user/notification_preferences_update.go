@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationPreferencesUpdateInput holds the notification modes the user wants to change, per event.
+type NotificationPreferencesUpdateInput struct {
+	Preferences []NotificationPreferenceInput `json:"preferences"`
+}
+
+type NotificationPreferenceInput struct {
+	Event enum.NotificationEvent `json:"event"`
+	Mode  enum.NotificationMode  `json:"mode"`
+}
+
+func (in *NotificationPreferencesUpdateInput) sanitize() error {
+	if len(in.Preferences) == 0 {
+		return usererror.BadRequest("At least one notification preference must be provided.")
+	}
+
+	seen := make(map[enum.NotificationEvent]bool, len(in.Preferences))
+	for i := range in.Preferences {
+		event, ok := in.Preferences[i].Event.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unknown notification event %q.", in.Preferences[i].Event)
+		}
+		if seen[event] {
+			return usererror.BadRequestf("Notification event %q is provided more than once.", event)
+		}
+		seen[event] = true
+
+		mode, ok := in.Preferences[i].Mode.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unknown notification mode %q.", in.Preferences[i].Mode)
+		}
+
+		in.Preferences[i].Event = event
+		in.Preferences[i].Mode = mode
+	}
+
+	return nil
+}
+
+// UpdateNotificationPreferences updates the notification preferences of the user
+// and returns the preferences of the user for every event.
+func (c *Controller) UpdateNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *NotificationPreferencesUpdateInput,
+) ([]*types.NotificationPreference, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, prefIn := range in.Preferences {
+			err := c.notificationPrefStore.Upsert(ctx, &types.NotificationPreference{
+				PrincipalID: user.ID,
+				Event:       prefIn.Event,
+				Mode:        prefIn.Mode,
+				Created:     now,
+				Updated:     now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update notification preference for event %s: %w", prefIn.Event, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.notificationPrefStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	return withDefaultNotificationPreferences(user.ID, prefs), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/externaluser"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errOIDCDisabled = usererror.NotFound("Single sign-on is not enabled")
+
+// OIDCCallbackInput contains the parameters the identity provider redirects back with.
+type OIDCCallbackInput struct {
+	Code             string
+	State            string
+	Error            string
+	ErrorDescription string
+}
+
+// OIDCLoginStart starts a single sign-on login. It returns the URL of the identity provider the user has
+// to be redirected to, and the login state that has to be kept by the client until the callback.
+func (c *Controller) OIDCLoginStart(ctx context.Context) (string, *oidc.LoginState, error) {
+	if c.oidcProvider == nil {
+		return "", nil, errOIDCDisabled
+	}
+
+	state := oidc.NewLoginState()
+
+	authURL, err := c.oidcProvider.AuthCodeURL(ctx, state)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate authorization URL: %w", err)
+	}
+
+	return authURL, state, nil
+}
+
+// OIDCLoginCallback completes a single sign-on login - returns the session token if successful.
+// The user is created on the first login and its user group memberships are synced with the groups of the ID token.
+func (c *Controller) OIDCLoginCallback(
+	ctx context.Context,
+	state *oidc.LoginState,
+	in *OIDCCallbackInput,
+) (*types.TokenResponse, error) {
+	if c.oidcProvider == nil {
+		return nil, errOIDCDisabled
+	}
+
+	if in.Error != "" {
+		log.Ctx(ctx).Info().
+			Str("error", in.Error).
+			Str("error_description", in.ErrorDescription).
+			Msg("identity provider returned an error")
+		return nil, usererror.Forbidden("Single sign-on was denied by the identity provider")
+	}
+
+	if state == nil || subtle.ConstantTimeCompare([]byte(state.State), []byte(in.State)) != 1 {
+		return nil, usererror.BadRequest("Invalid or expired single sign-on login state")
+	}
+
+	claims, err := c.oidcProvider.Exchange(ctx, in.Code, state)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to complete single sign-on login")
+		return nil, usererror.ErrUnauthorized
+	}
+
+	user, err := c.findOrCreateExternalUser(ctx, &externaluser.Identity{
+		Provider:      c.oidcProvider.Issuer(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		DisplayName:   claims.Name,
+		Username:      claims.PreferredUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	err = c.memberSyncer.Sync(ctx, user.ID, c.oidcProvider.GroupMapping(), claims.Groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync usergroup memberships: %w", err)
+	}
+
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, token.GenerateIdentifier("oidc"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
+		Base: userevents.Base{PrincipalID: user.ID},
+	})
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
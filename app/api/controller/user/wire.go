@@ -16,10 +16,14 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/externaluser"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -42,6 +46,12 @@ func ProvideController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	notificationPrefStore store.NotificationPreferenceStore,
+	provisioner *externaluser.Provisioner,
+	memberSyncer *usergroup.MemberSyncer,
+	ldapSyncer *usergroup.LDAPSyncer,
+	oidcProvider *oidc.Provider,
+	config *types.Config,
 ) *Controller {
 	return NewController(
 		tx,
@@ -55,5 +65,12 @@ func ProvideController(
 		gitSignatureResultStore,
 		eventReporter,
 		repoFinder,
-		favoriteStore)
+		favoriteStore,
+		notificationPrefStore,
+		provisioner,
+		memberSyncer,
+		ldapSyncer,
+		oidcProvider,
+		config,
+	)
 }
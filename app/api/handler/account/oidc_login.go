@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/auth/oidc"
+)
+
+const (
+	oidcStateCookieName   = "gitness_oidc_state"
+	oidcStateCookieMaxAge = 10 * time.Minute
+)
+
+// HandleOIDCLogin starts the single sign-on login by redirecting the user to the identity provider.
+func HandleOIDCLogin(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		authURL, state, err := userCtrl.OIDCLoginStart(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cookie := newOIDCStateCookie(r)
+		cookie.Value = state.Encode()
+		cookie.MaxAge = int(oidcStateCookieMaxAge.Seconds())
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback completes the single sign-on login once the identity provider redirects back.
+// If a cookie name is configured, the session token is set as cookie and the user is redirected to the UI.
+func HandleOIDCCallback(userCtrl *user.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var state *oidc.LoginState
+		if cookie, err := r.Cookie(oidcStateCookieName); err == nil {
+			state, _ = oidc.DecodeLoginState(cookie.Value)
+		}
+
+		// the login state is single use - remove it regardless of the outcome.
+		cookie := newOIDCStateCookie(r)
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+
+		q := r.URL.Query()
+		tokenResponse, err := userCtrl.OIDCLoginCallback(ctx, state, &user.OIDCCallbackInput{
+			Code:             q.Get("code"),
+			State:            q.Get("state"),
+			Error:            q.Get("error"),
+			ErrorDescription: q.Get("error_description"),
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if cookieName == "" {
+			render.JSON(w, http.StatusOK, tokenResponse)
+			return
+		}
+
+		includeTokenCookie(r, w, tokenResponse, cookieName)
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+// newOIDCStateCookie returns the cookie holding the login state during the single sign-on login.
+// Unlike the token cookie it uses lax same-site mode, as it has to be sent along with the redirect
+// from the identity provider.
+func newOIDCStateCookie(r *http.Request) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookieName,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
@@ -37,6 +37,12 @@ func HandleSearchRepo(ctrl *keywordsearch.Controller) http.HandlerFunc {
 			return
 		}
 
+		caseSensitive, err := request.ParseCaseSensitiveFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		repoRef, err := request.GetRepoRefFromPath(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
@@ -44,9 +50,10 @@ func HandleSearchRepo(ctrl *keywordsearch.Controller) http.HandlerFunc {
 		}
 
 		result, err := ctrl.SearchRepo(ctx, session, repoRef, keywordsearch.SearchRepoInput{
-			Query: query,
-			Limit: limit,
-			Regex: regex,
+			Query:         query,
+			Limit:         limit,
+			Regex:         regex,
+			CaseSensitive: caseSensitive,
 		})
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
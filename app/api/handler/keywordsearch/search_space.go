@@ -43,6 +43,12 @@ func HandleSearchSpace(ctrl *keywordsearch.Controller) http.HandlerFunc {
 			return
 		}
 
+		caseSensitive, err := request.ParseCaseSensitiveFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		spaceRef, err := request.GetSpaceRefFromPath(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
@@ -52,11 +58,12 @@ func HandleSearchSpace(ctrl *keywordsearch.Controller) http.HandlerFunc {
 		repoPaths := request.GetRepoPathsFromQuery(r)
 
 		result, err := ctrl.SearchSpace(ctx, session, spaceRef, keywordsearch.SearchSpaceInput{
-			Query:     query,
-			RepoPaths: repoPaths,
-			Limit:     limit,
-			Regex:     regex,
-			Recursive: recursive,
+			Query:         query,
+			RepoPaths:     repoPaths,
+			Limit:         limit,
+			Regex:         regex,
+			Recursive:     recursive,
+			CaseSensitive: caseSensitive,
 		})
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
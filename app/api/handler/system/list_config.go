@@ -32,6 +32,9 @@ type ConfigOutput struct {
 	SSHEnabled                    bool `json:"ssh_enabled"`
 	GitspaceEnabled               bool `json:"gitspace_enabled"`
 	ArtifactRegistryEnabled       bool `json:"artifact_registry_enabled"`
+	OIDCEnabled                   bool `json:"oidc_enabled"`
+	LocalLoginEnabled             bool `json:"local_login_enabled"`
+	LDAPEnabled                   bool `json:"ldap_enabled"`
 	UI                            UI   `json:"ui"`
 }
 
@@ -53,6 +56,9 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
 			GitspaceEnabled:               config.Gitspace.Enable,
 			ArtifactRegistryEnabled:       config.Registry.Enable,
+			OIDCEnabled:                   config.OIDC.Enabled,
+			LocalLoginEnabled:             sysCtrl.IsLocalLoginEnabled(),
+			LDAPEnabled:                   config.LDAP.Enabled,
 			UI:                            UI{ShowPlugin: config.UI.ShowPlugin},
 		})
 	}
@@ -51,6 +51,14 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// request to complete a single sign-on login.
+type oidcCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -84,4 +92,25 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	onOIDCLogin := openapi3.Operation{}
+	onOIDCLogin.WithTags("account")
+	onOIDCLogin.WithMapOfAnything(map[string]any{"operationId": "onOIDCLogin"})
+	_ = reflector.SetRequest(&onOIDCLogin, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/login", onOIDCLogin)
+
+	onOIDCCallback := openapi3.Operation{}
+	onOIDCCallback.WithTags("account")
+	onOIDCCallback.WithMapOfAnything(map[string]any{"operationId": "onOIDCCallback"})
+	_ = reflector.SetRequest(&onOIDCCallback, new(oidcCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/callback", onOIDCCallback)
 }
@@ -52,6 +52,10 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 			return "SpacesettingsGeneralSettings"
 		case reflect.TypeFor[settings.SecuritySettings]():
 			return "ReposettingsSecuritySettings"
+		case reflect.TypeFor[settings.NotificationSettings]():
+			return "ReposettingsNotificationSettings"
+		case reflect.TypeFor[settings.ChatChannel]():
+			return "ReposettingsChatChannel"
 		default:
 			return defaultDefName
 		}
@@ -17,12 +17,14 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -43,6 +45,21 @@ type triggerRequest struct {
 	Identifier string `path:"trigger_identifier"`
 }
 
+type executionArtifactRequest struct {
+	executionRequest
+	ArtifactID int64 `path:"artifact_id"`
+}
+
+type stageApprovalRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+}
+
+type decideStageApprovalRequest struct {
+	stageApprovalRequest
+	execution.StageApprovalDecideInput
+}
+
 type logRequest struct {
 	executionRequest
 	StageNum string `path:"stage_number"`
@@ -144,6 +161,28 @@ var queryParameterBranch = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTestStatus = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTestStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The status of the test cases to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.TestStatus("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 func pipelineOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("pipeline")
@@ -241,6 +280,107 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]any{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(getExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/retry", executionRetry)
+
+	executionTestResults := openapi3.Operation{}
+	executionTestResults.WithTags("pipeline")
+	executionTestResults.WithMapOfAnything(map[string]any{"operationId": "listExecutionTestResults"})
+	executionTestResults.WithParameters(queryParameterTestStatus, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&executionTestResults, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionTestResults, new(types.TestResults), http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionTestResults, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionTestResults, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionTestResults, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionTestResults, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/test-results",
+		executionTestResults)
+
+	executionArtifacts := openapi3.Operation{}
+	executionArtifacts.WithTags("pipeline")
+	executionArtifacts.WithMapOfAnything(map[string]any{"operationId": "listExecutionArtifacts"})
+	executionArtifacts.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&executionArtifacts, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionArtifacts, []types.ExecutionArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionArtifacts, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionArtifacts, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionArtifacts, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionArtifacts, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts",
+		executionArtifacts)
+
+	executionArtifactDownload := openapi3.Operation{}
+	executionArtifactDownload.WithTags("pipeline")
+	executionArtifactDownload.WithMapOfAnything(map[string]any{"operationId": "downloadExecutionArtifact"})
+	_ = reflector.SetRequest(&executionArtifactDownload, new(executionArtifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&executionArtifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&executionArtifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&executionArtifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionArtifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionArtifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionArtifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_id}",
+		executionArtifactDownload)
+
+	stageApprovalFind := openapi3.Operation{}
+	stageApprovalFind.WithTags("pipeline")
+	stageApprovalFind.WithMapOfAnything(map[string]any{"operationId": "findExecutionStageApproval"})
+	_ = reflector.SetRequest(&stageApprovalFind, new(stageApprovalRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&stageApprovalFind, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageApprovalFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageApprovalFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageApprovalFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageApprovalFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/approval",
+		stageApprovalFind)
+
+	stageApprovalApprove := openapi3.Operation{}
+	stageApprovalApprove.WithTags("pipeline")
+	stageApprovalApprove.WithMapOfAnything(map[string]any{"operationId": "approveExecutionStage"})
+	_ = reflector.SetRequest(&stageApprovalApprove, new(decideStageApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stageApprovalApprove, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}"+
+			"/stages/{stage_number}/approval/approve",
+		stageApprovalApprove)
+
+	stageApprovalReject := openapi3.Operation{}
+	stageApprovalReject.WithTags("pipeline")
+	stageApprovalReject.WithMapOfAnything(map[string]any{"operationId": "rejectExecutionStage"})
+	_ = reflector.SetRequest(&stageApprovalReject, new(decideStageApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stageApprovalReject, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}"+
+			"/stages/{stage_number}/approval/reject",
+		stageApprovalReject)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]any{"operationId": "deleteExecution"})
@@ -903,6 +903,73 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/target-branch", opChangeTargetBranch)
 
+	opStackGet := openapi3.Operation{}
+	opStackGet.WithTags("pullreq")
+	opStackGet.WithMapOfAnything(map[string]any{"operationId": "prStackGet"})
+	_ = reflector.SetRequest(&opStackGet, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStackGet, new([]types.PullReqStackEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStackGet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStackGet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStackGet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStackGet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack", opStackGet)
+
+	opStackParentChange := openapi3.Operation{}
+	opStackParentChange.WithTags("pullreq")
+	opStackParentChange.WithMapOfAnything(map[string]any{"operationId": "prStackParentChange"})
+	_ = reflector.SetRequest(&opStackParentChange, struct {
+		pullReqRequest
+		pullreq.StackParentChangeInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStackParentChange, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStackParentChange, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStackParentChange, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStackParentChange, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStackParentChange, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack/parent", opStackParentChange)
+
+	opFindPullReqWatch := openapi3.Operation{}
+	opFindPullReqWatch.WithTags("pullreq")
+	opFindPullReqWatch.WithMapOfAnything(map[string]any{"operationId": "findPullReqWatch"})
+	_ = reflector.SetRequest(&opFindPullReqWatch, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPullReqWatch, new(types.NotificationWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPullReqWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPullReqWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPullReqWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPullReqWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opFindPullReqWatch)
+
+	opUpdatePullReqWatch := openapi3.Operation{}
+	opUpdatePullReqWatch.WithTags("pullreq")
+	opUpdatePullReqWatch.WithMapOfAnything(map[string]any{"operationId": "updatePullReqWatch"})
+	_ = reflector.SetRequest(&opUpdatePullReqWatch, struct {
+		pullReqRequest
+		types.NotificationWatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(types.NotificationWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePullReqWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opUpdatePullReqWatch)
+
+	opDeletePullReqWatch := openapi3.Operation{}
+	opDeletePullReqWatch.WithTags("pullreq")
+	opDeletePullReqWatch.WithMapOfAnything(map[string]any{"operationId": "deletePullReqWatch"})
+	_ = reflector.SetRequest(&opDeletePullReqWatch, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePullReqWatch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePullReqWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePullReqWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePullReqWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePullReqWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opDeletePullReqWatch)
+
 	fileViewAdd := openapi3.Operation{}
 	fileViewAdd.WithTags("pullreq")
 	fileViewAdd.WithMapOfAnything(map[string]any{"operationId": "fileViewAddPullReq"})
@@ -203,6 +203,11 @@ type generalSettingsRequest struct {
 	settings.GeneralSettings
 }
 
+type notificationSettingsRequest struct {
+	repoRequest
+	settings.NotificationSettings
+}
+
 type archiveRequest struct {
 	repoRequest
 	GitRef string `path:"git_ref" required:"true"`
@@ -1355,6 +1360,34 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodGet, "/repos/{repo_ref}/settings/general", opSettingsGeneralFind)
 
+	opSettingsNotificationUpdate := openapi3.Operation{}
+	opSettingsNotificationUpdate.WithTags("repository")
+	opSettingsNotificationUpdate.WithMapOfAnything(
+		map[string]any{"operationId": "updateNotificationSettings"})
+	_ = reflector.SetRequest(&opSettingsNotificationUpdate, new(notificationSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(settings.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/repos/{repo_ref}/settings/notification", opSettingsNotificationUpdate)
+
+	opSettingsNotificationFind := openapi3.Operation{}
+	opSettingsNotificationFind.WithTags("repository")
+	opSettingsNotificationFind.WithMapOfAnything(
+		map[string]any{"operationId": "findNotificationSettings"})
+	_ = reflector.SetRequest(&opSettingsNotificationFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(settings.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/notification", opSettingsNotificationFind)
+
 	opArchive := openapi3.Operation{}
 	opArchive.WithTags("repository")
 	opArchive.WithMapOfAnything(map[string]any{"operationId": "archive"})
@@ -1389,6 +1422,46 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/summary", opSummary)
 
+	opFindRepoWatch := openapi3.Operation{}
+	opFindRepoWatch.WithTags("repository")
+	opFindRepoWatch.WithMapOfAnything(map[string]any{"operationId": "findRepoWatch"})
+	_ = reflector.SetRequest(&opFindRepoWatch, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindRepoWatch, new(types.NotificationWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindRepoWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindRepoWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindRepoWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindRepoWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/watch", opFindRepoWatch)
+
+	opUpdateRepoWatch := openapi3.Operation{}
+	opUpdateRepoWatch.WithTags("repository")
+	opUpdateRepoWatch.WithMapOfAnything(map[string]any{"operationId": "updateRepoWatch"})
+	_ = reflector.SetRequest(&opUpdateRepoWatch, struct {
+		repoRequest
+		types.NotificationWatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(types.NotificationWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateRepoWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/watch", opUpdateRepoWatch)
+
+	opDeleteRepoWatch := openapi3.Operation{}
+	opDeleteRepoWatch.WithTags("repository")
+	opDeleteRepoWatch.WithMapOfAnything(map[string]any{"operationId": "deleteRepoWatch"})
+	_ = reflector.SetRequest(&opDeleteRepoWatch, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteRepoWatch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteRepoWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteRepoWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteRepoWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteRepoWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/watch", opDeleteRepoWatch)
+
 	opListActivities := openapi3.Operation{}
 	opListActivities.WithTags("repository")
 	opListActivities.WithMapOfAnything(map[string]any{"operationId": "listActivities"})
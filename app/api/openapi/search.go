@@ -54,6 +54,21 @@ var QueryParameterRegexSearch = openapi3.ParameterOrRef{
 	},
 }
 
+var QueryParameterCaseSensitiveSearch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCaseSensitive,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether the search should distinguish between upper and lower case."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var QueryParameterRepoPathSearch = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamRepoPath,
@@ -82,7 +97,8 @@ func searchOperations(reflector *openapi3.Reflector) {
 	opSearchRepo.WithSummary("Keyword search in a repository")
 	opSearchRepo.WithMapOfAnything(map[string]any{"operationId": "searchRepo"})
 	opSearchRepo.WithParameters(
-		QueryParameterQuerySearch, QueryParameterLimit, QueryParameterRegexSearch)
+		QueryParameterQuerySearch, QueryParameterLimit, QueryParameterRegexSearch,
+		QueryParameterCaseSensitiveSearch)
 	_ = reflector.SetRequest(&opSearchRepo, new(repoRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opSearchRepo, new(types.SearchResult), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opSearchRepo, new(usererror.Error), http.StatusBadRequest)
@@ -98,7 +114,7 @@ func searchOperations(reflector *openapi3.Reflector) {
 	opSearchSpace.WithMapOfAnything(map[string]any{"operationId": "searchSpace"})
 	opSearchSpace.WithParameters(
 		QueryParameterQuerySearch, QueryParameterLimit, QueryParameterRegexSearch,
-		QueryParameterRepoPathSearch, QueryParameterRecursive)
+		QueryParameterCaseSensitiveSearch, QueryParameterRepoPathSearch, QueryParameterRecursive)
 	_ = reflector.SetRequest(&opSearchSpace, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opSearchSpace, new(types.SearchResult), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opSearchSpace, new(usererror.Error), http.StatusBadRequest)
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/customrole"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -71,6 +72,11 @@ type generalSpaceSettingsRequest struct {
 	settings.GeneralSettingsSpace
 }
 
+type notificationSpaceSettingsRequest struct {
+	spaceRequest
+	settings.NotificationSettings
+}
+
 var queryParameterSortRepo = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -369,6 +375,34 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodGet, "/spaces/{space_ref}/settings/general", opSettingsGeneralFind)
 
+	opSettingsNotificationUpdate := openapi3.Operation{}
+	opSettingsNotificationUpdate.WithTags("space")
+	opSettingsNotificationUpdate.WithMapOfAnything(
+		map[string]any{"operationId": "updateSpaceNotificationSettings"})
+	_ = reflector.SetRequest(&opSettingsNotificationUpdate, new(notificationSpaceSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(settings.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/spaces/{space_ref}/settings/notification", opSettingsNotificationUpdate)
+
+	opSettingsNotificationFind := openapi3.Operation{}
+	opSettingsNotificationFind.WithTags("space")
+	opSettingsNotificationFind.WithMapOfAnything(
+		map[string]any{"operationId": "findSpaceNotificationSettings"})
+	_ = reflector.SetRequest(&opSettingsNotificationFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(settings.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsNotificationFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/settings/notification", opSettingsNotificationFind)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("space")
 	opDelete.WithMapOfAnything(map[string]any{"operationId": "deleteSpace"})
@@ -576,6 +610,82 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
 
+	opCustomRoleCreate := openapi3.Operation{}
+	opCustomRoleCreate.WithTags("space")
+	opCustomRoleCreate.WithMapOfAnything(map[string]any{"operationId": "customRoleCreate"})
+	_ = reflector.SetRequest(&opCustomRoleCreate, &struct {
+		spaceRequest
+		customrole.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(types.CustomRole), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/roles", opCustomRoleCreate)
+
+	opCustomRoleList := openapi3.Operation{}
+	opCustomRoleList.WithTags("space")
+	opCustomRoleList.WithMapOfAnything(map[string]any{"operationId": "customRoleList"})
+	opCustomRoleList.WithParameters(QueryParameterInherited)
+	_ = reflector.SetRequest(&opCustomRoleList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, []types.CustomRole{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/roles", opCustomRoleList)
+
+	opCustomRoleFind := openapi3.Operation{}
+	opCustomRoleFind.WithTags("space")
+	opCustomRoleFind.WithMapOfAnything(map[string]any{"operationId": "customRoleFind"})
+	_ = reflector.SetRequest(&opCustomRoleFind, &struct {
+		spaceRequest
+		Identifier string `path:"role_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(types.CustomRole), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/roles/{role_identifier}", opCustomRoleFind)
+
+	opCustomRoleUpdate := openapi3.Operation{}
+	opCustomRoleUpdate.WithTags("space")
+	opCustomRoleUpdate.WithMapOfAnything(map[string]any{"operationId": "customRoleUpdate"})
+	_ = reflector.SetRequest(&opCustomRoleUpdate, &struct {
+		spaceRequest
+		Identifier string `path:"role_identifier"`
+		customrole.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(types.CustomRole), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/roles/{role_identifier}", opCustomRoleUpdate)
+
+	opCustomRoleDelete := openapi3.Operation{}
+	opCustomRoleDelete.WithTags("space")
+	opCustomRoleDelete.WithMapOfAnything(map[string]any{"operationId": "customRoleDelete"})
+	_ = reflector.SetRequest(&opCustomRoleDelete, &struct {
+		spaceRequest
+		Identifier string `path:"role_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/roles/{role_identifier}", opCustomRoleDelete)
+
 	opDefineLabel := openapi3.Operation{}
 	opDefineLabel.WithTags("space")
 	opDefineLabel.WithMapOfAnything(
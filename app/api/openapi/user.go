@@ -258,6 +258,29 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteToken, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/tokens/{token_identifier}", opDeleteToken)
 
+	opListNotificationPreferences := openapi3.Operation{}
+	opListNotificationPreferences.WithTags("user")
+	opListNotificationPreferences.WithMapOfAnything(map[string]any{"operationId": "listNotificationPreferences"})
+	_ = reflector.SetRequest(&opListNotificationPreferences, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotificationPreferences, new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationPreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListNotificationPreferences, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences", opListNotificationPreferences)
+
+	opUpdateNotificationPreferences := openapi3.Operation{}
+	opUpdateNotificationPreferences.WithTags("user")
+	opUpdateNotificationPreferences.WithMapOfAnything(map[string]any{"operationId": "updateNotificationPreferences"})
+	_ = reflector.SetRequest(&opUpdateNotificationPreferences,
+		new(user.NotificationPreferencesUpdateInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences,
+		new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences,
+		new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/user/notification-preferences", opUpdateNotificationPreferences)
+
 	opCreateFavorite := openapi3.Operation{}
 	opCreateFavorite.WithTags("user")
 	opCreateFavorite.WithMapOfAnything(map[string]any{"operationId": "createFavorite"})
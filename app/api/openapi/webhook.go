@@ -112,6 +112,16 @@ type getRepoWebhookExecutionRequest struct {
 	repoWebhookExecutionRequest
 }
 
+type retriggerSpaceWebhookDeadLettersRequest struct {
+	spaceWebhookRequest
+	types.WebhookExecutionsRetriggerInput
+}
+
+type retriggerRepoWebhookDeadLettersRequest struct {
+	repoWebhookRequest
+	types.WebhookExecutionsRetriggerInput
+}
+
 var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -259,6 +269,36 @@ func webhookOperations(reflector *openapi3.Reflector) {
 		retriggerSpaceWebhookExecution,
 	)
 
+	listSpaceWebhookDeadLetters := openapi3.Operation{}
+	listSpaceWebhookDeadLetters.WithTags("webhook")
+	listSpaceWebhookDeadLetters.WithMapOfAnything(map[string]any{"operationId": "listSpaceWebhookDeadLetters"})
+	listSpaceWebhookDeadLetters.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&listSpaceWebhookDeadLetters, new(listSpaceWebhookExecutionsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookDeadLetters, new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookDeadLetters, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookDeadLetters, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookDeadLetters, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookDeadLetters, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/failed", listSpaceWebhookDeadLetters)
+
+	retriggerSpaceWebhookDeadLetters := openapi3.Operation{}
+	retriggerSpaceWebhookDeadLetters.WithTags("webhook")
+	retriggerSpaceWebhookDeadLetters.WithMapOfAnything(
+		map[string]any{"operationId": "retriggerSpaceWebhookDeadLetters"},
+	)
+	_ = reflector.SetRequest(&retriggerSpaceWebhookDeadLetters,
+		new(retriggerSpaceWebhookDeadLettersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookDeadLetters,
+		new(types.WebhookExecutionsRetriggerOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookDeadLetters, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookDeadLetters, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookDeadLetters, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookDeadLetters, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/failed/retrigger",
+		retriggerSpaceWebhookDeadLetters)
+
 	// repo
 
 	createRepoWebhook := openapi3.Operation{}
@@ -358,4 +398,34 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger",
 		retriggerRepoWebhookExecution)
+
+	listRepoWebhookDeadLetters := openapi3.Operation{}
+	listRepoWebhookDeadLetters.WithTags("webhook")
+	listRepoWebhookDeadLetters.WithMapOfAnything(map[string]any{"operationId": "listRepoWebhookDeadLetters"})
+	listRepoWebhookDeadLetters.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&listRepoWebhookDeadLetters, new(listRepoWebhookExecutionsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listRepoWebhookDeadLetters, new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listRepoWebhookDeadLetters, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listRepoWebhookDeadLetters, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listRepoWebhookDeadLetters, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listRepoWebhookDeadLetters, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/failed", listRepoWebhookDeadLetters)
+
+	retriggerRepoWebhookDeadLetters := openapi3.Operation{}
+	retriggerRepoWebhookDeadLetters.WithTags("webhook")
+	retriggerRepoWebhookDeadLetters.WithMapOfAnything(
+		map[string]any{"operationId": "retriggerRepoWebhookDeadLetters"},
+	)
+	_ = reflector.SetRequest(&retriggerRepoWebhookDeadLetters,
+		new(retriggerRepoWebhookDeadLettersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerRepoWebhookDeadLetters,
+		new(types.WebhookExecutionsRetriggerOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&retriggerRepoWebhookDeadLetters, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerRepoWebhookDeadLetters, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerRepoWebhookDeadLetters, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerRepoWebhookDeadLetters, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/failed/retrigger",
+		retriggerRepoWebhookDeadLetters)
 }
@@ -35,6 +35,8 @@ const (
 	QueryParamValueID   = "value_id"
 	QueryParamRegex     = "regex"
 
+	QueryParamCaseSensitive = "case_sensitive"
+
 	QueryParamState = "state"
 	QueryParamKind  = "kind"
 	QueryParamType  = "type"
@@ -216,6 +218,11 @@ func ParseRegexFromQuery(r *http.Request) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamRegex, false)
 }
 
+// ParseCaseSensitiveFromQuery extracts the case sensitive option from the URL query.
+func ParseCaseSensitiveFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamCaseSensitive, false)
+}
+
 // ParseInheritedFromQuery extracts the inherited option from the URL query.
 func ParseInheritedFromQuery(r *http.Request) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamInherited, false)
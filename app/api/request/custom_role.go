@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const PathParamCustomRoleIdentifier = "role_identifier"
+
+// ParseCustomRoleFilter extracts the custom role filter from the url.
+func ParseCustomRoleFilter(r *http.Request) (*types.CustomRoleFilter, error) {
+	// inherited is used to list custom roles from parent spaces
+	inherited, err := ParseInheritedFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CustomRoleFilter{
+		Inherited: inherited,
+	}, nil
+}
+
+func GetCustomRoleIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCustomRoleIdentifier)
+}
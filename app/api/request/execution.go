@@ -40,3 +40,26 @@ func ParseListExecutionsFilterFromRequest(r *http.Request) (types.ListExecutions
 		Order:              ParseOrder(r),
 	}, nil
 }
+
+const QueryParamTestStatus = "status"
+
+// ParseTestCaseFilter extracts the test case filter from the url.
+func ParseTestCaseFilter(r *http.Request) *types.TestCaseFilter {
+	strStatuses, _ := QueryParamList(r, QueryParamTestStatus)
+	m := make(map[enum.TestStatus]struct{}) // use map to eliminate duplicates
+	for _, s := range strStatuses {
+		if status, ok := enum.TestStatus(s).Sanitize(); ok {
+			m[status] = struct{}{}
+		}
+	}
+
+	statuses := make([]enum.TestStatus, 0, len(m))
+	for s := range m {
+		statuses = append(statuses, s)
+	}
+
+	return &types.TestCaseFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		Status:     statuses,
+	}
+}
@@ -26,6 +26,7 @@ const (
 	PathParamStageNumber        = "stage_number"
 	PathParamStepNumber         = "step_number"
 	PathParamTriggerIdentifier  = "trigger_identifier"
+	PathParamArtifactID         = "artifact_id"
 	QueryParamLatest            = "latest"
 	QueryParamLastExecutions    = "last_executions"
 	QueryParamBranch            = "branch"
@@ -51,6 +52,10 @@ func GetStepNumberFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamStepNumber)
 }
 
+func GetArtifactIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamArtifactID)
+}
+
 func GetLatestFromPath(r *http.Request) bool {
 	l, _ := QueryParamAsBoolOrDefault(r, QueryParamLatest, false)
 	return l
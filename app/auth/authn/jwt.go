@@ -93,6 +93,10 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get principal for token: %w", err)
 		}
+
+		if principal.Blocked {
+			return nil, errors.New("principal is blocked")
+		}
 	}
 
 	// Support for multiple secrets (comma-separated)
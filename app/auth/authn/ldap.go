@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/services/usergroup"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// ldapFailureWindow is for how long rejected credentials and failed attempts of a username are remembered.
+	ldapFailureWindow = time.Minute
+	// ldapMaxFailures is the number of failed attempts of a username after which the directory isn't queried
+	// for the username anymore, until no attempt fails for ldapFailureWindow.
+	ldapMaxFailures = 5
+	// ldapFailureCacheSize is the max number of remembered rejected credentials and usernames.
+	ldapFailureCacheSize = 10000
+)
+
+var _ Authenticator = (*LDAPAuthenticator)(nil)
+
+// LDAPAuthenticator authenticates requests with basic auth credentials against an LDAP directory
+// if they can't be authenticated by the wrapped authenticator, e.g. for git operations using the LDAP password.
+// Failed attempts are throttled, so that basic auth requests can't be used to brute force the passwords
+// of the directory, and requests repeating rejected credentials (e.g. expired tokens) don't reach the directory.
+type LDAPAuthenticator struct {
+	inner    Authenticator
+	syncer   *usergroup.LDAPSyncer
+	failures *ldapFailures
+}
+
+func NewLDAPAuthenticator(inner Authenticator, syncer *usergroup.LDAPSyncer) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		inner:    inner,
+		syncer:   syncer,
+		failures: newLDAPFailures(ldapMaxFailures, ldapFailureWindow),
+	}
+}
+
+func (a *LDAPAuthenticator) Authenticate(r *http.Request) (*auth.Session, error) {
+	session, err := a.inner.Authenticate(r)
+	if err == nil || errors.Is(err, ErrNoAuthData) {
+		return session, err
+	}
+
+	if !strings.HasPrefix(r.Header.Get(request.HeaderAuthorization), "Basic ") {
+		return nil, err
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, err
+	}
+
+	if a.failures.blocked(username, password) {
+		return nil, err
+	}
+
+	user, ldapErr := a.syncer.Authenticate(r.Context(), username, password)
+	if errors.Is(ldapErr, ldap.ErrInvalidCredentials) {
+		a.failures.failed(username, password)
+		// report the error of the wrapped authenticator, the password was most likely a token.
+		return nil, err
+	}
+	if ldapErr != nil {
+		return nil, fmt.Errorf("failed to authenticate with ldap: %w", ldapErr)
+	}
+
+	a.failures.succeeded(username)
+
+	if user.Blocked {
+		return nil, errors.New("user is blocked")
+	}
+
+	return &auth.Session{
+		Principal: *user.ToPrincipal(),
+		Metadata:  &auth.EmptyMetadata{},
+	}, nil
+}
+
+// ldapFailures keeps track of the recently rejected credentials and of the failed attempts per username.
+type ldapFailures struct {
+	maxFailures int
+	credentials *expirable.LRU[[sha256.Size]byte, struct{}]
+
+	mx        sync.Mutex
+	usernames *expirable.LRU[string, int]
+}
+
+func newLDAPFailures(maxFailures int, window time.Duration) *ldapFailures {
+	return &ldapFailures{
+		maxFailures: maxFailures,
+		credentials: expirable.NewLRU[[sha256.Size]byte, struct{}](ldapFailureCacheSize, nil, window),
+		usernames:   expirable.NewLRU[string, int](ldapFailureCacheSize, nil, window),
+	}
+}
+
+// blocked returns true if the credentials have been rejected recently
+// or if there were too many failed attempts for the username.
+func (f *ldapFailures) blocked(username, password string) bool {
+	if _, ok := f.credentials.Get(credentialsKey(username, password)); ok {
+		return true
+	}
+
+	count, _ := f.usernames.Get(strings.ToLower(username))
+
+	return count >= f.maxFailures
+}
+
+func (f *ldapFailures) failed(username, password string) {
+	f.credentials.Add(credentialsKey(username, password), struct{}{})
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	username = strings.ToLower(username)
+	count, _ := f.usernames.Peek(username)
+	f.usernames.Add(username, count+1)
+}
+
+func (f *ldapFailures) succeeded(username string) {
+	f.usernames.Remove(strings.ToLower(username))
+}
+
+// credentialsKey returns a hash of the credentials, so that the passwords aren't kept in memory.
+func credentialsKey(username, password string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(strings.ToLower(username) + "\x00" + password))
+}
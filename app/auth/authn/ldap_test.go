@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLDAPFailures(t *testing.T) {
+	f := newLDAPFailures(3, time.Minute)
+
+	if f.blocked("alice", "secret") {
+		t.Fatal("expected no block without failures")
+	}
+
+	f.failed("alice", "wrong-1")
+
+	if !f.blocked("ALICE", "wrong-1") {
+		t.Error("expected rejected credentials to be blocked")
+	}
+	if f.blocked("alice", "secret") {
+		t.Error("expected other credentials not to be blocked after a single failure")
+	}
+
+	f.failed("alice", "wrong-2")
+	f.failed("Alice", "wrong-3")
+
+	if !f.blocked("alice", "secret") {
+		t.Error("expected the username to be blocked after too many failures")
+	}
+	if f.blocked("bob", "secret") {
+		t.Error("expected other usernames not to be blocked")
+	}
+
+	f.succeeded("alice")
+
+	if f.blocked("alice", "secret") {
+		t.Error("expected the username not to be blocked after a success")
+	}
+}
+
+func TestLDAPFailures_Expire(t *testing.T) {
+	f := newLDAPFailures(1, 10*time.Millisecond)
+
+	f.failed("alice", "wrong")
+	if !f.blocked("alice", "secret") {
+		t.Fatal("expected the username to be blocked")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if f.blocked("alice", "wrong") {
+		t.Error("expected failures to expire")
+	}
+}
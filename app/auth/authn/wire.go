@@ -18,6 +18,7 @@ import (
 	"crypto/rand"
 	"fmt"
 
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -34,6 +35,7 @@ func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	ldapSyncer *usergroup.LDAPSyncer,
 ) Authenticator {
 	if config.Auth.AnonymousUserSecret == "" {
 		var secretBytes [32]byte
@@ -43,5 +45,16 @@ func ProvideAuthenticator(
 		config.Auth.AnonymousUserSecret = string(secretBytes[:])
 		log.Warn().Msg("No anonymous secret provided - generated random secret.")
 	}
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName, config.Auth.AnonymousUserSecret)
+	tokenAuthenticator := NewTokenAuthenticator(
+		principalStore,
+		tokenStore,
+		config.Token.CookieName,
+		config.Auth.AnonymousUserSecret,
+	)
+
+	if ldapSyncer == nil {
+		return tokenAuthenticator
+	}
+
+	return NewLDAPAuthenticator(tokenAuthenticator, ldapSyncer)
 }
@@ -16,12 +16,15 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -35,17 +38,20 @@ type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceFinder     refcache.SpaceFinder
 	publicAccess    publicaccess.Service
+	customRoleStore store.CustomRoleStore
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
 	publicAccess publicaccess.Service,
+	customRoleStore store.CustomRoleStore,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceFinder:     spaceFinder,
 		publicAccess:    publicAccess,
+		customRoleStore: customRoleStore,
 	}
 }
 
@@ -217,7 +223,12 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 		)
 	}
 
-	if !roleHasPermission(membershipMetadata.Role, requestedPermission) {
+	permissions, err := ResolveRolePermissions(ctx, a.spaceFinder, a.customRoleStore, space, membershipMetadata.Role)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve permissions of ephemeral membership role: %w", err)
+	}
+
+	if _, ok := slices.BinarySearch(permissions, requestedPermission); !ok {
 		return false, fmt.Errorf(
 			"requested permission '%s' is outside of ephemeral membership role '%s'",
 			requestedPermission,
@@ -253,3 +264,54 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 
 	return false, fmt.Errorf("no %s permission provided", requestedPermission)
 }
+
+// ResolveRolePermissions returns the sorted list of permissions granted by the membership role in the space.
+// Built-in roles have a fixed set of permissions, custom roles are resolved using FindCustomRole.
+func ResolveRolePermissions(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	customRoleStore store.CustomRoleStore,
+	space *types.SpaceCore,
+	role enum.MembershipRole,
+) ([]enum.Permission, error) {
+	if role.IsBuiltIn() {
+		return role.Permissions(), nil
+	}
+
+	customRole, err := FindCustomRole(ctx, spaceFinder, customRoleStore, space, string(role))
+	if err != nil {
+		return nil, err
+	}
+
+	return customRole.Permissions, nil
+}
+
+// FindCustomRole looks up the custom role in the space first and then in its ancestors,
+// the definition closest to the space wins.
+// Returns store.ErrResourceNotFound in case the custom role isn't defined anywhere in the space tree.
+func FindCustomRole(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	customRoleStore store.CustomRoleStore,
+	space *types.SpaceCore,
+	identifier string,
+) (*types.CustomRole, error) {
+	for {
+		customRole, err := customRoleStore.Find(ctx, space.ID, identifier)
+		if err == nil {
+			return customRole, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find custom role in space %d: %w", space.ID, err)
+		}
+
+		if space.ParentID == 0 {
+			return nil, gitness_store.ErrResourceNotFound
+		}
+
+		space, err = spaceFinder.FindByID(ctx, space.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space: %w", err)
+		}
+	}
+}
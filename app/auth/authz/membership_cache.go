@@ -41,17 +41,20 @@ type PermissionCache cache.Cache[PermissionCacheKey, bool]
 func NewPermissionCache(
 	spaceFinder refcache.SpaceFinder,
 	membershipStore store.MembershipStore,
+	customRoleStore store.CustomRoleStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
 		spaceFinder:     spaceFinder,
 		membershipStore: membershipStore,
+		customRoleStore: customRoleStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
 	spaceFinder     refcache.SpaceFinder
 	membershipStore store.MembershipStore
+	customRoleStore store.CustomRoleStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
@@ -82,9 +85,16 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		if membership != nil {
+			permissions, err := ResolveRolePermissions(ctx, g.spaceFinder, g.customRoleStore, space, membership.Role)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return false, fmt.Errorf("failed to resolve permissions of membership role %q: %w", membership.Role, err)
+			}
+
+			// a custom role that doesn't exist (anymore) doesn't grant any permissions.
+			if _, ok := slices.BinarySearch(permissions, key.Permission); ok {
+				return true, nil
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
@@ -103,11 +113,6 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	return false, nil
 }
 
-func roleHasPermission(role enum.MembershipRole, permission enum.Permission) bool {
-	_, hasRole := slices.BinarySearch(role.Permissions(), permission)
-	return hasRole
-}
-
 // findFirstExistingSpace returns the initial or first existing ancestor space (permissions are inherited).
 func (g permissionCacheGetter) findFirstExistingSpace(ctx context.Context, spaceRef string) (*types.SpaceCore, error) {
 	for {
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type customRoleTest struct {
+	spaceFinder     refcache.SpaceFinder
+	spaceStore      *database.SpaceStore
+	spacePathStore  store.SpacePathStore
+	membershipStore *database.MembershipStore
+	customRoleStore *database.CustomRoleStore
+	userID          int64
+}
+
+func setupCustomRoleTest(t *testing.T) *customRoleTest {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, _ = db.Exec("PRAGMA foreign_keys = ON;")
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %s", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	evictor := cache.NewEvictor[*types.SpaceCore]("namespace", "space-topic", nil)
+	spacePathCache := cache.New(ctx, spacePathStore, store.ToLowerSpacePathTransformation, evictor, time.Minute)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	principalInfoCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+
+	user := &types.User{UID: "jane", Email: "jane@example.com", DisplayName: "Jane"}
+	if err = principalStore.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %s", err)
+	}
+	user, err = principalStore.FindUserByUID(ctx, "jane")
+	if err != nil {
+		t.Fatalf("failed to find user: %s", err)
+	}
+
+	return &customRoleTest{
+		spaceFinder: refcache.NewSpaceFinder(
+			cache.NewSpaceIDCache(ctx, spaceStore, evictor, time.Minute),
+			spacePathCache,
+			cache.NewSpaceCaseInsensitiveCache(ctx, spaceStore, evictor, time.Minute),
+			evictor,
+		),
+		spaceStore:      spaceStore,
+		spacePathStore:  spacePathStore,
+		membershipStore: database.NewMembershipStore(db, principalInfoCache, spacePathStore, spaceStore),
+		customRoleStore: database.NewCustomRoleStore(db),
+		userID:          user.ID,
+	}
+}
+
+func (c *customRoleTest) createSpace(t *testing.T, identifier string, parentID int64) *types.SpaceCore {
+	t.Helper()
+
+	ctx := context.Background()
+
+	space := &types.Space{Identifier: identifier, ParentID: parentID, CreatedBy: c.userID}
+	if err := c.spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %s", err)
+	}
+
+	err := c.spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: identifier, ParentID: parentID, CreatedBy: c.userID, SpaceID: space.ID, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %s", err)
+	}
+
+	return space.Core()
+}
+
+func (c *customRoleTest) createRole(t *testing.T, spaceID int64, identifier string, permissions ...enum.Permission) {
+	t.Helper()
+
+	err := c.customRoleStore.Create(context.Background(), &types.CustomRole{
+		SpaceID:     spaceID,
+		Identifier:  identifier,
+		Permissions: permissions,
+		CreatedBy:   c.userID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create custom role: %s", err)
+	}
+}
+
+func TestResolveRolePermissions(t *testing.T) {
+	c := setupCustomRoleTest(t)
+	ctx := context.Background()
+
+	root := c.createSpace(t, "acme", 0)
+	team := c.createSpace(t, "team", root.ID)
+
+	c.createRole(t, root.ID, "release-manager",
+		enum.PermissionPipelineExecute, enum.PermissionRepoPush, enum.PermissionRepoView)
+	c.createRole(t, team.ID, "auditor", enum.PermissionRepoView)
+
+	tests := []struct {
+		name    string
+		space   *types.SpaceCore
+		role    enum.MembershipRole
+		want    []enum.Permission
+		wantErr error
+	}{
+		{
+			name:  "built-in role",
+			space: team,
+			role:  enum.MembershipRoleReader,
+			want:  enum.MembershipRoleReader.Permissions(),
+		},
+		{
+			name:  "custom role defined in space",
+			space: team,
+			role:  "auditor",
+			want:  []enum.Permission{enum.PermissionRepoView},
+		},
+		{
+			name:  "custom role inherited from parent space",
+			space: team,
+			role:  "Release-Manager",
+			want:  []enum.Permission{enum.PermissionPipelineExecute, enum.PermissionRepoPush, enum.PermissionRepoView},
+		},
+		{
+			name:    "custom role of a subspace isn't visible in parent space",
+			space:   root,
+			role:    "auditor",
+			wantErr: gitness_store.ErrResourceNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := authz.ResolveRolePermissions(ctx, c.spaceFinder, c.customRoleStore, test.space, test.role)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("ResolveRolePermissions() error = %v, want %v", err, test.wantErr)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("ResolveRolePermissions() = %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("ResolveRolePermissions() = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionCache_CustomRole(t *testing.T) {
+	c := setupCustomRoleTest(t)
+	ctx := context.Background()
+
+	root := c.createSpace(t, "acme", 0)
+	team := c.createSpace(t, "team", root.ID)
+	c.createSpace(t, "project", team.ID)
+
+	c.createRole(t, root.ID, "release-manager",
+		enum.PermissionPipelineExecute, enum.PermissionRepoPush, enum.PermissionRepoView)
+
+	err := c.membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: team.ID, PrincipalID: c.userID},
+		CreatedBy:     c.userID,
+		Role:          "release-manager",
+	})
+	if err != nil {
+		t.Fatalf("failed to create membership: %s", err)
+	}
+
+	permissionCache := authz.NewPermissionCache(c.spaceFinder, c.membershipStore, c.customRoleStore, 0)
+
+	tests := []struct {
+		spaceRef   string
+		permission enum.Permission
+		want       bool
+	}{
+		{spaceRef: "acme/team", permission: enum.PermissionRepoPush, want: true},
+		{spaceRef: "acme/team", permission: enum.PermissionPipelineExecute, want: true},
+		{spaceRef: "acme/team", permission: enum.PermissionRepoEdit, want: false},
+		{spaceRef: "acme/team/project", permission: enum.PermissionRepoView, want: true},
+		{spaceRef: "acme", permission: enum.PermissionRepoView, want: false},
+	}
+
+	for _, test := range tests {
+		got, err := permissionCache.Get(ctx, authz.PermissionCacheKey{
+			PrincipalID: c.userID,
+			SpaceRef:    test.spaceRef,
+			Permission:  test.permission,
+		})
+		if err != nil {
+			t.Fatalf("Get(%s, %s) error = %v", test.spaceRef, test.permission, err)
+		}
+		if got != test.want {
+			t.Errorf("Get(%s, %s) = %t, want %t", test.spaceRef, test.permission, got, test.want)
+		}
+	}
+}
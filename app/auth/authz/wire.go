@@ -34,14 +34,16 @@ func ProvideAuthorizer(
 	pCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
 	publicAccess publicaccess.Service,
+	customRoleStore store.CustomRoleStore,
 ) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceFinder, publicAccess)
+	return NewMembershipAuthorizer(pCache, spaceFinder, publicAccess, customRoleStore)
 }
 
 func ProvidePermissionCache(
 	spaceFinder refcache.SpaceFinder,
 	membershipStore store.MembershipStore,
+	customRoleStore store.CustomRoleStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceFinder, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceFinder, membershipStore, customRoleStore, permissionCacheTimeout)
 }
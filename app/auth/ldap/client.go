@@ -0,0 +1,333 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// ProviderName is the provider of the external identities of users authenticated via LDAP.
+const ProviderName = "ldap"
+
+const (
+	timeout    = 30 * time.Second
+	pagingSize = 500
+)
+
+var (
+	// ErrInvalidCredentials is returned if the user doesn't exist or the password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Config contains the settings of an LDAP server.
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+
+	BindDN       string
+	BindPassword string
+
+	UserBaseDN           string
+	UserFilter           string
+	UsernameAttribute    string
+	EmailAttribute       string
+	DisplayNameAttribute string
+
+	GroupBaseDN          string
+	GroupFilter          string
+	GroupNameAttribute   string
+	GroupMemberAttribute string
+}
+
+// User is a user entry of the directory.
+type User struct {
+	DN          string
+	Username    string
+	Email       string
+	DisplayName string
+}
+
+// Group is a group entry of the directory.
+type Group struct {
+	DN   string
+	Name string
+	// Members contains the values of the member attribute, usually the DNs of the members.
+	Members []string
+}
+
+// HasMember returns true if the user is a member of the group,
+// either referenced by its DN (e.g. groupOfNames) or its username (e.g. posixGroup).
+func (g *Group) HasMember(user *User) bool {
+	userDN := NormalizeDN(user.DN)
+	for _, member := range g.Members {
+		if NormalizeDN(member) == userDN || strings.EqualFold(member, user.Username) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Client authenticates users against an LDAP server using bind operations and reads its users and groups.
+// Every operation uses a new connection bound with the service account.
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) (*Client, error) {
+	if config.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if config.UserBaseDN == "" {
+		return nil, errors.New("user base DN is required")
+	}
+	if strings.Count(config.UserFilter, "%s") != 1 {
+		return nil, errors.New("user filter must contain exactly one %s placeholder")
+	}
+	if config.UsernameAttribute == "" {
+		return nil, errors.New("username attribute is required")
+	}
+	if config.GroupBaseDN == "" {
+		config.GroupBaseDN = config.UserBaseDN
+	}
+
+	return &Client{config: config}, nil
+}
+
+// Authenticate finds the user with the username and verifies the password by binding as the user.
+func (c *Client) Authenticate(username, password string) (*User, error) {
+	// an empty password would result in an unauthenticated bind, which succeeds on most servers.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf(c.config.UserFilter, goldap.EscapeFilter(username))
+
+	users, err := c.searchUsers(conn, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	user := users[0]
+
+	err = conn.Bind(user.DN, password)
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListUsers returns all users of the directory that match the user filter.
+func (c *Client) ListUsers() ([]*User, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// the user filter is used with a wildcard instead of a specific username.
+	return c.searchUsers(conn, fmt.Sprintf(c.config.UserFilter, "*"))
+}
+
+// ListGroups returns all groups of the directory that match the group filter.
+func (c *Client) ListGroups() ([]*Group, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return c.searchGroups(conn, c.config.GroupFilter)
+}
+
+// ListUserGroups returns the names of all groups the user is a member of.
+// The membership is resolved by the server, the group member attribute is matched
+// against either the DN (e.g. groupOfNames) or the username (e.g. posixGroup) of the user.
+func (c *Client) ListUserGroups(user *User) ([]string, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	memberAttribute := goldap.EscapeFilter(c.config.GroupMemberAttribute)
+	filter := fmt.Sprintf("(&%s(|(%s=%s)(%s=%s)))",
+		wrapFilter(c.config.GroupFilter),
+		memberAttribute, goldap.EscapeFilter(user.DN),
+		memberAttribute, goldap.EscapeFilter(user.Username),
+	)
+
+	groups, err := c.searchGroups(conn, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.Name
+	}
+
+	return names, nil
+}
+
+func (c *Client) searchGroups(conn *goldap.Conn, filter string) ([]*Group, error) {
+	result, err := conn.SearchWithPaging(goldap.NewSearchRequest(
+		c.config.GroupBaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{c.config.GroupNameAttribute, c.config.GroupMemberAttribute},
+		nil,
+	), pagingSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+
+	groups := make([]*Group, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		name := entry.GetAttributeValue(c.config.GroupNameAttribute)
+		if name == "" {
+			continue
+		}
+
+		groups = append(groups, &Group{
+			DN:      entry.DN,
+			Name:    name,
+			Members: entry.GetAttributeValues(c.config.GroupMemberAttribute),
+		})
+	}
+
+	return groups, nil
+}
+
+// wrapFilter returns the filter enclosed in parentheses, so that it can be combined with other filters.
+func wrapFilter(filter string) string {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return "(objectClass=*)"
+	}
+	if !strings.HasPrefix(filter, "(") {
+		return "(" + filter + ")"
+	}
+
+	return filter
+}
+
+func (c *Client) searchUsers(conn *goldap.Conn, filter string) ([]*User, error) {
+	result, err := conn.SearchWithPaging(goldap.NewSearchRequest(
+		c.config.UserBaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{c.config.UsernameAttribute, c.config.EmailAttribute, c.config.DisplayNameAttribute},
+		nil,
+	), pagingSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	users := make([]*User, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		username := entry.GetAttributeValue(c.config.UsernameAttribute)
+		if username == "" {
+			continue
+		}
+
+		users = append(users, &User{
+			DN:          entry.DN,
+			Username:    username,
+			Email:       entry.GetAttributeValue(c.config.EmailAttribute),
+			DisplayName: entry.GetAttributeValue(c.config.DisplayNameAttribute),
+		})
+	}
+
+	return users, nil
+}
+
+// connect opens a new connection to the server and binds with the service account.
+func (c *Client) connect() (*goldap.Conn, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.config.InsecureSkipVerify, //nolint:gosec // explicitly configured
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := goldap.DialURL(c.config.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+
+	conn.SetTimeout(timeout)
+
+	if c.config.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if c.config.BindDN != "" {
+		err = conn.Bind(c.config.BindDN, c.config.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to bind with service account: %w", err)
+	}
+
+	return conn, nil
+}
+
+// NormalizeDN returns the DN in a form that can be used for comparison.
+func NormalizeDN(dn string) string {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+
+	rdns := make([]string, len(parsed.RDNs))
+	for i, rdn := range parsed.RDNs {
+		attributes := make([]string, len(rdn.Attributes))
+		for j, attribute := range rdn.Attributes {
+			attributes[j] = strings.ToLower(attribute.Type) + "=" + strings.ToLower(attribute.Value)
+		}
+		rdns[i] = strings.Join(attributes, "+")
+	}
+
+	return strings.Join(rdns, ",")
+}
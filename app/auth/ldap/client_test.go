@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/ldap/ldaptest"
+)
+
+const (
+	testBaseDN       = "dc=example,dc=com"
+	testBindDN       = "cn=gitness,ou=services,dc=example,dc=com"
+	testBindPassword = "service-secret"
+)
+
+func newTestDirectory(t *testing.T) *ldaptest.Server {
+	t.Helper()
+
+	server, err := ldaptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start ldap server: %s", err)
+	}
+	t.Cleanup(server.Close)
+
+	server.Add(testBindDN, map[string][]string{
+		"objectClass":              {"applicationProcess"},
+		"cn":                       {"gitness"},
+		ldaptest.PasswordAttribute: {testBindPassword},
+	})
+	server.Add("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":              {"person"},
+		"uid":                      {"alice"},
+		"cn":                       {"Alice Smith"},
+		"mail":                     {"alice@example.com"},
+		ldaptest.PasswordAttribute: {"alice-secret"},
+	})
+	server.Add("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":              {"person"},
+		"uid":                      {"bob"},
+		"cn":                       {"Bob Jones"},
+		"mail":                     {"bob@example.com"},
+		ldaptest.PasswordAttribute: {"bob-secret"},
+	})
+	server.Add("cn=developers,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"developers"},
+		"member":      {"UID=Alice,OU=People,DC=example,DC=com", "uid=bob,ou=people,dc=example,dc=com"},
+	})
+	server.Add("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"admins"},
+		"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+	})
+
+	return server
+}
+
+func newTestClient(t *testing.T, server *ldaptest.Server) *ldap.Client {
+	t.Helper()
+
+	client, err := ldap.NewClient(ldap.Config{
+		URL:                  server.URL(),
+		BindDN:               testBindDN,
+		BindPassword:         testBindPassword,
+		UserBaseDN:           "ou=people," + testBaseDN,
+		UserFilter:           "(&(objectClass=person)(uid=%s))",
+		UsernameAttribute:    "uid",
+		EmailAttribute:       "mail",
+		DisplayNameAttribute: "cn",
+		GroupBaseDN:          "ou=groups," + testBaseDN,
+		GroupFilter:          "(objectClass=groupOfNames)",
+		GroupNameAttribute:   "cn",
+		GroupMemberAttribute: "member",
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	return client
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	client := newTestClient(t, newTestDirectory(t))
+
+	user, err := client.Authenticate("alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &ldap.User{
+		DN:          "uid=alice,ou=people,dc=example,dc=com",
+		Username:    "alice",
+		Email:       "alice@example.com",
+		DisplayName: "Alice Smith",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("got user %+v, want %+v", user, want)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{name: "wrong password", username: "alice", password: "bob-secret"},
+		{name: "empty password", username: "alice", password: ""},
+		{name: "unknown user", username: "carol", password: "alice-secret"},
+		{name: "wildcard username", username: "*", password: "alice-secret"},
+		{name: "filter injection", username: "alice)(uid=*", password: "alice-secret"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.Authenticate(test.username, test.password)
+			if !errors.Is(err, ldap.ErrInvalidCredentials) {
+				t.Errorf("expected invalid credentials error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_ServiceAccountBindFailure(t *testing.T) {
+	server := newTestDirectory(t)
+
+	client, err := ldap.NewClient(ldap.Config{
+		URL:               server.URL(),
+		BindDN:            testBindDN,
+		BindPassword:      "wrong",
+		UserBaseDN:        testBaseDN,
+		UserFilter:        "(uid=%s)",
+		UsernameAttribute: "uid",
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	_, err = client.Authenticate("alice", "alice-secret")
+	if err == nil || errors.Is(err, ldap.ErrInvalidCredentials) {
+		t.Errorf("expected service account bind error, got: %v", err)
+	}
+}
+
+func TestClient_ListUsersAndGroups(t *testing.T) {
+	server := newTestDirectory(t)
+	client := newTestClient(t, server)
+
+	users, err := client.ListUsers()
+	if err != nil {
+		t.Fatalf("failed to list users: %s", err)
+	}
+
+	usernames := make([]string, len(users))
+	for i, user := range users {
+		usernames[i] = user.Username
+	}
+	sort.Strings(usernames)
+
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(usernames, want) {
+		t.Errorf("got users %v, want %v", usernames, want)
+	}
+
+	alice := &ldap.User{DN: "uid=alice,ou=people,dc=example,dc=com", Username: "alice"}
+	bob := &ldap.User{DN: "uid=bob,ou=people,dc=example,dc=com", Username: "bob"}
+
+	groups, err := client.ListUserGroups(alice)
+	if err != nil {
+		t.Fatalf("failed to list groups: %s", err)
+	}
+	sort.Strings(groups)
+	if want := []string{"admins", "developers"}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("got groups %v, want %v", groups, want)
+	}
+
+	// the user DN and username are escaped in the group search filter.
+	groups, err = client.ListUserGroups(&ldap.User{DN: "*", Username: "*)(cn=*"})
+	if err != nil {
+		t.Fatalf("failed to list groups: %s", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("expected no groups for a user with filter characters, got %v", groups)
+	}
+
+	server.Remove("uid=bob,ou=people,dc=example,dc=com")
+	server.Add("cn=developers,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"developers"},
+		"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+	})
+
+	groups, err = client.ListUserGroups(bob)
+	if err != nil {
+		t.Fatalf("failed to list groups: %s", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("expected no groups after removal, got %v", groups)
+	}
+
+	users, err = client.ListUsers()
+	if err != nil {
+		t.Fatalf("failed to list users: %s", err)
+	}
+	if len(users) != 1 || users[0].Username != "alice" {
+		t.Errorf("expected only alice after removal, got %+v", users)
+	}
+}
+
+func TestGroup_HasMember(t *testing.T) {
+	group := &ldap.Group{Members: []string{"uid=alice,ou=people,dc=example,dc=com", "bob"}}
+
+	if !group.HasMember(&ldap.User{DN: "UID=alice, OU=People,DC=Example,DC=com", Username: "alice"}) {
+		t.Error("expected member referenced by DN")
+	}
+	if !group.HasMember(&ldap.User{DN: "uid=bob,ou=people,dc=example,dc=com", Username: "Bob"}) {
+		t.Error("expected member referenced by username")
+	}
+	if group.HasMember(&ldap.User{DN: "uid=carol,ou=people,dc=example,dc=com", Username: "carol"}) {
+		t.Error("unexpected member")
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ldaptest provides an in-process LDAP server for tests.
+// It supports simple bind and search operations on an in-memory directory.
+package ldaptest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/harness/gitness/app/auth/ldap"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// PasswordAttribute is the attribute of an entry that contains the password used for binding.
+const PasswordAttribute = "userPassword"
+
+type entry struct {
+	dn         string
+	attributes map[string][]string
+}
+
+// Server is an LDAP server serving an in-memory directory on a local port.
+type Server struct {
+	listener net.Listener
+	closed   chan struct{}
+
+	mx      sync.RWMutex
+	entries map[string]entry
+
+	wg sync.WaitGroup
+}
+
+// NewServer starts a new LDAP server with an empty directory.
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s := &Server{
+		listener: listener,
+		closed:   make(chan struct{}),
+		entries:  map[string]entry{},
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// URL returns the URL of the server.
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Close stops the server and waits for all connections to be closed.
+func (s *Server) Close() {
+	close(s.closed)
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+// Add adds the entry to the directory, replacing an existing entry with the same DN.
+func (s *Server) Add(dn string, attributes map[string][]string) {
+	normalized := make(map[string][]string, len(attributes))
+	for name, values := range attributes {
+		normalized[strings.ToLower(name)] = values
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.entries[ldap.NormalizeDN(dn)] = entry{dn: dn, attributes: normalized}
+}
+
+// Remove removes the entry from the directory.
+func (s *Server) Remove(dn string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.entries, ldap.NormalizeDN(dn))
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	// open connections are closed on Close to unblock the read.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-done:
+		case <-s.closed:
+			_ = conn.Close()
+		}
+	}()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		if len(packet.Children) < 2 {
+			return
+		}
+
+		messageID, ok := packet.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+
+		op := packet.Children[1]
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			code, msg := s.bind(op)
+			err = write(conn, messageID, result(goldap.ApplicationBindResponse, code, msg))
+		case goldap.ApplicationSearchRequest:
+			err = s.search(conn, messageID, op)
+		case goldap.ApplicationUnbindRequest:
+			return
+		default:
+			err = write(conn, messageID, result(goldap.ApplicationExtendedResponse,
+				goldap.LDAPResultUnwillingToPerform, "operation not supported"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) bind(op *ber.Packet) (uint16, string) {
+	if len(op.Children) < 3 {
+		return goldap.LDAPResultProtocolError, "invalid bind request"
+	}
+
+	dn := value(op.Children[1])
+	auth := op.Children[2]
+	if auth.ClassType != ber.ClassContext || auth.Tag != 0 {
+		return goldap.LDAPResultAuthMethodNotSupported, "only simple bind is supported"
+	}
+
+	password := value(auth)
+
+	// anonymous bind
+	if dn == "" && password == "" {
+		return goldap.LDAPResultSuccess, ""
+	}
+
+	s.mx.RLock()
+	e, ok := s.entries[ldap.NormalizeDN(dn)]
+	s.mx.RUnlock()
+
+	if !ok || password == "" {
+		return goldap.LDAPResultInvalidCredentials, "invalid credentials"
+	}
+
+	for _, p := range e.attributes[strings.ToLower(PasswordAttribute)] {
+		if p == password {
+			return goldap.LDAPResultSuccess, ""
+		}
+	}
+
+	return goldap.LDAPResultInvalidCredentials, "invalid credentials"
+}
+
+func (s *Server) search(conn net.Conn, messageID int64, op *ber.Packet) error {
+	if len(op.Children) < 8 {
+		return write(conn, messageID, result(goldap.ApplicationSearchResultDone,
+			goldap.LDAPResultProtocolError, "invalid search request"))
+	}
+
+	baseDN := ldap.NormalizeDN(value(op.Children[0]))
+	scope, _ := op.Children[1].Value.(int64)
+	filter := op.Children[6]
+
+	var attributes []string
+	for _, attribute := range op.Children[7].Children {
+		attributes = append(attributes, strings.ToLower(value(attribute)))
+	}
+
+	s.mx.RLock()
+	var matches []entry
+	for dn, e := range s.entries {
+		if !inScope(dn, baseDN, scope) {
+			continue
+		}
+
+		ok, err := matchFilter(e, filter)
+		if err != nil {
+			s.mx.RUnlock()
+			return write(conn, messageID, result(goldap.ApplicationSearchResultDone,
+				goldap.LDAPResultProtocolError, err.Error()))
+		}
+		if ok {
+			matches = append(matches, e)
+		}
+	}
+	s.mx.RUnlock()
+
+	for _, e := range matches {
+		if err := write(conn, messageID, searchEntry(e, attributes)); err != nil {
+			return err
+		}
+	}
+
+	return write(conn, messageID, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess, ""))
+}
+
+func inScope(dn, baseDN string, scope int64) bool {
+	switch scope {
+	case goldap.ScopeBaseObject:
+		return dn == baseDN
+	case goldap.ScopeSingleLevel:
+		_, parent, ok := strings.Cut(dn, ",")
+		return ok && parent == baseDN
+	default:
+		return baseDN == "" || dn == baseDN || strings.HasSuffix(dn, ","+baseDN)
+	}
+}
+
+func matchFilter(e entry, filter *ber.Packet) (bool, error) {
+	if filter.ClassType != ber.ClassContext {
+		return false, errors.New("invalid filter")
+	}
+
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			ok, err := matchFilter(e, child)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			ok, err := matchFilter(e, child)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+
+	case goldap.FilterNot:
+		if len(filter.Children) != 1 {
+			return false, errors.New("invalid not filter")
+		}
+		ok, err := matchFilter(e, filter.Children[0])
+		return !ok, err
+
+	case goldap.FilterPresent:
+		return len(e.attributes[strings.ToLower(value(filter))]) > 0, nil
+
+	case goldap.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false, errors.New("invalid equality filter")
+		}
+		expected := value(filter.Children[1])
+		for _, v := range e.attributes[strings.ToLower(value(filter.Children[0]))] {
+			if strings.EqualFold(v, expected) {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case goldap.FilterSubstrings:
+		if len(filter.Children) != 2 {
+			return false, errors.New("invalid substrings filter")
+		}
+		for _, v := range e.attributes[strings.ToLower(value(filter.Children[0]))] {
+			if matchSubstrings(strings.ToLower(v), filter.Children[1].Children) {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("filter %q is not supported", goldap.FilterMap[uint64(filter.Tag)])
+	}
+}
+
+func matchSubstrings(v string, parts []*ber.Packet) bool {
+	for _, part := range parts {
+		s := strings.ToLower(value(part))
+		switch part.Tag {
+		case goldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case goldap.FilterSubstringsAny:
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		case goldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+			v = ""
+		}
+	}
+
+	return true
+}
+
+func searchEntry(e entry, attributes []string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+
+	list := ber.NewSequence("")
+	for name, values := range e.attributes {
+		if name == strings.ToLower(PasswordAttribute) || !requested(name, attributes) {
+			continue
+		}
+
+		attribute := ber.NewSequence("")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+		}
+		attribute.AppendChild(set)
+		list.AppendChild(attribute)
+	}
+	op.AppendChild(list)
+
+	return op
+}
+
+func requested(name string, attributes []string) bool {
+	if len(attributes) == 0 {
+		return true
+	}
+
+	for _, attribute := range attributes {
+		if attribute == "*" || attribute == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func result(application ber.Tag, code uint16, msg string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, application, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, msg, ""))
+	return op
+}
+
+func write(conn net.Conn, messageID int64, op *ber.Packet) error {
+	packet := ber.NewSequence("")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, ""))
+	packet.AppendChild(op)
+
+	_, err := conn.Write(packet.Bytes())
+	return err
+}
+
+func value(p *ber.Packet) string {
+	return string(p.Data.Bytes())
+}
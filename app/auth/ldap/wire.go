@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideClient,
+)
+
+// ProvideClient provides the LDAP client, or nil if LDAP authentication isn't enabled.
+func ProvideClient(config *types.Config) (*Client, error) {
+	if !config.LDAP.Enabled {
+		return nil, nil //nolint:nilnil // nil client means LDAP authentication is disabled
+	}
+
+	client, err := NewClient(Config{
+		URL:                  config.LDAP.URL,
+		StartTLS:             config.LDAP.StartTLS,
+		InsecureSkipVerify:   config.LDAP.InsecureSkipVerify,
+		BindDN:               config.LDAP.BindDN,
+		BindPassword:         config.LDAP.BindPassword,
+		UserBaseDN:           config.LDAP.UserBaseDN,
+		UserFilter:           config.LDAP.UserFilter,
+		UsernameAttribute:    config.LDAP.UsernameAttribute,
+		EmailAttribute:       config.LDAP.EmailAttribute,
+		DisplayNameAttribute: config.LDAP.DisplayNameAttribute,
+		GroupBaseDN:          config.LDAP.GroupBaseDN,
+		GroupFilter:          config.LDAP.GroupFilter,
+		GroupNameAttribute:   config.LDAP.GroupNameAttribute,
+		GroupMemberAttribute: config.LDAP.GroupMemberAttribute,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap configuration: %w", err)
+	}
+
+	return client, nil
+}
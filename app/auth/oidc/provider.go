@@ -0,0 +1,360 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	httpTimeout = 30 * time.Second
+)
+
+var (
+	// ErrInvalidIDToken is returned if the ID token returned by the provider fails verification.
+	ErrInvalidIDToken = errors.New("invalid id token")
+
+	// signatureAlgorithms are the accepted ID token signature algorithms (symmetric algorithms are not supported).
+	signatureAlgorithms = []jose.SignatureAlgorithm{
+		jose.RS256, jose.RS384, jose.RS512,
+		jose.PS256, jose.PS384, jose.PS512,
+		jose.ES256, jose.ES384, jose.ES512,
+		jose.EdDSA,
+	}
+)
+
+// Config contains the settings of an OpenID Connect provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	GroupsClaim  string
+
+	// GroupMapping maps groups of the ID token to user group references ("<space path>/<identifier>").
+	GroupMapping map[string]string
+}
+
+// Claims are the verified claims of an ID token.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Groups            []string
+}
+
+// Provider implements the authorization code flow with PKCE against an OpenID Connect provider.
+// The provider metadata and signing keys are discovered lazily and cached.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata
+	keySet   *jose.JSONWebKeySet
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func NewProvider(config Config) (*Provider, error) {
+	config.Issuer = strings.TrimRight(config.Issuer, "/")
+
+	if config.Issuer == "" {
+		return nil, errors.New("issuer is required")
+	}
+	if config.ClientID == "" {
+		return nil, errors.New("client ID is required")
+	}
+	if config.RedirectURL == "" {
+		return nil, errors.New("redirect URL is required")
+	}
+
+	for group, userGroupRef := range config.GroupMapping {
+		if !strings.Contains(strings.Trim(userGroupRef, "/"), "/") {
+			return nil, fmt.Errorf(
+				"group %q is mapped to %q, expected a usergroup reference in the form '<space path>/<identifier>'",
+				group, userGroupRef)
+		}
+	}
+
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// Issuer returns the issuer URL of the provider.
+func (p *Provider) Issuer() string {
+	return p.config.Issuer
+}
+
+// GroupMapping returns the mapping of ID token groups to user group references.
+func (p *Provider) GroupMapping() map[string]string {
+	return p.config.GroupMapping
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint the user has to be redirected to.
+func (p *Provider) AuthCodeURL(ctx context.Context, state *LoginState) (string, error) {
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return p.oauth2Config(metadata).AuthCodeURL(state.State,
+		oauth2.S256ChallengeOption(state.Verifier),
+		oauth2.SetAuthURLParam("nonce", state.Nonce),
+	), nil
+}
+
+// Exchange exchanges the authorization code for tokens and returns the claims of the verified ID token.
+func (p *Provider) Exchange(ctx context.Context, code string, state *LoginState) (*Claims, error) {
+	metadata, err := p.getMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.oauth2Config(metadata).Exchange(
+		context.WithValue(ctx, oauth2.HTTPClient, p.client),
+		code,
+		oauth2.VerifierOption(state.Verifier),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response doesn't contain an id token", ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, metadata, rawIDToken, state.Nonce)
+}
+
+func (p *Provider) oauth2Config(metadata *providerMetadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      p.config.Scopes,
+	}
+}
+
+func (p *Provider) verify(
+	ctx context.Context,
+	metadata *providerMetadata,
+	rawIDToken string,
+	nonce string,
+) (*Claims, error) {
+	idToken, err := jwt.ParseSigned(rawIDToken, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if len(idToken.Headers) != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one signature", ErrInvalidIDToken)
+	}
+
+	keys, err := p.getKeys(ctx, metadata, idToken.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		registered jwt.Claims
+		raw        map[string]any
+	)
+
+	verified := false
+	for _, key := range keys {
+		if err = idToken.Claims(key.Key, &registered, &raw); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrInvalidIDToken)
+	}
+
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidIDToken)
+	}
+
+	err = registered.ValidateWithLeeway(jwt.Expected{
+		Issuer:      p.config.Issuer,
+		AnyAudience: jwt.Audience{p.config.ClientID},
+		Time:        time.Now(),
+	}, jwt.DefaultLeeway)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if registered.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	if tokenNonce, _ := raw["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	claims := &Claims{
+		Subject: registered.Subject,
+		Groups:  stringsClaim(raw[p.config.GroupsClaim]),
+	}
+	claims.Email, _ = raw["email"].(string)
+	claims.Name, _ = raw["name"].(string)
+	claims.PreferredUsername, _ = raw["preferred_username"].(string)
+
+	// some providers return the email_verified claim as a string
+	switch v := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified = v == "true"
+	}
+
+	return claims, nil
+}
+
+// stringsClaim converts a claim that is either a string or a list of strings to a list of strings.
+func stringsClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func (p *Provider) getMetadata(ctx context.Context) (*providerMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	metadata := &providerMetadata{}
+	if err := p.getJSON(ctx, p.config.Issuer+discoveryPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover provider metadata: %w", err)
+	}
+
+	if strings.TrimRight(metadata.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("issuer %q of provider metadata doesn't match the configured issuer %q",
+			metadata.Issuer, p.config.Issuer)
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("provider metadata is missing required endpoints")
+	}
+
+	p.metadata = metadata
+
+	return metadata, nil
+}
+
+// getKeys returns the signing keys of the provider for the key ID.
+// The key set is fetched again if no key with the key ID is known, as the provider might have rotated its keys.
+func (p *Provider) getKeys(
+	ctx context.Context,
+	metadata *providerMetadata,
+	keyID string,
+) ([]jose.JSONWebKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keySet != nil {
+		if keys := findKeys(p.keySet, keyID); len(keys) > 0 {
+			return keys, nil
+		}
+	}
+
+	keySet := &jose.JSONWebKeySet{}
+	if err := p.getJSON(ctx, metadata.JWKSURI, keySet); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	p.keySet = keySet
+
+	keys := findKeys(keySet, keyID)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no key found for key ID %q", ErrInvalidIDToken, keyID)
+	}
+
+	return keys, nil
+}
+
+func findKeys(keySet *jose.JSONWebKeySet, keyID string) []jose.JSONWebKey {
+	if keyID != "" {
+		return keySet.Key(keyID)
+	}
+
+	return keySet.Keys
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth/oidc/oidctest"
+)
+
+const (
+	testClientID     = "gitness"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://gitness.test/api/v1/oidc/callback"
+)
+
+func newTestProvider(t *testing.T, issuer *oidctest.Issuer) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(Config{
+		Issuer:       issuer.URL(),
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "profile", "email"},
+		GroupsClaim:  "groups",
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %s", err)
+	}
+
+	return provider
+}
+
+// authorize follows the authorization URL and returns the code and the state the issuer redirected back with.
+func authorize(t *testing.T, provider *Provider, state *LoginState) (string, string) {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), state)
+	if err != nil {
+		t.Fatalf("failed to get auth code URL: %s", err)
+	}
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL) //nolint:noctx
+	if err != nil {
+		t.Fatalf("authorization request failed: %s", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected redirect from authorization endpoint, got status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect location: %s", err)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	issuer := oidctest.NewIssuer(testClientID, testClientSecret)
+	defer issuer.Close()
+
+	issuer.SetUser(map[string]any{
+		"sub":                "1234",
+		"email":              "jane@example.com",
+		"email_verified":     true,
+		"name":               "Jane Doe",
+		"preferred_username": "jane",
+		"groups":             []string{"developers", "admins"},
+	})
+
+	provider := newTestProvider(t, issuer)
+	state := NewLoginState()
+
+	code, returnedState := authorize(t, provider, state)
+	if returnedState != state.State {
+		t.Fatalf("expected state %q, got %q", state.State, returnedState)
+	}
+
+	claims, err := provider.Exchange(context.Background(), code, state)
+	if err != nil {
+		t.Fatalf("failed to exchange code: %s", err)
+	}
+
+	want := &Claims{
+		Subject:           "1234",
+		Email:             "jane@example.com",
+		EmailVerified:     true,
+		Name:              "Jane Doe",
+		PreferredUsername: "jane",
+		Groups:            []string{"developers", "admins"},
+	}
+	if !reflect.DeepEqual(claims, want) {
+		t.Errorf("expected claims %+v, got %+v", want, claims)
+	}
+}
+
+func TestProvider_Exchange_WrongVerifier(t *testing.T) {
+	issuer := oidctest.NewIssuer(testClientID, testClientSecret)
+	defer issuer.Close()
+
+	provider := newTestProvider(t, issuer)
+	state := NewLoginState()
+
+	code, _ := authorize(t, provider, state)
+
+	state.Verifier = NewLoginState().Verifier
+
+	if _, err := provider.Exchange(context.Background(), code, state); err == nil {
+		t.Error("expected exchange with wrong PKCE verifier to fail")
+	}
+}
+
+func TestProvider_Exchange_WrongNonce(t *testing.T) {
+	issuer := oidctest.NewIssuer(testClientID, testClientSecret)
+	defer issuer.Close()
+
+	provider := newTestProvider(t, issuer)
+	state := NewLoginState()
+
+	code, _ := authorize(t, provider, state)
+
+	state.Nonce = NewLoginState().Nonce
+
+	_, err := provider.Exchange(context.Background(), code, state)
+	if !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("expected ErrInvalidIDToken, got %v", err)
+	}
+}
+
+func TestProvider_Verify(t *testing.T) {
+	issuer := oidctest.NewIssuer(testClientID, testClientSecret)
+	defer issuer.Close()
+
+	otherIssuer := oidctest.NewIssuer(testClientID, testClientSecret)
+	defer otherIssuer.Close()
+
+	provider := newTestProvider(t, issuer)
+
+	metadata, err := provider.getMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get metadata: %s", err)
+	}
+
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":   issuer.URL(),
+			"aud":   testClientID,
+			"sub":   "1234",
+			"exp":   4102444800, // 2100-01-01
+			"nonce": "nonce",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(claims map[string]any)
+		signer *oidctest.Issuer
+	}{
+		{
+			name:   "wrong issuer",
+			modify: func(claims map[string]any) { claims["iss"] = otherIssuer.URL() },
+		},
+		{
+			name:   "wrong audience",
+			modify: func(claims map[string]any) { claims["aud"] = "other" },
+		},
+		{
+			name:   "expired",
+			modify: func(claims map[string]any) { claims["exp"] = 946684800 }, // 2000-01-01
+		},
+		{
+			name:   "missing expiry",
+			modify: func(claims map[string]any) { delete(claims, "exp") },
+		},
+		{
+			name:   "missing subject",
+			modify: func(claims map[string]any) { delete(claims, "sub") },
+		},
+		{
+			name:   "signed by other key",
+			signer: otherIssuer,
+		},
+	}
+
+	if _, err := provider.verify(context.Background(), metadata, issuer.SignIDToken(validClaims()), "nonce"); err != nil {
+		t.Fatalf("expected valid token to pass verification, got %s", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := validClaims()
+			if test.modify != nil {
+				test.modify(claims)
+			}
+
+			signer := issuer
+			if test.signer != nil {
+				signer = test.signer
+			}
+
+			_, err := provider.verify(context.Background(), metadata, signer.SignIDToken(claims), "nonce")
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoginState_Encode(t *testing.T) {
+	state := NewLoginState()
+
+	decoded, err := DecodeLoginState(state.Encode())
+	if err != nil {
+		t.Fatalf("failed to decode login state: %s", err)
+	}
+
+	if *decoded != *state {
+		t.Errorf("expected %+v, got %+v", state, decoded)
+	}
+
+	for _, invalid := range []string{"", "a.b", "a..c", "a.b.c.d"} {
+		if _, err := DecodeLoginState(invalid); !errors.Is(err, ErrInvalidLoginState) {
+			t.Errorf("expected ErrInvalidLoginState for %q, got %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const loginStateSeparator = "."
+
+var ErrInvalidLoginState = errors.New("invalid login state")
+
+// LoginState holds the values of a single login attempt that have to survive the round trip to the provider.
+// It's kept on the client side (in a cookie) until the provider redirects back with the authorization code.
+type LoginState struct {
+	// State protects the callback against cross-site request forgery.
+	State string
+	// Nonce binds the ID token to the login attempt.
+	Nonce string
+	// Verifier is the PKCE code verifier.
+	Verifier string
+}
+
+// NewLoginState generates the random values of a new login attempt.
+func NewLoginState() *LoginState {
+	// the PKCE verifier generator provides 32 bytes of randomness encoded as URL-safe base64, good for all values.
+	return &LoginState{
+		State:    oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+}
+
+// Encode encodes the login state into a string that is safe to be used as a cookie value.
+func (s *LoginState) Encode() string {
+	return strings.Join([]string{s.State, s.Nonce, s.Verifier}, loginStateSeparator)
+}
+
+// DecodeLoginState decodes a login state previously encoded with Encode.
+func DecodeLoginState(v string) (*LoginState, error) {
+	parts := strings.Split(v, loginStateSeparator)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, ErrInvalidLoginState
+	}
+
+	return &LoginState{
+		State:    parts[0],
+		Nonce:    parts[1],
+		Verifier: parts[2],
+	}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideProvider,
+)
+
+// ProvideProvider provides the OpenID Connect provider, or nil if single sign-on isn't enabled.
+func ProvideProvider(config *types.Config) (*Provider, error) {
+	if !config.OIDC.Enabled {
+		return nil, nil //nolint:nilnil // nil provider means single sign-on is disabled
+	}
+
+	redirectURL := config.OIDC.RedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimRight(config.URL.API, "/") + "/v1/oidc/callback"
+	}
+
+	provider, err := NewProvider(Config{
+		Issuer:       config.OIDC.Issuer,
+		ClientID:     config.OIDC.ClientID,
+		ClientSecret: config.OIDC.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       config.OIDC.Scopes,
+		GroupsClaim:  config.OIDC.GroupsClaim,
+		GroupMapping: config.OIDC.GroupMapping,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc configuration: %w", err)
+	}
+
+	return provider, nil
+}
@@ -60,6 +60,10 @@ type ChecksRequestedPayload struct {
 	Base
 	PrincipalID int64  `json:"principal_id"`
 	CommitSHA   string `json:"commit_sha"`
+	// PullReqIDs are the pull requests of the merge group tested by the checks, in the merge queue order.
+	PullReqIDs []int64 `json:"pullreq_ids,omitempty"`
+	// Bisect is true if the merge group is a part of a failed merge group that is being bisected.
+	Bisect bool `json:"bisect,omitempty"`
 }
 
 func (r *Reporter) ChecksRequested(ctx context.Context, payload *ChecksRequestedPayload) {
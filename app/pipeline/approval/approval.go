@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrNotPending is returned when a decision is made on an approval that was already decided.
+	ErrNotPending = errors.New("the approval is not pending")
+
+	// ErrNotWaiting is returned when a decision is made on an approval whose stage isn't waiting for it.
+	ErrNotWaiting = errors.New("the stage is not waiting for approval")
+
+	// ErrSelfApproval is returned when the principal that triggered the execution tries to approve it.
+	// The approvers are declared in the pipeline yaml, which the trigger principal usually controls.
+	ErrSelfApproval = errors.New("the principal that triggered the execution can't approve it")
+)
+
+// Service decides the approval gates of pipeline stages and expires the ones past their deadline.
+type Service struct {
+	approvalStore      store.StageApprovalStore
+	stageStore         store.StageStore
+	executionStore     store.ExecutionStore
+	userGroupStore     store.UserGroupStore
+	userGroupService   usergroup.Service
+	principalInfoCache store.PrincipalInfoCache
+	executionManager   manager.ExecutionManager
+	scheduler          *job.Scheduler
+}
+
+func NewService(
+	approvalStore store.StageApprovalStore,
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	userGroupStore store.UserGroupStore,
+	userGroupService usergroup.Service,
+	principalInfoCache store.PrincipalInfoCache,
+	executionManager manager.ExecutionManager,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		approvalStore:      approvalStore,
+		stageStore:         stageStore,
+		executionStore:     executionStore,
+		userGroupStore:     userGroupStore,
+		userGroupService:   userGroupService,
+		principalInfoCache: principalInfoCache,
+		executionManager:   executionManager,
+		scheduler:          scheduler,
+	}
+}
+
+// Find returns the approval gate of a stage along with the principal that decided it.
+func (s *Service) Find(ctx context.Context, stageID int64) (*types.StageApproval, error) {
+	approval, err := s.approvalStore.FindByStageID(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage approval: %w", err)
+	}
+
+	if err = s.backfillDecider(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// IsApprover returns true if the principal is allowed to decide the approval.
+// An approval without approvers and user groups can be decided by anyone with its permission.
+// User groups are looked up in the space the repository belongs to.
+func (s *Service) IsApprover(
+	ctx context.Context,
+	approval *types.StageApproval,
+	spaceID int64,
+	principal *types.Principal,
+) (bool, error) {
+	if len(approval.Approvers) == 0 && len(approval.UserGroups) == 0 {
+		return true, nil
+	}
+
+	if slices.Contains(approval.Approvers, principal.UID) {
+		return true, nil
+	}
+
+	if len(approval.UserGroups) == 0 {
+		return false, nil
+	}
+
+	userGroups, err := s.userGroupStore.FindManyByIdentifiersAndSpaceID(ctx, approval.UserGroups, spaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find approval user groups: %w", err)
+	}
+
+	userGroupIDs := make([]int64, len(userGroups))
+	for i, userGroup := range userGroups {
+		userGroupIDs[i] = userGroup.ID
+	}
+
+	userIDs, err := s.userGroupService.ListUserIDsByGroupIDs(ctx, userGroupIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to list members of approval user groups: %w", err)
+	}
+
+	return slices.Contains(userIDs, principal.ID), nil
+}
+
+// Decide records the decision on a pending approval and completes its stage:
+// an approved stage succeeds and its dependents get scheduled, otherwise the stage fails.
+// If completing the stage fails, the decision is applied again by the timeout job.
+func (s *Service) Decide(
+	ctx context.Context,
+	approval *types.StageApproval,
+	decision enum.StageApprovalDecision,
+	decidedBy *types.Principal,
+	comment string,
+) error {
+	if approval.Decision != enum.StageApprovalDecisionPending {
+		return ErrNotPending
+	}
+
+	stage, err := s.stageStore.Find(ctx, approval.StageID)
+	if err != nil {
+		return fmt.Errorf("failed to find approval stage: %w", err)
+	}
+
+	if stage.Status != enum.CIStatusBlocked {
+		return ErrNotWaiting
+	}
+
+	if decision == enum.StageApprovalDecisionApproved && decidedBy != nil {
+		execution, err := s.executionStore.Find(ctx, stage.ExecutionID)
+		if err != nil {
+			return fmt.Errorf("failed to find approval execution: %w", err)
+		}
+		if execution.CreatedBy == decidedBy.ID {
+			return ErrSelfApproval
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	approval.Decision = decision
+	approval.Decided = now
+	approval.Comment = comment
+	if decidedBy != nil {
+		approval.DecidedBy = &decidedBy.ID
+		approval.Decider = decidedBy.ToPrincipalInfo()
+	}
+
+	// the approval is updated first, the optimistic lock makes sure only one decision is applied.
+	err = s.approvalStore.Update(ctx, approval)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return ErrNotPending
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update stage approval: %w", err)
+	}
+
+	return s.applyDecision(ctx, approval, stage)
+}
+
+// applyDecision completes the blocked stage of a decided approval.
+func (s *Service) applyDecision(ctx context.Context, approval *types.StageApproval, stage *types.Stage) error {
+	switch approval.Decision {
+	case enum.StageApprovalDecisionApproved:
+		stage.Status = enum.CIStatusSuccess
+	case enum.StageApprovalDecisionRejected:
+		stage.Status = enum.CIStatusFailure
+		stage.Error = "approval rejected"
+		if approval.Decider != nil {
+			stage.Error = fmt.Sprintf("approval rejected by %s", approval.Decider.UID)
+		}
+	case enum.StageApprovalDecisionExpired:
+		stage.Status = enum.CIStatusFailure
+		stage.Error = "approval timed out"
+	case enum.StageApprovalDecisionPending:
+		return fmt.Errorf("invalid approval decision %q", approval.Decision)
+	}
+	stage.Stopped = approval.Decided
+
+	s.resumeExecution(ctx, stage.ExecutionID)
+
+	// completing the stage schedules its dependents or finishes the execution.
+	if err := s.executionManager.AfterStage(ctx, stage); err != nil {
+		return fmt.Errorf("failed to complete approval stage: %w", err)
+	}
+
+	return nil
+}
+
+// resumeExecution moves a blocked execution back to its previous state.
+// It's blocked again by the manager if it still waits for another approval.
+func (s *Service) resumeExecution(ctx context.Context, executionID int64) {
+	execution, err := s.executionStore.Find(ctx, executionID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find the execution of the approval")
+		return
+	}
+
+	if execution.Status != enum.CIStatusBlocked {
+		return
+	}
+
+	execution.Status = enum.CIStatusRunning
+	if execution.Started == 0 {
+		execution.Status = enum.CIStatusPending
+	}
+
+	if err = s.executionStore.Update(ctx, execution); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to resume the execution of the approval")
+	}
+}
+
+func (s *Service) backfillDecider(ctx context.Context, approval *types.StageApproval) error {
+	if approval.DecidedBy == nil {
+		return nil
+	}
+
+	decider, err := s.principalInfoCache.Get(ctx, *approval.DecidedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get approval decider: %w", err)
+	}
+
+	approval.Decider = decider
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeTimeouts        = "gitness:pipeline:approval-timeouts"
+	jobCronTimeouts        = "* * * * *" // Every minute.
+	jobMaxDurationTimeouts = time.Minute
+
+	// timeoutsBatchSize is the number of expired approvals fetched from the DB at once.
+	timeoutsBatchSize = 100
+
+	// decisionGracePeriod is the time a decision is given to complete its stage
+	// before the timeout job applies it again.
+	decisionGracePeriod = time.Minute
+)
+
+// Register registers the recurring job that expires the approvals past their deadline
+// and applies the decisions whose stage wasn't completed.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeTimeouts, jobTypeTimeouts, jobCronTimeouts, jobMaxDurationTimeouts)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for approval timeouts: %w", err)
+	}
+
+	return nil
+}
+
+// Handle expires the pending approvals past their deadline, which fails their stages,
+// and completes the stages that are still blocked although their approval was decided.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now().UnixMilli()
+
+	expired, err := s.expire(ctx, now)
+	if err != nil {
+		return "", err
+	}
+
+	applied, err := s.reapply(ctx, now-decisionGracePeriod.Milliseconds())
+	if err != nil {
+		return "", err
+	}
+
+	result := "no expired approvals or unapplied decisions found"
+	if expired+applied > 0 {
+		result = fmt.Sprintf("expired %d approvals and applied %d decisions", expired, applied)
+	}
+
+	return result, nil
+}
+
+func (s *Service) expire(ctx context.Context, now int64) (int, error) {
+	approvals, err := s.approvalStore.ListExpired(ctx, now, timeoutsBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired approvals: %w", err)
+	}
+
+	n := 0
+	for _, approval := range approvals {
+		err = s.Decide(ctx, approval, enum.StageApprovalDecisionExpired, nil, "")
+		if errors.Is(err, ErrNotWaiting) {
+			// the stage was cancelled or skipped meanwhile, only close the approval.
+			approval.Decision = enum.StageApprovalDecisionExpired
+			approval.Decided = now
+			err = s.approvalStore.Update(ctx, approval)
+		}
+		if errors.Is(err, ErrNotPending) || errors.Is(err, gitness_store.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", approval.StageID).Msg("failed to expire approval")
+			continue
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// reapply completes the stages whose approval was decided before the given time,
+// but which are still blocked because completing them failed after the decision was recorded.
+func (s *Service) reapply(ctx context.Context, decidedBefore int64) (int, error) {
+	approvals, err := s.approvalStore.ListUnapplied(ctx, decidedBefore, timeoutsBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unapplied approval decisions: %w", err)
+	}
+
+	n := 0
+	for _, approval := range approvals {
+		stage, err := s.stageStore.Find(ctx, approval.StageID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", approval.StageID).Msg("failed to find approval stage")
+			continue
+		}
+		if stage.Status != enum.CIStatusBlocked {
+			continue
+		}
+
+		if err = s.backfillDecider(ctx, approval); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill approval decider")
+		}
+
+		err = s.applyDecision(ctx, approval, stage)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			// the stage was completed meanwhile.
+			continue
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", approval.StageID).Msg("failed to apply approval decision")
+			continue
+		}
+		n++
+	}
+
+	return n, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+// ProvideService provides the stage approval service and registers its timeout job.
+func ProvideService(
+	approvalStore store.StageApprovalStore,
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	userGroupStore store.UserGroupStore,
+	userGroupService usergroup.Service,
+	principalInfoCache store.PrincipalInfoCache,
+	executionManager manager.ExecutionManager,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service := NewService(approvalStore, stageStore, executionStore, userGroupStore, userGroupService,
+		principalInfoCache, executionManager, scheduler)
+
+	if err := executor.Register(jobTypeTimeouts, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -73,9 +73,10 @@ func (s *service) Cancel(ctx context.Context, repo *types.RepositoryCore, execut
 
 	// do not cancel the build if the build status is
 	// complete. only cancel the build if the status is
-	// running or pending.
+	// running, pending or blocked waiting for an approval.
 	if execution.Status != enum.CIStatusPending &&
-		execution.Status != enum.CIStatusRunning {
+		execution.Status != enum.CIStatusRunning &&
+		execution.Status != enum.CIStatusBlocked {
 		return nil
 	}
 
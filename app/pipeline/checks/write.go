@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/store"
@@ -25,6 +26,18 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+const (
+	// MaxFailedTests is the max number of failed tests listed in the check summary.
+	MaxFailedTests = 10
+
+	maxSummaryLength = 2048
+)
+
+// checkMetadata is the metadata of pipeline checks.
+type checkMetadata struct {
+	Tests *types.TestSummary `json:"tests,omitempty"`
+}
+
 // Write is a util function which writes execution and pipeline state to the
 // check store.
 func Write(
@@ -32,6 +45,20 @@ func Write(
 	checkStore store.CheckStore,
 	execution *types.Execution,
 	pipeline *types.Pipeline,
+) error {
+	return WriteWithTestSummary(ctx, checkStore, execution, pipeline, nil, nil)
+}
+
+// WriteWithTestSummary writes execution and pipeline state to the check store like Write.
+// If the execution has test results, the check summary lists the failed tests instead of
+// the pipeline description and the check metadata contains the test summary.
+func WriteWithTestSummary(
+	ctx context.Context,
+	checkStore store.CheckStore,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	testSummary *types.TestSummary,
+	failedTests []*types.TestCase,
 ) error {
 	payload := types.CheckPayloadInternal{
 		Number:     execution.Number,
@@ -47,6 +74,14 @@ func Write(
 	if summary == "" {
 		summary = pipeline.Identifier
 	}
+	metadata := []byte("{}")
+	if testSummary != nil && testSummary.Total > 0 {
+		summary = testResultSummary(testSummary, failedTests)
+		metadata, err = json.Marshal(checkMetadata{Tests: testSummary})
+		if err != nil {
+			return fmt.Errorf("could not marshal check metadata: %w", err)
+		}
+	}
 	check := &types.Check{
 		RepoID:     execution.RepoID,
 		Identifier: pipeline.Identifier,
@@ -56,7 +91,7 @@ func Write(
 		CreatedBy:  execution.CreatedBy,
 		Status:     execution.Status.ConvertToCheckStatus(),
 		CommitSHA:  execution.After,
-		Metadata:   []byte("{}"),
+		Metadata:   metadata,
 		Payload: types.CheckPayload{
 			Version: "1",
 			Kind:    enum.CheckPayloadKindPipeline,
@@ -69,3 +104,36 @@ func Write(
 	}
 	return nil
 }
+
+// testResultSummary returns a short summary of the test results that lists the failed tests.
+func testResultSummary(testSummary *types.TestSummary, failedTests []*types.TestCase) string {
+	if testSummary.Failed == 0 {
+		return fmt.Sprintf("%d tests passed, %d skipped", testSummary.Passed, testSummary.Skipped)
+	}
+
+	head := fmt.Sprintf("%d of %d tests failed", testSummary.Failed, testSummary.Total)
+
+	// leave enough room for the number of remaining tests.
+	length := len(head) + 32
+	names := make([]string, 0, len(failedTests))
+	for _, tc := range failedTests {
+		name := tc.Name
+		if tc.ClassName != "" {
+			name = tc.ClassName + "." + tc.Name
+		}
+		if length+len(name)+2 > maxSummaryLength {
+			break
+		}
+		length += len(name) + 2
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return head
+	}
+
+	if remaining := testSummary.Failed - int64(len(names)); remaining > 0 {
+		names = append(names, fmt.Sprintf("and %d more", remaining))
+	}
+
+	return head + ": " + strings.Join(names, ", ")
+}
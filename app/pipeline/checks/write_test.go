@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestTestResultSummary(t *testing.T) {
+	failed := []*types.TestCase{
+		{ClassName: "math", Name: "TestDiv"},
+		{Name: "TestPow"},
+	}
+
+	tests := []struct {
+		name    string
+		summary types.TestSummary
+		failed  []*types.TestCase
+		want    string
+	}{
+		{
+			name:    "passed",
+			summary: types.TestSummary{Total: 5, Passed: 4, Skipped: 1},
+			want:    "4 tests passed, 1 skipped",
+		},
+		{
+			name:    "all failed listed",
+			summary: types.TestSummary{Total: 5, Passed: 3, Failed: 2},
+			failed:  failed,
+			want:    "2 of 5 tests failed: math.TestDiv, TestPow",
+		},
+		{
+			name:    "more failed than listed",
+			summary: types.TestSummary{Total: 50, Failed: 12},
+			failed:  failed,
+			want:    "12 of 50 tests failed: math.TestDiv, TestPow, and 10 more",
+		},
+		{
+			name:    "no failed tests listed",
+			summary: types.TestSummary{Total: 5, Failed: 1},
+			want:    "1 of 5 tests failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := testResultSummary(&test.summary, test.failed); got != test.want {
+				t.Errorf("testResultSummary() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTestResultSummaryMaxLength(t *testing.T) {
+	failed := make([]*types.TestCase, 100)
+	for i := range failed {
+		failed[i] = &types.TestCase{Name: strings.Repeat("x", 100)}
+	}
+
+	got := testResultSummary(&types.TestSummary{Total: 100, Failed: 100}, failed)
+	if len(got) > maxSummaryLength {
+		t.Errorf("expected summary to be at most %d bytes long, got %d", maxSummaryLength, len(got))
+	}
+	if !strings.HasSuffix(got, "more") {
+		t.Errorf("expected summary to end with the number of remaining tests, got %q", got)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// BlockForApproval marks an approval stage as waiting for its decision. The stage is never
+// handed to a runner, it stays blocked until the approval is decided or its deadline passes.
+func BlockForApproval(stage *types.Stage, approval *types.StageApproval, now int64) {
+	stage.Status = enum.CIStatusBlocked
+	stage.Started = now
+	if approval.Timeout > 0 {
+		approval.Deadline = now + approval.Timeout
+	}
+}
+
+// blockForApproval blocks a waiting approval stage whose dependencies are complete.
+func (t *teardown) blockForApproval(ctx context.Context, stage *types.Stage) error {
+	approval, err := t.Approvals.FindByStageID(ctx, stage.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find stage approval: %w", err)
+	}
+
+	BlockForApproval(stage, approval, time.Now().UnixMilli())
+
+	if err = t.Stages.Update(ctx, stage); err != nil {
+		return err
+	}
+
+	if err = t.Approvals.Update(ctx, approval); err != nil {
+		return fmt.Errorf("failed to update stage approval deadline: %w", err)
+	}
+
+	return nil
+}
+
+// isExecutionBlocked returns true if an incomplete execution can't make progress
+// until one of its approval stages is decided.
+func isExecutionBlocked(stages []*types.Stage) bool {
+	blocked := false
+	for _, stage := range stages {
+		switch stage.Status {
+		case enum.CIStatusPending, enum.CIStatusRunning:
+			return false
+		case enum.CIStatusBlocked:
+			blocked = true
+		default:
+		}
+	}
+	return blocked
+}
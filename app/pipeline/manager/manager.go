@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
@@ -28,10 +30,12 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/testreport"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/livelog"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -45,6 +49,10 @@ const (
 	pipelineJWTLifetime = 72 * time.Hour
 	// pipelineJWTRole specifies the role of an ephemeral pipeline jwt token.
 	pipelineJWTRole = enum.MembershipRoleContributor
+	// maxTestReportSize specifies the max size of a single test report.
+	maxTestReportSize = 32 << 20
+	// artifactBlobPathFmt is the blob store path of an artifact, given its execution id, step id and name.
+	artifactBlobPathFmt = "pipeline-artifacts/%d/%d/%s"
 )
 
 var noContext = context.Background()
@@ -112,6 +120,12 @@ type (
 		// UploadLogs uploads the full logs.
 		UploadLogs(ctx context.Context, step int64, r io.Reader) error
 
+		// UploadTestReport uploads a JUnit XML test report of a step, identified by its stage and number.
+		UploadTestReport(ctx context.Context, stageID int64, stepNum int, r io.Reader) error
+
+		// UploadArtifact uploads a file published by a step, identified by its stage and number.
+		UploadArtifact(ctx context.Context, stageID int64, stepNum int, name string, r io.Reader) error
+
 		// BeforeStep signals the build step is about to start.
 		BeforeStep(ctx context.Context, step *types.Step) error
 
@@ -146,8 +160,12 @@ type Manager struct {
 	Scheduler scheduler.Scheduler
 	Secrets   store.SecretStore
 	// Status  store.StatusService
-	Stages store.StageStore
-	Steps  store.StepStore
+	Stages    store.StageStore
+	Steps     store.StepStore
+	TestCases store.TestCaseStore
+	Artifacts store.ExecutionArtifactStore
+	Approvals store.StageApprovalStore
+	BlobStore blob.Store
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
@@ -173,6 +191,10 @@ func New(
 	secretStore store.SecretStore,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	approvalStore store.StageApprovalStore,
+	blobStore blob.Store,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
 	reporter events.Reporter,
@@ -193,6 +215,10 @@ func New(
 		Secrets:          secretStore,
 		Stages:           stageStore,
 		Steps:            stepStore,
+		TestCases:        testCaseStore,
+		Artifacts:        artifactStore,
+		Approvals:        approvalStore,
+		BlobStore:        blobStore,
 		Users:            userStore,
 		publicAccess:     publicAccess,
 		reporter:         reporter,
@@ -284,6 +310,118 @@ func (m *Manager) UploadLogs(ctx context.Context, step int64, r io.Reader) error
 	return nil
 }
 
+// UploadTestReport parses a JUnit XML test report of a step and stores its test cases.
+func (m *Manager) UploadTestReport(ctx context.Context, stageID int64, stepNum int, r io.Reader) error {
+	log := log.With().
+		Int64("stage-id", stageID).
+		Int("step-number", stepNum).
+		Logger()
+
+	step, err := m.Steps.FindByNumber(ctx, stageID, stepNum)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot find step")
+		return err
+	}
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot find stage")
+		return err
+	}
+
+	cases, err := testreport.ParseJUnit(io.LimitReader(r, maxTestReportSize))
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot parse test report")
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	for _, tc := range cases {
+		tc.ExecutionID = stage.ExecutionID
+		tc.StageID = stage.ID
+		tc.StepID = step.ID
+		tc.Created = now
+	}
+
+	err = m.TestCases.CreateMany(ctx, cases)
+	if err != nil {
+		log.Error().Err(err).Msg("manager: cannot store test cases")
+		return err
+	}
+	return nil
+}
+
+// UploadArtifact stores a file published by a step in the blob store.
+func (m *Manager) UploadArtifact(ctx context.Context, stageID int64, stepNum int, name string, r io.Reader) error {
+	log := log.With().
+		Int64("stage-id", stageID).
+		Int("step-number", stepNum).
+		Str("artifact", name).
+		Logger()
+
+	name = path.Clean(strings.TrimPrefix(name, "/"))
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("invalid artifact name %q", name)
+	}
+
+	step, err := m.Steps.FindByNumber(ctx, stageID, stepNum)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot find step")
+		return err
+	}
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot find stage")
+		return err
+	}
+
+	blobPath := fmt.Sprintf(artifactBlobPathFmt, stage.ExecutionID, step.ID, name)
+
+	// read one byte past the limit to detect artifacts exceeding it, a limit of 0 disables it.
+	maxSize := m.Config.CI.ArtifactMaxSize
+	if maxSize > 0 {
+		r = io.LimitReader(r, maxSize+1)
+	}
+	counter := &countingReader{r: r}
+	if err = m.BlobStore.Upload(ctx, counter, blobPath); err != nil {
+		log.Error().Err(err).Msg("manager: cannot upload artifact")
+		return err
+	}
+
+	if maxSize > 0 && counter.n > maxSize {
+		if err = m.BlobStore.Delete(ctx, blobPath); err != nil {
+			log.Warn().Err(err).Msg("manager: cannot delete oversized artifact")
+		}
+		return fmt.Errorf("artifact %q exceeds the size limit of %d bytes", name, maxSize)
+	}
+
+	err = m.Artifacts.Create(ctx, &types.ExecutionArtifact{
+		ExecutionID: stage.ExecutionID,
+		StageID:     stage.ID,
+		StepID:      step.ID,
+		Name:        name,
+		Size:        counter.n,
+		BlobPath:    blobPath,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("manager: cannot store artifact")
+		return err
+	}
+	return nil
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 // Details provides details about the stage.
 func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext, error) {
 	log := log.With().Ctx(ctx).
@@ -490,6 +628,8 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+		TestCases:   m.TestCases,
+		Approvals:   m.Approvals,
 		Reporter:    m.reporter,
 	}
 	return t.do(noContext, stage) //nolint:contextcheck
@@ -118,10 +118,13 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 // This accounts for the fact that another agent may have already updated
 // the execution status, which may happen if two stages execute concurrently.
 func (s *setup) updateExecution(ctx context.Context, execution *types.Execution) (bool, error) {
-	if execution.Status != enum.CIStatusPending {
+	// an execution blocked by an approval resumes once one of its stages starts running.
+	if execution.Status != enum.CIStatusPending && execution.Status != enum.CIStatusBlocked {
 		return false, nil
 	}
-	execution.Started = time.Now().UnixMilli()
+	if execution.Started == 0 {
+		execution.Started = time.Now().UnixMilli()
+	}
 	execution.Status = enum.CIStatusRunning
 	err := s.Executions.Update(ctx, execution)
 	if errors.Is(err, gitness_store.ErrVersionConflict) {
@@ -44,6 +44,8 @@ type teardown struct {
 	Repos       store.RepoStore
 	Steps       store.StepStore
 	Stages      store.StageStore
+	TestCases   store.TestCaseStore
+	Approvals   store.StageApprovalStore
 	Reporter    events.Reporter
 }
 
@@ -132,6 +134,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 	if !isexecutionComplete(stages) {
 		log.Warn().Err(err).
 			Msg("manager: execution pending completion of additional stages")
+		if isExecutionBlocked(stages) && execution.Status != enum.CIStatusBlocked {
+			t.blockExecution(ctx, repo, execution)
+		}
 		return nil
 	}
 
@@ -181,8 +186,24 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		log.Error().Err(err).Msg("manager: cannot find pipeline")
 		return err
 	}
+	// the check summary lists the failed tests, if the execution uploaded any test reports.
+	testSummary, err := t.TestCases.Summarize(ctx, execution.ID)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot summarize test cases")
+	}
+	var failedTests []*types.TestCase
+	if testSummary != nil && testSummary.Failed > 0 {
+		failedTests, err = t.TestCases.List(ctx, execution.ID, &types.TestCaseFilter{
+			Pagination: types.Pagination{Page: 1, Size: checks.MaxFailedTests},
+			Status:     []enum.TestStatus{enum.TestStatusFailed},
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("manager: cannot list failed test cases")
+		}
+	}
+
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, t.Checks, execution, pipeline)
+	err = checks.WriteWithTestSummary(ctx, t.Checks, execution, pipeline, testSummary, failedTests)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
@@ -322,6 +343,25 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
+		if sibling.Type == types.StageTypeApproval {
+			log.Debug().Msg("manager: block next stage for approval")
+
+			err := t.blockForApproval(noContext, sibling) //nolint:contextcheck
+			if errors.Is(err, gitness_store.ErrVersionConflict) {
+				rErr := t.resync(ctx, sibling)
+				if rErr != nil {
+					log.Warn().Err(rErr).Msg("failed to resync after version conflict")
+				}
+				continue
+			}
+			if err != nil {
+				log.Error().Err(err).
+					Msg("manager: cannot block stage for approval")
+				errs = multierror.Append(errs, err)
+			}
+			continue
+		}
+
 		log.Debug().Msg("manager: schedule next stage")
 
 		sibling.Status = enum.CIStatusPending
@@ -349,6 +389,22 @@ func (t *teardown) scheduleDownstream(
 	return errs
 }
 
+// blockExecution marks the execution as waiting for the approval of one of its stages.
+func (t *teardown) blockExecution(ctx context.Context, repo *types.Repository, execution *types.Execution) {
+	execution.Status = enum.CIStatusBlocked
+	err := t.Executions.Update(noContext, execution) //nolint:contextcheck
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		log.Ctx(ctx).Warn().Err(err).Msg("manager: execution updated by another goroutine")
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("manager: cannot mark the execution as blocked")
+		return
+	}
+
+	t.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionUpdated, execution) //nolint:contextcheck
+}
+
 // resync updates the stage from the database. Note that it does
 // not update the Version field. This is by design. It prevents
 // the current go routine from updating a stage that has been
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
 
@@ -53,13 +54,18 @@ func ProvideExecutionManager(
 	secretStore store.SecretStore,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	approvalStore store.StageApprovalStore,
+	blobStore blob.Store,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
 	reporter *events.Reporter,
 ) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
 		logStore, logStream, checkStore, repoStore, scheduler, secretStore,
-		stageStore, stepStore, userStore, publicAccess, *reporter)
+		stageStore, stepStore, testCaseStore, artifactStore, approvalStore, blobStore, userStore,
+		publicAccess, *reporter)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	dockerclient "github.com/docker/docker/client"
+)
+
+const (
+	// envArtifactsDir is the environment variable exposing the artifacts directory to the steps.
+	envArtifactsDir = "GITNESS_ARTIFACTS_DIR"
+	// artifactsDir is the directory within the step container whose files are published as artifacts.
+	artifactsDir = "/tmp/gitness/artifacts"
+
+	// maxArtifactFiles is the maximum number of artifacts uploaded per step.
+	maxArtifactFiles = 100
+)
+
+// uploadArtifacts uploads the files the step placed in the artifacts directory.
+func (c *stepOutputCollector) uploadArtifacts(
+	ctx context.Context,
+	step collectedStep,
+	stageID int64,
+	stepNum int,
+) error {
+	uploaded := 0
+	match := func(name string, size int64) (bool, error) {
+		if c.maxArtifactSize > 0 && size > c.maxArtifactSize {
+			return false, fmt.Errorf("artifact %q exceeds the size limit of %d bytes", name, c.maxArtifactSize)
+		}
+		if uploaded >= maxArtifactFiles {
+			return false, fmt.Errorf("number of artifacts exceeds the limit of %d", maxArtifactFiles)
+		}
+		uploaded++
+		return true, nil
+	}
+
+	upload := func(name string, r io.Reader) error {
+		name = strings.TrimPrefix(name, artifactsDir+"/")
+		if err := c.uploader.UploadArtifact(ctx, stageID, stepNum, name, r); err != nil {
+			return fmt.Errorf("failed to upload artifact %q: %w", name, err)
+		}
+		return nil
+	}
+
+	err := c.walkContainerFiles(ctx, step.containerID, artifactsDir, match, upload)
+	if dockerclient.IsErrNotFound(err) {
+		// the step didn't publish any artifacts.
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/drone/drone-yaml/yaml"
+	harness "github.com/drone/spec/dist/go"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	// labelTestReports holds the json encoded list of test report paths declared by a step.
+	labelTestReports = "io.gitness.test-reports"
+
+	// maxTestReportFileSize is the maximum size of a single test report file that is uploaded.
+	maxTestReportFileSize = 32 << 20
+	// maxTestReportFiles is the maximum number of test report files uploaded per step.
+	maxTestReportFiles = 100
+)
+
+// testReportPaths returns the junit report paths declared by the steps of the stage, keyed by step id.
+func testReportPaths(config *harness.Config, stageName string) map[string][]string {
+	if config == nil {
+		return nil
+	}
+	pipeline, ok := config.Spec.(*harness.Pipeline)
+	if !ok {
+		return nil
+	}
+
+	for _, stage := range pipeline.Stages {
+		if stage.Id != stageName {
+			continue
+		}
+		stageSpec, ok := stage.Spec.(*harness.StageCI)
+		if !ok {
+			return nil
+		}
+
+		reports := make(map[string][]string)
+		collectTestReportPaths(stageSpec.Steps, reports)
+		return reports
+	}
+
+	return nil
+}
+
+func collectTestReportPaths(steps []*harness.Step, reports map[string][]string) {
+	for _, step := range steps {
+		var declared []*harness.Report
+		switch spec := step.Spec.(type) {
+		case *harness.StepRun:
+			declared = spec.Reports
+		case *harness.StepExec:
+			declared = spec.Reports
+		case *harness.StepPlugin:
+			declared = spec.Reports
+		case *harness.StepParallel:
+			collectTestReportPaths(spec.Steps, reports)
+		case *harness.StepGroup:
+			collectTestReportPaths(spec.Steps, reports)
+		}
+
+		for _, report := range declared {
+			if report == nil || (report.Type != "" && !strings.EqualFold(report.Type, "junit")) {
+				continue
+			}
+			for _, p := range report.Path {
+				if p = strings.TrimSpace(p); p != "" {
+					reports[step.Id] = append(reports[step.Id], p)
+				}
+			}
+		}
+	}
+}
+
+// droneTestReportPaths returns the junit report paths declared by the steps of the drone yaml pipeline,
+// keyed by step name. Reports are declared the same way as in v1 yaml, e.g.
+//
+//	steps:
+//	- name: test
+//	  reports:
+//	  - type: junit
+//	    path: [unit.xml, e2e/*.xml]
+func droneTestReportPaths(data []byte, pipelineName string) (map[string][]string, error) {
+	resources, err := yaml.ParseRawBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse raw yaml: %w", err)
+	}
+
+	for _, resource := range resources {
+		if resource.Kind != "pipeline" {
+			continue
+		}
+
+		var pipeline struct {
+			Name  string `yaml:"name"`
+			Steps []struct {
+				Name    string `yaml:"name"`
+				Reports []struct {
+					Type string        `yaml:"type"`
+					Path stringOrSlice `yaml:"path"`
+				} `yaml:"reports"`
+			} `yaml:"steps"`
+		}
+		if err = yamlv3.Unmarshal(resource.Data, &pipeline); err != nil {
+			return nil, fmt.Errorf("could not parse steps of pipeline %q: %w", pipeline.Name, err)
+		}
+		if pipeline.Name != pipelineName {
+			continue
+		}
+
+		reports := make(map[string][]string)
+		for _, step := range pipeline.Steps {
+			for _, report := range step.Reports {
+				if report.Type != "" && !strings.EqualFold(report.Type, "junit") {
+					continue
+				}
+				for _, p := range report.Path {
+					if p = strings.TrimSpace(p); p != "" {
+						reports[step.Name] = append(reports[step.Name], p)
+					}
+				}
+			}
+		}
+		return reports, nil
+	}
+
+	return nil, nil //nolint:nilnil // unknown pipeline has no test reports
+}
+
+// stringOrSlice is a yaml value that is either a single string or a list of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalYAML(node *yamlv3.Node) error {
+	if node.Kind == yamlv3.ScalarNode {
+		*s = []string{node.Value}
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+
+	return nil
+}
+
+// encodeTestReportPaths encodes the test report paths of each step for use as a label value.
+func encodeTestReportPaths(reports map[string][]string) (map[string]string, error) {
+	encoded := make(map[string]string, len(reports))
+	for step, paths := range reports {
+		raw, err := json.Marshal(paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode test report paths: %w", err)
+		}
+		encoded[step] = string(raw)
+	}
+	return encoded, nil
+}
+
+func (c *stepOutputCollector) uploadTestReports(
+	ctx context.Context,
+	step collectedStep,
+	stageID int64,
+	stepNum int,
+) error {
+	raw := step.labels[labelTestReports]
+	if raw == "" {
+		return nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal([]byte(raw), &patterns); err != nil {
+		return fmt.Errorf("invalid test report paths: %w", err)
+	}
+
+	var errs []error
+	uploaded := 0
+	for _, pattern := range patterns {
+		if !path.IsAbs(pattern) {
+			pattern = path.Join(step.workingDir, pattern)
+		}
+		pattern = path.Clean(pattern)
+
+		match := func(name string, size int64) (bool, error) {
+			if !matchTestReport(pattern, name) {
+				return false, nil
+			}
+			if size > maxTestReportFileSize {
+				return false, fmt.Errorf("test report %q exceeds the size limit of %d bytes", name, maxTestReportFileSize)
+			}
+			if uploaded >= maxTestReportFiles {
+				return false, fmt.Errorf("number of test report files exceeds the limit of %d", maxTestReportFiles)
+			}
+			uploaded++
+			return true, nil
+		}
+
+		upload := func(name string, r io.Reader) error {
+			if err := c.uploader.UploadTestReport(ctx, stageID, stepNum, r); err != nil {
+				return fmt.Errorf("failed to upload test report %q: %w", name, err)
+			}
+			return nil
+		}
+
+		if err := c.walkContainerFiles(ctx, step.containerID, testReportBaseDir(pattern), match, upload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// testReportBaseDir returns the longest leading path of the pattern that contains no glob characters.
+func testReportBaseDir(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "*?[{") {
+			if i <= 1 {
+				return "/"
+			}
+			return strings.Join(segments[:i], "/")
+		}
+	}
+	return pattern
+}
+
+// matchTestReport returns true if the file matches the report pattern.
+// A pattern without glob characters matches the file itself or any xml file within the directory.
+func matchTestReport(pattern, name string) bool {
+	if name == pattern {
+		return true
+	}
+	if !strings.ContainsAny(pattern, "*?[{") {
+		return strings.HasPrefix(name, pattern+"/") && strings.EqualFold(path.Ext(name), ".xml")
+	}
+	ok, _ := doublestar.Match(pattern, name)
+	return ok
+}
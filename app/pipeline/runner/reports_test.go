@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	harness "github.com/drone/spec/dist/go"
+)
+
+func TestTestReportBaseDir(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "/gitness/report.xml", want: "/gitness/report.xml"},
+		{pattern: "/gitness/reports/**/*.xml", want: "/gitness/reports"},
+		{pattern: "/gitness/*.xml", want: "/gitness"},
+		{pattern: "/*/report.xml", want: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := testReportBaseDir(tt.pattern); got != tt.want {
+				t.Errorf("testReportBaseDir(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTestReport(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "/gitness/report.xml", name: "/gitness/report.xml", want: true},
+		{pattern: "/gitness/reports", name: "/gitness/reports/unit.xml", want: true},
+		{pattern: "/gitness/reports", name: "/gitness/reports/unit.log", want: false},
+		{pattern: "/gitness/reports/**/*.xml", name: "/gitness/reports/a/b/unit.xml", want: true},
+		{pattern: "/gitness/reports/*.xml", name: "/gitness/reports/a/unit.xml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"|"+tt.name, func(t *testing.T) {
+			if got := matchTestReport(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("matchTestReport(%q, %q) = %t, want %t", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTestReportPaths(t *testing.T) {
+	config := &harness.Config{
+		Spec: &harness.Pipeline{
+			Stages: []*harness.Stage{
+				{
+					Id: "build",
+					Spec: &harness.StageCI{
+						Steps: []*harness.Step{
+							{
+								Id: "unit",
+								Spec: &harness.StepRun{Reports: []*harness.Report{
+									{Type: "junit", Path: harness.Stringorslice{"unit.xml"}},
+									{Type: "cobertura", Path: harness.Stringorslice{"coverage.xml"}},
+								}},
+							},
+							{
+								Id: "parallel",
+								Spec: &harness.StepParallel{Steps: []*harness.Step{
+									{
+										Id: "e2e",
+										Spec: &harness.StepRun{Reports: []*harness.Report{
+											{Path: harness.Stringorslice{"e2e/*.xml", "smoke.xml"}},
+										}},
+									},
+								}},
+							},
+							{Id: "lint", Spec: &harness.StepRun{}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := testReportPaths(config, "build")
+	want := map[string][]string{
+		"unit": {"unit.xml"},
+		"e2e":  {"e2e/*.xml", "smoke.xml"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("testReportPaths() = %v, want %v", got, want)
+	}
+
+	if got := testReportPaths(config, "deploy"); len(got) != 0 {
+		t.Errorf("expected no report paths for unknown stage, got %v", got)
+	}
+}
+
+func TestDroneTestReportPaths(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: build
+steps:
+- name: unit
+  image: golang
+  reports:
+  - type: junit
+    path: unit.xml
+  - type: cobertura
+    path: coverage.xml
+- name: e2e
+  image: golang
+  reports:
+  - path: [e2e/*.xml, smoke.xml]
+- name: lint
+  image: golang
+---
+kind: pipeline
+name: deploy
+steps:
+- name: deploy
+  image: alpine
+  reports:
+  - path: deploy.xml
+`)
+
+	got, err := droneTestReportPaths(data, "build")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		"unit": {"unit.xml"},
+		"e2e":  {"e2e/*.xml", "smoke.xml"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("droneTestReportPaths() = %v, want %v", got, want)
+	}
+
+	if got, err := droneTestReportPaths(data, "release"); err != nil || len(got) != 0 {
+		t.Errorf("expected no report paths for unknown pipeline, got %v, %v", got, err)
+	}
+}
@@ -74,6 +74,7 @@ func NewExecutionRunner(
 	config *types.Config,
 	client runnerclient.Client,
 	resolver *resolver.Manager,
+	outputUploader StepOutputUploader,
 ) (*runtime2.Runner, error) {
 	// For linux/windows, containers need to have extra hosts set in order to interact with
 	// Harness. For docker desktop for mac, this is built in and not needed.
@@ -98,8 +99,21 @@ func NewExecutionRunner(
 	if err != nil {
 		return nil, err
 	}
+
+	docker, err := dockerclient.NewClientWithOpts(
+		append([]dockerclient.Opt{dockerclient.FromEnv}, dockerOpts(config)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := &stepOutputCollector{
+		docker:          docker,
+		uploader:        outputUploader,
+		maxArtifactSize: config.CI.ArtifactMaxSize,
+	}
+
 	exec := runtime.NewExecer(tracer, remote, upload,
-		engine, int64(config.CI.ParallelWorkers))
+		&legacyStepEngine{Engine: engine, collector: collector}, int64(config.CI.ParallelWorkers))
 
 	legacyRunner := &runtime.Runner{
 		Machine:  config.InstanceID,
@@ -107,7 +121,7 @@ func NewExecutionRunner(
 		Reporter: tracer,
 		Lookup:   resource.Lookup,
 		Lint:     linter.New().Lint,
-		Compiler: compiler,
+		Compiler: &legacyStepCompiler{Compiler: compiler, client: client},
 		Exec:     exec.Exec,
 	}
 
@@ -116,7 +130,12 @@ func NewExecutionRunner(
 		return nil, err
 	}
 
-	exec2 := runtime2.NewExecer(tracer, remote, upload, engine2, int64(config.CI.ParallelWorkers))
+	stepEngine := &stepEngine{
+		Engine:    engine2,
+		collector: collector,
+	}
+
+	exec2 := runtime2.NewExecer(tracer, remote, upload, stepEngine, int64(config.CI.ParallelWorkers))
 
 	compiler2 := &compiler2.CompilerImpl{
 		Environ:    provider.Static(map[string]string{}),
@@ -132,7 +151,7 @@ func NewExecutionRunner(
 		Client:       client,
 		Resolver:     resolver.GetLookupFn(),
 		Reporter:     tracer,
-		Compiler:     compiler2,
+		Compiler:     &stepCompiler{Compiler: compiler2},
 		Exec:         exec2.Exec,
 		LegacyRunner: legacyRunner,
 	}
@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/drone-runners/drone-runner-docker/engine"
+	compiler2 "github.com/drone-runners/drone-runner-docker/engine2/compiler"
+	engine2 "github.com/drone-runners/drone-runner-docker/engine2/engine"
+	"github.com/drone/drone-go/drone"
+	runnerclient "github.com/drone/runner-go/client"
+	"github.com/drone/runner-go/pipeline/runtime"
+	"github.com/rs/zerolog/log"
+)
+
+// labelStageID holds the id of the stage the step belongs to.
+const labelStageID = "io.gitness.stage.id"
+
+// StepOutputUploader uploads the outputs produced by pipeline steps.
+type StepOutputUploader interface {
+	UploadTestReport(ctx context.Context, stageID int64, stepNum int, r io.Reader) error
+	UploadArtifact(ctx context.Context, stageID int64, stepNum int, name string, r io.Reader) error
+}
+
+// stepCompiler wraps the pipeline compiler and prepares the compiled steps
+// for collecting their test reports and artifacts.
+type stepCompiler struct {
+	compiler2.Compiler
+}
+
+func (c *stepCompiler) Compile(ctx context.Context, args compiler2.Args) (*engine2.Spec, error) {
+	spec, err := c.Compiler.Compile(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := encodeTestReportPaths(testReportPaths(args.Config, args.Stage.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range spec.Steps {
+		step.Labels, step.Envs = prepareStep(step.Labels, step.Envs, args.Stage.ID, reports[step.Name])
+	}
+
+	return spec, nil
+}
+
+// legacyStepCompiler wraps the drone yaml pipeline compiler and prepares the compiled steps
+// for collecting their test reports and artifacts.
+type legacyStepCompiler struct {
+	runtime.Compiler
+	client runnerclient.Client
+}
+
+func (c *legacyStepCompiler) Compile(ctx context.Context, args runtime.CompilerArgs) runtime.Spec {
+	specv := c.Compiler.Compile(ctx, args)
+
+	spec, ok := specv.(*engine.Spec)
+	if !ok {
+		return specv
+	}
+
+	// the parsed drone yaml pipeline drops unknown fields, hence the report paths are read from the raw yaml.
+	// failing to read them never fails the stage, test reports of the stage are just not collected.
+	reports, err := c.testReportPaths(ctx, args.Stage)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("stage_id", args.Stage.ID).
+			Msg("failed to read test report paths of drone yaml pipeline")
+	}
+
+	for _, step := range spec.Steps {
+		step.Labels, step.Envs = prepareStep(step.Labels, step.Envs, args.Stage.ID, reports[step.Name])
+	}
+
+	return spec
+}
+
+func (c *legacyStepCompiler) testReportPaths(ctx context.Context, stage *drone.Stage) (map[string]string, error) {
+	details, err := c.client.Detail(ctx, stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stage details: %w", err)
+	}
+	if details.Config == nil {
+		return nil, nil //nolint:nilnil // stage without config has no test reports
+	}
+
+	paths, err := droneTestReportPaths(details.Config.Data, stage.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeTestReportPaths(paths)
+}
+
+// prepareStep returns copies of the labels and envs of a compiled step, extended with
+// the stage id, the encoded test report paths (if any) and the artifacts directory.
+// The labels and envs might be shared between the steps of the stage, hence they're never modified in place.
+func prepareStep(
+	labels map[string]string,
+	envs map[string]string,
+	stageID int64,
+	reportPaths string,
+) (map[string]string, map[string]string) {
+	newLabels := make(map[string]string, len(labels)+2)
+	for k, v := range labels {
+		newLabels[k] = v
+	}
+	newLabels[labelStageID] = strconv.FormatInt(stageID, 10)
+	if reportPaths != "" {
+		newLabels[labelTestReports] = reportPaths
+	}
+
+	newEnvs := make(map[string]string, len(envs)+1)
+	for k, v := range envs {
+		newEnvs[k] = v
+	}
+	newEnvs[envArtifactsDir] = artifactsDir
+
+	return newLabels, newEnvs
+}
+
+// stepOutputCollector uploads the test reports and artifacts of a finished step.
+type stepOutputCollector struct {
+	docker   dockerclient.APIClient
+	uploader StepOutputUploader
+
+	// maxArtifactSize is the maximum size in bytes of a single artifact (0 for no limit).
+	maxArtifactSize int64
+}
+
+// collectedStep holds the properties of a compiled step needed to collect its outputs.
+type collectedStep struct {
+	containerID string
+	workingDir  string
+	labels      map[string]string
+	envs        map[string]string
+}
+
+func (c *stepOutputCollector) collect(ctx context.Context, step collectedStep, output io.Writer) {
+	stageID, err := strconv.ParseInt(step.labels[labelStageID], 10, 64)
+	if err != nil {
+		// the step wasn't compiled by the step compiler, nothing to collect.
+		return
+	}
+	stepNum, err := strconv.Atoi(step.envs["DRONE_STEP_NUMBER"])
+	if err != nil {
+		_, _ = fmt.Fprintf(output, "failed to collect step outputs: invalid step number: %s\n", err)
+		return
+	}
+
+	// failing to collect outputs never fails the step, the problem is surfaced in the step logs.
+	if uploadErr := c.uploadTestReports(ctx, step, stageID, stepNum); uploadErr != nil {
+		_, _ = fmt.Fprintf(output, "failed to upload test reports: %s\n", uploadErr)
+	}
+	if uploadErr := c.uploadArtifacts(ctx, step, stageID, stepNum); uploadErr != nil {
+		_, _ = fmt.Fprintf(output, "failed to upload artifacts: %s\n", uploadErr)
+	}
+}
+
+// stepEngine wraps the pipeline engine and uploads the test reports
+// and artifacts of a step once the step finished.
+type stepEngine struct {
+	engine2.Engine
+	collector *stepOutputCollector
+}
+
+func (e *stepEngine) Run(
+	ctx context.Context,
+	spec *engine2.Spec,
+	step *engine2.Step,
+	output io.Writer,
+) (*engine2.State, error) {
+	state, err := e.Engine.Run(ctx, spec, step, output)
+	if err != nil || state == nil {
+		return state, err
+	}
+
+	e.collector.collect(ctx, collectedStep{
+		containerID: step.ID,
+		workingDir:  step.WorkingDir,
+		labels:      step.Labels,
+		envs:        step.Envs,
+	}, output)
+
+	return state, nil
+}
+
+// legacyStepEngine wraps the drone yaml pipeline engine and uploads the test reports
+// and artifacts of a step once the step finished.
+type legacyStepEngine struct {
+	runtime.Engine
+	collector *stepOutputCollector
+}
+
+func (e *legacyStepEngine) Run(
+	ctx context.Context,
+	specv runtime.Spec,
+	stepv runtime.Step,
+	output io.Writer,
+) (*runtime.State, error) {
+	state, err := e.Engine.Run(ctx, specv, stepv, output)
+	if err != nil || state == nil {
+		return state, err
+	}
+
+	step, ok := stepv.(*engine.Step)
+	if !ok {
+		return state, nil
+	}
+
+	e.collector.collect(ctx, collectedStep{
+		containerID: step.ID,
+		workingDir:  step.WorkingDir,
+		labels:      step.Labels,
+		envs:        step.Envs,
+	}, output)
+
+	return state, nil
+}
+
+// walkContainerFiles copies the path out of the container and calls fn for each regular file
+// accepted by match. The name passed to match and fn is the absolute path of the file in the container.
+func (c *stepOutputCollector) walkContainerFiles(
+	ctx context.Context,
+	containerID string,
+	srcPath string,
+	match func(name string, size int64) (bool, error),
+	fn func(name string, r io.Reader) error,
+) error {
+	rc, _, err := c.docker.CopyFromContainer(ctx, containerID, srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %q from container: %w", srcPath, err)
+	}
+	defer rc.Close()
+
+	var errs []error
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive of %q: %w", srcPath, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// archive entries are relative to the parent of the copied path.
+		name := path.Join(path.Dir(srcPath), hdr.Name)
+		ok, err := match(name, hdr.Size)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if err := fn(name, tr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -15,6 +15,7 @@
 package runner
 
 import (
+	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/types"
 
@@ -35,8 +36,9 @@ func ProvideExecutionRunner(
 	config *types.Config,
 	client runnerclient.Client,
 	resolver *resolver.Manager,
+	executionManager manager.ExecutionManager,
 ) (*runtime2.Runner, error) {
-	return NewExecutionRunner(config, client, resolver)
+	return NewExecutionRunner(config, client, resolver, executionManager)
 }
 
 // ProvideExecutionPoller provides a poller which can poll the manager
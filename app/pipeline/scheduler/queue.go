@@ -142,6 +142,10 @@ func (q *queue) signal(ctx context.Context) error {
 		if item.Machine != "" {
 			continue
 		}
+		// approval stages wait for a decision and are never executed by a runner.
+		if item.Type == types.StageTypeApproval {
+			continue
+		}
 
 		// if the stage defines concurrency limits we
 		// need to make sure those limits are not exceeded
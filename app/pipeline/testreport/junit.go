@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxMessageLen = 1024
+	maxDetailsLen = 16 * 1024
+)
+
+// ErrUnsupportedReport is returned if the report isn't a JUnit XML report.
+var ErrUnsupportedReport = errors.New("unsupported test report format")
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *junitFailure `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// ParseJUnit parses a JUnit/xUnit XML report. The root element of the report can be
+// either a <testsuites> or a single <testsuite> element, suites can be nested.
+// The returned test cases only have their result fields set.
+func ParseJUnit(r io.Reader) ([]*types.TestCase, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test report: %w", err)
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []junitSuite
+	switch root {
+	case "testsuites":
+		var dst junitSuites
+		if err := xml.Unmarshal(data, &dst); err != nil {
+			return nil, fmt.Errorf("failed to parse test report: %w", err)
+		}
+		suites = dst.Suites
+	case "testsuite":
+		var dst junitSuite
+		if err := xml.Unmarshal(data, &dst); err != nil {
+			return nil, fmt.Errorf("failed to parse test report: %w", err)
+		}
+		suites = []junitSuite{dst}
+	default:
+		return nil, fmt.Errorf("%w: root element %q", ErrUnsupportedReport, root)
+	}
+
+	var cases []*types.TestCase
+	for i := range suites {
+		cases = appendSuite(cases, &suites[i])
+	}
+
+	return cases, nil
+}
+
+func appendSuite(cases []*types.TestCase, suite *junitSuite) []*types.TestCase {
+	for i := range suite.Cases {
+		cases = append(cases, convertCase(suite.Name, &suite.Cases[i]))
+	}
+	for i := range suite.Suites {
+		cases = appendSuite(cases, &suite.Suites[i])
+	}
+	return cases
+}
+
+func convertCase(suite string, src *junitCase) *types.TestCase {
+	dst := &types.TestCase{
+		Suite:     suite,
+		ClassName: src.ClassName,
+		Name:      src.Name,
+		Status:    enum.TestStatusPassed,
+		Duration:  parseDuration(src.Time),
+	}
+
+	var result *junitFailure
+	switch {
+	case src.Failure != nil:
+		dst.Status = enum.TestStatusFailed
+		result = src.Failure
+	case src.Error != nil:
+		dst.Status = enum.TestStatusFailed
+		result = src.Error
+	case src.Skipped != nil:
+		dst.Status = enum.TestStatusSkipped
+		result = src.Skipped
+	}
+
+	if result != nil {
+		message := result.Message
+		if message == "" {
+			message = result.Type
+		}
+		dst.Message = truncate(strings.TrimSpace(message), maxMessageLen)
+		dst.Details = truncate(strings.TrimSpace(result.Body), maxDetailsLen)
+	}
+
+	return dst
+}
+
+// rootElement returns the name of the first element of the XML document.
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("%w: no root element", ErrUnsupportedReport)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse test report: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// parseDuration converts the duration in seconds to milliseconds. Invalid durations are ignored.
+func parseDuration(s string) int64 {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return 0
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return 0
+	}
+	return int64(math.Round(seconds * 1000))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	// make sure not to cut a multi-byte character in half.
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
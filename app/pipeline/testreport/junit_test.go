@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const testSuitesReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="math" tests="3">
+    <testcase name="TestAdd" classname="math.Add" time="0.012"/>
+    <testcase name="TestDiv" classname="math.Div" time="1.5">
+      <failure message="division by zero" type="AssertionError">expected 1, got 0
+at math_test.go:12</failure>
+    </testcase>
+    <testcase name="TestPow" classname="math.Pow">
+      <skipped message="not implemented"/>
+    </testcase>
+    <testsuite name="math/big">
+      <testcase name="TestBig" classname="math.Big" time="0">
+        <error type="panic"/>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func TestParseJUnit(t *testing.T) {
+	cases, err := ParseJUnit(strings.NewReader(testSuitesReport))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		suite    string
+		name     string
+		status   enum.TestStatus
+		duration int64
+		message  string
+	}{
+		{suite: "math", name: "TestAdd", status: enum.TestStatusPassed, duration: 12},
+		{suite: "math", name: "TestDiv", status: enum.TestStatusFailed, duration: 1500, message: "division by zero"},
+		{suite: "math", name: "TestPow", status: enum.TestStatusSkipped, message: "not implemented"},
+		{suite: "math/big", name: "TestBig", status: enum.TestStatusFailed, message: "panic"},
+	}
+	if len(cases) != len(want) {
+		t.Fatalf("expected %d test cases, got %d", len(want), len(cases))
+	}
+	for i, w := range want {
+		got := cases[i]
+		if got.Suite != w.suite || got.Name != w.name || got.Status != w.status ||
+			got.Duration != w.duration || got.Message != w.message {
+			t.Errorf("test case %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if !strings.HasPrefix(cases[1].Details, "expected 1, got 0") {
+		t.Errorf("expected failure details to be set, got %q", cases[1].Details)
+	}
+}
+
+func TestParseJUnitSingleSuite(t *testing.T) {
+	report := `<testsuite name="unit"><testcase name="a" time="2"/><testcase name="b" time="1,000.5"/></testsuite>`
+	cases, err := ParseJUnit(strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cases) != 2 || cases[0].Duration != 2000 || cases[1].Duration != 1000500 {
+		t.Errorf("unexpected test cases %+v", cases)
+	}
+}
+
+func TestParseJUnitInvalid(t *testing.T) {
+	if _, err := ParseJUnit(strings.NewReader(`<coverage/>`)); !errors.Is(err, ErrUnsupportedReport) {
+		t.Errorf("expected unsupported report error, got %v", err)
+	}
+	if _, err := ParseJUnit(strings.NewReader(``)); !errors.Is(err, ErrUnsupportedReport) {
+		t.Errorf("expected unsupported report error for empty report, got %v", err)
+	}
+	if _, err := ParseJUnit(strings.NewReader(`<testsuite><testcase>`)); err == nil {
+		t.Error("expected malformed report to be rejected")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("héllo", 2); got != "h" {
+		t.Errorf("expected multi-byte character not to be cut, got %q", got)
+	}
+	if got := truncate("hello", 10); got != "hello" {
+		t.Errorf("expected short string to be unchanged, got %q", got)
+	}
+}
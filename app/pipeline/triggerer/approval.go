@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-yaml/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// approvalConfig is the approval section of a drone yaml pipeline of type approval, e.g.
+//
+//	kind: pipeline
+//	type: approval
+//	name: deploy-approval
+//	approval:
+//	  approvers: [admin]
+//	  user_groups: [release-managers]
+//	  permission: pipeline_execute
+//	  timeout: 24h
+//
+// The approvers are controlled by whoever changes the yaml, hence the principal that triggered
+// the execution is never allowed to approve it, no matter whether it's listed as approver.
+type approvalConfig struct {
+	Approvers  []string `yaml:"approvers"`
+	UserGroups []string `yaml:"user_groups"`
+	Permission string   `yaml:"permission"`
+	Timeout    string   `yaml:"timeout"`
+}
+
+// parseApprovalConfigs returns the approval configuration of all approval pipelines
+// in the drone yaml, keyed by pipeline name.
+func parseApprovalConfigs(data string) (map[string]*approvalConfig, error) {
+	resources, err := yaml.ParseRawString(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse raw yaml: %w", err)
+	}
+
+	configs := map[string]*approvalConfig{}
+	for _, resource := range resources {
+		if resource.Kind != "pipeline" || resource.Type != types.StageTypeApproval {
+			continue
+		}
+
+		var pipeline struct {
+			Name     string         `yaml:"name"`
+			Approval approvalConfig `yaml:"approval"`
+		}
+		if err = yamlv3.Unmarshal(resource.Data, &pipeline); err != nil {
+			return nil, fmt.Errorf("could not parse approval of pipeline %q: %w", pipeline.Name, err)
+		}
+
+		name := pipeline.Name
+		if name == "" {
+			name = "default"
+		}
+		configs[name] = &pipeline.Approval
+	}
+
+	return configs, nil
+}
+
+// newStageApproval validates the approval configuration and returns a pending approval for the stage.
+func newStageApproval(name string, config *approvalConfig, now int64) (*types.StageApproval, error) {
+	if config == nil {
+		config = &approvalConfig{}
+	}
+
+	permission := enum.PermissionPipelineExecute
+	if config.Permission != "" {
+		permission = enum.Permission(config.Permission)
+	}
+	switch permission {
+	case enum.PermissionPipelineView, enum.PermissionPipelineEdit,
+		enum.PermissionPipelineDelete, enum.PermissionPipelineExecute:
+	default:
+		return nil, fmt.Errorf("approval of stage %q has an unsupported permission %q", name, config.Permission)
+	}
+
+	var timeout time.Duration
+	if config.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(config.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("approval of stage %q has an invalid timeout %q", name, config.Timeout)
+		}
+	}
+
+	return &types.StageApproval{
+		Approvers:  config.Approvers,
+		UserGroups: config.UserGroups,
+		Permission: permission,
+		Timeout:    timeout.Milliseconds(),
+		Decision:   enum.StageApprovalDecisionPending,
+		Created:    now,
+		Updated:    now,
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-yaml/yaml"
+	"github.com/drone/drone-yaml/yaml/linter"
+)
+
+const approvalYAML = `kind: pipeline
+type: docker
+name: build
+
+steps:
+- name: test
+  image: golang
+---
+kind: pipeline
+type: approval
+name: deploy-approval
+
+approval:
+  approvers: [admin]
+  user_groups: [release-managers]
+  permission: pipeline_edit
+  timeout: 2h
+
+depends_on: [build]
+`
+
+func TestParseApprovalConfigs(t *testing.T) {
+	manifest, err := yaml.ParseString(approvalYAML)
+	if err != nil {
+		t.Fatalf("failed to parse yaml: %v", err)
+	}
+	if err = linter.Manifest(manifest, true); err != nil {
+		t.Fatalf("approval pipeline fails linting: %v", err)
+	}
+
+	configs, err := parseApprovalConfigs(approvalYAML)
+	if err != nil {
+		t.Fatalf("parseApprovalConfigs() error = %v", err)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("parseApprovalConfigs() returned %d configs, want 1", len(configs))
+	}
+
+	config, ok := configs["deploy-approval"]
+	if !ok {
+		t.Fatalf("parseApprovalConfigs() didn't return the config of the approval pipeline")
+	}
+
+	approval, err := newStageApproval("deploy-approval", config, 1000)
+	if err != nil {
+		t.Fatalf("newStageApproval() error = %v", err)
+	}
+	if len(approval.Approvers) != 1 || approval.Approvers[0] != "admin" {
+		t.Errorf("unexpected approvers %v", approval.Approvers)
+	}
+	if len(approval.UserGroups) != 1 || approval.UserGroups[0] != "release-managers" {
+		t.Errorf("unexpected user groups %v", approval.UserGroups)
+	}
+	if approval.Permission != enum.PermissionPipelineEdit {
+		t.Errorf("unexpected permission %q", approval.Permission)
+	}
+	if approval.Timeout != 2*60*60*1000 {
+		t.Errorf("unexpected timeout %d", approval.Timeout)
+	}
+	if approval.Decision != enum.StageApprovalDecisionPending {
+		t.Errorf("unexpected decision %q", approval.Decision)
+	}
+}
+
+func TestNewStageApproval(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *approvalConfig
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:    "unsupported permission",
+			config:  &approvalConfig{Permission: "repo_push"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			config:  &approvalConfig{Timeout: "soon"},
+			wantErr: true,
+		},
+		{
+			name:    "negative timeout",
+			config:  &approvalConfig{Timeout: "-1h"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval, err := newStageApproval("approval", tt.config, 1000)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newStageApproval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && approval.Permission != enum.PermissionPipelineExecute {
+				t.Errorf("unexpected default permission %q", approval.Permission)
+			}
+		})
+	}
+}
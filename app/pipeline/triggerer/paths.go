@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-yaml/yaml"
+	"github.com/rs/zerolog/log"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// changedPathsFunc returns a function that lists the files changed between the before and after
+// commits of the hook. The files are listed only once, when first needed by a path condition.
+// The function returns nil if the changed files can't be determined.
+func (t *triggerer) changedPathsFunc(ctx context.Context, repo *types.Repository, base *Hook) func() []string {
+	var (
+		done  bool
+		paths []string
+	)
+
+	return func() []string {
+		if done {
+			return paths
+		}
+		done = true
+
+		if base.Before == "" || base.After == "" || base.Before == base.After {
+			return nil
+		}
+		if before, err := sha.New(base.Before); err != nil || before.IsNil() {
+			return nil
+		}
+
+		output, err := t.git.DiffFileNames(ctx, &git.DiffParams{
+			ReadParams: git.CreateReadParams(repo),
+			BaseRef:    base.Before,
+			HeadRef:    base.After,
+			NoRenames:  true,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("before", base.Before).
+				Str("after", base.After).
+				Msg("trigger: cannot list changed files, ignoring path conditions")
+			return nil
+		}
+
+		paths = output.Files
+		if paths == nil {
+			paths = []string{}
+		}
+
+		return paths
+	}
+}
+
+// parseV1PathConditions returns the path condition of each stage of a v1 yaml, in the order of the stages, e.g.
+//
+//	when:
+//	  paths:
+//	    include: [services/api/**]
+//	    exclude: ["**/*.md"]
+//
+// Stages whose when clause is an expression have an empty condition.
+func parseV1PathConditions(data []byte) ([]yaml.Condition, error) {
+	var config struct {
+		Spec struct {
+			Stages []struct {
+				When yamlv3.Node `yaml:"when"`
+			} `yaml:"stages"`
+		} `yaml:"spec"`
+	}
+	if err := yamlv3.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("could not parse v1 yaml stage conditions: %w", err)
+	}
+
+	conditions := make([]yaml.Condition, len(config.Spec.Stages))
+	for i, stage := range config.Spec.Stages {
+		if stage.When.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		var when struct {
+			Paths yaml.Condition `yaml:"paths"`
+		}
+		if err := stage.When.Decode(&when); err != nil {
+			return nil, fmt.Errorf("could not parse path condition of stage %d: %w", i+1, err)
+		}
+		conditions[i] = when.Paths
+	}
+
+	return conditions, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"slices"
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+const v1PathsYAML = `version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: api
+    type: ci
+    when:
+      paths:
+        include: [services/api/**]
+        exclude: ["**/*.md"]
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang
+          script: go test ./...
+  - name: web
+    type: ci
+    when: <+ trigger.event == "push" >
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: node
+          script: npm test
+`
+
+func TestParseV1PathConditions(t *testing.T) {
+	// the path conditions must not break parsing the yaml into the spec.
+	if _, err := v1yaml.ParseString(v1PathsYAML); err != nil {
+		t.Fatalf("failed to parse v1 yaml: %v", err)
+	}
+
+	conditions, err := parseV1PathConditions([]byte(v1PathsYAML))
+	if err != nil {
+		t.Fatalf("parseV1PathConditions() error = %v", err)
+	}
+	if len(conditions) != 2 {
+		t.Fatalf("parseV1PathConditions() returned %d conditions, want 2", len(conditions))
+	}
+
+	if !slices.Equal(conditions[0].Include, []string{"services/api/**"}) ||
+		!slices.Equal(conditions[0].Exclude, []string{"**/*.md"}) {
+		t.Errorf("unexpected condition of the first stage %+v", conditions[0])
+	}
+	if len(conditions[1].Include) != 0 || len(conditions[1].Exclude) != 0 {
+		t.Errorf("unexpected condition of the second stage %+v", conditions[1])
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/checks"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrExecutionNotDone is returned when retrying an execution that is still running.
+	ErrExecutionNotDone = errors.New("the execution is not done yet")
+
+	// ErrNothingToRetry is returned when retrying an execution without failed or cancelled stages.
+	ErrNothingToRetry = errors.New("the execution has no failed or cancelled stages")
+)
+
+// Retry creates a new execution linked to a finished one, that reuses the stages which succeeded
+// and schedules the failed or cancelled stages along with all stages that depend on them.
+//
+//nolint:gocognit // refactor if needed.
+func (t *triggerer) Retry(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	original *types.Execution,
+	principal *types.Principal,
+) (*types.Execution, error) {
+	if !original.Status.IsDone() {
+		return nil, ErrExecutionNotDone
+	}
+
+	originalStages, err := t.stageStore.ListWithSteps(ctx, original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of the execution: %w", err)
+	}
+
+	retried := stagesToRetry(originalStages)
+	if len(retried) == 0 {
+		return nil, ErrNothingToRetry
+	}
+
+	originalApprovals, err := t.approvalStore.ListByExecution(ctx, original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals of the execution: %w", err)
+	}
+	approvalsByStage := make(map[int64]*types.StageApproval, len(originalApprovals))
+	for _, approval := range originalApprovals {
+		approvalsByStage[approval.StageID] = approval
+	}
+
+	now := time.Now().UnixMilli()
+	execution := &types.Execution{
+		RepoID:       original.RepoID,
+		PipelineID:   original.PipelineID,
+		Trigger:      principal.UID,
+		CreatedBy:    principal.ID,
+		Parent:       original.Number,
+		Status:       enum.CIStatusPending,
+		Event:        original.Event,
+		Action:       original.Action,
+		Link:         original.Link,
+		Timestamp:    original.Timestamp,
+		Title:        original.Title,
+		Message:      original.Message,
+		Before:       original.Before,
+		After:        original.After,
+		Ref:          original.Ref,
+		Fork:         original.Fork,
+		Source:       original.Source,
+		Target:       original.Target,
+		Author:       original.Author,
+		AuthorName:   original.AuthorName,
+		AuthorEmail:  original.AuthorEmail,
+		AuthorAvatar: original.AuthorAvatar,
+		Params:       original.Params,
+		Cron:         original.Cron,
+		Debug:        original.Debug,
+		Sender:       principal.UID,
+		Created:      now,
+		Updated:      now,
+	}
+
+	stages := make([]*types.Stage, len(originalStages))
+	approvals := map[string]*types.StageApproval{}
+	for i, originalStage := range originalStages {
+		stage := &types.Stage{
+			RepoID:    originalStage.RepoID,
+			Number:    originalStage.Number,
+			Name:      originalStage.Name,
+			Kind:      originalStage.Kind,
+			Type:      originalStage.Type,
+			ErrIgnore: originalStage.ErrIgnore,
+			OS:        originalStage.OS,
+			Arch:      originalStage.Arch,
+			Variant:   originalStage.Variant,
+			Kernel:    originalStage.Kernel,
+			Limit:     originalStage.Limit,
+			LimitRepo: originalStage.LimitRepo,
+			OnSuccess: originalStage.OnSuccess,
+			OnFailure: originalStage.OnFailure,
+			DependsOn: originalStage.DependsOn,
+			Labels:    originalStage.Labels,
+			Created:   now,
+			Updated:   now,
+		}
+		stages[i] = stage
+
+		originalApproval := approvalsByStage[originalStage.ID]
+
+		if _, ok := retried[originalStage.Name]; !ok {
+			// the stage succeeded, the new execution reuses its results.
+			stage.Status = originalStage.Status
+			stage.Error = originalStage.Error
+			stage.ExitCode = originalStage.ExitCode
+			stage.Machine = originalStage.Machine
+			stage.Started = originalStage.Started
+			stage.Stopped = originalStage.Stopped
+			stage.Steps = make([]*types.Step, len(originalStage.Steps))
+			for j, step := range originalStage.Steps {
+				reused := *step
+				reused.ID = 0
+				reused.Version = 0
+				stage.Steps[j] = &reused
+			}
+
+			if originalApproval != nil {
+				reused := *originalApproval
+				reused.ID = 0
+				reused.Version = 0
+				approvals[stage.Name] = &reused
+			}
+			continue
+		}
+
+		stage.Status = retriedStageStatus(stage, retried)
+
+		if originalApproval == nil {
+			continue
+		}
+
+		approval := &types.StageApproval{
+			Approvers:  originalApproval.Approvers,
+			UserGroups: originalApproval.UserGroups,
+			Permission: originalApproval.Permission,
+			Timeout:    originalApproval.Timeout,
+			Decision:   enum.StageApprovalDecisionPending,
+			Created:    now,
+			Updated:    now,
+		}
+		approvals[stage.Name] = approval
+
+		if stage.Status == enum.CIStatusPending {
+			manager.BlockForApproval(stage, approval, now)
+		}
+	}
+
+	if isBlocked(stages) {
+		execution.Status = enum.CIStatusBlocked
+	}
+
+	pipeline, err = t.pipelineStore.IncrementSeqNum(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment execution sequence number: %w", err)
+	}
+	execution.Number = pipeline.Seq
+
+	err = t.createExecutionWithStages(ctx, execution, stages, approvals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retried execution: %w", err)
+	}
+
+	t.copyReusedLogs(ctx, originalStages, stages)
+	t.copyReusedResults(ctx, originalStages, stages, retried)
+
+	// try to write to check store. log on failure but don't error out the execution
+	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("retry: could not write to check store")
+	}
+
+	for _, stage := range stages {
+		if stage.Status != enum.CIStatusPending {
+			continue
+		}
+		err = t.scheduler.Schedule(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule retried stage: %w", err)
+		}
+	}
+
+	execution.Stages = stages
+
+	return execution, nil
+}
+
+// stagesToRetry returns the names of the failed or cancelled stages along with all stages depending on them.
+// Skipped stages are retried as well, they were skipped because a stage failed or the execution was cancelled.
+func stagesToRetry(stages []*types.Stage) map[string]struct{} {
+	retried := map[string]struct{}{}
+	for _, stage := range stages {
+		if stage.Status.IsFailed() || stage.Status == enum.CIStatusSkipped {
+			retried[stage.Name] = struct{}{}
+		}
+	}
+
+	// add the dependents of the retried stages until no more stages are added.
+	for n := -1; n != len(retried); {
+		n = len(retried)
+		for _, stage := range stages {
+			if _, ok := retried[stage.Name]; ok {
+				continue
+			}
+			if slices.ContainsFunc(stage.DependsOn, func(dep string) bool {
+				_, ok := retried[dep]
+				return ok
+			}) {
+				retried[stage.Name] = struct{}{}
+			}
+		}
+	}
+
+	return retried
+}
+
+// retriedStageStatus returns the initial status of a retried stage:
+// it waits for its retried dependencies, the others are complete already.
+func retriedStageStatus(stage *types.Stage, retried map[string]struct{}) enum.CIStatus {
+	for _, dep := range stage.DependsOn {
+		if _, ok := retried[dep]; ok {
+			return enum.CIStatusWaitingOnDeps
+		}
+	}
+	return enum.CIStatusPending
+}
+
+// copyReusedLogs copies the logs of the reused steps, so they are available in the retried execution.
+func (t *triggerer) copyReusedLogs(ctx context.Context, originalStages, stages []*types.Stage) {
+	for i, stage := range stages {
+		originalSteps := make(map[int64]*types.Step, len(originalStages[i].Steps))
+		for _, step := range originalStages[i].Steps {
+			originalSteps[step.Number] = step
+		}
+
+		for _, step := range stage.Steps {
+			originalStep, ok := originalSteps[step.Number]
+			if !ok {
+				continue
+			}
+			if err := t.copyLogs(ctx, originalStep.ID, step.ID); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("step.id", originalStep.ID).Msg("retry: could not copy step logs")
+			}
+		}
+	}
+}
+
+// copyReusedResults copies the test cases and artifacts of the reused stages,
+// so the retried execution reports the results of all its stages.
+func (t *triggerer) copyReusedResults(
+	ctx context.Context,
+	originalStages []*types.Stage,
+	stages []*types.Stage,
+	retried map[string]struct{},
+) {
+	for i, stage := range stages {
+		if _, ok := retried[stage.Name]; ok {
+			continue
+		}
+
+		originalStage := originalStages[i]
+		err := t.testCaseStore.CopyStage(ctx, originalStage.ID, stage.ExecutionID, stage.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", originalStage.ID).Msg("retry: could not copy test cases")
+		}
+		err = t.artifactStore.CopyStage(ctx, originalStage.ID, stage.ExecutionID, stage.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", originalStage.ID).Msg("retry: could not copy artifacts")
+		}
+	}
+}
+
+func (t *triggerer) copyLogs(ctx context.Context, fromStepID, toStepID int64) error {
+	rc, err := t.logStore.Find(ctx, fromStepID)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return t.logStore.Create(ctx, toStepID, rc)
+}
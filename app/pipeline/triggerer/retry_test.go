@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStagesToRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []*types.Stage
+		want   []string
+		// waiting lists the retried stages that wait for retried dependencies.
+		waiting []string
+	}{
+		{
+			name: "all succeeded",
+			stages: []*types.Stage{
+				{Name: "build", Status: enum.CIStatusSuccess},
+				{Name: "test", Status: enum.CIStatusSuccess, DependsOn: []string{"build"}},
+			},
+		},
+		{
+			name: "failed stage and its dependents",
+			stages: []*types.Stage{
+				{Name: "build", Status: enum.CIStatusSuccess},
+				{Name: "lint", Status: enum.CIStatusSuccess},
+				{Name: "test", Status: enum.CIStatusFailure, DependsOn: []string{"build"}},
+				{Name: "publish", Status: enum.CIStatusSkipped, DependsOn: []string{"test", "lint"}},
+				{Name: "deploy", Status: enum.CIStatusSkipped, DependsOn: []string{"publish"}},
+			},
+			want:    []string{"deploy", "publish", "test"},
+			waiting: []string{"deploy", "publish"},
+		},
+		{
+			name: "dependents that ran on failure",
+			stages: []*types.Stage{
+				{Name: "build", Status: enum.CIStatusKilled},
+				{Name: "notify", Status: enum.CIStatusSuccess, DependsOn: []string{"build"}, OnFailure: true},
+				{Name: "docs", Status: enum.CIStatusSuccess},
+			},
+			want:    []string{"build", "notify"},
+			waiting: []string{"notify"},
+		},
+		{
+			name: "retried stage depending on another retried stage",
+			stages: []*types.Stage{
+				{Name: "build", Status: enum.CIStatusFailure},
+				{Name: "lint", Status: enum.CIStatusKilled},
+				{Name: "test", Status: enum.CIStatusSkipped, DependsOn: []string{"build"}},
+				{Name: "docs", Status: enum.CIStatusSuccess},
+				{Name: "publish", Status: enum.CIStatusSkipped, DependsOn: []string{"docs"}},
+			},
+			want:    []string{"build", "lint", "publish", "test"},
+			waiting: []string{"test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retried := stagesToRetry(tt.stages)
+
+			var got []string
+			for name := range retried {
+				got = append(got, name)
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("stagesToRetry() = %v, want %v", got, tt.want)
+			}
+
+			var waiting []string
+			for _, stage := range tt.stages {
+				if _, ok := retried[stage.Name]; !ok {
+					continue
+				}
+				if retriedStageStatus(stage, retried) == enum.CIStatusWaitingOnDeps {
+					waiting = append(waiting, stage.Name)
+				}
+			}
+			slices.Sort(waiting)
+
+			if !slices.Equal(waiting, tt.waiting) {
+				t.Errorf("stages waiting on dependencies = %v, want %v", waiting, tt.waiting)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package triggerer
 
 import (
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/drone/drone-yaml/yaml"
 )
 
@@ -41,3 +42,42 @@ func skipRepo(document *yaml.Pipeline, repo string) bool {
 func skipCron(document *yaml.Pipeline, cron string) bool {
 	return !document.Trigger.Cron.Match(cron)
 }
+
+// skipPaths returns true if the pipeline defines path conditions and none of the changed files match them.
+func skipPaths(document *yaml.Pipeline, changedPaths func() []string) bool {
+	return skipPathCondition(&document.Trigger.Paths, changedPaths)
+}
+
+// skipPathCondition returns true if none of the changed files is included and not excluded by the condition.
+// Nothing is skipped if the changed files aren't known, e.g. for manual or cron executions.
+func skipPathCondition(condition *yaml.Condition, changedPaths func() []string) bool {
+	if len(condition.Include) == 0 && len(condition.Exclude) == 0 {
+		return false
+	}
+
+	paths := changedPaths()
+	if paths == nil {
+		return false
+	}
+
+	for _, path := range paths {
+		if matchPath(condition.Exclude, path) {
+			continue
+		}
+		if len(condition.Include) == 0 || matchPath(condition.Include, path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchPath returns true if the path matches any of the patterns, which support '**' for any number of directories.
+func matchPath(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/drone/drone-yaml/yaml"
+)
+
+func TestSkipPathCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition yaml.Condition
+		paths     []string
+		want      bool
+	}{
+		{
+			name:  "no condition",
+			paths: []string{"README.md"},
+		},
+		{
+			name:      "unknown changed paths",
+			condition: yaml.Condition{Include: []string{"api/**"}},
+		},
+		{
+			name:      "included path changed",
+			condition: yaml.Condition{Include: []string{"services/api/**"}},
+			paths:     []string{"README.md", "services/api/cmd/main.go"},
+		},
+		{
+			name:      "no included path changed",
+			condition: yaml.Condition{Include: []string{"services/api/**"}},
+			paths:     []string{"README.md", "services/web/index.ts"},
+			want:      true,
+		},
+		{
+			name:      "no changed paths",
+			condition: yaml.Condition{Include: []string{"services/api/**"}},
+			paths:     []string{},
+			want:      true,
+		},
+		{
+			name:      "only excluded paths changed",
+			condition: yaml.Condition{Exclude: []string{"**/*.md", "docs/**"}},
+			paths:     []string{"README.md", "docs/setup.txt"},
+			want:      true,
+		},
+		{
+			name:      "not excluded path changed",
+			condition: yaml.Condition{Exclude: []string{"**/*.md"}},
+			paths:     []string{"README.md", "main.go"},
+		},
+		{
+			name: "included paths are excluded",
+			condition: yaml.Condition{
+				Include: []string{"services/api/**"},
+				Exclude: []string{"**/*.md"},
+			},
+			paths: []string{"services/api/README.md", "services/web/main.go"},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changedPaths := func() []string { return tt.paths }
+			if got := skipPathCondition(&tt.condition, changedPaths); got != tt.want {
+				t.Errorf("skipPathCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipPaths(t *testing.T) {
+	manifest, err := yaml.ParseString(`kind: pipeline
+type: docker
+name: api
+
+trigger:
+  paths:
+    include:
+    - services/api/**
+`)
+	if err != nil {
+		t.Fatalf("failed to parse yaml: %v", err)
+	}
+
+	pipeline, ok := manifest.Resources[0].(*yaml.Pipeline)
+	if !ok {
+		t.Fatalf("unexpected resource %T", manifest.Resources[0])
+	}
+
+	if skipPaths(pipeline, func() []string { return []string{"services/api/go.mod"} }) {
+		t.Errorf("pipeline skipped, but an included path changed")
+	}
+	if !skipPaths(pipeline, func() []string { return []string{"services/web/package.json"} }) {
+		t.Errorf("pipeline not skipped, but no included path changed")
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -78,12 +79,26 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Retry creates a new execution that re-runs the failed or cancelled stages of a finished execution.
+	Retry(
+		ctx context.Context,
+		pipeline *types.Pipeline,
+		execution *types.Execution,
+		principal *types.Principal,
+	) (*types.Execution, error)
 }
 
 type triggerer struct {
 	executionStore   store.ExecutionStore
 	checkStore       store.CheckStore
 	stageStore       store.StageStore
+	approvalStore    store.StageApprovalStore
+	stepStore        store.StepStore
+	logStore         store.LogStore
+	testCaseStore    store.TestCaseStore
+	artifactStore    store.ExecutionArtifactStore
+	git              git.Interface
 	tx               dbtx.Transactor
 	pipelineStore    store.PipelineStore
 	fileService      file.Service
@@ -100,6 +115,12 @@ func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	approvalStore store.StageApprovalStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	git git.Interface,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
 	repoStore store.RepoStore,
@@ -115,6 +136,12 @@ func New(
 		executionStore:   executionStore,
 		checkStore:       checkStore,
 		stageStore:       stageStore,
+		approvalStore:    approvalStore,
+		stepStore:        stepStore,
+		logStore:         logStore,
+		testCaseStore:    testCaseStore,
+		artifactStore:    artifactStore,
+		git:              git,
 		scheduler:        scheduler,
 		urlProvider:      urlProvider,
 		tx:               tx,
@@ -205,6 +232,7 @@ func (t *triggerer) Trigger(
 	// and creating stages accordingly. For V1 YAML - for now we can just parse the stages
 	// and create them sequentially.
 	stages := []*types.Stage{}
+	approvals := map[string]*types.StageApproval{}
 	//nolint:nestif // refactor if needed
 	if !isV1Yaml(file.Data) {
 		// Convert from jsonnet/starlark to drone yaml
@@ -233,6 +261,14 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		approvalConfigs, err := parseApprovalConfigs(string(file.Data))
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse approvals")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		changedPaths := t.changedPathsFunc(ctx, repo, base)
+
 		var matched []*yaml.Pipeline
 		var dag = dag.New()
 		for _, document := range manifest.Resources {
@@ -264,6 +300,8 @@ func (t *triggerer) Trigger(
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match repo")
 			case skipCron(pipeline, base.Cron):
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match cron job")
+			case skipPaths(pipeline, changedPaths):
+				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match changed paths")
 			default:
 				matched = append(matched, pipeline)
 				node.Skip = false
@@ -319,6 +357,14 @@ func (t *triggerer) Trigger(
 			if stage.Name == "" {
 				stage.Name = "default"
 			}
+			if stage.Type == types.StageTypeApproval {
+				approval, err := newStageApproval(stage.Name, approvalConfigs[stage.Name], now)
+				if err != nil {
+					log.Warn().Err(err).Msg("trigger: invalid approval")
+					return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+				}
+				approvals[stage.Name] = approval
+			}
 			if len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
@@ -338,13 +384,29 @@ func (t *triggerer) Trigger(
 				len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
+
+			// approval stages are never queued, they wait for a decision instead.
+			if approval, ok := approvals[stage.Name]; ok && stage.Status == enum.CIStatusPending {
+				manager.BlockForApproval(stage, approval, now)
+			}
+		}
+
+		if isBlocked(stages) {
+			execution.Status = enum.CIStatusBlocked
 		}
 	} else {
 		stages, err = parseV1Stages(
-			ctx, file.Data, repo, execution, t.templateStore, t.pluginStore, t.publicAccess)
+			ctx, file.Data, repo, execution, t.templateStore, t.pluginStore, t.publicAccess,
+			t.changedPathsFunc(ctx, repo, base))
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 YAML into stages: %w", err)
 		}
+
+		if len(stages) == 0 {
+			log.Info().Msg("trigger: skipping execution, no matching stages")
+			//nolint:nilnil // on purpose
+			return nil, nil
+		}
 	}
 
 	// Increment pipeline number using optimistic locking.
@@ -358,7 +420,7 @@ func (t *triggerer) Trigger(
 	execution.Number = pipeline.Seq
 	execution.Params = combine(execution.Params, Envs(ctx, repo, pipeline, t.urlProvider))
 
-	err = t.createExecutionWithStages(ctx, execution, stages)
+	err = t.createExecutionWithStages(ctx, execution, stages, approvals)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: cannot create execution")
 		return nil, err
@@ -384,6 +446,20 @@ func (t *triggerer) Trigger(
 	return execution, nil
 }
 
+// isBlocked returns true if none of the stages can be executed before an approval is decided.
+func isBlocked(stages []*types.Stage) bool {
+	blocked := false
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusPending {
+			return false
+		}
+		if stage.Status == enum.CIStatusBlocked {
+			blocked = true
+		}
+	}
+	return blocked
+}
+
 func trunc(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -396,8 +472,9 @@ func trunc(s string, i int) string {
 // if we are unable to do so or the yaml contains something unexpected.
 // Currently, all the stages will be executed one after the other on completion.
 // Once we have depends on in v1, this will be changed to use the DAG.
+// Stages whose path conditions don't match any of the changed files are skipped.
 //
-//nolint:gocognit // refactor if needed.
+//nolint:gocognit,gocyclo,cyclop // refactor if needed.
 func parseV1Stages(
 	ctx context.Context,
 	data []byte,
@@ -406,6 +483,7 @@ func parseV1Stages(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	changedPaths func() []string,
 ) ([]*types.Stage, error) {
 	stages := []*types.Stage{}
 	// For V1 YAML, just go through the YAML and create stages serially for now
@@ -414,6 +492,12 @@ func parseV1Stages(
 		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
 	}
 
+	// the spec doesn't support path conditions, they are read from the yaml directly.
+	pathConditions, err := parseV1PathConditions(data)
+	if err != nil {
+		return nil, err
+	}
+
 	// Normalize the config to make sure stage names and step names are unique
 	err = normalize.Normalize(config)
 	if err != nil {
@@ -455,6 +539,12 @@ func parseV1Stages(
 			// Only parse CI stages for now
 			switch stage.Spec.(type) {
 			case *v1yaml.StageCI:
+				if idx < len(pathConditions) && skipPathCondition(&pathConditions[idx], changedPaths) {
+					log.Ctx(ctx).Info().Str("stage", stage.Id).
+						Msg("trigger: skipping stage, does not match changed paths")
+					continue
+				}
+
 				now := time.Now().UnixMilli()
 				var onSuccess, onFailure bool
 				onSuccess = true
@@ -479,7 +569,7 @@ func parseV1Stages(
 				}
 				temp := &types.Stage{
 					RepoID:    repo.ID,
-					Number:    int64(idx + 1),
+					Number:    int64(len(stages) + 1),
 					Name:      stage.Id, // for v1, ID is the unique identifier per stage
 					Created:   now,
 					Updated:   now,
@@ -507,11 +597,13 @@ func isV1Yaml(data []byte) bool {
 	return regexp.MustCompilePOSIX(`^spec:`).Match(data)
 }
 
-// createExecutionWithStages writes an execution along with its stages in a single transaction.
+// createExecutionWithStages writes an execution along with its stages, the steps of the stages
+// which were already executed and the approvals of its approval stages in a single transaction.
 func (t *triggerer) createExecutionWithStages(
 	ctx context.Context,
 	execution *types.Execution,
 	stages []*types.Stage,
+	approvals map[string]*types.StageApproval,
 ) error {
 	return t.tx.WithTx(ctx, func(ctx context.Context) error {
 		err := t.executionStore.Create(ctx, execution)
@@ -525,6 +617,25 @@ func (t *triggerer) createExecutionWithStages(
 			if err != nil {
 				return err
 			}
+
+			for _, step := range stage.Steps {
+				step.StageID = stage.ID
+				err = t.stepStore.Create(ctx, step)
+				if err != nil {
+					return err
+				}
+			}
+
+			approval, ok := approvals[stage.Name]
+			if !ok {
+				continue
+			}
+			approval.ExecutionID = execution.ID
+			approval.StageID = stage.ID
+			err = t.approvalStore.Create(ctx, approval)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
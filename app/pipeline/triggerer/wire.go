@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
@@ -36,6 +37,12 @@ func ProvideTriggerer(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	approvalStore store.StageApprovalStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
+	testCaseStore store.TestCaseStore,
+	artifactStore store.ExecutionArtifactStore,
+	git git.Interface,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
 	fileService file.Service,
@@ -47,7 +54,7 @@ func ProvideTriggerer(
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
-		tx, repoStore, urlProvider, scheduler, fileService, converterService,
+	return New(executionStore, checkStore, stageStore, approvalStore, stepStore, logStore, testCaseStore,
+		artifactStore, git, pipelineStore, tx, repoStore, urlProvider, scheduler, fileService, converterService,
 		templateStore, pluginStore, publicAccess)
 }
@@ -291,6 +291,16 @@ func setupSpaces(
 				})
 			})
 
+			r.Route("/roles", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleCustomRoleList(spaceCtrl))
+				r.Post("/", handlerspace.HandleCustomRoleCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamCustomRoleIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleCustomRoleFind(spaceCtrl))
+					r.Patch("/", handlerspace.HandleCustomRoleUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleCustomRoleDelete(spaceCtrl))
+				})
+			})
+
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
@@ -304,6 +314,8 @@ func setupSpaces(
 			r.Route("/settings", func(r chi.Router) {
 				r.Get("/general", handlerreposettings.HandleGeneralFindSpace(repoSettingsCtrl))
 				r.Patch("/general", handlerreposettings.HandleGeneralUpdateSpace(repoSettingsCtrl))
+				r.Get("/notification", handlerreposettings.HandleNotificationFindSpace(repoSettingsCtrl))
+				r.Patch("/notification", handlerreposettings.HandleNotificationUpdateSpace(repoSettingsCtrl))
 			})
 		})
 	})
@@ -344,6 +356,8 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
+				r.Get("/failed", handlerwebhook.HandleListDeadLettersSpace(webhookCtrl))
+				r.Post("/failed/retrigger", handlerwebhook.HandleRetriggerDeadLettersSpace(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionSpace(webhookCtrl))
@@ -419,6 +433,14 @@ func setupRepos(r chi.Router,
 				r.Patch("/security", handlerreposettings.HandleSecurityUpdate(repoSettingsCtrl))
 				r.Get("/general", handlerreposettings.HandleGeneralFind(repoSettingsCtrl))
 				r.Patch("/general", handlerreposettings.HandleGeneralUpdate(repoSettingsCtrl))
+				r.Get("/notification", handlerreposettings.HandleNotificationFind(repoSettingsCtrl))
+				r.Patch("/notification", handlerreposettings.HandleNotificationUpdate(repoSettingsCtrl))
+			})
+
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindWatch(repoCtrl))
+				r.Put("/", handlerrepo.HandleUpdateWatch(repoCtrl))
+				r.Delete("/", handlerrepo.HandleDeleteWatch(repoCtrl))
 			})
 
 			r.Get("/summary", handlerrepo.HandleSummary(repoCtrl))
@@ -642,7 +664,19 @@ func setupExecutions(
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
+			r.Get("/test-results", handlerexecution.HandleListTestResults(executionCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamArtifactID),
+					handlerexecution.HandleDownloadArtifact(executionCtrl))
+			})
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Route(fmt.Sprintf("/stages/{%s}/approval", request.PathParamStageNumber), func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleFindApproval(executionCtrl))
+				r.Post("/approve", handlerexecution.HandleApprove(executionCtrl))
+				r.Post("/reject", handlerexecution.HandleReject(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -747,6 +781,17 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 
 			r.Put("/target-branch", handlerpullreq.HandleChangeTargetBranch(pullreqCtrl))
 
+			r.Route("/stack", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleStackGet(pullreqCtrl))
+				r.Put("/parent", handlerpullreq.HandleStackParentChange(pullreqCtrl))
+			})
+
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleFindWatch(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleUpdateWatch(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleDeleteWatch(pullreqCtrl))
+			})
+
 			r.Route("/file-views", func(r chi.Router) {
 				r.Put("/", handlerpullreq.HandleFileViewAdd(pullreqCtrl))
 				r.Get("/", handlerpullreq.HandleFileViewList(pullreqCtrl))
@@ -821,6 +866,8 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsRepo(webhookCtrl))
+				r.Get("/failed", handlerwebhook.HandleListDeadLettersRepo(webhookCtrl))
+				r.Post("/failed/retrigger", handlerwebhook.HandleRetriggerDeadLettersRepo(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionRepo(webhookCtrl))
@@ -906,6 +953,10 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				handleruser.HandleUpdatePublicKey(userCtrl))
 		})
 
+		// Notification preferences
+		r.Get("/notification-preferences", handleruser.HandleListNotificationPreferences(userCtrl))
+		r.Patch("/notification-preferences", handleruser.HandleUpdateNotificationPreferences(userCtrl))
+
 		// Favorites
 		r.Route("/favorite", func(r chi.Router) {
 			r.Post("/", handleruser.HandleCreateFavorite(userCtrl))
@@ -1013,6 +1064,11 @@ func setupAccountWithoutAuth(
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
+
+	r.Route("/oidc", func(r chi.Router) {
+		r.Get("/login", account.HandleOIDCLogin(userCtrl))
+		r.Get("/callback", account.HandleOIDCCallback(userCtrl, cookieName, config.URL.UI))
+	})
 }
 
 func setupAccountWithAuth(r chi.Router, userCtrl *user.Controller, config *types.Config) {
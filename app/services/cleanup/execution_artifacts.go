@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeExecutionArtifacts        = "gitness:cleanup:execution-artifacts"
+	jobCronExecutionArtifacts        = "17 * * * *" // At minute 17 past every hour.
+	jobMaxDurationExecutionArtifacts = 10 * time.Minute
+
+	// executionArtifactsBatchSize is the number of expired artifacts fetched from the DB at once.
+	executionArtifactsBatchSize = 100
+)
+
+type executionArtifactsCleanupJob struct {
+	retentionTime  time.Duration
+	retentionCount int64
+
+	executionArtifactStore store.ExecutionArtifactStore
+	blobStore              blob.Store
+}
+
+func newExecutionArtifactsCleanupJob(
+	retentionTime time.Duration,
+	retentionCount int64,
+	executionArtifactStore store.ExecutionArtifactStore,
+	blobStore blob.Store,
+) *executionArtifactsCleanupJob {
+	return &executionArtifactsCleanupJob{
+		retentionTime:  retentionTime,
+		retentionCount: retentionCount,
+
+		executionArtifactStore: executionArtifactStore,
+		blobStore:              blobStore,
+	}
+}
+
+// Handle purges execution artifacts whose execution was deleted, as well as artifacts that are past
+// the retention time or that belong to executions which aren't among the latest executions of their pipeline.
+func (j *executionArtifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	orphaned, err := j.purge(ctx, func(ctx context.Context) ([]*types.ExecutionArtifact, error) {
+		return j.executionArtifactStore.ListOrphaned(ctx, executionArtifactsBatchSize)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to purge orphaned execution artifacts: %w", err)
+	}
+
+	retention := types.ExecutionArtifactRetention{
+		KeepExecutions: j.retentionCount,
+	}
+	if j.retentionTime > 0 {
+		retention.CreatedBefore = time.Now().Add(-j.retentionTime).UnixMilli()
+	}
+
+	expired := 0
+	if retention.CreatedBefore > 0 || retention.KeepExecutions > 0 {
+		log.Ctx(ctx).Info().Msgf(
+			"start purging execution artifacts (retention time: %s, retained executions: %d)",
+			j.retentionTime,
+			j.retentionCount,
+		)
+
+		expired, err = j.purge(ctx, func(ctx context.Context) ([]*types.ExecutionArtifact, error) {
+			return j.executionArtifactStore.ListExpired(ctx, retention, executionArtifactsBatchSize)
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to purge expired execution artifacts: %w", err)
+		}
+	}
+
+	result := "no orphaned or expired execution artifacts found"
+	if orphaned+expired > 0 {
+		result = fmt.Sprintf("deleted %d orphaned and %d expired execution artifacts", orphaned, expired)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// purge deletes the artifacts returned by list together with their blobs, until list returns a partial batch.
+func (j *executionArtifactsCleanupJob) purge(
+	ctx context.Context,
+	list func(ctx context.Context) ([]*types.ExecutionArtifact, error),
+) (int, error) {
+	n := 0
+	for {
+		artifacts, err := list(ctx)
+		if err != nil {
+			return n, fmt.Errorf("failed to list execution artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			// the blob is shared with the copies of the artifact made by retried executions.
+			shared, err := j.executionArtifactStore.CountByBlobPath(ctx, artifact.BlobPath)
+			if err != nil {
+				return n, fmt.Errorf("failed to count execution artifacts of blob %q: %w", artifact.BlobPath, err)
+			}
+
+			if shared <= 1 {
+				err = j.blobStore.Delete(ctx, artifact.BlobPath)
+				if err != nil && !errors.Is(err, blob.ErrNotFound) {
+					return n, fmt.Errorf("failed to delete blob of execution artifact %d: %w", artifact.ID, err)
+				}
+			}
+
+			if err = j.executionArtifactStore.Delete(ctx, artifact.ID); err != nil {
+				return n, fmt.Errorf("failed to delete execution artifact %d: %w", artifact.ID, err)
+			}
+			n++
+		}
+
+		if len(artifacts) < executionArtifactsBatchSize {
+			return n, nil
+		}
+	}
+}
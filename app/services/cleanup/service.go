@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
@@ -30,6 +31,8 @@ type Config struct {
 	DeletedRepositoriesRetentionTime      time.Duration
 	DeletedRepositoriesCleanupCron        string
 	DeletedRepositoriesCleanupMaxDuration time.Duration
+	ExecutionArtifactsRetentionTime       time.Duration
+	ExecutionArtifactsRetentionCount      int64
 }
 
 func (c *Config) Prepare() error {
@@ -51,18 +54,24 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesCleanupMaxDuration <= 0 {
 		return errors.New("config.DeletedRepositoriesCleanupMaxDuration has to be provided")
 	}
+
+	if c.ExecutionArtifactsRetentionTime < 0 || c.ExecutionArtifactsRetentionCount < 0 {
+		return errors.New("config.ExecutionArtifactsRetention has to be non-negative")
+	}
 	return nil
 }
 
 // Service is responsible for cleaning up data in db / git / ...
 type Service struct {
-	config                Config
-	scheduler             *job.Scheduler
-	executor              *job.Executor
-	webhookExecutionStore store.WebhookExecutionStore
-	tokenStore            store.TokenStore
-	repoStore             store.RepoStore
-	repoCtrl              *repo.Controller
+	config                 Config
+	scheduler              *job.Scheduler
+	executor               *job.Executor
+	webhookExecutionStore  store.WebhookExecutionStore
+	tokenStore             store.TokenStore
+	repoStore              store.RepoStore
+	repoCtrl               *repo.Controller
+	executionArtifactStore store.ExecutionArtifactStore
+	blobStore              blob.Store
 }
 
 func NewService(
@@ -73,6 +82,8 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	executionArtifactStore store.ExecutionArtifactStore,
+	blobStore blob.Store,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -81,12 +92,14 @@ func NewService(
 	return &Service{
 		config: config,
 
-		scheduler:             scheduler,
-		executor:              executor,
-		webhookExecutionStore: webhookExecutionStore,
-		tokenStore:            tokenStore,
-		repoStore:             repoStore,
-		repoCtrl:              repoCtrl,
+		scheduler:              scheduler,
+		executor:               executor,
+		webhookExecutionStore:  webhookExecutionStore,
+		tokenStore:             tokenStore,
+		repoStore:              repoStore,
+		repoCtrl:               repoCtrl,
+		executionArtifactStore: executionArtifactStore,
+		blobStore:              blobStore,
 	}, nil
 }
 
@@ -136,6 +149,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeExecutionArtifacts,
+		jobTypeExecutionArtifacts,
+		jobCronExecutionArtifacts,
+		jobMaxDurationExecutionArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule execution artifacts cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -170,5 +194,17 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeExecutionArtifacts,
+		newExecutionArtifactsCleanupJob(
+			s.config.ExecutionArtifactsRetentionTime,
+			s.config.ExecutionArtifactsRetentionCount,
+			s.executionArtifactStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for execution artifacts cleanup: %w", err)
+	}
 	return nil
 }
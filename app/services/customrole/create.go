@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	for _, role := range enum.MembershipRoles {
+		if strings.EqualFold(in.Identifier, string(role)) {
+			return usererror.BadRequestf("Identifier '%s' is reserved for a built-in role", in.Identifier)
+		}
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	permissions, err := sanitizePermissions(in.Permissions)
+	if err != nil {
+		return err
+	}
+
+	in.Permissions = permissions
+
+	return nil
+}
+
+// sanitizePermissions returns the sorted list of unique permissions.
+// Only permissions that can be granted by a space membership are allowed.
+func sanitizePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, usererror.BadRequest("At least one permission must be provided")
+	}
+
+	allowed := enum.MembershipRoleSpaceOwner.Permissions()
+
+	result := make([]enum.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if _, ok := slices.BinarySearch(allowed, permission); !ok {
+			return nil, usererror.BadRequestf("Permission '%s' can't be granted by a membership role", permission)
+		}
+
+		result = append(result, permission)
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
+
+// Create creates a new custom role in the space.
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID int64,
+	in *CreateInput,
+) (*types.CustomRole, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	role := &types.CustomRole{
+		SpaceID:     spaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Permissions: in.Permissions,
+		CreatedBy:   principalID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := s.customRoleStore.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	return role, nil
+}
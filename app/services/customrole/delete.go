@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes the custom role defined in the space.
+// A role that is still assigned to memberships of the space or any of its subspaces can't be deleted.
+func (s *Service) Delete(ctx context.Context, spaceID int64, identifier string) error {
+	role, err := s.customRoleStore.Find(ctx, spaceID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetDescendantsIDs(ctx, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get descendant space IDs: %w", err)
+	}
+
+	count, err := s.membershipStore.CountByRole(ctx, spaceIDs, enum.MembershipRole(role.Identifier))
+	if err != nil {
+		return fmt.Errorf("failed to count memberships with custom role: %w", err)
+	}
+
+	if count > 0 {
+		return usererror.Conflict(
+			fmt.Sprintf("Custom role '%s' is assigned to %d membership(s)", role.Identifier, count))
+	}
+
+	if err = s.customRoleStore.Delete(ctx, role.ID); err != nil {
+		return fmt.Errorf("failed to delete custom role: %w", err)
+	}
+
+	return nil
+}
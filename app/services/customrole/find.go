@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find returns the custom role defined in the space.
+func (s *Service) Find(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error) {
+	role, err := s.customRoleStore.Find(ctx, spaceID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return role, nil
+}
+
+// Resolve returns the role that should be stored in a membership of the space.
+// Built-in roles are returned sanitized, custom roles must be defined in the space or any of its ancestors
+// and are returned with the identifier of the closest definition.
+func (s *Service) Resolve(
+	ctx context.Context,
+	space *types.SpaceCore,
+	role enum.MembershipRole,
+) (enum.MembershipRole, error) {
+	if builtIn, ok := role.Sanitize(); ok {
+		return builtIn, nil
+	}
+
+	customRole, err := authz.FindCustomRole(ctx, s.spaceFinder, s.customRoleStore, space, string(role))
+	if err != nil {
+		return "", fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return enum.MembershipRole(customRole.Identifier), nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// List returns the custom roles defined in the space.
+// If inherited is requested, the roles defined in the ancestor spaces are included as well.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.CustomRoleFilter,
+) ([]*types.CustomRole, error) {
+	spaceIDs := []int64{spaceID}
+
+	if filter.Inherited {
+		var err error
+		spaceIDs, err = s.spaceStore.GetAncestorIDs(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ancestor space IDs: %w", err)
+		}
+	}
+
+	roles, err := s.customRoleStore.List(ctx, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+)
+
+// Service manages the custom membership roles of spaces.
+type Service struct {
+	spaceStore      store.SpaceStore
+	spaceFinder     refcache.SpaceFinder
+	membershipStore store.MembershipStore
+	customRoleStore store.CustomRoleStore
+}
+
+func NewService(
+	spaceStore store.SpaceStore,
+	spaceFinder refcache.SpaceFinder,
+	membershipStore store.MembershipStore,
+	customRoleStore store.CustomRoleStore,
+) *Service {
+	return &Service{
+		spaceStore:      spaceStore,
+		spaceFinder:     spaceFinder,
+		membershipStore: membershipStore,
+		customRoleStore: customRoleStore,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrole
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Description *string           `json:"description,omitempty"`
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Permissions != nil {
+		permissions, err := sanitizePermissions(in.Permissions)
+		if err != nil {
+			return err
+		}
+
+		in.Permissions = permissions
+	}
+
+	return nil
+}
+
+// Update updates the description and the permissions of the custom role defined in the space.
+func (s *Service) Update(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+	in *UpdateInput,
+) (*types.CustomRole, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	role, err := s.customRoleStore.Find(ctx, spaceID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	if in.Description != nil {
+		role.Description = *in.Description
+	}
+
+	if in.Permissions != nil {
+		role.Permissions = in.Permissions
+	}
+
+	role.Updated = time.Now().UnixMilli()
+
+	if err = s.customRoleStore.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to update custom role: %w", err)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externaluser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	uidMaxLength = 64
+	uidAttempts  = 10
+)
+
+var uidInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+
+var (
+	// ErrEmailMissing is returned if the identity provider didn't provide a valid email address for a new user.
+	ErrEmailMissing = errors.New("external identity has no valid email address")
+
+	// ErrEmailConflict is returned if a user with the same email address exists,
+	// but it can't be linked to the external identity: either the identity provider didn't verify
+	// the email address, or the user is an administrator or already linked to another external identity.
+	ErrEmailConflict = errors.New("a user with the same email address already exists")
+)
+
+// Identity describes a user authenticated by an external identity provider.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	DisplayName   string
+	Username      string
+}
+
+// Provisioner creates and links users of external identity providers (just-in-time provisioning).
+type Provisioner struct {
+	tx                    dbtx.Transactor
+	principalUIDCheck     check.PrincipalUID
+	principalStore        store.PrincipalStore
+	externalIdentityStore store.ExternalIdentityStore
+	eventReporter         *userevents.Reporter
+}
+
+func NewProvisioner(
+	tx dbtx.Transactor,
+	principalUIDCheck check.PrincipalUID,
+	principalStore store.PrincipalStore,
+	externalIdentityStore store.ExternalIdentityStore,
+	eventReporter *userevents.Reporter,
+) *Provisioner {
+	return &Provisioner{
+		tx:                    tx,
+		principalUIDCheck:     principalUIDCheck,
+		principalStore:        principalStore,
+		externalIdentityStore: externalIdentityStore,
+		eventReporter:         eventReporter,
+	}
+}
+
+// FindOrCreate returns the user that is linked to the external identity.
+// On the first login of the identity, it gets linked to the existing user with the same (verified) email address,
+// or a new user is created for it. Administrators and users already linked to an external identity
+// are never linked automatically.
+func (p *Provisioner) FindOrCreate(ctx context.Context, ext *Identity) (*types.User, error) {
+	now := time.Now().UnixMilli()
+
+	identity, err := p.externalIdentityStore.Find(ctx, ext.Provider, ext.Subject)
+	if err == nil {
+		user, err := p.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user of external identity: %w", err)
+		}
+
+		if err = p.externalIdentityStore.Touch(ctx, ext.Provider, ext.Subject, now); err != nil {
+			return nil, fmt.Errorf("failed to update external identity: %w", err)
+		}
+
+		return user, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find external identity: %w", err)
+	}
+
+	ext.Email = strings.TrimSpace(ext.Email)
+	if err = check.Email(ext.Email); err != nil {
+		return nil, ErrEmailMissing
+	}
+
+	var (
+		user    *types.User
+		created bool
+	)
+
+	err = p.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = p.principalStore.FindUserByEmail(ctx, ext.Email)
+		switch {
+		case err == nil && !ext.EmailVerified:
+			return ErrEmailConflict
+		case err == nil:
+			if err = p.checkLinkable(ctx, user); err != nil {
+				return err
+			}
+
+			log.Ctx(ctx).Info().
+				Str("user_uid", user.UID).
+				Str("provider", ext.Provider).
+				Msg("linking existing user to external identity")
+		case errors.Is(err, gitness_store.ErrResourceNotFound):
+			user, err = p.createUser(ctx, ext, now)
+			if err != nil {
+				return err
+			}
+			created = true
+		default:
+			return fmt.Errorf("failed to find user by email: %w", err)
+		}
+
+		err = p.externalIdentityStore.Create(ctx, &types.ExternalIdentity{
+			Provider:    ext.Provider,
+			Subject:     ext.Subject,
+			PrincipalID: user.ID,
+			Created:     now,
+			Updated:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create external identity: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		p.eventReporter.Registered(ctx, &userevents.RegisteredPayload{
+			Base: userevents.Base{PrincipalID: user.ID},
+		})
+	}
+
+	return user, nil
+}
+
+// checkLinkable returns ErrEmailConflict if the existing user can't be linked to an external identity by email.
+func (p *Provisioner) checkLinkable(ctx context.Context, user *types.User) error {
+	if user.Admin {
+		return ErrEmailConflict
+	}
+
+	identities, err := p.externalIdentityStore.ListByPrincipal(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list external identities of user: %w", err)
+	}
+
+	if len(identities) > 0 {
+		return ErrEmailConflict
+	}
+
+	return nil
+}
+
+// createUser creates a new user for an external identity.
+// The user is created without a password, so it can't be used for the local login.
+func (p *Provisioner) createUser(ctx context.Context, ext *Identity, now int64) (*types.User, error) {
+	uid, err := p.uniqueUID(ctx, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(ext.DisplayName)
+	if check.DisplayName(displayName) != nil {
+		displayName = uid
+	}
+
+	user := &types.User{
+		UID:         uid,
+		Email:       ext.Email,
+		DisplayName: displayName,
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = p.principalStore.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// uniqueUID derives a unique principal UID for an external identity from its username or email address.
+func (p *Provisioner) uniqueUID(ctx context.Context, ext *Identity) (string, error) {
+	base := ext.Username
+	if base == "" {
+		base, _, _ = strings.Cut(ext.Email, "@")
+	}
+
+	base = strings.Trim(uidInvalidChars.ReplaceAllString(base, "-"), "-.")
+	if len(base) > uidMaxLength {
+		base = base[:uidMaxLength]
+	}
+	if p.principalUIDCheck(base) != nil {
+		base = "user"
+	}
+
+	for i := range uidAttempts {
+		uid := base
+		if i > 0 {
+			uid = fmt.Sprintf("%s-%d", base, i+1)
+		}
+
+		_, err := p.principalStore.FindByUID(ctx, uid)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return uid, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check if principal uid is available: %w", err)
+		}
+	}
+
+	return base + "-" + strings.ToLower(uniuri.NewLen(8)), nil
+}
@@ -49,6 +49,16 @@ func (s *Service) handleUpdateDefaultBranch(ctx context.Context,
 	return nil
 }
 
+func (s *Service) handleEventRepoDeleted(ctx context.Context,
+	event *events.Event[*repoevents.DeletedPayload]) error {
+	err := s.indexer.Remove(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to remove index of repo %d: %w", event.Payload.RepoID, err)
+	}
+
+	return nil
+}
+
 func (s *Service) indexRepo(
 	ctx context.Context,
 	repoID int64,
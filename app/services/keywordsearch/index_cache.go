@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// indexCache holds recently used decoded repository indexes, bounded both by the number
+// of indexes and by their estimated memory size.
+//
+// Indexes are loaded from the disk outside the cache lock. To prevent a load that raced
+// with a reindex (or a removal) from caching a stale index, every put and evict bumps the cache
+// generation, and a loaded index is cached only if the generation didn't change during the load.
+type indexCache struct {
+	root     string
+	maxCount int
+	maxBytes int64
+	maxAge   time.Duration
+
+	mx         sync.Mutex
+	entries    map[int64]*list.Element
+	lru        *list.List // of *indexCacheEntry, most recently used first
+	size       int64
+	generation uint64
+}
+
+type indexCacheEntry struct {
+	repoID  int64
+	idx     *repoIndex
+	size    int64
+	expires time.Time
+}
+
+func newIndexCache(root string, maxCount int, maxBytes int64, maxAge time.Duration) *indexCache {
+	return &indexCache{
+		root:     root,
+		maxCount: maxCount,
+		maxBytes: maxBytes,
+		maxAge:   maxAge,
+		entries:  make(map[int64]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// get returns the index of the repository, loading it from the disk if it isn't cached.
+// It returns nil without an error if the repository hasn't been indexed yet.
+func (c *indexCache) get(repoID int64) (*repoIndex, error) {
+	c.mx.Lock()
+	if elem, ok := c.entries[repoID]; ok {
+		entry := elem.Value.(*indexCacheEntry) //nolint:errcheck // the list holds only cache entries
+		if time.Now().Before(entry.expires) {
+			c.lru.MoveToFront(elem)
+			c.mx.Unlock()
+			return entry.idx, nil
+		}
+		c.remove(elem)
+	}
+	generation := c.generation
+	c.mx.Unlock()
+
+	idx, err := loadRepoIndex(c.root, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.generation == generation {
+		c.add(repoID, idx)
+	}
+
+	return idx, nil
+}
+
+// put stores the freshly built index of the repository, replacing the cached one.
+func (c *indexCache) put(repoID int64, idx *repoIndex) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.generation++
+	c.add(repoID, idx)
+}
+
+// evict drops the index of the repository from the cache.
+func (c *indexCache) evict(repoID int64) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.generation++
+	if elem, ok := c.entries[repoID]; ok {
+		c.remove(elem)
+	}
+}
+
+// add must be called with the lock held.
+func (c *indexCache) add(repoID int64, idx *repoIndex) {
+	if elem, ok := c.entries[repoID]; ok {
+		c.remove(elem)
+	}
+
+	entry := &indexCacheEntry{
+		repoID:  repoID,
+		idx:     idx,
+		size:    idx.size(),
+		expires: time.Now().Add(c.maxAge),
+	}
+
+	// an index that alone exceeds the memory limit isn't cached at all
+	if entry.size > c.maxBytes {
+		return
+	}
+
+	c.entries[repoID] = c.lru.PushFront(entry)
+	c.size += entry.size
+
+	for c.lru.Len() > c.maxCount || c.size > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove must be called with the lock held.
+func (c *indexCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*indexCacheEntry) //nolint:errcheck // the list holds only cache entries
+	delete(c.entries, entry.repoID)
+	c.size -= entry.size
+}
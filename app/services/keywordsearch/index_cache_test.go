@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIndexCache(t *testing.T) {
+	root := t.TempDir()
+
+	idx1 := newRepoIndex(1, "main", "1111111111111111111111111111111111111111")
+	idx1.add(indexedFile{Path: "a.txt", BlobSHA: "a", Content: []byte("hello world")})
+	idx2 := newRepoIndex(2, "main", "2222222222222222222222222222222222222222")
+	idx2.add(indexedFile{Path: "b.txt", BlobSHA: "b", Content: []byte("goodbye world")})
+
+	// on the disk is an outdated index of the repository 1
+	if err := saveRepoIndex(root, newRepoIndex(1, "main", "0000000000000000000000000000000000000000")); err != nil {
+		t.Fatalf("failed to save index: %v", err)
+	}
+
+	c := newIndexCache(root, 4, idx1.size()+idx2.size()-1, time.Hour)
+
+	c.put(1, idx1)
+
+	idx, err := c.get(1)
+	if err != nil {
+		t.Fatalf("failed to get index: %v", err)
+	}
+	if idx != idx1 {
+		t.Errorf("expected the put index to be served from the cache, got %+v", idx)
+	}
+
+	// both indexes don't fit into the memory limit, so the least recently used one is dropped
+	c.put(2, idx2)
+
+	if _, ok := c.entries[1]; ok {
+		t.Errorf("expected index of repo 1 to be evicted")
+	}
+	if c.size != idx2.size() {
+		t.Errorf("expected cache size %d, got %d", idx2.size(), c.size)
+	}
+
+	idx, err = c.get(1)
+	if err != nil {
+		t.Fatalf("failed to get index: %v", err)
+	}
+	if idx == nil || idx.CommitSHA != "0000000000000000000000000000000000000000" {
+		t.Errorf("expected the index to be loaded from the disk, got %+v", idx)
+	}
+
+	// an index that is larger than the memory limit isn't cached
+	c = newIndexCache(root, 4, idx1.size()-1, time.Hour)
+	c.put(1, idx1)
+
+	if c.lru.Len() != 0 || c.size != 0 {
+		t.Errorf("expected an empty cache, got %d entries of %d bytes", c.lru.Len(), c.size)
+	}
+}
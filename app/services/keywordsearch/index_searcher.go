@@ -22,9 +22,16 @@ import (
 
 type Indexer interface {
 	Index(ctx context.Context, repo *types.Repository) error
+	Remove(ctx context.Context, repoID int64) error
 }
 
 type Searcher interface {
-	Search(ctx context.Context, repoIDs []int64, query string, enableRegex bool, maxResultCount int) (
-		types.SearchResult, error)
+	Search(
+		ctx context.Context,
+		repoIDs []int64,
+		query string,
+		enableRegex bool,
+		caseSensitive bool,
+		maxResultCount int,
+	) (types.SearchResult, error)
 }
@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// indexFormatVersion is stored in every index file.
+// Index files with a different version are ignored and rebuilt from scratch.
+const indexFormatVersion = 1
+
+// binaryDetectionSize is the number of leading bytes inspected to detect binary files.
+const binaryDetectionSize = 8000
+
+// trigram is a sequence of three bytes packed into an integer.
+type trigram uint32
+
+// repoIndex is the trigram index of the default branch of a single repository.
+// Trigrams are computed on the lower-cased content, so the same index serves
+// both case-sensitive and case-insensitive queries.
+type repoIndex struct {
+	Version   int
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+
+	Files []indexedFile
+
+	// Postings maps a trigram to the sorted list of positions in Files that contain it.
+	Postings map[trigram][]uint32
+}
+
+// indexedFile is a single file of the indexed tree.
+type indexedFile struct {
+	Path    string
+	BlobSHA string
+
+	// Skipped is set for files that are not searchable (binary or too large).
+	// They are kept in the index so that they don't have to be inspected again on reindex.
+	Skipped bool
+	Content []byte
+}
+
+func newRepoIndex(repoID int64, branch, commitSHA string) *repoIndex {
+	return &repoIndex{
+		Version:   indexFormatVersion,
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Files:     nil,
+		Postings:  make(map[trigram][]uint32),
+	}
+}
+
+// add appends the file to the index and updates the posting lists.
+// Files must be added in the order of their positions, which keeps the posting lists sorted.
+func (idx *repoIndex) add(f indexedFile) {
+	pos := uint32(len(idx.Files)) //nolint:gosec // number of files is way lower than max uint32
+	idx.Files = append(idx.Files, f)
+
+	if f.Skipped {
+		return
+	}
+
+	for t := range trigramsOf(bytes.ToLower(f.Content)) {
+		idx.Postings[t] = append(idx.Postings[t], pos)
+	}
+}
+
+// blobs returns the indexed files mapped by their blob SHA.
+func (idx *repoIndex) blobs() map[string]indexedFile {
+	m := make(map[string]indexedFile, len(idx.Files))
+	for _, f := range idx.Files {
+		m[f.BlobSHA] = f
+	}
+	return m
+}
+
+// size returns the estimated memory footprint of the decoded index in bytes.
+func (idx *repoIndex) size() int64 {
+	if idx == nil {
+		return 0
+	}
+
+	// rough per-item overheads of the slice headers, strings and map entries
+	const fileOverhead, postingOverhead = 80, 48
+
+	n := int64(len(idx.Branch) + len(idx.CommitSHA))
+	for _, f := range idx.Files {
+		n += fileOverhead + int64(len(f.Path)+len(f.BlobSHA)+len(f.Content))
+	}
+	for _, list := range idx.Postings {
+		n += postingOverhead + 4*int64(cap(list))
+	}
+
+	return n
+}
+
+// candidates returns positions of files that contain all the provided trigrams.
+// If no trigrams are provided, all searchable files are returned.
+func (idx *repoIndex) candidates(trigrams []trigram) []uint32 {
+	if len(trigrams) == 0 {
+		result := make([]uint32, 0, len(idx.Files))
+		for i, f := range idx.Files {
+			if !f.Skipped {
+				result = append(result, uint32(i)) //nolint:gosec // number of files is way lower than max uint32
+			}
+		}
+		return result
+	}
+
+	// start with the shortest posting list to keep the intersection cheap
+	lists := make([][]uint32, len(trigrams))
+	for i, t := range trigrams {
+		lists[i] = idx.Postings[t]
+		if len(lists[i]) == 0 {
+			return nil
+		}
+	}
+	slices.SortFunc(lists, func(a, b []uint32) int { return len(a) - len(b) })
+
+	result := slices.Clone(lists[0])
+	for _, list := range lists[1:] {
+		result = intersect(result, list)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// intersect returns the intersection of two sorted lists. The result reuses the memory of the first list.
+func intersect(a, b []uint32) []uint32 {
+	result := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// trigramsOf returns the set of all trigrams found in the data.
+func trigramsOf(data []byte) map[trigram]struct{} {
+	set := make(map[trigram]struct{})
+	for i := 0; i+3 <= len(data); i++ {
+		set[trigram(uint32(data[i])<<16|uint32(data[i+1])<<8|uint32(data[i+2]))] = struct{}{}
+	}
+	return set
+}
+
+// isBinary reports whether the content looks like a binary file.
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), binaryDetectionSize)], 0) >= 0
+}
+
+// indexFilePath returns the location of the index file of a repository.
+func indexFilePath(root string, repoID int64) string {
+	return filepath.Join(root, fmt.Sprintf("%d.idx", repoID))
+}
+
+// loadRepoIndex reads the index of the repository from the disk.
+// It returns nil without an error if the repository hasn't been indexed yet.
+func loadRepoIndex(root string, repoID int64) (*repoIndex, error) {
+	f, err := os.Open(indexFilePath(root, repoID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil //nolint:nilnil // no index is a valid state
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader for index file: %w", err)
+	}
+	defer gz.Close()
+
+	idx := &repoIndex{}
+	if err = gob.NewDecoder(gz).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	if idx.Version != indexFormatVersion {
+		return nil, nil //nolint:nilnil // outdated index is treated as missing
+	}
+
+	return idx, nil
+}
+
+// saveRepoIndex writes the index of the repository to the disk.
+// The index is written to a temporary file first, which then atomically replaces the old index.
+func saveRepoIndex(root string, idx *repoIndex) error {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(root, fmt.Sprintf("%d.idx.*.tmp", idx.RepoID))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	if err = gob.NewEncoder(gz).Encode(idx); err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err = gz.Close(); err != nil {
+		return fmt.Errorf("failed to flush compressed index: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), indexFilePath(root, idx.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+// removeRepoIndex deletes the index of the repository from the disk.
+func removeRepoIndex(root string, repoID int64) error {
+	err := os.Remove(indexFilePath(root, repoID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove index file: %w", err)
+	}
+	return nil
+}
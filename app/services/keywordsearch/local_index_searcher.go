@@ -16,21 +16,242 @@ package keywordsearch
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
 )
 
+// defaultMaxResultCount is used if the caller doesn't limit the number of results.
+const defaultMaxResultCount = 100
+
+// indexCacheMaxAge is the duration after which a decoded index is dropped from the cache.
+const indexCacheMaxAge = 30 * time.Minute
+
+// LocalIndexSearcher maintains a trigram index of the default branch of every repository
+// in the local file system and uses it to serve keyword searches.
+// The index is updated incrementally: only blobs that changed since the last indexing are read from git.
 type LocalIndexSearcher struct {
+	git         git.Interface
+	root        string
+	maxFileSize int64
+
+	// indexes holds recently used decoded indexes, to avoid reading and decoding the index file on every query.
+	indexes *indexCache
+
+	// mxRepos holds a lock for each repository, to prevent concurrent reindexing of the same repository.
+	mxRepos sync.Map
 }
 
-func NewLocalIndexSearcher() LocalIndexSearcher {
-	return LocalIndexSearcher{}
+func NewLocalIndexSearcher(config Config, gitI git.Interface) *LocalIndexSearcher {
+	return &LocalIndexSearcher{
+		git:         gitI,
+		root:        config.IndexRoot,
+		maxFileSize: config.MaxFileSize,
+		indexes: newIndexCache(
+			config.IndexRoot, config.IndexCacheSize, config.IndexCacheMaxBytes, indexCacheMaxAge),
+	}
 }
 
-func (s LocalIndexSearcher) Search(context.Context, []int64, string, bool, int) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+func (s *LocalIndexSearcher) Search(
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	caseSensitive bool,
+	maxResultCount int,
+) (types.SearchResult, error) {
+	q, err := compileQuery(query, enableRegex, caseSensitive)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	repoIDs = slices.Clone(repoIDs)
+	slices.Sort(repoIDs)
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		idx, err := s.indexes.get(repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to load search index of repo %d: %w", repoID, err)
+		}
+		if idx == nil {
+			log.Ctx(ctx).Debug().Msgf("repository %d isn't indexed yet, skipping it in the search", repoID)
+			continue
+		}
+
+		if !q.search(idx, &result, maxResultCount) {
+			break
+		}
+	}
+
+	return result, nil
 }
 
-func (s LocalIndexSearcher) Index(context.Context, *types.Repository) error {
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	mx, _ := s.mxRepos.LoadOrStore(repo.ID, &sync.Mutex{})
+	mx.(*sync.Mutex).Lock()
+	defer mx.(*sync.Mutex).Unlock()
+
+	if repo.IsEmpty || repo.DefaultBranch == "" {
+		return s.removeIndex(repo.ID)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		return s.removeIndex(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+
+	prev, err := s.indexes.get(repo.ID)
+	if err != nil {
+		// a broken index is simply rebuilt from scratch
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to load search index of repo %d, rebuilding it", repo.ID)
+		prev = nil
+	}
+
+	if prev != nil && prev.CommitSHA == commitSHA && prev.Branch == repo.DefaultBranch {
+		return nil
+	}
+
+	var prevBlobs map[string]indexedFile
+	if prev != nil {
+		prevBlobs = prev.blobs()
+	}
+
+	treeOut, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       "",
+		Recursive:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list files of the default branch: %w", err)
+	}
+
+	idx := newRepoIndex(repo.ID, repo.DefaultBranch, commitSHA)
+
+	var reused, read int
+	for _, node := range treeOut.Nodes {
+		if node.Type != git.TreeNodeTypeBlob || node.Mode == git.TreeNodeModeSymlink {
+			continue
+		}
+
+		if f, ok := prevBlobs[node.SHA]; ok {
+			f.Path = node.Path
+			idx.add(f)
+			reused++
+			continue
+		}
+
+		f, err := s.readFile(ctx, readParams, node)
+		if err != nil {
+			return err
+		}
+
+		idx.add(f)
+		read++
+	}
+
+	if err := saveRepoIndex(s.root, idx); err != nil {
+		return fmt.Errorf("failed to save search index: %w", err)
+	}
+
+	// the new index replaces the cached one right away, so that searches don't reload it from the disk
+	s.indexes.put(repo.ID, idx)
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("commit_sha", commitSHA).
+		Int("files_reused", reused).
+		Int("files_read", read).
+		Msg("updated repository search index")
+
 	return nil
 }
+
+// Remove deletes the index of the repository.
+func (s *LocalIndexSearcher) Remove(_ context.Context, repoID int64) error {
+	mx, _ := s.mxRepos.LoadOrStore(repoID, &sync.Mutex{})
+	mx.(*sync.Mutex).Lock()
+	defer mx.(*sync.Mutex).Unlock()
+
+	err := s.removeIndex(repoID)
+
+	s.mxRepos.Delete(repoID)
+
+	return err
+}
+
+func (s *LocalIndexSearcher) removeIndex(repoID int64) error {
+	defer s.indexes.evict(repoID)
+	return removeRepoIndex(s.root, repoID)
+}
+
+func (s *LocalIndexSearcher) readFile(
+	ctx context.Context,
+	readParams git.ReadParams,
+	node git.TreeNode,
+) (indexedFile, error) {
+	f := indexedFile{
+		Path:    node.Path,
+		BlobSHA: node.SHA,
+		Skipped: true,
+		Content: nil,
+	}
+
+	blobOut, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  s.maxFileSize,
+	})
+	if err != nil {
+		return indexedFile{}, fmt.Errorf("failed to get blob of file %q: %w", node.Path, err)
+	}
+	defer blobOut.Content.Close()
+
+	if blobOut.Size > s.maxFileSize {
+		return f, nil
+	}
+
+	content, err := io.ReadAll(blobOut.Content)
+	if err != nil {
+		return indexedFile{}, fmt.Errorf("failed to read blob of file %q: %w", node.Path, err)
+	}
+
+	if isBinary(content) {
+		return f, nil
+	}
+
+	f.Skipped = false
+	f.Content = content
+
+	return f, nil
+}
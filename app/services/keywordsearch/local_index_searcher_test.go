@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// stubGit implements only the git.Interface methods used by the indexer; the embedded
+// interface leaves the rest unimplemented (they panic if called).
+type stubGit struct {
+	git.Interface
+	commitSHA string
+	files     map[string]string // path -> content, the content also acts as the blob SHA
+	blobReads int
+}
+
+func (s *stubGit) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{
+		Branch: git.Branch{Name: params.BranchName, SHA: sha.Must(s.commitSHA)},
+	}, nil
+}
+
+func (s *stubGit) ListTreeNodes(_ context.Context, _ *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	out := &git.ListTreeNodeOutput{}
+	for _, path := range slices.Sorted(maps.Keys(s.files)) {
+		out.Nodes = append(out.Nodes, git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  s.files[path],
+			Path: path,
+		})
+	}
+	return out, nil
+}
+
+func (s *stubGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	s.blobReads++
+	return &git.GetBlobOutput{
+		Size:    int64(len(params.SHA)),
+		Content: io.NopCloser(strings.NewReader(params.SHA)),
+	}, nil
+}
+
+func TestLocalIndexSearcher(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repository{ID: 7, GitUID: "uid", DefaultBranch: "main"}
+
+	gitStub := &stubGit{
+		commitSHA: "1111111111111111111111111111111111111111",
+		files: map[string]string{
+			"main.go":   "package main\n\nfunc main() {\n\tprintln(\"Hello World\")\n}\n",
+			"README.md": "# hello\nsay hello to the world\n",
+			"logo.png":  "\x89PNG\x00\x00hello",
+			"big.txt":   strings.Repeat("hello ", 100),
+		},
+	}
+
+	s := NewLocalIndexSearcher(Config{IndexRoot: t.TempDir(), MaxFileSize: 200, IndexCacheSize: 4, IndexCacheMaxBytes: 1 << 20}, gitStub)
+
+	if err := s.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to index: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		regex         bool
+		caseSensitive bool
+		wantFiles     []string
+		wantMatches   int
+	}{
+		{
+			name:        "case insensitive literal",
+			query:       "hello",
+			wantFiles:   []string{"README.md", "main.go"},
+			wantMatches: 3,
+		},
+		{
+			name:          "case sensitive literal",
+			query:         "Hello",
+			caseSensitive: true,
+			wantFiles:     []string{"main.go"},
+			wantMatches:   1,
+		},
+		{
+			name:        "regex",
+			query:       `func \w+\(`,
+			regex:       true,
+			wantFiles:   []string{"main.go"},
+			wantMatches: 1,
+		},
+		{
+			name:        "literal with regex characters",
+			query:       `main()`,
+			wantFiles:   []string{"main.go"},
+			wantMatches: 1,
+		},
+		{
+			name:      "no match",
+			query:     "goodbye",
+			wantFiles: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := s.Search(ctx, []int64{repo.ID, 8}, test.query, test.regex, test.caseSensitive, 10)
+			if err != nil {
+				t.Fatalf("failed to search: %v", err)
+			}
+
+			var files []string
+			for _, fm := range result.FileMatches {
+				if fm.RepoID != repo.ID || fm.RepoBranch != "main" {
+					t.Errorf("unexpected repo of file match: %+v", fm)
+				}
+				files = append(files, fm.FileName)
+			}
+
+			if strings.Join(files, ",") != strings.Join(test.wantFiles, ",") {
+				t.Errorf("expected files %v, got %v", test.wantFiles, files)
+			}
+			if result.Stats.TotalMatches != test.wantMatches {
+				t.Errorf("expected %d matches, got %d", test.wantMatches, result.Stats.TotalMatches)
+			}
+		})
+	}
+
+	result, err := s.Search(ctx, []int64{repo.ID}, "println", false, false, 10)
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(result.FileMatches) != 1 || len(result.FileMatches[0].Matches) != 1 {
+		t.Fatalf("expected a single match, got %+v", result)
+	}
+	match := result.FileMatches[0].Matches[0]
+	if match.LineNum != 4 || match.Before != "func main() {" || match.After != "}" {
+		t.Errorf("unexpected match: %+v", match)
+	}
+	f := match.Fragments
+	if len(f) != 1 || f[0].Pre != "\t" || f[0].Match != "println" || f[0].Post != `("Hello World")` {
+		t.Errorf("unexpected fragments: %+v", f)
+	}
+
+	// reindexing after a change reads only the new blobs
+	gitStub.blobReads = 0
+	gitStub.commitSHA = "2222222222222222222222222222222222222222"
+	gitStub.files["new.txt"] = "brand new file"
+
+	if err := s.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to reindex: %v", err)
+	}
+	if gitStub.blobReads != 1 {
+		t.Errorf("expected only a single blob read on reindex, got %d", gitStub.blobReads)
+	}
+
+	result, err = s.Search(ctx, []int64{repo.ID}, "brand", false, false, 10)
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(result.FileMatches) != 1 || result.FileMatches[0].FileName != "new.txt" {
+		t.Errorf("expected a match in the new file, got %+v", result.FileMatches)
+	}
+
+	// removed index is no longer served from the cache
+	if err := s.Remove(ctx, repo.ID); err != nil {
+		t.Fatalf("failed to remove index: %v", err)
+	}
+
+	result, err = s.Search(ctx, []int64{repo.ID}, "brand", false, false, 10)
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(result.FileMatches) != 0 {
+		t.Errorf("expected no matches after the index removal, got %+v", result.FileMatches)
+	}
+}
+
+func TestCompileQueryInvalidRegex(t *testing.T) {
+	if _, err := compileQuery("func(", true, false); err == nil {
+		t.Error("expected an error for an invalid regular expression")
+	}
+}
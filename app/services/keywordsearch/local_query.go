@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/langstats"
+	"github.com/harness/gitness/types"
+)
+
+// localQuery is a compiled search query.
+type localQuery struct {
+	re *regexp.Regexp
+
+	// trigrams are the (lower-cased) trigrams every matching file is guaranteed to contain.
+	trigrams []trigram
+}
+
+func compileQuery(query string, enableRegex, caseSensitive bool) (*localQuery, error) {
+	pattern := query
+	if !enableRegex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, errors.InvalidArgumentf("Invalid regular expression: %s", err)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.InvalidArgumentf("Invalid regular expression: %s", err)
+	}
+
+	set := make(map[trigram]struct{})
+	for _, literal := range requiredLiterals(parsed.Simplify()) {
+		for t := range trigramsOf(bytes.ToLower([]byte(literal))) {
+			set[t] = struct{}{}
+		}
+	}
+
+	trigrams := make([]trigram, 0, len(set))
+	for t := range set {
+		trigrams = append(trigrams, t)
+	}
+
+	return &localQuery{
+		re:       re,
+		trigrams: trigrams,
+	}, nil
+}
+
+// requiredLiterals returns literal strings that must be present in any text matched by the regular expression.
+// The result is not exhaustive, it is only used to narrow down the set of files that need to be scanned.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op { //nolint:exhaustive // other operators don't guarantee presence of any literal
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+		return nil
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	default:
+		return nil
+	}
+}
+
+// search scans the candidate files of the index and appends the matches to the result.
+// It returns false once the result holds maxMatches matching lines.
+func (q *localQuery) search(idx *repoIndex, result *types.SearchResult, maxMatches int) bool {
+	for _, pos := range idx.candidates(q.trigrams) {
+		f := idx.Files[pos]
+
+		matches := q.matchFile(f.Content, maxMatches-result.Stats.TotalMatches)
+		if len(matches) == 0 {
+			continue
+		}
+
+		result.FileMatches = append(result.FileMatches, types.FileMatch{
+			FileName:   f.Path,
+			RepoID:     idx.RepoID,
+			RepoPath:   "", // filled by the caller
+			RepoBranch: idx.Branch,
+			Language:   languageOf(f.Path),
+			Matches:    matches,
+		})
+		result.Stats.TotalFiles++
+		result.Stats.TotalMatches += len(matches)
+
+		if result.Stats.TotalMatches >= maxMatches {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchFile returns at most limit matching lines of the content.
+func (q *localQuery) matchFile(content []byte, limit int) []types.Match {
+	if !q.re.Match(content) {
+		return nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	var matches []types.Match
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+
+		locs := q.re.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fragments := make([]types.Fragment, 0, len(locs))
+		prev := 0
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				// ignore empty matches, they can't be highlighted anyway
+				continue
+			}
+			fragments = append(fragments, types.Fragment{
+				Pre:   line[prev:loc[0]],
+				Match: line[loc[0]:loc[1]],
+				Post:  "",
+			})
+			prev = loc[1]
+		}
+		if len(fragments) == 0 {
+			continue
+		}
+		fragments[len(fragments)-1].Post = line[prev:]
+
+		var before, after string
+		if i > 0 {
+			before = strings.TrimSuffix(lines[i-1], "\r")
+		}
+		if i < len(lines)-1 {
+			after = strings.TrimSuffix(lines[i+1], "\r")
+		}
+
+		matches = append(matches, types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+			Before:    before,
+			After:     after,
+		})
+
+		if len(matches) >= limit {
+			break
+		}
+	}
+
+	return matches
+}
+
+func languageOf(path string) string {
+	lang, _ := langstats.GetLanguageByExtension(filepath.Ext(path))
+	if lang == langstats.Unclassified {
+		return ""
+	}
+	return lang
+}
@@ -33,6 +33,15 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexRoot is the directory in which the local search index is stored.
+	IndexRoot string
+	// MaxFileSize is the size of the largest file (in bytes) that gets indexed.
+	MaxFileSize int64
+	// IndexCacheSize is the number of decoded repository indexes kept in memory.
+	IndexCacheSize int
+	// IndexCacheMaxBytes is the estimated memory size (in bytes) of all decoded repository indexes kept in memory.
+	IndexCacheMaxBytes int64
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +57,18 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexRoot == "" {
+		return errors.New("config.IndexRoot is required")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+	if c.IndexCacheSize < 1 {
+		return errors.New("config.IndexCacheSize has to be a positive number")
+	}
+	if c.IndexCacheMaxBytes < 1 {
+		return errors.New("config.IndexCacheMaxBytes has to be a positive number")
+	}
 	return nil
 }
 
@@ -106,6 +127,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterDeleted(service.handleEventRepoDeleted)
 			return nil
 		})
 	if err != nil {
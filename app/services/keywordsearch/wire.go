@@ -21,6 +21,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -48,6 +49,8 @@ func ProvideService(ctx context.Context,
 		indexer)
 }
 
-func ProvideLocalIndexSearcher() LocalIndexSearcher { return NewLocalIndexSearcher() }
-func ProvideIndexer(l LocalIndexSearcher) Indexer   { return l }
-func ProvideSearcher(l LocalIndexSearcher) Searcher { return l }
+func ProvideLocalIndexSearcher(config Config, gitI git.Interface) *LocalIndexSearcher {
+	return NewLocalIndexSearcher(config, gitI)
+}
+func ProvideIndexer(l *LocalIndexSearcher) Indexer   { return l }
+func ProvideSearcher(l *LocalIndexSearcher) Searcher { return l }
@@ -110,7 +110,7 @@ func (*Service) PreparePullReqMergeInputNoRefUpdates(
 	var author *git.Identity
 
 	switch method {
-	case enum.MergeMethodMerge:
+	case enum.MergeMethodMerge, enum.MergeMethodSemiLinear:
 		author = controller.IdentityFromPrincipalInfo(*principal)
 	case enum.MergeMethodSquash:
 		author = controller.IdentityFromPrincipalInfo(pr.Author)
@@ -123,7 +123,7 @@ func (*Service) PreparePullReqMergeInputNoRefUpdates(
 	switch method {
 	case enum.MergeMethodMerge, enum.MergeMethodSquash:
 		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
+	case enum.MergeMethodRebase, enum.MergeMethodSemiLinear:
 		committer = controller.IdentityFromPrincipalInfo(*principal)
 	case enum.MergeMethodFastForward:
 		committer = nil // Not important for fast-forward merge
@@ -131,7 +131,7 @@ func (*Service) PreparePullReqMergeInputNoRefUpdates(
 
 	if title == "" {
 		switch method {
-		case enum.MergeMethodMerge:
+		case enum.MergeMethodMerge, enum.MergeMethodSemiLinear:
 			if sourceRepo == nil {
 				title = fmt.Sprintf("Merge branch '%s' of deleted fork (#%d)",
 					pr.SourceBranch, pr.Number)
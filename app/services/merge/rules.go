@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/services/checkreq"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -99,6 +101,8 @@ func (s *Service) checkRules(
 		CheckResults:        checkResults,
 		CodeOwners:          codeOwnerWithApproval,
 		OmitMQViolations:    isMergeQueue,
+		FindUnsignedCommits: s.unsignedCommitsFinder(in.TargetRepo, in.PullReq),
+		FindChangedFiles:    s.changedFilesFinder(in.TargetRepo, in.PullReq),
 	})
 	if err != nil {
 		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -112,5 +116,86 @@ func (s *Service) checkRules(
 	}
 	violations = append(violations, checkreq.Violations(requirements.Unsatisfied(checkResults))...)
 
+	stackViolations, err := s.stackViolations(ctx, in.PullReq)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, err
+	}
+	violations = append(violations, stackViolations...)
+
 	return ruleOut, violations, nil
 }
+
+// unsignedCommitsFinder returns a function that finds the commits of the pull request without a good signature.
+// The commits are listed and verified only once, when the function is called for the first time.
+func (s *Service) unsignedCommitsFinder(
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) ([]protection.UnsignedCommit, error) {
+	var (
+		done            bool
+		unsignedCommits []protection.UnsignedCommit
+	)
+
+	return func(ctx context.Context) ([]protection.UnsignedCommit, error) {
+		if done {
+			return unsignedCommits, nil
+		}
+
+		output, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		commits := make([]*types.Commit, len(output.Commits))
+		for i := range output.Commits {
+			commits[i] = controller.MapCommit(&output.Commits[i])
+		}
+
+		err = s.signatureVerifyService.VerifyCommits(ctx, repo.ID, commits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commits: %w", err)
+		}
+
+		unsignedCommits = protection.UnsignedCommits(commits)
+		done = true
+
+		return unsignedCommits, nil
+	}
+}
+
+// changedFilesFinder returns a function that finds the paths of all files changed by the pull request.
+// The diff is calculated only once, when the function is called for the first time.
+func (s *Service) changedFilesFinder(
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) ([]string, error) {
+	var (
+		done         bool
+		changedFiles []string
+	)
+
+	return func(ctx context.Context) ([]string, error) {
+		if done {
+			return changedFiles, nil
+		}
+
+		output, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+			ReadParams: git.CreateReadParams(repo),
+			BaseRef:    pr.MergeBaseSHA,
+			HeadRef:    pr.SourceSHA,
+			NoRenames:  true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files changed by the pull request: %w", err)
+		}
+
+		changedFiles = output.Files
+		done = true
+
+		return changedFiles, nil
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/services/checkreq"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -50,6 +51,8 @@ type Service struct {
 	sseStreamer       sse.Streamer
 	instrumentation   instrument.Service
 	checkRequirements checkreq.Provider
+
+	signatureVerifyService publickey.SignatureVerifyService
 }
 
 func NewService(
@@ -69,6 +72,7 @@ func NewService(
 	sseStreamer sse.Streamer,
 	instrumentation instrument.Service,
 	checkRequirements checkreq.Provider,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Service {
 	return &Service{
 		git:               git,
@@ -87,5 +91,7 @@ func NewService(
 		sseStreamer:       sseStreamer,
 		instrumentation:   instrumentation,
 		checkRequirements: checkRequirements,
+
+		signatureVerifyService: signatureVerifyService,
 	}
 }
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	StackRuleIdentifier = "pullreq_stack"
+
+	CodeStackParentNotMerged = "pullreq.stack.parent_not_merged"
+)
+
+// stackViolations returns a violation if the pull request depends on another pull request.
+// The dependency is removed once the parent pull request is merged and this pull request is retargeted,
+// so the merge is blocked until all ancestor pull requests land.
+func (s *Service) stackViolations(ctx context.Context, pr *types.PullReq) ([]types.RuleViolations, error) {
+	if pr.StackParentID == nil {
+		return nil, nil
+	}
+
+	parent, err := s.pullreqStore.Find(ctx, *pr.StackParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stack parent pull request: %w", err)
+	}
+
+	return []types.RuleViolations{StackViolation(parent)}, nil
+}
+
+// StackViolation returns the violation blocking the merge of a pull request that depends on the provided parent.
+func StackViolation(parent *types.PullReq) types.RuleViolations {
+	violations := types.RuleViolations{
+		Rule: types.RuleInfo{
+			Identifier: StackRuleIdentifier,
+			State:      enum.RuleStateActive,
+		},
+		Bypassable: false,
+		Bypassed:   false,
+	}
+
+	switch {
+	case parent.State == enum.PullReqStateMerged:
+		violations.Addf(CodeStackParentNotMerged,
+			"Pull request #%d has been merged, waiting for this pull request to be retargeted to %q.",
+			parent.Number, parent.TargetBranch)
+	case parent.State == enum.PullReqStateClosed:
+		violations.Addf(CodeStackParentNotMerged,
+			"Pull request #%d this pull request depends on is closed. Remove the dependency to merge.",
+			parent.Number)
+	default:
+		violations.Addf(CodeStackParentNotMerged,
+			"Pull request #%d this pull request depends on must be merged first.",
+			parent.Number)
+	}
+
+	return violations
+}
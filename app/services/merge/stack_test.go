@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStackViolation(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       enum.PullReqState
+		wantMessage string
+	}{
+		{
+			name:        "parent-open",
+			state:       enum.PullReqStateOpen,
+			wantMessage: "must be merged first",
+		},
+		{
+			name:        "parent-merged",
+			state:       enum.PullReqStateMerged,
+			wantMessage: `waiting for this pull request to be retargeted to "main"`,
+		},
+		{
+			name:        "parent-closed",
+			state:       enum.PullReqStateClosed,
+			wantMessage: "Remove the dependency to merge",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parent := &types.PullReq{
+				Number:       7,
+				State:        test.state,
+				TargetBranch: "main",
+			}
+
+			violations := StackViolation(parent)
+
+			if violations.Rule.Identifier != StackRuleIdentifier {
+				t.Errorf("rule identifier mismatch: want=%s got=%s", StackRuleIdentifier, violations.Rule.Identifier)
+			}
+
+			if len(violations.Violations) != 1 {
+				t.Fatalf("expected one violation, got %d", len(violations.Violations))
+			}
+
+			v := violations.Violations[0]
+			if v.Code != CodeStackParentNotMerged {
+				t.Errorf("violation code mismatch: want=%s got=%s", CodeStackParentNotMerged, v.Code)
+			}
+
+			if !strings.Contains(v.Message, "#7") || !strings.Contains(v.Message, test.wantMessage) {
+				t.Errorf("unexpected violation message: %s", v.Message)
+			}
+
+			if !protection.IsCritical([]types.RuleViolations{violations}) {
+				t.Error("stack violation must block the merge")
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/services/checkreq"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -52,6 +53,7 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	instrumentation instrument.Service,
 	checkRequirements checkreq.Provider,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Service {
 	return NewService(
 		git,
@@ -70,5 +72,6 @@ func ProvideService(
 		sseStreamer,
 		instrumentation,
 		checkRequirements,
+		signatureVerifyService,
 	)
 }
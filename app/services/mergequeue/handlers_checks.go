@@ -96,13 +96,10 @@ func (s *Service) handlerCheckFinished(
 		return nil
 	}
 
-	// One failure is enough to remove the PR from the merge queue.
+	// One failure is enough to fail the whole merge group. A group with more than one entry is bisected
+	// to find the PR that caused the failure. Only that PR is removed from the merge queue.
 	if !status.IsSuccess() {
-		err = s.remove(ctx, entry.PullReqID, types.PullRequestActivityPayloadMergeQueueRemove{
-			Reason:          enum.MergeQueueRemovalReasonCheckFail,
-			MergeQueueCheck: event.Payload.Identifier,
-			MergeCommitSHA:  commitSHA.String(),
-		})
+		err = s.checksFailed(ctx, q, entry, commitSHA, event.Payload.Identifier, mergeQueueSetup)
 		if errors.Is(err, ErrNotInQueue) {
 			// Perhaps a check was running after the PR has already been taken from the merge queue.
 			return nil
@@ -111,13 +108,13 @@ func (s *Service) handlerCheckFinished(
 			log.Ctx(ctx).Warn().Err(err).
 				Int64("repo_id", repoID).
 				Int64("pullreq_id", entry.PullReqID).
-				Msg("failed to remove merge queue entry from merge queue")
+				Msg("failed to handle failed merge queue checks")
 			return err
 		}
 
 		err = s.reprocess(ctx, repo, q, mergeQueueSetup)
 		if err != nil {
-			return fmt.Errorf("failed to process merge queue after removing entry because failed check: %w", err)
+			return fmt.Errorf("failed to process merge queue after failed check: %w", err)
 		}
 
 		return nil
@@ -150,14 +147,20 @@ func (s *Service) handlerCheckFinished(
 	}
 
 	err = s.fastForward(ctx, q, entry)
-	if err != nil {
-		if isFastForwardError(err) {
-			// Somebody bypassed the merge queue and changed the protected branch directly, so we reset and reprocess.
-			// reset is best-effort: reprocess re-reads the live target SHA and recreates merge commits for any entry
-			// whose base no longer matches, so the queue self-heals even if this reset fails.
-			s.reset(ctx, repo, q)
-		} else {
-			return fmt.Errorf("failed to fast forward target branch to the merge commit: %w", err)
+	switch {
+	case isFastForwardError(err):
+		// Somebody bypassed the merge queue and changed the protected branch directly, so we reset and reprocess.
+		// reset is best-effort: reprocess re-reads the live target SHA and recreates merge commits for any entry
+		// whose base no longer matches, so the queue self-heals even if this reset fails.
+		s.reset(ctx, repo, q)
+	case err != nil:
+		return fmt.Errorf("failed to fast forward target branch to the merge commit: %w", err)
+	default:
+		// The merged entries might have been the passing half of a bisected merge group.
+		// Continue with bisecting the failed group that is now at the head of the merge queue.
+		err = s.bisectNext(ctx, q, mergeQueueSetup)
+		if err != nil {
+			return fmt.Errorf("failed to bisect failed merge group after fast forwarding: %w", err)
 		}
 	}
 
@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Merge queue bisection.
+//
+// Pull requests are tested in merge groups (batches) of up to protection.MergeQueueSetup.GroupSize entries.
+// The merge commit of every entry contains the changes of all entries ahead of it in the queue,
+// so the checks that run on the merge commit of the group's last entry cover the whole group.
+// When the checks of a group fail, the group is bisected: the first half is tested as a new group
+// and the second half waits in the bisect pending state. If the first half passes, it's merged
+// and the second half is bisected next. If it fails, the first half is bisected further.
+// A group with a single entry that is known to fail contains the pull request that caused the failure,
+// which is removed from the queue with enum.MergeQueueRemovalReasonCheckFail. The rest of the queue is retested.
+//
+// A failed group can only be bisected once all entries ahead of it are merged, because until then
+// the failure could have been caused by an entry ahead of it.
+
+// checksFailed handles failed checks of a merge queue entry's merge commit.
+func (s *Service) checksFailed(
+	ctx context.Context,
+	q *types.MergeQueue,
+	entry *types.MergeQueueEntry,
+	commitSHA sha.SHA,
+	checkIdentifier string,
+	setup protection.MergeQueueSetup,
+) error {
+	unlock, err := s.locker.LockBranch(ctx, q.RepoID, q.Branch, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue bisect: %w", err)
+	}
+	defer unlock()
+
+	entries, err := s.mergeQueueEntryStore.ListForMergeQueue(ctx, q.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	start, end, ok := findFailedGroup(entries, entry.PullReqID, commitSHA)
+	if !ok {
+		// The entry is no longer in the queue or its failure is already known.
+		return nil
+	}
+
+	// Other checks of the failed merge commit might still be running, but the result is already known.
+	s.stopChecks(ctx, q, commitSHA)
+
+	group := entries[start:end]
+
+	if len(group) == 1 && (start == 0 || !group[0].ChecksCommitSHA.Equal(commitSHA)) {
+		return s.removeFailed(ctx, group[0], commitSHA, checkIdentifier)
+	}
+
+	if start > 0 {
+		// Entries ahead of the group haven't been merged yet. The group waits to be bisected until they are.
+		return s.storeEntries(ctx, markBisectPending(group, commitSHA))
+	}
+
+	return s.bisect(ctx, q, group, commitSHA, setup)
+}
+
+// bisectNext bisects the failed merge group at the head of the merge queue, if there is one.
+// It's called after entries have been merged, because a failed group can be bisected only
+// when it's at the head of the queue.
+func (s *Service) bisectNext(
+	ctx context.Context,
+	q *types.MergeQueue,
+	setup protection.MergeQueueSetup,
+) error {
+	unlock, err := s.locker.LockBranch(ctx, q.RepoID, q.Branch, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue bisect: %w", err)
+	}
+	defer unlock()
+
+	entries, err := s.mergeQueueEntryStore.ListForMergeQueue(ctx, q.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	group := headBisectGroup(entries)
+	if len(group) == 0 {
+		return nil
+	}
+
+	return s.bisect(ctx, q, group, group[0].ChecksCommitSHA, setup)
+}
+
+// bisect bisects a failed merge group at the head of the merge queue.
+// Must be called while holding the branch lock.
+func (s *Service) bisect(
+	ctx context.Context,
+	q *types.MergeQueue,
+	group []*types.MergeQueueEntry,
+	failedCommitSHA sha.SHA,
+	setup protection.MergeQueueSetup,
+) error {
+	if len(group) == 1 {
+		return s.removeFailed(ctx, group[0], failedCommitSHA, s.findFailedCheck(ctx, q.RepoID, failedCommitSHA))
+	}
+
+	toStore := splitFailedGroup(group, failedCommitSHA, setup.MaxCheckDurationSeconds, time.Now().UnixMilli())
+
+	if err := s.storeEntries(ctx, toStore); err != nil {
+		return err
+	}
+
+	leader := group[len(group)/2-1]
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", q.RepoID).
+		Str("branch", q.Branch).
+		Str("failed_commit_sha", failedCommitSHA.String()).
+		Int("group_size", len(group)).
+		Int64("leader_pullreq_id", leader.PullReqID).
+		Msg("bisecting failed merge queue group")
+
+	s.startChecks(ctx, q, leader.MergeCommitSHA, group[:len(group)/2], true)
+
+	return nil
+}
+
+// removeFailed removes the entry that caused checks to fail from the merge queue.
+func (s *Service) removeFailed(
+	ctx context.Context,
+	entry *types.MergeQueueEntry,
+	failedCommitSHA sha.SHA,
+	checkIdentifier string,
+) error {
+	err := s.remove(ctx, entry.PullReqID, types.PullRequestActivityPayloadMergeQueueRemove{
+		Reason:          enum.MergeQueueRemovalReasonCheckFail,
+		MergeQueueCheck: checkIdentifier,
+		MergeCommitSHA:  failedCommitSHA.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request that failed checks from merge queue: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) storeEntries(ctx context.Context, entries []*types.MergeQueueEntry) error {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			if err := s.mergeQueueEntryStore.Update(ctx, entry); err != nil {
+				return fmt.Errorf("failed to update merge queue entry %d: %w", entry.PullReqID, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update merge queue entries: %w", err)
+	}
+
+	return nil
+}
+
+// findFailedCheck returns the identifier of a failed check of the commit. Used only for the removal activity.
+func (s *Service) findFailedCheck(ctx context.Context, repoID int64, commitSHA sha.SHA) string {
+	checks, err := s.ListChecks(ctx, repoID, commitSHA)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list checks of failed merge queue commit")
+		return ""
+	}
+
+	for _, check := range checks {
+		if check.Status.IsCompleted() && !check.Status.IsSuccess() && check.BypassedBy == nil {
+			return check.Identifier
+		}
+	}
+
+	return ""
+}
+
+// findFailedGroup finds the merge group whose checks failed for the commit SHA.
+// It returns the range of the group's entries. The entry is the one with the commit SHA as its merge commit.
+// If the commit SHA isn't the entry's checks commit (checks of a stale merge group),
+// the group consists only of the entry. Returns false if the failure doesn't need to be handled.
+func findFailedGroup(
+	entries []*types.MergeQueueEntry,
+	pullReqID int64,
+	commitSHA sha.SHA,
+) (start, end int, ok bool) {
+	index := -1
+	for i, e := range entries {
+		if e.PullReqID == pullReqID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return 0, 0, false
+	}
+
+	entry := entries[index]
+
+	if entry.State == enum.MergeQueueEntryStateBisectPending || !entry.MergeCommitSHA.Equal(commitSHA) {
+		return 0, 0, false
+	}
+
+	if !entry.ChecksCommitSHA.Equal(commitSHA) {
+		return index, index + 1, true
+	}
+
+	start = index
+	for start > 0 &&
+		entries[start-1].State == enum.MergeQueueEntryStateMergeGroup &&
+		entries[start-1].ChecksCommitSHA.Equal(commitSHA) {
+		start--
+	}
+
+	return start, index + 1, true
+}
+
+// headBisectGroup returns the failed merge group at the head of the merge queue.
+func headBisectGroup(entries []*types.MergeQueueEntry) []*types.MergeQueueEntry {
+	if len(entries) == 0 || entries[0].State != enum.MergeQueueEntryStateBisectPending {
+		return nil
+	}
+
+	failedCommitSHA := entries[0].ChecksCommitSHA
+
+	end := 1
+	for end < len(entries) &&
+		entries[end].State == enum.MergeQueueEntryStateBisectPending &&
+		entries[end].ChecksCommitSHA.Equal(failedCommitSHA) {
+		end++
+	}
+
+	return entries[:end]
+}
+
+// markBisectPending moves all entries of a failed merge group to the bisect pending state.
+func markBisectPending(group []*types.MergeQueueEntry, failedCommitSHA sha.SHA) []*types.MergeQueueEntry {
+	for _, entry := range group {
+		entry.State = enum.MergeQueueEntryStateBisectPending
+		entry.ChecksCommitSHA = failedCommitSHA
+		entry.ChecksStarted = nil
+		entry.ChecksDeadline = nil
+	}
+
+	return group
+}
+
+// splitFailedGroup splits a failed merge group in two halves. The first half becomes a new merge group
+// for which the checks should be started. The second half waits in the bisect pending state.
+// The group must have at least two entries. Returns all entries that need to be stored.
+func splitFailedGroup(
+	group []*types.MergeQueueEntry,
+	failedCommitSHA sha.SHA,
+	maxCheckDurationSeconds int,
+	now int64,
+) []*types.MergeQueueEntry {
+	half := len(group) / 2
+	first := group[:half]
+	checksCommitSHA := first[half-1].MergeCommitSHA
+
+	var deadline *int64
+	if maxCheckDurationSeconds > 0 {
+		d := now + int64(maxCheckDurationSeconds)*1000
+		deadline = &d
+	}
+
+	for i, entry := range first {
+		entry.State = enum.MergeQueueEntryStateMergeGroup
+		if i == half-1 {
+			entry.State = enum.MergeQueueEntryStateChecksInProgress
+		}
+		entry.ChecksCommitSHA = checksCommitSHA
+		entry.ChecksStarted = &now
+		entry.ChecksDeadline = deadline
+	}
+
+	markBisectPending(group[half:], failedCommitSHA)
+
+	return group
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func mergeCommitSHA(pullReqID int64) sha.SHA {
+	return sha.Must(fmt.Sprintf("%040d", pullReqID))
+}
+
+// groupEntry returns an entry of a merge group tested on the merge commit of the entry checksOf.
+func groupEntry(pullReqID int64, state enum.MergeQueueEntryState, checksOf int64) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		PullReqID:       pullReqID,
+		State:           state,
+		MergeCommitSHA:  mergeCommitSHA(pullReqID),
+		ChecksCommitSHA: mergeCommitSHA(checksOf),
+	}
+}
+
+func TestFindFailedGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []*types.MergeQueueEntry
+		pullReqID int64
+		wantStart int
+		wantEnd   int
+		wantOK    bool
+	}{
+		{
+			name: "group at head",
+			entries: []*types.MergeQueueEntry{
+				groupEntry(1, enum.MergeQueueEntryStateMergeGroup, 3),
+				groupEntry(2, enum.MergeQueueEntryStateMergeGroup, 3),
+				groupEntry(3, enum.MergeQueueEntryStateChecksInProgress, 3),
+				groupEntry(4, enum.MergeQueueEntryStateChecksInProgress, 4),
+			},
+			pullReqID: 3,
+			wantStart: 0,
+			wantEnd:   3,
+			wantOK:    true,
+		},
+		{
+			name: "group behind another group",
+			entries: []*types.MergeQueueEntry{
+				groupEntry(1, enum.MergeQueueEntryStateMergeGroup, 2),
+				groupEntry(2, enum.MergeQueueEntryStateChecksInProgress, 2),
+				groupEntry(3, enum.MergeQueueEntryStateMergeGroup, 4),
+				groupEntry(4, enum.MergeQueueEntryStateChecksInProgress, 4),
+			},
+			pullReqID: 4,
+			wantStart: 2,
+			wantEnd:   4,
+			wantOK:    true,
+		},
+		{
+			name: "stale checks of a group member",
+			entries: []*types.MergeQueueEntry{
+				groupEntry(1, enum.MergeQueueEntryStateMergeGroup, 2),
+				groupEntry(2, enum.MergeQueueEntryStateChecksInProgress, 2),
+			},
+			pullReqID: 1,
+			wantStart: 0,
+			wantEnd:   1,
+			wantOK:    true,
+		},
+		{
+			name: "failure already known",
+			entries: []*types.MergeQueueEntry{
+				groupEntry(1, enum.MergeQueueEntryStateBisectPending, 2),
+				groupEntry(2, enum.MergeQueueEntryStateBisectPending, 2),
+			},
+			pullReqID: 2,
+		},
+		{
+			name: "not in queue",
+			entries: []*types.MergeQueueEntry{
+				groupEntry(1, enum.MergeQueueEntryStateChecksInProgress, 1),
+			},
+			pullReqID: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := findFailedGroup(tt.entries, tt.pullReqID, mergeCommitSHA(tt.pullReqID))
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %t, want %t", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("range = [%d, %d), want [%d, %d)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestHeadBisectGroup(t *testing.T) {
+	entries := []*types.MergeQueueEntry{
+		groupEntry(1, enum.MergeQueueEntryStateBisectPending, 2),
+		groupEntry(2, enum.MergeQueueEntryStateBisectPending, 2),
+		groupEntry(3, enum.MergeQueueEntryStateBisectPending, 5),
+		groupEntry(4, enum.MergeQueueEntryStateChecksPending, 0),
+	}
+
+	group := headBisectGroup(entries)
+	if len(group) != 2 || group[0].PullReqID != 1 || group[1].PullReqID != 2 {
+		t.Errorf("unexpected head bisect group: %v", group)
+	}
+
+	if group := headBisectGroup(entries[3:]); group != nil {
+		t.Errorf("expected no bisect group, got %v", group)
+	}
+}
+
+func TestSplitFailedGroup(t *testing.T) {
+	const now int64 = 1_000_000
+
+	tests := []struct {
+		name       string
+		size       int
+		wantLeader int64
+	}{
+		{name: "two entries", size: 2, wantLeader: 1},
+		{name: "three entries", size: 3, wantLeader: 1},
+		{name: "four entries", size: 4, wantLeader: 2},
+		{name: "five entries", size: 5, wantLeader: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failedSHA := mergeCommitSHA(int64(tt.size))
+
+			group := make([]*types.MergeQueueEntry, tt.size)
+			for i := range group {
+				state := enum.MergeQueueEntryStateMergeGroup
+				if i == tt.size-1 {
+					state = enum.MergeQueueEntryStateChecksInProgress
+				}
+				group[i] = groupEntry(int64(i+1), state, int64(tt.size))
+			}
+
+			toStore := splitFailedGroup(group, failedSHA, 60, now)
+			if len(toStore) != tt.size {
+				t.Fatalf("toStore len = %d, want %d", len(toStore), tt.size)
+			}
+
+			for _, entry := range toStore {
+				var wantState enum.MergeQueueEntryState
+				var wantChecksSHA sha.SHA
+
+				switch {
+				case entry.PullReqID < tt.wantLeader:
+					wantState = enum.MergeQueueEntryStateMergeGroup
+					wantChecksSHA = mergeCommitSHA(tt.wantLeader)
+				case entry.PullReqID == tt.wantLeader:
+					wantState = enum.MergeQueueEntryStateChecksInProgress
+					wantChecksSHA = mergeCommitSHA(tt.wantLeader)
+				default:
+					wantState = enum.MergeQueueEntryStateBisectPending
+					wantChecksSHA = failedSHA
+				}
+
+				if entry.State != wantState {
+					t.Errorf("entry %d: state = %q, want %q", entry.PullReqID, entry.State, wantState)
+				}
+				if !entry.ChecksCommitSHA.Equal(wantChecksSHA) {
+					t.Errorf("entry %d: ChecksCommitSHA = %s, want %s",
+						entry.PullReqID, entry.ChecksCommitSHA, wantChecksSHA)
+				}
+
+				tested := entry.State != enum.MergeQueueEntryStateBisectPending
+				if tested != (entry.ChecksStarted != nil && entry.ChecksDeadline != nil) {
+					t.Errorf("entry %d: unexpected checks timestamps started=%v deadline=%v",
+						entry.PullReqID, entry.ChecksStarted, entry.ChecksDeadline)
+				}
+			}
+		})
+	}
+}
@@ -34,6 +34,8 @@ import (
 // maxInProgress limits the total number of ChecksInProgress entries (existing + newly created).
 // A value <= 0 means no limit.
 // maxCheckDurationSeconds is used to compute ChecksDeadline from now.
+// No checks are started for entries behind a failed merge group waiting to be bisected,
+// because their merge commits contain the changes that caused the failure.
 func (s *Service) updateChecks(
 	entries []*types.MergeQueueEntry,
 	groupSize int,
@@ -48,10 +50,18 @@ func (s *Service) updateChecks(
 		}
 	}
 
+	limit := len(entries)
+	for i, entry := range entries {
+		if entry.State == enum.MergeQueueEntryStateBisectPending {
+			limit = i
+			break
+		}
+	}
+
 	chainStart := -1
 
-	for i := 0; i <= len(entries); i++ {
-		isPending := i < len(entries) && entries[i].State == enum.MergeQueueEntryStateChecksPending
+	for i := 0; i <= limit; i++ {
+		isPending := i < limit && entries[i].State == enum.MergeQueueEntryStateChecksPending
 
 		if isPending && chainStart == -1 {
 			chainStart = i
@@ -107,7 +117,15 @@ func (s *Service) updateChecks(
 	return entries, toStore
 }
 
-func (s *Service) startChecks(ctx context.Context, q *types.MergeQueue, commitSHA sha.SHA) {
+// startChecks requests the merge queue checks for the merge commit of the group's last entry.
+// The bisect flag marks groups that are tested to find the pull request that caused a group to fail.
+func (s *Service) startChecks(
+	ctx context.Context,
+	q *types.MergeQueue,
+	commitSHA sha.SHA,
+	group []*types.MergeQueueEntry,
+	bisect bool,
+) {
 	if commitSHA.IsEmpty() || commitSHA.IsNil() {
 		log.Ctx(ctx).Warn().
 			Int64("repo_id", q.RepoID).
@@ -124,9 +142,21 @@ func (s *Service) startChecks(ctx context.Context, q *types.MergeQueue, commitSH
 		},
 		PrincipalID: bootstrap.NewSystemServiceSession().Principal.ID,
 		CommitSHA:   commitSHA.String(),
+		PullReqIDs:  groupPullReqIDs(group),
+		Bisect:      bisect,
 	})
 }
 
+// groupPullReqIDs returns the IDs of the pull requests in a merge group, in the merge queue order.
+func groupPullReqIDs(group []*types.MergeQueueEntry) []int64 {
+	ids := make([]int64, len(group))
+	for i, entry := range group {
+		ids[i] = entry.PullReqID
+	}
+
+	return ids
+}
+
 func (s *Service) stopChecks(ctx context.Context, q *types.MergeQueue, commitSHA sha.SHA) {
 	if commitSHA.IsEmpty() || commitSHA.IsNil() {
 		log.Ctx(ctx).Warn().
@@ -190,6 +190,18 @@ func TestUpdateChecks(t *testing.T) {
 				{3, enum.MergeQueueEntryStateChecksInProgress, 3},
 			},
 		},
+		{
+			name: "no checks behind a group waiting to be bisected",
+			entries: []*types.MergeQueueEntry{
+				pendingEntry(1),
+				nonPendingEntry(2, enum.MergeQueueEntryStateBisectPending),
+				pendingEntry(3),
+				pendingEntry(4),
+			},
+			wantStored: []wantEntry{
+				{1, enum.MergeQueueEntryStateChecksInProgress, 1},
+			},
+		},
 	}
 
 	svc := &Service{}
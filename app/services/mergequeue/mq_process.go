@@ -326,9 +326,18 @@ func (s *Service) process(
 		}
 
 		for _, entry := range entriesToUpdate {
-			if entry.State == enum.MergeQueueEntryStateChecksInProgress {
-				s.startChecks(ctx, data.queue, entry.MergeCommitSHA)
+			if entry.State != enum.MergeQueueEntryStateChecksInProgress {
+				continue
 			}
+
+			group := make([]*types.MergeQueueEntry, 0, setup.GroupSize)
+			for _, e := range entriesToUpdate {
+				if e.ChecksCommitSHA.Equal(entry.MergeCommitSHA) {
+					group = append(group, e)
+				}
+			}
+
+			s.startChecks(ctx, data.queue, entry.MergeCommitSHA, group, false)
 		}
 	}
 
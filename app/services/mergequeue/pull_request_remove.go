@@ -229,11 +229,14 @@ func (s *Service) remove(
 		// the leader orphans them. Their merge commits are still valid - only the check
 		// grouping is broken - so we move them back to ChecksPending rather than
 		// fully resetting them. Identify chain members by matching ChecksCommitSHA.
+		// The same applies to the entries of a failed merge group waiting to be bisected,
+		// because the removed entry might have been the cause of the failure.
 		if !removedEntry.ChecksCommitSHA.IsEmpty() && !removedEntry.ChecksCommitSHA.IsNil() {
 			for i := 0; i < index; i++ {
 				e := entries[i]
 
-				if e.State != enum.MergeQueueEntryStateMergeGroup {
+				if e.State != enum.MergeQueueEntryStateMergeGroup &&
+					e.State != enum.MergeQueueEntryStateBisectPending {
 					continue
 				}
 
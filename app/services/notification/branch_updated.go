@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -43,8 +44,15 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
-		return nil
+	reviewers, err = s.applyWatchesAndPreferences(
+		ctx, enum.NotificationEventBranchUpdated, payload.Base, reviewers, payload.summary(), payload.Committer.ID)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.BranchUpdatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
 	}
 
 	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
@@ -64,7 +72,7 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload],
 ) (*PullReqBranchUpdatedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
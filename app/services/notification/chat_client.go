@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// chatRequestTimeout is the maximum time a single post to a chat channel can take.
+const chatRequestTimeout = 10 * time.Second
+
+// ChatConfig contains the network restrictions for posting to chat channels.
+type ChatConfig struct {
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
+	AllowLinkLocal      bool
+}
+
+// ChatClient posts pull request notifications to the chat channels (Slack, Microsoft Teams
+// or a generic incoming-webhook) configured on the repository and on its parent spaces.
+//
+// A chat channel isn't addressed to individual recipients, hence the recipients are ignored
+// and the client posts at most one message per event:
+//   - comments are posted only via SendCommentPRAuthor,
+//   - added reviewers are posted only via SendReviewerAdded.
+//
+// Failing to post to a channel is only logged, so that a broken channel can't cause
+// repeated posts to the other channels when the event gets retried.
+type ChatClient struct {
+	settings   *settings.Service
+	httpClient *http.Client
+}
+
+func NewChatClient(config ChatConfig, settingsService *settings.Service) *ChatClient {
+	httpClient := webhook.NewHTTPClient(
+		config.AllowLoopback,
+		config.AllowPrivateNetwork,
+		config.AllowLinkLocal,
+		false,
+	)
+	httpClient.Timeout = chatRequestTimeout
+
+	return &ChatClient{
+		settings:   settingsService,
+		httpClient: httpClient,
+	}
+}
+
+// chatMessage is the channel independent representation of a notification.
+type chatMessage struct {
+	Event events.EventType
+	Base  *BasePullReqPayload
+	Text  string
+}
+
+func (c *ChatClient) SendCommentPRAuthor(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.post(ctx, chatMessage{
+		Event: pullreqevents.CommentCreatedEvent,
+		Base:  payload.Base,
+		Text:  payload.summary(),
+	})
+}
+
+func (c *ChatClient) SendCommentMentions(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendCommentParticipants(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.post(ctx, chatMessage{
+		Event: pullreqevents.ReviewerAddedEvent,
+		Base:  payload.Base,
+		Text:  payload.summary(),
+	})
+}
+
+func (c *ChatClient) SendReviewersAdded(context.Context, []*types.PrincipalInfo, *ReviewersAddedPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendUserGroupReviewerAdded(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*ReviewersAddedPayload,
+) error {
+	return nil
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.post(ctx, chatMessage{
+		Event: pullreqevents.BranchUpdatedEvent,
+		Base:  payload.Base,
+		Text:  payload.summary(),
+	})
+}
+
+func (c *ChatClient) SendReviewSubmitted(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.post(ctx, chatMessage{
+		Event: pullreqevents.ReviewSubmittedEvent,
+		Base:  payload.Base,
+		Text:  payload.summary(),
+	})
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	var event events.EventType
+	switch payload.State {
+	case PullReqStateMerged:
+		event = pullreqevents.MergedEvent
+	case PullReqStateClosed:
+		event = pullreqevents.ClosedEvent
+	case PullReqStateReopened:
+		event = pullreqevents.ReopenedEvent
+	}
+
+	return c.post(ctx, chatMessage{
+		Event: event,
+		Base:  payload.Base,
+		Text:  payload.summary(),
+	})
+}
+
+// SendDigest does nothing, digests are sent to individual recipients only.
+func (c *ChatClient) SendDigest(context.Context, []*types.PrincipalInfo, *DigestPayload) error {
+	return nil
+}
+
+// post sends the message to all chat channels of the repository.
+func (c *ChatClient) post(ctx context.Context, msg chatMessage) error {
+	channels, err := c.settings.RepoChatChannels(ctx, msg.Base.Repo.ID, msg.Base.Repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find chat channels: %w", err)
+	}
+
+	for _, channel := range channels {
+		body, err := renderChatMessage(channel.Type, msg)
+		if err != nil {
+			return fmt.Errorf("failed to render %s chat message: %w", channel.Type, err)
+		}
+
+		err = c.send(ctx, channel.URL, body)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", msg.Base.Repo.ID).
+				Str("channel_type", string(channel.Type)).
+				Msgf("failed to post %s notification to chat channel", msg.Event)
+		}
+	}
+
+	return nil
+}
+
+func (c *ChatClient) send(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain the body to allow reuse of the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("chat channel responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// renderChatMessage returns the request body of the message in the format expected by the channel type.
+func renderChatMessage(channelType enum.ChatChannelType, msg chatMessage) ([]byte, error) {
+	title := GetSubjectPullRequest(msg.Base.Repo.Identifier, msg.Base.PullReq.Number, msg.Base.PullReq.Title)
+
+	switch channelType {
+	case enum.ChatChannelTypeSlack:
+		return json.Marshal(slackMessage{
+			Text: fmt.Sprintf("<%s|%s>\n%s",
+				msg.Base.PullReqURL, escapeSlackText(title), escapeSlackText(msg.Text)),
+		})
+	case enum.ChatChannelTypeTeams:
+		return json.Marshal(teamsMessageCard{
+			Type:    "MessageCard",
+			Context: "https://schema.org/extensions",
+			Summary: title,
+			Title:   title,
+			Text:    msg.Text,
+			PotentialAction: []teamsAction{{
+				Type: "OpenUri",
+				Name: "View pull request",
+				Targets: []teamsActionTarget{{
+					OS:  "default",
+					URI: msg.Base.PullReqURL,
+				}},
+			}},
+		})
+	case enum.ChatChannelTypeGeneric:
+		return json.Marshal(genericChatMessage{
+			Event:         msg.Event,
+			RepoPath:      msg.Base.Repo.Path,
+			PullReqNumber: msg.Base.PullReq.Number,
+			PullReqTitle:  msg.Base.PullReq.Title,
+			PullReqURL:    msg.Base.PullReqURL,
+			Title:         title,
+			Text:          msg.Text,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported chat channel type %q", channelType)
+	}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// escapeSlackText escapes the control characters of Slack's mrkdwn format.
+func escapeSlackText(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+type teamsMessageCard struct {
+	Type            string        `json:"@type"`
+	Context         string        `json:"@context"`
+	Summary         string        `json:"summary"`
+	Title           string        `json:"title"`
+	Text            string        `json:"text"`
+	PotentialAction []teamsAction `json:"potentialAction"`
+}
+
+type teamsAction struct {
+	Type    string              `json:"@type"`
+	Name    string              `json:"name"`
+	Targets []teamsActionTarget `json:"targets"`
+}
+
+type teamsActionTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+type genericChatMessage struct {
+	Event         events.EventType `json:"event"`
+	RepoPath      string           `json:"repo_path"`
+	PullReqNumber int64            `json:"pullreq_number"`
+	PullReqTitle  string           `json:"pullreq_title"`
+	PullReqURL    string           `json:"pullreq_url"`
+	Title         string           `json:"title"`
+	Text          string           `json:"text"`
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+// settingsStoreStub stubs store.SettingsStore (Find only) with the repo scoped settings.
+type settingsStoreStub struct {
+	appstore.SettingsStore
+	repoSettings map[string]json.RawMessage
+}
+
+func (s *settingsStoreStub) Find(
+	_ context.Context,
+	scope enum.SettingsScope,
+	_ int64,
+	key string,
+) (json.RawMessage, error) {
+	raw, ok := s.repoSettings[key]
+	if scope != enum.SettingsScopeRepo || !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	return raw, nil
+}
+
+type chatServerStub struct {
+	mx       sync.Mutex
+	requests map[string]map[string]any // path -> request body
+}
+
+func (s *chatServerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	var m map[string]any
+	_ = json.Unmarshal(body, &m)
+
+	s.mx.Lock()
+	s.requests[r.URL.Path] = m
+	s.mx.Unlock()
+
+	if r.URL.Path == "/broken" {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func buildChatClient(t *testing.T, channels ...settings.ChatChannel) (*ChatClient, *chatServerStub) {
+	t.Helper()
+
+	server := &chatServerStub{requests: map[string]map[string]any{}}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	require.NoError(t, err)
+
+	// chat channels are stored with encrypted URLs
+	stored := make([]map[string]any, len(channels))
+	for i := range channels {
+		encryptedURL, err := encrypter.Encrypt(httpServer.URL + channels[i].URL)
+		require.NoError(t, err)
+
+		stored[i] = map[string]any{"type": channels[i].Type, "encrypted_url": encryptedURL}
+	}
+
+	raw, err := json.Marshal(stored)
+	require.NoError(t, err)
+
+	settingsService := settings.NewService(
+		&settingsStoreStub{repoSettings: map[string]json.RawMessage{string(settings.KeyChatChannels): raw}},
+		refcache.SpaceFinder{},
+		encrypter,
+	)
+
+	client := NewChatClient(ChatConfig{AllowLoopback: true}, settingsService)
+
+	return client, server
+}
+
+func TestChatClient_PostsToAllChannelsOfRepo(t *testing.T) {
+	t.Parallel()
+
+	client, server := buildChatClient(t,
+		settings.ChatChannel{Type: enum.ChatChannelTypeSlack, URL: "/slack"},
+		settings.ChatChannel{Type: enum.ChatChannelTypeTeams, URL: "/teams"},
+		settings.ChatChannel{Type: enum.ChatChannelTypeGeneric, URL: "/generic"},
+		settings.ChatChannel{Type: enum.ChatChannelTypeGeneric, URL: "/broken"},
+	)
+
+	payload := &PullReqStateChangedPayload{
+		Base:      buildBasePayload(),
+		ChangedBy: &types.PrincipalInfo{ID: 2, DisplayName: "Alice"},
+		State:     PullReqStateMerged,
+	}
+
+	// a failing channel doesn't fail the notification
+	err := client.SendPullReqStateChanged(context.Background(), nil, payload)
+	require.NoError(t, err)
+
+	require.Len(t, server.requests, 4)
+
+	require.Equal(t,
+		"<https://example.com/pr/42|[my-repo] My PR (PR #42)>\nAlice merged the pull request.",
+		server.requests["/slack"]["text"])
+
+	require.Equal(t, "MessageCard", server.requests["/teams"]["@type"])
+	require.Equal(t, "[my-repo] My PR (PR #42)", server.requests["/teams"]["title"])
+	require.Equal(t, "Alice merged the pull request.", server.requests["/teams"]["text"])
+
+	require.Equal(t, map[string]any{
+		"event":          "merged",
+		"repo_path":      "space/my-repo",
+		"pullreq_number": float64(42),
+		"pullreq_title":  "My PR",
+		"pullreq_url":    "https://example.com/pr/42",
+		"title":          "[my-repo] My PR (PR #42)",
+		"text":           "Alice merged the pull request.",
+	}, server.requests["/generic"])
+}
+
+func TestChatClient_PostsCommentOnce(t *testing.T) {
+	t.Parallel()
+
+	client, server := buildChatClient(t,
+		settings.ChatChannel{Type: enum.ChatChannelTypeGeneric, URL: "/generic"},
+	)
+
+	payload := &CommentPayload{
+		Base:      buildBasePayload(),
+		Commenter: &types.PrincipalInfo{ID: 2, DisplayName: "Alice"},
+		Text:      "looks good",
+	}
+
+	require.NoError(t, client.SendCommentMentions(context.Background(), nil, payload))
+	require.NoError(t, client.SendCommentParticipants(context.Background(), nil, payload))
+	require.Empty(t, server.requests)
+
+	require.NoError(t, client.SendCommentPRAuthor(context.Background(), nil, payload))
+	require.Len(t, server.requests, 1)
+	require.Equal(t, "Alice commented:\nlooks good", server.requests["/generic"]["text"])
+}
+
+func TestChatClient_EscapesSlackText(t *testing.T) {
+	t.Parallel()
+
+	base := buildBasePayload()
+	base.PullReq.Title = "Fix <script> & co"
+
+	body, err := renderChatMessage(enum.ChatChannelTypeSlack, chatMessage{Base: base, Text: "a < b"})
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"text":"<https://example.com/pr/42|[my-repo] Fix &lt;script&gt; &amp; co (PR #42)>\na &lt; b"}`,
+		string(body))
+}
+
+func TestFanOutClient_CallsAllClients(t *testing.T) {
+	t.Parallel()
+
+	failing := &testNotificationClient{reviewerAddedErr: errors.New("smtp unreachable")}
+	succeeding := &testNotificationClient{}
+
+	client := NewFanOutClient(failing, succeeding)
+
+	payload := &ReviewerAddedPayload{
+		Base:     buildBasePayload(),
+		Reviewer: &types.PrincipalInfo{ID: 2, DisplayName: "Alice"},
+	}
+
+	// the notification was delivered by one client, failing the event would redeliver it through both.
+	err := client.SendReviewerAdded(context.Background(), []*types.PrincipalInfo{payload.Reviewer}, payload)
+	require.NoError(t, err)
+	require.Len(t, failing.reviewerAddedCalls, 1)
+	require.Len(t, succeeding.reviewerAddedCalls, 1)
+}
+
+func TestFanOutClient_AllClientsFail(t *testing.T) {
+	t.Parallel()
+
+	mail := &testNotificationClient{reviewerAddedErr: errors.New("smtp unreachable")}
+	chat := &testNotificationClient{reviewerAddedErr: errors.New("failed to find chat settings")}
+
+	client := NewFanOutClient(mail, chat)
+
+	payload := &ReviewerAddedPayload{
+		Base:     buildBasePayload(),
+		Reviewer: &types.PrincipalInfo{ID: 2, DisplayName: "Alice"},
+	}
+
+	err := client.SendReviewerAdded(context.Background(), []*types.PrincipalInfo{payload.Reviewer}, payload)
+	require.ErrorIs(t, err, mail.reviewerAddedErr)
+	require.ErrorIs(t, err, chat.reviewerAddedErr)
+}
@@ -21,7 +21,8 @@ import (
 )
 
 // Client is an interface for sending notifications, such as emails, Slack messages etc.
-// It is implemented by MailClient and in future we can have other implementations for other channels like Slack etc.
+// It is implemented by MailClient and ChatClient, FanOutClient allows using multiple clients together.
+// NOTE: Recipients might be empty, clients that notify individual recipients shouldn't send anything in that case.
 type Client interface {
 	SendCommentPRAuthor(
 		ctx context.Context,
@@ -68,4 +69,9 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendDigest(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *DigestPayload,
+	) error
 }
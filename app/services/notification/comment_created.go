@@ -48,6 +48,24 @@ func (s *Service) notifyCommentCreated(
 		)
 	}
 
+	// the author notification is sent for every comment, even without a recipient,
+	// because channel based clients (like chat) use it to post the comment once.
+	var authorRecipients []*types.PrincipalInfo
+	if author != nil {
+		authorRecipients = []*types.PrincipalInfo{author}
+	}
+
+	mentions, participants, authorRecipients, err = s.applyCommentPreferences(
+		ctx, payload, mentions, participants, authorRecipients)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.CommentCreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	if len(mentions) > 0 {
 		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
 		if err != nil {
@@ -72,25 +90,69 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
-	if author != nil {
-		err = s.notificationClient.SendCommentPRAuthor(
-			ctx,
-			[]*types.PrincipalInfo{author},
-			payload,
+	err = s.notificationClient.SendCommentPRAuthor(ctx, authorRecipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send notification to author for event %s for pullReqID %d: %w",
+			pullreqevents.CommentCreatedEvent,
+			event.Payload.PullReqID,
+			err,
 		)
-		if err != nil {
-			return fmt.Errorf(
-				"failed to send notification to author for event %s for pullReqID %d: %w",
-				pullreqevents.CommentCreatedEvent,
-				event.Payload.PullReqID,
-				err,
-			)
-		}
 	}
 
 	return nil
 }
 
+// applyCommentPreferences adds the watchers of the pull request to the author recipients
+// and applies the notification preferences to all recipients of the comment.
+func (s *Service) applyCommentPreferences(
+	ctx context.Context,
+	payload *CommentPayload,
+	mentions []*types.PrincipalInfo,
+	participants []*types.PrincipalInfo,
+	authorRecipients []*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, []*types.PrincipalInfo, []*types.PrincipalInfo, error) {
+	states, err := s.getWatchStates(ctx, payload.Base)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	excludedIDs := make([]int64, 0, len(mentions)+len(participants)+1)
+	excludedIDs = append(excludedIDs, payload.Commenter.ID)
+	for _, p := range mentions {
+		excludedIDs = append(excludedIDs, p.ID)
+	}
+	for _, p := range participants {
+		excludedIDs = append(excludedIDs, p.ID)
+	}
+
+	authorRecipients, err = s.addWatchers(ctx, states, authorRecipients, excludedIDs...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	summary := payload.summary()
+	event := gitnessenum.NotificationEventComment
+
+	// mentions address the recipients directly, so they are sent even if the pull request got unwatched.
+	mentions, err = s.applyPreferences(ctx, event, payload.Base, nil, mentions, summary)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	participants, err = s.applyPreferences(ctx, event, payload.Base, states, participants, summary)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	authorRecipients, err = s.applyPreferences(ctx, event, payload.Base, states, authorRecipients, summary)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return mentions, participants, authorRecipients, nil
+}
+
 func (s *Service) processCommentCreatedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CommentCreatedPayload],
@@ -101,7 +163,7 @@ func (s *Service) processCommentCreatedEvent(
 	author *types.PrincipalInfo,
 	err error,
 ) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -126,13 +126,15 @@ func buildService(
 	notifClient Client,
 ) *Service {
 	return &Service{
-		repoStore:            repoStore,
-		pullReqStore:         prStore,
-		principalInfoView:    piView,
-		principalInfoCache:   piCache,
-		pullReqActivityStore: activityStore,
-		urlProvider:          &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient:   notifClient,
+		repoStore:                   repoStore,
+		pullReqStore:                prStore,
+		principalInfoView:           piView,
+		principalInfoCache:          piCache,
+		pullReqActivityStore:        activityStore,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 }
 
@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigestHourly  = "gitness:notification:digest-hourly"
+	jobTypeDigestDaily   = "gitness:notification:digest-daily"
+	jobMaxDurationDigest = 15 * time.Minute
+)
+
+// DigestPayload contains the queued notifications of a recipient, grouped by pull request.
+type DigestPayload struct {
+	Recipient *types.PrincipalInfo
+	Mode      enum.NotificationMode
+	ItemCount int
+	PullReqs  []*DigestPullReq
+}
+
+type DigestPullReq struct {
+	RepoPath  string
+	Number    int64
+	Title     string
+	URL       string
+	Summaries []string
+}
+
+func newDigestPayload(
+	recipient *types.PrincipalInfo,
+	mode enum.NotificationMode,
+	items []*types.NotificationDigestItem,
+) *DigestPayload {
+	payload := &DigestPayload{
+		Recipient: recipient,
+		Mode:      mode,
+		ItemCount: len(items),
+	}
+
+	pullReqs := make(map[int64]*DigestPullReq)
+	for _, item := range items {
+		pr, ok := pullReqs[item.PullReqID]
+		if !ok {
+			pr = &DigestPullReq{
+				RepoPath: item.RepoPath,
+				Number:   item.PullReqNumber,
+				Title:    item.PullReqTitle,
+				URL:      item.PullReqURL,
+			}
+			pullReqs[item.PullReqID] = pr
+			payload.PullReqs = append(payload.PullReqs, pr)
+		}
+
+		pr.Summaries = append(pr.Summaries, item.Summary)
+	}
+
+	return payload
+}
+
+type digestJob struct {
+	mode                    enum.NotificationMode
+	notificationDigestStore store.NotificationDigestStore
+	principalInfoCache      store.PrincipalInfoCache
+	notificationClient      Client
+}
+
+// Handle sends the queued notifications of every principal in a single digest.
+// Notifications are removed only after their digest got sent, a failed digest is retried on the next run.
+func (j *digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	principalIDs, err := j.notificationDigestStore.ListPrincipalIDs(ctx, j.mode)
+	if err != nil {
+		return "", fmt.Errorf("failed to list principals with queued notifications: %w", err)
+	}
+
+	var sent int
+	for _, principalID := range principalIDs {
+		err = j.sendDigest(ctx, principalID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal_id", principalID).
+				Msgf("failed to send %s notification digest", j.mode)
+			continue
+		}
+
+		sent++
+	}
+
+	result := fmt.Sprintf("sent %d of %d %s digests", sent, len(principalIDs), j.mode)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *digestJob) sendDigest(ctx context.Context, principalID int64) error {
+	items, err := j.notificationDigestStore.List(ctx, principalID, j.mode)
+	if err != nil {
+		return fmt.Errorf("failed to list queued notifications: %w", err)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	recipient, err := j.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient from principalInfoCache: %w", err)
+	}
+
+	payload := newDigestPayload(recipient, j.mode, items)
+
+	err = j.notificationClient.SendDigest(ctx, []*types.PrincipalInfo{recipient}, payload)
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	err = j.notificationDigestStore.DeleteMany(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to delete sent notifications: %w", err)
+	}
+
+	return nil
+}
+
+// registerDigestJobs registers and schedules the jobs sending the hourly and the daily digests.
+func (s *Service) registerDigestJobs(
+	ctx context.Context,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+) error {
+	jobs := []struct {
+		jobType string
+		cron    string
+		mode    enum.NotificationMode
+	}{
+		{jobType: jobTypeDigestHourly, cron: s.config.DigestHourlyCron, mode: enum.NotificationModeHourly},
+		{jobType: jobTypeDigestDaily, cron: s.config.DigestDailyCron, mode: enum.NotificationModeDaily},
+	}
+
+	for _, j := range jobs {
+		err := executor.Register(j.jobType, &digestJob{
+			mode:                    j.mode,
+			notificationDigestStore: s.notificationDigestStore,
+			principalInfoCache:      s.principalInfoCache,
+			notificationClient:      s.notificationClient,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register %s notification digest job: %w", j.mode, err)
+		}
+
+		err = scheduler.AddRecurring(ctx, j.jobType, j.jobType, j.cron, jobMaxDurationDigest)
+		if err != nil {
+			return fmt.Errorf("failed to schedule %s notification digest job: %w", j.mode, err)
+		}
+	}
+
+	return nil
+}
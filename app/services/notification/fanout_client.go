@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ Client = FanOutClient{}
+
+// FanOutClient forwards every notification to all of its clients, e.g. to send both emails and chat messages.
+// All clients are called even if some of them fail. Failures are only returned if no client delivered
+// the notification, as the redelivery of the event would otherwise send it again through the other clients.
+type FanOutClient struct {
+	clients []Client
+}
+
+func NewFanOutClient(clients ...Client) FanOutClient {
+	return FanOutClient{
+		clients: clients,
+	}
+}
+
+func fanOut[T any](
+	ctx context.Context,
+	clients []Client,
+	recipients []*types.PrincipalInfo,
+	payload T,
+	send func(Client, context.Context, []*types.PrincipalInfo, T) error,
+) error {
+	var errs []error
+	for _, client := range clients {
+		if err := send(client, ctx, recipients, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == len(clients) {
+		return errors.Join(errs...)
+	}
+
+	for _, err := range errs {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to send notification through one of the clients")
+	}
+
+	return nil
+}
+
+func (f FanOutClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendCommentPRAuthor)
+}
+
+func (f FanOutClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendCommentMentions)
+}
+
+func (f FanOutClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendCommentParticipants)
+}
+
+func (f FanOutClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendReviewerAdded)
+}
+
+func (f FanOutClient) SendReviewersAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewersAddedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendReviewersAdded)
+}
+
+func (f FanOutClient) SendUserGroupReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewersAddedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendUserGroupReviewerAdded)
+}
+
+func (f FanOutClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendPullReqBranchUpdated)
+}
+
+func (f FanOutClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendReviewSubmitted)
+}
+
+func (f FanOutClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendPullReqStateChanged)
+}
+
+func (f FanOutClient) SendDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DigestPayload,
+) error {
+	return fanOut(ctx, f.clients, recipients, payload, Client.SendDigest)
+}
@@ -33,6 +33,7 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateDigest               = "digest.html"
 )
 
 type MailClient struct {
@@ -61,7 +62,7 @@ func (m MailClient) SendCommentPRAuthor(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 func (m MailClient) SendCommentMentions(
 	ctx context.Context,
@@ -79,7 +80,7 @@ func (m MailClient) SendCommentMentions(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 func (m MailClient) SendCommentParticipants(
 	ctx context.Context,
@@ -97,7 +98,7 @@ func (m MailClient) SendCommentParticipants(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendReviewerAdded(
@@ -116,7 +117,7 @@ func (m MailClient) SendReviewerAdded(
 			pullreqevents.ReviewerAddedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendReviewersAdded(
@@ -134,7 +135,7 @@ func (m MailClient) SendReviewersAdded(
 		return fmt.Errorf("failed to generate mail requests after processing reviewers added event: %w", err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendUserGroupReviewerAdded(
@@ -153,7 +154,7 @@ func (m MailClient) SendUserGroupReviewerAdded(
 			pullreqevents.UserGroupReviewerAdded, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendPullReqBranchUpdated(
@@ -172,7 +173,7 @@ func (m MailClient) SendPullReqBranchUpdated(
 			pullreqevents.BranchUpdatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendReviewSubmitted(
@@ -188,7 +189,7 @@ func (m MailClient) SendReviewSubmitted(
 			err,
 		)
 	}
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendPullReqStateChanged(
@@ -204,6 +205,34 @@ func (m MailClient) SendPullReqStateChanged(
 		)
 	}
 
+	return m.send(ctx, email)
+}
+
+func (m MailClient) SendDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DigestPayload,
+) error {
+	body, err := GetHTMLBody(TemplateDigest, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate %s digest mail: %w", payload.Mode, err)
+	}
+
+	email := &mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectDigest, payload.Mode, payload.ItemCount),
+		Body:         string(body),
+	}
+
+	return m.send(ctx, email)
+}
+
+// send sends the email, unless all of its recipients got filtered out by their notification preferences.
+func (m MailClient) send(ctx context.Context, email *mailer.Payload) error {
+	if len(email.ToRecipients) == 0 {
+		return nil
+	}
+
 	return m.Mailer.Send(ctx, *email)
 }
 
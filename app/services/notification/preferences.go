@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// watchStates maps principal IDs to the watch state of a pull request:
+// true if the principal watches it, false if the principal explicitly unwatched it.
+type watchStates map[int64]bool
+
+// getWatchStates returns the watch states of the pull request.
+// Watches of the pull request take precedence over the watches of its repository.
+func (s *Service) getWatchStates(ctx context.Context, base *BasePullReqPayload) (watchStates, error) {
+	watches, err := s.notificationWatchStore.ListForPullReq(ctx, base.Repo.ID, base.PullReq.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification watches: %w", err)
+	}
+
+	states := make(watchStates, len(watches))
+	for _, watch := range watches {
+		if watch.PullReqID == 0 {
+			states[watch.PrincipalID] = watch.Watching
+		}
+	}
+	for _, watch := range watches {
+		if watch.PullReqID != 0 {
+			states[watch.PrincipalID] = watch.Watching
+		}
+	}
+
+	return states, nil
+}
+
+// addWatchers returns the recipients extended with all users watching the pull request.
+// Principals that are already recipients or whose IDs are excluded are not added.
+func (s *Service) addWatchers(
+	ctx context.Context,
+	states watchStates,
+	recipients []*types.PrincipalInfo,
+	excludedIDs ...int64,
+) ([]*types.PrincipalInfo, error) {
+	skip := make(map[int64]bool, len(recipients)+len(excludedIDs))
+	for _, recipient := range recipients {
+		skip[recipient.ID] = true
+	}
+	for _, id := range excludedIDs {
+		skip[id] = true
+	}
+
+	var watcherIDs []int64
+	for id, watching := range states {
+		if watching && !skip[id] {
+			watcherIDs = append(watcherIDs, id)
+		}
+	}
+	if len(watcherIDs) == 0 {
+		return recipients, nil
+	}
+
+	slices.Sort(watcherIDs)
+
+	watchers, err := s.principalInfoCache.Map(ctx, watcherIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watchers from principalInfoCache: %w", err)
+	}
+
+	for _, id := range watcherIDs {
+		watcher, ok := watchers[id]
+		if !ok || watcher.Type != enum.PrincipalTypeUser {
+			continue
+		}
+		recipients = append(recipients, watcher)
+	}
+
+	return recipients, nil
+}
+
+// applyWatchesAndPreferences adds the watchers of the pull request, except the actor, to the recipients
+// and applies the notification preferences of all recipients for the event.
+func (s *Service) applyWatchesAndPreferences(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	base *BasePullReqPayload,
+	recipients []*types.PrincipalInfo,
+	summary string,
+	actorID int64,
+) ([]*types.PrincipalInfo, error) {
+	states, err := s.getWatchStates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err = s.addWatchers(ctx, states, recipients, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyPreferences(ctx, event, base, states, recipients, summary)
+}
+
+// applyPreferences removes recipients who unwatched the pull request or who don't want immediate
+// notifications for the event. Notifications of recipients who prefer a digest get queued for the digest.
+func (s *Service) applyPreferences(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	base *BasePullReqPayload,
+	states watchStates,
+	recipients []*types.PrincipalInfo,
+	summary string,
+) ([]*types.PrincipalInfo, error) {
+	if len(recipients) == 0 {
+		return recipients, nil
+	}
+
+	ids := make([]int64, 0, len(recipients))
+	for _, recipient := range recipients {
+		ids = append(ids, recipient.ID)
+	}
+
+	modes, err := s.notificationPreferenceStore.MapModes(ctx, event, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	filtered := make([]*types.PrincipalInfo, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		if watching, ok := states[recipient.ID]; ok && !watching {
+			continue
+		}
+
+		mode, _ := modes[recipient.ID].Sanitize()
+
+		switch mode {
+		case enum.NotificationModeOff:
+		case enum.NotificationModeHourly, enum.NotificationModeDaily:
+			err = s.notificationDigestStore.Create(ctx, &types.NotificationDigestItem{
+				EventID:       base.EventID,
+				PrincipalID:   recipient.ID,
+				Mode:          mode,
+				Event:         event,
+				RepoID:        base.Repo.ID,
+				RepoPath:      base.Repo.Path,
+				PullReqID:     base.PullReq.ID,
+				PullReqNumber: base.PullReq.Number,
+				PullReqTitle:  base.PullReq.Title,
+				PullReqURL:    base.PullReqURL,
+				Summary:       summary,
+				Created:       now,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to queue notification for the %s digest: %w", mode, err)
+			}
+		case enum.NotificationModeImmediate:
+			filtered = append(filtered, recipient)
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+type notificationPreferenceStoreStub struct {
+	store.NotificationPreferenceStore
+	modes map[int64]enum.NotificationMode
+}
+
+func (s *notificationPreferenceStoreStub) MapModes(
+	_ context.Context,
+	_ enum.NotificationEvent,
+	principalIDs []int64,
+) (map[int64]enum.NotificationMode, error) {
+	result := make(map[int64]enum.NotificationMode)
+	for _, id := range principalIDs {
+		if mode, ok := s.modes[id]; ok {
+			result[id] = mode
+		}
+	}
+	return result, nil
+}
+
+type notificationWatchStoreStub struct {
+	store.NotificationWatchStore
+	watches []*types.NotificationWatch
+}
+
+func (s *notificationWatchStoreStub) ListForPullReq(
+	context.Context,
+	int64,
+	int64,
+) ([]*types.NotificationWatch, error) {
+	return s.watches, nil
+}
+
+type notificationDigestStoreStub struct {
+	store.NotificationDigestStore
+	created []*types.NotificationDigestItem
+}
+
+func (s *notificationDigestStoreStub) Create(_ context.Context, item *types.NotificationDigestItem) error {
+	s.created = append(s.created, item)
+	return nil
+}
+
+func buildPreferencesBase() *BasePullReqPayload {
+	return &BasePullReqPayload{
+		Repo:       &types.Repository{ID: 10, Path: "space/repo"},
+		PullReq:    &types.PullReq{ID: 20, Number: 42, Title: "My PR"},
+		PullReqURL: "https://example/pr/42",
+	}
+}
+
+func TestGetWatchStates_PullReqWatchOverridesRepoWatch(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		notificationWatchStore: &notificationWatchStoreStub{watches: []*types.NotificationWatch{
+			{PrincipalID: 1, RepoID: 10, PullReqID: 20, Watching: false},
+			{PrincipalID: 1, RepoID: 10, Watching: true},
+			{PrincipalID: 2, RepoID: 10, Watching: true},
+			{PrincipalID: 3, RepoID: 10, PullReqID: 20, Watching: true},
+		}},
+	}
+
+	states, err := svc.getWatchStates(context.Background(), buildPreferencesBase())
+	require.NoError(t, err)
+	require.Equal(t, watchStates{1: false, 2: true, 3: true}, states)
+}
+
+func TestAddWatchers(t *testing.T) {
+	t.Parallel()
+
+	recipient := &types.PrincipalInfo{ID: 2, Type: enum.PrincipalTypeUser}
+	watcher := &types.PrincipalInfo{ID: 5, Type: enum.PrincipalTypeUser}
+	serviceAccount := &types.PrincipalInfo{ID: 7, Type: enum.PrincipalTypeServiceAccount}
+
+	svc := &Service{
+		principalInfoCache: &principalInfoCacheMapStub{result: map[int64]*types.PrincipalInfo{
+			watcher.ID:        watcher,
+			serviceAccount.ID: serviceAccount,
+		}},
+	}
+
+	states := watchStates{1: true, 2: true, 5: true, 6: false, 7: true}
+
+	got, err := svc.addWatchers(context.Background(), states, []*types.PrincipalInfo{recipient}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.PrincipalInfo{recipient, watcher}, got)
+}
+
+func TestApplyPreferences(t *testing.T) {
+	t.Parallel()
+
+	immediate := &types.PrincipalInfo{ID: 1, Type: enum.PrincipalTypeUser}
+	off := &types.PrincipalInfo{ID: 2, Type: enum.PrincipalTypeUser}
+	hourly := &types.PrincipalInfo{ID: 3, Type: enum.PrincipalTypeUser}
+	unwatched := &types.PrincipalInfo{ID: 4, Type: enum.PrincipalTypeUser}
+	explicitImmediate := &types.PrincipalInfo{ID: 5, Type: enum.PrincipalTypeUser}
+
+	digestStore := &notificationDigestStoreStub{}
+	svc := &Service{
+		notificationPreferenceStore: &notificationPreferenceStoreStub{modes: map[int64]enum.NotificationMode{
+			off.ID:               enum.NotificationModeOff,
+			hourly.ID:            enum.NotificationModeHourly,
+			explicitImmediate.ID: enum.NotificationModeImmediate,
+		}},
+		notificationDigestStore: digestStore,
+	}
+
+	base := buildPreferencesBase()
+
+	got, err := svc.applyPreferences(
+		context.Background(),
+		enum.NotificationEventComment,
+		base,
+		watchStates{unwatched.ID: false, immediate.ID: true},
+		[]*types.PrincipalInfo{immediate, off, hourly, unwatched, explicitImmediate},
+		"Alice commented:\nhello",
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*types.PrincipalInfo{immediate, explicitImmediate}, got)
+
+	require.Len(t, digestStore.created, 1)
+	item := digestStore.created[0]
+	require.Equal(t, hourly.ID, item.PrincipalID)
+	require.Equal(t, enum.NotificationModeHourly, item.Mode)
+	require.Equal(t, enum.NotificationEventComment, item.Event)
+	require.Equal(t, base.Repo.Path, item.RepoPath)
+	require.Equal(t, base.PullReq.Number, item.PullReqNumber)
+	require.Equal(t, base.PullReqURL, item.PullReqURL)
+	require.Equal(t, "Alice commented:\nhello", item.Summary)
+}
+
+func TestNewDigestPayload_GroupsItemsByPullReq(t *testing.T) {
+	t.Parallel()
+
+	recipient := &types.PrincipalInfo{ID: 1, DisplayName: "Alice"}
+	items := []*types.NotificationDigestItem{
+		{ID: 1, PullReqID: 20, PullReqNumber: 42, RepoPath: "space/repo", Summary: "first"},
+		{ID: 2, PullReqID: 21, PullReqNumber: 43, RepoPath: "space/repo", Summary: "other"},
+		{ID: 3, PullReqID: 20, PullReqNumber: 42, RepoPath: "space/repo", Summary: "second"},
+	}
+
+	payload := newDigestPayload(recipient, enum.NotificationModeDaily, items)
+
+	require.Equal(t, 3, payload.ItemCount)
+	require.Len(t, payload.PullReqs, 2)
+	require.Equal(t, int64(42), payload.PullReqs[0].Number)
+	require.Equal(t, []string{"first", "second"}, payload.PullReqs[0].Summaries)
+	require.Equal(t, int64(43), payload.PullReqs[1].Number)
+	require.Equal(t, []string{"other"}, payload.PullReqs[1].Summaries)
+
+	body, err := GetHTMLBody(TemplateDigest, payload)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "space/repo #42")
+}
@@ -22,6 +22,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"golang.org/x/exp/maps"
 )
@@ -30,7 +31,7 @@ func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
 ) error {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -60,7 +61,18 @@ func (s *Service) notifyPullReqCreated(
 		ReviewerNames: strings.Join(reviewerNames, ", "),
 	}
 
-	err = s.notificationClient.SendReviewersAdded(ctx, []*types.PrincipalInfo{base.Author}, authorPayload)
+	authorRecipients, err := s.applyPreferences(ctx, enum.NotificationEventReviewerAdded, base, nil,
+		[]*types.PrincipalInfo{base.Author}, authorPayload.summary())
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences of author for event %s for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	err = s.notificationClient.SendReviewersAdded(ctx, authorRecipients, authorPayload)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send batched email to author for event %s for pullReqID %d: %w",
@@ -76,9 +88,20 @@ func (s *Service) notifyPullReqCreated(
 			Base:     base,
 			Reviewer: reviewer,
 		}
+		recipients, err := s.applyPreferences(ctx, enum.NotificationEventReviewerAdded, base, nil,
+			[]*types.PrincipalInfo{reviewer}, payload.summary())
+		if err != nil {
+			return fmt.Errorf(
+				"failed to apply notification preferences of reviewer for event %s for pullReqID %d: %w",
+				pullreqevents.CreatedEvent,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
+
 		if err := s.notificationClient.SendReviewerAdded(
 			ctx,
-			[]*types.PrincipalInfo{reviewer},
+			recipients,
 			payload,
 		); err != nil {
 			return fmt.Errorf(
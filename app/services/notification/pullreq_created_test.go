@@ -80,8 +80,10 @@ func TestNotifyPullReqCreated_SendsBatchedEmailToAuthorAndIndividualToReviewers(
 				reviewerC.ID: reviewerC,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -144,8 +146,10 @@ func TestNotifyPullReqCreated_SingleReviewer(t *testing.T) {
 				reviewer.ID: reviewer,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -189,8 +193,10 @@ func TestNotifyPullReqCreated_ReturnsEarlyWhenNoReviewers(t *testing.T) {
 				author.ID: author,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -289,8 +295,10 @@ func TestNotifyPullReqCreated_ReturnsErrorWhenAuthorEmailFails(t *testing.T) {
 				reviewer.ID: reviewer,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -335,8 +343,10 @@ func TestNotifyPullReqCreated_ReturnsErrorWhenReviewerEmailFails(t *testing.T) {
 				reviewerB.ID: reviewerB,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -379,8 +389,10 @@ func TestNotifyPullReqCreated_ExcludesAuthorFromReviewers(t *testing.T) {
 				reviewerB.ID: reviewerB,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	// Author is accidentally included in ReviewerIDs
@@ -433,8 +445,10 @@ func TestNotifyPullReqCreated_ReturnsEarlyWhenOnlyAuthorInReviewers(t *testing.T
 				author.ID: author,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	// Only the author is in ReviewerIDs
@@ -484,8 +498,10 @@ func TestNotifyPullReqCreated_ReviewerNamesAreCommaSeparated(t *testing.T) {
 		principalInfoCache: &prCreatedCacheStub{
 			byIDMap: principalMap,
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/42"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/42"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.CreatedPayload]{
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -41,7 +42,7 @@ func (s *Service) notifyPullReqStateMerged(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateMerged)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateMerged)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -51,6 +52,16 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
+	recipients, err = s.applyStateChangedPreferences(ctx, payload, recipients)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.MergedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -70,7 +81,7 @@ func (s *Service) notifyPullReqStateClosed(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateClosed)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateClosed)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -80,6 +91,16 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
+	recipients, err = s.applyStateChangedPreferences(ctx, payload, recipients)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.ClosedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -99,7 +120,7 @@ func (s *Service) notifyPullReqStateReOpened(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateReopened)
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.ID, event.Payload.Base, PullReqStateReopened)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -109,6 +130,16 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
+	recipients, err = s.applyStateChangedPreferences(ctx, payload, recipients)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.ReopenedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -124,12 +155,28 @@ func (s *Service) notifyPullReqStateReOpened(
 	return nil
 }
 
+func (s *Service) applyStateChangedPreferences(
+	ctx context.Context,
+	payload *PullReqStateChangedPayload,
+	recipients []*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, error) {
+	return s.applyWatchesAndPreferences(
+		ctx,
+		enum.NotificationEventStateChanged,
+		payload.Base,
+		recipients,
+		payload.summary(),
+		payload.ChangedBy.ID,
+	)
+}
+
 func (s *Service) processPullReqStateChangedEvent(
 	ctx context.Context,
+	eventID string,
 	baseEvent pullreqevents.Base,
 	state PullReqState,
 ) (*PullReqStateChangedPayload, []*types.PrincipalInfo, error) {
-	basePayload, err := s.getBasePayload(ctx, baseEvent)
+	basePayload, err := s.getBasePayload(ctx, eventID, baseEvent)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
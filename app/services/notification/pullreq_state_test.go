@@ -138,6 +138,7 @@ func TestProcessPullReqStateChangedEvent_IncludesUserGroupMembers(t *testing.T)
 
 	payload, recipients, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateClosed,
 	)
@@ -180,6 +181,7 @@ func TestProcessPullReqStateChangedEvent_DeduplicatesRecipients(t *testing.T) {
 
 	_, recipients, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateReopened,
 	)
@@ -214,6 +216,7 @@ func TestProcessPullReqStateChangedEvent_NoUserGroupReviewers(t *testing.T) {
 
 	_, recipients, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateMerged,
 	)
@@ -240,6 +243,7 @@ func TestProcessPullReqStateChangedEvent_ErrorFromUserGroupReviewerStore(t *test
 
 	_, _, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateClosed,
 	)
@@ -264,6 +268,7 @@ func TestProcessPullReqStateChangedEvent_ErrorFromListUserIDsByGroupIDs(t *testi
 
 	_, _, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateClosed,
 	)
@@ -289,6 +294,7 @@ func TestProcessPullReqStateChangedEvent_ErrorFromPrincipalInfoCacheMap(t *testi
 
 	_, _, err := svc.processPullReqStateChangedEvent(
 		context.Background(),
+		"event-id",
 		pullreqevents.Base{PullReqID: 20, TargetRepoID: 10, PrincipalID: modifier.ID},
 		PullReqStateClosed,
 	)
@@ -45,6 +45,23 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
+	recipients, err = s.applyWatchesAndPreferences(
+		ctx,
+		enum.NotificationEventReviewSubmitted,
+		notificationPayload.Base,
+		recipients,
+		notificationPayload.summary(),
+		notificationPayload.Reviewer.ID,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.ReviewSubmittedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	err = s.notificationClient.SendReviewSubmitted(
 		ctx,
 		recipients,
@@ -66,7 +83,7 @@ func (s *Service) processReviewSubmittedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
 ) (*ReviewSubmittedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,6 +43,17 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	recipients, err = s.applyPreferences(
+		ctx, enum.NotificationEventReviewerAdded, payload.Base, nil, recipients, payload.summary())
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences for event %s for pullReqID %d: %w",
+			pullreqevents.ReviewerAddedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
@@ -59,7 +71,7 @@ func (s *Service) processReviewerAddedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReviewerAddedPayload],
 ) (*ReviewerAddedPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 )
@@ -35,6 +36,7 @@ const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+	subjectDigest        = "Your %s pull request digest (%d updates)"
 )
 
 var (
@@ -77,12 +79,17 @@ type BasePullReqPayload struct {
 	PullReq    *types.PullReq
 	Author     *types.PrincipalInfo
 	PullReqURL string
+	// EventID is the ID of the event being processed, it deduplicates notifications queued for digests.
+	EventID string
 }
 
 type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	DigestHourlyCron string
+	DigestDailyCron  string
 }
 
 type Service struct {
@@ -99,6 +106,10 @@ type Service struct {
 	pullReqActivityStore   store.PullReqActivityStore
 	spacePathStore         store.SpacePathStore
 	urlProvider            url.Provider
+
+	notificationPreferenceStore store.NotificationPreferenceStore
+	notificationWatchStore      store.NotificationWatchStore
+	notificationDigestStore     store.NotificationDigestStore
 }
 
 func NewService(
@@ -116,6 +127,11 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationWatchStore store.NotificationWatchStore,
+	notificationDigestStore store.NotificationDigestStore,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
 ) (*Service, error) {
 	service := &Service{
 		config:                 config,
@@ -131,6 +147,10 @@ func NewService(
 		pullReqActivityStore:   pullReqActivityStore,
 		spacePathStore:         spacePathStore,
 		urlProvider:            urlProvider,
+
+		notificationPreferenceStore: notificationPreferenceStore,
+		notificationWatchStore:      notificationWatchStore,
+		notificationDigestStore:     notificationDigestStore,
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -163,11 +183,17 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	err = service.registerDigestJobs(ctx, executor, scheduler)
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
 
 func (s *Service) getBasePayload(
 	ctx context.Context,
+	eventID string,
 	base pullreqevents.Base,
 ) (*BasePullReqPayload, error) {
 	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
@@ -191,5 +217,6 @@ func (s *Service) getBasePayload(
 		PullReq:    pullReq,
 		Author:     author,
 		PullReqURL: s.urlProvider.GenerateUIPRURL(ctx, repo.Path, pullReq.Number),
+		EventID:    eventID,
 	}, nil
 }
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// summaryMaxTextLength is the maximum length of the comment text included in a summary.
+const summaryMaxTextLength = 500
+
+// The summaries are short plain text descriptions of the events, used by chat messages and digests.
+
+func (p *CommentPayload) summary() string {
+	text := p.Text
+	if runes := []rune(text); len(runes) > summaryMaxTextLength {
+		text = string(runes[:summaryMaxTextLength]) + "…"
+	}
+
+	return fmt.Sprintf("%s commented:\n%s", p.Commenter.DisplayName, text)
+}
+
+func (p *ReviewerAddedPayload) summary() string {
+	return fmt.Sprintf("%s was added as a reviewer.", p.Reviewer.DisplayName)
+}
+
+func (p *ReviewersAddedPayload) summary() string {
+	if p.ReviewerCount == 1 {
+		return fmt.Sprintf("%s was added as a reviewer.", p.ReviewerNames)
+	}
+
+	return fmt.Sprintf("%s were added as reviewers.", p.ReviewerNames)
+}
+
+func (p *PullReqBranchUpdatedPayload) summary() string {
+	newSHA := p.NewSHA
+	if len(newSHA) > 7 {
+		newSHA = newSHA[:7]
+	}
+
+	return fmt.Sprintf("%s pushed new commits (%s).", p.Committer.DisplayName, newSHA)
+}
+
+func (p *ReviewSubmittedPayload) summary() string {
+	var action string
+	switch p.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		action = "approved the pull request"
+	case enum.PullReqReviewDecisionChangeReq:
+		action = "requested changes"
+	case enum.PullReqReviewDecisionReviewed, enum.PullReqReviewDecisionPending:
+		action = "reviewed the pull request"
+	}
+
+	return fmt.Sprintf("%s %s.", p.Reviewer.DisplayName, action)
+}
+
+func (p *PullReqStateChangedPayload) summary() string {
+	return fmt.Sprintf("%s %s the pull request.", p.ChangedBy.DisplayName, p.State)
+}
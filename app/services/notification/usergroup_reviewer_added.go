@@ -22,6 +22,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // ReviewersAddedPayload is used when multiple reviewers are added at once
@@ -62,7 +63,18 @@ func (s *Service) notifyUserGroupReviewerAdded(
 		ReviewerNames: strings.Join(reviewerNames, ", "),
 	}
 
-	err = s.notificationClient.SendUserGroupReviewerAdded(ctx, []*types.PrincipalInfo{base.Author}, authorPayload)
+	authorRecipients, err := s.applyPreferences(ctx, enum.NotificationEventReviewerAdded, base, nil,
+		[]*types.PrincipalInfo{base.Author}, authorPayload.summary())
+	if err != nil {
+		return fmt.Errorf(
+			"failed to apply notification preferences of author for event %s for pullReqID %d: %w",
+			pullreqevents.UserGroupReviewerAdded,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	err = s.notificationClient.SendUserGroupReviewerAdded(ctx, authorRecipients, authorPayload)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
@@ -79,7 +91,18 @@ func (s *Service) notifyUserGroupReviewerAdded(
 			Reviewer: member,
 		}
 
-		err = s.notificationClient.SendReviewerAdded(ctx, []*types.PrincipalInfo{member}, reviewerPayload)
+		recipients, err := s.applyPreferences(ctx, enum.NotificationEventReviewerAdded, base, nil,
+			[]*types.PrincipalInfo{member}, reviewerPayload.summary())
+		if err != nil {
+			return fmt.Errorf(
+				"failed to apply notification preferences of reviewer for event %s for pullReqID %d: %w",
+				pullreqevents.UserGroupReviewerAdded,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
+
+		err = s.notificationClient.SendReviewerAdded(ctx, recipients, reviewerPayload)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send email for event %s for pullReqID %d: %w",
@@ -97,7 +120,7 @@ func (s *Service) processUserGroupReviewerAddedEvent(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.UserGroupReviewerAddedPayload],
 ) (*BasePullReqPayload, []*types.PrincipalInfo, error) {
-	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	base, err := s.getBasePayload(ctx, event.ID, event.Payload.Base)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
 	}
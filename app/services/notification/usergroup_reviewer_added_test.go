@@ -229,6 +229,10 @@ func (c *testNotificationClient) SendPullReqStateChanged(
 	_ context.Context, _ []*types.PrincipalInfo, _ *PullReqStateChangedPayload) error {
 	return nil
 }
+func (c *testNotificationClient) SendDigest(
+	_ context.Context, _ []*types.PrincipalInfo, _ *DigestPayload) error {
+	return nil
+}
 
 func TestNotifyUserGroupReviewerAdded_SendsGroupedEmailToAuthorAndIndividualToMembers(t *testing.T) {
 	t.Parallel()
@@ -254,8 +258,10 @@ func TestNotifyUserGroupReviewerAdded_SendsGroupedEmailToAuthorAndIndividualToMe
 				reviewerB.ID: reviewerB,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/99"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/99"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.UserGroupReviewerAddedPayload]{
@@ -308,8 +314,10 @@ func TestNotifyUserGroupReviewerAdded_ReturnsEarlyWhenNoMembers(t *testing.T) {
 				author.ID: author,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/99"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/99"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	// Only the author is in the group
@@ -352,8 +360,10 @@ func TestNotifyUserGroupReviewerAdded_ReturnsErrorWhenAuthorEmailFails(t *testin
 				reviewerA.ID: reviewerA,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/99"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/99"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.UserGroupReviewerAddedPayload]{
@@ -398,8 +408,10 @@ func TestNotifyUserGroupReviewerAdded_ReturnsErrorWhenReviewerEmailFails(t *test
 				reviewerB.ID: reviewerB,
 			},
 		},
-		urlProvider:        &urlProviderStub{prURL: "https://example/pr/99"},
-		notificationClient: notifClient,
+		urlProvider:                 &urlProviderStub{prURL: "https://example/pr/99"},
+		notificationClient:          notifClient,
+		notificationPreferenceStore: &notificationPreferenceStoreStub{},
+		notificationWatchStore:      &notificationWatchStoreStub{},
 	}
 
 	event := &events.Event[*pullreqevents.UserGroupReviewerAddedPayload]{
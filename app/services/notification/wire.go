@@ -19,16 +19,20 @@ import (
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideChatClient,
+	ProvideNotificationClient,
 	ProvideNotificationService,
 )
 
@@ -47,6 +51,11 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationWatchStore store.NotificationWatchStore,
+	notificationDigestStore store.NotificationDigestStore,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -63,9 +72,24 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		notificationPreferenceStore,
+		notificationWatchStore,
+		notificationDigestStore,
+		executor,
+		scheduler,
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
+func ProvideMailClient(mailer mailer.Mailer) MailClient {
 	return NewMailClient(mailer)
 }
+
+func ProvideChatClient(config ChatConfig, settingsService *settings.Service) *ChatClient {
+	return NewChatClient(config, settingsService)
+}
+
+// ProvideNotificationClient provides the client used by the notification service,
+// which sends emails as well as chat messages.
+func ProvideNotificationClient(mailClient MailClient, chatClient *ChatClient) Client {
+	return NewFanOutClient(mailClient, chatClient)
+}
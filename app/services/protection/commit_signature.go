@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxReportedUnsignedCommits is the max number of unsigned commits individually listed in rule violations.
+const maxReportedUnsignedCommits = 10
+
+// UnsignedCommit is a commit without a good signature, i.e. a commit that isn't signed at all
+// or that has a signature of an unknown, expired or revoked key or a signature that doesn't match.
+type UnsignedCommit struct {
+	SHA sha.SHA
+	// Result is the signature verification result. It is empty if the commit isn't signed.
+	Result enum.GitSignatureResult
+}
+
+// UnsignedCommits returns all commits that are not signed or don't have a good signature.
+// The signatures of the commits must be verified before calling the function.
+func UnsignedCommits(commits []*types.Commit) []UnsignedCommit {
+	var unsigned []UnsignedCommit
+	for _, commit := range commits {
+		if commit.Signature == nil {
+			unsigned = append(unsigned, UnsignedCommit{SHA: commit.SHA})
+			continue
+		}
+
+		if commit.Signature.Result != enum.GitSignatureGood {
+			unsigned = append(unsigned, UnsignedCommit{SHA: commit.SHA, Result: commit.Signature.Result})
+		}
+	}
+
+	return unsigned
+}
+
+func addUnsignedCommitViolations(violations *types.RuleViolations, code string, commits []UnsignedCommit) {
+	for i, commit := range commits {
+		if i == maxReportedUnsignedCommits {
+			violations.Addf(code,
+				"Found %d more commit(s) without a valid signature.",
+				len(commits)-maxReportedUnsignedCommits)
+			return
+		}
+
+		if commit.Result == "" {
+			violations.Addf(code, "Commit %s is not signed.", commit.SHA)
+			continue
+		}
+
+		violations.Addf(code, "Commit %s doesn't have a valid signature: %s.", commit.SHA, commit.Result)
+	}
+}
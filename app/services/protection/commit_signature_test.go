@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestUnsignedCommits(t *testing.T) {
+	commits := []*types.Commit{
+		{SHA: sha.Must("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+		{
+			SHA:       sha.Must("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+			Signature: &types.GitSignatureResult{Result: enum.GitSignatureGood},
+		},
+		{
+			SHA:       sha.Must("cccccccccccccccccccccccccccccccccccccccc"),
+			Signature: &types.GitSignatureResult{Result: enum.GitSignatureUnverified},
+		},
+	}
+
+	unsigned := UnsignedCommits(commits)
+	if len(unsigned) != 2 {
+		t.Fatalf("expected 2 unsigned commits, got %d", len(unsigned))
+	}
+	if unsigned[0].SHA != commits[0].SHA || unsigned[0].Result != "" {
+		t.Errorf("unexpected first unsigned commit: %+v", unsigned[0])
+	}
+	if unsigned[1].SHA != commits[2].SHA || unsigned[1].Result != enum.GitSignatureUnverified {
+		t.Errorf("unexpected second unsigned commit: %+v", unsigned[1])
+	}
+}
+
+func TestPush_Violations_RequireSignedCommits(t *testing.T) {
+	unsigned := make([]UnsignedCommit, maxReportedUnsignedCommits+5)
+	for i := range unsigned {
+		unsigned[i] = UnsignedCommit{SHA: sha.Must(fmt.Sprintf("%040x", i+1))}
+	}
+
+	tests := []struct {
+		name       string
+		rule       bool
+		required   bool
+		violations int
+	}{
+		{name: "required", rule: true, required: true, violations: maxReportedUnsignedCommits + 1},
+		{name: "not-required-by-rule", rule: false, required: true, violations: 0},
+		{name: "not-verified", rule: true, required: false, violations: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := Push{Push: DefPush{RequireSignedCommits: test.rule}}
+
+			out, err := p.Violations(context.Background(), &PushViolationsInput{
+				Actor:                &types.Principal{ID: 1},
+				RequireSignedCommits: test.required,
+				UnsignedCommits:      unsigned,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := len(out.Violations[0].Violations); got != test.violations {
+				t.Errorf("expected %d violations, got %d", test.violations, got)
+			}
+			for _, v := range out.Violations[0].Violations {
+				if v.Code != codePushRequireSignedCommits {
+					t.Errorf("unexpected violation code %q", v.Code)
+				}
+			}
+		})
+	}
+}
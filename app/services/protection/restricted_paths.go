@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+)
+
+// maxReportedRestrictedFiles is the max number of restricted files individually listed in rule violations.
+const maxReportedRestrictedFiles = 10
+
+// DefRestrictedPaths defines file paths that can be changed only by the listed principals.
+// The patterns use the same globstar syntax as the branch patterns, e.g. ".harness/**" or "deploy/prod/**".
+type DefRestrictedPaths struct {
+	Patterns     []string `json:"patterns,omitempty"`
+	UserIDs      []int64  `json:"user_ids,omitempty"`
+	UserGroupIDs []int64  `json:"user_group_ids,omitempty"`
+}
+
+func (v *DefRestrictedPaths) IsActive() bool {
+	return v != nil && len(v.Patterns) > 0
+}
+
+// RestrictedFiles returns the files that match any of the restricted path patterns.
+func (v *DefRestrictedPaths) RestrictedFiles(files []string) []string {
+	if !v.IsActive() {
+		return nil
+	}
+
+	var restricted []string
+	for _, file := range files {
+		for _, pattern := range v.Patterns {
+			if patternMatches(pattern, file) {
+				restricted = append(restricted, file)
+				break
+			}
+		}
+	}
+
+	return restricted
+}
+
+// isAllowed returns true if the actor is allowed to change the restricted paths.
+func (v *DefRestrictedPaths) isAllowed(
+	ctx context.Context,
+	actor *types.Principal,
+	userGroupResolverFn func(context.Context, []int64) ([]int64, error),
+) bool {
+	allowed := DefBypass{
+		UserIDs:      v.UserIDs,
+		UserGroupIDs: v.UserGroupIDs,
+	}
+
+	return allowed.matches(ctx, actor, false, userGroupResolverFn)
+}
+
+// verify adds a violation for every file the actor isn't allowed to change.
+func (v *DefRestrictedPaths) verify(
+	ctx context.Context,
+	violations *types.RuleViolations,
+	code string,
+	actor *types.Principal,
+	userGroupResolverFn func(context.Context, []int64) ([]int64, error),
+	changedFiles []string,
+) {
+	restrictedFiles := v.RestrictedFiles(changedFiles)
+	if len(restrictedFiles) == 0 || v.isAllowed(ctx, actor, userGroupResolverFn) {
+		return
+	}
+
+	for i, file := range restrictedFiles {
+		if i == maxReportedRestrictedFiles {
+			violations.Addf(code,
+				"Found %d more restricted file(s) changed.",
+				len(restrictedFiles)-maxReportedRestrictedFiles)
+			return
+		}
+
+		violations.Addf(code, "Changing the restricted file %q is not allowed.", file)
+	}
+}
+
+func (v *DefRestrictedPaths) Sanitize() error {
+	if v == nil {
+		return nil
+	}
+
+	if len(v.Patterns) == 0 {
+		return errors.InvalidArgument("At least one restricted path pattern must be specified.")
+	}
+
+	if len(v.Patterns) > maxElements {
+		return errors.InvalidArgument("Too many restricted path patterns provided.")
+	}
+
+	for i, pattern := range v.Patterns {
+		// paths reported by git are relative to the repository root
+		pattern = strings.TrimPrefix(pattern, "/")
+		if err := patternValidate(pattern); err != nil {
+			return fmt.Errorf("pattern %q: %w", v.Patterns[i], err)
+		}
+
+		v.Patterns[i] = pattern
+	}
+
+	if err := validateIDSlice(v.UserIDs); err != nil {
+		return fmt.Errorf("user IDs error: %w", err)
+	}
+
+	if err := validateIDSlice(v.UserGroupIDs); err != nil {
+		return fmt.Errorf("user group IDs error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDefRestrictedPaths_RestrictedFiles(t *testing.T) {
+	def := DefRestrictedPaths{Patterns: []string{".harness/**", "deploy/prod/**", "CODEOWNERS"}}
+
+	files := []string{
+		".harness/pipeline.yaml",
+		".harness/nested/stage.yaml",
+		"deploy/prod/values.yaml",
+		"deploy/staging/values.yaml",
+		"CODEOWNERS",
+		"docs/CODEOWNERS",
+		"main.go",
+	}
+
+	want := []string{
+		".harness/pipeline.yaml",
+		".harness/nested/stage.yaml",
+		"deploy/prod/values.yaml",
+		"CODEOWNERS",
+	}
+
+	if got := def.RestrictedFiles(files); !reflect.DeepEqual(want, got) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+
+	var nilDef *DefRestrictedPaths
+	if got := nilDef.RestrictedFiles(files); got != nil {
+		t.Errorf("expected no restricted files for undefined restrictions, got %v", got)
+	}
+}
+
+func TestDefRestrictedPaths_Sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     *DefRestrictedPaths
+		expErr  bool
+		expPats []string
+	}{
+		{name: "nil", def: nil},
+		{name: "valid", def: &DefRestrictedPaths{Patterns: []string{".harness/**"}},
+			expPats: []string{".harness/**"}},
+		{name: "leading-slash", def: &DefRestrictedPaths{Patterns: []string{"/CODEOWNERS"}},
+			expPats: []string{"CODEOWNERS"}},
+		{name: "no-patterns", def: &DefRestrictedPaths{UserIDs: []int64{1}}, expErr: true},
+		{name: "empty-pattern", def: &DefRestrictedPaths{Patterns: []string{""}}, expErr: true},
+		{name: "invalid-pattern", def: &DefRestrictedPaths{Patterns: []string{"[a"}}, expErr: true},
+		{name: "invalid-user", def: &DefRestrictedPaths{Patterns: []string{"a"}, UserIDs: []int64{0}}, expErr: true},
+		{name: "invalid-group", def: &DefRestrictedPaths{Patterns: []string{"a"}, UserGroupIDs: []int64{-1}},
+			expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t, got %v", test.expErr, err)
+			}
+			if err == nil && test.def != nil && !reflect.DeepEqual(test.expPats, test.def.Patterns) {
+				t.Errorf("patterns mismatch: want=%v got=%v", test.expPats, test.def.Patterns)
+			}
+		})
+	}
+}
+
+func TestPush_Violations_RestrictedPaths(t *testing.T) {
+	changedFiles := make([]string, maxReportedRestrictedFiles+5)
+	for i := range changedFiles {
+		changedFiles[i] = fmt.Sprintf("deploy/prod/file%02d.yaml", i)
+	}
+
+	resolveGroups := func(_ context.Context, userGroupIDs []int64) ([]int64, error) {
+		if len(userGroupIDs) > 0 {
+			return []int64{3}, nil
+		}
+		return nil, nil
+	}
+
+	tests := []struct {
+		name       string
+		actorID    int64
+		restricted bool
+		files      []string
+		resolver   func(context.Context, []int64) ([]int64, error)
+		violations int
+	}{
+		{name: "not-allowed", actorID: 1, restricted: true, files: changedFiles,
+			violations: maxReportedRestrictedFiles + 1},
+		{name: "allowed-user", actorID: 2, restricted: true, files: changedFiles, violations: 0},
+		{name: "allowed-group", actorID: 3, restricted: true, files: changedFiles, resolver: resolveGroups,
+			violations: 0},
+		{name: "group-resolve-error", actorID: 3, restricted: true, files: changedFiles,
+			resolver: func(context.Context, []int64) ([]int64, error) {
+				return nil, errors.New("failed")
+			},
+			violations: maxReportedRestrictedFiles + 1},
+		{name: "unrestricted-files", actorID: 1, restricted: true, files: []string{"main.go"}, violations: 0},
+		{name: "not-verified", actorID: 1, restricted: false, files: changedFiles, violations: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := Push{Push: DefPush{RestrictedPaths: &DefRestrictedPaths{
+				Patterns:     []string{"deploy/prod/**"},
+				UserIDs:      []int64{2},
+				UserGroupIDs: []int64{7},
+			}}}
+
+			out, err := p.Violations(context.Background(), &PushViolationsInput{
+				ResolveUserGroupID: test.resolver,
+				Actor:              &types.Principal{ID: test.actorID},
+				RestrictedPaths:    test.restricted,
+				ChangedFiles:       test.files,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := len(out.Violations[0].Violations); got != test.violations {
+				t.Errorf("expected %d violations, got %d", test.violations, got)
+			}
+			for _, v := range out.Violations[0].Violations {
+				if v.Code != codePushRestrictedPaths {
+					t.Errorf("unexpected violation code %q", v.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestPush_Violations_RestrictedPaths_Monitor(t *testing.T) {
+	p := Push{
+		Bypass: DefBypass{UserIDs: []int64{5}},
+		Push:   DefPush{RestrictedPaths: &DefRestrictedPaths{Patterns: []string{"CODEOWNERS"}}},
+	}
+
+	for _, actorID := range []int64{1, 5} {
+		out, err := p.Violations(context.Background(), &PushViolationsInput{
+			Actor:           &types.Principal{ID: actorID},
+			AllowBypass:     true,
+			RestrictedPaths: true,
+			ChangedFiles:    []string{"CODEOWNERS"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		violations := out.Violations[0]
+		if len(violations.Violations) != 1 {
+			t.Fatalf("expected a violation for actor %d", actorID)
+		}
+
+		if bypassed := actorID == 5; violations.Bypassed != bypassed {
+			t.Errorf("actor %d: expected bypassed=%t", actorID, bypassed)
+		}
+
+		violations.Rule.State = enum.RuleStateActive
+		if critical := actorID != 5; violations.IsCritical() != critical {
+			t.Errorf("actor %d: expected critical=%t for an active rule", actorID, critical)
+		}
+
+		violations.Rule.State = enum.RuleStateMonitor
+		if violations.IsCritical() {
+			t.Errorf("actor %d: violations of a monitored rule must not be critical", actorID)
+		}
+	}
+}
@@ -164,6 +164,11 @@ func (v *Branch) UserIDs() ([]int64, error) {
 	for _, id := range v.PullReq.Reviewers.DefaultReviewerIDs {
 		uniqueUserMap[id] = struct{}{}
 	}
+	if v.PullReq.RestrictedPaths != nil {
+		for _, id := range v.PullReq.RestrictedPaths.UserIDs {
+			uniqueUserMap[id] = struct{}{}
+		}
+	}
 
 	ids := make([]int64, 0, len(uniqueUserMap))
 	for id := range uniqueUserMap {
@@ -184,6 +189,11 @@ func (v *Branch) UserGroupIDs() ([]int64, error) {
 	for _, id := range v.PullReq.Reviewers.DefaultUserGroupReviewerIDs {
 		uniqueGroupsMap[id] = struct{}{}
 	}
+	if v.PullReq.RestrictedPaths != nil {
+		for _, id := range v.PullReq.RestrictedPaths.UserGroupIDs {
+			uniqueGroupsMap[id] = struct{}{}
+		}
+	}
 
 	ids := make([]int64, 0, len(uniqueGroupsMap))
 	for id := range uniqueGroupsMap {
@@ -284,6 +284,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 					enum.MergeMethodFastForward,
 					enum.MergeMethodMerge,
 					enum.MergeMethodRebase,
+					enum.MergeMethodSemiLinear,
 					enum.MergeMethodSquash,
 				},
 				RequiresCodeOwnersApprovalLatest:    true,
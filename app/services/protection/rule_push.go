@@ -17,7 +17,9 @@ package protection
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -88,6 +90,15 @@ func (p *Push) Violations(
 		)
 	}
 
+	if p.Push.RequireSignedCommits && in.RequireSignedCommits {
+		addUnsignedCommitViolations(&violations, codePushRequireSignedCommits, in.UnsignedCommits)
+	}
+
+	if p.Push.RestrictedPaths.IsActive() && in.RestrictedPaths {
+		p.Push.RestrictedPaths.verify(ctx, &violations, codePushRestrictedPaths,
+			in.Actor, in.ResolveUserGroupID, in.ChangedFiles)
+	}
+
 	bypassable := p.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
 	bypassed := in.AllowBypass && bypassable
 	violations.Bypassable = bypassable
@@ -99,11 +110,19 @@ func (p *Push) Violations(
 }
 
 func (p *Push) UserIDs() ([]int64, error) {
-	return p.Bypass.UserIDs, nil
+	if p.Push.RestrictedPaths == nil {
+		return p.Bypass.UserIDs, nil
+	}
+
+	return cache.Deduplicate(slices.Concat(p.Bypass.UserIDs, p.Push.RestrictedPaths.UserIDs)), nil
 }
 
 func (p *Push) UserGroupIDs() ([]int64, error) {
-	return p.Bypass.UserGroupIDs, nil
+	if p.Push.RestrictedPaths == nil {
+		return p.Bypass.UserGroupIDs, nil
+	}
+
+	return cache.Deduplicate(slices.Concat(p.Bypass.UserGroupIDs, p.Push.RestrictedPaths.UserGroupIDs)), nil
 }
 
 func (p *Push) Sanitize() error {
@@ -111,6 +130,10 @@ func (p *Push) Sanitize() error {
 		return fmt.Errorf("bypass: %w", err)
 	}
 
+	if err := p.Push.RestrictedPaths.Sanitize(); err != nil {
+		return fmt.Errorf("restricted paths: %w", err)
+	}
+
 	return nil
 }
 
@@ -69,6 +69,7 @@ func (s branchRuleSet) MergeVerify(
 			out.RequiresBypassMessage = out.RequiresBypassMessage || rOut.RequiresBypassMessage
 			out.DefaultReviewerApprovals = append(out.DefaultReviewerApprovals, rOut.DefaultReviewerApprovals...)
 			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
+			out.RequiresSignedCommits = out.RequiresSignedCommits || rOut.RequiresSignedCommits
 
 			return nil
 		})
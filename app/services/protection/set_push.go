@@ -78,6 +78,10 @@ func (s pushRuleSet) PushVerify(
 		out.PrincipalCommitterMatch = out.PrincipalCommitterMatch || rOut.PrincipalCommitterMatch
 
 		out.SecretScanningEnabled = out.SecretScanningEnabled || rOut.SecretScanningEnabled
+
+		out.RequireSignedCommits = out.RequireSignedCommits || rOut.RequireSignedCommits
+
+		out.RestrictedPaths = out.RestrictedPaths || rOut.RestrictedPaths
 	}
 
 	return out, violations, nil
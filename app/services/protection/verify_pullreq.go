@@ -50,6 +50,14 @@ type (
 		CheckResults        []types.CheckResult
 		CodeOwners          *codeowners.Evaluation
 		OmitMQViolations    bool // should be set to true only by the merge queue service
+
+		// FindUnsignedCommits returns the commits of the pull request without a good signature.
+		// It's called only if a rule requires signed commits.
+		FindUnsignedCommits func(ctx context.Context) ([]UnsignedCommit, error)
+
+		// FindChangedFiles returns the paths of all files changed by the pull request.
+		// It's called only if a rule restricts changes of file paths.
+		FindChangedFiles func(ctx context.Context) ([]string, error)
 	}
 
 	MergeVerifyOutput struct {
@@ -69,6 +77,7 @@ type (
 		RequiresNoChangeRequests         bool
 		RequiresBypassMessage            bool
 		RequiresMergeQueue               bool
+		RequiresSignedCommits            bool
 	}
 
 	RequiredChecksInput struct {
@@ -164,6 +173,9 @@ const (
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
 
 	codePullReqCommitsTargetIsAncestor = "pullreq.commits.require_target_is_ancestor"
+	codePullReqCommitsRequireSigned    = "pullreq.commits.require_signed"
+
+	codePullReqRestrictedPaths = "pullreq.restricted_paths"
 )
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
@@ -384,6 +396,31 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	if v.Commits.RequireSigned {
+		out.RequiresSignedCommits = true
+
+		if in.FindUnsignedCommits != nil {
+			unsignedCommits, err := in.FindUnsignedCommits(ctx)
+			if err != nil {
+				return MergeVerifyOutput{}, nil, fmt.Errorf("failed to find unsigned commits: %w", err)
+			}
+
+			addUnsignedCommitViolations(&violations, codePullReqCommitsRequireSigned, unsignedCommits)
+		}
+	}
+
+	// pullreq.restricted_paths
+
+	if v.RestrictedPaths.IsActive() && in.FindChangedFiles != nil {
+		changedFiles, err := in.FindChangedFiles(ctx)
+		if err != nil {
+			return MergeVerifyOutput{}, nil, fmt.Errorf("failed to find changed files: %w", err)
+		}
+
+		v.RestrictedPaths.verify(ctx, &violations, codePullReqRestrictedPaths,
+			in.Actor, in.ResolveUserGroupIDs, changedFiles)
+	}
+
 	// pullreq.merge
 
 	out.AllowedMethods = enum.MergeMethods
@@ -533,6 +570,7 @@ func (c *DefStatusChecks) Sanitize() error {
 
 type DefCommits struct {
 	RequireTargetIsAncestor bool `json:"require_target_is_ancestor,omitempty"`
+	RequireSigned           bool `json:"require_signed,omitempty"`
 }
 
 func (v *DefCommits) Sanitize() error {
@@ -659,6 +697,8 @@ type DefPullReq struct {
 	Merge        DefMerge        `json:"merge"`
 	Reviewers    DefReviewers    `json:"reviewers"`
 	MergeQueue   *DefMergeQueue  `json:"merge_queue,omitempty"`
+
+	RestrictedPaths *DefRestrictedPaths `json:"restricted_paths,omitempty"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -690,6 +730,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge queue: %w", err)
 	}
 
+	if err := v.RestrictedPaths.Sanitize(); err != nil {
+		return fmt.Errorf("restricted paths: %w", err)
+	}
+
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -659,6 +660,18 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: []enum.MergeMethod{enum.MergeMethodRebase, enum.MergeMethodSquash},
 			},
 		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-semi-linear",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodSemiLinear,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodSemiLinear,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{enum.MergeMethodSemiLinear},
+			},
+		},
 		{
 			name: codePullReqMergeDeleteBranch,
 			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
@@ -803,6 +816,130 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqCommitsRequireSigned + "-fail",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				FindUnsignedCommits: func(context.Context) ([]UnsignedCommit, error) {
+					return []UnsignedCommit{
+						{SHA: sha.Must("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+						{SHA: sha.Must("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), Result: enum.GitSignatureRevoked},
+					}, nil
+				},
+			},
+			expCodes: []string{codePullReqCommitsRequireSigned, codePullReqCommitsRequireSigned},
+			expParams: [][]any{
+				{sha.Must("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+				{sha.Must("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), enum.GitSignatureRevoked},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:        enum.MergeMethods,
+				RequiresSignedCommits: true,
+			},
+		},
+		{
+			name: codePullReqCommitsRequireSigned + "-success",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				FindUnsignedCommits: func(context.Context) ([]UnsignedCommit, error) {
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:        enum.MergeMethods,
+				RequiresSignedCommits: true,
+			},
+		},
+		{
+			name: codePullReqCommitsRequireSigned + "-not-required",
+			def:  DefPullReq{},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				FindUnsignedCommits: func(context.Context) ([]UnsignedCommit, error) {
+					t.Fatal("unsigned commits must not be searched if not required")
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqRestrictedPaths + "-fail",
+			def: DefPullReq{RestrictedPaths: &DefRestrictedPaths{
+				Patterns: []string{".harness/**"},
+				UserIDs:  []int64{2},
+			}},
+			in: MergeVerifyInput{
+				Actor:  &types.Principal{ID: 1},
+				Method: enum.MergeMethodMerge,
+				FindChangedFiles: func(context.Context) ([]string, error) {
+					return []string{".harness/pipeline.yaml", "main.go"}, nil
+				},
+			},
+			expCodes:  []string{codePullReqRestrictedPaths},
+			expParams: [][]any{{".harness/pipeline.yaml"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqRestrictedPaths + "-allowed-user",
+			def: DefPullReq{RestrictedPaths: &DefRestrictedPaths{
+				Patterns: []string{".harness/**"},
+				UserIDs:  []int64{1},
+			}},
+			in: MergeVerifyInput{
+				Actor:  &types.Principal{ID: 1},
+				Method: enum.MergeMethodMerge,
+				FindChangedFiles: func(context.Context) ([]string, error) {
+					return []string{".harness/pipeline.yaml"}, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqRestrictedPaths + "-allowed-user-group",
+			def: DefPullReq{RestrictedPaths: &DefRestrictedPaths{
+				Patterns:     []string{".harness/**"},
+				UserGroupIDs: []int64{5},
+			}},
+			in: MergeVerifyInput{
+				ResolveUserGroupIDs: func(_ context.Context, userGroupIDs []int64) ([]int64, error) {
+					if slices.Contains(userGroupIDs, 5) {
+						return []int64{1}, nil
+					}
+					return nil, nil
+				},
+				Actor:  &types.Principal{ID: 1},
+				Method: enum.MergeMethodMerge,
+				FindChangedFiles: func(context.Context) ([]string, error) {
+					return []string{".harness/pipeline.yaml"}, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqRestrictedPaths + "-not-restricted",
+			def:  DefPullReq{},
+			in: MergeVerifyInput{
+				Actor:  &types.Principal{ID: 1},
+				Method: enum.MergeMethodMerge,
+				FindChangedFiles: func(context.Context) ([]string, error) {
+					t.Fatal("changed files must not be searched if paths are not restricted")
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 	}
 
 	for _, test := range tests {
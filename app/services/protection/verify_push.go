@@ -25,6 +25,8 @@ const (
 	codePushFileSizeLimit           = "push.file.size.limit"
 	codePushPrincipalCommitterMatch = "push.principal.committer.match"
 	codeSecretScanningEnabled       = "push.secret.scanning.enabled"
+	codePushRequireSignedCommits    = "push.require_signed_commits"
+	codePushRestrictedPaths         = "push.restricted_paths"
 )
 
 type (
@@ -49,6 +51,10 @@ type (
 		CommitterMismatchCount  int64
 		SecretScanningEnabled   bool
 		FoundSecretsCount       int
+		RequireSignedCommits    bool
+		UnsignedCommits         []UnsignedCommit
+		RestrictedPaths         bool
+		ChangedFiles            []string
 	}
 
 	PushViolationsOutput struct {
@@ -59,6 +65,8 @@ type (
 		FileSizeLimits          []int64
 		PrincipalCommitterMatch bool
 		SecretScanningEnabled   bool
+		RequireSignedCommits    bool
+		RestrictedPaths         bool
 		Protections             map[int64]PushProtection
 	}
 
@@ -75,13 +83,18 @@ type (
 		FileSizeLimit           int64 `json:"file_size_limit"`
 		PrincipalCommitterMatch bool  `json:"principal_committer_match"`
 		SecretScanningEnabled   bool  `json:"secret_scanning_enabled"`
+		RequireSignedCommits    bool  `json:"require_signed_commits,omitempty"`
+
+		RestrictedPaths *DefRestrictedPaths `json:"restricted_paths,omitempty"`
 	}
 )
 
 func (in *PushViolationsInput) HasViolations() bool {
 	return in.FindOversizeFilesOutput != nil && len(in.FindOversizeFilesOutput.FileInfosPerLimit) > 0 ||
 		in.CommitterMismatchCount > 0 ||
-		in.FoundSecretsCount > 0
+		in.FoundSecretsCount > 0 ||
+		len(in.UnsignedCommits) > 0 ||
+		in.RestrictedPaths && len(in.ChangedFiles) > 0
 }
 
 func (v *DefPush) PushVerify(
@@ -92,5 +105,7 @@ func (v *DefPush) PushVerify(
 		FileSizeLimits:          []int64{v.FileSizeLimit},
 		PrincipalCommitterMatch: v.PrincipalCommitterMatch,
 		SecretScanningEnabled:   v.SecretScanningEnabled,
+		RequireSignedCommits:    v.RequireSignedCommits,
+		RestrictedPaths:         v.RestrictedPaths.IsActive(),
 	}, nil, nil
 }
@@ -0,0 +1,400 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+var errStackParentChanged = errors.New("stack parent of the pull request has changed")
+
+// retargetStackOnMerge handles pull request merged events.
+// Every open pull request stacked on top of the merged pull request is retargeted
+// to the merged pull request's target branch and its source branch is rebased onto it.
+func (s *Service) retargetStackOnMerge(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	parent, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	pullreqList, err := s.listStackedPullReqs(ctx, parent)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests stacked on pull request %d: %w", parent.Number, err)
+	}
+
+	if len(pullreqList) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, parent.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	var failed int
+	for _, pr := range pullreqList {
+		if err := s.retargetStackedPullReq(ctx, pr, parent, repo, event.Payload.PrincipalID); err != nil {
+			failed++
+			log.Ctx(ctx).Err(err).
+				Int64("pullreq", pr.Number).
+				Int64("parent_pullreq", parent.Number).
+				Msg("failed to retarget stacked pull request after the parent pull request merge")
+		}
+	}
+
+	// Returning an error makes the event to be redelivered. The already retargeted pull requests
+	// are no longer stacked on the parent, so only the failed ones are going to be processed again.
+	if failed > 0 {
+		return fmt.Errorf("failed to retarget %d of %d pull requests stacked on pull request %d",
+			failed, len(pullreqList), parent.Number)
+	}
+
+	return nil
+}
+
+// listStackedPullReqs returns all open pull requests stacked directly on top of the parent pull request.
+// All pages are read before any of the pull requests is retargeted, because retargeting
+// removes a pull request from the result set and would shift the pages.
+func (s *Service) listStackedPullReqs(ctx context.Context, parent *types.PullReq) ([]*types.PullReq, error) {
+	const pageSize = 100
+
+	var all []*types.PullReq
+	for page := 1; ; page++ {
+		batch, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+			Page:          page,
+			Size:          pageSize,
+			TargetRepoID:  parent.TargetRepoID,
+			StackParentID: parent.ID,
+			States:        []enum.PullReqState{enum.PullReqStateOpen},
+			Sort:          enum.PullReqSortNumber,
+			Order:         enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, batch...)
+		if len(batch) < pageSize {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// retargetStackedPullReq updates a single pull request to target the branch its merged parent was merged into.
+// The dependency on the parent is removed, which unblocks the merge of the pull request.
+func (s *Service) retargetStackedPullReq(
+	ctx context.Context,
+	pr *types.PullReq,
+	parent *types.PullReq,
+	repo *types.Repository,
+	principalID int64,
+) error {
+	readParams := git.CreateReadParams(repo)
+	newTargetBranch := parent.TargetBranch
+
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: readParams,
+		Name:       newTargetBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve target branch reference: %w", err)
+	}
+
+	targetSHA := targetRef.SHA
+
+	mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       pr.SourceSHA,
+		Ref2:       targetSHA.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get merge base with the new target branch: %w", err)
+	}
+
+	diffStats, err := s.git.DiffStats(ctx, &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    mergeBaseInfo.MergeBaseSHA.String(),
+		HeadRef:    pr.SourceSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get diff stats: %w", err)
+	}
+
+	oldTargetBranch := pr.TargetBranch
+	oldMergeBaseSHA := pr.MergeBaseSHA
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return ErrPullReqNotOpen
+		}
+		if pr.StackParentID == nil || *pr.StackParentID != parent.ID {
+			return errStackParentChanged
+		}
+
+		pr.ActivitySeq++
+
+		pr.StackParentID = nil
+		pr.TargetBranch = newTargetBranch
+		pr.MergeBaseSHA = mergeBaseInfo.MergeBaseSHA.String()
+		pr.MergeTargetSHA = ptr.String(targetSHA.String())
+
+		pr.MergeSHA = nil // nil means: "needs to be recalculated"
+		pr.Stats.DiffStats = types.NewDiffStats(
+			diffStats.Commits,
+			diffStats.FilesChanged,
+			diffStats.Additions,
+			diffStats.Deletions,
+		)
+		pr.MarkAsMergeUnchecked()
+
+		return nil
+	})
+	if errors.Is(err, ErrPullReqNotOpen) || errors.Is(err, errStackParentChanged) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update stacked pull request: %w", err)
+	}
+
+	ctxNoCancel := context.WithoutCancel(ctx)
+
+	payload := &types.PullRequestActivityPayloadStackParentMerged{
+		ParentNumber:    parent.Number,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: newTargetBranch,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: mergeBaseInfo.MergeBaseSHA.String(),
+	}
+
+	// The pull request is retargeted before its source branch is rebased, so that the branch update
+	// event, which follows the rebase, recalculates the merge base against the new target branch.
+	rebasedSHA, conflicts, err := s.rebaseStackedPullReq(ctxNoCancel, pr, parent, repo, targetSHA)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq", pr.Number).
+			Msg("failed to rebase stacked pull request onto the new target branch")
+	}
+
+	payload.Rebased = !rebasedSHA.IsEmpty()
+	if payload.Rebased {
+		payload.RebaseSHA = rebasedSHA.String()
+	}
+	payload.RebaseConflicts = conflicts
+
+	_, err = s.activityStore.CreateWithPayload(ctxNoCancel, pr, principalID, payload, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).
+			Int64("pullreq", pr.Number).
+			Msg("failed to write pull request activity after the stack parent merge")
+	}
+
+	s.deletePRMergeRef(ctxNoCancel, pr, repo)
+
+	s.pullreqEvReporter.TargetBranchChanged(ctxNoCancel, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		SourceSHA:       pr.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: newTargetBranch,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: mergeBaseInfo.MergeBaseSHA.String(),
+	})
+
+	s.sseStreamer.Publish(ctxNoCancel, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	log.Ctx(ctx).Info().
+		Int64("pullreq", pr.Number).
+		Int64("parent_pullreq", parent.Number).
+		Str("old_target", oldTargetBranch).
+		Str("new_target", newTargetBranch).
+		Bool("rebased", payload.Rebased).
+		Msg("retargeted stacked pull request after the parent pull request merge")
+
+	return nil
+}
+
+// rebaseStackedPullReq rebases the source branch of the pull request onto the provided target commit.
+// Only the commits of the pull request itself are replayed, i.e. the commits that aren't reachable from
+// the source commit of the merged parent pull request, because the parent might have been squash or rebase
+// merged, in which case the target branch doesn't contain the parent's original commits.
+// It's the same operation as the repository rebase API, performed on behalf of the pull request author,
+// so the author's permissions and the branch protection rules apply.
+// It returns an empty SHA if the source branch hasn't been updated.
+func (s *Service) rebaseStackedPullReq(
+	ctx context.Context,
+	pr *types.PullReq,
+	parent *types.PullReq,
+	repo *types.Repository,
+	targetSHA sha.SHA,
+) (sha.SHA, []string, error) {
+	if pr.SourceRepoID == nil || *pr.SourceRepoID != pr.TargetRepoID {
+		return sha.None, nil, errors.New("source branch isn't in the target repository")
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to parse pull request source SHA: %w", err)
+	}
+
+	parentSourceSHA, err := sha.New(parent.SourceSHA)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to parse parent pull request source SHA: %w", err)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	isAncestor, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   targetSHA,
+		DescendantCommitSHA: sourceSHA,
+	})
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to check ancestor: %w", err)
+	}
+
+	if isAncestor.Ancestor {
+		// The source branch already contains the latest commit from the target branch - nothing to do.
+		return sha.None, nil, nil
+	}
+
+	author, err := s.principalStore.Find(ctx, pr.CreatedBy)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to find pull request author: %w", err)
+	}
+
+	session := &auth.Session{Principal: *author}
+	repoCore := repo.Core()
+
+	if err = apiauth.CheckRepo(ctx, s.authorizer, session, repoCore, enum.PermissionRepoPush); err != nil {
+		return sha.None, nil, fmt.Errorf("pull request author isn't allowed to push: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repoCore)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ListRepoBranchRules(ctx, repo.ID)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              author,
+		AllowBypass:        false,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repoCore,
+		RefAction:          protection.RefActionUpdateForce,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return sha.None, nil, fmt.Errorf("rebase blocked by protection rules: %s",
+			protection.GenerateErrorMessageForBlockingViolations(violations))
+	}
+
+	// Branch rules are verified above, the same as the repository rebase API does.
+	writeParams, err := githook.CreateWriteParamsForOperation(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		git.Identity{
+			Name:  author.DisplayName,
+			Email: author.Email,
+		},
+		repo.ID,
+		repo.GitUID,
+		author.ID,
+		false,
+		enum.GitOpTypeAPIRefsOnly,
+	)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams: writeParams,
+		BaseSHA:     targetSHA,
+		HeadBranch:  pr.SourceBranch,
+		Refs: []git.RefUpdate{{
+			Name: git.GetBranchRefPath(pr.SourceBranch),
+			Old:  sourceSHA,
+			New:  sha.SHA{}, // update to the result of the merge
+		}},
+		HeadBranchExpectedSHA: sourceSHA,
+		Method:                gitenum.MergeMethodRebase,
+		RebaseUpstreamSHA:     parentSourceSHA,
+	})
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("rebase execution failed: %w", err)
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		return sha.None, mergeOutput.ConflictFiles, nil
+	}
+
+	return mergeOutput.MergeSHA, nil, nil
+}
+
+// isStackedOnMergedPullReq returns true if the pull request depends on an already merged pull request.
+// Such pull requests are retargeted to the parent's target branch by retargetStackOnMerge.
+func (s *Service) isStackedOnMergedPullReq(ctx context.Context, pr *types.PullReq) bool {
+	if pr.StackParentID == nil {
+		return false
+	}
+
+	parent, err := s.pullreqStore.Find(ctx, *pr.StackParentID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq", pr.Number).
+			Msg("failed to find stack parent pull request")
+		return false
+	}
+
+	return parent.State == enum.PullReqStateMerged
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	mockgit "github.com/harness/gitness/mocks/git"
+	mocksse "github.com/harness/gitness/mocks/sse"
+	mockstore "github.com/harness/gitness/mocks/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// makeStackParent builds a merged parent pull request that was merged into the main branch.
+func makeStackParent() *types.PullReq {
+	srcRepoID := int64(1)
+	return &types.PullReq{
+		ID:           5,
+		Number:       3,
+		State:        enum.PullReqStateMerged,
+		TargetRepoID: 1,
+		SourceRepoID: &srcRepoID,
+		TargetBranch: "main",
+		SourceBranch: "parent",
+		SourceSHA:    "dddddddddddddddddddddddddddddddddddddddd",
+	}
+}
+
+// TestRetargetStackedPullReq_Happy verifies that a pull request stacked on a merged pull request
+// is retargeted to the parent's target branch and no longer depends on it.
+func TestRetargetStackedPullReq_Happy(t *testing.T) {
+	t.Parallel()
+
+	pullreqStore := &mockstore.PullReqStore{}
+	activityStore := &mockstore.PullReqActivityStore{}
+	gitMock := &mockgit.Interface{}
+	sseMock := &mocksse.Streamer{}
+
+	repo := makeRepo("main")
+	parent := makeStackParent()
+	pr := makePR(42, "parent", sourceSHAStr, mergeBaseSHAStr)
+	pr.StackParentID = &parent.ID
+
+	gitMock.On("GetRef", mock.Anything, mock.MatchedBy(func(p git.GetRefParams) bool {
+		return p.Name == "main" && p.Type == gitenum.RefTypeBranch
+	})).Return(git.GetRefResponse{SHA: defaultSHA}, nil).Once()
+
+	gitMock.On("MergeBase", mock.Anything, mock.AnythingOfType("git.MergeBaseParams")).
+		Return(git.MergeBaseOutput{MergeBaseSHA: mergeBaseSHA}, nil).Once()
+
+	gitMock.On("DiffStats", mock.Anything, mock.AnythingOfType("*git.DiffParams")).
+		Return(git.DiffStatsOutput{Commits: 1, FilesChanged: 2}, nil).Once()
+
+	updated := &types.PullReq{}
+	pullreqStore.On("UpdateOptLock", pr, mock.AnythingOfType("func(*types.PullReq) error")).
+		Run(func(args mock.Arguments) {
+			mutateFn, _ := args.Get(1).(func(*types.PullReq) error)
+			*updated = *pr
+			if err := mutateFn(updated); err != nil {
+				t.Errorf("unexpected mutation error: %v", err)
+			}
+		}).
+		Return(updated, nil).Once()
+
+	// The source branch already contains the latest commit of the new target branch - no rebase.
+	gitMock.On("IsAncestor", mock.Anything, mock.AnythingOfType("git.IsAncestorParams")).
+		Return(git.IsAncestorOutput{Ancestor: true}, nil).Once()
+
+	activityStore.On("CreateWithPayload",
+		mock.AnythingOfType("*types.PullReq"),
+		int64(1),
+		mock.MatchedBy(func(p *types.PullRequestActivityPayloadStackParentMerged) bool {
+			return p.ParentNumber == 3 && p.OldTargetBranch == "parent" && p.NewTargetBranch == "main" &&
+				!p.Rebased
+		}),
+		(*types.PullReqActivityMetadata)(nil),
+	).Return((*types.PullReqActivity)(nil), nil).Once()
+
+	gitMock.On("UpdateRef", mock.Anything, mock.AnythingOfType("git.UpdateRefParams")).
+		Return(nil).Once()
+
+	sseMock.On("Publish", int64(10), enum.SSETypePullReqUpdated, mock.Anything).Once()
+
+	svc := newTestService(pullreqStore, nil, activityStore, gitMock, sseMock)
+
+	err := svc.retargetStackedPullReq(context.Background(), pr, parent, repo, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if updated.TargetBranch != "main" {
+		t.Errorf("expected target branch main, got %s", updated.TargetBranch)
+	}
+	if updated.StackParentID != nil {
+		t.Errorf("expected the stack parent to be removed, got %d", *updated.StackParentID)
+	}
+	if updated.MergeBaseSHA != mergeBaseSHAStr {
+		t.Errorf("expected merge base %s, got %s", mergeBaseSHAStr, updated.MergeBaseSHA)
+	}
+
+	pullreqStore.AssertExpectations(t)
+	activityStore.AssertExpectations(t)
+	gitMock.AssertExpectations(t)
+	sseMock.AssertExpectations(t)
+}
+
+// TestRetargetStackedPullReq_ParentChanged verifies that a pull request whose stack parent
+// has been changed concurrently is left untouched.
+func TestRetargetStackedPullReq_ParentChanged(t *testing.T) {
+	t.Parallel()
+
+	pullreqStore := &mockstore.PullReqStore{}
+	activityStore := &mockstore.PullReqActivityStore{}
+	gitMock := &mockgit.Interface{}
+
+	repo := makeRepo("main")
+	parent := makeStackParent()
+	otherParentID := int64(9)
+	pr := makePR(42, "parent", sourceSHAStr, mergeBaseSHAStr)
+	pr.StackParentID = &otherParentID
+
+	gitMock.On("GetRef", mock.Anything, mock.AnythingOfType("git.GetRefParams")).
+		Return(git.GetRefResponse{SHA: defaultSHA}, nil).Once()
+	gitMock.On("MergeBase", mock.Anything, mock.AnythingOfType("git.MergeBaseParams")).
+		Return(git.MergeBaseOutput{MergeBaseSHA: mergeBaseSHA}, nil).Once()
+	gitMock.On("DiffStats", mock.Anything, mock.AnythingOfType("*git.DiffParams")).
+		Return(git.DiffStatsOutput{}, nil).Once()
+
+	pullreqStore.On("UpdateOptLock", pr, mock.AnythingOfType("func(*types.PullReq) error")).
+		Run(func(args mock.Arguments) {
+			mutateFn, _ := args.Get(1).(func(*types.PullReq) error)
+			dup := *pr
+			if err := mutateFn(&dup); !errors.Is(err, errStackParentChanged) {
+				t.Errorf("expected errStackParentChanged, got %v", err)
+			}
+		}).
+		Return(nil, errStackParentChanged).Once()
+
+	svc := newTestService(pullreqStore, nil, activityStore, gitMock, nil)
+
+	err := svc.retargetStackedPullReq(context.Background(), pr, parent, repo, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pullreqStore.AssertExpectations(t)
+	gitMock.AssertExpectations(t)
+	activityStore.AssertNotCalled(t, "CreateWithPayload",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRetargetStackOnMerge_ChildFails verifies that all stacked pull requests are processed
+// even if one of them fails and that the failure is reported, so that the event is redelivered.
+func TestRetargetStackOnMerge_ChildFails(t *testing.T) {
+	t.Parallel()
+
+	pullreqStore := &mockstore.PullReqStore{}
+	repoStore := &mockstore.RepoStore{}
+	gitMock := &mockgit.Interface{}
+
+	parent := makeStackParent()
+	pr1 := makePR(42, "parent", sourceSHAStr, mergeBaseSHAStr)
+	pr1.StackParentID = &parent.ID
+	pr2 := makePR(43, "parent", sourceSHAStr, mergeBaseSHAStr)
+	pr2.StackParentID = &parent.ID
+
+	pullreqStore.On("Find", parent.ID).Return(parent, nil).Once()
+	pullreqStore.On("List", mock.MatchedBy(func(f *types.PullReqFilter) bool {
+		return f.Page == 1 && f.StackParentID == parent.ID
+	})).Return([]*types.PullReq{pr1, pr2}, nil).Once()
+
+	repoStore.On("Find", int64(1)).Return(makeRepo("main"), nil).Once()
+
+	gitMock.On("GetRef", mock.Anything, mock.AnythingOfType("git.GetRefParams")).
+		Return(git.GetRefResponse{}, errors.New("git failure")).Twice()
+
+	svc := newTestService(pullreqStore, repoStore, nil, gitMock, nil)
+
+	err := svc.retargetStackOnMerge(context.Background(), &events.Event[*pullreqevents.MergedPayload]{
+		Payload: &pullreqevents.MergedPayload{
+			Base: pullreqevents.Base{PullReqID: parent.ID, PrincipalID: 1},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	pullreqStore.AssertExpectations(t)
+	repoStore.AssertExpectations(t)
+	gitMock.AssertExpectations(t)
+}
+
+// TestUpdatePullReqTargetOnBranchDelete_StackedOnMerged verifies that a pull request stacked
+// on a merged pull request isn't retargeted to the default branch when the parent's branch is deleted.
+func TestUpdatePullReqTargetOnBranchDelete_StackedOnMerged(t *testing.T) {
+	t.Parallel()
+
+	pullreqStore := &mockstore.PullReqStore{}
+	repoStore := &mockstore.RepoStore{}
+	gitMock := &mockgit.Interface{}
+
+	parent := makeStackParent()
+	pr := makePR(42, "parent", sourceSHAStr, mergeBaseSHAStr)
+	pr.StackParentID = &parent.ID
+
+	pullreqStore.On("List", mock.AnythingOfType("*types.PullReqFilter")).
+		Return([]*types.PullReq{pr}, nil).Once()
+	pullreqStore.On("Find", parent.ID).Return(parent, nil).Once()
+
+	repoStore.On("Find", int64(1)).Return(makeRepo("develop"), nil).Once()
+
+	svc := newTestService(pullreqStore, repoStore, nil, gitMock, nil)
+
+	err := svc.updatePullReqTargetOnBranchDelete(context.Background(), makeEvent("parent"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pullreqStore.AssertExpectations(t)
+	repoStore.AssertExpectations(t)
+	gitMock.AssertNotCalled(t, "GetRef", mock.Anything, mock.Anything)
+}
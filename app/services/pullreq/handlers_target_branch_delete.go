@@ -96,6 +96,11 @@ func (s *Service) updatePullReqTargetOnBranchDelete(
 
 	// Update each PR to target the default branch
 	for _, pr := range pullreqList {
+		// PRs stacked on a merged PR are retargeted to the branch the parent was merged into.
+		if s.isStackedOnMergedPullReq(ctx, pr) {
+			continue
+		}
+
 		if err := s.updatePRToDefaultBranch(ctx, pr, repo, branch, event.Payload.PrincipalID); err != nil {
 			log.Ctx(ctx).Err(err).
 				Int64("pullreq", pr.Number).
@@ -206,8 +211,9 @@ func (s *Service) updatePRToDefaultBranch(
 
 		pr.ActivitySeq++
 
-		// Update to target the default branch
+		// Update to target the default branch, it's no longer stacked on the PR of the deleted branch
 		pr.TargetBranch = repo.DefaultBranch
+		pr.StackParentID = nil
 		pr.MergeBaseSHA = mergeBaseInfo.MergeBaseSHA.String()
 		pr.MergeTargetSHA = ptr.String(defaultBranchSHA.String())
 
@@ -25,7 +25,9 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -56,6 +58,8 @@ type Service struct {
 	fileViewStore           store.PullReqFileViewStore
 	sseStreamer             sse.Streamer
 	urlProvider             url.Provider
+	protectionManager       *protection.Manager
+	userGroupService        usergroup.Service
 
 	cancelMutex        sync.Mutex
 	cancelMergeability map[string]context.CancelFunc
@@ -86,6 +90,8 @@ func New(ctx context.Context,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
 ) (*Service, error) {
 	service := &Service{
 		pullreqEvReporter:       pullreqEvReporter,
@@ -107,6 +113,8 @@ func New(ctx context.Context,
 		cancelMergeability:      make(map[string]context.CancelFunc),
 		pubsub:                  bus,
 		sseStreamer:             sseStreamer,
+		protectionManager:       protectionManager,
+		userGroupService:        userGroupService,
 	}
 
 	var err error
@@ -219,6 +227,27 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// stacked pull requests
+
+	const groupPullReqStack = "gitness:pullreq:stack"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqStack, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(config.PullReq.StackConcurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(service.retargetStackOnMerge)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -63,6 +64,8 @@ func ProvideService(ctx context.Context,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
 ) (*Service, error) {
 	return New(ctx,
 		config,
@@ -86,6 +89,8 @@ func ProvideService(ctx context.Context,
 		pubsub,
 		urlProvider,
 		sseStreamer,
+		protectionManager,
+		userGroupService,
 	)
 }
 
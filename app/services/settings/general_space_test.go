@@ -33,7 +33,7 @@ func TestSpaceUpdateGeneralSettingsDeletesDefaultBranch(t *testing.T) {
 
 	ctx := context.Background()
 	store := newInMemorySettingsStore()
-	service := NewService(store, refcache.SpaceFinder{})
+	service := NewService(store, refcache.SpaceFinder{}, nil)
 
 	require.NoError(t, service.Set(ctx, enum.SettingsScopeSpace, 1, DefaultBranchKey, ptrString("develop")))
 
@@ -57,7 +57,7 @@ func TestSpaceUpdateGeneralSettingsRejectsInvalidBranch(t *testing.T) {
 
 	ctx := context.Background()
 	store := newInMemorySettingsStore()
-	service := NewService(store, refcache.SpaceFinder{})
+	service := NewService(store, refcache.SpaceFinder{}, nil)
 
 	_, _, err := SpaceUpdateGeneralSettings(ctx, service, 1, &GeneralSettingsSpace{
 		DefaultBranch: ptrString("bad branch"),
@@ -74,7 +74,7 @@ func TestSpaceGetDefaultBranchReturnsConfiguredBranch(t *testing.T) {
 
 	ctx := context.Background()
 	store := newInMemorySettingsStore()
-	service := NewService(store, refcache.SpaceFinder{})
+	service := NewService(store, refcache.SpaceFinder{}, nil)
 
 	require.NoError(t, service.Set(ctx, enum.SettingsScopeSpace, 1, DefaultBranchKey, ptrString("release/1.0")))
 
@@ -86,7 +86,7 @@ func TestSpaceGetDefaultBranchReturnsConfiguredBranch(t *testing.T) {
 func TestSpaceGetDefaultBranchFallsBackToGlobalDefault(t *testing.T) {
 	t.Parallel()
 
-	service := NewService(newInMemorySettingsStore(), refcache.SpaceFinder{})
+	service := NewService(newInMemorySettingsStore(), refcache.SpaceFinder{}, nil)
 
 	branch, err := service.SpaceGetDefaultBranch(context.Background(), 1, false)
 	require.NoError(t, err)
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxChatChannels is the maximum number of chat channels that can be configured on a single repo or space.
+const maxChatChannels = 10
+
+// NotificationSettings represents the notification related part of repository and space settings.
+type NotificationSettings struct {
+	ChatChannels *[]ChatChannel `json:"chat_channels" yaml:"chat_channels"`
+}
+
+// ChatChannel is an incoming-webhook of a chat application that receives pull request notifications.
+// The webhook URL acts as a credential: it's stored encrypted and only its redacted form is ever returned.
+type ChatChannel struct {
+	// Identifier identifies a configured channel, which allows keeping it on update without resending its URL.
+	Identifier string               `json:"identifier" yaml:"identifier"`
+	Type       enum.ChatChannelType `json:"type" yaml:"type"`
+	// URL is the full webhook URL on input, and the redacted webhook URL on output.
+	URL string `json:"url" yaml:"url"`
+}
+
+// storedChatChannel is the form in which chat channels are stored in the settings.
+type storedChatChannel struct {
+	ChatChannel
+	EncryptedURL []byte `json:"encrypted_url"`
+}
+
+func GetDefaultNotificationSettings() *NotificationSettings {
+	return &NotificationSettings{
+		ChatChannels: &[]ChatChannel{},
+	}
+}
+
+func GetNotificationSettingsMappings(s *NotificationSettings) []SettingHandler {
+	return []SettingHandler{
+		Mapping(KeyChatChannels, s.ChatChannels),
+	}
+}
+
+// GetNotificationSettingsAsKeyValues validates the notification settings update
+// and returns the settings in the form in which they are stored - with encrypted chat channel URLs.
+// Chat channels without URL are resolved by their identifier among the currently configured channels.
+func (s *Service) GetNotificationSettingsAsKeyValues(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	in *NotificationSettings,
+) ([]KeyValue, error) {
+	kvs := make([]KeyValue, 0, 1)
+
+	if in == nil || in.ChatChannels == nil {
+		return kvs, nil
+	}
+
+	channels := *in.ChatChannels
+	if len(channels) > maxChatChannels {
+		return nil, errors.InvalidArgumentf("At most %d chat channels can be configured.", maxChatChannels)
+	}
+
+	var existing []storedChatChannel
+	if _, err := s.Get(ctx, scope, scopeID, KeyChatChannels, &existing); err != nil {
+		return nil, fmt.Errorf("failed to find existing chat channels: %w", err)
+	}
+
+	stored := make([]storedChatChannel, len(channels))
+	for i, channel := range channels {
+		if channel.URL == "" {
+			idx := slices.IndexFunc(existing, func(c storedChatChannel) bool {
+				return channel.Identifier != "" && c.Identifier == channel.Identifier
+			})
+			if idx < 0 {
+				return nil, errors.InvalidArgumentf(
+					"Chat channel requires either a URL or the identifier %q of a configured channel.",
+					channel.Identifier)
+			}
+
+			stored[i] = existing[idx]
+			continue
+		}
+
+		channelType, ok := channel.Type.Sanitize()
+		if !ok {
+			return nil, errors.InvalidArgumentf("Unsupported chat channel type %q.", channel.Type)
+		}
+
+		u, err := url.Parse(channel.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.InvalidArgument("Chat channel URL must be a valid http or https URL.")
+		}
+
+		encryptedURL, err := s.encrypter.Encrypt(channel.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt chat channel URL: %w", err)
+		}
+
+		stored[i] = storedChatChannel{
+			ChatChannel: ChatChannel{
+				Identifier: newChatChannelIdentifier(),
+				Type:       channelType,
+				URL:        u.Scheme + "://" + u.Host + "/***",
+			},
+			EncryptedURL: encryptedURL,
+		}
+	}
+
+	kvs = append(kvs, KeyValue{
+		Key:   KeyChatChannels,
+		Value: stored,
+	})
+
+	return kvs, nil
+}
+
+func newChatChannelIdentifier() string {
+	var buf [8]byte
+	_, _ = rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
+
+// RepoChatChannels returns all chat channels that receive notifications of the repo:
+// the channels configured on the repo itself and on every space it's part of.
+func (s *Service) RepoChatChannels(
+	ctx context.Context,
+	repoID int64,
+	parentSpaceID int64,
+) ([]ChatChannel, error) {
+	var channels []storedChatChannel
+
+	_, err := s.RepoGet(ctx, repoID, KeyChatChannels, &channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chat channels of repo %d: %w", repoID, err)
+	}
+
+	for spaceID := parentSpaceID; spaceID > 0; {
+		var spaceChannels []storedChatChannel
+		_, err = s.SpaceGet(ctx, spaceID, KeyChatChannels, false, &spaceChannels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find chat channels of space %d: %w", spaceID, err)
+		}
+
+		channels = append(channels, spaceChannels...)
+
+		space, err := s.spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space with id %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	// the same channel might be configured on multiple levels, post to it only once.
+	seen := make(map[string]struct{}, len(channels))
+	result := make([]ChatChannel, 0, len(channels))
+	for _, channel := range channels {
+		channelURL, err := s.encrypter.Decrypt(channel.EncryptedURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt URL of chat channel %s: %w", channel.Identifier, err)
+		}
+
+		if _, ok := seen[channelURL]; ok {
+			continue
+		}
+		seen[channelURL] = struct{}{}
+
+		channel.URL = channelURL
+		result = append(result, channel.ChatChannel)
+	}
+
+	return result, nil
+}
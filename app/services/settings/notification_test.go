@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoUpdateNotificationSettingsEncryptsURLs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newInMemorySettingsStore()
+	encrypter, err := encrypt.New("0123456789abcdef0123456789abcdef", false)
+	require.NoError(t, err)
+	service := NewService(store, refcache.SpaceFinder{}, encrypter)
+
+	const secretURL = "https://hooks.slack.com/services/T000/B000/secret"
+
+	update := func(channels ...ChatChannel) (*NotificationSettings, error) {
+		kvs, err := service.GetNotificationSettingsAsKeyValues(ctx, enum.SettingsScopeRepo, 1,
+			&NotificationSettings{ChatChannels: &channels})
+		if err != nil {
+			return nil, err
+		}
+
+		_, out, err := RepoUpdateWithDefaults(ctx, service, 1,
+			GetDefaultNotificationSettings, GetNotificationSettingsMappings, kvs...)
+		return out, err
+	}
+
+	out, err := update(ChatChannel{Type: enum.ChatChannelTypeSlack, URL: secretURL})
+	require.NoError(t, err)
+	require.Len(t, *out.ChatChannels, 1)
+
+	channel := (*out.ChatChannels)[0]
+	require.NotEmpty(t, channel.Identifier)
+	require.Equal(t, enum.ChatChannelTypeSlack, channel.Type)
+	require.Equal(t, "https://hooks.slack.com/***", channel.URL)
+
+	for key, raw := range store.values {
+		require.False(t, strings.Contains(string(raw), "secret"), "plain URL is stored in setting %s", key)
+	}
+
+	// a channel is kept by its identifier, without resending the URL
+	out, err = update(
+		ChatChannel{Identifier: channel.Identifier},
+		ChatChannel{Type: enum.ChatChannelTypeGeneric, URL: "https://example.com/hook"},
+	)
+	require.NoError(t, err)
+	require.Len(t, *out.ChatChannels, 2)
+	require.Equal(t, channel, (*out.ChatChannels)[0])
+
+	channels, err := service.RepoChatChannels(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []ChatChannel{
+		{Identifier: channel.Identifier, Type: enum.ChatChannelTypeSlack, URL: secretURL},
+		{Identifier: (*out.ChatChannels)[1].Identifier, Type: enum.ChatChannelTypeGeneric, URL: "https://example.com/hook"},
+	}, channels)
+
+	_, err = update(ChatChannel{Identifier: "unknown"})
+	require.True(t, errors.IsInvalidArgument(err), "expected invalid argument error, got %v", err)
+
+	_, err = update(ChatChannel{Type: enum.ChatChannelTypeSlack, URL: "ftp://example.com"})
+	require.True(t, errors.IsInvalidArgument(err), "expected invalid argument error, got %v", err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/services/refcache"
 	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types/enum"
 )
@@ -44,15 +45,18 @@ type SettingHandler interface {
 type Service struct {
 	settingsStore appstore.SettingsStore
 	spaceFinder   refcache.SpaceFinder
+	encrypter     encrypt.Encrypter
 }
 
 func NewService(
 	settingsStore appstore.SettingsStore,
 	spaceFinder refcache.SpaceFinder,
+	encrypter encrypt.Encrypter,
 ) *Service {
 	return &Service{
 		settingsStore: settingsStore,
 		spaceFinder:   spaceFinder,
+		encrypter:     encrypter,
 	}
 }
 
@@ -32,4 +32,6 @@ var (
 	DefaultAutoMergeEnabled            = false
 	DefaultBranchKey               Key = "default_branch"
 	DefaultBranch                      = string("main")
+	// KeyChatChannels [[]ChatChannel] lists the chat channels that receive pull request notifications.
+	KeyChatChannels Key = "chat_channels"
 )
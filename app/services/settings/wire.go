@@ -17,6 +17,7 @@ package settings
 import (
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
 )
@@ -28,6 +29,7 @@ var WireSet = wire.NewSet(
 func ProvideService(
 	settingsStore store.SettingsStore,
 	spaceFinder refcache.SpaceFinder,
+	encrypter encrypt.Encrypter,
 ) *Service {
-	return NewService(settingsStore, spaceFinder)
+	return NewService(settingsStore, spaceFinder, encrypter)
 }
@@ -0,0 +1,414 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/services/externaluser"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeLDAPSync = "gitness:usergroup:ldap-sync"
+
+// membershipRoleRank orders the membership roles, if a user is in multiple groups
+// that are mapped to the same space the highest role is granted.
+var membershipRoleRank = map[enum.MembershipRole]int{
+	enum.MembershipRoleReader:      1,
+	enum.MembershipRoleExecutor:    2,
+	enum.MembershipRoleContributor: 3,
+	enum.MembershipRoleSpaceOwner:  4,
+}
+
+type ldapSpaceRole struct {
+	group    string
+	spaceRef string
+	role     enum.MembershipRole
+}
+
+// LDAPSyncer authenticates users against an LDAP directory and keeps their user group and space memberships
+// in sync with the groups of the directory. It runs periodically as a job, which also blocks users
+// that got removed from the directory.
+type LDAPSyncer struct {
+	client                *ldap.Client
+	systemUID             string
+	groupMapping          map[string]string
+	spaceRoles            []ldapSpaceRole
+	principalStore        store.PrincipalStore
+	externalIdentityStore store.ExternalIdentityStore
+	membershipStore       store.MembershipStore
+	spaceFinder           refcache.SpaceFinder
+	memberSyncer          *MemberSyncer
+	provisioner           *externaluser.Provisioner
+}
+
+func NewLDAPSyncer(
+	client *ldap.Client,
+	systemUID string,
+	groupMapping map[string]string,
+	spaceRoleMapping map[string]string,
+	principalStore store.PrincipalStore,
+	externalIdentityStore store.ExternalIdentityStore,
+	membershipStore store.MembershipStore,
+	spaceFinder refcache.SpaceFinder,
+	memberSyncer *MemberSyncer,
+	provisioner *externaluser.Provisioner,
+) (*LDAPSyncer, error) {
+	// group names are compared case-insensitive, as the directories do.
+	groups := make(map[string]string, len(groupMapping))
+	for group, userGroupRef := range groupMapping {
+		groups[strings.ToLower(group)] = userGroupRef
+	}
+
+	spaceRoles := make([]ldapSpaceRole, 0, len(spaceRoleMapping))
+	for group, value := range spaceRoleMapping {
+		i := strings.LastIndex(value, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid space role mapping %q of group %q, expected <space path>=<role>", value, group)
+		}
+
+		role, ok := enum.MembershipRole(value[i+1:]).Sanitize()
+		if !ok {
+			return nil, fmt.Errorf("invalid role in space role mapping of group %q: %q", group, value[i+1:])
+		}
+
+		spaceRoles = append(spaceRoles, ldapSpaceRole{
+			group:    strings.ToLower(group),
+			spaceRef: value[:i],
+			role:     role,
+		})
+	}
+
+	return &LDAPSyncer{
+		client:                client,
+		systemUID:             systemUID,
+		groupMapping:          groups,
+		spaceRoles:            spaceRoles,
+		principalStore:        principalStore,
+		externalIdentityStore: externalIdentityStore,
+		membershipStore:       membershipStore,
+		spaceFinder:           spaceFinder,
+		memberSyncer:          memberSyncer,
+		provisioner:           provisioner,
+	}, nil
+}
+
+// Register registers the sync job and schedules it.
+func (s *LDAPSyncer) Register(
+	ctx context.Context,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+	cron string,
+	maxDuration time.Duration,
+) error {
+	if err := executor.Register(jobTypeLDAPSync, s); err != nil {
+		return fmt.Errorf("failed to register ldap sync job: %w", err)
+	}
+
+	if cron == "" {
+		return nil
+	}
+
+	if err := scheduler.AddRecurring(ctx, jobTypeLDAPSync, jobTypeLDAPSync, cron, maxDuration); err != nil {
+		return fmt.Errorf("failed to schedule ldap sync job: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate verifies the credentials against the directory and returns the linked user,
+// which is created on the first login.
+func (s *LDAPSyncer) Authenticate(ctx context.Context, username, password string) (*types.User, error) {
+	ldapUser, err := s.client.Authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.provisioner.FindOrCreate(ctx, ldapIdentity(ldapUser))
+}
+
+// Login authenticates the user like Authenticate and additionally syncs its memberships with the directory groups.
+// Blocked users are returned without syncing the memberships, it's up to the caller to reject them.
+func (s *LDAPSyncer) Login(ctx context.Context, username, password string) (*types.User, error) {
+	ldapUser, err := s.client.Authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.provisioner.FindOrCreate(ctx, ldapIdentity(ldapUser))
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return user, nil
+	}
+
+	groups, err := s.client.ListUserGroups(ldapUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ldap groups of user: %w", err)
+	}
+
+	systemID, err := s.systemPrincipalID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.syncUser(ctx, user.ID, systemID, groups); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Handle syncs all users of the directory that are either already linked or are members of a mapped group.
+// Linked users that aren't in the directory anymore are blocked and removed from all mapped groups and spaces.
+func (s *LDAPSyncer) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	ldapUsers, err := s.client.ListUsers()
+	if err != nil {
+		return "", fmt.Errorf("failed to list ldap users: %w", err)
+	}
+
+	// protect against blocking all users because of a misconfigured filter or base DN.
+	if len(ldapUsers) == 0 {
+		return "", errors.New("ldap directory returned no users, aborting sync")
+	}
+
+	ldapGroups, err := s.client.ListGroups()
+	if err != nil {
+		return "", fmt.Errorf("failed to list ldap groups: %w", err)
+	}
+
+	identities, err := s.externalIdentityStore.ListByProvider(ctx, ldap.ProviderName)
+	if err != nil {
+		return "", fmt.Errorf("failed to list ldap identities: %w", err)
+	}
+
+	linked := make(map[string]struct{}, len(identities))
+	for _, identity := range identities {
+		linked[identity.Subject] = struct{}{}
+	}
+
+	systemID, err := s.systemPrincipalID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	present := make(map[string]struct{}, len(ldapUsers))
+	var synced, blocked int
+
+	for _, ldapUser := range ldapUsers {
+		identity := ldapIdentity(ldapUser)
+		present[identity.Subject] = struct{}{}
+
+		var groups []string
+		for _, group := range ldapGroups {
+			if group.HasMember(ldapUser) {
+				groups = append(groups, group.Name)
+			}
+		}
+
+		if _, ok := linked[identity.Subject]; !ok && !s.isMapped(groups) {
+			continue
+		}
+
+		user, err := s.provisioner.FindOrCreate(ctx, identity)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("ldap_dn", ldapUser.DN).
+				Msg("failed to provision ldap user, skipping it")
+			continue
+		}
+
+		if user.Blocked {
+			continue
+		}
+
+		if err = s.syncUser(ctx, user.ID, systemID, groups); err != nil {
+			return "", fmt.Errorf("failed to sync user %q: %w", user.UID, err)
+		}
+
+		synced++
+	}
+
+	for _, identity := range identities {
+		if _, ok := present[identity.Subject]; ok {
+			continue
+		}
+
+		user, err := s.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find user of ldap identity: %w", err)
+		}
+
+		if !user.Blocked {
+			user.Blocked = true
+			user.Updated = time.Now().UnixMilli()
+			if err = s.principalStore.UpdateUser(ctx, user); err != nil {
+				return "", fmt.Errorf("failed to block user %q: %w", user.UID, err)
+			}
+
+			log.Ctx(ctx).Info().
+				Str("user_uid", user.UID).
+				Msg("blocked user that was removed from the ldap directory")
+
+			blocked++
+		}
+
+		if err = s.syncUser(ctx, user.ID, systemID, nil); err != nil {
+			return "", fmt.Errorf("failed to remove memberships of user %q: %w", user.UID, err)
+		}
+	}
+
+	return fmt.Sprintf("synced %d users, blocked %d users", synced, blocked), nil
+}
+
+func (s *LDAPSyncer) isMapped(groups []string) bool {
+	for _, group := range groups {
+		group = strings.ToLower(group)
+		if _, ok := s.groupMapping[group]; ok {
+			return true
+		}
+		for _, spaceRole := range s.spaceRoles {
+			if spaceRole.group == group {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *LDAPSyncer) syncUser(ctx context.Context, principalID, systemID int64, groups []string) error {
+	lowerGroups := make([]string, len(groups))
+	for i, group := range groups {
+		lowerGroups[i] = strings.ToLower(group)
+	}
+
+	if err := s.memberSyncer.Sync(ctx, principalID, s.groupMapping, lowerGroups); err != nil {
+		return fmt.Errorf("failed to sync usergroup memberships: %w", err)
+	}
+
+	if err := s.syncSpaceRoles(ctx, principalID, systemID, lowerGroups); err != nil {
+		return fmt.Errorf("failed to sync space memberships: %w", err)
+	}
+
+	return nil
+}
+
+// syncSpaceRoles grants the principal the highest role of its groups in each mapped space.
+// Only memberships created by the sync are updated or removed, memberships added manually are left untouched.
+func (s *LDAPSyncer) syncSpaceRoles(ctx context.Context, principalID, systemID int64, groups []string) error {
+	if len(s.spaceRoles) == 0 {
+		return nil
+	}
+
+	isMember := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		isMember[group] = struct{}{}
+	}
+
+	desired := make(map[string]enum.MembershipRole)
+	for _, spaceRole := range s.spaceRoles {
+		role := desired[spaceRole.spaceRef]
+		if _, ok := isMember[spaceRole.group]; ok && membershipRoleRank[spaceRole.role] > membershipRoleRank[role] {
+			role = spaceRole.role
+		}
+		desired[spaceRole.spaceRef] = role
+	}
+
+	now := time.Now().UnixMilli()
+
+	for spaceRef, role := range desired {
+		space, err := s.spaceFinder.FindByRef(ctx, spaceRef)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().
+				Str("space", spaceRef).
+				Msg("space of ldap space role mapping not found, skipping it")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space %q: %w", spaceRef, err)
+		}
+
+		key := types.MembershipKey{SpaceID: space.ID, PrincipalID: principalID}
+
+		membership, err := s.membershipStore.Find(ctx, key)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			membership, err = nil, nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find membership: %w", err)
+		}
+
+		switch {
+		case membership == nil && role != "":
+			err = s.membershipStore.Create(ctx, &types.Membership{
+				MembershipKey: key,
+				CreatedBy:     systemID,
+				Created:       now,
+				Updated:       now,
+				Role:          role,
+			})
+		case membership == nil || membership.CreatedBy != systemID:
+			// nothing to remove, or the membership was added manually.
+		case role == "":
+			err = s.membershipStore.Delete(ctx, key)
+		case membership.Role != role:
+			membership.Role = role
+			membership.Updated = now
+			err = s.membershipStore.Update(ctx, membership)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update membership in space %q: %w", spaceRef, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *LDAPSyncer) systemPrincipalID(ctx context.Context) (int64, error) {
+	principal, err := s.principalStore.FindByUID(ctx, s.systemUID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find system principal: %w", err)
+	}
+
+	return principal.ID, nil
+}
+
+func ldapIdentity(user *ldap.User) *externaluser.Identity {
+	return &externaluser.Identity{
+		Provider: ldap.ProviderName,
+		Subject:  strings.ToLower(user.Username),
+		Email:    user.Email,
+		// the email attribute of a directory entry isn't verified, so an existing user
+		// with the same email address is never linked to the ldap identity automatically.
+		EmailVerified: false,
+		DisplayName:   user.DisplayName,
+		Username:      user.Username,
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/ldap/ldaptest"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/externaluser"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	testSystemUID    = "gitness"
+	testBindDN       = "cn=gitness,ou=services,dc=example,dc=com"
+	testBindPassword = "service-secret"
+)
+
+type ldapSyncTest struct {
+	directory       *ldaptest.Server
+	syncer          *usergroup.LDAPSyncer
+	principalStore  *database.PrincipalStore
+	membershipStore *database.MembershipStore
+	memberStore     *database.UserGroupMemberStore
+	systemID        int64
+	adminID         int64
+	spaceID         int64
+	userGroupID     int64
+}
+
+func setupLDAPSyncTest(t *testing.T) *ldapSyncTest {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db, err := sqlx.Connect("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, _ = db.Exec("PRAGMA foreign_keys = ON;")
+	_, _ = db.Exec("PRAGMA busy_timeout = 5000;")
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %s", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	evictor := cache.NewEvictor[*types.SpaceCore]("namespace", "space-topic", nil)
+	spacePathCache := cache.New(ctx, spacePathStore, store.ToLowerSpacePathTransformation, evictor, time.Minute)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	principalInfoCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	membershipStore := database.NewMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
+	userGroupStore := database.NewUserGroupStore(db)
+	memberStore := database.NewUserGroupMemberStore(db)
+	externalIdentityStore := database.NewExternalIdentityStore(db)
+
+	spaceFinder := refcache.NewSpaceFinder(
+		cache.NewSpaceIDCache(ctx, spaceStore, evictor, time.Minute),
+		spacePathCache,
+		cache.NewSpaceCaseInsensitiveCache(ctx, spaceStore, evictor, time.Minute),
+		evictor,
+	)
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil, events.ProvideNoopCollector())
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err)
+	}
+
+	eventReporter, err := userevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create event reporter: %s", err)
+	}
+
+	system := &types.Service{UID: testSystemUID, Email: "system@gitness.io", DisplayName: "Gitness", Admin: true}
+	if err = principalStore.CreateService(ctx, system); err != nil {
+		t.Fatalf("failed to create system principal: %s", err)
+	}
+	systemPrincipal, err := principalStore.FindByUID(ctx, testSystemUID)
+	if err != nil {
+		t.Fatalf("failed to find system principal: %s", err)
+	}
+
+	admin := &types.User{UID: "admin", Email: "admin@example.com", DisplayName: "Admin"}
+	if err = principalStore.CreateUser(ctx, admin); err != nil {
+		t.Fatalf("failed to create admin: %s", err)
+	}
+	admin, err = principalStore.FindUserByUID(ctx, "admin")
+	if err != nil {
+		t.Fatalf("failed to find admin: %s", err)
+	}
+
+	space := &types.Space{Identifier: "acme", CreatedBy: admin.ID}
+	if err = spaceStore.Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %s", err)
+	}
+	err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: "acme", CreatedBy: admin.ID, SpaceID: space.ID, IsPrimary: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create space path: %s", err)
+	}
+
+	err = userGroupStore.Create(ctx, space.ID, &types.UserGroup{
+		Identifier: "developers",
+		Name:       "Developers",
+		SpaceID:    space.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create usergroup: %s", err)
+	}
+	userGroup, err := userGroupStore.FindByIdentifier(ctx, space.ID, "developers")
+	if err != nil {
+		t.Fatalf("failed to find usergroup: %s", err)
+	}
+
+	directory, err := ldaptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start ldap server: %s", err)
+	}
+	t.Cleanup(directory.Close)
+
+	directory.Add(testBindDN, map[string][]string{ldaptest.PasswordAttribute: {testBindPassword}})
+	addLDAPUser(directory, "alice")
+	addLDAPUser(directory, "bob")
+	addLDAPUser(directory, "carol")
+	setLDAPGroup(directory, "developers", "alice", "bob")
+	setLDAPGroup(directory, "admins", "alice")
+
+	client, err := ldap.NewClient(ldap.Config{
+		URL:                  directory.URL(),
+		BindDN:               testBindDN,
+		BindPassword:         testBindPassword,
+		UserBaseDN:           "ou=people,dc=example,dc=com",
+		UserFilter:           "(&(objectClass=person)(uid=%s))",
+		UsernameAttribute:    "uid",
+		EmailAttribute:       "mail",
+		DisplayNameAttribute: "cn",
+		GroupBaseDN:          "ou=groups,dc=example,dc=com",
+		GroupFilter:          "(objectClass=groupOfNames)",
+		GroupNameAttribute:   "cn",
+		GroupMemberAttribute: "member",
+	})
+	if err != nil {
+		t.Fatalf("failed to create ldap client: %s", err)
+	}
+
+	tx := dbtx.New(db)
+
+	syncer, err := usergroup.NewLDAPSyncer(
+		client,
+		testSystemUID,
+		map[string]string{"Developers": "acme/developers"},
+		map[string]string{
+			"developers": "acme=" + string(enum.MembershipRoleContributor),
+			"admins":     "acme=" + string(enum.MembershipRoleSpaceOwner),
+		},
+		principalStore,
+		externalIdentityStore,
+		membershipStore,
+		spaceFinder,
+		usergroup.NewMemberSyncer(tx, spaceFinder, userGroupStore, memberStore),
+		externaluser.NewProvisioner(tx, check.PrincipalUIDDefault, principalStore, externalIdentityStore, eventReporter),
+	)
+	if err != nil {
+		t.Fatalf("failed to create ldap syncer: %s", err)
+	}
+
+	return &ldapSyncTest{
+		directory:       directory,
+		syncer:          syncer,
+		principalStore:  principalStore,
+		membershipStore: membershipStore,
+		memberStore:     memberStore,
+		systemID:        systemPrincipal.ID,
+		adminID:         admin.ID,
+		spaceID:         space.ID,
+		userGroupID:     userGroup.ID,
+	}
+}
+
+func addLDAPUser(directory *ldaptest.Server, username string) {
+	directory.Add("uid="+username+",ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":              {"person"},
+		"uid":                      {username},
+		"cn":                       {username},
+		"mail":                     {username + "@example.com"},
+		ldaptest.PasswordAttribute: {username + "-secret"},
+	})
+}
+
+func setLDAPGroup(directory *ldaptest.Server, name string, usernames ...string) {
+	members := make([]string, len(usernames))
+	for i, username := range usernames {
+		members[i] = "uid=" + username + ",ou=people,dc=example,dc=com"
+	}
+
+	directory.Add("cn="+name+",ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {name},
+		"member":      members,
+	})
+}
+
+func (test *ldapSyncTest) sync(t *testing.T) {
+	t.Helper()
+
+	if _, err := test.syncer.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("sync failed: %s", err)
+	}
+}
+
+func (test *ldapSyncTest) user(t *testing.T, uid string) *types.User {
+	t.Helper()
+
+	user, err := test.principalStore.FindUserByUID(context.Background(), uid)
+	if err != nil {
+		t.Fatalf("failed to find user %q: %s", uid, err)
+	}
+
+	return user
+}
+
+// role returns the role of the user in the space, or an empty string if it isn't a member.
+func (test *ldapSyncTest) role(t *testing.T, uid string) enum.MembershipRole {
+	t.Helper()
+
+	membership, err := test.membershipStore.Find(context.Background(), types.MembershipKey{
+		SpaceID:     test.spaceID,
+		PrincipalID: test.user(t, uid).ID,
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return ""
+	}
+	if err != nil {
+		t.Fatalf("failed to find membership: %s", err)
+	}
+
+	return membership.Role
+}
+
+func (test *ldapSyncTest) userGroupMembers(t *testing.T) []string {
+	t.Helper()
+
+	principals, err := test.memberStore.MapPrincipals(context.Background(), []int64{test.userGroupID})
+	if err != nil {
+		t.Fatalf("failed to list usergroup members: %s", err)
+	}
+
+	var uids []string
+	for _, principal := range principals[test.userGroupID] {
+		uids = append(uids, principal.UID)
+	}
+	sort.Strings(uids)
+
+	return uids
+}
+
+func TestLDAPSyncer_Handle(t *testing.T) {
+	test := setupLDAPSyncTest(t)
+	ctx := context.Background()
+
+	test.sync(t)
+
+	// only members of mapped groups are provisioned.
+	if _, err := test.principalStore.FindUserByUID(ctx, "carol"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected carol not to be provisioned, got: %v", err)
+	}
+
+	if got := test.userGroupMembers(t); len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("expected usergroup members [alice bob], got %v", got)
+	}
+	if got := test.role(t, "alice"); got != enum.MembershipRoleSpaceOwner {
+		t.Errorf("expected alice to get the highest role, got %q", got)
+	}
+	if got := test.role(t, "bob"); got != enum.MembershipRoleContributor {
+		t.Errorf("expected bob to be contributor, got %q", got)
+	}
+
+	// carol logs in, and gets added to the space manually.
+	carol, err := test.syncer.Login(ctx, "carol", "carol-secret")
+	if err != nil {
+		t.Fatalf("login failed: %s", err)
+	}
+	err = test.membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: test.spaceID, PrincipalID: carol.ID},
+		CreatedBy:     test.adminID,
+		Role:          enum.MembershipRoleReader,
+	})
+	if err != nil {
+		t.Fatalf("failed to add membership: %s", err)
+	}
+
+	// bob leaves the company, alice isn't admin anymore.
+	test.directory.Remove("uid=bob,ou=people,dc=example,dc=com")
+	setLDAPGroup(test.directory, "developers", "alice")
+	setLDAPGroup(test.directory, "admins")
+
+	test.sync(t)
+
+	if !test.user(t, "bob").Blocked {
+		t.Error("expected bob to be blocked")
+	}
+	if test.user(t, "alice").Blocked || test.user(t, "carol").Blocked {
+		t.Error("expected users of the directory not to be blocked")
+	}
+	if got := test.userGroupMembers(t); len(got) != 1 || got[0] != "alice" {
+		t.Errorf("expected usergroup members [alice], got %v", got)
+	}
+	if got := test.role(t, "alice"); got != enum.MembershipRoleContributor {
+		t.Errorf("expected alice to be downgraded to contributor, got %q", got)
+	}
+	if got := test.role(t, "bob"); got != "" {
+		t.Errorf("expected membership of bob to be removed, got %q", got)
+	}
+	if got := test.role(t, "carol"); got != enum.MembershipRoleReader {
+		t.Errorf("expected manually added membership of carol to be kept, got %q", got)
+	}
+
+	// blocked users can't be authenticated via the directory either.
+	bob, err := test.syncer.Authenticate(ctx, "bob", "bob-secret")
+	if !errors.Is(err, ldap.ErrInvalidCredentials) {
+		t.Errorf("expected invalid credentials for removed user, got user %v and error %v", bob, err)
+	}
+}
+
+func TestLDAPSyncer_HandleEmptyDirectory(t *testing.T) {
+	test := setupLDAPSyncTest(t)
+
+	test.sync(t)
+
+	for _, username := range []string{"alice", "bob", "carol"} {
+		test.directory.Remove("uid=" + username + ",ou=people,dc=example,dc=com")
+	}
+
+	if _, err := test.syncer.Handle(context.Background(), "", nil); err == nil {
+		t.Fatal("expected sync to fail for an empty directory")
+	}
+
+	if test.user(t, "alice").Blocked || test.user(t, "bob").Blocked {
+		t.Error("expected no user to be blocked if the directory returns no users")
+	}
+}
+
+func TestLDAPSyncer_Login(t *testing.T) {
+	test := setupLDAPSyncTest(t)
+	ctx := context.Background()
+
+	if _, err := test.syncer.Login(ctx, "alice", "wrong"); !errors.Is(err, ldap.ErrInvalidCredentials) {
+		t.Errorf("expected invalid credentials, got: %v", err)
+	}
+
+	user, err := test.syncer.Login(ctx, "alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("login failed: %s", err)
+	}
+	if user.UID != "alice" || user.Email != "alice@example.com" {
+		t.Errorf("unexpected user provisioned: %+v", user)
+	}
+
+	// memberships are synced on login, without waiting for the job.
+	if got := test.role(t, "alice"); got != enum.MembershipRoleSpaceOwner {
+		t.Errorf("expected alice to be space owner after login, got %q", got)
+	}
+
+	again, err := test.syncer.Login(ctx, "ALICE", "alice-secret")
+	if err != nil {
+		t.Fatalf("second login failed: %s", err)
+	}
+	if again.ID != user.ID {
+		t.Errorf("expected the same user on the second login, got %d and %d", user.ID, again.ID)
+	}
+}
+
+func TestLDAPSyncer_LoginDoesntLinkByEmail(t *testing.T) {
+	test := setupLDAPSyncTest(t)
+	ctx := context.Background()
+
+	local := &types.User{UID: "carol-local", Email: "carol@example.com", DisplayName: "Carol"}
+	if err := test.principalStore.CreateUser(ctx, local); err != nil {
+		t.Fatalf("failed to create local user: %s", err)
+	}
+
+	_, err := test.syncer.Login(ctx, "carol", "carol-secret")
+	if !errors.Is(err, externaluser.ErrEmailConflict) {
+		t.Errorf("expected email conflict for an existing local user, got: %v", err)
+	}
+}
+
+func TestNewLDAPSyncer_InvalidSpaceRoleMapping(t *testing.T) {
+	for _, value := range []string{"acme", "=reader", "acme=admin"} {
+		_, err := usergroup.NewLDAPSyncer(nil, testSystemUID, nil, map[string]string{"developers": value},
+			nil, nil, nil, refcache.SpaceFinder{}, nil, nil)
+		if err == nil {
+			t.Errorf("expected error for space role mapping %q", value)
+		}
+	}
+}
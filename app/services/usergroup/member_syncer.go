@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MemberSyncer keeps the user group memberships of principals in sync
+// with the groups reported for them by an external identity provider.
+type MemberSyncer struct {
+	tx             dbtx.Transactor
+	spaceFinder    refcache.SpaceFinder
+	userGroupStore store.UserGroupStore
+	memberStore    store.UserGroupMemberStore
+}
+
+func NewMemberSyncer(
+	tx dbtx.Transactor,
+	spaceFinder refcache.SpaceFinder,
+	userGroupStore store.UserGroupStore,
+	memberStore store.UserGroupMemberStore,
+) *MemberSyncer {
+	return &MemberSyncer{
+		tx:             tx,
+		spaceFinder:    spaceFinder,
+		userGroupStore: userGroupStore,
+		memberStore:    memberStore,
+	}
+}
+
+// Sync adds the principal to the user groups the provided external groups are mapped to,
+// and removes it from all other user groups of the mapping.
+// The mapping maps external group names to user group references in the form "<space path>/<identifier>".
+// Memberships in user groups that aren't part of the mapping are left untouched.
+func (s *MemberSyncer) Sync(
+	ctx context.Context,
+	principalID int64,
+	mapping map[string]string,
+	groups []string,
+) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+
+	externalGroups := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		externalGroups[group] = struct{}{}
+	}
+
+	userGroupIDs := make(map[string]int64)
+	desired := make(map[int64]bool)
+
+	for externalGroup, userGroupRef := range mapping {
+		userGroupID, ok := userGroupIDs[userGroupRef]
+		if !ok {
+			userGroup, err := s.resolve(ctx, userGroupRef)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				log.Ctx(ctx).Warn().
+					Str("usergroup", userGroupRef).
+					Msg("usergroup of external group mapping not found, skipping it")
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to resolve usergroup %q: %w", userGroupRef, err)
+			}
+
+			userGroupID = userGroup.ID
+			userGroupIDs[userGroupRef] = userGroupID
+		}
+
+		_, isMember := externalGroups[externalGroup]
+		desired[userGroupID] = desired[userGroupID] || isMember
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		currentIDs, err := s.memberStore.ListUserGroupIDs(ctx, principalID)
+		if err != nil {
+			return fmt.Errorf("failed to list usergroups of principal: %w", err)
+		}
+
+		current := make(map[int64]struct{}, len(currentIDs))
+		for _, id := range currentIDs {
+			current[id] = struct{}{}
+		}
+
+		now := time.Now().UnixMilli()
+
+		for userGroupID, shouldBeMember := range desired {
+			_, isMember := current[userGroupID]
+
+			switch {
+			case shouldBeMember && !isMember:
+				err = s.memberStore.Create(ctx, &types.UserGroupMember{
+					UserGroupID: userGroupID,
+					PrincipalID: principalID,
+					Created:     now,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to add principal to usergroup: %w", err)
+				}
+			case !shouldBeMember && isMember:
+				err = s.memberStore.Delete(ctx, userGroupID, principalID)
+				if err != nil {
+					return fmt.Errorf("failed to remove principal from usergroup: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *MemberSyncer) resolve(ctx context.Context, userGroupRef string) (*types.UserGroup, error) {
+	spacePath, identifier, err := paths.DisectLeaf(userGroupRef)
+	if err != nil || spacePath == "" {
+		return nil, fmt.Errorf("invalid usergroup reference %q", userGroupRef)
+	}
+
+	space, err := s.spaceFinder.FindByRef(ctx, spacePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	return s.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+}
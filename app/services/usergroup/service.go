@@ -18,14 +18,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type service struct {
+	memberStore store.UserGroupMemberStore
 }
 
-func NewService() Service {
-	return &service{}
+func NewService(memberStore store.UserGroupMemberStore) Service {
+	return &service{
+		memberStore: memberStore,
+	}
 }
 
 func (s *service) List(
@@ -37,15 +41,25 @@ func (s *service) List(
 }
 
 func (s *service) ListUserIDsByGroupIDs(
-	_ context.Context,
-	_ []int64,
+	ctx context.Context,
+	userGroupIDs []int64,
 ) ([]int64, error) {
-	return []int64{}, nil
+	userIDs, err := s.memberStore.ListPrincipalIDs(ctx, userGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usergroup members: %w", err)
+	}
+
+	return userIDs, nil
 }
 
 func (s *service) MapGroupIDsToPrincipals(
-	_ context.Context,
-	_ []int64,
+	ctx context.Context,
+	groupIDs []int64,
 ) (map[int64][]*types.Principal, error) {
-	return map[int64][]*types.Principal{}, nil
+	principals, err := s.memberStore.MapPrincipals(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map usergroup members: %w", err)
+	}
+
+	return principals, nil
 }
@@ -15,6 +15,16 @@
 package usergroup
 
 import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/services/externaluser"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
 	"github.com/google/wire"
 )
 
@@ -22,12 +32,65 @@ import (
 var WireSet = wire.NewSet(
 	ProvideUserGroupResolver,
 	ProvideService,
+	ProvideMemberSyncer,
+	ProvideLDAPSyncer,
 )
 
 func ProvideUserGroupResolver() Resolver {
 	return NewGitnessResolver()
 }
 
-func ProvideService() Service {
-	return NewService()
+func ProvideService(memberStore store.UserGroupMemberStore) Service {
+	return NewService(memberStore)
+}
+
+func ProvideMemberSyncer(
+	tx dbtx.Transactor,
+	spaceFinder refcache.SpaceFinder,
+	userGroupStore store.UserGroupStore,
+	memberStore store.UserGroupMemberStore,
+) *MemberSyncer {
+	return NewMemberSyncer(tx, spaceFinder, userGroupStore, memberStore)
+}
+
+// ProvideLDAPSyncer provides the LDAP syncer and schedules its sync job, or nil if LDAP isn't enabled.
+func ProvideLDAPSyncer(
+	ctx context.Context,
+	config *types.Config,
+	client *ldap.Client,
+	principalStore store.PrincipalStore,
+	externalIdentityStore store.ExternalIdentityStore,
+	membershipStore store.MembershipStore,
+	spaceFinder refcache.SpaceFinder,
+	memberSyncer *MemberSyncer,
+	provisioner *externaluser.Provisioner,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+) (*LDAPSyncer, error) {
+	if client == nil {
+		return nil, nil //nolint:nilnil // nil syncer means LDAP is disabled
+	}
+
+	syncer, err := NewLDAPSyncer(
+		client,
+		config.Principal.System.UID,
+		config.LDAP.GroupMapping,
+		config.LDAP.SpaceRoleMapping,
+		principalStore,
+		externalIdentityStore,
+		membershipStore,
+		spaceFinder,
+		memberSyncer,
+		provisioner,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = syncer.Register(ctx, executor, scheduler, config.LDAP.SyncCron, config.LDAP.SyncMaxDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return syncer, nil
 }
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// webhookNeedsUpdate returns true if the execution changes the latest execution result of the webhook
+// or the state of its circuit breaker.
+func (w *WebhookExecutor) webhookNeedsUpdate(
+	webhook *types.WebhookCore,
+	execution *types.WebhookExecutionCore,
+) bool {
+	if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
+		return true
+	}
+
+	if !w.autoRetry {
+		return false
+	}
+
+	return execution.DeadLetter ||
+		(execution.Result == enum.WebhookExecutionResultSuccess && webhook.ConsecutiveFailures > 0)
+}
+
+// updateWebhookAfterExecution updates the latest execution result of the webhook (best effort).
+// With automatic retries enabled, it also counts the consecutive permanently failed deliveries
+// and disables the webhook once Config.CircuitBreakerThreshold is reached.
+func (w *WebhookExecutor) updateWebhookAfterExecution(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	execution *types.WebhookExecutionCore,
+) {
+	var tripped bool
+	_, err := w.webhookExecutorStore.UpdateOptLock(ctx, webhook, func(hook *types.WebhookCore) error {
+		tripped = false
+		hook.LatestExecutionResult = &execution.Result
+
+		if !w.autoRetry {
+			return nil
+		}
+
+		switch {
+		case execution.Result == enum.WebhookExecutionResultSuccess:
+			hook.ConsecutiveFailures = 0
+		case execution.DeadLetter:
+			hook.ConsecutiveFailures++
+			tripped = hook.Enabled &&
+				w.config.CircuitBreakerThreshold > 0 &&
+				hook.ConsecutiveFailures >= w.config.CircuitBreakerThreshold
+		}
+
+		if tripped {
+			hook.Enabled = false
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to update latest execution result to %s for webhook %d",
+			execution.Result, webhook.ID)
+		return
+	}
+
+	if tripped {
+		log.Ctx(ctx).Warn().
+			Int64("webhook_id", webhook.ID).
+			Msgf("webhook got disabled after %d consecutive failed deliveries", w.config.CircuitBreakerThreshold)
+	}
+}
@@ -138,12 +138,16 @@ func CheckExtraHeaders(headers []types.ExtraHeader) error {
 		if header.Key == "" {
 			return check.NewValidationError("Header key cannot be empty.")
 		}
+		// the content type is configured via the content type of the webhook
+		if strings.EqualFold(header.Key, "Content-Type") {
+			return check.NewValidationError("The Content-Type header is set by the content type of the webhook.")
+		}
 		// check for duplicate header keys (case insensitive)
-		for existingKey := range headerKeys {
-			if strings.EqualFold(existingKey, header.Key) {
-				return check.NewValidationErrorf("Duplicate header key '%s' detected.", header.Key)
-			}
+		key := strings.ToLower(header.Key)
+		if _, ok := headerKeys[key]; ok {
+			return check.NewValidationErrorf("Duplicate header key '%s' detected.", header.Key)
 		}
+		headerKeys[key] = struct{}{}
 	}
 
 	return nil
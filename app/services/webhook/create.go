@@ -78,6 +78,16 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckExtraHeaders(in.ExtraHeaders); err != nil {
 		return err
 	}
+	if err := CheckPayloadTemplate(
+		in.ContentType,
+		in.PayloadTemplateType,
+		in.PayloadTemplate,
+		in.Triggers,
+	); err != nil {
+		return err
+	}
+	in.ContentType, _ = in.ContentType.Sanitize()
+	in.PayloadTemplateType, _ = in.PayloadTemplateType.Sanitize()
 
 	return nil
 }
@@ -131,6 +141,9 @@ func (s *Service) Create(
 		Triggers:              DeduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
 		ExtraHeaders:          in.ExtraHeaders,
+		ContentType:           in.ContentType,
+		PayloadTemplateType:   in.PayloadTemplateType,
+		PayloadTemplate:       in.PayloadTemplate,
 	}
 
 	err = s.webhookStore.Create(ctx, hook)
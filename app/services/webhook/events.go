@@ -210,7 +210,8 @@ func (w *WebhookExecutor) TriggerForEvent(
 					result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// with automatic retries, the retry job takes care of retriable errors
+		if !w.autoRetry && result.Execution.Result == enum.WebhookExecutionResultRetriableError {
 			retryRequired = true
 		}
 	}
@@ -16,14 +16,21 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
 
+// maxRetriggerDeadLetters defines the maximum number of permanently failed executions retriggered at once.
+const maxRetriggerDeadLetters = 1000
+
 // FindExecution finds a webhook execution.
 func (s *Service) FindExecution(
 	ctx context.Context,
@@ -102,5 +109,117 @@ func (s *Service) RetriggerExecution(
 			webhook.ID, webhookExecution.ID, executionResult.Execution.ID)
 	}
 
+	// the retriggered execution replaces the permanently failed one (best effort)
+	if webhookExecution.DeadLetter {
+		err = s.webhookExecutionStore.UpdateDeadLetter(ctx, []int64{webhookExecution.ID}, false)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf(
+				"failed to unmark retriggered execution %d of webhook %d as permanently failed",
+				webhookExecution.ID, webhook.ID)
+		}
+	}
+
 	return executionResult.Execution, nil
 }
+
+// ListDeadLetters returns the permanently failed executions of the webhook.
+func (s *Service) ListDeadLetters(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	filter *types.WebhookExecutionFilter,
+) ([]*types.WebhookExecution, int64, error) {
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.webhookExecutionStore.CountDeadLetters(ctx, webhook.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count permanently failed executions for webhook %d: %w", webhook.ID, err)
+	}
+
+	webhookExecutions, err := s.webhookExecutionStore.ListDeadLetters(ctx, webhook.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list permanently failed executions for webhook %d: %w", webhook.ID, err)
+	}
+
+	return webhookExecutions, total, nil
+}
+
+// RetriggerDeadLetters queues the permanently failed executions of the webhook for retriggering.
+// If no execution IDs are provided, all permanently failed executions of the webhook get queued.
+func (s *Service) RetriggerDeadLetters(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	in *types.WebhookExecutionsRetriggerInput,
+) (*types.WebhookExecutionsRetriggerOutput, error) {
+	if len(in.ExecutionIDs) > maxRetriggerDeadLetters {
+		return nil, errors.InvalidArgumentf(
+			"At most %d executions can be retriggered at once.", maxRetriggerDeadLetters)
+	}
+
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, err := s.webhookExecutionStore.ListDeadLetters(ctx, webhook.ID, &types.WebhookExecutionFilter{
+		Page: 1,
+		Size: maxRetriggerDeadLetters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permanently failed executions for webhook %d: %w", webhook.ID, err)
+	}
+
+	requested := make(map[int64]bool, len(in.ExecutionIDs))
+	for _, id := range in.ExecutionIDs {
+		requested[id] = true
+	}
+
+	ids := make([]int64, 0, len(deadLetters))
+	for _, execution := range deadLetters {
+		if !execution.Retriggerable || (len(requested) > 0 && !requested[execution.ID]) {
+			continue
+		}
+		ids = append(ids, execution.ID)
+	}
+
+	if len(ids) == 0 {
+		return &types.WebhookExecutionsRetriggerOutput{}, nil
+	}
+
+	data, err := json.Marshal(retriggerDeadLettersInput{
+		WebhookID:    webhook.ID,
+		ExecutionIDs: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.webhookExecutionStore.UpdateDeadLetter(ctx, ids, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmark executions as permanently failed: %w", err)
+	}
+
+	err = s.jobScheduler.RunJob(ctx, job.Definition{
+		UID:     fmt.Sprintf("%s%d-%d", jobUIDPrefixRetriggerDeadLetters, webhook.ID, time.Now().UnixMilli()),
+		Type:    jobTypeRetriggerDeadLetters,
+		Timeout: jobMaxDurationRetriggerDeadLetters,
+		Data:    string(data),
+	})
+	if err != nil {
+		// restore the executions as permanently failed so they can be retriggered again (best effort)
+		if rErr := s.webhookExecutionStore.UpdateDeadLetter(ctx, ids, true); rErr != nil {
+			log.Ctx(ctx).Warn().Err(rErr).Msgf(
+				"failed to restore permanently failed executions of webhook %d", webhook.ID)
+		}
+
+		return nil, fmt.Errorf("failed to start job to retrigger executions: %w", err)
+	}
+
+	return &types.WebhookExecutionsRetriggerOutput{Queued: len(ids)}, nil
+}
@@ -16,11 +16,16 @@ package webhook
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	mergequeueevents "github.com/harness/gitness/app/events/mergequeue"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MergeQueueChecksPayload describes the body of merge queue checks requested/canceled triggers.
@@ -33,6 +38,10 @@ type MergeQueueChecksPayload struct {
 type MergeQueueChecksSegment struct {
 	Branch    string `json:"branch"`
 	CommitSHA string `json:"commit_sha"`
+	// PullReqs are the pull requests of the merge group tested by the checks, in the merge queue order.
+	PullReqs []PullReqInfo `json:"pull_reqs,omitempty"`
+	// Bisect is true if the merge group is tested to find the pull request that caused a merge group to fail.
+	Bisect bool `json:"bisect,omitempty"`
 }
 
 // handleEventMergeQueueChecksRequested handles merge queue checks requested events.
@@ -45,6 +54,11 @@ func (s *Service) handleEventMergeQueueChecksRequested(
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			repoInfo := repositoryInfoFrom(ctx, repo, s.urlProvider)
 
+			pullReqs, err := s.mergeGroupPullReqInfos(ctx, repo, event.Payload.PullReqIDs)
+			if err != nil {
+				return nil, err
+			}
+
 			return &MergeQueueChecksPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerMergeQueueChecksRequested,
@@ -54,11 +68,39 @@ func (s *Service) handleEventMergeQueueChecksRequested(
 				MergeQueueChecksSegment: MergeQueueChecksSegment{
 					Branch:    event.Payload.Branch,
 					CommitSHA: event.Payload.CommitSHA,
+					PullReqs:  pullReqs,
+					Bisect:    event.Payload.Bisect,
 				},
 			}, nil
 		})
 }
 
+// mergeGroupPullReqInfos returns the pull request infos of a merge group in the merge queue order.
+// Pull requests that don't exist anymore are skipped, the rest of the merge group is still reported.
+func (s *Service) mergeGroupPullReqInfos(
+	ctx context.Context,
+	repo *types.Repository,
+	pullReqIDs []int64,
+) ([]PullReqInfo, error) {
+	pullReqs := make([]PullReqInfo, 0, len(pullReqIDs))
+	for _, pullReqID := range pullReqIDs {
+		pr, err := s.pullreqStore.Find(ctx, pullReqID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().
+				Int64("pullreq_id", pullReqID).
+				Msg("pull request of the merge group doesn't exist anymore, skipping it")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull request %d of the merge group: %w", pullReqID, err)
+		}
+
+		pullReqs = append(pullReqs, pullReqInfoFrom(ctx, pr, repo, s.urlProvider))
+	}
+
+	return pullReqs, nil
+}
+
 // handleEventMergeQueueChecksCanceled handles merge queue checks canceled events.
 func (s *Service) handleEventMergeQueueChecksCanceled(
 	ctx context.Context,
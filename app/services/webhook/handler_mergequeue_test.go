@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type pullReqStoreStub struct {
+	store.PullReqStore
+	pullReqs map[int64]*types.PullReq
+	err      error
+}
+
+func (s pullReqStoreStub) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	pr, ok := s.pullReqs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return pr, nil
+}
+
+type uiURLProviderStub struct {
+	url.Provider
+}
+
+func (uiURLProviderStub) GenerateUIPRURL(context.Context, string, int64) string {
+	return "http://localhost/pulls"
+}
+
+func TestMergeGroupPullReqInfos(t *testing.T) {
+	repo := &types.Repository{Path: "space/repo"}
+	pullReqs := map[int64]*types.PullReq{
+		1: {ID: 1, Number: 11},
+		3: {ID: 3, Number: 33},
+	}
+
+	t.Run("skips_missing_pull_requests", func(t *testing.T) {
+		s := &Service{
+			pullreqStore: pullReqStoreStub{pullReqs: pullReqs},
+			urlProvider:  uiURLProviderStub{},
+		}
+
+		infos, err := s.mergeGroupPullReqInfos(context.Background(), repo, []int64{3, 2, 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(infos) != 2 || infos[0].Number != 33 || infos[1].Number != 11 {
+			t.Errorf("unexpected pull request infos: %+v", infos)
+		}
+	})
+
+	t.Run("fails_on_store_error", func(t *testing.T) {
+		errStore := errors.New("store failure")
+		s := &Service{
+			pullreqStore: pullReqStoreStub{err: errStore},
+			urlProvider:  uiURLProviderStub{},
+		}
+
+		_, err := s.mergeGroupPullReqInfos(context.Background(), repo, []int64{1})
+		if !errors.Is(err, errStore) {
+			t.Errorf("expected store error, got: %v", err)
+		}
+	})
+}
@@ -31,7 +31,9 @@ var (
 	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(
+// NewHTTPClient returns an http client for calling user provided URLs.
+// Connections to loopback, private and link-local addresses are refused unless explicitly allowed.
+func NewHTTPClient(
 	allowLoopback bool,
 	allowPrivateNetwork bool,
 	allowLinkLocal bool,
@@ -0,0 +1,557 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	webhookMaxPayloadTemplateLength = 64 * 1024
+
+	// webhookMaxRenderedPayloadSize defines the max allowed size of a payload rendered using a payload template.
+	webhookMaxRenderedPayloadSize = 4 * 1024 * 1024
+
+	// webhookPayloadTemplateTimeLimit defines the max duration of rendering a payload template.
+	webhookPayloadTemplateTimeLimit = 2 * time.Second
+
+	// samplePayloadMaxDepth limits the depth of the generated sample payload (protects against recursive types).
+	samplePayloadMaxDepth = 8
+
+	// formPayloadField is the name of the form field that contains the payload
+	// in case the payload isn't split into multiple fields by a JSONPath template.
+	formPayloadField = "payload"
+)
+
+// ContentTypeHeader returns the value of the Content-Type header for the provided webhook content type.
+func ContentTypeHeader(contentType enum.WebhookContentType) string {
+	contentType, _ = contentType.Sanitize()
+	if contentType == enum.WebhookContentTypeForm {
+		return "application/x-www-form-urlencoded"
+	}
+
+	return "application/json"
+}
+
+// CheckPayloadTemplate validates the content type and the payload template of a webhook.
+// The template is rendered against a sample payload of every trigger the webhook is configured for
+// (all repository triggers if none are configured), with all fields set except the optional ones,
+// which guarantees that the template can be parsed and references only fields that every payload contains.
+// Optional fields can be accessed in Go templates with the index function, e.g. {{index . "head_commit"}}.
+func CheckPayloadTemplate(
+	contentType enum.WebhookContentType,
+	templateType enum.WebhookPayloadTemplateType,
+	tmpl string,
+	triggers []enum.WebhookTrigger,
+) error {
+	if _, ok := contentType.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook content type '%s' is invalid.", contentType)
+	}
+	templateType, ok := templateType.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("The provided webhook payload template type '%s' is invalid.", templateType)
+	}
+
+	if len(tmpl) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+
+	if templateType == enum.WebhookPayloadTemplateTypeNone {
+		if tmpl != "" {
+			return check.NewValidationError("A payload template requires a payload template type.")
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(tmpl) == "" {
+		return check.NewValidationError("The payload template of a webhook can't be empty.")
+	}
+
+	if len(triggers) == 0 {
+		triggers = slices.Sorted(maps.Keys(triggerPayloads))
+	}
+
+	for _, trigger := range triggers {
+		newPayload, ok := triggerPayloads[trigger]
+		if !ok {
+			return check.NewValidationErrorf("Payload templates aren't supported for the trigger '%s'.", trigger)
+		}
+
+		sample, err := json.Marshal(samplePayload(newPayload()))
+		if err != nil {
+			return fmt.Errorf("failed to serialize sample payload of trigger %s: %w", trigger, err)
+		}
+
+		if _, err := renderPayload(contentType, templateType, tmpl, sample); err != nil {
+			return check.NewValidationErrorf("The payload template is invalid for the trigger '%s': %s", trigger, err)
+		}
+	}
+
+	return nil
+}
+
+// renderPayload renders the request body of a webhook from the JSON serialized webhook payload.
+func renderPayload(
+	contentType enum.WebhookContentType,
+	templateType enum.WebhookPayloadTemplateType,
+	tmpl string,
+	payload []byte,
+) ([]byte, error) {
+	body, err := renderPayloadBody(contentType, templateType, tmpl, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	templateType, _ = templateType.Sanitize()
+	if templateType != enum.WebhookPayloadTemplateTypeNone && len(body) > webhookMaxRenderedPayloadSize {
+		return nil, errRenderedPayloadTooLarge
+	}
+
+	return body, nil
+}
+
+func renderPayloadBody(
+	contentType enum.WebhookContentType,
+	templateType enum.WebhookPayloadTemplateType,
+	tmpl string,
+	payload []byte,
+) ([]byte, error) {
+	contentType, _ = contentType.Sanitize()
+	templateType, _ = templateType.Sanitize()
+
+	switch templateType {
+	case enum.WebhookPayloadTemplateTypeGoTemplate:
+		body, err := renderGoTemplate(tmpl, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if contentType == enum.WebhookContentTypeForm {
+			return []byte(url.Values{formPayloadField: {string(body)}}.Encode()), nil
+		}
+
+		if !json.Valid(body) {
+			return nil, errors.New("template output is not valid JSON")
+		}
+
+		return body, nil
+
+	case enum.WebhookPayloadTemplateTypeJSONPath:
+		fields, err := renderJSONPathTemplate(tmpl, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if contentType == enum.WebhookContentTypeForm {
+			return encodeFormFields(fields)
+		}
+
+		body, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize rendered fields: %w", err)
+		}
+
+		return body, nil
+
+	case enum.WebhookPayloadTemplateTypeNone:
+	}
+
+	if contentType == enum.WebhookContentTypeForm {
+		return []byte(url.Values{formPayloadField: {string(bytes.TrimSpace(payload))}}.Encode()), nil
+	}
+
+	return payload, nil
+}
+
+// renderGoTemplate executes a Go text/template with the decoded payload as data.
+// Referencing a field that doesn't exist in the payload is an error.
+// The execution fails if the output exceeds webhookMaxRenderedPayloadSize
+// or if it takes longer than webhookPayloadTemplateTimeLimit.
+func renderGoTemplate(tmpl string, payload []byte) ([]byte, error) {
+	deadline := time.Now().Add(webhookPayloadTemplateTimeLimit)
+	funcs := template.FuncMap{
+		"json": templateFuncJSON,
+		templateFuncDeadlineName: func() (string, error) {
+			if time.Now().After(deadline) {
+				return "", errPayloadTemplateTimeout
+			}
+			return "", nil
+		},
+	}
+
+	t, err := template.New("payload").
+		Option("missingkey=error").
+		Funcs(funcs).
+		Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := injectDeadlineChecks(t, funcs); err != nil {
+		return nil, err
+	}
+
+	data, err := decodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &boundedWriter{
+		limit:    webhookMaxRenderedPayloadSize,
+		deadline: deadline,
+	}
+
+	if err := t.Execute(w, data); err != nil {
+		if errors.Is(err, errPayloadTemplateTimeout) {
+			return nil, errPayloadTemplateTimeout
+		}
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return w.buf.Bytes(), nil
+}
+
+// templateFuncDeadlineName is the name of the template function that fails once the rendering deadline passes.
+const templateFuncDeadlineName = "_deadline"
+
+// injectDeadlineChecks inserts a call of the deadline function at the beginning of every template
+// and of every range loop body. Together with the writer, which checks the deadline on every write,
+// this bounds the work of templates that loop or recurse without producing any output.
+func injectDeadlineChecks(t *template.Template, funcs template.FuncMap) error {
+	checkTmpl, err := template.New("deadline").Funcs(funcs).Parse("{{" + templateFuncDeadlineName + "}}")
+	if err != nil {
+		return fmt.Errorf("failed to parse deadline check: %w", err)
+	}
+	deadlineCheck := checkTmpl.Tree.Root.Nodes[0]
+
+	var inject func(list *parse.ListNode)
+	inject = func(list *parse.ListNode) {
+		if list == nil {
+			return
+		}
+		for _, node := range list.Nodes {
+			switch n := node.(type) {
+			case *parse.RangeNode:
+				inject(n.List)
+				inject(n.ElseList)
+				n.List.Nodes = append([]parse.Node{deadlineCheck}, n.List.Nodes...)
+			case *parse.IfNode:
+				inject(n.List)
+				inject(n.ElseList)
+			case *parse.WithNode:
+				inject(n.List)
+				inject(n.ElseList)
+			}
+		}
+	}
+
+	for _, tt := range t.Templates() {
+		if tt.Tree == nil || tt.Tree.Root == nil {
+			continue
+		}
+		inject(tt.Tree.Root)
+		tt.Tree.Root.Nodes = append([]parse.Node{deadlineCheck}, tt.Tree.Root.Nodes...)
+	}
+
+	return nil
+}
+
+var (
+	errRenderedPayloadTooLarge = fmt.Errorf("rendered payload exceeds the size limit of %d bytes",
+		webhookMaxRenderedPayloadSize)
+	errPayloadTemplateTimeout = fmt.Errorf("rendering of the payload template exceeded the time limit of %s",
+		webhookPayloadTemplateTimeLimit)
+)
+
+// boundedWriter is a buffer that fails all writes that exceed the size limit or happen after the deadline.
+type boundedWriter struct {
+	buf      bytes.Buffer
+	limit    int
+	deadline time.Time
+}
+
+func (w *boundedWriter) Write(p []byte) (int, error) {
+	if time.Now().After(w.deadline) {
+		return 0, errPayloadTemplateTimeout
+	}
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errRenderedPayloadTooLarge
+	}
+
+	return w.buf.Write(p)
+}
+
+// templateFuncJSON returns the JSON encoding of the value, strings are quoted and escaped.
+func templateFuncJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// renderJSONPathTemplate builds the body from a JSON object template.
+// String values starting with '$' are JSONPath expressions evaluated against the payload,
+// all other values are copied as they are.
+func renderJSONPathTemplate(tmpl string, payload []byte) (map[string]any, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(tmpl), &fields); err != nil {
+		return nil, fmt.Errorf("template has to be a JSON object: %w", err)
+	}
+
+	data, err := decodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]any, len(fields))
+	for name, field := range fields {
+		expr, ok := field.(string)
+		if !ok || !strings.HasPrefix(expr, "$") {
+			out[name] = field
+			continue
+		}
+
+		value, err := evalJSONPath(data, expr)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+
+		out[name] = value
+	}
+
+	return out, nil
+}
+
+// encodeFormFields URL encodes the fields as form, non-string values are JSON encoded.
+func encodeFormFields(fields map[string]any) ([]byte, error) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := url.Values{}
+	for _, name := range names {
+		switch v := fields[name].(type) {
+		case nil:
+			values.Set(name, "")
+		case string:
+			values.Set(name, v)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize field %q: %w", name, err)
+			}
+			values.Set(name, string(b))
+		}
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+func decodePayload(payload []byte) (any, error) {
+	var data any
+
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	return data, nil
+}
+
+// evalJSONPath evaluates a JSONPath expression that addresses a single value.
+// Supported is the root '$', child fields as '.name' or ['name'] and array indices as [0].
+// A path that doesn't exist in the data evaluates to nil.
+func evalJSONPath(data any, expr string) (any, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath expression %q has to start with '$'", expr)
+	}
+
+	path := expr[1:]
+	for path != "" {
+		var (
+			key   string
+			index int
+			isKey bool
+		)
+
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			key, path, isKey = path[1:end+1], path[end+1:], true
+			if key == "" {
+				return nil, fmt.Errorf("JSONPath expression %q contains an empty field name", expr)
+			}
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath expression %q has an unterminated bracket", expr)
+			}
+			selector := path[1:end]
+			path = path[end+1:]
+
+			if unquoted, ok := unquoteSelector(selector); ok {
+				key, isKey = unquoted, true
+				break
+			}
+
+			var err error
+			index, err = strconv.Atoi(selector)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("JSONPath expression %q has an unsupported selector [%s]", expr, selector)
+			}
+
+		default:
+			return nil, fmt.Errorf("JSONPath expression %q is invalid near %q", expr, path)
+		}
+
+		switch v := data.(type) {
+		case map[string]any:
+			if !isKey {
+				return nil, nil
+			}
+			data = v[key]
+		case []any:
+			if isKey || index >= len(v) {
+				return nil, nil
+			}
+			data = v[index]
+		default:
+			return nil, nil
+		}
+	}
+
+	return data, nil
+}
+
+func unquoteSelector(s string) (string, bool) {
+	if len(s) < 2 {
+		return "", false
+	}
+	if (s[0] == '\'' && s[len(s)-1] == '\'') || (s[0] == '"' && s[len(s)-1] == '"') {
+		return s[1 : len(s)-1], true
+	}
+
+	return "", false
+}
+
+// triggerPayloads holds constructors of the payloads sent by the repository webhook triggers.
+var triggerPayloads = map[enum.WebhookTrigger]func() any{
+	enum.WebhookTriggerBranchCreated:               func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerBranchUpdated:               func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerBranchDeleted:               func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerTagCreated:                  func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerTagUpdated:                  func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerTagDeleted:                  func() any { return &ReferencePayload{} },
+	enum.WebhookTriggerPullReqCreated:              func() any { return &PullReqCreatedPayload{} },
+	enum.WebhookTriggerPullReqReopened:             func() any { return &PullReqReopenedPayload{} },
+	enum.WebhookTriggerPullReqBranchUpdated:        func() any { return &PullReqBranchUpdatedPayload{} },
+	enum.WebhookTriggerPullReqClosed:               func() any { return &PullReqClosedPayload{} },
+	enum.WebhookTriggerPullReqMerged:               func() any { return &PullReqClosedPayload{} },
+	enum.WebhookTriggerPullReqCommentCreated:       func() any { return &PullReqCommentPayload{} },
+	enum.WebhookTriggerPullReqCommentUpdated:       func() any { return &PullReqCommentPayload{} },
+	enum.WebhookTriggerPullReqCommentStatusUpdated: func() any { return &PullReqActivityStatusUpdatedPayload{} },
+	enum.WebhookTriggerPullReqUpdated:              func() any { return &PullReqUpdatedPayload{} },
+	enum.WebhookTriggerPullReqLabelAssigned:        func() any { return &PullReqLabelAssignedPayload{} },
+	enum.WebhookTriggerPullReqReviewSubmitted:      func() any { return &PullReqReviewSubmittedPayload{} },
+	enum.WebhookTriggerPullReqTargetBranchChanged:  func() any { return &PullReqTargetBranchChangedPayload{} },
+	enum.WebhookTriggerMergeQueueChecksRequested:   func() any { return &MergeQueueChecksPayload{} },
+	enum.WebhookTriggerMergeQueueChecksCanceled:    func() any { return &MergeQueueChecksPayload{} },
+}
+
+// samplePayload fills the provided payload with sample values, used for validation of payload templates.
+// All fields are set except the optional ones (tagged with omitempty), which real payloads might not contain.
+func samplePayload(payload any) any {
+	fillSampleValue(reflect.ValueOf(payload).Elem(), 0)
+
+	return payload
+}
+
+// fillSampleValue sets the value and all its fields to a non-zero value.
+func fillSampleValue(v reflect.Value, depth int) {
+	if depth > samplePayloadMaxDepth || !v.CanSet() {
+		return
+	}
+
+	if v.Type() == reflect.TypeOf(json.RawMessage{}) {
+		v.SetBytes([]byte("{}"))
+		return
+	}
+
+	//nolint:exhaustive // all other kinds are kept as they are
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		fillSampleValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if strings.Contains(v.Type().Field(i).Tag.Get("json"), ",omitempty") {
+				continue
+			}
+			fillSampleValue(v.Field(i), depth+1)
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillSampleValue(v.Index(0), depth+1)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		key := reflect.New(v.Type().Key()).Elem()
+		key.SetString("key")
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillSampleValue(elem, depth+1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.String:
+		v.SetString("sample")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	}
+}
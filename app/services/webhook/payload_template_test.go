@@ -0,0 +1,275 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testPayload = `{"trigger":"pullreq_created","pull_req":{"number":7,"title":"Fix \"quotes\""},` +
+	`"repo":{"identifier":"repo1"},"commits":[{"sha":"abc"},{"sha":"def"}]}`
+
+func TestRenderPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  enum.WebhookContentType
+		templateType enum.WebhookPayloadTemplateType
+		template     string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "default_json",
+			want: testPayload,
+		},
+		{
+			name:        "default_form",
+			contentType: enum.WebhookContentTypeForm,
+			want:        "payload=" + url.QueryEscape(testPayload),
+		},
+		{
+			name:         "go_template_json",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"text":{{json .pull_req.title}},"number":{{.pull_req.number}}}`,
+			want:         `{"text":"Fix \"quotes\"","number":7}`,
+		},
+		{
+			name:         "go_template_form",
+			contentType:  enum.WebhookContentTypeForm,
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `PR #{{.pull_req.number}}`,
+			want:         "payload=PR+%237",
+		},
+		{
+			name:         "go_template_invalid_json",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"text":{{.pull_req.title}}}`,
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_parse_error",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{{.pull_req.title`,
+			wantErr:      true,
+		},
+		{
+			name:         "jsonpath_json",
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template: `{"title":"$.pull_req.title","sha":"$.commits[1].sha","repo":"$['repo']['identifier']",` +
+				`"missing":"$.pull_req.nope","static":"bot","count":1}`,
+			want: `{"count":1,"missing":null,"repo":"repo1","sha":"def","static":"bot","title":"Fix \"quotes\""}`,
+		},
+		{
+			name:         "jsonpath_form",
+			contentType:  enum.WebhookContentTypeForm,
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template:     `{"title":"$.pull_req.title","number":"$.pull_req.number","pr":"$.pull_req"}`,
+			want: "number=7&pr=" + url.QueryEscape(`{"number":7,"title":"Fix \"quotes\""}`) +
+				"&title=" + url.QueryEscape(`Fix "quotes"`),
+		},
+		{
+			name:         "jsonpath_not_object",
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template:     `["$.pull_req"]`,
+			wantErr:      true,
+		},
+		{
+			name:         "jsonpath_invalid_expression",
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template:     `{"a":"$.commits[*]"}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderPayload(test.contentType, test.templateType, test.template, []byte(testPayload))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got body %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got body %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckPayloadTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  enum.WebhookContentType
+		templateType enum.WebhookPayloadTemplateType
+		template     string
+		triggers     []enum.WebhookTrigger
+		wantErr      bool
+	}{
+		{name: "defaults"},
+		{name: "invalid_content_type", contentType: "xml", wantErr: true},
+		{name: "invalid_template_type", templateType: "jinja", wantErr: true},
+		{name: "template_without_type", template: "{}", wantErr: true},
+		{name: "empty_template", templateType: enum.WebhookPayloadTemplateTypeGoTemplate, wantErr: true},
+		{
+			name:         "go_template_nested_fields",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"pr":{{json .pull_req.title}},"comment":{{json .comment.text}}}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerPullReqCommentCreated},
+		},
+		{
+			name:         "go_template_field_of_other_trigger",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"pr":{{json .pull_req.title}}}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerPullReqCreated, enum.WebhookTriggerBranchCreated},
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_field_of_other_trigger_all_triggers",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"pr":{{json .pull_req.title}}}`,
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_common_fields_all_triggers",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"trigger":{{json .trigger}},"repo":{{json .repo.identifier}}}`,
+		},
+		{
+			name:         "go_template_unknown_function",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{{upper .trigger}}`,
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_optional_field",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"count":{{.total_commits_count}}}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerBranchUpdated},
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_optional_field_index",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"count":{{json (index . "total_commits_count")}}}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerBranchUpdated},
+		},
+		{
+			name:         "go_template_missing_key",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{"text":{{json .pull_req.no_such_field}}}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerPullReqCreated},
+			wantErr:      true,
+		},
+		{
+			name:         "go_template_output_too_large",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template: `{{define "a"}}{{printf "%0*d" 1000000 0}}{{end}}` +
+				`{{template "a"}}{{template "a"}}{{template "a"}}{{template "a"}}{{template "a"}}`,
+			wantErr: true,
+		},
+		{
+			name:         "go_template_unsupported_trigger",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			template:     `{}`,
+			triggers:     []enum.WebhookTrigger{enum.WebhookTriggerArtifactCreated},
+			wantErr:      true,
+		},
+		{
+			name:         "jsonpath",
+			contentType:  enum.WebhookContentTypeForm,
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template:     `{"text":"$.pull_req.title"}`,
+		},
+		{
+			name:         "jsonpath_syntax_error",
+			templateType: enum.WebhookPayloadTemplateTypeJSONPath,
+			template:     `{"text":"$.pull_req[title"}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckPayloadTemplate(test.contentType, test.templateType, test.template, test.triggers)
+			if (err != nil) != test.wantErr {
+				t.Errorf("CheckPayloadTemplate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderGoTemplateTimeLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{
+			name:     "loop_without_output",
+			template: `{{range 1000000000000}}{{end}}`,
+		},
+		{
+			name: "nested_templates_without_output",
+			template: `{{define "a"}}{{range 1000}}{{end}}{{end}}` +
+				`{{define "b"}}{{range 1000}}{{template "a"}}{{end}}{{end}}` +
+				`{{range 1000}}{{template "b"}}{{end}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+
+			_, err := renderGoTemplate(test.template, []byte(testPayload))
+			if !errors.Is(err, errPayloadTemplateTimeout) {
+				t.Errorf("expected timeout error, got %v", err)
+			}
+			if d := time.Since(start); d > 2*webhookPayloadTemplateTimeLimit {
+				t.Errorf("rendering took %s, expected to be aborted after %s", d, webhookPayloadTemplateTimeLimit)
+			}
+		})
+	}
+}
+
+func TestCheckExtraHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []types.ExtraHeader
+		wantErr bool
+	}{
+		{name: "valid", headers: []types.ExtraHeader{{Key: "X-A", Value: "1"}, {Key: "X-B", Value: "2"}}},
+		{name: "duplicate", headers: []types.ExtraHeader{{Key: "X-A"}, {Key: "x-a"}}, wantErr: true},
+		{name: "content_type", headers: []types.ExtraHeader{{Key: "content-type"}}, wantErr: true},
+		{name: "empty_key", headers: []types.ExtraHeader{{Key: ""}}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckExtraHeaders(test.headers)
+			if (err != nil) != test.wantErr {
+				t.Errorf("CheckExtraHeaders() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRetry        = "gitness:webhook:retry"
+	jobCronRetry        = "* * * * *" // every minute
+	jobMaxDurationRetry = 10 * time.Minute
+
+	// retryBatchSize defines the maximum number of due retries processed by a single run of the retry job.
+	retryBatchSize = 100
+
+	// retryLease defines for how long a claimed retry is reserved for the retry job that claimed it.
+	// If the retry isn't completed until then (e.g. the instance crashed), it's picked up again.
+	retryLease = 10 * webhookTimeLimit
+
+	jobTypeRetriggerDeadLetters        = "gitness:webhook:retrigger-dead-letters"
+	jobMaxDurationRetriggerDeadLetters = 30 * time.Minute
+	jobUIDPrefixRetriggerDeadLetters   = "webhook-retrigger-dead-letters-"
+)
+
+// planRetry schedules an automatic retry of an execution that failed with a retriable error,
+// or marks the delivery as permanently failed if there's no attempt left.
+func (w *WebhookExecutor) planRetry(execution *types.WebhookExecutionCore) {
+	switch {
+	case execution.Result == enum.WebhookExecutionResultSuccess:
+	case execution.Result == enum.WebhookExecutionResultRetriableError &&
+		execution.Retriggerable &&
+		execution.Attempt < w.config.RetryMaxAttempts:
+		retryAt := time.Now().Add(retryBackoff(w.config, execution.Attempt)).UnixMilli()
+		execution.RetryAt = &retryAt
+	default:
+		execution.DeadLetter = true
+	}
+}
+
+// retryBackoff returns the delay before the retry of the provided attempt.
+// The delay starts with Config.RetryBackoffBase and doubles with every attempt up to Config.RetryBackoffMax.
+func retryBackoff(config Config, attempt int) time.Duration {
+	backoff := config.RetryBackoffBase
+	for i := 1; i < attempt && backoff < config.RetryBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, config.RetryBackoffMax)
+}
+
+type retryJob struct {
+	webhookExecutionStore store.WebhookExecutionStore
+	executor              *WebhookExecutor
+}
+
+// Handle executes the next attempt of all deliveries with a due automatic retry.
+func (j *retryJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	executions, err := j.webhookExecutionStore.ListDueRetries(ctx, time.Now().UnixMilli(), retryBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list webhook executions with due retries: %w", err)
+	}
+
+	var retried int
+	for _, execution := range executions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		ok, err := j.retry(ctx, execution)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("webhook_execution_id", execution.ID).
+				Msg("failed to retry webhook execution")
+			continue
+		}
+
+		if ok {
+			retried++
+		}
+	}
+
+	result := fmt.Sprintf("retried %d of %d due webhook executions", retried, len(executions))
+
+	if len(executions) > 0 {
+		log.Ctx(ctx).Info().Msg(result)
+	}
+
+	return result, nil
+}
+
+func (j *retryJob) retry(ctx context.Context, execution *types.WebhookExecution) (bool, error) {
+	if execution.RetryAt == nil {
+		return false, nil
+	}
+
+	leaseUntil := time.Now().Add(retryLease).UnixMilli()
+	claimed, err := j.webhookExecutionStore.ClaimRetry(ctx, execution.ID, *execution.RetryAt, leaseUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim retry: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	webhook, err := j.executor.webhookExecutorStore.FindWebhook(ctx, execution.WebhookID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return false, j.clearRetry(ctx, execution.ID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	// the webhook got disabled in the meantime - the delivery can't succeed anymore
+	if !webhook.Enabled {
+		return false, j.deadLetter(ctx, execution.ID)
+	}
+
+	result, err := j.executor.reexecuteWebhook(ctx, webhook,
+		GitnessWebhookExecutionToWebhookExecutionCore(execution), execution.Attempt+1)
+	if errors.Is(err, ErrWebhookNotRetriggerable) {
+		return false, j.deadLetter(ctx, execution.ID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to execute webhook: %w", err)
+	}
+
+	// the retry is complete only once the new attempt is stored - it owns the next retry (if any).
+	// Otherwise, the lease expires and the retry is attempted again.
+	if result.Execution == nil || result.Execution.ID == 0 {
+		return false, fmt.Errorf("failed to store attempt %d of webhook execution", execution.Attempt+1)
+	}
+
+	if result.Err != nil {
+		log.Ctx(ctx).Debug().Err(result.Err).Msgf(
+			"attempt %d of webhook %d execution %d (new id: %d) had an error",
+			execution.Attempt+1, webhook.ID, execution.ID, result.Execution.ID)
+	}
+
+	return true, j.clearRetry(ctx, execution.ID)
+}
+
+func (j *retryJob) clearRetry(ctx context.Context, executionID int64) error {
+	if err := j.webhookExecutionStore.ClearRetry(ctx, executionID); err != nil {
+		return fmt.Errorf("failed to clear retry: %w", err)
+	}
+
+	return nil
+}
+
+func (j *retryJob) deadLetter(ctx context.Context, executionID int64) error {
+	err := j.webhookExecutionStore.UpdateDeadLetter(ctx, []int64{executionID}, true)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook execution as permanently failed: %w", err)
+	}
+
+	return j.clearRetry(ctx, executionID)
+}
+
+type retriggerDeadLettersInput struct {
+	WebhookID    int64   `json:"webhook_id"`
+	ExecutionIDs []int64 `json:"execution_ids"`
+}
+
+type retriggerDeadLettersJob struct {
+	executor *WebhookExecutor
+}
+
+// Handle retriggers the permanently failed webhook executions provided in the job data.
+func (j *retriggerDeadLettersJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input retriggerDeadLettersInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	var succeeded int
+	for _, executionID := range input.ExecutionIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result, err := j.executor.RetriggerWebhookExecution(ctx, executionID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("webhook_id", input.WebhookID).
+				Int64("webhook_execution_id", executionID).
+				Msg("failed to retrigger webhook execution")
+			continue
+		}
+
+		if result.Err == nil {
+			succeeded++
+		}
+	}
+
+	result := fmt.Sprintf("%d of %d retriggered executions of webhook %d succeeded",
+		succeeded, len(input.ExecutionIDs), input.WebhookID)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// registerRetryJobs registers the job retrying failed deliveries and the job retriggering
+// permanently failed deliveries in bulk.
+func (s *Service) registerRetryJobs(
+	ctx context.Context,
+	jobExecutor *job.Executor,
+	jobScheduler *job.Scheduler,
+) error {
+	err := jobExecutor.Register(jobTypeRetry, &retryJob{
+		webhookExecutionStore: s.webhookExecutionStore,
+		executor:              s.WebhookExecutor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register webhook retry job: %w", err)
+	}
+
+	err = jobScheduler.AddRecurring(ctx, jobTypeRetry, jobTypeRetry, jobCronRetry, jobMaxDurationRetry)
+	if err != nil {
+		return fmt.Errorf("failed to schedule webhook retry job: %w", err)
+	}
+
+	err = jobExecutor.Register(jobTypeRetriggerDeadLetters, &retriggerDeadLettersJob{
+		executor: s.WebhookExecutor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register webhook dead letter retrigger job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,398 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	config := Config{
+		RetryBackoffBase: time.Minute,
+		RetryBackoffMax:  10 * time.Minute,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Minute},
+		{attempt: 2, want: 2 * time.Minute},
+		{attempt: 3, want: 4 * time.Minute},
+		{attempt: 4, want: 8 * time.Minute},
+		{attempt: 5, want: 10 * time.Minute},
+		{attempt: 50, want: 10 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := retryBackoff(config, test.attempt); got != test.want {
+			t.Errorf("attempt %d: got backoff %s, want %s", test.attempt, got, test.want)
+		}
+	}
+}
+
+func TestPlanRetry(t *testing.T) {
+	w := &WebhookExecutor{
+		config: Config{
+			RetryMaxAttempts: 3,
+			RetryBackoffBase: time.Minute,
+			RetryBackoffMax:  time.Hour,
+		},
+		autoRetry: true,
+	}
+
+	tests := []struct {
+		name           string
+		execution      types.WebhookExecutionCore
+		wantRetry      bool
+		wantDeadLetter bool
+	}{
+		{
+			name:      "success",
+			execution: types.WebhookExecutionCore{Result: enum.WebhookExecutionResultSuccess, Attempt: 1},
+		},
+		{
+			name: "retriable_error_with_attempts_left",
+			execution: types.WebhookExecutionCore{
+				Result: enum.WebhookExecutionResultRetriableError, Retriggerable: true, Attempt: 2,
+			},
+			wantRetry: true,
+		},
+		{
+			name: "retriable_error_without_attempts_left",
+			execution: types.WebhookExecutionCore{
+				Result: enum.WebhookExecutionResultRetriableError, Retriggerable: true, Attempt: 3,
+			},
+			wantDeadLetter: true,
+		},
+		{
+			name: "retriable_error_without_body",
+			execution: types.WebhookExecutionCore{
+				Result: enum.WebhookExecutionResultRetriableError, Attempt: 1,
+			},
+			wantDeadLetter: true,
+		},
+		{
+			name: "fatal_error",
+			execution: types.WebhookExecutionCore{
+				Result: enum.WebhookExecutionResultFatalError, Retriggerable: true, Attempt: 1,
+			},
+			wantDeadLetter: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			execution := test.execution
+			w.planRetry(&execution)
+
+			if got := execution.RetryAt != nil; got != test.wantRetry {
+				t.Errorf("got retry scheduled %t, want %t", got, test.wantRetry)
+			}
+			if execution.DeadLetter != test.wantDeadLetter {
+				t.Errorf("got dead letter %t, want %t", execution.DeadLetter, test.wantDeadLetter)
+			}
+		})
+	}
+}
+
+type webhookExecutorStoreStub struct {
+	WebhookExecutorStore
+	webhook   *types.WebhookCore
+	updated   *types.WebhookCore
+	created   *types.WebhookExecutionCore
+	createErr error
+}
+
+func (s *webhookExecutorStoreStub) FindWebhook(context.Context, int64) (*types.WebhookCore, error) {
+	return s.webhook, nil
+}
+
+func (s *webhookExecutorStoreStub) CreateWebhookExecution(
+	_ context.Context,
+	execution *types.WebhookExecutionCore,
+) error {
+	s.created = execution
+	if s.createErr != nil {
+		return s.createErr
+	}
+	execution.ID = 100
+	return nil
+}
+
+func (s *webhookExecutorStoreStub) UpdateOptLock(
+	_ context.Context,
+	hook *types.WebhookCore,
+	mutateFn func(hook *types.WebhookCore) error,
+) (*types.WebhookCore, error) {
+	dup := *hook
+	if err := mutateFn(&dup); err != nil {
+		return nil, err
+	}
+	s.updated = &dup
+	return &dup, nil
+}
+
+func TestUpdateWebhookAfterExecution_CircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int64
+		execution   types.WebhookExecutionCore
+		wantFails   int64
+		wantEnabled bool
+	}{
+		{
+			name:        "success_resets_failures",
+			failures:    2,
+			execution:   types.WebhookExecutionCore{Result: enum.WebhookExecutionResultSuccess},
+			wantFails:   0,
+			wantEnabled: true,
+		},
+		{
+			name:        "pending_retry_is_not_counted",
+			failures:    2,
+			execution:   types.WebhookExecutionCore{Result: enum.WebhookExecutionResultRetriableError},
+			wantFails:   2,
+			wantEnabled: true,
+		},
+		{
+			name:        "dead_letter_below_threshold",
+			failures:    1,
+			execution:   types.WebhookExecutionCore{Result: enum.WebhookExecutionResultFatalError, DeadLetter: true},
+			wantFails:   2,
+			wantEnabled: true,
+		},
+		{
+			name:        "dead_letter_reaching_threshold_disables_webhook",
+			failures:    2,
+			execution:   types.WebhookExecutionCore{Result: enum.WebhookExecutionResultFatalError, DeadLetter: true},
+			wantFails:   3,
+			wantEnabled: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &webhookExecutorStoreStub{}
+			w := &WebhookExecutor{
+				config:               Config{CircuitBreakerThreshold: 3},
+				webhookExecutorStore: store,
+				autoRetry:            true,
+			}
+
+			webhook := &types.WebhookCore{ID: 1, Enabled: true, ConsecutiveFailures: test.failures}
+			execution := test.execution
+
+			w.updateWebhookAfterExecution(context.Background(), webhook, &execution)
+
+			if store.updated == nil {
+				t.Fatal("webhook wasn't updated")
+			}
+			if store.updated.ConsecutiveFailures != test.wantFails {
+				t.Errorf("got %d consecutive failures, want %d", store.updated.ConsecutiveFailures, test.wantFails)
+			}
+			if store.updated.Enabled != test.wantEnabled {
+				t.Errorf("got enabled %t, want %t", store.updated.Enabled, test.wantEnabled)
+			}
+			if *store.updated.LatestExecutionResult != execution.Result {
+				t.Errorf("got latest execution result %s, want %s",
+					*store.updated.LatestExecutionResult, execution.Result)
+			}
+		})
+	}
+}
+
+type urlProviderStub struct {
+	url string
+}
+
+func (p urlProviderStub) GetWebhookURL(context.Context, *types.WebhookCore) (string, error) {
+	return p.url, nil
+}
+
+func TestExecuteWebhook_RequestErrorResult(t *testing.T) {
+	done := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-done
+	}))
+	defer slowServer.Close()
+	defer close(done)
+
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		autoRetry  bool
+		wantResult enum.WebhookExecutionResult
+	}{
+		{
+			name:       "timeout_without_auto_retry",
+			url:        slowServer.URL,
+			wantResult: enum.WebhookExecutionResultFatalError,
+		},
+		{
+			name:       "timeout_with_auto_retry",
+			url:        slowServer.URL,
+			autoRetry:  true,
+			wantResult: enum.WebhookExecutionResultRetriableError,
+		},
+		{
+			name:       "connection_refused_without_auto_retry",
+			url:        closedServer.URL,
+			wantResult: enum.WebhookExecutionResultFatalError,
+		},
+		{
+			name:       "connection_refused_with_auto_retry",
+			url:        closedServer.URL,
+			autoRetry:  true,
+			wantResult: enum.WebhookExecutionResultRetriableError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &webhookExecutorStoreStub{}
+			w := &WebhookExecutor{
+				config: Config{
+					RetryMaxAttempts:        3,
+					RetryBackoffBase:        time.Minute,
+					RetryBackoffMax:         time.Hour,
+					CircuitBreakerThreshold: 3,
+				},
+				webhookURLProvider:   urlProviderStub{url: test.url},
+				webhookExecutorStore: store,
+				secureHTTPClient:     &http.Client{},
+				autoRetry:            test.autoRetry,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			webhook := &types.WebhookCore{ID: 1, Enabled: true}
+			execution, err := w.executeWebhook(ctx, webhook, "trigger", enum.WebhookTriggerBranchCreated,
+				map[string]string{"key": "value"}, nil, 1)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if execution.Result != test.wantResult {
+				t.Errorf("got result %s, want %s", execution.Result, test.wantResult)
+			}
+			if store.created == nil || store.created.Result != test.wantResult {
+				t.Errorf("expected the execution with result %s to be stored, got %+v", test.wantResult, store.created)
+			}
+			if got := execution.RetryAt != nil; got != test.autoRetry {
+				t.Errorf("got retry scheduled %t, want %t", got, test.autoRetry)
+			}
+		})
+	}
+}
+
+type webhookExecutionStoreStub struct {
+	store.WebhookExecutionStore
+	retryAt int64
+	cleared bool
+}
+
+func (s *webhookExecutionStoreStub) ClaimRetry(
+	_ context.Context,
+	_ int64,
+	retryAt int64,
+	leaseUntil int64,
+) (bool, error) {
+	if s.retryAt != retryAt {
+		return false, nil
+	}
+	s.retryAt = leaseUntil
+	return true, nil
+}
+
+func (s *webhookExecutionStoreStub) ClearRetry(context.Context, int64) error {
+	s.cleared = true
+	return nil
+}
+
+func TestRetryJob_ClearsClaimOnlyAfterAttemptIsStored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		createErr   error
+		wantRetried bool
+	}{
+		{
+			name:        "attempt_stored",
+			wantRetried: true,
+		},
+		{
+			name:      "attempt_not_stored",
+			createErr: errors.New("db is down"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executionStore := &webhookExecutionStoreStub{retryAt: 1000}
+			executorStore := &webhookExecutorStoreStub{
+				webhook:   &types.WebhookCore{ID: 1, Enabled: true},
+				createErr: test.createErr,
+			}
+			j := &retryJob{
+				webhookExecutionStore: executionStore,
+				executor: &WebhookExecutor{
+					config:               Config{RetryMaxAttempts: 3, CircuitBreakerThreshold: 3},
+					webhookURLProvider:   urlProviderStub{url: server.URL},
+					webhookExecutorStore: executorStore,
+					secureHTTPClient:     &http.Client{},
+					autoRetry:            true,
+				},
+			}
+
+			retryAt := int64(1000)
+			retried, err := j.retry(context.Background(), &types.WebhookExecution{
+				ID:            1,
+				WebhookID:     1,
+				Retriggerable: true,
+				Attempt:       1,
+				RetryAt:       &retryAt,
+			})
+
+			if retried != test.wantRetried {
+				t.Errorf("got retried %t, want %t", retried, test.wantRetried)
+			}
+			if (err == nil) != test.wantRetried {
+				t.Errorf("got unexpected error: %v", err)
+			}
+			if executionStore.cleared != test.wantRetried {
+				t.Errorf("got claim cleared %t, want %t", executionStore.cleared, test.wantRetried)
+			}
+			if executionStore.retryAt <= retryAt {
+				t.Errorf("expected the retry to be postponed by the lease, got %d", executionStore.retryAt)
+			}
+		})
+	}
+}
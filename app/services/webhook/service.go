@@ -31,6 +31,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
@@ -55,6 +56,16 @@ type Config struct {
 	AllowLoopback       bool
 	AllowLinkLocal      bool
 	InternalSecret      string
+
+	// RetryMaxAttempts is the maximum number of automatic delivery attempts, including the first one.
+	RetryMaxAttempts int
+	// RetryBackoffBase is the delay before the first automatic retry, it doubles with every further attempt.
+	RetryBackoffBase time.Duration
+	// RetryBackoffMax is the maximum delay between two automatic retries.
+	RetryBackoffMax time.Duration
+	// CircuitBreakerThreshold is the number of consecutive permanently failed deliveries
+	// after which a webhook gets disabled. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int64
 }
 
 func (c *Config) Prepare() error {
@@ -73,6 +84,15 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("Config.MaxRetries can't be negative")
 	}
+	if c.RetryMaxAttempts < 0 {
+		return errors.New("Config.RetryMaxAttempts can't be negative")
+	}
+	if c.RetryBackoffBase < 0 || c.RetryBackoffMax < c.RetryBackoffBase {
+		return errors.New("Config.RetryBackoffBase can't be negative or exceed Config.RetryBackoffMax")
+	}
+	if c.CircuitBreakerThreshold < 0 {
+		return errors.New("Config.CircuitBreakerThreshold can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -92,7 +112,7 @@ type WebhookExecutorStore interface {
 
 	UpdateOptLock(
 		ctx context.Context, hook *types.WebhookCore,
-		execution *types.WebhookExecutionCore,
+		mutateFn func(hook *types.WebhookCore) error,
 	) (*types.WebhookCore, error)
 
 	FindWebhook(
@@ -122,6 +142,10 @@ type WebhookExecutor struct {
 	principalStore             store.PrincipalStore
 	webhookExecutorStore       WebhookExecutorStore
 	source                     string
+
+	// autoRetry enables automatic retries of failed deliveries and the circuit breaker.
+	// If disabled, the caller is responsible for retrying events with retriable errors.
+	autoRetry bool
 }
 
 func NewWebhookExecutor(
@@ -136,25 +160,25 @@ func NewWebhookExecutor(
 ) *WebhookExecutor {
 	return &WebhookExecutor{
 		webhookExecutorStore: webhookExecutorStore,
-		secureHTTPClient: newHTTPClient(
+		secureHTTPClient: NewHTTPClient(
 			config.AllowLoopback,
 			config.AllowPrivateNetwork,
 			config.AllowLinkLocal,
 			false,
 		),
-		insecureHTTPClient: newHTTPClient(
+		insecureHTTPClient: NewHTTPClient(
 			config.AllowLoopback,
 			config.AllowPrivateNetwork,
 			config.AllowLinkLocal,
 			true,
 		),
-		secureHTTPClientInternal: newHTTPClient(
+		secureHTTPClientInternal: NewHTTPClient(
 			config.AllowLoopback,
 			true,
 			config.AllowLinkLocal,
 			false,
 		),
-		insecureHTTPClientInternal: newHTTPClient(
+		insecureHTTPClientInternal: NewHTTPClient(
 			config.AllowLoopback,
 			true,
 			config.AllowLinkLocal,
@@ -189,6 +213,7 @@ type Service struct {
 	config                Config
 	auditService          audit.Service
 	sseStreamer           sse.Streamer
+	jobScheduler          *job.Scheduler
 }
 
 func NewService(
@@ -215,6 +240,8 @@ func NewService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	jobExecutor *job.Executor,
+	jobScheduler *job.Scheduler,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service Config is invalid: %w", err)
@@ -226,6 +253,9 @@ func NewService(
 	executor := NewWebhookExecutor(config, webhookURLProvider, encrypter, spacePathStore,
 		secretService, principalStore, webhookExecutorStore, RepoTrigger)
 
+	// failed deliveries are retried by the retry job instead of reprocessing the events
+	executor.autoRetry = true
+
 	service := &Service{
 		WebhookExecutor:       executor,
 		tx:                    tx,
@@ -244,9 +274,15 @@ func NewService(
 		labelValueStore:       labelValueStore,
 		auditService:          auditService,
 		sseStreamer:           sseStreamer,
+		jobScheduler:          jobScheduler,
+	}
+
+	err := service.registerRetryJobs(ctx, jobExecutor, jobScheduler)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
 		func(r *gitevents.Reader) error {
 			const idleTimeout = 1 * time.Minute
 			r.Configure(
@@ -77,11 +77,19 @@ func (s *GitnessWebhookExecutorStore) CreateWebhookExecution(
 
 func (s *GitnessWebhookExecutorStore) UpdateOptLock(
 	ctx context.Context, hook *types.WebhookCore,
-	execution *types.WebhookExecutionCore,
+	mutateFn func(hook *types.WebhookCore) error,
 ) (*types.WebhookCore, error) {
 	webhook := CoreWebhookToGitnessWebhook(hook)
 	fn := func(hook *types.Webhook) error {
-		hook.LatestExecutionResult = &execution.Result
+		hookCore := GitnessWebhookToWebhookCore(hook)
+		if err := mutateFn(hookCore); err != nil {
+			return err
+		}
+
+		// only fields that are updated by the webhook executor are copied back
+		hook.LatestExecutionResult = hookCore.LatestExecutionResult
+		hook.Enabled = hookCore.Enabled
+		hook.ConsecutiveFailures = hookCore.ConsecutiveFailures
 		return nil
 	}
 	gitnessWebhook, err := s.webhookStore.UpdateOptLock(ctx, webhook, fn)
@@ -109,7 +109,9 @@ func (w *WebhookExecutor) triggerWebhooks(
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
 		// skip execution in case of success or unrecoverable error
-		if execution.Result == enum.WebhookExecutionResultSuccess ||
+		// with automatic retries, any previous execution is owned by the retry job (or already final)
+		if w.autoRetry ||
+			execution.Result == enum.WebhookExecutionResultSuccess ||
 			execution.Result == enum.WebhookExecutionResultFatalError {
 			skipExecution[execution.WebhookID] = true
 		}
@@ -140,7 +142,7 @@ func (w *WebhookExecutor) triggerWebhooks(
 		}
 
 		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil, 1)
 	}
 
 	return results, nil
@@ -156,17 +158,28 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 		return nil, fmt.Errorf("failed to find webhook execution with id %d: %w", webhookExecutionID, err)
 	}
 
-	// ensure webhook can be retriggered
-	if !webhookExecution.Retriggerable {
-		return nil, ErrWebhookNotRetriggerable
-	}
-
 	// find webhook
 	webhook, err := w.webhookExecutorStore.FindWebhook(ctx, webhookExecution.WebhookID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
 	}
 
+	// a retrigger is a new delivery and thus starts with the first attempt
+	return w.reexecuteWebhook(ctx, webhook, webhookExecution, 1)
+}
+
+// reexecuteWebhook executes the webhook again with the request body of the provided execution.
+func (w *WebhookExecutor) reexecuteWebhook(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	webhookExecution *types.WebhookExecutionCore,
+	attempt int,
+) (*TriggerResult, error) {
+	// ensure webhook can be retriggered
+	if !webhookExecution.Retriggerable {
+		return nil, ErrWebhookNotRetriggerable
+	}
+
 	// reuse same trigger id as original execution
 	triggerID := webhookExecution.TriggerID
 	triggerType := webhookExecution.TriggerType
@@ -176,7 +189,7 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 	// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
 	body.WriteString(webhookExecution.Request.Body)
 
-	newExecution, err := w.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID)
+	newExecution, err := w.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID, attempt)
 	return &TriggerResult{
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
@@ -189,7 +202,7 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 //nolint:gocognit // refactor into smaller chunks if necessary.
 func (w *WebhookExecutor) executeWebhook(
 	ctx context.Context, webhook *types.WebhookCore, triggerID string,
-	triggerType enum.WebhookTrigger, body any, rerunOfID *int64,
+	triggerType enum.WebhookTrigger, body any, rerunOfID *int64, attempt int,
 ) (*types.WebhookExecutionCore, error) {
 	// build execution entry on the fly (save no matter what)
 	execution := types.WebhookExecutionCore{
@@ -197,6 +210,7 @@ func (w *WebhookExecutor) executeWebhook(
 		WebhookID:   webhook.ID,
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
+		Attempt:     attempt,
 		// for unexpected errors we don't retry - protect the system. User can retrigger manually (if body was set)
 		Result: enum.WebhookExecutionResultFatalError,
 		Error:  "An unknown error occurred",
@@ -206,6 +220,11 @@ func (w *WebhookExecutor) executeWebhook(
 		execution.Duration = int64(time.Since(start))
 		execution.Created = time.Now().UnixMilli()
 
+		// schedule an automatic retry or mark the delivery as permanently failed
+		if w.autoRetry {
+			w.planRetry(&execution)
+		}
+
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
 		err := w.webhookExecutorStore.CreateWebhookExecution(oCtx, &execution)
 		if err != nil {
@@ -214,14 +233,9 @@ func (w *WebhookExecutor) executeWebhook(
 				execution.Result, execution.Response.Status, execution.Error)
 		}
 
-		// update latest execution result of webhook IFF it's different from before (best effort)
-		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
-			_, err = w.webhookExecutorStore.UpdateOptLock(oCtx, webhook, &execution)
-			if err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msgf(
-					"failed to update latest execution result to %s for webhook %d",
-					execution.Result, webhook.ID)
-			}
+		// update latest execution result and circuit breaker of webhook IFF anything changed (best effort)
+		if w.webhookNeedsUpdate(webhook, &execution) {
+			w.updateWebhookAfterExecution(oCtx, webhook, &execution)
 		}
 	}(ctx, time.Now())
 
@@ -262,10 +276,9 @@ func (w *WebhookExecutor) executeWebhook(
 	var dnsError *net.DNSError
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
-		// we assume timeout without any response is not worth retrying - protect the system
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = w.requestErrorResult()
 		return &execution, tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
@@ -274,12 +287,20 @@ func (w *WebhookExecutor) executeWebhook(
 		execution.Result = enum.WebhookExecutionResultFatalError
 		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
-	case err != nil:
-		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
+	case errors.Is(err, errLoopbackNotAllowed),
+		errors.Is(err, errLinkLocalNotAllowed),
+		errors.Is(err, errPrivateNetworkNotAllowed):
+		// the target address is blocked, retrying won't change that
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
 		execution.Result = enum.WebhookExecutionResultFatalError
 		return &execution, tErr
+
+	case err != nil:
+		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
+		execution.Error = tErr.Error()
+		execution.Result = w.requestErrorResult()
+		return &execution, tErr
 	}
 
 	// handle response
@@ -288,9 +309,23 @@ func (w *WebhookExecutor) executeWebhook(
 	return &execution, err
 }
 
+// requestErrorResult returns the result of an execution whose request failed without a response
+// (timeout, connection refused or reset, ...).
+// Such errors might be temporary, but without automatic retries we don't retry them - protect the system.
+// User can retrigger manually. With automatic retries, the system is protected by the retry backoff
+// and the circuit breaker, hence the execution is retried.
+func (w *WebhookExecutor) requestErrorResult() enum.WebhookExecutionResult {
+	if w.autoRetry {
+		return enum.WebhookExecutionResultRetriableError
+	}
+
+	return enum.WebhookExecutionResultFatalError
+}
+
 // prepareHTTPRequest prepares a new http.Request object for the webhook using the provided body as request body.
 // All execution.Request.XXX values are set accordingly.
-// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized.
+// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized
+// and rendered using the payload template of the webhook.
 func (w *WebhookExecutor) prepareHTTPRequest(
 	ctx context.Context, execution *types.WebhookExecutionCore,
 	triggerType enum.WebhookTrigger, webhook *types.WebhookCore, body any,
@@ -330,6 +365,19 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 			execution.Result = enum.WebhookExecutionResultFatalError
 			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
 		}
+
+		// render the body using the payload template and content type of the webhook
+		rendered, err := renderPayload(webhook.ContentType, webhook.PayloadTemplateType, webhook.PayloadTemplate,
+			bBuff.Bytes())
+		if err != nil {
+			// ASSUMPTION: there was an issue with the user provided template, not retriable
+			tErr := fmt.Errorf("failed to render payload template: %w", err)
+			execution.Error = tErr.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return nil, tErr
+		}
+
+		bBuff = bytes.NewBuffer(rendered)
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
@@ -352,7 +400,7 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 
 	// setup headers
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", w.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("Content-Type", ContentTypeHeader(webhook.ContentType))
 
 	req.Header.Add(w.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(w.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -545,6 +593,9 @@ func CoreWebhookExecutionToGitnessWebhookExecution(execution *types.WebhookExecu
 		Retriggerable: execution.Retriggerable,
 		Duration:      execution.Duration,
 		Created:       execution.Created,
+		Attempt:       execution.Attempt,
+		RetryAt:       execution.RetryAt,
+		DeadLetter:    execution.DeadLetter,
 	}
 }
 
@@ -562,6 +613,9 @@ func GitnessWebhookExecutionToWebhookExecutionCore(execution *types.WebhookExecu
 		Retriggerable: execution.Retriggerable,
 		Duration:      execution.Duration,
 		Created:       execution.Created,
+		Attempt:       execution.Attempt,
+		RetryAt:       execution.RetryAt,
+		DeadLetter:    execution.DeadLetter,
 	}
 }
 
@@ -586,6 +640,10 @@ func GitnessWebhookToWebhookCore(webhook *types.Webhook) *types.WebhookCore {
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
 		ExtraHeaders:          webhook.ExtraHeaders,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		ContentType:           webhook.ContentType,
+		PayloadTemplateType:   webhook.PayloadTemplateType,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
@@ -610,6 +668,10 @@ func CoreWebhookToGitnessWebhook(webhook *types.WebhookCore) *types.Webhook {
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
 		ExtraHeaders:          webhook.ExtraHeaders,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		ContentType:           webhook.ContentType,
+		PayloadTemplateType:   webhook.PayloadTemplateType,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
@@ -119,6 +119,10 @@ func (s *Service) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		// re-enabling a webhook resets its circuit breaker
+		if *in.Enabled && !hook.Enabled {
+			hook.ConsecutiveFailures = 0
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
@@ -133,6 +137,29 @@ func (s *Service) Update(
 	if in.ExtraHeaders != nil {
 		hook.ExtraHeaders = in.ExtraHeaders
 	}
+	if in.ContentType != nil {
+		hook.ContentType = *in.ContentType
+	}
+	if in.PayloadTemplateType != nil {
+		hook.PayloadTemplateType = *in.PayloadTemplateType
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
+
+	// validate the payload template against the resulting content type, template type and triggers
+	if in.ContentType != nil || in.PayloadTemplateType != nil || in.PayloadTemplate != nil || in.Triggers != nil {
+		if err := CheckPayloadTemplate(
+			hook.ContentType,
+			hook.PayloadTemplateType,
+			hook.PayloadTemplate,
+			hook.Triggers,
+		); err != nil {
+			return nil, err
+		}
+		hook.ContentType, _ = hook.ContentType.Sanitize()
+		hook.PayloadTemplateType, _ = hook.PayloadTemplateType.Sanitize()
+	}
 
 	if err := s.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
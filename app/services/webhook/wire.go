@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -63,6 +64,8 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	jobExecutor *job.Executor,
+	jobScheduler *job.Scheduler,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -87,6 +90,8 @@ func ProvideService(
 		sseStreamer,
 		secretService,
 		spacePathStore,
+		jobExecutor,
+		jobScheduler,
 	)
 }
 
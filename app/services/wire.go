@@ -15,6 +15,7 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/services/aitaskevent"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
@@ -33,10 +34,12 @@ import (
 	"github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/repoactivity"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/registry/job/handler"
 	registryasyncprocessing "github.com/harness/gitness/registry/services/asyncprocessing"
+	registryscanning "github.com/harness/gitness/registry/services/scanning"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 
 	"github.com/google/wire"
@@ -55,6 +58,7 @@ type Services struct {
 	RepoSizeCalculator             *repo.SizeCalculator
 	Repo                           *repo.Service
 	Cleanup                        *cleanup.Service
+	StageApproval                  *approval.Service
 	Notification                   *notification.Service
 	Keywordsearch                  *keywordsearch.Service
 	GitspaceService                *GitspaceServices
@@ -65,7 +69,9 @@ type Services struct {
 	Branch                         *branch.Service
 	repoActivity                   *repoactivity.Service
 	registryAsyncProcessingService *registryasyncprocessing.Service
+	registryScanningService        *registryscanning.Service
 	languageAnalyzer               languageanalyzer.LanguageAnalyzer
+	ldapSyncer                     *usergroup.LDAPSyncer
 }
 
 type GitspaceServices struct {
@@ -107,6 +113,7 @@ func ProvideServices(
 	repoSizeCalculator *repo.SizeCalculator,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
+	stageApprovalSvc *approval.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
@@ -117,8 +124,10 @@ func ProvideServices(
 	branchSvc *branch.Service,
 	repoActivitySvc *repoactivity.Service,
 	registryAsyncProcessingService *registryasyncprocessing.Service,
+	registryScanningService *registryscanning.Service,
 	registryJobRpmRegistryIndex *handler.JobRpmRegistryIndex,
 	languageAnalyzer languageanalyzer.LanguageAnalyzer,
+	ldapSyncer *usergroup.LDAPSyncer,
 ) Services {
 	return Services{
 		Webhook:                        webhooksSvc,
@@ -129,6 +138,7 @@ func ProvideServices(
 		RepoSizeCalculator:             repoSizeCalculator,
 		Repo:                           repo,
 		Cleanup:                        cleanupSvc,
+		StageApproval:                  stageApprovalSvc,
 		Notification:                   notificationSvc,
 		Keywordsearch:                  keywordsearchSvc,
 		GitspaceService:                gitspaceSvc,
@@ -139,6 +149,8 @@ func ProvideServices(
 		Branch:                         branchSvc,
 		repoActivity:                   repoActivitySvc,
 		registryAsyncProcessingService: registryAsyncProcessingService,
+		registryScanningService:        registryScanningService,
 		languageAnalyzer:               languageAnalyzer,
+		ldapSyncer:                     ldapSyncer,
 	}
 }
@@ -446,6 +446,27 @@ type (
 			userID int64,
 			filter types.MembershipSpaceFilter,
 		) ([]types.MembershipSpace, error)
+
+		// CountByRole returns the number of memberships in the spaces that have the role assigned.
+		CountByRole(ctx context.Context, spaceIDs []int64, role enum.MembershipRole) (int64, error)
+	}
+
+	// CustomRoleStore defines the custom membership role data storage.
+	CustomRoleStore interface {
+		// Find returns the custom role of the space with the identifier, the identifier is case-insensitive.
+		Find(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error)
+
+		// List returns all custom roles of the spaces.
+		List(ctx context.Context, spaceIDs []int64) ([]*types.CustomRole, error)
+
+		// Create creates a new custom role.
+		Create(ctx context.Context, role *types.CustomRole) error
+
+		// Update updates the description and the permissions of the custom role.
+		Update(ctx context.Context, role *types.CustomRole) error
+
+		// Delete deletes the custom role.
+		Delete(ctx context.Context, id int64) error
 	}
 
 	// PublicAccessStore defines the publicly accessible resources data storage.
@@ -841,6 +862,29 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListDeadLetters lists the permanently failed webhook executions for a given webhook id.
+		ListDeadLetters(
+			ctx context.Context, webhookID int64,
+			opts *types.WebhookExecutionFilter,
+		) ([]*types.WebhookExecution, error)
+
+		// CountDeadLetters counts the permanently failed webhook executions for a given webhook id.
+		CountDeadLetters(ctx context.Context, webhookID int64) (int64, error)
+
+		// UpdateDeadLetter marks or unmarks the webhook executions with the given ids as permanently failed.
+		UpdateDeadLetter(ctx context.Context, ids []int64, deadLetter bool) error
+
+		// ListDueRetries lists the webhook executions with an automatic retry that is due at the provided time.
+		ListDueRetries(ctx context.Context, now int64, limit int) ([]*types.WebhookExecution, error)
+
+		// ClaimRetry postpones the due automatic retry of the webhook execution to leaseUntil,
+		// so that the retry is picked up again if it isn't completed until then.
+		// It returns false if the retry was already claimed.
+		ClaimRetry(ctx context.Context, id int64, retryAt int64, leaseUntil int64) (bool, error)
+
+		// ClearRetry clears the automatic retry of the webhook execution.
+		ClearRetry(ctx context.Context, id int64) error
 	}
 
 	CheckStore interface {
@@ -1164,6 +1208,79 @@ type (
 		Update(ctx context.Context, e *types.Step) error
 	}
 
+	TestCaseStore interface {
+		// CreateMany creates the test cases of a test report.
+		CreateMany(ctx context.Context, cases []*types.TestCase) error
+
+		// List lists the test cases of an execution, failed test cases first.
+		List(ctx context.Context, executionID int64, filter *types.TestCaseFilter) ([]*types.TestCase, error)
+
+		// Count counts the test cases of an execution matching the filter.
+		Count(ctx context.Context, executionID int64, filter *types.TestCaseFilter) (int64, error)
+
+		// Summarize returns the number of test cases of an execution by status.
+		Summarize(ctx context.Context, executionID int64) (*types.TestSummary, error)
+
+		// CopyStage copies the test cases of a stage to a stage of another execution,
+		// which must have the same steps.
+		CopyStage(ctx context.Context, fromStageID, toExecutionID, toStageID int64) error
+	}
+
+	ExecutionArtifactStore interface {
+		// Create creates an execution artifact.
+		Create(ctx context.Context, artifact *types.ExecutionArtifact) error
+
+		// Find returns an artifact of an execution given its ID.
+		Find(ctx context.Context, executionID, id int64) (*types.ExecutionArtifact, error)
+
+		// List lists the artifacts of an execution.
+		List(ctx context.Context, executionID int64, pagination types.Pagination) ([]*types.ExecutionArtifact, error)
+
+		// Count counts the artifacts of an execution.
+		Count(ctx context.Context, executionID int64) (int64, error)
+
+		// ListExpired lists up to limit artifacts that are expired according to the retention.
+		ListExpired(
+			ctx context.Context,
+			retention types.ExecutionArtifactRetention,
+			limit int,
+		) ([]*types.ExecutionArtifact, error)
+
+		// ListOrphaned lists up to limit artifacts whose execution was deleted.
+		ListOrphaned(ctx context.Context, limit int) ([]*types.ExecutionArtifact, error)
+
+		// CopyStage copies the artifacts of a stage to a stage of another execution,
+		// which must have the same steps. The copies share the blobs of the original artifacts.
+		CopyStage(ctx context.Context, fromStageID, toExecutionID, toStageID int64) error
+
+		// CountByBlobPath counts the artifacts stored in a blob.
+		CountByBlobPath(ctx context.Context, blobPath string) (int64, error)
+
+		// Delete deletes an execution artifact.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	StageApprovalStore interface {
+		// Create creates the approval gate of a stage.
+		Create(ctx context.Context, approval *types.StageApproval) error
+
+		// FindByStageID returns the approval gate of a stage.
+		FindByStageID(ctx context.Context, stageID int64) (*types.StageApproval, error)
+
+		// ListByExecution lists the approval gates of an execution.
+		ListByExecution(ctx context.Context, executionID int64) ([]*types.StageApproval, error)
+
+		// Update tries to update an approval gate using the optimistic locking mechanism.
+		Update(ctx context.Context, approval *types.StageApproval) error
+
+		// ListExpired lists up to limit pending approval gates whose deadline passed before now.
+		ListExpired(ctx context.Context, now int64, limit int) ([]*types.StageApproval, error)
+
+		// ListUnapplied lists up to limit approval gates decided before the given time
+		// whose stage is still blocked.
+		ListUnapplied(ctx context.Context, decidedBefore int64, limit int) ([]*types.StageApproval, error)
+	}
+
 	ConnectorStore interface {
 		// Find returns a connector given an ID.
 		Find(ctx context.Context, id int64) (*types.Connector, error)
@@ -1320,6 +1437,42 @@ type (
 		) error
 	}
 
+	// UserGroupMemberStore stores the user principals that are members of user groups.
+	UserGroupMemberStore interface {
+		// ListUserGroupIDs returns the IDs of all user groups the principal is a member of.
+		ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error)
+
+		// ListPrincipalIDs returns the distinct IDs of all principals that are members of any of the user groups.
+		ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+
+		// MapPrincipals returns the member principals of each of the provided user groups.
+		MapPrincipals(ctx context.Context, userGroupIDs []int64) (map[int64][]*types.Principal, error)
+
+		// Create adds the principal to the user group. It's a no-op if the principal is already a member.
+		Create(ctx context.Context, member *types.UserGroupMember) error
+
+		// Delete removes the principal from the user group.
+		Delete(ctx context.Context, userGroupID, principalID int64) error
+	}
+
+	// ExternalIdentityStore stores the links between user principals and external identity providers.
+	ExternalIdentityStore interface {
+		// Find returns the external identity given the provider and the subject.
+		Find(ctx context.Context, provider, subject string) (*types.ExternalIdentity, error)
+
+		// Create creates a new external identity.
+		Create(ctx context.Context, identity *types.ExternalIdentity) error
+
+		// Touch updates the last updated time of the external identity.
+		Touch(ctx context.Context, provider, subject string, updated int64) error
+
+		// ListByProvider returns all external identities of the provider.
+		ListByProvider(ctx context.Context, provider string) ([]*types.ExternalIdentity, error)
+
+		// ListByPrincipal returns all external identities linked to the principal.
+		ListByPrincipal(ctx context.Context, principalID int64) ([]*types.ExternalIdentity, error)
+	}
+
 	PublicKeyStore interface {
 		// Find returns a public key given an ID.
 		Find(ctx context.Context, id int64) (*types.PublicKey, error)
@@ -1723,6 +1876,51 @@ type (
 		Delete(ctx context.Context, principalID int64, in *types.FavoriteResource) error
 	}
 
+	NotificationPreferenceStore interface {
+		// List returns all notification preferences of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationPreference, error)
+
+		// MapModes returns the notification modes of the principals for the event.
+		// Principals without a preference for the event are not part of the result.
+		MapModes(
+			ctx context.Context,
+			event enum.NotificationEvent,
+			principalIDs []int64,
+		) (map[int64]enum.NotificationMode, error)
+
+		// Upsert creates or updates the notification preference of the principal for the event.
+		Upsert(ctx context.Context, pref *types.NotificationPreference) error
+	}
+
+	NotificationWatchStore interface {
+		// Find finds the watch of the principal for a repo (pullReqID is zero) or a pull request.
+		Find(ctx context.Context, principalID, repoID, pullReqID int64) (*types.NotificationWatch, error)
+
+		// ListForPullReq returns the watches of the pull request and the watches of its repo.
+		ListForPullReq(ctx context.Context, repoID, pullReqID int64) ([]*types.NotificationWatch, error)
+
+		// Upsert creates or updates a watch.
+		Upsert(ctx context.Context, watch *types.NotificationWatch) error
+
+		// Delete deletes the watch of the principal for a repo (pullReqID is zero) or a pull request.
+		Delete(ctx context.Context, principalID, repoID, pullReqID int64) error
+	}
+
+	NotificationDigestStore interface {
+		// Create queues a notification for the next digest.
+		// Notifications are deduplicated by the event ID, principal and event.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns IDs of all principals with queued notifications for the digest mode.
+		ListPrincipalIDs(ctx context.Context, mode enum.NotificationMode) ([]int64, error)
+
+		// List returns the queued notifications of the principal for the digest mode, oldest first.
+		List(ctx context.Context, principalID int64, mode enum.NotificationMode) ([]*types.NotificationDigestItem, error)
+
+		// DeleteMany deletes the queued notifications with the provided IDs.
+		DeleteMany(ctx context.Context, ids []int64) error
+	}
+
 	AutoLinkStore interface {
 		// Create creates a new autolink.
 		Create(ctx context.Context, autolink *types.AutoLink) error
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CustomRoleStore = (*CustomRoleStore)(nil)
+
+// NewCustomRoleStore returns a new CustomRoleStore.
+func NewCustomRoleStore(db *sqlx.DB) *CustomRoleStore {
+	return &CustomRoleStore{
+		db: db,
+	}
+}
+
+// CustomRoleStore implements store.CustomRoleStore backed by a relational database.
+type CustomRoleStore struct {
+	db *sqlx.DB
+}
+
+type customRole struct {
+	ID          int64  `db:"custom_role_id"`
+	SpaceID     int64  `db:"custom_role_space_id"`
+	Identifier  string `db:"custom_role_identifier"`
+	Description string `db:"custom_role_description"`
+	Permissions string `db:"custom_role_permissions"`
+	CreatedBy   int64  `db:"custom_role_created_by"`
+	Created     int64  `db:"custom_role_created"`
+	Updated     int64  `db:"custom_role_updated"`
+}
+
+const (
+	customRoleColumns = `
+		 custom_role_id
+		,custom_role_space_id
+		,custom_role_identifier
+		,custom_role_description
+		,custom_role_permissions
+		,custom_role_created_by
+		,custom_role_created
+		,custom_role_updated`
+)
+
+// Find returns the custom role of the space with the identifier, the identifier is case-insensitive.
+func (s *CustomRoleStore) Find(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error) {
+	const sqlQuery = `
+	SELECT` + customRoleColumns + `
+	FROM custom_roles
+	WHERE custom_role_space_id = $1
+		AND LOWER(custom_role_identifier) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &customRole{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find custom role")
+	}
+
+	return mapToCustomRole(dst)
+}
+
+// List returns all custom roles of the spaces.
+func (s *CustomRoleStore) List(ctx context.Context, spaceIDs []int64) ([]*types.CustomRole, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.CustomRole{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(customRoleColumns).
+		From("custom_roles").
+		Where(squirrel.Eq{"custom_role_space_id": spaceIDs}).
+		OrderBy("custom_role_identifier", "custom_role_space_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert custom role list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*customRole
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list custom roles")
+	}
+
+	roles := make([]*types.CustomRole, len(dst))
+	for i, r := range dst {
+		roles[i], err = mapToCustomRole(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+// Create creates a new custom role.
+func (s *CustomRoleStore) Create(ctx context.Context, role *types.CustomRole) error {
+	const sqlQuery = `
+	INSERT INTO custom_roles (
+		 custom_role_space_id
+		,custom_role_identifier
+		,custom_role_description
+		,custom_role_permissions
+		,custom_role_created_by
+		,custom_role_created
+		,custom_role_updated
+	) VALUES (
+		 :custom_role_space_id
+		,:custom_role_identifier
+		,:custom_role_description
+		,:custom_role_permissions
+		,:custom_role_created_by
+		,:custom_role_created
+		,:custom_role_updated
+	) RETURNING custom_role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRole, err := mapToInternalCustomRole(role)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind custom role object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&role.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert custom role")
+	}
+
+	return nil
+}
+
+// Update updates the description and the permissions of the custom role.
+func (s *CustomRoleStore) Update(ctx context.Context, role *types.CustomRole) error {
+	const sqlQuery = `
+	UPDATE custom_roles
+	SET
+		 custom_role_description = :custom_role_description
+		,custom_role_permissions = :custom_role_permissions
+		,custom_role_updated = :custom_role_updated
+	WHERE custom_role_id = :custom_role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRole, err := mapToInternalCustomRole(role)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind custom role object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update custom role")
+	}
+
+	return nil
+}
+
+// Delete deletes the custom role.
+func (s *CustomRoleStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM custom_roles
+	WHERE custom_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete custom role")
+	}
+
+	return nil
+}
+
+func mapToCustomRole(r *customRole) (*types.CustomRole, error) {
+	var permissions []enum.Permission
+	if err := json.Unmarshal([]byte(r.Permissions), &permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal permissions of custom role: %w", err)
+	}
+
+	return &types.CustomRole{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		Description: r.Description,
+		Permissions: permissions,
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}, nil
+}
+
+func mapToInternalCustomRole(r *types.CustomRole) (*customRole, error) {
+	permissions, err := json.Marshal(r.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal permissions of custom role: %w", err)
+	}
+
+	return &customRole{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		Description: r.Description,
+		Permissions: string(permissions),
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}, nil
+}
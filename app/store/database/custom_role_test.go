@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCustomRoleStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	roleStore := database.NewCustomRoleStore(db)
+
+	permissions := []enum.Permission{enum.PermissionPipelineExecute, enum.PermissionRepoPush, enum.PermissionRepoView}
+	role := &types.CustomRole{
+		SpaceID:     1,
+		Identifier:  "release-manager",
+		Description: "Release Manager",
+		Permissions: permissions,
+		CreatedBy:   userID,
+	}
+	if err := roleStore.Create(ctx, role); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	duplicate := &types.CustomRole{SpaceID: 1, Identifier: "Release-Manager", Permissions: permissions, CreatedBy: userID}
+	if err := roleStore.Create(ctx, duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Create() of a duplicate identifier error = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	child := &types.CustomRole{SpaceID: 2, Identifier: "auditor", Permissions: permissions[2:], CreatedBy: userID}
+	if err := roleStore.Create(ctx, child); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := roleStore.Find(ctx, 1, "RELEASE-MANAGER")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if !reflect.DeepEqual(found, role) {
+		t.Errorf("Find() = %+v, want %+v", found, role)
+	}
+
+	if _, err = roleStore.Find(ctx, 2, "release-manager"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Find() in another space error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	roles, err := roleStore.List(ctx, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(roles) != 2 || roles[0].Identifier != "auditor" || roles[1].Identifier != "release-manager" {
+		t.Errorf("List() returned unexpected roles: %+v", roles)
+	}
+
+	role.Description = "Releases"
+	role.Permissions = permissions[1:]
+	if err = roleStore.Update(ctx, role); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err = roleStore.Find(ctx, 1, "release-manager")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if !reflect.DeepEqual(found, role) {
+		t.Errorf("Find() after update = %+v, want %+v", found, role)
+	}
+
+	membershipStore := database.NewMembershipStore(db, nil, spacePathStore, spaceStore)
+	if err = membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: userID},
+		CreatedBy:     userID,
+		Role:          "release-manager",
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	count, err := membershipStore.CountByRole(ctx, []int64{1, 2}, "Release-Manager")
+	if err != nil {
+		t.Fatalf("CountByRole() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountByRole() = %d, want 1", count)
+	}
+
+	if err = roleStore.Delete(ctx, role.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = roleStore.Find(ctx, 1, "release-manager"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Find() after delete error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
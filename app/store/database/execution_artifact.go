@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ExecutionArtifactStore = (*executionArtifactStore)(nil)
+
+const (
+	executionArtifactColumns = `
+	execution_artifact_id
+	,execution_artifact_execution_id
+	,execution_artifact_stage_id
+	,execution_artifact_step_id
+	,execution_artifact_name
+	,execution_artifact_size
+	,execution_artifact_blob_path
+	,execution_artifact_created
+	`
+)
+
+type executionArtifact struct {
+	ID          int64  `db:"execution_artifact_id"`
+	ExecutionID int64  `db:"execution_artifact_execution_id"`
+	StageID     int64  `db:"execution_artifact_stage_id"`
+	StepID      int64  `db:"execution_artifact_step_id"`
+	Name        string `db:"execution_artifact_name"`
+	Size        int64  `db:"execution_artifact_size"`
+	BlobPath    string `db:"execution_artifact_blob_path"`
+	Created     int64  `db:"execution_artifact_created"`
+}
+
+// NewExecutionArtifactStore returns a new ExecutionArtifactStore.
+func NewExecutionArtifactStore(db *sqlx.DB) store.ExecutionArtifactStore {
+	return &executionArtifactStore{
+		db: db,
+	}
+}
+
+type executionArtifactStore struct {
+	db *sqlx.DB
+}
+
+// Create creates an execution artifact.
+func (s *executionArtifactStore) Create(ctx context.Context, artifact *types.ExecutionArtifact) error {
+	const executionArtifactInsertStmt = `
+	INSERT INTO execution_artifacts (
+		execution_artifact_execution_id
+		,execution_artifact_stage_id
+		,execution_artifact_step_id
+		,execution_artifact_name
+		,execution_artifact_size
+		,execution_artifact_blob_path
+		,execution_artifact_created
+	) VALUES (
+		:execution_artifact_execution_id
+		,:execution_artifact_stage_id
+		,:execution_artifact_step_id
+		,:execution_artifact_name
+		,:execution_artifact_size
+		,:execution_artifact_blob_path
+		,:execution_artifact_created
+	) RETURNING execution_artifact_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(executionArtifactInsertStmt, mapExecutionArtifactToInternal(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind execution artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Execution artifact query failed")
+	}
+
+	return nil
+}
+
+// Find returns an artifact of an execution given its ID.
+func (s *executionArtifactStore) Find(
+	ctx context.Context,
+	executionID int64,
+	id int64,
+) (*types.ExecutionArtifact, error) {
+	const findQueryStmt = `
+	SELECT` + executionArtifactColumns + `
+	FROM execution_artifacts
+	WHERE execution_artifact_execution_id = $1 AND execution_artifact_id = $2`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(executionArtifact)
+	if err := db.GetContext(ctx, dst, findQueryStmt, executionID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find execution artifact")
+	}
+
+	return mapInternalToExecutionArtifact(dst), nil
+}
+
+// List lists the artifacts of an execution.
+func (s *executionArtifactStore) List(
+	ctx context.Context,
+	executionID int64,
+	pagination types.Pagination,
+) ([]*types.ExecutionArtifact, error) {
+	stmt := database.Builder.
+		Select(executionArtifactColumns).
+		From("execution_artifacts").
+		Where("execution_artifact_execution_id = ?", executionID).
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size)).
+		OrderBy("execution_artifact_name", "execution_artifact_id")
+
+	return s.list(ctx, stmt)
+}
+
+// Count counts the artifacts of an execution.
+func (s *executionArtifactStore) Count(ctx context.Context, executionID int64) (int64, error) {
+	const countQueryStmt = `
+	SELECT count(*)
+	FROM execution_artifacts
+	WHERE execution_artifact_execution_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, countQueryStmt, executionID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count execution artifacts")
+	}
+
+	return count, nil
+}
+
+// ListExpired lists up to limit artifacts that are expired according to the retention.
+func (s *executionArtifactStore) ListExpired(
+	ctx context.Context,
+	retention types.ExecutionArtifactRetention,
+	limit int,
+) ([]*types.ExecutionArtifact, error) {
+	expired := squirrel.Or{}
+	if retention.CreatedBefore > 0 {
+		expired = append(expired, squirrel.Lt{"execution_artifact_created": retention.CreatedBefore})
+	}
+	if retention.KeepExecutions > 0 {
+		expired = append(expired, squirrel.Expr("execution_number <= pipeline_seq - ?", retention.KeepExecutions))
+	}
+	if len(expired) == 0 {
+		return []*types.ExecutionArtifact{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(executionArtifactColumns).
+		From("execution_artifacts").
+		Join("executions ON execution_id = execution_artifact_execution_id").
+		Join("pipelines ON pipeline_id = execution_pipeline_id").
+		Where(expired).
+		OrderBy("execution_artifact_id").
+		Limit(uint64(limit)) //nolint:gosec
+
+	return s.list(ctx, stmt)
+}
+
+// ListOrphaned lists up to limit artifacts whose execution was deleted.
+func (s *executionArtifactStore) ListOrphaned(ctx context.Context, limit int) ([]*types.ExecutionArtifact, error) {
+	stmt := database.Builder.
+		Select(executionArtifactColumns).
+		From("execution_artifacts").
+		Where("NOT EXISTS (SELECT 1 FROM executions WHERE execution_id = execution_artifact_execution_id)").
+		OrderBy("execution_artifact_id").
+		Limit(uint64(limit)) //nolint:gosec
+
+	return s.list(ctx, stmt)
+}
+
+// CopyStage copies the artifacts of a stage to a stage of another execution, which must have the same steps.
+// The copies share the blobs of the original artifacts.
+func (s *executionArtifactStore) CopyStage(ctx context.Context, fromStageID, toExecutionID, toStageID int64) error {
+	const executionArtifactCopyStmt = `
+	INSERT INTO execution_artifacts (
+		execution_artifact_execution_id
+		,execution_artifact_stage_id
+		,execution_artifact_step_id
+		,execution_artifact_name
+		,execution_artifact_size
+		,execution_artifact_blob_path
+		,execution_artifact_created
+	)
+	SELECT
+		$1
+		,$2
+		,to_step.step_id
+		,execution_artifact_name
+		,execution_artifact_size
+		,execution_artifact_blob_path
+		,execution_artifact_created
+	FROM execution_artifacts
+	JOIN steps from_step ON from_step.step_id = execution_artifact_step_id
+	JOIN steps to_step ON to_step.step_stage_id = $2 AND to_step.step_number = from_step.step_number
+	WHERE execution_artifact_stage_id = $3`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, executionArtifactCopyStmt, toExecutionID, toStageID, fromStageID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to copy execution artifacts")
+	}
+
+	return nil
+}
+
+// CountByBlobPath counts the artifacts stored in a blob.
+func (s *executionArtifactStore) CountByBlobPath(ctx context.Context, blobPath string) (int64, error) {
+	const countQueryStmt = `
+	SELECT count(*)
+	FROM execution_artifacts
+	WHERE execution_artifact_blob_path = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, countQueryStmt, blobPath).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count execution artifacts of blob")
+	}
+
+	return count, nil
+}
+
+// Delete deletes an execution artifact.
+func (s *executionArtifactStore) Delete(ctx context.Context, id int64) error {
+	const executionArtifactDeleteStmt = `
+	DELETE FROM execution_artifacts
+	WHERE execution_artifact_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, executionArtifactDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Could not delete execution artifact")
+	}
+
+	return nil
+}
+
+func (s *executionArtifactStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.ExecutionArtifact, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := []*executionArtifact{}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list execution artifacts")
+	}
+
+	res := make([]*types.ExecutionArtifact, len(dst))
+	for i := range dst {
+		res[i] = mapInternalToExecutionArtifact(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapExecutionArtifactToInternal(in *types.ExecutionArtifact) *executionArtifact {
+	return &executionArtifact{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		StepID:      in.StepID,
+		Name:        in.Name,
+		Size:        in.Size,
+		BlobPath:    in.BlobPath,
+		Created:     in.Created,
+	}
+}
+
+func mapInternalToExecutionArtifact(in *executionArtifact) *types.ExecutionArtifact {
+	return &types.ExecutionArtifact{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		StepID:      in.StepID,
+		Name:        in.Name,
+		Size:        in.Size,
+		BlobPath:    in.BlobPath,
+		Created:     in.Created,
+	}
+}
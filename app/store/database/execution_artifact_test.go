@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestExecutionArtifactStore_ListExpired(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const repoID int64 = 1
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	pipeline := &types.Pipeline{Identifier: "pipeline", RepoID: repoID, CreatedBy: userID, Seq: 3}
+	if err := database.NewPipelineStore(db).Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline %v", err)
+	}
+
+	executionStore := database.NewExecutionStore(db)
+	executions := make([]*types.Execution, 3)
+	for i := range executions {
+		executions[i] = &types.Execution{PipelineID: pipeline.ID, RepoID: repoID, CreatedBy: userID, Number: int64(i + 1)}
+		if err := executionStore.Create(ctx, executions[i]); err != nil {
+			t.Fatalf("failed to create execution %v", err)
+		}
+	}
+
+	stageStore := database.NewStageStore(db)
+	if err := stageStore.Create(ctx, &types.Stage{
+		ExecutionID: executions[0].ID, RepoID: repoID, Number: 1, Name: "build",
+	}); err != nil {
+		t.Fatalf("failed to create stage %v", err)
+	}
+	stages, err := stageStore.ListWithSteps(ctx, executions[0].ID)
+	if err != nil || len(stages) != 1 {
+		t.Fatalf("failed to list stages %v", err)
+	}
+	step := &types.Step{StageID: stages[0].ID, Number: 1, Name: "build"}
+	if err := database.NewStepStore(db).Create(ctx, step); err != nil {
+		t.Fatalf("failed to create step %v", err)
+	}
+
+	store := database.NewExecutionArtifactStore(db)
+	for i, execution := range executions {
+		artifact := &types.ExecutionArtifact{
+			ExecutionID: execution.ID,
+			StageID:     stages[0].ID,
+			StepID:      step.ID,
+			Name:        fmt.Sprintf("%d/app.bin", execution.Number),
+			Size:        42,
+			BlobPath:    "pipeline-artifacts/app.bin",
+			Created:     int64(i+1) * 1000,
+		}
+		if err := store.Create(ctx, artifact); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		retention types.ExecutionArtifactRetention
+		want      []int64
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:      "created before",
+			retention: types.ExecutionArtifactRetention{CreatedBefore: 2000},
+			want:      []int64{executions[0].ID},
+		},
+		{
+			name:      "keep latest executions",
+			retention: types.ExecutionArtifactRetention{KeepExecutions: 1},
+			want:      []int64{executions[0].ID, executions[1].ID},
+		},
+		{
+			name:      "keep all executions",
+			retention: types.ExecutionArtifactRetention{KeepExecutions: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := store.ListExpired(ctx, tt.retention, 10)
+			if err != nil {
+				t.Fatalf("ListExpired() error = %v", err)
+			}
+			if len(artifacts) != len(tt.want) {
+				t.Fatalf("ListExpired() returned %d artifacts, want %d", len(artifacts), len(tt.want))
+			}
+			for i, artifact := range artifacts {
+				if artifact.ExecutionID != tt.want[i] {
+					t.Errorf("artifact %d belongs to execution %d, want %d", i, artifact.ExecutionID, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("copy stage", func(t *testing.T) {
+		retried := &types.Execution{PipelineID: pipeline.ID, RepoID: repoID, CreatedBy: userID, Number: 4}
+		if err := executionStore.Create(ctx, retried); err != nil {
+			t.Fatalf("failed to create execution %v", err)
+		}
+		reused := &types.Stage{ExecutionID: retried.ID, RepoID: repoID, Number: 1, Name: "build"}
+		if err := stageStore.Create(ctx, reused); err != nil {
+			t.Fatalf("failed to create stage %v", err)
+		}
+		reusedStep := &types.Step{StageID: reused.ID, Number: 1, Name: "build"}
+		if err := database.NewStepStore(db).Create(ctx, reusedStep); err != nil {
+			t.Fatalf("failed to create step %v", err)
+		}
+
+		if err := store.CopyStage(ctx, stages[0].ID, retried.ID, reused.ID); err != nil {
+			t.Fatalf("CopyStage() error = %v", err)
+		}
+
+		copies, err := store.List(ctx, retried.ID, types.Pagination{Page: 1, Size: 10})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		// the fixture stores the artifacts of all executions in the stage of the first one.
+		if len(copies) != len(executions) {
+			t.Fatalf("CopyStage() copied %d artifacts, want %d", len(copies), len(executions))
+		}
+		for _, artifact := range copies {
+			if artifact.StageID != reused.ID || artifact.StepID != reusedStep.ID {
+				t.Errorf("copied artifact %q belongs to stage %d and step %d, want %d and %d",
+					artifact.Name, artifact.StageID, artifact.StepID, reused.ID, reusedStep.ID)
+			}
+		}
+
+		shared, err := store.CountByBlobPath(ctx, "pipeline-artifacts/app.bin")
+		if err != nil {
+			t.Fatalf("CountByBlobPath() error = %v", err)
+		}
+		if shared != int64(2*len(executions)) {
+			t.Errorf("CountByBlobPath() = %d, want %d", shared, 2*len(executions))
+		}
+	})
+
+	t.Run("orphaned", func(t *testing.T) {
+		// artifacts of a deleted execution are kept until their blobs are purged.
+		if err := executionStore.Delete(ctx, pipeline.ID, executions[1].Number); err != nil {
+			t.Fatalf("failed to delete execution %v", err)
+		}
+
+		artifacts, err := store.ListOrphaned(ctx, 10)
+		if err != nil {
+			t.Fatalf("ListOrphaned() error = %v", err)
+		}
+		if len(artifacts) != 1 || artifacts[0].ExecutionID != executions[1].ID {
+			t.Fatalf("ListOrphaned() = %+v, want the artifact of execution %d", artifacts, executions[1].ID)
+		}
+	})
+}
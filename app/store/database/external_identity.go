@@ -0,0 +1,176 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ExternalIdentityStore = (*ExternalIdentityStore)(nil)
+
+// NewExternalIdentityStore returns a new ExternalIdentityStore.
+func NewExternalIdentityStore(db *sqlx.DB) *ExternalIdentityStore {
+	return &ExternalIdentityStore{
+		db: db,
+	}
+}
+
+// ExternalIdentityStore implements store.ExternalIdentityStore backed by a relational database.
+type ExternalIdentityStore struct {
+	db *sqlx.DB
+}
+
+type externalIdentity struct {
+	Provider    string `db:"external_identity_provider"`
+	Subject     string `db:"external_identity_subject"`
+	PrincipalID int64  `db:"external_identity_principal_id"`
+	Created     int64  `db:"external_identity_created"`
+	Updated     int64  `db:"external_identity_updated"`
+}
+
+const (
+	externalIdentitySelectBase = `
+	SELECT
+		 external_identity_provider
+		,external_identity_subject
+		,external_identity_principal_id
+		,external_identity_created
+		,external_identity_updated
+	FROM external_identities`
+)
+
+// Find returns the external identity given the provider and the subject.
+func (s *ExternalIdentityStore) Find(
+	ctx context.Context,
+	provider, subject string,
+) (*types.ExternalIdentity, error) {
+	const sqlQuery = externalIdentitySelectBase + `
+	WHERE external_identity_provider = $1
+		AND external_identity_subject = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &externalIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, provider, subject); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find external identity")
+	}
+
+	identity := types.ExternalIdentity(*dst)
+
+	return &identity, nil
+}
+
+// Create creates a new external identity.
+func (s *ExternalIdentityStore) Create(ctx context.Context, identity *types.ExternalIdentity) error {
+	const sqlQuery = `
+	INSERT INTO external_identities (
+		 external_identity_provider
+		,external_identity_subject
+		,external_identity_principal_id
+		,external_identity_created
+		,external_identity_updated
+	) VALUES (
+		 :external_identity_provider
+		,:external_identity_subject
+		,:external_identity_principal_id
+		,:external_identity_created
+		,:external_identity_updated
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, externalIdentity(*identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind external identity object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert external identity")
+	}
+
+	return nil
+}
+
+// Touch updates the last updated time of the external identity.
+func (s *ExternalIdentityStore) Touch(ctx context.Context, provider, subject string, updated int64) error {
+	const sqlQuery = `
+	UPDATE external_identities
+	SET external_identity_updated = $1
+	WHERE external_identity_provider = $2
+		AND external_identity_subject = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, updated, provider, subject); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update external identity")
+	}
+
+	return nil
+}
+
+// ListByProvider returns all external identities of the provider.
+func (s *ExternalIdentityStore) ListByProvider(
+	ctx context.Context,
+	provider string,
+) ([]*types.ExternalIdentity, error) {
+	const sqlQuery = externalIdentitySelectBase + `
+	WHERE external_identity_provider = $1
+	ORDER BY external_identity_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*externalIdentity
+	if err := db.SelectContext(ctx, &dst, sqlQuery, provider); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list external identities")
+	}
+
+	identities := make([]*types.ExternalIdentity, len(dst))
+	for i, identity := range dst {
+		identities[i] = (*types.ExternalIdentity)(identity)
+	}
+
+	return identities, nil
+}
+
+// ListByPrincipal returns all external identities linked to the principal.
+func (s *ExternalIdentityStore) ListByPrincipal(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.ExternalIdentity, error) {
+	const sqlQuery = externalIdentitySelectBase + `
+	WHERE external_identity_principal_id = $1
+	ORDER BY external_identity_provider, external_identity_subject`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*externalIdentity
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list external identities")
+	}
+
+	identities := make([]*types.ExternalIdentity, len(dst))
+	for i, identity := range dst {
+		identities[i] = (*types.ExternalIdentity)(identity)
+	}
+
+	return identities, nil
+}
@@ -198,6 +198,38 @@ func (s *MembershipStore) Delete(ctx context.Context, key types.MembershipKey) e
 	return nil
 }
 
+// CountByRole returns the number of memberships in the spaces that have the role assigned.
+func (s *MembershipStore) CountByRole(
+	ctx context.Context,
+	spaceIDs []int64,
+	role enum.MembershipRole,
+) (int64, error) {
+	if len(spaceIDs) == 0 {
+		return 0, nil
+	}
+
+	stmt := database.Builder.
+		Select("count(*)").
+		From("memberships").
+		Where(squirrel.Eq{"membership_space_id": spaceIDs}).
+		Where("LOWER(membership_role) = LOWER(?)", role)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert membership count by role query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing membership count by role query")
+	}
+
+	return count, nil
+}
+
 // CountUsers returns a number of users memberships that matches the provided filter.
 func (s *MembershipStore) CountUsers(ctx context.Context,
 	spaceID int64,
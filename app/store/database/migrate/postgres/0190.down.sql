@@ -0,0 +1,3 @@
+DROP TABLE notification_digests;
+DROP TABLE notification_watches;
+DROP TABLE notification_preferences;
@@ -0,0 +1,62 @@
+CREATE TABLE notification_preferences (
+ notification_preference_principal_id INTEGER NOT NULL
+,notification_preference_event TEXT NOT NULL
+,notification_preference_mode TEXT NOT NULL
+,notification_preference_created BIGINT NOT NULL
+,notification_preference_updated BIGINT NOT NULL
+,CONSTRAINT pk_notification_preferences
+    PRIMARY KEY (notification_preference_principal_id, notification_preference_event)
+,CONSTRAINT fk_notification_preference_principal_id FOREIGN KEY (notification_preference_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+-- notification_watch_pullreq_id is 0 for the watch of the whole repository.
+CREATE TABLE notification_watches (
+ notification_watch_principal_id INTEGER NOT NULL
+,notification_watch_repo_id INTEGER NOT NULL
+,notification_watch_pullreq_id INTEGER NOT NULL
+,notification_watch_watching BOOLEAN NOT NULL
+,notification_watch_created BIGINT NOT NULL
+,notification_watch_updated BIGINT NOT NULL
+,CONSTRAINT pk_notification_watches PRIMARY KEY
+    (notification_watch_principal_id, notification_watch_repo_id, notification_watch_pullreq_id)
+,CONSTRAINT fk_notification_watch_principal_id FOREIGN KEY (notification_watch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_watch_repo_id FOREIGN KEY (notification_watch_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_watches_repo_id_pullreq_id
+    ON notification_watches(notification_watch_repo_id, notification_watch_pullreq_id);
+
+CREATE TABLE notification_digests (
+ notification_digest_id SERIAL PRIMARY KEY
+,notification_digest_principal_id INTEGER NOT NULL
+,notification_digest_mode TEXT NOT NULL
+,notification_digest_event TEXT NOT NULL
+,notification_digest_repo_id INTEGER NOT NULL
+,notification_digest_repo_path TEXT NOT NULL
+,notification_digest_pullreq_id INTEGER NOT NULL
+,notification_digest_pullreq_number INTEGER NOT NULL
+,notification_digest_pullreq_title TEXT NOT NULL
+,notification_digest_pullreq_url TEXT NOT NULL
+,notification_digest_summary TEXT NOT NULL
+,notification_digest_created BIGINT NOT NULL
+,CONSTRAINT fk_notification_digest_principal_id FOREIGN KEY (notification_digest_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_digest_repo_id FOREIGN KEY (notification_digest_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digests_mode_principal_id
+    ON notification_digests(notification_digest_mode, notification_digest_principal_id);
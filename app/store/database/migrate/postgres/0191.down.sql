@@ -0,0 +1,8 @@
+DROP INDEX webhook_executions_webhook_id_dead_letter;
+DROP INDEX webhook_executions_retry_at;
+
+ALTER TABLE webhook_executions DROP COLUMN webhook_execution_dead_letter;
+ALTER TABLE webhook_executions DROP COLUMN webhook_execution_retry_at;
+ALTER TABLE webhook_executions DROP COLUMN webhook_execution_attempt;
+
+ALTER TABLE webhooks DROP COLUMN webhook_consecutive_failures;
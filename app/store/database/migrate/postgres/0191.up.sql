@@ -0,0 +1,13 @@
+ALTER TABLE webhooks ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_attempt INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_retry_at BIGINT;
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_dead_letter BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX webhook_executions_retry_at
+    ON webhook_executions(webhook_execution_retry_at)
+    WHERE webhook_execution_retry_at IS NOT NULL;
+
+CREATE INDEX webhook_executions_webhook_id_dead_letter
+    ON webhook_executions(webhook_execution_webhook_id)
+    WHERE webhook_execution_dead_letter = TRUE;
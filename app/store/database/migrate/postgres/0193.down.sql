@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS pullreqs_stack_parent_id;
+
+ALTER TABLE pullreqs
+    DROP CONSTRAINT IF EXISTS fk_pullreq_stack_parent_id,
+    DROP COLUMN IF EXISTS pullreq_stack_parent_id;
@@ -0,0 +1,10 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_stack_parent_id INTEGER,
+    ADD CONSTRAINT fk_pullreq_stack_parent_id FOREIGN KEY (pullreq_stack_parent_id)
+        REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL;
+
+CREATE INDEX pullreqs_stack_parent_id
+    ON pullreqs (pullreq_stack_parent_id)
+    WHERE pullreq_stack_parent_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE usergroup_members;
+DROP TABLE external_identities;
@@ -0,0 +1,33 @@
+CREATE TABLE external_identities (
+ external_identity_provider TEXT NOT NULL
+,external_identity_subject TEXT NOT NULL
+,external_identity_principal_id INTEGER NOT NULL
+,external_identity_created BIGINT NOT NULL
+,external_identity_updated BIGINT NOT NULL
+,CONSTRAINT pk_external_identities PRIMARY KEY (external_identity_provider, external_identity_subject)
+,CONSTRAINT fk_external_identity_principal_id FOREIGN KEY (external_identity_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX external_identities_principal_id
+    ON external_identities(external_identity_principal_id);
+
+CREATE TABLE usergroup_members (
+ usergroup_member_usergroup_id INTEGER NOT NULL
+,usergroup_member_principal_id INTEGER NOT NULL
+,usergroup_member_created BIGINT NOT NULL
+,CONSTRAINT pk_usergroup_members PRIMARY KEY (usergroup_member_usergroup_id, usergroup_member_principal_id)
+,CONSTRAINT fk_usergroup_member_usergroup_id FOREIGN KEY (usergroup_member_usergroup_id)
+    REFERENCES usergroups (usergroup_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_member_principal_id FOREIGN KEY (usergroup_member_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX usergroup_members_principal_id
+    ON usergroup_members(usergroup_member_principal_id);
@@ -0,0 +1,21 @@
+CREATE TABLE custom_roles (
+ custom_role_id SERIAL PRIMARY KEY
+,custom_role_space_id INTEGER NOT NULL
+,custom_role_identifier TEXT NOT NULL
+,custom_role_description TEXT NOT NULL
+,custom_role_permissions TEXT NOT NULL
+,custom_role_created_by INTEGER NOT NULL
+,custom_role_created BIGINT NOT NULL
+,custom_role_updated BIGINT NOT NULL
+,CONSTRAINT fk_custom_role_space_id FOREIGN KEY (custom_role_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_custom_role_created_by FOREIGN KEY (custom_role_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX custom_roles_space_id_identifier
+    ON custom_roles(custom_role_space_id, LOWER(custom_role_identifier));
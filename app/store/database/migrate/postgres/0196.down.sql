@@ -0,0 +1,3 @@
+DROP TABLE registry_replication_run_artifacts;
+DROP TABLE registry_replication_runs;
+DROP TABLE registry_replication_rules;
@@ -0,0 +1,14 @@
+CREATE TABLE registry_signing_keys (
+ registry_signing_key_id SERIAL PRIMARY KEY
+,registry_signing_key_registry_id INTEGER NOT NULL
+,registry_signing_key_private_key BYTEA NOT NULL
+,registry_signing_key_public_key TEXT NOT NULL
+,registry_signing_key_created BIGINT NOT NULL
+,CONSTRAINT fk_registry_signing_key_registry_id FOREIGN KEY (registry_signing_key_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX registry_signing_keys_registry_id
+    ON registry_signing_keys(registry_signing_key_registry_id);
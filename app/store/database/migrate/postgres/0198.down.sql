@@ -0,0 +1,3 @@
+DROP TABLE artifact_vulnerabilities;
+DROP TABLE artifact_scans;
+DROP TABLE registry_scan_policies;
@@ -0,0 +1 @@
+DROP TABLE stage_approvals;
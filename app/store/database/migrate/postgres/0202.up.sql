@@ -0,0 +1,38 @@
+CREATE TABLE stage_approvals (
+ stage_approval_id SERIAL PRIMARY KEY
+,stage_approval_execution_id INTEGER NOT NULL
+,stage_approval_stage_id INTEGER NOT NULL
+,stage_approval_approvers TEXT NOT NULL
+,stage_approval_user_groups TEXT NOT NULL
+,stage_approval_permission TEXT NOT NULL
+,stage_approval_timeout BIGINT NOT NULL
+,stage_approval_deadline BIGINT NOT NULL
+,stage_approval_decision TEXT NOT NULL
+,stage_approval_decided_by INTEGER
+,stage_approval_decided BIGINT NOT NULL
+,stage_approval_comment TEXT NOT NULL
+,stage_approval_created BIGINT NOT NULL
+,stage_approval_updated BIGINT NOT NULL
+,stage_approval_version INTEGER NOT NULL
+,CONSTRAINT fk_stage_approval_execution_id FOREIGN KEY (stage_approval_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_stage_approval_stage_id FOREIGN KEY (stage_approval_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_stage_approval_decided_by FOREIGN KEY (stage_approval_decided_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX stage_approvals_stage_id
+    ON stage_approvals(stage_approval_stage_id);
+
+CREATE INDEX stage_approvals_execution_id
+    ON stage_approvals(stage_approval_execution_id);
+
+CREATE INDEX stage_approvals_decision_deadline
+    ON stage_approvals(stage_approval_decision, stage_approval_deadline);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notification_digests_event_id_principal_id_event;
+
+ALTER TABLE notification_digests DROP COLUMN notification_digest_event_id;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS registry_replication_runs_rule_id_unfinished;
@@ -0,0 +1,17 @@
+-- only one manual or scheduled run of a rule can be unfinished, older duplicates are stopped.
+UPDATE registry_replication_runs
+SET registry_replication_run_status = 'stopped'
+WHERE registry_replication_run_status IN ('pending', 'running')
+    AND registry_replication_run_trigger <> 'push'
+    AND registry_replication_run_id < (
+        SELECT MAX(latest.registry_replication_run_id)
+        FROM registry_replication_runs latest
+        WHERE latest.registry_replication_run_rule_id = registry_replication_runs.registry_replication_run_rule_id
+            AND latest.registry_replication_run_status IN ('pending', 'running')
+            AND latest.registry_replication_run_trigger <> 'push'
+    );
+
+CREATE UNIQUE INDEX registry_replication_runs_rule_id_unfinished
+    ON registry_replication_runs(registry_replication_run_rule_id)
+    WHERE registry_replication_run_status IN ('pending', 'running')
+        AND registry_replication_run_trigger <> 'push';
@@ -0,0 +1,20 @@
+DELETE FROM execution_artifacts
+WHERE NOT EXISTS (SELECT 1 FROM executions WHERE execution_id = execution_artifact_execution_id)
+    OR NOT EXISTS (SELECT 1 FROM stages WHERE stage_id = execution_artifact_stage_id)
+    OR NOT EXISTS (SELECT 1 FROM steps WHERE step_id = execution_artifact_step_id);
+
+ALTER TABLE execution_artifacts ADD CONSTRAINT fk_execution_artifact_execution_id
+    FOREIGN KEY (execution_artifact_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+ALTER TABLE execution_artifacts ADD CONSTRAINT fk_execution_artifact_stage_id
+    FOREIGN KEY (execution_artifact_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
+ALTER TABLE execution_artifacts ADD CONSTRAINT fk_execution_artifact_step_id
+    FOREIGN KEY (execution_artifact_step_id)
+    REFERENCES steps (step_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE;
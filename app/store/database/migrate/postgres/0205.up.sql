@@ -0,0 +1,5 @@
+-- artifacts must outlive their execution until the cleanup job deleted their blobs,
+-- hence they are no longer removed together with the execution, stage or step.
+ALTER TABLE execution_artifacts DROP CONSTRAINT fk_execution_artifact_execution_id;
+ALTER TABLE execution_artifacts DROP CONSTRAINT fk_execution_artifact_stage_id;
+ALTER TABLE execution_artifacts DROP CONSTRAINT fk_execution_artifact_step_id;
@@ -0,0 +1 @@
+DROP INDEX execution_artifacts_blob_path;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS pullreqs_stack_parent_id;
+
+ALTER TABLE pullreqs DROP COLUMN pullreq_stack_parent_id;
@@ -0,0 +1,5 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_stack_parent_id INTEGER REFERENCES pullreqs(pullreq_id) ON DELETE SET NULL;
+
+CREATE INDEX pullreqs_stack_parent_id
+    ON pullreqs (pullreq_stack_parent_id)
+    WHERE pullreq_stack_parent_id IS NOT NULL;
@@ -0,0 +1,70 @@
+CREATE TABLE registry_replication_rules (
+ registry_replication_rule_id INTEGER PRIMARY KEY AUTOINCREMENT
+,registry_replication_rule_space_id INTEGER NOT NULL
+,registry_replication_rule_source_registry_id INTEGER NOT NULL
+,registry_replication_rule_destination_type TEXT NOT NULL
+,registry_replication_rule_destination_registry_id INTEGER
+,registry_replication_rule_destination_config TEXT NOT NULL
+,registry_replication_rule_allowed_patterns TEXT NOT NULL
+,registry_replication_rule_blocked_patterns TEXT NOT NULL
+,registry_replication_rule_created_by INTEGER NOT NULL
+,registry_replication_rule_created BIGINT NOT NULL
+,registry_replication_rule_updated BIGINT NOT NULL
+,CONSTRAINT fk_registry_replication_rule_space_id FOREIGN KEY (registry_replication_rule_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_registry_replication_rule_source_registry_id FOREIGN KEY (registry_replication_rule_source_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_registry_replication_rule_destination_registry_id
+    FOREIGN KEY (registry_replication_rule_destination_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_registry_replication_rule_created_by FOREIGN KEY (registry_replication_rule_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX registry_replication_rules_space_id
+    ON registry_replication_rules(registry_replication_rule_space_id);
+
+CREATE INDEX registry_replication_rules_source_registry_id
+    ON registry_replication_rules(registry_replication_rule_source_registry_id);
+
+CREATE TABLE registry_replication_runs (
+ registry_replication_run_id INTEGER PRIMARY KEY AUTOINCREMENT
+,registry_replication_run_rule_id INTEGER NOT NULL
+,registry_replication_run_trigger TEXT NOT NULL
+,registry_replication_run_status TEXT NOT NULL
+,registry_replication_run_created BIGINT NOT NULL
+,registry_replication_run_updated BIGINT NOT NULL
+,CONSTRAINT fk_registry_replication_run_rule_id FOREIGN KEY (registry_replication_run_rule_id)
+    REFERENCES registry_replication_rules (registry_replication_rule_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX registry_replication_runs_rule_id
+    ON registry_replication_runs(registry_replication_run_rule_id, registry_replication_run_id);
+
+CREATE TABLE registry_replication_run_artifacts (
+ registry_replication_run_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,registry_replication_run_artifact_run_id INTEGER NOT NULL
+,registry_replication_run_artifact_image TEXT NOT NULL
+,registry_replication_run_artifact_tag TEXT NOT NULL
+,registry_replication_run_artifact_status TEXT NOT NULL
+,registry_replication_run_artifact_log TEXT NOT NULL
+,registry_replication_run_artifact_created BIGINT NOT NULL
+,registry_replication_run_artifact_updated BIGINT NOT NULL
+,CONSTRAINT fk_registry_replication_run_artifact_run_id FOREIGN KEY (registry_replication_run_artifact_run_id)
+    REFERENCES registry_replication_runs (registry_replication_run_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX registry_replication_run_artifacts_run_id
+    ON registry_replication_run_artifacts(registry_replication_run_artifact_run_id);
@@ -0,0 +1,60 @@
+CREATE TABLE registry_scan_policies (
+ registry_scan_policy_registry_id INTEGER PRIMARY KEY
+,registry_scan_policy_enabled BOOLEAN NOT NULL
+,registry_scan_policy_quarantine_severity TEXT NOT NULL
+,registry_scan_policy_created_by INTEGER NOT NULL
+,registry_scan_policy_updated_by INTEGER NOT NULL
+,registry_scan_policy_created BIGINT NOT NULL
+,registry_scan_policy_updated BIGINT NOT NULL
+,CONSTRAINT fk_registry_scan_policy_registry_id FOREIGN KEY (registry_scan_policy_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE artifact_scans (
+ artifact_scan_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_scan_registry_id INTEGER NOT NULL
+,artifact_scan_artifact_id INTEGER NOT NULL
+,artifact_scan_scanner TEXT NOT NULL
+,artifact_scan_status TEXT NOT NULL
+,artifact_scan_error TEXT NOT NULL
+,artifact_scan_critical INTEGER NOT NULL
+,artifact_scan_high INTEGER NOT NULL
+,artifact_scan_medium INTEGER NOT NULL
+,artifact_scan_low INTEGER NOT NULL
+,artifact_scan_unknown INTEGER NOT NULL
+,artifact_scan_quarantined BOOLEAN NOT NULL
+,artifact_scan_created BIGINT NOT NULL
+,artifact_scan_updated BIGINT NOT NULL
+,CONSTRAINT fk_artifact_scan_registry_id FOREIGN KEY (artifact_scan_registry_id)
+    REFERENCES registries (registry_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_artifact_scan_artifact_id FOREIGN KEY (artifact_scan_artifact_id)
+    REFERENCES artifacts (artifact_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX artifact_scans_artifact_id
+    ON artifact_scans(artifact_scan_artifact_id);
+
+CREATE TABLE artifact_vulnerabilities (
+ artifact_vulnerability_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_vulnerability_artifact_id INTEGER NOT NULL
+,artifact_vulnerability_vulnerability_id TEXT NOT NULL
+,artifact_vulnerability_package_name TEXT NOT NULL
+,artifact_vulnerability_installed_version TEXT NOT NULL
+,artifact_vulnerability_fixed_version TEXT NOT NULL
+,artifact_vulnerability_severity TEXT NOT NULL
+,artifact_vulnerability_title TEXT NOT NULL
+,artifact_vulnerability_target TEXT NOT NULL
+,CONSTRAINT fk_artifact_vulnerability_artifact_id FOREIGN KEY (artifact_vulnerability_artifact_id)
+    REFERENCES artifacts (artifact_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX artifact_vulnerabilities_artifact_id
+    ON artifact_vulnerabilities(artifact_vulnerability_artifact_id);
@@ -0,0 +1,29 @@
+CREATE TABLE test_cases (
+ test_case_id INTEGER PRIMARY KEY AUTOINCREMENT
+,test_case_execution_id INTEGER NOT NULL
+,test_case_stage_id INTEGER NOT NULL
+,test_case_step_id INTEGER NOT NULL
+,test_case_suite TEXT NOT NULL
+,test_case_class_name TEXT NOT NULL
+,test_case_name TEXT NOT NULL
+,test_case_status TEXT NOT NULL
+,test_case_duration BIGINT NOT NULL
+,test_case_message TEXT NOT NULL
+,test_case_details TEXT NOT NULL
+,test_case_created BIGINT NOT NULL
+,CONSTRAINT fk_test_case_execution_id FOREIGN KEY (test_case_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_test_case_stage_id FOREIGN KEY (test_case_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_test_case_step_id FOREIGN KEY (test_case_step_id)
+    REFERENCES steps (step_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX test_cases_execution_id_status
+    ON test_cases(test_case_execution_id, test_case_status);
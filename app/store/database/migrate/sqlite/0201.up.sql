@@ -0,0 +1,31 @@
+CREATE TABLE execution_artifacts (
+ execution_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,execution_artifact_execution_id INTEGER NOT NULL
+,execution_artifact_stage_id INTEGER NOT NULL
+,execution_artifact_step_id INTEGER NOT NULL
+,execution_artifact_name TEXT NOT NULL
+,execution_artifact_size BIGINT NOT NULL
+,execution_artifact_blob_path TEXT NOT NULL
+,execution_artifact_created BIGINT NOT NULL
+,CONSTRAINT fk_execution_artifact_execution_id FOREIGN KEY (execution_artifact_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_execution_artifact_stage_id FOREIGN KEY (execution_artifact_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_execution_artifact_step_id FOREIGN KEY (execution_artifact_step_id)
+    REFERENCES steps (step_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX execution_artifacts_step_id_name
+    ON execution_artifacts(execution_artifact_step_id, execution_artifact_name);
+
+CREATE INDEX execution_artifacts_execution_id
+    ON execution_artifacts(execution_artifact_execution_id);
+
+CREATE INDEX execution_artifacts_created
+    ON execution_artifacts(execution_artifact_created);
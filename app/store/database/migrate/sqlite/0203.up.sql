@@ -0,0 +1,5 @@
+ALTER TABLE notification_digests ADD COLUMN notification_digest_event_id TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX notification_digests_event_id_principal_id_event
+    ON notification_digests(notification_digest_event_id, notification_digest_principal_id, notification_digest_event)
+    WHERE notification_digest_event_id <> '';
@@ -0,0 +1,59 @@
+CREATE TABLE execution_artifacts_temp (
+ execution_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,execution_artifact_execution_id INTEGER NOT NULL
+,execution_artifact_stage_id INTEGER NOT NULL
+,execution_artifact_step_id INTEGER NOT NULL
+,execution_artifact_name TEXT NOT NULL
+,execution_artifact_size BIGINT NOT NULL
+,execution_artifact_blob_path TEXT NOT NULL
+,execution_artifact_created BIGINT NOT NULL
+,CONSTRAINT fk_execution_artifact_execution_id FOREIGN KEY (execution_artifact_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_execution_artifact_stage_id FOREIGN KEY (execution_artifact_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_execution_artifact_step_id FOREIGN KEY (execution_artifact_step_id)
+    REFERENCES steps (step_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+INSERT INTO execution_artifacts_temp (
+execution_artifact_id
+,execution_artifact_execution_id
+,execution_artifact_stage_id
+,execution_artifact_step_id
+,execution_artifact_name
+,execution_artifact_size
+,execution_artifact_blob_path
+,execution_artifact_created
+)
+SELECT
+execution_artifact_id
+,execution_artifact_execution_id
+,execution_artifact_stage_id
+,execution_artifact_step_id
+,execution_artifact_name
+,execution_artifact_size
+,execution_artifact_blob_path
+,execution_artifact_created
+FROM execution_artifacts
+WHERE EXISTS (SELECT 1 FROM executions WHERE execution_id = execution_artifact_execution_id)
+    AND EXISTS (SELECT 1 FROM stages WHERE stage_id = execution_artifact_stage_id)
+    AND EXISTS (SELECT 1 FROM steps WHERE step_id = execution_artifact_step_id);
+
+DROP TABLE execution_artifacts;
+
+ALTER TABLE execution_artifacts_temp RENAME TO execution_artifacts;
+
+CREATE UNIQUE INDEX execution_artifacts_step_id_name
+    ON execution_artifacts(execution_artifact_step_id, execution_artifact_name);
+
+CREATE INDEX execution_artifacts_execution_id
+    ON execution_artifacts(execution_artifact_execution_id);
+
+CREATE INDEX execution_artifacts_created
+    ON execution_artifacts(execution_artifact_created);
@@ -0,0 +1,47 @@
+-- artifacts must outlive their execution until the cleanup job deleted their blobs,
+-- hence they are no longer removed together with the execution, stage or step.
+-- SQLite cannot drop constraints, so recreate the table without the foreign keys.
+CREATE TABLE execution_artifacts_temp (
+ execution_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,execution_artifact_execution_id INTEGER NOT NULL
+,execution_artifact_stage_id INTEGER NOT NULL
+,execution_artifact_step_id INTEGER NOT NULL
+,execution_artifact_name TEXT NOT NULL
+,execution_artifact_size BIGINT NOT NULL
+,execution_artifact_blob_path TEXT NOT NULL
+,execution_artifact_created BIGINT NOT NULL
+);
+
+INSERT INTO execution_artifacts_temp (
+execution_artifact_id
+,execution_artifact_execution_id
+,execution_artifact_stage_id
+,execution_artifact_step_id
+,execution_artifact_name
+,execution_artifact_size
+,execution_artifact_blob_path
+,execution_artifact_created
+)
+SELECT
+execution_artifact_id
+,execution_artifact_execution_id
+,execution_artifact_stage_id
+,execution_artifact_step_id
+,execution_artifact_name
+,execution_artifact_size
+,execution_artifact_blob_path
+,execution_artifact_created
+FROM execution_artifacts;
+
+DROP TABLE execution_artifacts;
+
+ALTER TABLE execution_artifacts_temp RENAME TO execution_artifacts;
+
+CREATE UNIQUE INDEX execution_artifacts_step_id_name
+    ON execution_artifacts(execution_artifact_step_id, execution_artifact_name);
+
+CREATE INDEX execution_artifacts_execution_id
+    ON execution_artifacts(execution_artifact_execution_id);
+
+CREATE INDEX execution_artifacts_created
+    ON execution_artifacts(execution_artifact_created);
@@ -0,0 +1,2 @@
+CREATE INDEX execution_artifacts_blob_path
+    ON execution_artifacts(execution_artifact_blob_path);
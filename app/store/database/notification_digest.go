@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID            int64                  `db:"notification_digest_id"`
+	EventID       string                 `db:"notification_digest_event_id"`
+	PrincipalID   int64                  `db:"notification_digest_principal_id"`
+	Mode          enum.NotificationMode  `db:"notification_digest_mode"`
+	Event         enum.NotificationEvent `db:"notification_digest_event"`
+	RepoID        int64                  `db:"notification_digest_repo_id"`
+	RepoPath      string                 `db:"notification_digest_repo_path"`
+	PullReqID     int64                  `db:"notification_digest_pullreq_id"`
+	PullReqNumber int64                  `db:"notification_digest_pullreq_number"`
+	PullReqTitle  string                 `db:"notification_digest_pullreq_title"`
+	PullReqURL    string                 `db:"notification_digest_pullreq_url"`
+	Summary       string                 `db:"notification_digest_summary"`
+	Created       int64                  `db:"notification_digest_created"`
+}
+
+const (
+	notificationDigestColumns = `
+		 notification_digest_id
+		,notification_digest_event_id
+		,notification_digest_principal_id
+		,notification_digest_mode
+		,notification_digest_event
+		,notification_digest_repo_id
+		,notification_digest_repo_path
+		,notification_digest_pullreq_id
+		,notification_digest_pullreq_number
+		,notification_digest_pullreq_title
+		,notification_digest_pullreq_url
+		,notification_digest_summary
+		,notification_digest_created`
+)
+
+// Create queues a notification for the next digest.
+// A notification that is already queued for the same event and principal is ignored.
+func (s *NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+	INSERT INTO notification_digests (
+		 notification_digest_event_id
+		,notification_digest_principal_id
+		,notification_digest_mode
+		,notification_digest_event
+		,notification_digest_repo_id
+		,notification_digest_repo_path
+		,notification_digest_pullreq_id
+		,notification_digest_pullreq_number
+		,notification_digest_pullreq_title
+		,notification_digest_pullreq_url
+		,notification_digest_summary
+		,notification_digest_created
+	) VALUES (
+		 :notification_digest_event_id
+		,:notification_digest_principal_id
+		,:notification_digest_mode
+		,:notification_digest_event
+		,:notification_digest_repo_id
+		,:notification_digest_repo_path
+		,:notification_digest_pullreq_id
+		,:notification_digest_pullreq_number
+		,:notification_digest_pullreq_title
+		,:notification_digest_pullreq_url
+		,:notification_digest_summary
+		,:notification_digest_created
+	)
+	ON CONFLICT DO NOTHING
+	RETURNING notification_digest_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, notificationDigestItem(*item))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&item.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the notification is already queued
+		return nil
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification digest")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns IDs of all principals with queued notifications for the digest mode.
+func (s *NotificationDigestStore) ListPrincipalIDs(
+	ctx context.Context,
+	mode enum.NotificationMode,
+) ([]int64, error) {
+	const sqlQuery = `
+	SELECT DISTINCT notification_digest_principal_id
+	FROM notification_digests
+	WHERE notification_digest_mode = $1
+	ORDER BY notification_digest_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery, mode); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principals with notification digests")
+	}
+
+	return ids, nil
+}
+
+// List returns the queued notifications of the principal for the digest mode, oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+	mode enum.NotificationMode,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = `
+	SELECT` + notificationDigestColumns + `
+	FROM notification_digests
+	WHERE notification_digest_principal_id = $1 AND notification_digest_mode = $2
+	ORDER BY notification_digest_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestItem
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID, mode); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification digests")
+	}
+
+	result := make([]*types.NotificationDigestItem, len(dst))
+	for i, item := range dst {
+		result[i] = (*types.NotificationDigestItem)(item)
+	}
+
+	return result, nil
+}
+
+// DeleteMany deletes the queued notifications with the provided IDs.
+func (s *NotificationDigestStore) DeleteMany(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Delete("notification_digests").
+		Where(squirrel.Eq{"notification_digest_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification digests")
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestNotificationDigestStoreCreateDeduplicates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const repoID int64 = 1
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	store := database.NewNotificationDigestStore(db)
+
+	newItem := func(eventID string, event enum.NotificationEvent) *types.NotificationDigestItem {
+		return &types.NotificationDigestItem{
+			EventID:     eventID,
+			PrincipalID: userID,
+			Mode:        enum.NotificationModeDaily,
+			Event:       event,
+			RepoID:      repoID,
+			RepoPath:    "space/repo",
+			Summary:     "summary",
+			Created:     1000,
+		}
+	}
+
+	items := []*types.NotificationDigestItem{
+		newItem("event-1", enum.NotificationEventComment),
+		newItem("event-1", enum.NotificationEventComment), // redelivered event
+		newItem("event-1", enum.NotificationEventReviewerAdded),
+		newItem("event-2", enum.NotificationEventComment),
+		newItem("", enum.NotificationEventComment),
+		newItem("", enum.NotificationEventComment),
+	}
+	for _, item := range items {
+		if err := store.Create(ctx, item); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if items[1].ID != 0 {
+		t.Errorf("expected the duplicate item not to be created, got ID %d", items[1].ID)
+	}
+
+	list, err := store.List(ctx, userID, enum.NotificationModeDaily)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 5 {
+		t.Errorf("expected 5 queued notifications, got %d", len(list))
+	}
+}
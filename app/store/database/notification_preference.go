@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	PrincipalID int64                  `db:"notification_preference_principal_id"`
+	Event       enum.NotificationEvent `db:"notification_preference_event"`
+	Mode        enum.NotificationMode  `db:"notification_preference_mode"`
+	Created     int64                  `db:"notification_preference_created"`
+	Updated     int64                  `db:"notification_preference_updated"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_principal_id
+		,notification_preference_event
+		,notification_preference_mode
+		,notification_preference_created
+		,notification_preference_updated`
+)
+
+// List returns all notification preferences of the principal.
+func (s *NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationPreference, error) {
+	const sqlQuery = `
+	SELECT` + notificationPreferenceColumns + `
+	FROM notification_preferences
+	WHERE notification_preference_principal_id = $1
+	ORDER BY notification_preference_event`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	result := make([]*types.NotificationPreference, len(dst))
+	for i, p := range dst {
+		result[i] = mapNotificationPreference(p)
+	}
+
+	return result, nil
+}
+
+// MapModes returns the notification modes of the principals for the event.
+func (s *NotificationPreferenceStore) MapModes(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	principalIDs []int64,
+) (map[int64]enum.NotificationMode, error) {
+	result := make(map[int64]enum.NotificationMode, len(principalIDs))
+	if len(principalIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where("notification_preference_event = ?", event).
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to select notification preferences")
+	}
+
+	for _, p := range dst {
+		result[p.PrincipalID] = p.Mode
+	}
+
+	return result, nil
+}
+
+// Upsert creates or updates the notification preference of the principal for the event.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, pref *types.NotificationPreference) error {
+	const sqlQuery = `
+	INSERT INTO notification_preferences (
+		 notification_preference_principal_id
+		,notification_preference_event
+		,notification_preference_mode
+		,notification_preference_created
+		,notification_preference_updated
+	) VALUES (
+		 :notification_preference_principal_id
+		,:notification_preference_event
+		,:notification_preference_mode
+		,:notification_preference_created
+		,:notification_preference_updated
+	)
+	ON CONFLICT (notification_preference_principal_id, notification_preference_event) DO
+	UPDATE SET
+		 notification_preference_mode = EXCLUDED.notification_preference_mode
+		,notification_preference_updated = EXCLUDED.notification_preference_updated
+	RETURNING notification_preference_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, notificationPreference(*pref))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&pref.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification preference")
+	}
+
+	return nil
+}
+
+func mapNotificationPreference(p *notificationPreference) *types.NotificationPreference {
+	return &types.NotificationPreference{
+		PrincipalID: p.PrincipalID,
+		Event:       p.Event,
+		Mode:        p.Mode,
+		Created:     p.Created,
+		Updated:     p.Updated,
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationWatchStore = (*NotificationWatchStore)(nil)
+
+// NewNotificationWatchStore returns a new NotificationWatchStore.
+func NewNotificationWatchStore(db *sqlx.DB) *NotificationWatchStore {
+	return &NotificationWatchStore{
+		db: db,
+	}
+}
+
+// NotificationWatchStore implements store.NotificationWatchStore backed by a relational database.
+type NotificationWatchStore struct {
+	db *sqlx.DB
+}
+
+type notificationWatch struct {
+	PrincipalID int64 `db:"notification_watch_principal_id"`
+	RepoID      int64 `db:"notification_watch_repo_id"`
+	PullReqID   int64 `db:"notification_watch_pullreq_id"`
+	Watching    bool  `db:"notification_watch_watching"`
+	Created     int64 `db:"notification_watch_created"`
+	Updated     int64 `db:"notification_watch_updated"`
+}
+
+const (
+	notificationWatchSelectBase = `
+	SELECT
+		 notification_watch_principal_id
+		,notification_watch_repo_id
+		,notification_watch_pullreq_id
+		,notification_watch_watching
+		,notification_watch_created
+		,notification_watch_updated
+	FROM notification_watches`
+)
+
+// Find finds the watch of the principal for a repo (pullReqID is zero) or a pull request.
+func (s *NotificationWatchStore) Find(
+	ctx context.Context,
+	principalID, repoID, pullReqID int64,
+) (*types.NotificationWatch, error) {
+	const sqlQuery = notificationWatchSelectBase + `
+	WHERE notification_watch_principal_id = $1
+		AND notification_watch_repo_id = $2
+		AND notification_watch_pullreq_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationWatch{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, repoID, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification watch")
+	}
+
+	return mapNotificationWatch(dst), nil
+}
+
+// ListForPullReq returns the watches of the pull request and the watches of its repo.
+func (s *NotificationWatchStore) ListForPullReq(
+	ctx context.Context,
+	repoID, pullReqID int64,
+) ([]*types.NotificationWatch, error) {
+	const sqlQuery = notificationWatchSelectBase + `
+	WHERE notification_watch_repo_id = $1
+		AND notification_watch_pullreq_id IN (0, $2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationWatch
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification watches")
+	}
+
+	result := make([]*types.NotificationWatch, len(dst))
+	for i, w := range dst {
+		result[i] = mapNotificationWatch(w)
+	}
+
+	return result, nil
+}
+
+// Upsert creates or updates a watch.
+func (s *NotificationWatchStore) Upsert(ctx context.Context, watch *types.NotificationWatch) error {
+	const sqlQuery = `
+	INSERT INTO notification_watches (
+		 notification_watch_principal_id
+		,notification_watch_repo_id
+		,notification_watch_pullreq_id
+		,notification_watch_watching
+		,notification_watch_created
+		,notification_watch_updated
+	) VALUES (
+		 :notification_watch_principal_id
+		,:notification_watch_repo_id
+		,:notification_watch_pullreq_id
+		,:notification_watch_watching
+		,:notification_watch_created
+		,:notification_watch_updated
+	)
+	ON CONFLICT (notification_watch_principal_id, notification_watch_repo_id, notification_watch_pullreq_id) DO
+	UPDATE SET
+		 notification_watch_watching = EXCLUDED.notification_watch_watching
+		,notification_watch_updated = EXCLUDED.notification_watch_updated
+	RETURNING notification_watch_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, notificationWatch(*watch))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification watch object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&watch.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification watch")
+	}
+
+	return nil
+}
+
+// Delete deletes the watch of the principal for a repo (pullReqID is zero) or a pull request.
+func (s *NotificationWatchStore) Delete(ctx context.Context, principalID, repoID, pullReqID int64) error {
+	const sqlQuery = `
+	DELETE FROM notification_watches
+	WHERE notification_watch_principal_id = $1
+		AND notification_watch_repo_id = $2
+		AND notification_watch_pullreq_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, repoID, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification watch")
+	}
+
+	return nil
+}
+
+func mapNotificationWatch(w *notificationWatch) *types.NotificationWatch {
+	return &types.NotificationWatch{
+		PrincipalID: w.PrincipalID,
+		RepoID:      w.RepoID,
+		PullReqID:   w.PullReqID,
+		Watching:    w.Watching,
+		Created:     w.Created,
+		Updated:     w.Updated,
+	}
+}
@@ -106,6 +106,8 @@ type pullReq struct {
 	Deletions   null.Int `db:"pullreq_deletions"`
 
 	Type null.String `db:"pullreq_type"`
+
+	StackParentID null.Int `db:"pullreq_stack_parent_id"`
 }
 
 const (
@@ -147,7 +149,8 @@ const (
 		,pullreq_file_count
 		,pullreq_additions
 		,pullreq_deletions
-		,pullreq_type`
+		,pullreq_type
+		,pullreq_stack_parent_id`
 
 	pullReqColumns = pullReqColumnsNoDescription + `
 		,pullreq_description`
@@ -250,6 +253,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_additions
 		,pullreq_deletions
 		,pullreq_type
+		,pullreq_stack_parent_id
 		,pullreq_root_space_id
 		,pullreq_root_space_identifier
 	) values (
@@ -289,6 +293,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_additions
 		,:pullreq_deletions
 		,:pullreq_type
+		,:pullreq_stack_parent_id
 		,:pullreq_root_space_id
 		,:pullreq_root_space_identifier
 	) RETURNING pullreq_id`
@@ -342,6 +347,7 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_additions = :pullreq_additions
 		,pullreq_deletions = :pullreq_deletions
 		,pullreq_type = :pullreq_type
+		,pullreq_stack_parent_id = :pullreq_stack_parent_id
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -791,6 +797,10 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 		*stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
+	if opts.StackParentID != 0 {
+		*stmt = stmt.Where("pullreq_stack_parent_id = ?", opts.StackParentID)
+	}
+
 	if opts.Query != "" {
 		*stmt = stmt.Where(PartialMatch("pullreq_title", opts.Query))
 	}
@@ -989,7 +999,8 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 				Deletions:    pr.Deletions.Ptr(),
 			},
 		},
-		Type: (*enum.PullReqType)(pr.Type.Ptr()),
+		Type:          (*enum.PullReqType)(pr.Type.Ptr()),
+		StackParentID: pr.StackParentID.Ptr(),
 	}
 }
 
@@ -1036,6 +1047,7 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		Additions:               null.IntFromPtr(pr.Stats.Additions),
 		Deletions:               null.IntFromPtr(pr.Stats.Deletions),
 		Type:                    null.StringFromPtr((*string)(pr.Type)),
+		StackParentID:           null.IntFromPtr(pr.StackParentID),
 	}
 
 	return m
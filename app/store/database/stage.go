@@ -192,6 +192,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Stage query failed")
 	}
+	st.ID = stage.ID
 	return nil
 }
 
@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.StageApprovalStore = (*stageApprovalStore)(nil)
+
+const (
+	stageApprovalColumns = `
+	stage_approval_id
+	,stage_approval_execution_id
+	,stage_approval_stage_id
+	,stage_approval_approvers
+	,stage_approval_user_groups
+	,stage_approval_permission
+	,stage_approval_timeout
+	,stage_approval_deadline
+	,stage_approval_decision
+	,stage_approval_decided_by
+	,stage_approval_decided
+	,stage_approval_comment
+	,stage_approval_created
+	,stage_approval_updated
+	,stage_approval_version
+	`
+)
+
+type stageApproval struct {
+	ID          int64                      `db:"stage_approval_id"`
+	ExecutionID int64                      `db:"stage_approval_execution_id"`
+	StageID     int64                      `db:"stage_approval_stage_id"`
+	Approvers   sqlxtypes.JSONText         `db:"stage_approval_approvers"`
+	UserGroups  sqlxtypes.JSONText         `db:"stage_approval_user_groups"`
+	Permission  enum.Permission            `db:"stage_approval_permission"`
+	Timeout     int64                      `db:"stage_approval_timeout"`
+	Deadline    int64                      `db:"stage_approval_deadline"`
+	Decision    enum.StageApprovalDecision `db:"stage_approval_decision"`
+	DecidedBy   *int64                     `db:"stage_approval_decided_by"`
+	Decided     int64                      `db:"stage_approval_decided"`
+	Comment     string                     `db:"stage_approval_comment"`
+	Created     int64                      `db:"stage_approval_created"`
+	Updated     int64                      `db:"stage_approval_updated"`
+	Version     int64                      `db:"stage_approval_version"`
+}
+
+// NewStageApprovalStore returns a new StageApprovalStore.
+func NewStageApprovalStore(db *sqlx.DB) store.StageApprovalStore {
+	return &stageApprovalStore{
+		db: db,
+	}
+}
+
+type stageApprovalStore struct {
+	db *sqlx.DB
+}
+
+// Create creates the approval gate of a stage.
+func (s *stageApprovalStore) Create(ctx context.Context, approval *types.StageApproval) error {
+	const stageApprovalInsertStmt = `
+	INSERT INTO stage_approvals (
+		stage_approval_execution_id
+		,stage_approval_stage_id
+		,stage_approval_approvers
+		,stage_approval_user_groups
+		,stage_approval_permission
+		,stage_approval_timeout
+		,stage_approval_deadline
+		,stage_approval_decision
+		,stage_approval_decided_by
+		,stage_approval_decided
+		,stage_approval_comment
+		,stage_approval_created
+		,stage_approval_updated
+		,stage_approval_version
+	) VALUES (
+		:stage_approval_execution_id
+		,:stage_approval_stage_id
+		,:stage_approval_approvers
+		,:stage_approval_user_groups
+		,:stage_approval_permission
+		,:stage_approval_timeout
+		,:stage_approval_deadline
+		,:stage_approval_decision
+		,:stage_approval_decided_by
+		,:stage_approval_decided
+		,:stage_approval_comment
+		,:stage_approval_created
+		,:stage_approval_updated
+		,:stage_approval_version
+	) RETURNING stage_approval_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(stageApprovalInsertStmt, mapStageApprovalToInternal(approval))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind stage approval object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&approval.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Stage approval query failed")
+	}
+
+	return nil
+}
+
+// FindByStageID returns the approval gate of a stage.
+func (s *stageApprovalStore) FindByStageID(ctx context.Context, stageID int64) (*types.StageApproval, error) {
+	const findQueryStmt = `
+	SELECT` + stageApprovalColumns + `
+	FROM stage_approvals
+	WHERE stage_approval_stage_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(stageApproval)
+	if err := db.GetContext(ctx, dst, findQueryStmt, stageID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find stage approval")
+	}
+
+	return mapInternalToStageApproval(dst)
+}
+
+// ListByExecution lists the approval gates of an execution.
+func (s *stageApprovalStore) ListByExecution(
+	ctx context.Context,
+	executionID int64,
+) ([]*types.StageApproval, error) {
+	const listQueryStmt = `
+	SELECT` + stageApprovalColumns + `
+	FROM stage_approvals
+	WHERE stage_approval_execution_id = $1
+	ORDER BY stage_approval_id`
+
+	return s.list(ctx, listQueryStmt, executionID)
+}
+
+// Update tries to update an approval gate using the optimistic locking mechanism.
+func (s *stageApprovalStore) Update(ctx context.Context, approval *types.StageApproval) error {
+	const stageApprovalUpdateStmt = `
+	UPDATE stage_approvals
+	SET
+		stage_approval_deadline = :stage_approval_deadline
+		,stage_approval_decision = :stage_approval_decision
+		,stage_approval_decided_by = :stage_approval_decided_by
+		,stage_approval_decided = :stage_approval_decided
+		,stage_approval_comment = :stage_approval_comment
+		,stage_approval_updated = :stage_approval_updated
+		,stage_approval_version = :stage_approval_version
+	WHERE stage_approval_id = :stage_approval_id AND stage_approval_version = :stage_approval_version - 1`
+
+	dbApproval := mapStageApprovalToInternal(approval)
+	dbApproval.Version++
+	dbApproval.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(stageApprovalUpdateStmt, dbApproval)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind stage approval object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update stage approval")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	approval.Version = dbApproval.Version
+	approval.Updated = dbApproval.Updated
+
+	return nil
+}
+
+// ListExpired lists up to limit pending approval gates whose deadline passed before now.
+func (s *stageApprovalStore) ListExpired(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.StageApproval, error) {
+	const listQueryStmt = `
+	SELECT` + stageApprovalColumns + `
+	FROM stage_approvals
+	WHERE stage_approval_decision = $1
+		AND stage_approval_deadline > 0
+		AND stage_approval_deadline < $2
+	ORDER BY stage_approval_deadline
+	LIMIT $3`
+
+	return s.list(ctx, listQueryStmt, enum.StageApprovalDecisionPending, now, limit)
+}
+
+// ListUnapplied lists up to limit approval gates decided before the given time whose stage is still blocked.
+func (s *stageApprovalStore) ListUnapplied(
+	ctx context.Context,
+	decidedBefore int64,
+	limit int,
+) ([]*types.StageApproval, error) {
+	const listQueryStmt = `
+	SELECT` + stageApprovalColumns + `
+	FROM stage_approvals
+	JOIN stages ON stage_id = stage_approval_stage_id
+	WHERE stage_approval_decision <> $1
+		AND stage_approval_decided < $2
+		AND stage_status = $3
+	ORDER BY stage_approval_decided
+	LIMIT $4`
+
+	return s.list(ctx, listQueryStmt, enum.StageApprovalDecisionPending, decidedBefore, enum.CIStatusBlocked, limit)
+}
+
+func (s *stageApprovalStore) list(ctx context.Context, query string, args ...any) ([]*types.StageApproval, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stageApproval{}
+	if err := db.SelectContext(ctx, &dst, query, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list stage approvals")
+	}
+
+	res := make([]*types.StageApproval, len(dst))
+	for i := range dst {
+		approval, err := mapInternalToStageApproval(dst[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = approval
+	}
+
+	return res, nil
+}
+
+func mapStageApprovalToInternal(in *types.StageApproval) *stageApproval {
+	approvers := in.Approvers
+	if approvers == nil {
+		approvers = []string{}
+	}
+	userGroups := in.UserGroups
+	if userGroups == nil {
+		userGroups = []string{}
+	}
+
+	return &stageApproval{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		Approvers:   EncodeToSQLXJSON(approvers),
+		UserGroups:  EncodeToSQLXJSON(userGroups),
+		Permission:  in.Permission,
+		Timeout:     in.Timeout,
+		Deadline:    in.Deadline,
+		Decision:    in.Decision,
+		DecidedBy:   in.DecidedBy,
+		Decided:     in.Decided,
+		Comment:     in.Comment,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+}
+
+func mapInternalToStageApproval(in *stageApproval) (*types.StageApproval, error) {
+	approval := &types.StageApproval{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		Permission:  in.Permission,
+		Timeout:     in.Timeout,
+		Deadline:    in.Deadline,
+		Decision:    in.Decision,
+		DecidedBy:   in.DecidedBy,
+		Decided:     in.Decided,
+		Comment:     in.Comment,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+
+	if err := json.Unmarshal(in.Approvers, &approval.Approvers); err != nil {
+		return nil, fmt.Errorf("could not unmarshal stage approval approvers: %w", err)
+	}
+	if err := json.Unmarshal(in.UserGroups, &approval.UserGroups); err != nil {
+		return nil, fmt.Errorf("could not unmarshal stage approval user groups: %w", err)
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStageApprovalStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const repoID int64 = 1
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	pipeline := &types.Pipeline{Identifier: "pipeline", RepoID: repoID, CreatedBy: userID, Seq: 1}
+	if err := database.NewPipelineStore(db).Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline %v", err)
+	}
+
+	execution := &types.Execution{PipelineID: pipeline.ID, RepoID: repoID, CreatedBy: userID, Number: 1}
+	if err := database.NewExecutionStore(db).Create(ctx, execution); err != nil {
+		t.Fatalf("failed to create execution %v", err)
+	}
+
+	stageStore := database.NewStageStore(db)
+	stages := []*types.Stage{
+		{ExecutionID: execution.ID, RepoID: repoID, Number: 1, Name: "approve", Type: types.StageTypeApproval},
+		{ExecutionID: execution.ID, RepoID: repoID, Number: 2, Name: "release", Type: types.StageTypeApproval},
+	}
+	for _, stage := range stages {
+		if err := stageStore.Create(ctx, stage); err != nil {
+			t.Fatalf("failed to create stage %v", err)
+		}
+	}
+
+	store := database.NewStageApprovalStore(db)
+	approvals := []*types.StageApproval{
+		{
+			ExecutionID: execution.ID,
+			StageID:     stages[0].ID,
+			Approvers:   []string{"admin"},
+			Permission:  enum.PermissionPipelineExecute,
+			Timeout:     1000,
+			Deadline:    2000,
+			Decision:    enum.StageApprovalDecisionPending,
+			Created:     1000,
+			Updated:     1000,
+		},
+		{
+			ExecutionID: execution.ID,
+			StageID:     stages[1].ID,
+			UserGroups:  []string{"release-managers"},
+			Permission:  enum.PermissionPipelineEdit,
+			Decision:    enum.StageApprovalDecisionPending,
+			Created:     1000,
+			Updated:     1000,
+		},
+	}
+	for _, approval := range approvals {
+		if err := store.Create(ctx, approval); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	approval, err := store.FindByStageID(ctx, stages[1].ID)
+	if err != nil {
+		t.Fatalf("FindByStageID() error = %v", err)
+	}
+	if approval.ID != approvals[1].ID || len(approval.Approvers) != 0 ||
+		len(approval.UserGroups) != 1 || approval.UserGroups[0] != "release-managers" {
+		t.Errorf("FindByStageID() returned unexpected approval %+v", approval)
+	}
+
+	expired, err := store.ListExpired(ctx, 3000, 10)
+	if err != nil {
+		t.Fatalf("ListExpired() error = %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != approvals[0].ID {
+		t.Fatalf("ListExpired() returned %d approvals, want only the first one", len(expired))
+	}
+
+	decidedBy := userID
+	approval.Decision = enum.StageApprovalDecisionApproved
+	approval.DecidedBy = &decidedBy
+	approval.Decided = 3000
+	approval.Comment = "ship it"
+	stale := *approval
+	if err = store.Update(ctx, approval); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err = store.Update(ctx, &stale); !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Errorf("Update() with stale version error = %v, want %v", err, gitness_store.ErrVersionConflict)
+	}
+
+	list, err := store.ListByExecution(ctx, execution.ID)
+	if err != nil {
+		t.Fatalf("ListByExecution() error = %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListByExecution() returned %d approvals, want 2", len(list))
+	}
+	if list[1].Decision != enum.StageApprovalDecisionApproved || list[1].DecidedBy == nil ||
+		*list[1].DecidedBy != userID || list[1].Comment != "ship it" {
+		t.Errorf("ListByExecution() returned unexpected approval %+v", list[1])
+	}
+
+	// the decided approval is unapplied as long as its stage is blocked.
+	unapplied, err := store.ListUnapplied(ctx, 4000, 10)
+	if err != nil {
+		t.Fatalf("ListUnapplied() error = %v", err)
+	}
+	if len(unapplied) != 0 {
+		t.Fatalf("ListUnapplied() returned %d approvals of stages that aren't blocked, want none", len(unapplied))
+	}
+
+	stages[1].Status = enum.CIStatusBlocked
+	if err = stageStore.Update(ctx, stages[1]); err != nil {
+		t.Fatalf("failed to update stage %v", err)
+	}
+	unapplied, err = store.ListUnapplied(ctx, 4000, 10)
+	if err != nil {
+		t.Fatalf("ListUnapplied() error = %v", err)
+	}
+	if len(unapplied) != 1 || unapplied[0].ID != approvals[1].ID {
+		t.Fatalf("ListUnapplied() returned %d approvals, want only the decided one", len(unapplied))
+	}
+	if unapplied, err = store.ListUnapplied(ctx, 3000, 10); err != nil || len(unapplied) != 0 {
+		t.Errorf("ListUnapplied() within the grace period returned %d approvals (error: %v), want none",
+			len(unapplied), err)
+	}
+}
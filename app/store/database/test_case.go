@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.TestCaseStore = (*testCaseStore)(nil)
+
+const (
+	testCaseColumns = `
+	test_case_id
+	,test_case_execution_id
+	,test_case_stage_id
+	,test_case_step_id
+	,test_case_suite
+	,test_case_class_name
+	,test_case_name
+	,test_case_status
+	,test_case_duration
+	,test_case_message
+	,test_case_details
+	,test_case_created
+	`
+
+	// testCaseOrder lists failed test cases first, followed by skipped and passed ones.
+	testCaseOrder = `CASE test_case_status WHEN 'failed' THEN 0 WHEN 'skipped' THEN 1 ELSE 2 END`
+)
+
+type testCase struct {
+	ID          int64           `db:"test_case_id"`
+	ExecutionID int64           `db:"test_case_execution_id"`
+	StageID     int64           `db:"test_case_stage_id"`
+	StepID      int64           `db:"test_case_step_id"`
+	Suite       string          `db:"test_case_suite"`
+	ClassName   string          `db:"test_case_class_name"`
+	Name        string          `db:"test_case_name"`
+	Status      enum.TestStatus `db:"test_case_status"`
+	Duration    int64           `db:"test_case_duration"`
+	Message     string          `db:"test_case_message"`
+	Details     string          `db:"test_case_details"`
+	Created     int64           `db:"test_case_created"`
+}
+
+// NewTestCaseStore returns a new TestCaseStore.
+func NewTestCaseStore(db *sqlx.DB) store.TestCaseStore {
+	return &testCaseStore{
+		db: db,
+	}
+}
+
+type testCaseStore struct {
+	db *sqlx.DB
+}
+
+// CreateMany creates the test cases of a test report.
+func (s *testCaseStore) CreateMany(ctx context.Context, cases []*types.TestCase) error {
+	const testCaseInsertStmt = `
+	INSERT INTO test_cases (
+		test_case_execution_id
+		,test_case_stage_id
+		,test_case_step_id
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_details
+		,test_case_created
+	) VALUES (
+		:test_case_execution_id
+		,:test_case_stage_id
+		,:test_case_step_id
+		,:test_case_suite
+		,:test_case_class_name
+		,:test_case_name
+		,:test_case_status
+		,:test_case_duration
+		,:test_case_message
+		,:test_case_details
+		,:test_case_created
+	) RETURNING test_case_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	for _, tc := range cases {
+		query, arg, err := db.BindNamed(testCaseInsertStmt, mapTestCaseToInternal(tc))
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind test case object")
+		}
+
+		if err = db.QueryRowContext(ctx, query, arg...).Scan(&tc.ID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Test case query failed")
+		}
+	}
+
+	return nil
+}
+
+// List lists the test cases of an execution, failed test cases first.
+func (s *testCaseStore) List(
+	ctx context.Context,
+	executionID int64,
+	filter *types.TestCaseFilter,
+) ([]*types.TestCase, error) {
+	stmt := database.Builder.
+		Select(testCaseColumns).
+		From("test_cases").
+		Where("test_case_execution_id = ?", executionID)
+
+	stmt = applyTestCaseFilter(stmt, filter)
+	stmt = stmt.
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size)).
+		OrderBy(testCaseOrder, "test_case_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := []*testCase{}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list test cases")
+	}
+
+	res := make([]*types.TestCase, len(dst))
+	for i := range dst {
+		res[i] = mapInternalToTestCase(dst[i])
+	}
+
+	return res, nil
+}
+
+// Count counts the test cases of an execution matching the filter.
+func (s *testCaseStore) Count(
+	ctx context.Context,
+	executionID int64,
+	filter *types.TestCaseFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("test_cases").
+		Where("test_case_execution_id = ?", executionID)
+
+	stmt = applyTestCaseFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count test cases")
+	}
+
+	return count, nil
+}
+
+// CopyStage copies the test cases of a stage to a stage of another execution, which must have the same steps.
+func (s *testCaseStore) CopyStage(ctx context.Context, fromStageID, toExecutionID, toStageID int64) error {
+	const testCaseCopyStmt = `
+	INSERT INTO test_cases (
+		test_case_execution_id
+		,test_case_stage_id
+		,test_case_step_id
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_details
+		,test_case_created
+	)
+	SELECT
+		$1
+		,$2
+		,to_step.step_id
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_details
+		,test_case_created
+	FROM test_cases
+	JOIN steps from_step ON from_step.step_id = test_case_step_id
+	JOIN steps to_step ON to_step.step_stage_id = $2 AND to_step.step_number = from_step.step_number
+	WHERE test_case_stage_id = $3`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, testCaseCopyStmt, toExecutionID, toStageID, fromStageID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to copy test cases")
+	}
+
+	return nil
+}
+
+// Summarize returns the number of test cases of an execution by status.
+func (s *testCaseStore) Summarize(ctx context.Context, executionID int64) (*types.TestSummary, error) {
+	stmt := database.Builder.
+		Select("test_case_status", "count(*)", "COALESCE(SUM(test_case_duration), 0)").
+		From("test_cases").
+		Where("test_case_execution_id = ?", executionID).
+		GroupBy("test_case_status")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to summarize test cases")
+	}
+	defer rows.Close()
+
+	summary := &types.TestSummary{}
+	for rows.Next() {
+		var (
+			status   enum.TestStatus
+			count    int64
+			duration int64
+		)
+		if err = rows.Scan(&status, &count, &duration); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan test case summary")
+		}
+
+		summary.Total += count
+		summary.Duration += duration
+		switch status {
+		case enum.TestStatusPassed:
+			summary.Passed += count
+		case enum.TestStatusFailed:
+			summary.Failed += count
+		case enum.TestStatusSkipped:
+			summary.Skipped += count
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to summarize test cases")
+	}
+
+	return summary, nil
+}
+
+func applyTestCaseFilter(stmt squirrel.SelectBuilder, filter *types.TestCaseFilter) squirrel.SelectBuilder {
+	if len(filter.Status) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"test_case_status": filter.Status})
+	}
+	return stmt
+}
+
+func mapTestCaseToInternal(in *types.TestCase) *testCase {
+	return &testCase{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		StepID:      in.StepID,
+		Suite:       in.Suite,
+		ClassName:   in.ClassName,
+		Name:        in.Name,
+		Status:      in.Status,
+		Duration:    in.Duration,
+		Message:     in.Message,
+		Details:     in.Details,
+		Created:     in.Created,
+	}
+}
+
+func mapInternalToTestCase(in *testCase) *types.TestCase {
+	return &types.TestCase{
+		ID:          in.ID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		StepID:      in.StepID,
+		Suite:       in.Suite,
+		ClassName:   in.ClassName,
+		Name:        in.Name,
+		Status:      in.Status,
+		Duration:    in.Duration,
+		Message:     in.Message,
+		Details:     in.Details,
+		Created:     in.Created,
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTestCaseStore_ListSummarize(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const repoID int64 = 1
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	pipeline := &types.Pipeline{Identifier: "pipeline", RepoID: repoID, CreatedBy: userID}
+	if err := database.NewPipelineStore(db).Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline %v", err)
+	}
+	execution := &types.Execution{PipelineID: pipeline.ID, RepoID: repoID, CreatedBy: userID, Number: 1}
+	if err := database.NewExecutionStore(db).Create(ctx, execution); err != nil {
+		t.Fatalf("failed to create execution %v", err)
+	}
+	stageStore := database.NewStageStore(db)
+	if err := stageStore.Create(ctx, &types.Stage{
+		ExecutionID: execution.ID, RepoID: repoID, Number: 1, Name: "test",
+	}); err != nil {
+		t.Fatalf("failed to create stage %v", err)
+	}
+	stages, err := stageStore.ListWithSteps(ctx, execution.ID)
+	if err != nil || len(stages) != 1 {
+		t.Fatalf("failed to list stages %v", err)
+	}
+	stage := stages[0]
+	step := &types.Step{StageID: stage.ID, Number: 1, Name: "test"}
+	if err := database.NewStepStore(db).Create(ctx, step); err != nil {
+		t.Fatalf("failed to create step %v", err)
+	}
+
+	newCase := func(name string, status enum.TestStatus, duration int64) *types.TestCase {
+		return &types.TestCase{
+			ExecutionID: execution.ID, StageID: stage.ID, StepID: step.ID,
+			Suite: "suite", Name: name, Status: status, Duration: duration,
+		}
+	}
+
+	store := database.NewTestCaseStore(db)
+	cases := []*types.TestCase{
+		newCase("a", enum.TestStatusPassed, 10),
+		newCase("b", enum.TestStatusSkipped, 0),
+		newCase("c", enum.TestStatusFailed, 20),
+		newCase("d", enum.TestStatusPassed, 30),
+	}
+	if err := store.CreateMany(ctx, cases); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+
+	summary, err := store.Summarize(ctx, execution.ID)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	want := types.TestSummary{Total: 4, Passed: 2, Failed: 1, Skipped: 1, Duration: 60}
+	if *summary != want {
+		t.Errorf("Summarize() = %+v, want %+v", *summary, want)
+	}
+
+	list, err := store.List(ctx, execution.ID, &types.TestCaseFilter{Pagination: types.Pagination{Page: 1, Size: 10}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var names string
+	for _, tc := range list {
+		names += tc.Name
+	}
+	if names != "cbad" {
+		t.Errorf("expected failed test cases first, got order %q", names)
+	}
+
+	failed := &types.TestCaseFilter{
+		Pagination: types.Pagination{Page: 1, Size: 10},
+		Status:     []enum.TestStatus{enum.TestStatusFailed},
+	}
+	count, err := store.Count(ctx, execution.ID, failed)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+
+	// a retried execution reuses the test cases of the stages it didn't rerun.
+	retried := &types.Execution{PipelineID: pipeline.ID, RepoID: repoID, CreatedBy: userID, Number: 2}
+	if err = database.NewExecutionStore(db).Create(ctx, retried); err != nil {
+		t.Fatalf("failed to create execution %v", err)
+	}
+	reused := &types.Stage{ExecutionID: retried.ID, RepoID: repoID, Number: 1, Name: "test"}
+	if err = stageStore.Create(ctx, reused); err != nil {
+		t.Fatalf("failed to create stage %v", err)
+	}
+	reusedStep := &types.Step{StageID: reused.ID, Number: 1, Name: "test"}
+	if err = database.NewStepStore(db).Create(ctx, reusedStep); err != nil {
+		t.Fatalf("failed to create step %v", err)
+	}
+
+	if err = store.CopyStage(ctx, stage.ID, retried.ID, reused.ID); err != nil {
+		t.Fatalf("CopyStage() error = %v", err)
+	}
+	summary, err = store.Summarize(ctx, retried.ID)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if *summary != want {
+		t.Errorf("Summarize() of the copies = %+v, want %+v", *summary, want)
+	}
+	list, err = store.List(ctx, retried.ID, &types.TestCaseFilter{Pagination: types.Pagination{Page: 1, Size: 10}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, tc := range list {
+		if tc.StageID != reused.ID || tc.StepID != reusedStep.ID {
+			t.Errorf("copied test case %q belongs to stage %d and step %d, want %d and %d",
+				tc.Name, tc.StageID, tc.StepID, reused.ID, reusedStep.ID)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(db *sqlx.DB) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db: db,
+	}
+}
+
+// UserGroupMemberStore implements store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db *sqlx.DB
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+type userGroupMemberPrincipal struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	principal
+}
+
+// ListUserGroupIDs returns the IDs of all user groups the principal is a member of.
+func (s *UserGroupMemberStore) ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT usergroup_member_usergroup_id
+	FROM usergroup_members
+	WHERE usergroup_member_principal_id = $1
+	ORDER BY usergroup_member_usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []int64
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list usergroups of principal")
+	}
+
+	return dst, nil
+}
+
+// ListPrincipalIDs returns the distinct IDs of all principals that are members of any of the user groups.
+func (s *UserGroupMemberStore) ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	if len(userGroupIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("DISTINCT usergroup_member_principal_id").
+		From("usergroup_members").
+		Where(squirrel.Eq{"usergroup_member_usergroup_id": userGroupIDs}).
+		OrderBy("usergroup_member_principal_id")
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []int64{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list usergroup member principal IDs")
+	}
+
+	return dst, nil
+}
+
+// MapPrincipals returns the member principals of each of the provided user groups.
+func (s *UserGroupMemberStore) MapPrincipals(
+	ctx context.Context,
+	userGroupIDs []int64,
+) (map[int64][]*types.Principal, error) {
+	result := make(map[int64][]*types.Principal, len(userGroupIDs))
+	if len(userGroupIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select("usergroup_member_usergroup_id", principalColumns).
+		From("usergroup_members").
+		InnerJoin("principals ON principal_id = usergroup_member_principal_id").
+		Where(squirrel.Eq{"usergroup_member_usergroup_id": userGroupIDs}).
+		OrderBy("usergroup_member_usergroup_id", "principal_id")
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*userGroupMemberPrincipal
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list usergroup member principals")
+	}
+
+	for _, m := range dst {
+		principal := m.Principal
+		result[m.UserGroupID] = append(result[m.UserGroupID], &principal)
+	}
+
+	return result, nil
+}
+
+// Create adds the principal to the user group. It's a no-op if the principal is already a member.
+func (s *UserGroupMemberStore) Create(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_members (
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created
+	) VALUES (
+		 :usergroup_member_usergroup_id
+		,:usergroup_member_principal_id
+		,:usergroup_member_created
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, userGroupMember(*member))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usergroup member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert usergroup member")
+	}
+
+	return nil
+}
+
+// Delete removes the principal from the user group.
+func (s *UserGroupMemberStore) Delete(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1
+		AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete usergroup member")
+	}
+
+	return nil
+}
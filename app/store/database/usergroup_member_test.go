@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestUserGroupMemberStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const otherUserID int64 = 2
+	if err := principalStore.CreateUser(ctx, &types.User{ID: otherUserID, UID: "user_2", Email: "u2"}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	userGroupStore := database.NewUserGroupStore(db)
+	groupIDs := make([]int64, 2)
+	for i, identifier := range []string{"developers", "admins"} {
+		group := &types.UserGroup{Identifier: identifier, Name: identifier}
+		if err := userGroupStore.Create(ctx, 1, group); err != nil {
+			t.Fatalf("failed to create usergroup %v", err)
+		}
+		groupIDs[i] = group.ID
+	}
+
+	memberStore := database.NewUserGroupMemberStore(db)
+
+	for _, m := range []types.UserGroupMember{
+		{UserGroupID: groupIDs[0], PrincipalID: userID},
+		{UserGroupID: groupIDs[0], PrincipalID: otherUserID},
+		{UserGroupID: groupIDs[1], PrincipalID: userID},
+		{UserGroupID: groupIDs[1], PrincipalID: userID}, // duplicates are ignored
+	} {
+		if err := memberStore.Create(ctx, &m); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	userGroupIDs, err := memberStore.ListUserGroupIDs(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListUserGroupIDs() error = %v", err)
+	}
+	if !reflect.DeepEqual(userGroupIDs, groupIDs) {
+		t.Errorf("ListUserGroupIDs() = %v, want %v", userGroupIDs, groupIDs)
+	}
+
+	principalIDs, err := memberStore.ListPrincipalIDs(ctx, groupIDs)
+	if err != nil {
+		t.Fatalf("ListPrincipalIDs() error = %v", err)
+	}
+	if want := []int64{userID, otherUserID}; !reflect.DeepEqual(principalIDs, want) {
+		t.Errorf("ListPrincipalIDs() = %v, want %v", principalIDs, want)
+	}
+
+	principals, err := memberStore.MapPrincipals(ctx, groupIDs)
+	if err != nil {
+		t.Fatalf("MapPrincipals() error = %v", err)
+	}
+	if len(principals[groupIDs[0]]) != 2 || len(principals[groupIDs[1]]) != 1 ||
+		principals[groupIDs[1]][0].UID != "user_1" {
+		t.Errorf("MapPrincipals() returned unexpected principals: %v", principals)
+	}
+
+	if err = memberStore.Delete(ctx, groupIDs[0], userID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	userGroupIDs, err = memberStore.ListUserGroupIDs(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListUserGroupIDs() error = %v", err)
+	}
+	if want := groupIDs[1:]; !reflect.DeepEqual(userGroupIDs, want) {
+		t.Errorf("ListUserGroupIDs() after delete = %v, want %v", userGroupIDs, want)
+	}
+}
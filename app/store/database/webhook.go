@@ -71,6 +71,11 @@ type webhook struct {
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
 	ExtraHeaders          null.String `db:"webhook_extra_headers"`
+	ConsecutiveFailures   int64       `db:"webhook_consecutive_failures"`
+
+	ContentType         enum.WebhookContentType         `db:"webhook_content_type"`
+	PayloadTemplateType enum.WebhookPayloadTemplateType `db:"webhook_payload_template_type"`
+	PayloadTemplate     string                          `db:"webhook_payload_template"`
 }
 
 const (
@@ -93,7 +98,11 @@ const (
 		,webhook_latest_execution_result
 		,webhook_type
 		,webhook_scope
-		,webhook_extra_headers`
+		,webhook_extra_headers
+		,webhook_consecutive_failures
+		,webhook_content_type
+		,webhook_payload_template_type
+		,webhook_payload_template`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -183,6 +192,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_type
 			,webhook_scope
 			,webhook_extra_headers
+			,webhook_consecutive_failures
+			,webhook_content_type
+			,webhook_payload_template_type
+			,webhook_payload_template
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -201,6 +214,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_type
 			,:webhook_scope
 			,:webhook_extra_headers
+			,:webhook_consecutive_failures
+			,:webhook_content_type
+			,:webhook_payload_template_type
+			,:webhook_payload_template
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -239,6 +256,10 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_extra_headers = :webhook_extra_headers
+			,webhook_consecutive_failures = :webhook_consecutive_failures
+			,webhook_content_type = :webhook_content_type
+			,webhook_payload_template_type = :webhook_payload_template_type
+			,webhook_payload_template = :webhook_payload_template
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -498,6 +519,10 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Type:                  hook.Type,
 		ExtraHeaders:          extraHeadersFromString(hook.ExtraHeaders.String),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		ContentType:           hook.ContentType,
+		PayloadTemplateType:   hook.PayloadTemplateType,
+		PayloadTemplate:       hook.PayloadTemplate,
 	}
 
 	switch {
@@ -536,8 +561,16 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Type:                  hook.Type,
 		ExtraHeaders:          extraHeadersToNullString(hook.ExtraHeaders),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		ContentType:           hook.ContentType,
+		PayloadTemplateType:   hook.PayloadTemplateType,
+		PayloadTemplate:       hook.PayloadTemplate,
 	}
 
+	// webhooks created without content type or template use the defaults
+	res.ContentType, _ = res.ContentType.Sanitize()
+	res.PayloadTemplateType, _ = res.PayloadTemplateType.Sanitize()
+
 	switch hook.ParentType {
 	case enum.WebhookParentRepo:
 		res.RepoID = null.IntFrom(hook.ParentID)
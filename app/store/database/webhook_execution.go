@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -63,6 +64,9 @@ type webhookExecution struct {
 	ResponseStatus     string                      `db:"webhook_execution_response_status"`
 	ResponseHeaders    string                      `db:"webhook_execution_response_headers"`
 	ResponseBody       string                      `db:"webhook_execution_response_body"`
+	Attempt            int                         `db:"webhook_execution_attempt"`
+	RetryAt            null.Int                    `db:"webhook_execution_retry_at"`
+	DeadLetter         bool                        `db:"webhook_execution_dead_letter"`
 }
 
 const (
@@ -83,7 +87,10 @@ const (
 		,webhook_execution_response_status_code
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
-		,webhook_execution_response_body`
+		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_retry_at
+		,webhook_execution_dead_letter`
 
 	webhookExecutionSelectBase = `
 	SELECT` + webhookExecutionColumns + `
@@ -125,6 +132,9 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
 		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_retry_at
+		,webhook_execution_dead_letter
 	) values (
 		 :webhook_execution_retrigger_of
 		,:webhook_execution_retriggerable
@@ -142,6 +152,9 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,:webhook_execution_response_status
 		,:webhook_execution_response_headers
 		,:webhook_execution_response_body
+		,:webhook_execution_attempt
+		,:webhook_execution_retry_at
+		,:webhook_execution_dead_letter
 	) RETURNING webhook_execution_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -254,6 +267,157 @@ func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	return mapToWebhookExecutions(dst), nil
 }
 
+// ListDeadLetters lists the permanently failed webhook executions for a given webhook id.
+func (s *WebhookExecutionStore) ListDeadLetters(
+	ctx context.Context,
+	webhookID int64,
+	opts *types.WebhookExecutionFilter,
+) ([]*types.WebhookExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns).
+		From("webhook_executions").
+		Where("webhook_execution_webhook_id = ?", webhookID).
+		Where("webhook_execution_dead_letter = ?", true)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("webhook_execution_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
+// CountDeadLetters counts the permanently failed webhook executions for a given webhook id.
+func (s *WebhookExecutionStore) CountDeadLetters(ctx context.Context, webhookID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("webhook_executions").
+		Where("webhook_execution_webhook_id = ?", webhookID).
+		Where("webhook_execution_dead_letter = ?", true)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Count query failed")
+	}
+
+	return count, nil
+}
+
+// UpdateDeadLetter marks or unmarks the webhook executions with the given ids as permanently failed.
+func (s *WebhookExecutionStore) UpdateDeadLetter(ctx context.Context, ids []int64, deadLetter bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Update("webhook_executions").
+		Set("webhook_execution_dead_letter", deadLetter).
+		Where(squirrel.Eq{"webhook_execution_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
+// ListDueRetries lists the webhook executions with an automatic retry that is due at the provided time.
+func (s *WebhookExecutionStore) ListDueRetries(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.WebhookExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns).
+		From("webhook_executions").
+		Where("webhook_execution_retry_at <= ?", now).
+		OrderBy("webhook_execution_retry_at").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
+// ClaimRetry postpones the due automatic retry of the webhook execution to leaseUntil.
+// It returns false if the retry was already claimed.
+func (s *WebhookExecutionStore) ClaimRetry(
+	ctx context.Context,
+	id int64,
+	retryAt int64,
+	leaseUntil int64,
+) (bool, error) {
+	const sqlQuery = `
+	UPDATE webhook_executions
+	SET webhook_execution_retry_at = $1
+	WHERE webhook_execution_id = $2 AND webhook_execution_retry_at = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, leaseUntil, id, retryAt)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return n > 0, nil
+}
+
+// ClearRetry clears the automatic retry of the webhook execution.
+func (s *WebhookExecutionStore) ClearRetry(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	UPDATE webhook_executions
+	SET webhook_execution_retry_at = NULL
+	WHERE webhook_execution_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
 func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution {
 	return &types.WebhookExecution{
 		ID:            execution.ID,
@@ -277,6 +441,9 @@ func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution
 			Headers:    execution.ResponseHeaders,
 			Body:       execution.ResponseBody,
 		},
+		Attempt:    execution.Attempt,
+		RetryAt:    execution.RetryAt.Ptr(),
+		DeadLetter: execution.DeadLetter,
 	}
 }
 
@@ -299,6 +466,9 @@ func mapToInternalWebhookExecution(execution *types.WebhookExecution) *webhookEx
 		ResponseStatus:     execution.Response.Status,
 		ResponseHeaders:    execution.Response.Headers,
 		ResponseBody:       execution.Response.Body,
+		Attempt:            execution.Attempt,
+		RetryAt:            null.IntFromPtr(execution.RetryAt),
+		DeadLetter:         execution.DeadLetter,
 	}
 }
 
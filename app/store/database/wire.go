@@ -33,6 +33,9 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalStore,
 	ProvideUserGroupStore,
 	ProvideUserGroupReviewerStore,
+	ProvideUserGroupMemberStore,
+	ProvideExternalIdentityStore,
+	ProvideCustomRoleStore,
 	ProvidePrincipalInfoView,
 	ProvideInfraProviderResourceView,
 	ProvideSpacePathStore,
@@ -49,6 +52,9 @@ var WireSet = wire.NewSet(
 	ProvidePipelineStore,
 	ProvideStageStore,
 	ProvideStepStore,
+	ProvideTestCaseStore,
+	ProvideExecutionArtifactStore,
+	ProvideStageApprovalStore,
 	ProvideSecretStore,
 	ProvideMembershipStore,
 	ProvideTokenStore,
@@ -90,6 +96,9 @@ var WireSet = wire.NewSet(
 	ProvideUsageMetricStore,
 	ProvideCDEGatewayStore,
 	ProvideFavoriteStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationWatchStore,
+	ProvideNotificationDigestStore,
 	ProvideAutolinkStore,
 	ProvideGitspaceSettingsStore,
 	ProvideAITaskStore,
@@ -141,6 +150,21 @@ func ProvideUserGroupReviewerStore(
 	return NewUsergroupReviewerStore(db, pInfoCache, userGroupStore)
 }
 
+// ProvideUserGroupMemberStore provides a usergroup member store.
+func ProvideUserGroupMemberStore(db *sqlx.DB) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db)
+}
+
+// ProvideExternalIdentityStore provides an external identity store.
+func ProvideExternalIdentityStore(db *sqlx.DB) store.ExternalIdentityStore {
+	return NewExternalIdentityStore(db)
+}
+
+// ProvideCustomRoleStore provides a custom role store.
+func ProvideCustomRoleStore(db *sqlx.DB) store.CustomRoleStore {
+	return NewCustomRoleStore(db)
+}
+
 // ProvidePrincipalInfoView provides a principal info store.
 func ProvidePrincipalInfoView(db *sqlx.DB) store.PrincipalInfoView {
 	return NewPrincipalInfoView(db)
@@ -265,6 +289,21 @@ func ProvideStepStore(db *sqlx.DB) store.StepStore {
 	return NewStepStore(db)
 }
 
+// ProvideTestCaseStore provides a test case store.
+func ProvideTestCaseStore(db *sqlx.DB) store.TestCaseStore {
+	return NewTestCaseStore(db)
+}
+
+// ProvideExecutionArtifactStore provides an execution artifact store.
+func ProvideExecutionArtifactStore(db *sqlx.DB) store.ExecutionArtifactStore {
+	return NewExecutionArtifactStore(db)
+}
+
+// ProvideStageApprovalStore provides a stage approval store.
+func ProvideStageApprovalStore(db *sqlx.DB) store.StageApprovalStore {
+	return NewStageApprovalStore(db)
+}
+
 // ProvideSecretStore provides a secret store.
 func ProvideSecretStore(db *sqlx.DB) store.SecretStore {
 	return NewSecretStore(db)
@@ -469,6 +508,18 @@ func ProvideFavoriteStore(db *sqlx.DB) store.FavoriteStore {
 	return NewFavoriteStore(db)
 }
 
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+func ProvideNotificationWatchStore(db *sqlx.DB) store.NotificationWatchStore {
+	return NewNotificationWatchStore(db)
+}
+
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
 func ProvideAutolinkStore(db *sqlx.DB) store.AutoLinkStore {
 	return NewAutoLinkStore(db)
 }
@@ -48,11 +48,12 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	schemeSSH      = "ssh"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP            = "http"
+	schemeHTTPS           = "https"
+	schemeSSH             = "ssh"
+	gitnessHomeDir        = ".gitness"
+	blobDir               = "blob"
+	keywordSearchIndexDir = "search"
 )
 
 // LoadConfig returns the system configuration from the
@@ -306,6 +307,9 @@ func ProvideGitConfig(config *types.Config) gittypes.Config {
 		Root:     config.Git.Root,
 		TmpDir:   config.Git.TmpDir,
 		HookPath: config.Git.HookPath,
+
+		CommitSigningKeyPath: config.Git.CommitSigningKeyPath,
+
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
@@ -335,14 +339,30 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		AllowLoopback:       config.Webhook.AllowLoopback,
 		AllowLinkLocal:      config.Webhook.AllowLinkLocal,
 		InternalSecret:      config.Webhook.InternalSecret,
+
+		RetryMaxAttempts:        config.Webhook.RetryMaxAttempts,
+		RetryBackoffBase:        config.Webhook.RetryBackoffBase,
+		RetryBackoffMax:         config.Webhook.RetryBackoffMax,
+		CircuitBreakerThreshold: config.Webhook.CircuitBreakerThreshold,
 	}
 }
 
 func ProvideNotificationConfig(config *types.Config) notification.Config {
 	return notification.Config{
-		EventReaderName: config.InstanceID,
-		Concurrency:     config.Notification.Concurrency,
-		MaxRetries:      config.Notification.MaxRetries,
+		EventReaderName:  config.InstanceID,
+		Concurrency:      config.Notification.Concurrency,
+		MaxRetries:       config.Notification.MaxRetries,
+		DigestHourlyCron: config.Notification.DigestHourlyCron,
+		DigestDailyCron:  config.Notification.DigestDailyCron,
+	}
+}
+
+// ProvideNotificationChatConfig loads the chat notification client config from the main config.
+func ProvideNotificationChatConfig(config *types.Config) notification.ChatConfig {
+	return notification.ChatConfig{
+		AllowLoopback:       config.Notification.Chat.AllowLoopback,
+		AllowPrivateNetwork: config.Notification.Chat.AllowPrivateNetwork,
+		AllowLinkLocal:      config.Notification.Chat.AllowLinkLocal,
 	}
 }
 
@@ -404,6 +424,8 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 		DeletedRepositoriesRetentionTime:      config.Repos.DeletedRetentionTime,
 		DeletedRepositoriesCleanupCron:        config.Repos.DeletedCleanupCron,
 		DeletedRepositoriesCleanupMaxDuration: config.Repos.DeletedCleanupMaxDuration,
+		ExecutionArtifactsRetentionTime:       config.CI.ArtifactRetentionTime,
+		ExecutionArtifactsRetentionCount:      config.CI.ArtifactRetentionCount,
 	}
 }
 
@@ -416,10 +438,19 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+	indexRoot := config.KeywordSearch.IndexRoot
+	if indexRoot == "" {
+		indexRoot = filepath.Join(config.Git.Root, keywordSearchIndexDir)
+	}
+
 	return keywordsearch.Config{
-		EventReaderName: config.InstanceID,
-		Concurrency:     config.KeywordSearch.Concurrency,
-		MaxRetries:      config.KeywordSearch.MaxRetries,
+		EventReaderName:    config.InstanceID,
+		Concurrency:        config.KeywordSearch.Concurrency,
+		MaxRetries:         config.KeywordSearch.MaxRetries,
+		IndexRoot:          indexRoot,
+		MaxFileSize:        config.KeywordSearch.MaxFileSize,
+		IndexCacheSize:     config.KeywordSearch.IndexCacheSize,
+		IndexCacheMaxBytes: config.KeywordSearch.IndexCacheMaxBytes,
 	}
 }
 
@@ -105,6 +105,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.StageApproval.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register stage approval service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -51,6 +51,8 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
 	aitaskevent "github.com/harness/gitness/app/events/aitask"
@@ -76,6 +78,7 @@ import (
 	"github.com/harness/gitness/app/gitspace/platformsecret"
 	"github.com/harness/gitness/app/gitspace/scm"
 	gitspacesecret "github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -96,8 +99,10 @@ import (
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/customrole"
 	"github.com/harness/gitness/app/services/dotrange"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/externaluser"
 	gitspacedeleteeventservice "github.com/harness/gitness/app/services/gitspacedeleteevent"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	gitspaceinfraeventservice "github.com/harness/gitness/app/services/gitspaceinfraevent"
@@ -160,9 +165,16 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/services/reindexing"
 	cargoutils "github.com/harness/gitness/registry/app/utils/cargo"
+	condautils "github.com/harness/gitness/registry/app/utils/conda"
+	debianutils "github.com/harness/gitness/registry/app/utils/debian"
 	gopackageutils "github.com/harness/gitness/registry/app/utils/gopackage"
+	rubygemsutils "github.com/harness/gitness/registry/app/utils/rubygems"
+	signingutils "github.com/harness/gitness/registry/app/utils/signing"
 	registryhandlers "github.com/harness/gitness/registry/job"
 	registryindex "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
+	registryreplication "github.com/harness/gitness/registry/services/replication"
+	registryscanning "github.com/harness/gitness/registry/services/scanning"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -218,6 +230,9 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usergroupservice.WireSet,
 		system.WireSet,
 		authn.WireSet,
+		oidc.WireSet,
+		ldap.WireSet,
+		externaluser.WireSet,
 		authz.WireSet,
 		infrastructure.WireSet,
 		infraproviderpkg.WireSet,
@@ -244,6 +259,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		events.ProvideNoopCollector,
 		cliserver.ProvideWebhookConfig,
 		cliserver.ProvideNotificationConfig,
+		cliserver.ProvideNotificationChatConfig,
 		webhook.WireSet,
 		languageanalyzer.WireSet,
 		cliserver.ProvideTriggerConfig,
@@ -288,6 +304,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		importer.ProvideWebhookService,
 		migrateservice.WireSet,
 		canceler.WireSet,
+		approval.WireSet,
 		exporter.WireSet,
 		metric.WireSet,
 		reposervice.WireSet,
@@ -347,6 +364,9 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		lfs.WireSet,
 		usage.WireSet,
 		registrywebhooks.WireSet,
+		registryreplication.WireSet,
+		registryscanning.WireSet,
+		registrycleanup.WireSet,
 		gitspacedeleteevents.WireSet,
 		gitspacedeleteeventservice.WireSet,
 		registryindex.WireSet,
@@ -355,8 +375,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideRepoActivityConfig,
 		repoactivity.WireSet,
 		autolink.WireSet,
+		customrole.WireSet,
 		dotrange.WireSet,
 		cargoutils.WireSet,
+		debianutils.WireSet,
+		rubygemsutils.WireSet,
+		condautils.WireSet,
+		signingutils.WireSet,
 		gopackageutils.WireSet,
 		registrypostporcessingevents.ProvideAsyncProcessingReporter,
 		registrypostporcessingevents.ProvideReaderFactory,
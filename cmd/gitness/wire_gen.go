@@ -40,17 +40,19 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/connector"
 	events14 "github.com/harness/gitness/app/events/aitask"
 	events6 "github.com/harness/gitness/app/events/check"
 	events13 "github.com/harness/gitness/app/events/git"
-	events8 "github.com/harness/gitness/app/events/gitspace"
-	events11 "github.com/harness/gitness/app/events/gitspacedelete"
-	events9 "github.com/harness/gitness/app/events/gitspaceinfra"
-	events10 "github.com/harness/gitness/app/events/gitspaceoperations"
+	events9 "github.com/harness/gitness/app/events/gitspace"
+	events12 "github.com/harness/gitness/app/events/gitspacedelete"
+	events10 "github.com/harness/gitness/app/events/gitspaceinfra"
+	events11 "github.com/harness/gitness/app/events/gitspaceoperations"
 	events5 "github.com/harness/gitness/app/events/mergequeue"
-	events12 "github.com/harness/gitness/app/events/pipeline"
+	events8 "github.com/harness/gitness/app/events/pipeline"
 	events7 "github.com/harness/gitness/app/events/pullreq"
 	events3 "github.com/harness/gitness/app/events/repo"
 	events4 "github.com/harness/gitness/app/events/rule"
@@ -65,6 +67,7 @@ import (
 	"github.com/harness/gitness/app/gitspace/platformsecret"
 	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -82,11 +85,13 @@ import (
 	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/checkreq"
-	"github.com/harness/gitness/app/services/cleanup"
+	cleanup2 "github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/customrole"
 	"github.com/harness/gitness/app/services/dotrange"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/externaluser"
 	"github.com/harness/gitness/app/services/gitspace/gitspacewire"
 	"github.com/harness/gitness/app/services/gitspacedeleteevent"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -146,6 +151,8 @@ import (
 	"github.com/harness/gitness/pubsub"
 	api2 "github.com/harness/gitness/registry/app/api"
 	cargo3 "github.com/harness/gitness/registry/app/api/controller/pkg/cargo"
+	conda3 "github.com/harness/gitness/registry/app/api/controller/pkg/conda"
+	debian3 "github.com/harness/gitness/registry/app/api/controller/pkg/debian"
 	"github.com/harness/gitness/registry/app/api/controller/pkg/generic"
 	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
 	huggingface2 "github.com/harness/gitness/registry/app/api/controller/pkg/huggingface"
@@ -153,6 +160,8 @@ import (
 	nuget2 "github.com/harness/gitness/registry/app/api/controller/pkg/nuget"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	rpm2 "github.com/harness/gitness/registry/app/api/controller/pkg/rpm"
+	rubygems3 "github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	terraform2 "github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
 	huggingface3 "github.com/harness/gitness/registry/app/api/handler/huggingface"
 	"github.com/harness/gitness/registry/app/api/router"
 	"github.com/harness/gitness/registry/app/events/artifact"
@@ -162,6 +171,8 @@ import (
 	"github.com/harness/gitness/registry/app/pkg"
 	"github.com/harness/gitness/registry/app/pkg/base"
 	cargo2 "github.com/harness/gitness/registry/app/pkg/cargo"
+	conda2 "github.com/harness/gitness/registry/app/pkg/conda"
+	debian2 "github.com/harness/gitness/registry/app/pkg/debian"
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
@@ -173,6 +184,8 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/pkg/quarantine"
 	"github.com/harness/gitness/registry/app/pkg/rpm"
+	rubygems2 "github.com/harness/gitness/registry/app/pkg/rubygems"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
 	"github.com/harness/gitness/registry/app/services/deletion"
 	"github.com/harness/gitness/registry/app/services/hook"
 	publicaccess2 "github.com/harness/gitness/registry/app/services/publicaccess"
@@ -182,10 +195,17 @@ import (
 	cache2 "github.com/harness/gitness/registry/app/store/cache"
 	database2 "github.com/harness/gitness/registry/app/store/database"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/app/utils/conda"
+	"github.com/harness/gitness/registry/app/utils/debian"
 	gopackage3 "github.com/harness/gitness/registry/app/utils/gopackage"
+	"github.com/harness/gitness/registry/app/utils/rubygems"
+	"github.com/harness/gitness/registry/app/utils/signing"
 	"github.com/harness/gitness/registry/gc"
 	job2 "github.com/harness/gitness/registry/job"
 	asyncprocessing2 "github.com/harness/gitness/registry/services/asyncprocessing"
+	"github.com/harness/gitness/registry/services/cleanup"
+	replication2 "github.com/harness/gitness/registry/services/replication"
+	"github.com/harness/gitness/registry/services/scanning"
 	webhook3 "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -225,7 +245,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceFinder, membershipStore)
+	customRoleStore := database.ProvideCustomRoleStore(db)
+	permissionCache := authz.ProvidePermissionCache(spaceFinder, membershipStore, customRoleStore)
 	publicAccessStore := database.ProvidePublicAccessStore(db)
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	cacheEvictor := cache.ProvideEvictorRepositoryCore(pubSub)
@@ -250,7 +271,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	upstreamProxyFinder := refcache2.ProvideUpstreamProxyFinder(upstreamProxyConfigRepository, upstreamProxyRegistryIDCache, evictor3)
 	registryFinder := refcache2.ProvideRegistryFinder(registryRepository, registryIDCache, registryUUIDToIDCache, registryRootRefCache, evictor2, spaceFinder, upstreamProxyFinder)
 	publicaccessService := publicaccess.ProvidePublicAccess(config, publicAccessStore, spaceFinder, repoFinder, registryFinder)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceFinder, publicaccessService)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceFinder, publicaccessService, customRoleStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
@@ -268,11 +289,38 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	favoriteStore := database.ProvideFavoriteStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore)
+	notificationPreferenceStore := database.ProvideNotificationPreferenceStore(db)
+	externalIdentityStore := database.ProvideExternalIdentityStore(db)
+	provisioner := externaluser.ProvideProvisioner(transactor, principalUID, principalStore, externalIdentityStore, reporter)
+	userGroupStore := database.ProvideUserGroupStore(db)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db)
+	memberSyncer := usergroup.ProvideMemberSyncer(transactor, spaceFinder, userGroupStore, userGroupMemberStore)
+	client, err := ldap.ProvideClient(config)
+	if err != nil {
+		return nil, err
+	}
+	jobStore := database.ProvideJobStore(db)
+	executor := job.ProvideExecutor(jobStore, pubSub)
+	lockConfig := server.ProvideLockConfig(config)
+	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	jobConfig := server.ProvideJobsConfig(config)
+	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
+	if err != nil {
+		return nil, err
+	}
+	ldapSyncer, err := usergroup.ProvideLDAPSyncer(ctx, config, client, principalStore, externalIdentityStore, membershipStore, spaceFinder, memberSyncer, provisioner, executor, jobScheduler)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := oidc.ProvideProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore, notificationPreferenceStore, provisioner, memberSyncer, ldapSyncer, provider, config)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
-	provider, err := url.ProvideURLProvider(config)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, ldapSyncer)
+	urlProvider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
@@ -284,7 +332,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	settingsStore := database.ProvideSettingsStore(db)
-	settingsService := settings.ProvideService(settingsStore, spaceFinder)
+	encrypter, err := encrypt.ProvideEncrypter(config)
+	if err != nil {
+		return nil, err
+	}
+	settingsService := settings.ProvideService(settingsStore, spaceFinder, encrypter)
 	protectionManager, err := protection.ProvideManager(ruleStore)
 	if err != nil {
 		return nil, err
@@ -304,39 +356,27 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	encrypter, err := encrypt.ProvideEncrypter(config)
-	if err != nil {
-		return nil, err
-	}
-	jobStore := database.ProvideJobStore(db)
-	executor := job.ProvideExecutor(jobStore, pubSub)
-	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
-	jobConfig := server.ProvideJobsConfig(config)
-	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
-	if err != nil {
-		return nil, err
-	}
 	triggerStore := database.ProvideTriggerStore(db)
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
+	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface)
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	auditService := audit.ProvideAuditService()
-	importerImporter := importer.ProvideImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, repoFinder, streamer, indexer, publicaccessService, eventsReporter, auditService, settingsService)
+	importerImporter := importer.ProvideImporter(config, urlProvider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, repoFinder, streamer, indexer, publicaccessService, eventsReporter, auditService, settingsService)
 	jobRepository, err := importer.ProvideJobRepositoryImport(encrypter, jobScheduler, executor, importerImporter)
 	if err != nil {
 		return nil, err
 	}
-	jobReferenceSync, err := importer.ProvideJobReferenceSync(config, provider, gitInterface, repoStore, repoFinder, jobScheduler, executor, indexer, eventsReporter)
+	jobReferenceSync, err := importer.ProvideJobReferenceSync(config, urlProvider, gitInterface, repoStore, repoFinder, jobScheduler, executor, indexer, eventsReporter)
 	if err != nil {
 		return nil, err
 	}
 	connectorService := importer.ProvideConnectorService()
-	jobRepositoryLink, err := importer.ProvideJobRepositoryLink(ctx, config, jobScheduler, executor, provider, gitInterface, connectorService, repoStore, linkedRepoStore, repoFinder, streamer, indexer, eventsReporter)
+	jobRepositoryLink, err := importer.ProvideJobRepositoryLink(ctx, config, jobScheduler, executor, urlProvider, gitInterface, connectorService, repoStore, linkedRepoStore, repoFinder, streamer, indexer, eventsReporter)
 	if err != nil {
 		return nil, err
 	}
@@ -356,8 +396,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqLabelSuggestionStore := database.ProvidePullReqLabelSuggestionStore(db)
 	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqLabelSuggestionStore, principalInfoCache, spaceFinder)
 	instrumentService := instrument.ProvideService()
-	userGroupStore := database.ProvideUserGroupStore(db)
-	usergroupService := usergroup.ProvideService()
+	usergroupService := usergroup.ProvideService(userGroupMemberStore)
 	reporter2, err := events4.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -374,12 +413,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	remoteauthService := remoteauth.ProvideRemoteAuth(tokenStore, principalStore)
-	lfsController := lfs.ProvideController(authorizer, repoFinder, repoStore, principalStore, lfsObjectStore, blobStore, remoteauthService, provider, settingsService)
+	lfsController := lfs.ProvideController(authorizer, repoFinder, repoStore, principalStore, lfsObjectStore, blobStore, remoteauthService, urlProvider, settingsService)
 	keyfetcherService := keyfetcher.ProvideService(publicKeyStore)
 	signatureVerifyService := publickey.ProvideSignatureVerifyService(principalStore, keyfetcherService, gitSignatureResultStore)
 	autoLinkStore := database.ProvideAutolinkStore(db)
 	autolinkService := autolink.ProvideAutoLink(transactor, spaceStore, repoStore, autoLinkStore)
-	dotrangeService := dotrange.ProvideService(gitInterface, repoFinder, provider, authorizer)
+	dotrangeService := dotrange.ProvideService(gitInterface, repoFinder, urlProvider, authorizer)
 	webhookService := importer.ProvideWebhookService()
 	repoLangStore := database.ProvideRepoLangStore(db)
 	reporter3, err := events5.ProvideReporter(eventsSystem)
@@ -404,12 +443,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	autoMergeStore := database.ProvideAutoMergeStore(db)
 	checkreqProvider := checkreq.ProvideProvider()
-	mergeService := merge.ProvideService(gitInterface, transactor, reporter4, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, pullReqReviewerStore, autoMergeStore, codeownersService, usergroupService, provider, streamer, instrumentService, checkreqProvider)
-	mergequeueService, err := mergequeue.ProvideService(ctx, config, gitInterface, transactor, reporter3, readerFactory, eventsReaderFactory, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, mergeQueueStore, mergeQueueEntryStore, protectionManager, mergeService, provider, lockerLocker, jobScheduler, executor)
+	mergeService := merge.ProvideService(gitInterface, transactor, reporter4, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, pullReqReviewerStore, autoMergeStore, codeownersService, usergroupService, urlProvider, streamer, instrumentService, checkreqProvider, signatureVerifyService)
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, gitInterface, transactor, reporter3, readerFactory, eventsReaderFactory, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, mergeQueueStore, mergeQueueEntryStore, protectionManager, mergeService, urlProvider, lockerLocker, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, repoActivityStore, linkedRepoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, jobRepositoryLink, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService, autolinkService, dotrangeService, connectorService, webhookService, repoLangStore, mergequeueService)
+	notificationWatchStore := database.ProvideNotificationWatchStore(db)
+	repoController := repo.ProvideController(config, transactor, urlProvider, authorizer, repoStore, repoActivityStore, linkedRepoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, jobRepositoryLink, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService, autolinkService, dotrangeService, connectorService, webhookService, repoLangStore, mergequeueService, notificationWatchStore)
 	reposettingsController := reposettings.ProvideController(authorizer, repoFinder, spaceFinder, settingsService, auditService)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -419,20 +459,32 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	stepStore := database.ProvideStepStore(db)
 	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore)
 	commitService := commit.ProvideService(gitInterface)
+	stageApprovalStore := database.ProvideStageApprovalStore(db)
+	logStore := logs.ProvideLogStore(db, config)
+	testCaseStore := database.ProvideTestCaseStore(db)
+	executionArtifactStore := database.ProvideExecutionArtifactStore(db)
 	fileService := file.ProvideService(gitInterface)
 	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, stageStore, pipelineStore, repoFinder)
-	logStore := logs.ProvideLogStore(db, config)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, stageApprovalStore, stepStore, logStore, testCaseStore, executionArtifactStore, gitInterface, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, urlProvider, templateStore, pluginStore, publicaccessService)
 	logStream := livelog.ProvideLogStream()
+	secretStore := database.ProvideSecretStore(db)
+	reporter5, err := events8.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, urlProvider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, testCaseStore, executionArtifactStore, stageApprovalStore, blobStore, principalStore, publicaccessService, reporter5)
+	approvalService, err := approval.ProvideService(stageApprovalStore, stageStore, executionStore, userGroupStore, usergroupService, principalInfoCache, executionManager, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, stageStore, pipelineStore, repoFinder, testCaseStore, executionArtifactStore, blobStore, approvalService)
 	logsController := logs2.ProvideController(authorizer, executionStore, pipelineStore, stageStore, stepStore, logStore, logStream, repoFinder)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
-	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
 	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, pullReqStore, checkStore, repoFinder, labelService, protectionManager)
-	repository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	repository, err := exporter.ProvideSpaceExporter(urlProvider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
@@ -440,7 +492,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	infraProviderResourceCache := cache.ProvideInfraProviderResourceCache(infraProviderResourceView)
 	gitspaceConfigStore := database.ProvideGitspaceConfigStore(db, principalInfoCache, infraProviderResourceCache, spaceIDCache)
 	gitspaceInstanceStore := database.ProvideGitspaceInstanceStore(db, spaceIDCache)
-	reporter5, err := events8.ProvideReporter(eventsSystem)
+	reporter6, err := events9.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -453,15 +505,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	dockerClientFactory := infraprovider.ProvideDockerClientFactory(dockerConfig)
-	reporter6, err := events9.ProvideReporter(eventsSystem)
+	reporter7, err := events10.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter6)
+	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter7)
 	factory := infraprovider.ProvideFactory(dockerProvider)
 	cdeGatewayStore := database.ProvideCDEGatewayStore(db)
 	infraproviderService := infraproviderwire.ProvideInfraProvider(transactor, gitspaceConfigStore, infraProviderResourceStore, infraProviderConfigStore, infraProviderTemplateStore, factory, spaceFinder, cdeGatewayStore)
-	gitnessSCM := scm.ProvideGitnessSCM(repoStore, repoFinder, gitInterface, tokenStore, principalStore, provider)
+	gitnessSCM := scm.ProvideGitnessSCM(repoStore, repoFinder, gitInterface, tokenStore, principalStore, urlProvider)
 	genericSCM := scm.ProvideGenericSCM()
 	scmFactory := scm.ProvideFactory(gitnessSCM, genericSCM)
 	scmSCM := scm.ProvideSCM(scmFactory)
@@ -479,11 +531,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	reporter7, err := events10.ProvideReporter(eventsSystem)
+	reporter8, err := events11.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	embeddedDockerOrchestrator := container.ProvideEmbeddedDockerOrchestrator(dockerClientFactory, statefulLogger, runargProvider, reporter7)
+	embeddedDockerOrchestrator := container.ProvideEmbeddedDockerOrchestrator(dockerClientFactory, statefulLogger, runargProvider, reporter8)
 	containerFactory := container.ProvideContainerOrchestratorFactory(embeddedDockerOrchestrator)
 	orchestratorConfig := gitspaceconfig.ProvideGitspaceOrchestratorConfig(config)
 	vsCodeConfig := gitspaceconfig.ProvideIDEVSCodeConfig(config)
@@ -504,25 +556,22 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, platformConnector, platformSecret, infraProvisioner, containerFactory, reporter5, orchestratorConfig, ideFactory, resolverFactory, gitspaceInstanceStore, gitspaceConfigStore, gitspacesettingsService, spaceStore, infraproviderService)
-	reporter8, err := events11.ProvideReporter(eventsSystem)
+	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, platformConnector, platformSecret, infraProvisioner, containerFactory, reporter6, orchestratorConfig, ideFactory, resolverFactory, gitspaceInstanceStore, gitspaceConfigStore, gitspacesettingsService, spaceStore, infraproviderService)
+	reporter9, err := events12.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	tokenGenerator := tokengenerator.ProvideTokenGenerator()
-	gitspaceService := gitspacewire.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter5, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter8, ideFactory, spaceStore, tokenGenerator)
+	gitspaceService := gitspacewire.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter6, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter9, ideFactory, spaceStore, tokenGenerator)
 	usageMetricStore := database.ProvideUsageMetricStore(db)
 	resourceMover := space.ProvideNoopResourceMover()
 	spaceService, err := space.ProvideService(transactor, jobScheduler, executor, encrypter, repoStore, spaceStore, spacePathStore, ruleStore, pullReqStore, resourceMover, spaceFinder, gitspaceService, infraproviderService, repoController)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space2.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repoFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, autolinkService, spaceService)
-	reporter9, err := events12.ProvideReporter(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	pipelineController := pipeline.ProvideController(triggerStore, authorizer, pipelineStore, reporter9, repoFinder)
+	customroleService := customrole.ProvideService(spaceStore, spaceFinder, membershipStore, customRoleStore)
+	spaceController := space2.ProvideController(config, transactor, urlProvider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repoFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, autolinkService, spaceService, customroleService)
+	pipelineController := pipeline.ProvideController(triggerStore, authorizer, pipelineStore, reporter5, repoFinder)
 	secretController := secret2.ProvideController(encrypter, secretStore, authorizer, spaceFinder)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoFinder)
 	scmService := connector.ProvideSCMConnectorHandler(secretStore)
@@ -545,7 +594,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory2, readerFactory3, reporter4, transactor, authorizer, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalStore, pullReqReviewerStore, pullReqReviewerSuggestionStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory2, readerFactory3, reporter4, transactor, authorizer, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalStore, pullReqReviewerStore, pullReqReviewerSuggestionStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, urlProvider, streamer, protectionManager, usergroupService)
 	if err != nil {
 		return nil, err
 	}
@@ -553,15 +602,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
+	pullReq := migrate.ProvidePullReqImporter(urlProvider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
 	branchStore := database.ProvideBranchStore(db)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, pullReqReviewerSuggestionStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, pullReqFileGroupStore, membershipStore, checkStore, autoMergeStore, mergeQueueStore, mergeQueueEntryStore, mergequeueService, gitInterface, repoFinder, reporter4, migrator, pullreqService, listService, mergeService, automergeService, protectionManager, streamer, dotrangeService, codeownersService, lockerLocker, settingsService, pullReq, labelService, labelStore, labelValueStore, pullReqLabelSuggestionStore, instrumentService, usergroupService, branchStore, usergroupResolver, signatureVerifyService)
+	pullreqController := pullreq2.ProvideController(transactor, urlProvider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, pullReqReviewerSuggestionStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, pullReqFileGroupStore, membershipStore, checkStore, autoMergeStore, mergeQueueStore, mergeQueueEntryStore, mergequeueService, gitInterface, repoFinder, reporter4, migrator, pullreqService, listService, mergeService, automergeService, protectionManager, streamer, dotrangeService, codeownersService, lockerLocker, settingsService, pullReq, labelService, labelStore, labelValueStore, pullReqLabelSuggestionStore, instrumentService, usergroupService, branchStore, usergroupResolver, signatureVerifyService, notificationWatchStore)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	urlProvider := webhook.ProvideURLProvider(ctx)
+	webhookURLProvider := webhook.ProvideURLProvider(ctx)
 	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
-	service3, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory2, readerFactory3, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore)
+	service3, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory2, readerFactory3, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, urlProvider, principalStore, gitInterface, encrypter, labelStore, webhookURLProvider, labelValueStore, auditService, streamer, secretService, spacePathStore, executor, jobScheduler)
 	if err != nil {
 		return nil, err
 	}
@@ -583,7 +632,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter10, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService, mergequeueService)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter10, eventsReporter, gitInterface, pullReqStore, urlProvider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService, mergequeueService, signatureVerifyService)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
 	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, spaceFinder, authorizer, usergroupService)
@@ -603,7 +652,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	rule := migrate.ProvideRuleImporter(ruleStore, transactor, principalStore)
 	migrateWebhook := migrate.ProvideWebhookImporter(webhookConfig, transactor, webhookStore)
 	migrateLabel := migrate.ProvideLabelImporter(transactor, labelStore, labelValueStore, spaceStore)
-	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, migrateLabel, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, spaceFinder, repoFinder, eventsReporter)
+	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, urlProvider, pullReq, rule, migrateWebhook, migrateLabel, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, spaceFinder, repoFinder, eventsReporter)
 	openapiService := openapi.ProvideOpenAPIService()
 	blobRepository := database2.ProvideBlobDao(db, mediaTypesRepository)
 	storageDriver, err := api2.DefaultStorageProvider(ctx, config)
@@ -629,7 +678,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	registryBlobRepository := database2.ProvideRegistryBlobDao(db)
-	manifestService := docker.ManifestServiceProvider(registryRepository, manifestRepository, blobRepository, mediaTypesRepository, manifestReferenceRepository, tagRepository, imageRepository, artifactRepository, layerRepository, gcService, transactor, eventReporter, spaceFinder, ociImageIndexMappingRepository, artifactReporter, provider, auditService, registryBlobRepository)
+	manifestService := docker.ManifestServiceProvider(registryRepository, manifestRepository, blobRepository, mediaTypesRepository, manifestReferenceRepository, tagRepository, imageRepository, artifactRepository, layerRepository, gcService, transactor, eventReporter, spaceFinder, ociImageIndexMappingRepository, artifactReporter, urlProvider, auditService, registryBlobRepository)
 	bandwidthStatRepository := database2.ProvideBandwidthStatDao(db)
 	quarantineArtifactRepository := database2.ProvideQuarantineArtifactDao(db)
 	replicationReporter, err := replication.ProvideNoOpReplicationReporter()
@@ -650,7 +699,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	evictor5 := publicaccess2.ProvideEvictorPublicAccess(pubSub)
 	publicaccessCache := publicaccess2.ProvidePublicAccessCache(ctx, publicaccessService, evictor5)
 	cacheService := publicaccess2.ProvideRegistryPublicAccess(publicaccessService, publicaccessCache, evictor5)
-	handler := api2.NewHandlerProvider(dockerController, spaceFinder, spaceStore, tokenStore, controller, authenticator, provider, authorizer, config, registryFinder, cacheService, auditService)
+	handler := api2.NewHandlerProvider(dockerController, spaceFinder, spaceStore, tokenStore, controller, authenticator, urlProvider, authorizer, config, registryFinder, cacheService, auditService)
 	registryOCIHandler := router.OCIHandlerProvider(handler)
 	genericBlobRepository := database2.ProvideGenericBlobDao(db)
 	nodesRepository := database2.ProvideNodeDao(db)
@@ -663,7 +712,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	service4, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory4, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
+	service4, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory4, webhooksRepository, webhooksExecutionRepository, spaceStore, urlProvider, principalStore, webhookURLProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -675,16 +724,43 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	registryHelper := cargo.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder)
-	interfacesRegistryHelper := helpers.ProvideRegistryHelper(artifactRepository, fileManager, imageRepository, artifactReporter, asyncprocessingReporter, transactor, provider, config)
-	packageWrapper := helpers.ProvidePackageWrapperProvider(interfacesRegistryHelper, registryFinder, registryHelper)
+	interfacesRegistryHelper := helpers.ProvideRegistryHelper(artifactRepository, fileManager, imageRepository, artifactReporter, asyncprocessingReporter, transactor, urlProvider, config)
+	signingKeyRepository := database2.ProvideSigningKeyDao(db)
+	keyStore := signing.KeyStoreProvider(signingKeyRepository, encrypter)
+	debianRegistryHelper := debian.RegistryHelperProvider(fileManager, artifactRepository, keyStore, spaceFinder)
+	rubygemsRegistryHelper := rubygems.RegistryHelperProvider(fileManager, artifactRepository, spaceFinder)
+	condaRegistryHelper := conda.RegistryHelperProvider(fileManager, artifactRepository, spaceFinder)
+	packageWrapper := helpers.ProvidePackageWrapperProvider(interfacesRegistryHelper, registryFinder, registryHelper, debianRegistryHelper, rubygemsRegistryHelper, condaRegistryHelper)
 	v3 := router.ProvideUntaggedImagesEnabled()
 	deletionPackageWrapper := deletion.ProvidePackageWrapper(packageWrapper)
 	reporter12 := artifact.ProvideArtifactReporterValue(artifactReporter)
 	reindexingService := reindexing.ProvideReindexingService(asyncprocessingReporter, reporter12, packageWrapper)
-	deletionService := deletion.NewService(artifactRepository, imageRepository, manifestRepository, tagRepository, registryBlobRepository, fileManager, transactor, v3, deletionPackageWrapper, reindexingService, artifactReporter, provider)
-	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, blobRepository, genericBlobRepository, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, spaceFinder, transactor, accessor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service4, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, packageWrapper, cacheService, finder, v3, deletionService, storageService, app)
+	deletionService := deletion.NewService(artifactRepository, imageRepository, manifestRepository, tagRepository, registryBlobRepository, fileManager, transactor, v3, deletionPackageWrapper, reindexingService, artifactReporter, urlProvider)
+	replicationRuleRepository := database2.ProvideReplicationRuleDao(db)
+	replicationRunRepository := database2.ProvideReplicationRunDao(db)
+	replicationConfig := replication2.ProvideConfig(config)
+	readerFactory5, err := replication.ProvideArtifactReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	replicationArtifactReporter, err := replication.ProvideArtifactReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	replicationService, err := replication2.ProvideService(ctx, replicationConfig, transactor, readerFactory4, readerFactory5, replicationArtifactReporter, replicationRuleRepository, replicationRunRepository, registryRepository, tagRepository, principalStore, spaceFinder, authorizer, secretService, urlProvider, executor, jobScheduler)
+	if err != nil {
+		return nil, err
+	}
+	scanPolicyRepository := database2.ProvideScanPolicyDao(db)
+	artifactScanRepository := database2.ProvideArtifactScanDao(db)
+	cleanupConfig := cleanup.ProvideConfig(config)
+	cleanupService, err := cleanup.ProvideService(ctx, cleanupConfig, registryRepository, cleanupPolicyRepository, artifactRepository, tagRepository, spaceFinder, deletionService, executor, jobScheduler)
+	if err != nil {
+		return nil, err
+	}
+	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, blobRepository, genericBlobRepository, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, spaceFinder, transactor, accessor, authenticator, urlProvider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service4, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, packageWrapper, cacheService, finder, v3, deletionService, storageService, replicationRuleRepository, replicationRunRepository, replicationService, scanPolicyRepository, artifactScanRepository, cleanupService, app)
 	packageTagRepository := database2.ProvidePackageTagDao(db)
-	localBase := base.LocalBaseProvider(registryRepository, registryFinder, fileManager, transactor, imageRepository, artifactRepository, nodesRepository, packageTagRepository, authorizer, spaceFinder, auditService)
+	localBase := base.LocalBaseProvider(registryRepository, registryFinder, fileManager, transactor, imageRepository, artifactRepository, nodesRepository, packageTagRepository, authorizer, spaceFinder, auditService, scanPolicyRepository, asyncprocessingReporter)
 	mavenDBStore := maven.DBStoreProvider(registryRepository, imageRepository, artifactRepository, spaceStore, bandwidthStatRepository, downloadStatRepository, nodesRepository, upstreamProxyConfigRepository)
 	mavenLocalRegistry := maven.LocalRegistryProvider(localBase, mavenDBStore, transactor, fileManager, artifactReporter)
 	mavenController := maven.ProvideProxyController(mavenLocalRegistry, secretService, spaceFinder)
@@ -694,68 +770,85 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	mavenHandler := api2.NewMavenHandlerProvider(controller2, spaceStore, tokenStore, controller, authenticator, authorizer, spaceFinder, registryFinder, cacheService, auditService)
 	handler2 := router.MavenHandlerProvider(mavenHandler)
 	genericDBStore := generic.DBStoreProvider(imageRepository, artifactRepository, bandwidthStatRepository, downloadStatRepository, registryRepository)
-	genericLocalRegistry := generic2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
+	genericLocalRegistry := generic2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider)
 	localRegistryHelper := generic2.LocalRegistryHelperProvider(genericLocalRegistry, localBase)
-	proxy := generic2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, localRegistryHelper)
+	proxy := generic2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, localRegistryHelper)
 	genericController := generic.ControllerProvider(spaceStore, authorizer, fileManager, genericDBStore, transactor, spaceFinder, genericLocalRegistry, proxy, finder, dependencyFirewallChecker, auditService, packageWrapper)
-	packagesHandler := api2.NewPackageHandlerProvider(registryRepository, downloadStatRepository, bandwidthStatRepository, spaceStore, tokenStore, controller, authenticator, provider, authorizer, spaceFinder, registryFinder, fileManager, finder, packageWrapper, auditService, artifactRepository)
-	genericHandler := api2.NewGenericHandlerProvider(spaceStore, genericController, tokenStore, controller, authenticator, provider, authorizer, packagesHandler, spaceFinder, registryFinder, auditService)
+	packagesHandler := api2.NewPackageHandlerProvider(registryRepository, downloadStatRepository, bandwidthStatRepository, spaceStore, tokenStore, controller, authenticator, urlProvider, authorizer, spaceFinder, registryFinder, fileManager, finder, packageWrapper, auditService, artifactRepository)
+	genericHandler := api2.NewGenericHandlerProvider(spaceStore, genericController, tokenStore, controller, authenticator, urlProvider, authorizer, packagesHandler, spaceFinder, registryFinder, auditService)
 	handler3 := router.GenericHandlerProvider(genericHandler)
-	pythonLocalRegistry := python.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, registryFinder, imageRepository, artifactRepository, provider, artifactReporter)
+	pythonLocalRegistry := python.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, registryFinder, imageRepository, artifactRepository, urlProvider, artifactReporter)
 	pythonLocalRegistryHelper := python.LocalRegistryHelperProvider(pythonLocalRegistry, localBase)
-	pythonProxy := python.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, pythonLocalRegistryHelper)
-	pythonController := python2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, pythonLocalRegistry, pythonProxy, finder, dependencyFirewallChecker)
+	pythonProxy := python.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, pythonLocalRegistryHelper)
+	pythonController := python2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, pythonLocalRegistry, pythonProxy, finder, dependencyFirewallChecker)
 	pythonHandler := api2.NewPythonHandlerProvider(pythonController, packagesHandler)
-	nugetLocalRegistry := nuget.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, artifactReporter)
+	nugetLocalRegistry := nuget.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, artifactReporter)
 	nugetLocalRegistryHelper := nuget.LocalRegistryHelperProvider(nugetLocalRegistry, localBase)
-	nugetProxy := nuget.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, nugetLocalRegistryHelper)
-	nugetController := nuget2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, nugetLocalRegistry, nugetProxy, finder, dependencyFirewallChecker)
+	nugetProxy := nuget.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, nugetLocalRegistryHelper)
+	nugetController := nuget2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, nugetLocalRegistry, nugetProxy, finder, dependencyFirewallChecker)
 	nugetHandler := api2.NewNugetHandlerProvider(nugetController, packagesHandler)
-	npmLocalRegistry := npm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, packageTagRepository, registryRepository, imageRepository, artifactRepository, nodesRepository, provider, artifactReporter)
+	npmLocalRegistry := npm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, packageTagRepository, registryRepository, imageRepository, artifactRepository, nodesRepository, urlProvider, artifactReporter)
 	npmLocalRegistryHelper := npm.LocalRegistryHelperProvider(npmLocalRegistry, localBase)
-	npmProxy := npm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, npmLocalRegistryHelper)
-	npmController := npm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, downloadStatRepository, provider, npmLocalRegistry, npmProxy, finder, dependencyFirewallChecker)
+	npmProxy := npm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, npmLocalRegistryHelper)
+	npmController := npm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, downloadStatRepository, urlProvider, npmLocalRegistry, npmProxy, finder, dependencyFirewallChecker)
 	npmHandler := api2.NewNPMHandlerProvider(npmController, packagesHandler)
 	rpmRegistryHelper := rpm.RegistryHelperProvider(localBase, fileManager, asyncprocessingReporter)
-	rpmLocalRegistry := rpm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, rpmRegistryHelper)
-	rpmProxy := rpm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, rpmRegistryHelper, spaceFinder, secretService)
-	rpmController := rpm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, rpmLocalRegistry, rpmProxy, asyncprocessingReporter, dependencyFirewallChecker)
+	rpmLocalRegistry := rpm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, rpmRegistryHelper)
+	rpmProxy := rpm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, localBase, rpmRegistryHelper, spaceFinder, secretService)
+	rpmController := rpm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, rpmLocalRegistry, rpmProxy, asyncprocessingReporter, dependencyFirewallChecker)
 	rpmHandler := api2.NewRpmHandlerProvider(rpmController, packagesHandler)
-	cargoLocalRegistry := cargo2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, artifactReporter, asyncprocessingReporter)
+	cargoLocalRegistry := cargo2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, artifactReporter, asyncprocessingReporter)
 	cargoLocalRegistryHelper := cargo2.LocalRegistryHelperProvider(cargoLocalRegistry, localBase, asyncprocessingReporter)
-	cargoProxy := cargo2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, cargoLocalRegistryHelper, artifactReporter)
-	cargoController := cargo3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, provider, cargoLocalRegistry, cargoProxy, cacheService, spaceFinder, finder, dependencyFirewallChecker)
+	cargoProxy := cargo2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, cargoLocalRegistryHelper, artifactReporter)
+	cargoController := cargo3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, urlProvider, cargoLocalRegistry, cargoProxy, cacheService, spaceFinder, finder, dependencyFirewallChecker)
 	cargoHandler := api2.NewCargoHandlerProvider(cargoController, packagesHandler)
-	gopackageLocalRegistry := gopackage.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, artifactReporter, asyncprocessingReporter)
+	gopackageLocalRegistry := gopackage.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, artifactReporter, asyncprocessingReporter)
 	gopackageLocalRegistryHelper := gopackage.LocalRegistryHelperProvider(gopackageLocalRegistry, localBase, asyncprocessingReporter)
-	gopackageProxy := gopackage.ProxyProvider(localBase, upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, artifactReporter, gopackageLocalRegistryHelper)
-	gopackageController := gopackage2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, provider, gopackageLocalRegistry, gopackageProxy, finder, dependencyFirewallChecker)
+	gopackageProxy := gopackage.ProxyProvider(localBase, upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, artifactReporter, gopackageLocalRegistryHelper)
+	gopackageController := gopackage2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, urlProvider, gopackageLocalRegistry, gopackageProxy, finder, dependencyFirewallChecker)
 	gopackageHandler := api2.NewGoPackageHandlerProvider(gopackageController, packagesHandler)
-	huggingfaceLocalRegistry := huggingface.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
-	huggingfaceController := huggingface2.ProvideController(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, huggingfaceLocalRegistry, finder)
+	huggingfaceLocalRegistry := huggingface.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider)
+	huggingfaceController := huggingface2.ProvideController(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, huggingfaceLocalRegistry, finder)
 	huggingfaceHandler := huggingface3.ProvideHandler(huggingfaceController, packagesHandler)
-	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler, spaceFinder, cacheService)
+	registryHelper2 := debian2.RegistryHelperProvider(localBase, fileManager, asyncprocessingReporter)
+	debianLocalRegistry := debian2.LocalRegistryProvider(localBase, fileManager, transactor, registryRepository, artifactRepository, registryHelper2, debianRegistryHelper)
+	debianProxy := debian2.ProxyProvider(upstreamProxyConfigRepository, artifactRepository, localBase, registryHelper2, spaceFinder, secretService)
+	debianController := debian3.ControllerProvider(registryRepository, debianLocalRegistry, debianProxy, finder, dependencyFirewallChecker)
+	debianHandler := api2.NewDebianHandlerProvider(debianController, packagesHandler)
+	terraformLocalRegistry := terraform.LocalRegistryProvider(localBase, fileManager, artifactRepository, keyStore, urlProvider)
+	terraformController := terraform2.ControllerProvider(registryRepository, terraformLocalRegistry, finder, dependencyFirewallChecker)
+	terraformHandler := api2.NewTerraformHandlerProvider(terraformController, packagesHandler)
+	registryHelper3 := rubygems2.RegistryHelperProvider(localBase, fileManager, asyncprocessingReporter)
+	rubygemsLocalRegistry := rubygems2.LocalRegistryProvider(localBase, fileManager, artifactRepository, registryHelper3)
+	rubygemsProxy := rubygems2.ProxyProvider(upstreamProxyConfigRepository, fileManager, artifactRepository, registryHelper3, spaceFinder, secretService)
+	rubygemsController := rubygems3.ControllerProvider(registryRepository, rubygemsLocalRegistry, rubygemsProxy, finder, dependencyFirewallChecker)
+	rubygemsHandler := api2.NewRubyGemsHandlerProvider(rubygemsController, packagesHandler)
+	registryHelper4 := conda2.RegistryHelperProvider(localBase, fileManager, asyncprocessingReporter)
+	condaLocalRegistry := conda2.LocalRegistryProvider(localBase, fileManager, artifactRepository, registryHelper4)
+	condaProxy := conda2.ProxyProvider(upstreamProxyConfigRepository, fileManager, artifactRepository, registryHelper4, spaceFinder, secretService)
+	condaController := conda3.ControllerProvider(registryRepository, condaLocalRegistry, condaProxy, finder, dependencyFirewallChecker)
+	condaHandler := api2.NewCondaHandlerProvider(condaController, packagesHandler)
+	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler, debianHandler, terraformHandler, rubygemsHandler, condaHandler, spaceFinder, cacheService)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4)
-	readerFactory5, err := events3.ProvideReaderFactory(eventsSystem)
+	readerFactory6, err := events3.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory5)
+	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory6)
 	if err != nil {
 		return nil, err
 	}
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, urlProvider, openapiService, appRouter, sender, lfsController)
 	serverServer := server2.ProvideServer(config, routerRouter)
 	sshAuthService := publickey.ProvideSSHAuthService(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, sshAuthService, repoController, lfsController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter9)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	clientClient := manager.ProvideExecutionClient(executionManager, urlProvider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
-	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
+	runtimeRunner, err := runner.ProvideExecutionRunner(config, clientClient, resolverManager, executionManager)
 	if err != nil {
 		return nil, err
 	}
-	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	poller := runner.ProvideExecutionPoller(runtimeRunner, clientClient)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoFinder, pipelineStore, triggererTriggerer, readerFactory2, readerFactory3)
 	if err != nil {
@@ -765,15 +858,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory6, err := events2.ProvideReaderFactory(eventsSystem)
+	readerFactory7, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory7, err := events4.ProvideReaderFactory(eventsSystem)
+	readerFactory8, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory6, readerFactory5, readerFactory3, readerFactory7, publicaccessService, spaceFinder, repoFinder)
+	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory7, readerFactory6, readerFactory3, readerFactory8, publicaccessService, spaceFinder, repoFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -785,67 +878,70 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory5, repoStore, provider, gitInterface, lockerLocker)
+	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory6, repoStore, urlProvider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
-	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	config2 := server.ProvideCleanupConfig(config)
+	service5, err := cleanup2.ProvideService(config2, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, executionArtifactStore, blobStore)
 	if err != nil {
 		return nil, err
 	}
 	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
+	mailClient := notification.ProvideMailClient(mailerMailer)
+	chatConfig := server.ProvideNotificationChatConfig(config)
+	chatClient := notification.ProvideChatClient(chatConfig, settingsService)
+	notificationClient := notification.ProvideNotificationClient(mailClient, chatClient)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, readerFactory3, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, userGroupReviewerStore, usergroupService, pullReqActivityStore, spacePathStore, provider)
+	notificationDigestStore := database.ProvideNotificationDigestStore(db)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, readerFactory3, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, userGroupReviewerStore, usergroupService, pullReqActivityStore, spacePathStore, urlProvider, notificationPreferenceStore, notificationWatchStore, notificationDigestStore, executor, jobScheduler)
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
-	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory2, readerFactory5, repoStore, indexer)
+	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory2, readerFactory6, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
 	gitspaceeventConfig := server.ProvideGitspaceEventConfig(config)
-	readerFactory8, err := events8.ProvideReaderFactory(eventsSystem)
+	readerFactory9, err := events9.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory8, gitspaceEventStore)
+	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, gitspaceEventStore)
 	if err != nil {
 		return nil, err
 	}
 	gitspacedeleteeventConfig := server.ProvideGitspaceDeleteEventConfig(config)
-	readerFactory9, err := events11.ProvideReaderFactory(eventsSystem)
+	readerFactory10, err := events12.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory9, gitspaceService)
+	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory10, gitspaceService)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory10, err := events9.ProvideReaderFactory(eventsSystem)
+	readerFactory11, err := events10.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory10, orchestratorOrchestrator, gitspaceService, reporter5)
+	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, reporter6)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory11, err := events10.ProvideReaderFactory(eventsSystem)
+	readerFactory12, err := events11.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, reporter5)
+	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory12, orchestratorOrchestrator, gitspaceService, reporter6)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory12, err := events14.ProvideReaderFactory(eventsSystem)
+	readerFactory13, err := events14.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	aiTaskStore := database.ProvideAITaskStore(db)
-	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory12, orchestratorOrchestrator, gitspaceService, aiTaskStore)
+	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory13, orchestratorOrchestrator, gitspaceService, aiTaskStore)
 	if err != nil {
 		return nil, err
 	}
@@ -870,12 +966,17 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	rpmHelper := asyncprocessing2.ProvideRpmHelper(fileManager, artifactRepository, upstreamProxyConfigRepository, spaceFinder, secretService, registryRepository)
 	gopackageRegistryHelper := gopackage3.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder, registryFinder)
-	readerFactory13, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
+	readerFactory14, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	asyncprocessingConfig := asyncprocessing2.ProvideRegistryPostProcessingConfig(config)
-	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory13, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
+	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory14, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
+	if err != nil {
+		return nil, err
+	}
+	scanningConfig := scanning.ProvideConfig(config)
+	scanningService, err := scanning.ProvideService(scanningConfig, asyncprocessingService, transactor, scanPolicyRepository, artifactScanRepository, registryRepository, imageRepository, artifactRepository, nodesRepository, quarantineArtifactRepository, quarantineService, finder, fileManager, spaceFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -883,11 +984,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	languageAnalyzer, err := languageanalyzer.ProvideAnalyzer(ctx, config, readerFactory5, readerFactory2, transactor, repoStore, repoFinder, repoLangStore, gitInterface)
+	languageAnalyzer, err := languageanalyzer.ProvideAnalyzer(ctx, config, readerFactory6, readerFactory2, transactor, repoStore, repoFinder, repoLangStore, gitInterface)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(service3, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service4, branchService, repoactivityService, asyncprocessingService, jobRpmRegistryIndex, languageAnalyzer)
+	servicesServices := services.ProvideServices(service3, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, service5, approvalService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service4, branchService, repoactivityService, asyncprocessingService, scanningService, jobRpmRegistryIndex, languageAnalyzer, ldapSyncer)
 	listenAndServeServer := server.ProvideNoOpMetricServer()
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices, listenAndServeServer)
 	return serverSystem, nil
@@ -596,6 +596,50 @@ func GetLatestCommit(
 	return GetCommit(ctx, repoPath, commitSHA)
 }
 
+// ListUnreachableCommitSHAs lists the commits reachable from the provided commits
+// that aren't reachable from any existing reference, i.e. `git rev-list <shas> --not --all`.
+// It's intended to be used in the pre-receive hook, before the references are updated.
+func ListUnreachableCommitSHAs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commitSHAs []sha.SHA,
+) ([]sha.SHA, error) {
+	if len(commitSHAs) == 0 {
+		return nil, nil
+	}
+
+	cmd := command.New("rev-list",
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	for _, commitSHA := range commitSHAs {
+		cmd.Add(command.WithArg(commitSHA.String()))
+	}
+	cmd.Add(command.WithArg("--not", "--all"))
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list unreachable commits")
+	}
+
+	var result []sha.SHA
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		commitSHA, err := sha.New(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit sha: %w", err)
+		}
+		result = append(result, commitSHA)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan commit sha list: %w", err)
+	}
+
+	return result, nil
+}
+
 // GetCommitDivergences returns the count of the diverging commits for all branch pairs.
 // IMPORTANT: If a maxCount is provided it limits the overal count of diverging commits
 // (maxCount 10 could lead to (0, 10) while it's actually (2, 12)).
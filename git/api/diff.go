@@ -645,11 +645,20 @@ func (g *Git) DiffFileName(ctx context.Context,
 	headRef string,
 	mergeBase bool,
 	ignoreWhitespace bool,
+	noRenames bool,
+	alternates []string,
 ) ([]string, error) {
-	cmd := command.New("diff", command.WithFlag("--name-only"))
+	cmd := command.New("diff",
+		command.WithFlag("--name-only"),
+		command.WithAlternateObjectDirs(alternates...),
+	)
 	if mergeBase {
 		cmd.Add(command.WithFlag("--merge-base"))
 	}
+	if noRenames {
+		// Report renamed files as deleted and added, so both the old and the new path are listed.
+		cmd.Add(command.WithFlag("--no-renames"))
+	}
 	cmd.Add(command.WithArg(baseRef, headRef))
 
 	if ignoreWhitespace {
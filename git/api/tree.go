@@ -388,6 +388,15 @@ func ListTreeNodes(
 	return list, nil
 }
 
+// ListTreeNodesRecursive lists all the nodes of a tree reachable from ref via the specified path.
+func (g *Git) ListTreeNodesRecursive(
+	ctx context.Context,
+	repoPath, rev, treePath string,
+	flattenDirectories bool,
+) ([]TreeNode, error) {
+	return ListTreeNodesRecursive(ctx, repoPath, rev, treePath, false, flattenDirectories)
+}
+
 func ListTreeNodesRecursive(
 	ctx context.Context,
 	repoPath, rev, treePath string,
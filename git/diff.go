@@ -38,6 +38,8 @@ type DiffParams struct {
 	MergeBase        bool
 	IncludePatch     bool
 	IgnoreWhitespace bool
+	// NoRenames disables rename detection (used only by DiffFileNames).
+	NoRenames bool
 }
 
 func (p DiffParams) Validate() error {
@@ -446,6 +448,8 @@ func (s *Service) DiffFileNames(ctx context.Context, params *DiffParams) (DiffFi
 		params.HeadRef,
 		params.MergeBase,
 		params.IgnoreWhitespace,
+		params.NoRenames,
+		params.AlternateObjectDirs,
 	)
 	if err != nil {
 		return DiffFileNamesOutput{}, fmt.Errorf("failed to get diff file data between '%s' and '%s': %w",
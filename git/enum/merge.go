@@ -26,6 +26,8 @@ const (
 	MergeMethodRebase MergeMethod = "rebase"
 	// MergeMethodFastForward fast-forward merging.
 	MergeMethodFastForward MergeMethod = "fast-forward"
+	// MergeMethodSemiLinear rebase before merging and then create merge commit.
+	MergeMethodSemiLinear MergeMethod = "semi-linear"
 )
 
 var MergeMethods = []MergeMethod{
@@ -33,11 +35,12 @@ var MergeMethods = []MergeMethod{
 	MergeMethodSquash,
 	MergeMethodRebase,
 	MergeMethodFastForward,
+	MergeMethodSemiLinear,
 }
 
 func (m MergeMethod) Sanitize() (MergeMethod, bool) {
 	switch m {
-	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase, MergeMethodFastForward:
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase, MergeMethodFastForward, MergeMethodSemiLinear:
 		return m, true
 	default:
 		return MergeMethodMerge, false
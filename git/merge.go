@@ -69,6 +69,11 @@ type MergeParams struct {
 	DeleteHeadBranch bool
 
 	Method enum.MergeMethod
+
+	// RebaseUpstreamSHA limits the commits replayed by the rebase merge method to the commits reachable
+	// from the head, but not from the RebaseUpstreamSHA - the same as "git rebase --onto <base> <upstream>".
+	// (optional, default: the commits since the merge base of the base and the head are replayed)
+	RebaseUpstreamSHA sha.SHA
 }
 
 type RefUpdate struct {
@@ -103,6 +108,10 @@ func (p *MergeParams) Validate() error {
 		}
 	}
 
+	if !p.RebaseUpstreamSHA.IsEmpty() && p.Method != enum.MergeMethodRebase {
+		return errors.InvalidArgument("rebase upstream is supported only by the rebase merge method")
+	}
+
 	return nil
 }
 
@@ -166,6 +175,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		mergeFunc = merge.Rebase
 	case enum.MergeMethodFastForward:
 		mergeFunc = merge.FastForward
+	case enum.MergeMethodSemiLinear:
+		mergeFunc = merge.SemiLinear
 	default:
 		// should not happen, the call to Sanitize above should handle this case.
 		panic(fmt.Sprintf("unsupported merge method: %q", mergeMethod))
@@ -207,6 +218,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	// the merge functions use the merge base as the boundary of the source commits
+	sourceBaseSHA := mergeBaseCommitSHA
+	if !params.RebaseUpstreamSHA.IsEmpty() {
+		sourceBaseSHA = params.RebaseUpstreamSHA
+	}
+
 	// find short stat and number of commits
 
 	shortStat, err := s.git.DiffShortStat(
@@ -259,7 +276,13 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	var mergeCommitSHA sha.SHA
 	var conflicts []string
 
+	commitSigner := s.commitSigner
+
 	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(s *sharedrepo.SharedRepo) error {
+		if commitSigner != nil {
+			s.SetCommitSigner(commitSigner)
+		}
+
 		message := parser.CleanUpWhitespace(params.Message)
 
 		mergeCommitSHA, conflicts, err = mergeFunc(
@@ -269,7 +292,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 				Author:       &author,
 				Committer:    &committer,
 				Message:      message,
-				MergeBaseSHA: mergeBaseCommitSHA,
+				MergeBaseSHA: sourceBaseSHA,
 				TargetSHA:    baseCommitSHA,
 				SourceSHA:    headCommitSHA,
 			})
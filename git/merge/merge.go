@@ -174,6 +174,44 @@ func Rebase(
 	return mergeSHA, nil, nil
 }
 
+// SemiLinear merges two the commits (targetSHA and sourceSHA) using the Semi-linear method.
+// The source commits are first rebased on top of the target commit (see Rebase)
+// and then the rebased commits are merged into the target with a merge commit (see Merge).
+// Commit author is used only for the merge commit - rebased commits preserve their authors.
+func SemiLinear(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	params Params,
+) (mergeSHA sha.SHA, conflicts []string, err error) {
+	targetSHA := params.TargetSHA
+
+	rebasedSHA, conflicts, err := Rebase(ctx, s, params)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to rebase in semi-linear merge: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return sha.None, conflicts, nil
+	}
+
+	// All commits were dropped during the rebase, so there's nothing to merge.
+	if rebasedSHA.Equal(targetSHA) {
+		return targetSHA, nil, nil
+	}
+
+	treeSHA, err := s.GetTreeSHA(ctx, rebasedSHA.String())
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to get tree sha of rebased commits: %w", err)
+	}
+
+	mergeSHA, err = s.CommitTree(ctx, params.Author, params.Committer, treeSHA, params.Message, false,
+		targetSHA, rebasedSHA)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("commit tree failed in semi-linear merge: %w", err)
+	}
+
+	return mergeSHA, nil, nil
+}
+
 // FastForward points the is internal implementation of merge used for Merge and Squash methods.
 // Commit author and committer aren't used here. Commit message isn't used here.
 func FastForward(
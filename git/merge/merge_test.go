@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemiLinear(t *testing.T) {
+	ctx := context.Background()
+
+	requireMergeTreeMergeBase(ctx, t)
+
+	sourceRepoPath := t.TempDir()
+	require.NoError(t, command.New("init", command.WithFlag("--bare")).Run(ctx, command.WithDir(sourceRepoPath)))
+
+	s, err := sharedrepo.NewSharedRepo(t.TempDir(), sourceRepoPath)
+	require.NoError(t, err)
+	defer s.Close(ctx)
+	require.NoError(t, s.Init(ctx))
+
+	alice := newSignature("Alice", "alice@example.com", 1)
+	bob := newSignature("Bob", "bob@example.com", 2)
+	author := newSignature("Merger", "merger@example.com", 3)
+	committer := newSignature("Gitness", "system@example.com", 4)
+
+	baseSHA := writeCommit(ctx, t, s, sha.None, "base.txt", alice, "base")
+	targetSHA := writeCommit(ctx, t, s, baseSHA, "target.txt", alice, "target")
+	source1SHA := writeCommit(ctx, t, s, baseSHA, "source1.txt", alice, "source 1")
+	source2SHA := writeCommit(ctx, t, s, source1SHA, "source2.txt", bob, "source 2")
+
+	mergeSHA, conflicts, err := SemiLinear(ctx, s, Params{
+		Author:       author,
+		Committer:    committer,
+		Message:      "merge source",
+		MergeBaseSHA: baseSHA,
+		TargetSHA:    targetSHA,
+		SourceSHA:    source2SHA,
+	})
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	// the merge commit joins the target with the rebased source commits
+	mergeCommit := getCommit(ctx, t, s, mergeSHA)
+	require.Len(t, mergeCommit.ParentSHAs, 2)
+	assert.Equal(t, targetSHA, mergeCommit.ParentSHAs[0])
+	assert.Equal(t, "merge source", mergeCommit.Title)
+	assert.Equal(t, author.Identity, mergeCommit.Author.Identity)
+	assert.Equal(t, committer.Identity, mergeCommit.Committer.Identity)
+
+	// the rebased source commits form a linear history on top of the target
+	rebased2 := getCommit(ctx, t, s, mergeCommit.ParentSHAs[1])
+	require.Len(t, rebased2.ParentSHAs, 1)
+	assert.NotEqual(t, source2SHA, rebased2.SHA)
+	assert.Equal(t, "source 2", rebased2.Title)
+	assert.Equal(t, bob.Identity, rebased2.Author.Identity)
+	assert.Equal(t, committer.Identity, rebased2.Committer.Identity)
+
+	rebased1 := getCommit(ctx, t, s, rebased2.ParentSHAs[0])
+	require.Len(t, rebased1.ParentSHAs, 1)
+	assert.Equal(t, targetSHA, rebased1.ParentSHAs[0])
+	assert.Equal(t, "source 1", rebased1.Title)
+	assert.Equal(t, alice.Identity, rebased1.Author.Identity)
+	assert.Equal(t, committer.Identity, rebased1.Committer.Identity)
+
+	// the merge commit has the same content as the tip of the rebased commits
+	assert.Equal(t, rebased2.TreeSHA, mergeCommit.TreeSHA)
+	for _, path := range []string{"base.txt", "target.txt", "source1.txt", "source2.txt"} {
+		err := s.ShowFile(ctx, path, mergeSHA.String(), io.Discard)
+		assert.NoError(t, err, "file %s is missing in the merge commit", path)
+	}
+}
+
+// TestRebase_SquashMergedParent rebases a branch stacked on a squash merged parent branch.
+// Only the commits of the stacked branch must be replayed, the parent's commits are already in the target.
+func TestRebase_SquashMergedParent(t *testing.T) {
+	ctx := context.Background()
+
+	requireMergeTreeMergeBase(ctx, t)
+
+	sourceRepoPath := t.TempDir()
+	require.NoError(t, command.New("init", command.WithFlag("--bare")).Run(ctx, command.WithDir(sourceRepoPath)))
+
+	s, err := sharedrepo.NewSharedRepo(t.TempDir(), sourceRepoPath)
+	require.NoError(t, err)
+	defer s.Close(ctx)
+	require.NoError(t, s.Init(ctx))
+
+	alice := newSignature("Alice", "alice@example.com", 1)
+	committer := newSignature("Gitness", "system@example.com", 2)
+
+	baseSHA := writeCommit(ctx, t, s, sha.None, "base.txt", alice, "base")
+	parent1SHA := writeCommit(ctx, t, s, baseSHA, "parent.txt", alice, "parent 1")
+	parent2SHA := writeCommit(ctx, t, s, parent1SHA, "parent.txt", alice, "parent 2")
+	childSHA := writeCommit(ctx, t, s, parent2SHA, "child.txt", alice, "child")
+
+	// the parent branch is squash merged, and the file is changed once more on the target branch afterwards
+	squashSHA := writeCommit(ctx, t, s, baseSHA, "parent.txt", alice, "parent 2")
+	targetSHA := writeCommit(ctx, t, s, squashSHA, "parent.txt", alice, "target")
+
+	// replaying all commits since the merge base replays the parent's commits, which conflict with the target
+	_, conflicts, err := Rebase(ctx, s, Params{
+		Committer:    committer,
+		MergeBaseSHA: baseSHA,
+		TargetSHA:    targetSHA,
+		SourceSHA:    childSHA,
+	})
+	require.NoError(t, err)
+	require.Contains(t, conflicts, "parent.txt")
+
+	// replaying only the commits that aren't reachable from the parent's source commit succeeds
+	rebasedSHA, conflicts, err := Rebase(ctx, s, Params{
+		Committer:    committer,
+		MergeBaseSHA: parent2SHA,
+		TargetSHA:    targetSHA,
+		SourceSHA:    childSHA,
+	})
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	rebased := getCommit(ctx, t, s, rebasedSHA)
+	require.Len(t, rebased.ParentSHAs, 1)
+	assert.Equal(t, targetSHA, rebased.ParentSHAs[0])
+	assert.Equal(t, "child", rebased.Title)
+
+	content := &bytes.Buffer{}
+	require.NoError(t, s.ShowFile(ctx, "parent.txt", rebasedSHA.String(), content))
+	assert.Equal(t, "target", content.String())
+	require.NoError(t, s.ShowFile(ctx, "child.txt", rebasedSHA.String(), io.Discard))
+}
+
+// requireMergeTreeMergeBase skips the test if the git binary doesn't support "merge-tree --merge-base" (git < 2.40).
+func requireMergeTreeMergeBase(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available on PATH")
+	}
+
+	// "git merge-tree -h" prints the usage and exits with code 129
+	usage, _ := exec.CommandContext(ctx, "git", "merge-tree", "-h").CombinedOutput()
+	if !bytes.Contains(usage, []byte("--merge-base")) {
+		t.Skip("git binary doesn't support merge-tree --merge-base")
+	}
+}
+
+func newSignature(name, email string, hour int) *api.Signature {
+	return &api.Signature{
+		Identity: api.Identity{Name: name, Email: email},
+		When:     time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC),
+	}
+}
+
+// writeCommit creates a commit that adds (or overwrites) a single file on top of the parent commit.
+func writeCommit(
+	ctx context.Context,
+	t *testing.T,
+	s *sharedrepo.SharedRepo,
+	parentSHA sha.SHA,
+	filePath string,
+	sig *api.Signature,
+	message string,
+) sha.SHA {
+	t.Helper()
+
+	var parents []sha.SHA
+	if parentSHA.IsEmpty() {
+		require.NoError(t, s.ClearIndex(ctx))
+	} else {
+		require.NoError(t, s.SetIndex(ctx, parentSHA))
+		parents = append(parents, parentSHA)
+	}
+
+	objectSHA, err := s.WriteGitObject(ctx, bytes.NewReader([]byte(message)))
+	require.NoError(t, err)
+	require.NoError(t, s.AddObjectToIndex(ctx, "100644", objectSHA, filePath))
+
+	treeSHA, err := s.WriteTree(ctx)
+	require.NoError(t, err)
+
+	commitSHA, err := s.CommitTree(ctx, sig, sig, treeSHA, message, false, parents...)
+	require.NoError(t, err)
+
+	return commitSHA
+}
+
+func getCommit(ctx context.Context, t *testing.T, s *sharedrepo.SharedRepo, commitSHA sha.SHA) *api.Commit {
+	t.Helper()
+
+	commit, err := api.GetCommit(ctx, s.Directory(), commitSHA)
+	require.NoError(t, err)
+
+	return commit
+}
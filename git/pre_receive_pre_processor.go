@@ -99,17 +99,30 @@ type FindLFSPointersOutput struct {
 	Total    int64
 }
 
+// FindNewCommitsParams requests all commits introduced by the push, e.g. for signature verification.
+// A commit is new if it's reachable from one of the new reference SHAs, but not from any existing reference.
+// This includes commits that already exist in the repository but aren't referenced, e.g. of a deleted branch.
+type FindNewCommitsParams struct {
+	NewSHAs []sha.SHA
+}
+
+type FindNewCommitsOutput struct {
+	Commits []Commit
+}
+
 type ProcessPreReceiveObjectsParams struct {
 	ReadParams
 	FindOversizeFilesParams     *FindOversizeFilesParams
 	FindCommitterMismatchParams *FindCommitterMismatchParams
 	FindLFSPointersParams       *FindLFSPointersParams
+	FindNewCommitsParams        *FindNewCommitsParams
 }
 
 type ProcessPreReceiveObjectsOutput struct {
 	FindOversizeFilesOutput     *FindOversizeFilesOutput
 	FindCommitterMismatchOutput *FindCommitterMismatchOutput
 	FindLFSPointersOutput       *FindLFSPointersOutput
+	FindNewCommitsOutput        *FindNewCommitsOutput
 }
 
 func (s *Service) ProcessPreReceiveObjects(
@@ -117,7 +130,7 @@ func (s *Service) ProcessPreReceiveObjects(
 	params ProcessPreReceiveObjectsParams,
 ) (ProcessPreReceiveObjectsOutput, error) {
 	if params.FindOversizeFilesParams == nil && params.FindCommitterMismatchParams == nil &&
-		params.FindLFSPointersParams == nil {
+		params.FindLFSPointersParams == nil && params.FindNewCommitsParams == nil {
 		return ProcessPreReceiveObjectsOutput{}, nil
 	}
 
@@ -173,6 +186,21 @@ func (s *Service) ProcessPreReceiveObjects(
 
 		output.FindLFSPointersOutput = out
 	}
+
+	if params.FindNewCommitsParams != nil {
+		out, err := findNewCommits(
+			ctx,
+			repoPath,
+			params.ReadParams.AlternateObjectDirs,
+			params.FindNewCommitsParams,
+		)
+		if err != nil {
+			return ProcessPreReceiveObjectsOutput{}, err
+		}
+
+		output.FindNewCommitsOutput = out
+	}
+
 	return output, nil
 }
 
@@ -309,6 +337,41 @@ func findCommitterMismatch(
 	}, nil
 }
 
+func findNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	params *FindNewCommitsParams,
+) (*FindNewCommitsOutput, error) {
+	commitSHAs, err := api.ListUnreachableCommitSHAs(ctx, repoPath, alternateObjectDirs, params.NewSHAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	if len(commitSHAs) == 0 {
+		return &FindNewCommitsOutput{}, nil
+	}
+
+	gitCommits, err := api.CatFileCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new commits: %w", err)
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(&gitCommits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+
+		commits[i] = *commit
+	}
+
+	return &FindNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
 func (s *Service) findLFSPointers(
 	ctx context.Context,
 	objects []parser.BatchCheckObject,
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFindNewCommits verifies that the new commits are computed from the reference updates,
+// so commits that already exist in the repository, but aren't referenced, are reported as new too.
+func TestFindNewCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available on PATH")
+	}
+
+	ctx := context.Background()
+	repoPath := t.TempDir()
+
+	runGit(t, repoPath, "init", "--initial-branch=main")
+	base := commitEmpty(t, repoPath, "base")
+
+	// The unsigned commit of a deleted branch remains in the object database, outside the push quarantine.
+	runGit(t, repoPath, "checkout", "-b", "feature")
+	unreferenced := commitEmpty(t, repoPath, "unreferenced")
+	runGit(t, repoPath, "checkout", "main")
+	runGit(t, repoPath, "branch", "-D", "feature")
+
+	// A commit created on top of the unreferenced commit, e.g. the one that's being pushed.
+	pushed := sha.Must(runGit(t, repoPath,
+		"commit-tree", "-p", unreferenced.String(), "-m", "pushed", unreferenced.String()+"^{tree}"))
+
+	tests := []struct {
+		name    string
+		newSHAs []sha.SHA
+		expect  []sha.SHA
+	}{
+		{
+			name:    "no reference updates",
+			newSHAs: nil,
+			expect:  nil,
+		},
+		{
+			name:    "existing referenced commit",
+			newSHAs: []sha.SHA{base},
+			expect:  nil,
+		},
+		{
+			name:    "existing unreferenced commit",
+			newSHAs: []sha.SHA{unreferenced},
+			expect:  []sha.SHA{unreferenced},
+		},
+		{
+			name:    "new commit on top of unreferenced commit",
+			newSHAs: []sha.SHA{pushed, base},
+			expect:  []sha.SHA{pushed, unreferenced},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := findNewCommits(ctx, repoPath, nil, &FindNewCommitsParams{NewSHAs: test.newSHAs})
+			require.NoError(t, err)
+
+			var commitSHAs []sha.SHA
+			for _, commit := range out.Commits {
+				commitSHAs = append(commitSHAs, commit.SHA)
+			}
+
+			assert.ElementsMatch(t, test.expect, commitSHAs)
+		})
+	}
+}
+
+func commitEmpty(t *testing.T, repoPath, message string) sha.SHA {
+	t.Helper()
+
+	runGit(t, repoPath, "commit", "--allow-empty", "--no-gpg-sign", "-m", message)
+
+	return sha.Must(runGit(t, repoPath, "rev-parse", "HEAD"))
+}
+
+func runGit(t *testing.T, repoPath string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	cmd.Env = append(cmd.Environ(),
+		"GIT_AUTHOR_NAME=Alice", "GIT_AUTHOR_EMAIL=alice@example.com",
+		"GIT_COMMITTER_NAME=Alice", "GIT_COMMITTER_EMAIL=alice@example.com",
+	)
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+
+	return strings.TrimSpace(string(output))
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/storage"
 	"github.com/harness/gitness/git/types"
 )
@@ -39,6 +40,7 @@ type Service struct {
 	store             storage.Store
 	gitHookPath       string
 	reposGraveyard    string
+	commitSigner      signing.Signer
 }
 
 func New(
@@ -65,6 +67,19 @@ func New(
 		return nil, err
 	}
 
+	var commitSigner signing.Signer
+	if config.CommitSigningKeyPath != "" {
+		key, err := os.ReadFile(config.CommitSigningKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit signing key: %w", err)
+		}
+
+		commitSigner, err = signing.NewSigner(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create commit signer: %w", err)
+		}
+	}
+
 	return &Service{
 		reposRoot:         reposRoot,
 		sharedRepoRoot:    sharedRepoDir,
@@ -73,6 +88,7 @@ func New(
 		hookClientFactory: hookClientFactory,
 		store:             storage,
 		gitHookPath:       config.HookPath,
+		commitSigner:      commitSigner,
 	}, nil
 }
 
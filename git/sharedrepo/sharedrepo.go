@@ -35,6 +35,7 @@ import (
 	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/tempdir"
 
 	"github.com/rs/zerolog/log"
@@ -44,6 +45,7 @@ import (
 type SharedRepo struct {
 	repoPath       string
 	sourceRepoPath string
+	commitSigner   signing.Signer
 }
 
 // NewSharedRepo creates a new temporary bare repository.
@@ -409,7 +411,75 @@ func (r *SharedRepo) CommitTree(
 		return sha.None, fmt.Errorf("failed to commit-tree in shared repo: %w", err)
 	}
 
-	return sha.New(stdout.String())
+	commitSHA, err := sha.New(stdout.String())
+	if err != nil {
+		return sha.None, err
+	}
+
+	if r.commitSigner != nil {
+		return r.signCommit(ctx, commitSHA)
+	}
+
+	return commitSHA, nil
+}
+
+// SetCommitSigner sets the signer used to sign all commits created with CommitTree.
+func (r *SharedRepo) SetCommitSigner(signer signing.Signer) {
+	r.commitSigner = signer
+}
+
+// signCommit adds the signature header to the commit object and returns the SHA of the signed commit.
+func (r *SharedRepo) signCommit(ctx context.Context, commitSHA sha.SHA) (sha.SHA, error) {
+	cmd := command.New("cat-file",
+		command.WithArg("commit"),
+		command.WithArg(commitSHA.String()),
+	)
+
+	stdout := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdout(stdout))
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to read commit object in shared repo: %w", err)
+	}
+
+	raw := stdout.Bytes()
+
+	// the signature header goes after all other headers, the headers end with an empty line.
+	headersEnd := bytes.Index(raw, []byte("\n\n"))
+	if headersEnd < 0 {
+		return sha.None, fmt.Errorf("commit object %s has no message separator", commitSHA)
+	}
+
+	signature, err := r.commitSigner.Sign(raw)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to sign commit: %w", err)
+	}
+
+	signed := bytes.NewBuffer(make([]byte, 0, len(raw)+len(signature)+64))
+	signed.Write(raw[:headersEnd+1])
+	signed.WriteString("gpgsig ")
+	signed.WriteString(strings.ReplaceAll(strings.TrimSuffix(string(signature), "\n"), "\n", "\n "))
+	signed.WriteByte('\n')
+	signed.Write(raw[headersEnd+1:])
+
+	cmd = command.New("hash-object",
+		command.WithFlag("-t", "commit"),
+		command.WithFlag("-w"),
+		command.WithFlag("--stdin"))
+
+	signedSHA := bytes.NewBuffer(nil)
+
+	err = cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdin(signed),
+		command.WithStdout(signedSHA))
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to write signed commit in shared repo: %w", err)
+	}
+
+	return sha.New(signedSHA.String())
 }
 
 // CommitSHAsForRebase returns list of SHAs of the commits between the two git revisions
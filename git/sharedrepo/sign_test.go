@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedrepo
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSignature = "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\nAAAAAQ==\n-----END SSH SIGNATURE-----\n"
+
+type testSigner struct {
+	signed []byte
+}
+
+func (s *testSigner) Sign(data []byte) ([]byte, error) {
+	s.signed = bytes.Clone(data)
+	return []byte(testSignature), nil
+}
+
+func TestCommitTree_Signed(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available on PATH")
+	}
+
+	ctx := context.Background()
+
+	sourceRepoPath := t.TempDir()
+	require.NoError(t, command.New("init", command.WithFlag("--bare")).Run(ctx, command.WithDir(sourceRepoPath)))
+
+	r, err := NewSharedRepo(t.TempDir(), sourceRepoPath)
+	require.NoError(t, err)
+	defer r.Close(ctx)
+
+	require.NoError(t, r.Init(ctx))
+
+	// create the empty tree
+	stdout := &bytes.Buffer{}
+	require.NoError(t, command.New("mktree").Run(ctx,
+		command.WithDir(r.Directory()), command.WithStdin(&bytes.Buffer{}), command.WithStdout(stdout)))
+	treeSHA, err := sha.New(stdout.String())
+	require.NoError(t, err)
+
+	signer := &testSigner{}
+	r.SetCommitSigner(signer)
+
+	sig := &api.Signature{
+		Identity: api.Identity{Name: "Jane", Email: "jane@example.com"},
+		When:     time.Unix(1700000000, 0).UTC(),
+	}
+
+	commitSHA, err := r.CommitTree(ctx, sig, sig, treeSHA, "title\n\nbody\n", false)
+	require.NoError(t, err)
+
+	stdout = &bytes.Buffer{}
+	require.NoError(t, command.New("cat-file", command.WithArg("commit"), command.WithArg(commitSHA.String())).
+		Run(ctx, command.WithDir(r.Directory()), command.WithStdout(stdout)))
+
+	raw, err := parser.Object(stdout.Bytes())
+	require.NoError(t, err)
+
+	require.Equal(t, "SSH SIGNATURE", raw.SignatureType)
+	require.Equal(t, testSignature, string(raw.Signature))
+	require.Equal(t, string(signer.signed), string(raw.SignedContent))
+	require.Equal(t, "title\n\nbody\n", raw.Message)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Signer creates detached ASCII-armored signatures of git objects.
+type Signer interface {
+	// Sign returns the ASCII-armored signature of the provided raw git object.
+	Sign(data []byte) ([]byte, error)
+}
+
+const pgpPrivateKeyBlockType = "PGP PRIVATE KEY BLOCK"
+
+// NewSigner creates a Signer from an unencrypted private key.
+// Supported are OpenSSH (or PEM encoded) private keys and ASCII-armored PGP private keys.
+func NewSigner(key []byte) (Signer, error) {
+	if bytes.Contains(key, []byte("-----BEGIN "+pgpPrivateKeyBlockType+"-----")) {
+		return newPGPSigner(key)
+	}
+
+	return newSSHSigner(key)
+}
+
+const (
+	sshMagicPreamble    = "SSHSIG"
+	sshSignatureVersion = 1
+	sshNamespace        = "git"
+	sshHashAlgorithm    = "sha512"
+	sshArmorType        = "SSH SIGNATURE"
+	sshArmorLineLength  = 70
+)
+
+// sshSigner signs git objects using the SSH signature format.
+// https://github.com/openssh/openssh-portable/blob/V_9_9_P2/PROTOCOL.sshsig
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+func newSSHSigner(key []byte) (*sshSigner, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, errors.New("encrypted SSH private keys are not supported")
+		}
+		return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+	}
+
+	return &sshSigner{signer: signer}, nil
+}
+
+func (s *sshSigner) Sign(data []byte) ([]byte, error) {
+	hash := sha512.Sum512(data)
+
+	signedData := ssh.Marshal(struct {
+		MagicPreamble [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		MagicPreamble: [6]byte([]byte(sshMagicPreamble)),
+		Namespace:     sshNamespace,
+		HashAlgorithm: sshHashAlgorithm,
+		Hash:          hash[:],
+	})
+
+	var (
+		signature *ssh.Signature
+		err       error
+	)
+
+	// RSA keys must not use the SHA-1 based "ssh-rsa" signature algorithm.
+	if algSigner, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = algSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign data with SSH key: %w", err)
+	}
+
+	blob := ssh.Marshal(struct {
+		MagicPreamble [6]byte
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{
+		MagicPreamble: [6]byte([]byte(sshMagicPreamble)),
+		Version:       sshSignatureVersion,
+		PublicKey:     s.signer.PublicKey().Marshal(),
+		Namespace:     sshNamespace,
+		HashAlgorithm: sshHashAlgorithm,
+		Signature:     ssh.Marshal(signature),
+	})
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("-----BEGIN " + sshArmorType + "-----\n")
+	for len(encoded) > sshArmorLineLength {
+		buf.WriteString(encoded[:sshArmorLineLength])
+		buf.WriteByte('\n')
+		encoded = encoded[sshArmorLineLength:]
+	}
+	buf.WriteString(encoded)
+	buf.WriteString("\n-----END " + sshArmorType + "-----\n")
+
+	return buf.Bytes(), nil
+}
+
+// pgpSigner signs git objects using a detached OpenPGP signature.
+type pgpSigner struct {
+	entity *openpgp.Entity
+}
+
+func newPGPSigner(key []byte) (*pgpSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PGP private key: %w", err)
+	}
+
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			continue
+		}
+		if entity.PrivateKey.Encrypted {
+			return nil, errors.New("encrypted PGP private keys are not supported")
+		}
+
+		return &pgpSigner{entity: entity}, nil
+	}
+
+	return nil, errors.New("no PGP private key found")
+}
+
+func (s *pgpSigner) Sign(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(buf, s.entity, bytes.NewReader(data), nil); err != nil {
+		return nil, fmt.Errorf("failed to sign data with PGP key: %w", err)
+	}
+
+	// git expects the signature to end with a new line
+	signature := strings.TrimRight(buf.String(), "\n") + "\n"
+
+	return []byte(signature), nil
+}
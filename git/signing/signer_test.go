@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testCommit = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author Jane <jane@example.com> 1700000000 +0000\n" +
+	"committer Jane <jane@example.com> 1700000000 +0000\n\nmessage\n"
+
+func TestSSHSigner(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     any
+		sigAlgo string
+	}{
+		{name: "ed25519", key: edKey, sigAlgo: ssh.KeyAlgoED25519},
+		{name: "rsa", key: rsaKey, sigAlgo: ssh.KeyAlgoRSASHA512},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block, err := ssh.MarshalPrivateKey(test.key, "")
+			require.NoError(t, err)
+
+			signer, err := NewSigner(pem.EncodeToMemory(block))
+			require.NoError(t, err)
+
+			armored, err := signer.Sign([]byte(testCommit))
+			require.NoError(t, err)
+
+			sigBlock, _ := pem.Decode(armored)
+			require.NotNil(t, sigBlock)
+			require.Equal(t, sshArmorType, sigBlock.Type)
+
+			var blob struct {
+				MagicPreamble [6]byte
+				Version       uint32
+				PublicKey     []byte
+				Namespace     string
+				Reserved      string
+				HashAlgorithm string
+				Signature     []byte
+			}
+			require.NoError(t, ssh.Unmarshal(sigBlock.Bytes, &blob))
+			require.Equal(t, sshMagicPreamble, string(blob.MagicPreamble[:]))
+			require.Equal(t, sshNamespace, blob.Namespace)
+
+			publicKey, err := ssh.ParsePublicKey(blob.PublicKey)
+			require.NoError(t, err)
+
+			var signature ssh.Signature
+			require.NoError(t, ssh.Unmarshal(blob.Signature, &signature))
+			require.Equal(t, test.sigAlgo, signature.Format)
+
+			hash := sha512.Sum512([]byte(testCommit))
+			signedData := ssh.Marshal(struct {
+				MagicPreamble [6]byte
+				Namespace     string
+				Reserved      string
+				HashAlgorithm string
+				Hash          []byte
+			}{
+				MagicPreamble: blob.MagicPreamble,
+				Namespace:     blob.Namespace,
+				HashAlgorithm: blob.HashAlgorithm,
+				Hash:          hash[:],
+			})
+			require.NoError(t, publicKey.Verify(signedData, &signature))
+		})
+	}
+}
+
+func TestPGPSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("Jane", "", "jane@example.com", nil)
+	require.NoError(t, err)
+
+	keyBuf := &bytes.Buffer{}
+	w, err := armor.Encode(keyBuf, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+
+	signer, err := NewSigner(keyBuf.Bytes())
+	require.NoError(t, err)
+
+	armored, err := signer.Sign([]byte(testCommit))
+	require.NoError(t, err)
+	require.True(t, bytes.HasSuffix(armored, []byte("-----END PGP SIGNATURE-----\n")))
+
+	_, err = openpgp.CheckArmoredDetachedSignature(
+		openpgp.EntityList{entity}, bytes.NewReader([]byte(testCommit)), bytes.NewReader(armored), nil)
+	require.NoError(t, err)
+}
+
+func TestNewSigner_InvalidKey(t *testing.T) {
+	_, err := NewSigner([]byte("not a key"))
+	require.Error(t, err)
+}
@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/git/api"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -55,6 +57,8 @@ type ListTreeNodeParams struct {
 	GitREF             string
 	Path               string
 	FlattenDirectories bool
+	// Recursive lists all nodes of the subtree, not only the direct children.
+	Recursive bool
 }
 
 type ListTreeNodeOutput struct {
@@ -126,7 +130,13 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
-	res, err := s.git.ListTreeNodes(ctx, repoPath, params.GitREF, params.Path, params.FlattenDirectories)
+	var res []api.TreeNode
+	var err error
+	if params.Recursive {
+		res, err = s.git.ListTreeNodesRecursive(ctx, repoPath, params.GitREF, params.Path, params.FlattenDirectories)
+	} else {
+		res, err = s.git.ListTreeNodes(ctx, repoPath, params.GitREF, params.Path, params.FlattenDirectories)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
 	}
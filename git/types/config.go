@@ -31,6 +31,9 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// CommitSigningKeyPath (optional) points to an unencrypted SSH or PGP private key
+	// used to sign commits created by the server during pull request merges.
+	CommitSigningKeyPath string
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
@@ -183,6 +183,14 @@ func toPackageType(packageTypeStr string) (artifactapi.PackageType, error) {
 		return artifactapi.PackageTypeGO, nil
 	case string(artifactapi.PackageTypeHUGGINGFACE):
 		return artifactapi.PackageTypeHUGGINGFACE, nil
+	case string(artifactapi.PackageTypeDEBIAN):
+		return artifactapi.PackageTypeDEBIAN, nil
+	case string(artifactapi.PackageTypeTERRAFORM):
+		return artifactapi.PackageTypeTERRAFORM, nil
+	case string(artifactapi.PackageTypeRUBYGEMS):
+		return artifactapi.PackageTypeRUBYGEMS, nil
+	case string(artifactapi.PackageTypeCONDA):
+		return artifactapi.PackageTypeCONDA, nil
 	default:
 		return "", errors.New("invalid package type")
 	}
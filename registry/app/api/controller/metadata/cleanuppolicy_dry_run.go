@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"net/http"
+
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (c *APIController) GetCleanupPolicyDryRun(
+	ctx context.Context,
+	r api.GetCleanupPolicyDryRunRequestObject,
+) (api.GetCleanupPolicyDryRunResponseObject, error) {
+	regInfo, statusCode, message := c.checkRegistryAccess(ctx, string(r.RegistryRef),
+		enum.PermissionRegistryView)
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return api.GetCleanupPolicyDryRun404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.GetCleanupPolicyDryRun401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.GetCleanupPolicyDryRun403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.GetCleanupPolicyDryRun400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	}
+
+	candidates, err := c.CleanupService.Preview(ctx, regInfo.RegistryID, regInfo.PackageType)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to evaluate cleanup policies of registry %d", regInfo.RegistryID)
+		return api.GetCleanupPolicyDryRun500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to evaluate cleanup policies"),
+			),
+		}, nil
+	}
+
+	return api.GetCleanupPolicyDryRun200JSONResponse{
+		CleanupPolicyDryRunResponseJSONResponse: api.CleanupPolicyDryRunResponseJSONResponse{
+			Data:   api.CleanupPolicyDryRun{Candidates: mapToCleanupCandidates(candidates)},
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func mapToCleanupCandidates(candidates []registrytypes.CleanupCandidate) []api.CleanupCandidate {
+	res := make([]api.CleanupCandidate, len(candidates))
+	for i, candidate := range candidates {
+		res[i] = api.CleanupCandidate{
+			Package:      candidate.Package,
+			Version:      candidate.Version,
+			Policy:       candidate.Policy,
+			Reason:       candidate.Reason,
+			LastModified: GetTimeInMs(candidate.LastUpdated),
+		}
+	}
+	return res
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/cleanup"
 	"github.com/harness/gitness/registry/types"
 )
 
@@ -61,26 +62,36 @@ func getCleanupPolicyEntity(
 	repoID int64,
 ) (*types.CleanupPolicy, error) {
 	// Validate required fields
-	if cleanupPolicy.ExpireDays == nil {
-		return nil, usererror.BadRequest("expireDays is required for cleanup policy")
-	}
 	if cleanupPolicy.Name == nil {
 		return nil, usererror.BadRequest("name is required for cleanup policy")
 	}
-	if cleanupPolicy.VersionPrefix == nil {
-		return nil, usererror.BadRequest("versionPrefix is required for cleanup policy")
+	if cleanupPolicy.ExpireDays == nil && cleanupPolicy.KeepLast == nil {
+		return nil, usererror.BadRequest("expireDays or keepLast is required for cleanup policy")
+	}
+	expireDays := derefOrZero(cleanupPolicy.ExpireDays)
+	keepLast := derefOrZero(cleanupPolicy.KeepLast)
+	if expireDays < 0 || keepLast < 0 {
+		return nil, usererror.BadRequest("expireDays and keepLast can't be negative")
+	}
+	versionPattern := derefOrEmpty(cleanupPolicy.VersionPattern)
+	if err := cleanup.ValidatePatterns(versionPattern); err != nil {
+		return nil, usererror.BadRequestf("invalid versionPattern: %s", err)
 	}
-	if cleanupPolicy.PackagePrefix == nil {
-		return nil, usererror.BadRequest("packagePrefix is required for cleanup policy")
+	excludePattern := derefOrEmpty(cleanupPolicy.ExcludePattern)
+	if err := cleanup.ValidatePatterns(excludePattern); err != nil {
+		return nil, usererror.BadRequestf("invalid excludePattern: %s", err)
 	}
 
-	expireTime := time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
+	expireTime := time.Duration(expireDays) * 24 * time.Hour
 	return &types.CleanupPolicy{
-		Name:          *cleanupPolicy.Name,
-		VersionPrefix: *cleanupPolicy.VersionPrefix,
-		PackagePrefix: *cleanupPolicy.PackagePrefix,
-		ExpiryTime:    expireTime.Milliseconds(),
-		RegistryID:    repoID,
+		Name:           *cleanupPolicy.Name,
+		VersionPrefix:  derefOrEmpty(cleanupPolicy.VersionPrefix),
+		PackagePrefix:  derefOrEmpty(cleanupPolicy.PackagePrefix),
+		VersionPattern: versionPattern,
+		ExcludePattern: excludePattern,
+		ExpiryTime:     expireTime.Milliseconds(),
+		KeepLast:       keepLast,
+		RegistryID:     repoID,
 	}, nil
 }
 
@@ -89,12 +100,33 @@ func getCleanupPolicyDto(
 ) *artifact.CleanupPolicy {
 	packagePrefix := cleanupPolicy.PackagePrefix
 	versionPrefix := cleanupPolicy.VersionPrefix
-	expiryDays := int(time.Duration(cleanupPolicy.ExpiryTime).Hours() / 24)
+	versionPattern := cleanupPolicy.VersionPattern
+	excludePattern := cleanupPolicy.ExcludePattern
+	// ExpiryTime is stored in milliseconds.
+	expiryDays := int((time.Duration(cleanupPolicy.ExpiryTime) * time.Millisecond).Hours() / 24)
+	keepLast := cleanupPolicy.KeepLast
 
 	return &artifact.CleanupPolicy{
-		Name:          &cleanupPolicy.Name,
-		VersionPrefix: &versionPrefix,
-		PackagePrefix: &packagePrefix,
-		ExpireDays:    &expiryDays,
+		Name:           &cleanupPolicy.Name,
+		VersionPrefix:  &versionPrefix,
+		PackagePrefix:  &packagePrefix,
+		VersionPattern: &versionPattern,
+		ExcludePattern: &excludePattern,
+		ExpireDays:     &expiryDays,
+		KeepLast:       &keepLast,
+	}
+}
+
+func derefOrZero(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func derefOrEmpty(v *[]string) []string {
+	if v == nil {
+		return []string{}
 	}
+	return *v
 }
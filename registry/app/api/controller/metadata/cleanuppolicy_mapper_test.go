@@ -16,7 +16,6 @@ package metadata
 
 import (
 	"testing"
-	"time"
 
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
 	"github.com/harness/gitness/registry/types"
@@ -93,7 +92,66 @@ func TestGetCleanupPolicyDto_FunctionExists(t *testing.T) {
 		assert.Equal(t, &input.VersionPrefix, dto.VersionPrefix)
 		assert.Equal(t, &input.PackagePrefix, dto.PackagePrefix)
 
-		expireDays := int(time.Duration(input.ExpiryTime).Hours() / 24)
+		expireDays := 3
 		assert.Equal(t, &expireDays, dto.ExpireDays)
 	})
 }
+
+func TestGetCleanupPolicyEntity_Rules(t *testing.T) {
+	name := "policy3"
+	keepLast := 5
+	versionPattern := []string{`v\d+\.\d+\.\d+`}
+	excludePattern := []string{"release-.*"}
+
+	input := artifact.CleanupPolicy{
+		Name:           &name,
+		KeepLast:       &keepLast,
+		VersionPattern: &versionPattern,
+		ExcludePattern: &excludePattern,
+	}
+
+	entity, err := getCleanupPolicyEntity(input, 42)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, entity.KeepLast)
+	assert.Equal(t, int64(0), entity.ExpiryTime)
+	assert.Equal(t, versionPattern, entity.VersionPattern)
+	assert.Equal(t, excludePattern, entity.ExcludePattern)
+	assert.Empty(t, entity.VersionPrefix)
+	assert.Empty(t, entity.PackagePrefix)
+
+	dto := getCleanupPolicyDto(*entity)
+	assert.Equal(t, &keepLast, dto.KeepLast)
+	assert.Equal(t, &versionPattern, dto.VersionPattern)
+	assert.Equal(t, &excludePattern, dto.ExcludePattern)
+}
+
+func TestGetCleanupPolicyEntity_Invalid(t *testing.T) {
+	name := "policy4"
+	days := 7
+	negative := -1
+	invalidPattern := []string{"v1.(["}
+
+	tests := []struct {
+		name  string
+		input artifact.CleanupPolicy
+	}{
+		{name: "missing name", input: artifact.CleanupPolicy{ExpireDays: &days}},
+		{name: "missing expireDays and keepLast", input: artifact.CleanupPolicy{Name: &name}},
+		{name: "negative keepLast", input: artifact.CleanupPolicy{Name: &name, KeepLast: &negative}},
+		{
+			name:  "invalid versionPattern",
+			input: artifact.CleanupPolicy{Name: &name, ExpireDays: &days, VersionPattern: &invalidPattern},
+		},
+		{
+			name:  "invalid excludePattern",
+			input: artifact.CleanupPolicy{Name: &name, ExpireDays: &days, ExcludePattern: &invalidPattern},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := getCleanupPolicyEntity(test.input, 42)
+			assert.NotNil(t, err)
+		})
+	}
+}
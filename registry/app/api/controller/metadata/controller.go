@@ -35,6 +35,8 @@ import (
 	"github.com/harness/gitness/registry/app/storage"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/services/cleanup"
+	"github.com/harness/gitness/registry/services/replication"
 	webhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 )
@@ -81,6 +83,12 @@ type APIController struct {
 	PublicAccess                 publicaccess.Service
 	DeletionService              *deletion.Service
 	StorageService               *storage.Service
+	ReplicationRuleRepository    store.ReplicationRuleRepository
+	ReplicationRunRepository     store.ReplicationRunRepository
+	ReplicationService           *replication.Service
+	ScanPolicyRepository         store.ScanPolicyRepository
+	ArtifactScanRepository       store.ArtifactScanRepository
+	CleanupService               *cleanup.Service
 	app                          *docker.App
 }
 
@@ -120,6 +128,12 @@ func NewAPIController(
 	publicAccess publicaccess.Service,
 	deletionService *deletion.Service,
 	storageService *storage.Service,
+	replicationRuleRepository store.ReplicationRuleRepository,
+	replicationRunRepository store.ReplicationRunRepository,
+	replicationService *replication.Service,
+	scanPolicyRepository store.ScanPolicyRepository,
+	artifactScanRepository store.ArtifactScanRepository,
+	cleanupService *cleanup.Service,
 	app *docker.App,
 ) *APIController {
 	return &APIController{
@@ -158,6 +172,12 @@ func NewAPIController(
 		PublicAccess:                 publicAccess,
 		DeletionService:              deletionService,
 		StorageService:               storageService,
+		ReplicationRuleRepository:    replicationRuleRepository,
+		ReplicationRunRepository:     replicationRunRepository,
+		ReplicationService:           replicationService,
+		ScanPolicyRepository:         scanPolicyRepository,
+		ArtifactScanRepository:       artifactScanRepository,
+		CleanupService:               cleanupService,
 		app:                          app,
 	}
 }
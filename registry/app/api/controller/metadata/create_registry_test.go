@@ -279,6 +279,12 @@ func TestCreateRegistry(t *testing.T) {
 					mockPublicAccessService,
 					nil, // deletionService.
 					nil, // storageService.
+					nil, // replicationRuleRepository
+					nil, // replicationRunRepository
+					nil, // replicationService
+					nil, // scanPolicyRepository
+					nil, // artifactScanRepository
+					nil, // cleanupService
 					nil, // app.
 				)
 			},
@@ -372,6 +378,12 @@ func TestCreateRegistry(t *testing.T) {
 					mockPublicAccessService,
 					nil, // deletionService.
 					nil, // storageService.
+					nil, // replicationRuleRepository
+					nil, // replicationRunRepository
+					nil, // replicationService
+					nil, // scanPolicyRepository
+					nil, // artifactScanRepository
+					nil, // cleanupService
 					nil, // app.
 				)
 			},
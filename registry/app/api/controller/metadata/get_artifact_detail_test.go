@@ -317,6 +317,12 @@ func setupBasicController(_ *testing.T) *metadata.APIController {
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -394,6 +400,12 @@ func setupControllerWithError(_ *testing.T, errorType string) *metadata.APIContr
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -589,6 +601,12 @@ func setupSnapshotController(_ *testing.T, packageType artifact.PackageType) *me
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -377,11 +377,17 @@ func setupFilesController(_ *testing.T, packageType artifact.PackageType) *metad
 		nil,                        // quarantineFinder
 		nil,                        // spaceStore
 		func(_ context.Context) bool { return false }, // untaggedImagesEnabled
-		mockPackageWrapper,                            // packageWrapper
-		nil,                                           // publicAccess
-		nil,                                           // deletionService
-		nil,                                           // storageService
-		nil,                                           // app
+		mockPackageWrapper, // packageWrapper
+		nil,                // publicAccess
+		nil,                // deletionService
+		nil,                // storageService
+		nil,                // replicationRuleRepository
+		nil,                // replicationRunRepository
+		nil,                // replicationService
+		nil,                // scanPolicyRepository
+		nil,                // artifactScanRepository
+		nil,                // cleanupService
+		nil,                // app
 	)
 }
 
@@ -458,6 +464,12 @@ func setupFilesControllerWithError(_ *testing.T, errorType string) *metadata.API
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -254,6 +254,12 @@ func setupArtifactsControllerWithError(_ *testing.T, errorType string) *metadata
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -422,6 +428,12 @@ func setupArtifactsSnapshotController(
 		nil,                // publicAccess
 		nil,                // deletionService
 		nil,                // storageService
+		nil,                // replicationRuleRepository
+		nil,                // replicationRunRepository
+		nil,                // replicationService
+		nil,                // scanPolicyRepository
+		nil,                // artifactScanRepository
+		nil,                // cleanupService
 		nil,                // app
 	)
 }
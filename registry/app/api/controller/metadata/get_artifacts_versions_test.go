@@ -326,11 +326,17 @@ func setupVersionsController(_ *testing.T, packageType artifact.PackageType) *me
 		nil,                        // quarantineFinder
 		nil,                        // spaceStore
 		func(_ context.Context) bool { return false }, // untaggedImagesEnabled
-		mockPackageWrapper,                            // packageWrapper
-		nil,                                           // publicAccess
-		nil,                                           // deletionService
-		nil,                                           // storageService
-		nil,                                           // app
+		mockPackageWrapper, // packageWrapper
+		nil,                // publicAccess
+		nil,                // deletionService
+		nil,                // storageService
+		nil,                // replicationRuleRepository
+		nil,                // replicationRunRepository
+		nil,                // replicationService
+		nil,                // scanPolicyRepository
+		nil,                // artifactScanRepository
+		nil,                // cleanupService
+		nil,                // app
 	)
 }
 
@@ -413,6 +419,12 @@ func setupVersionsControllerWithError(_ *testing.T, errorType string) *metadata.
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
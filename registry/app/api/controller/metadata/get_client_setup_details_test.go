@@ -591,6 +591,12 @@ func TestGenerateClientSetupDetails_WithUntaggedImages(t *testing.T) {
 				nil, // publicAccess
 				nil, // deletionService
 				nil, // storageService
+				nil, // replicationRuleRepository
+				nil, // replicationRunRepository
+				nil, // replicationService
+				nil, // scanPolicyRepository
+				nil, // artifactScanRepository
+				nil, // cleanupService
 				nil, // app
 			)
 
@@ -668,6 +674,12 @@ func TestGenerateClientSetupDetails_MavenWithGroupID(t *testing.T) {
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 
@@ -806,6 +818,12 @@ func setupControllerForPackageType(_ *testing.T, packageType artifact.PackageTyp
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -899,6 +917,12 @@ func setupControllerForError(_ *testing.T, errorType string) *metadata.APIContro
 		nil, // publicAccess
 		nil, // deletionService
 		nil, // storageService
+		nil, // replicationRuleRepository
+		nil, // replicationRunRepository
+		nil, // replicationService
+		nil, // scanPolicyRepository
+		nil, // artifactScanRepository
+		nil, // cleanupService
 		nil, // app
 	)
 }
@@ -1054,6 +1078,12 @@ func TestGenerateClientSetupDetailsSnapshot(t *testing.T) {
 				nil, // publicAccess
 				nil, // deletionService
 				nil, // storageService
+				nil, // replicationRuleRepository
+				nil, // replicationRunRepository
+				nil, // replicationService
+				nil, // scanPolicyRepository
+				nil, // artifactScanRepository
+				nil, // cleanupService
 				nil, // app
 			)
 
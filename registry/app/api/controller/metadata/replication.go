@@ -16,78 +16,546 @@ package metadata
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
 
-	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/replication"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *APIController) ListReplicationRules(
-	_ context.Context,
-	_ artifact.ListReplicationRulesRequestObject,
-) (artifact.ListReplicationRulesResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.ListReplicationRulesRequestObject,
+) (api.ListReplicationRulesResponseObject, error) {
+	if r.Params.SpaceRef == nil || len(*r.Params.SpaceRef) == 0 {
+		return api.ListReplicationRules400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, "space reference is required"),
+			),
+		}, nil
+	}
+	space, err := c.SpaceFinder.FindByRef(ctx, string(*r.Params.SpaceRef))
+	if err != nil {
+		return api.ListReplicationRules404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, "space not found"),
+			),
+		}, nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	if err = apiauth.CheckSpaceScope(ctx, c.Authorizer, session, space, enum.ResourceTypeRegistry,
+		enum.PermissionRegistryView); err != nil {
+		statusCode, message := HandleAuthError(err)
+		if statusCode == http.StatusUnauthorized {
+			return api.ListReplicationRules401JSONResponse{
+				UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(
+					*GetErrorResponse(http.StatusUnauthorized, message),
+				),
+			}, nil
+		}
+		return api.ListReplicationRules403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(
+				*GetErrorResponse(http.StatusForbidden, message),
+			),
+		}, nil
+	}
+
+	rules, err := c.ReplicationRuleRepository.ListBySpace(ctx, space.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to list replication rules of space %s", space.Path)
+		return api.ListReplicationRules500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to list replication rules"),
+			),
+		}, nil
+	}
+
+	apiRules := make([]api.ReplicationRule, 0, len(rules))
+	for _, rule := range rules {
+		apiRule, err := c.mapToReplicationRuleResponse(ctx, rule)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msgf("failed to map replication rule %d", rule.ID)
+			return api.ListReplicationRules500JSONResponse{
+				InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, "failed to list replication rules"),
+				),
+			}, nil
+		}
+		apiRules = append(apiRules, *apiRule)
+	}
+
+	count := int64(len(apiRules))
+	var pageCount int64
+	if count > 0 {
+		pageCount = 1
+	}
+	return api.ListReplicationRules200JSONResponse{
+		ListReplicationRuleResponseJSONResponse: api.ListReplicationRuleResponseJSONResponse{
+			Data: api.ListReplicationRule{
+				ItemCount: count,
+				PageCount: pageCount,
+				PageIndex: 0,
+				PageSize:  len(apiRules),
+				Rules:     apiRules,
+			},
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) CreateReplicationRule(
-	_ context.Context,
-	_ artifact.CreateReplicationRuleRequestObject,
-) (artifact.CreateReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.CreateReplicationRuleRequestObject,
+) (api.CreateReplicationRuleResponseObject, error) {
+	if r.Params.SpaceRef == nil || len(*r.Params.SpaceRef) == 0 || r.Body == nil {
+		return api.CreateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, "space reference and request body are required"),
+			),
+		}, nil
+	}
+	space, err := c.SpaceFinder.FindByRef(ctx, string(*r.Params.SpaceRef))
+	if err != nil {
+		return api.CreateReplicationRule404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, "space not found"),
+			),
+		}, nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	rule := &registrytypes.ReplicationRule{
+		SpaceID:   space.ID,
+		CreatedBy: session.Principal.ID,
+	}
+	statusCode, message := c.applyReplicationRuleRequest(ctx, session, space, rule, api.ReplicationRuleRequest(*r.Body))
+	switch statusCode {
+	case 0:
+	case http.StatusUnauthorized:
+		return api.CreateReplicationRule401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.CreateReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.CreateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	}
+
+	if err = c.ReplicationRuleRepository.Create(ctx, rule); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to create replication rule in space %s", space.Path)
+		return api.CreateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to create replication rule"),
+			),
+		}, nil
+	}
+
+	apiRule, err := c.mapToReplicationRuleResponse(ctx, rule)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to map replication rule %d", rule.ID)
+		return api.CreateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to get replication rule"),
+			),
+		}, nil
+	}
+	return api.CreateReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: api.ReplicationRuleResponseJSONResponse{
+			Data:   *apiRule,
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) DeleteReplicationRule(
-	_ context.Context,
-	_ artifact.DeleteReplicationRuleRequestObject,
-) (artifact.DeleteReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.DeleteReplicationRuleRequestObject,
+) (api.DeleteReplicationRuleResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryEdit)
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.DeleteReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.DeleteReplicationRule401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.DeleteReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.DeleteReplicationRule404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.DeleteReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	if err := c.ReplicationRuleRepository.Delete(ctx, rule.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to delete replication rule %d", rule.ID)
+		return api.DeleteReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to delete replication rule"),
+			),
+		}, nil
+	}
+	return api.DeleteReplicationRule200JSONResponse{
+		SuccessJSONResponse: api.SuccessJSONResponse{Status: api.StatusSUCCESS},
+	}, nil
 }
 
 func (c *APIController) GetReplicationRule(
-	_ context.Context,
-	_ artifact.GetReplicationRuleRequestObject,
-) (artifact.GetReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.GetReplicationRuleRequestObject,
+) (api.GetReplicationRuleResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryView)
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.GetReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.GetReplicationRule401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.GetReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.GetReplicationRule404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.GetReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	apiRule, err := c.mapToReplicationRuleResponse(ctx, rule)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to map replication rule %d", rule.ID)
+		return api.GetReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to get replication rule"),
+			),
+		}, nil
+	}
+	return api.GetReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: api.ReplicationRuleResponseJSONResponse{
+			Data:   *apiRule,
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) UpdateReplicationRule(
-	_ context.Context,
-	_ artifact.UpdateReplicationRuleRequestObject,
-) (artifact.UpdateReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.UpdateReplicationRuleRequestObject,
+) (api.UpdateReplicationRuleResponseObject, error) {
+	if r.Body == nil {
+		return api.UpdateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, "request body is required"),
+			),
+		}, nil
+	}
+
+	rule, space, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryEdit)
+	if statusCode == 0 {
+		session, _ := request.AuthSessionFrom(ctx)
+		statusCode, message = c.applyReplicationRuleRequest(ctx, session, space, rule,
+			api.ReplicationRuleRequest(*r.Body))
+	}
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.UpdateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.UpdateReplicationRule401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.UpdateReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.UpdateReplicationRule404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.UpdateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	if err := c.ReplicationRuleRepository.Update(ctx, rule); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to update replication rule %d", rule.ID)
+		return api.UpdateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to update replication rule"),
+			),
+		}, nil
+	}
+
+	apiRule, err := c.mapToReplicationRuleResponse(ctx, rule)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to map replication rule %d", rule.ID)
+		return api.UpdateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to get replication rule"),
+			),
+		}, nil
+	}
+	return api.UpdateReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: api.ReplicationRuleResponseJSONResponse{
+			Data:   *apiRule,
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) ListMigrationImages(
-	_ context.Context,
-	_ artifact.ListMigrationImagesRequestObject,
-) (artifact.ListMigrationImagesResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.ListMigrationImagesRequestObject,
+) (api.ListMigrationImagesResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryView)
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.ListMigrationImages400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.ListMigrationImages401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.ListMigrationImages403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.ListMigrationImages404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.ListMigrationImages500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	limit := GetPageLimit(r.Params.Size)
+	offset := GetOffset(r.Params.Size, r.Params.Page)
+	pageNumber := GetPageNumber(r.Params.Page)
+	sortByOrder := ""
+	if r.Params.SortOrder != nil {
+		sortByOrder = string(*r.Params.SortOrder)
+	}
+
+	images := make([]api.MigrationImage, 0)
+	var count int64
+	run, err := c.ReplicationRunRepository.FindLatestByRule(ctx, rule.ID)
+	if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find latest run of replication rule %d", rule.ID)
+		return api.ListMigrationImages500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to list replicated images"),
+			),
+		}, nil
+	}
+	if run != nil {
+		artifacts, err := c.ReplicationRunRepository.ListArtifacts(ctx, run.ID, "id",
+			GetSortByOrder(sortByOrder), limit, offset)
+		if err == nil {
+			count, err = c.ReplicationRunRepository.CountArtifacts(ctx, run.ID)
+		}
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msgf("failed to list artifacts of replication run %d", run.ID)
+			return api.ListMigrationImages500JSONResponse{
+				InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, "failed to list replicated images"),
+				),
+			}, nil
+		}
+		for _, artifact := range artifacts {
+			images = append(images, mapToMigrationImage(artifact))
+		}
+	}
+
+	return api.ListMigrationImages200JSONResponse{
+		ListMigrationImageResponseJSONResponse: api.ListMigrationImageResponseJSONResponse{
+			Data: api.ListMigrationImage{
+				Images:    images,
+				ItemCount: count,
+				PageCount: GetPageCount(count, limit),
+				PageIndex: pageNumber,
+				PageSize:  limit,
+			},
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) GetMigrationLogsForImage(
-	_ context.Context,
-	_ artifact.GetMigrationLogsForImageRequestObject,
-) (artifact.GetMigrationLogsForImageResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.GetMigrationLogsForImageRequestObject,
+) (api.GetMigrationLogsForImageResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryView)
+	var artifact *registrytypes.ReplicationRunArtifact
+	if statusCode == 0 {
+		artifact, statusCode, message = c.getReplicationRunArtifact(ctx, rule, r.ImageId)
+	}
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.GetMigrationLogsForImage400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.GetMigrationLogsForImage401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.GetMigrationLogsForImage403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.GetMigrationLogsForImage404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.GetMigrationLogsForImage500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	return api.GetMigrationLogsForImage200TextplainCharsetUtf8Response{
+		PlainTextResponseTextplainCharsetUtf8Response: api.PlainTextResponseTextplainCharsetUtf8Response{
+			Body:          strings.NewReader(artifact.Log),
+			ContentLength: int64(len(artifact.Log)),
+		},
+	}, nil
 }
 
 func (c *APIController) StartMigration(
-	_ context.Context,
-	_ artifact.StartMigrationRequestObject,
-) (artifact.StartMigrationResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.StartMigrationRequestObject,
+) (api.StartMigrationResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryEdit)
+	if statusCode == 0 {
+		_, err := c.ReplicationService.StartRun(ctx, rule, registrytypes.ReplicationTriggerManual)
+		switch {
+		case errors.Is(err, replication.ErrRunInProgress):
+			statusCode, message = http.StatusBadRequest, err.Error()
+		case err != nil:
+			log.Ctx(ctx).Error().Err(err).Msgf("failed to start run of replication rule %d", rule.ID)
+			statusCode, message = http.StatusInternalServerError, "failed to start replication"
+		}
+	}
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.StartMigration400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.StartMigration401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.StartMigration403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.StartMigration404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.StartMigration500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	return api.StartMigration200JSONResponse{
+		SuccessJSONResponse: api.SuccessJSONResponse{Status: api.StatusSUCCESS},
+	}, nil
 }
 
 func (c *APIController) StopMigration(
-	_ context.Context,
-	_ artifact.StopMigrationRequestObject,
-) (artifact.StopMigrationResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	ctx context.Context,
+	r api.StopMigrationRequestObject,
+) (api.StopMigrationResponseObject, error) {
+	rule, _, statusCode, message := c.getReplicationRule(ctx, r.Id, enum.PermissionRegistryEdit)
+	if statusCode == 0 {
+		err := c.ReplicationService.StopRun(ctx, rule)
+		switch {
+		case errors.Is(err, replication.ErrNoRunInProgress):
+			statusCode, message = http.StatusBadRequest, err.Error()
+		case err != nil:
+			log.Ctx(ctx).Error().Err(err).Msgf("failed to stop run of replication rule %d", rule.ID)
+			statusCode, message = http.StatusInternalServerError, "failed to stop replication"
+		}
+	}
+	switch statusCode {
+	case 0:
+	case http.StatusBadRequest:
+		return api.StopMigration400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.StopMigration401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.StopMigration403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusNotFound:
+		return api.StopMigration404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.StopMigration500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(statusCode, message),
+			),
+		}, nil
+	}
+
+	return api.StopMigration200JSONResponse{
+		SuccessJSONResponse: api.SuccessJSONResponse{Status: api.StatusSUCCESS},
+	}, nil
 }
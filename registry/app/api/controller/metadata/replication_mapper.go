@@ -0,0 +1,391 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/replication"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// getReplicationRule finds the replication rule with the provided identifier and verifies that the
+// session has the permission on the registries of the space the rule is defined in.
+// On failure, the http status code and the error message of the response are returned.
+func (c *APIController) getReplicationRule(
+	ctx context.Context,
+	id string,
+	permission enum.Permission,
+) (*registrytypes.ReplicationRule, *types.SpaceCore, int, string) {
+	ruleID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, "invalid replication rule identifier"
+	}
+
+	rule, err := c.ReplicationRuleRepository.Find(ctx, ruleID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, nil, http.StatusNotFound, "replication rule not found"
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find replication rule %d", ruleID)
+		return nil, nil, http.StatusInternalServerError, "failed to find replication rule"
+	}
+
+	space, err := c.SpaceFinder.FindByID(ctx, rule.SpaceID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find space of replication rule %d", ruleID)
+		return nil, nil, http.StatusInternalServerError, "failed to find replication rule"
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	if statusCode, message := c.checkReplicationRuleSpace(ctx, session, space, permission); statusCode != 0 {
+		return nil, nil, statusCode, message
+	}
+
+	return rule, space, 0, ""
+}
+
+func (c *APIController) checkReplicationRuleSpace(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	permission enum.Permission,
+) (int, string) {
+	err := apiauth.CheckSpaceScope(ctx, c.Authorizer, session, space, enum.ResourceTypeRegistry, permission)
+	if err != nil {
+		return HandleAuthError(err)
+	}
+	return 0, ""
+}
+
+// getReplicationRunArtifact finds an artifact of a run of the replication rule.
+func (c *APIController) getReplicationRunArtifact(
+	ctx context.Context,
+	rule *registrytypes.ReplicationRule,
+	id string,
+) (*registrytypes.ReplicationRunArtifact, int, string) {
+	artifactID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, http.StatusBadRequest, "invalid image identifier"
+	}
+
+	artifact, err := c.ReplicationRunRepository.FindArtifact(ctx, artifactID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, http.StatusNotFound, "image not found"
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find replication run artifact %d", artifactID)
+		return nil, http.StatusInternalServerError, "failed to find image"
+	}
+
+	run, err := c.ReplicationRunRepository.Find(ctx, artifact.RunID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find replication run %d", artifact.RunID)
+		return nil, http.StatusInternalServerError, "failed to find image"
+	}
+	if run.RuleID != rule.ID {
+		return nil, http.StatusNotFound, "image not found"
+	}
+
+	return artifact, 0, ""
+}
+
+// applyReplicationRuleRequest validates the request and applies it to the rule. The session has to be
+// allowed to download artifacts from the source registry and to upload artifacts to a local destination
+// registry, as runs replicate artifacts on behalf of the creator of the rule.
+// On failure, the http status code and the error message of the response are returned.
+func (c *APIController) applyReplicationRuleRequest(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	rule *registrytypes.ReplicationRule,
+	req api.ReplicationRuleRequest,
+) (int, string) {
+	if req.SourceType != api.ReplicationRuleRequestSourceTypeLocal {
+		return http.StatusBadRequest, fmt.Sprintf("unsupported source type %q", req.SourceType)
+	}
+	if err := replication.ValidatePatterns(req.AllowedPatterns); err != nil {
+		return http.StatusBadRequest, fmt.Sprintf("invalid allowed patterns: %s", err)
+	}
+	if err := replication.ValidatePatterns(req.BlockedPatterns); err != nil {
+		return http.StatusBadRequest, fmt.Sprintf("invalid blocked patterns: %s", err)
+	}
+
+	source, err := req.Source.AsLocalReplicationRegistry()
+	if err != nil {
+		return http.StatusBadRequest, "invalid source registry"
+	}
+	src, err := c.resolveReplicationRegistry(ctx, space, source.RegistryIdentifier)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Sprintf("invalid source registry: %s", err)
+	}
+	if src.RegistryType != api.RegistryTypeVIRTUAL {
+		return http.StatusBadRequest, "source registry has to be a virtual registry"
+	}
+	if src.PackageType != api.PackageTypeDOCKER && src.PackageType != api.PackageTypeHELM {
+		return http.StatusBadRequest, fmt.Sprintf("replication of %s registries is not supported", src.PackageType)
+	}
+	if statusCode, message := c.checkReplicationRegistry(ctx, session, src,
+		enum.PermissionArtifactsDownload); statusCode != 0 {
+		return statusCode, message
+	}
+
+	rule.SourceRegistryID = src.RegistryID
+	rule.AllowedPatterns = req.AllowedPatterns
+	rule.BlockedPatterns = req.BlockedPatterns
+
+	switch req.DestinationType {
+	case api.ReplicationRuleRequestDestinationTypeLocal:
+		return c.applyLocalReplicationDestination(ctx, session, space, rule, src, req.Destination)
+	case api.ReplicationRuleRequestDestinationTypeJfrog, api.ReplicationRuleRequestDestinationTypeGCP:
+		return c.applyRemoteReplicationDestination(ctx, session, rule,
+			registrytypes.ReplicationDestinationType(req.DestinationType), req.Destination)
+	default:
+		return http.StatusBadRequest, fmt.Sprintf("unsupported destination type %q", req.DestinationType)
+	}
+}
+
+func (c *APIController) applyLocalReplicationDestination(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	rule *registrytypes.ReplicationRule,
+	src *registrytypes.RegistryRequestBaseInfo,
+	destination api.ReplicationRegistry,
+) (int, string) {
+	local, err := destination.AsLocalReplicationRegistry()
+	if err != nil {
+		return http.StatusBadRequest, "invalid destination registry"
+	}
+	dst, err := c.resolveReplicationRegistry(ctx, space, local.RegistryIdentifier)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Sprintf("invalid destination registry: %s", err)
+	}
+	if dst.RegistryID == src.RegistryID {
+		return http.StatusBadRequest, "source and destination registry can't be the same"
+	}
+	if dst.RegistryType != api.RegistryTypeVIRTUAL {
+		return http.StatusBadRequest, "destination registry has to be a virtual registry"
+	}
+	if dst.PackageType != src.PackageType {
+		return http.StatusBadRequest, "source and destination registry have to be of the same package type"
+	}
+	if statusCode, message := c.checkReplicationRegistry(ctx, session, dst,
+		enum.PermissionArtifactsUpload); statusCode != 0 {
+		return statusCode, message
+	}
+	if err = c.ReplicationService.ValidateNoCycle(ctx, rule.ID, src.RegistryID, dst.RegistryID); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	rule.DestinationType = registrytypes.ReplicationDestinationLocal
+	rule.DestinationRegistryID = &dst.RegistryID
+	rule.DestinationConfig = registrytypes.ReplicationDestinationConfig{}
+	return 0, ""
+}
+
+func (c *APIController) applyRemoteReplicationDestination(
+	ctx context.Context,
+	session *auth.Session,
+	rule *registrytypes.ReplicationRule,
+	destinationType registrytypes.ReplicationDestinationType,
+	destination api.ReplicationRegistry,
+) (int, string) {
+	remote, err := destination.AsJfrogReplicationRegistry()
+	if err != nil {
+		return http.StatusBadRequest, "invalid destination registry"
+	}
+	u, err := url.Parse(remote.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return http.StatusBadRequest, "destination url has to be an absolute http or https url"
+	}
+
+	config := registrytypes.ReplicationDestinationConfig{
+		URL:       remote.Url,
+		Namespace: strings.Trim(remote.Namespace, "/"),
+	}
+	if remote.Username != nil {
+		config.Username = *remote.Username
+	}
+	if remote.PasswordSecretId != nil && *remote.PasswordSecretId != "" {
+		config.SecretIdentifier = *remote.PasswordSecretId
+		config.SecretSpaceID, err = c.RegistryMetadataHelper.GetSecretSpaceID(ctx, remote.PasswordSecretSpaceId)
+		if err != nil {
+			return http.StatusBadRequest, fmt.Sprintf("invalid password secret space: %s", err)
+		}
+		// the secret is sent to a destination of the caller's choice, so the caller has to be allowed to read it.
+		err = apiauth.CheckSecret(ctx, c.Authorizer, session, *remote.PasswordSecretSpaceId,
+			config.SecretIdentifier, enum.PermissionSecretAccess)
+		if err != nil {
+			return HandleAuthError(err)
+		}
+	}
+
+	rule.DestinationType = destinationType
+	rule.DestinationRegistryID = nil
+	rule.DestinationConfig = config
+	return 0, ""
+}
+
+// resolveReplicationRegistry resolves a registry referenced by a replication rule. The registry is either
+// referenced by its full path or by its identifier relative to the space of the rule.
+func (c *APIController) resolveReplicationRegistry(
+	ctx context.Context,
+	space *types.SpaceCore,
+	ref string,
+) (*registrytypes.RegistryRequestBaseInfo, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("registry identifier is required")
+	}
+	if strings.Contains(ref, "/") {
+		return c.RegistryMetadataHelper.GetRegistryRequestBaseInfo(ctx, "", ref)
+	}
+	return c.RegistryMetadataHelper.GetRegistryRequestBaseInfo(ctx, space.Path, ref)
+}
+
+func (c *APIController) checkReplicationRegistry(
+	ctx context.Context,
+	session *auth.Session,
+	regInfo *registrytypes.RegistryRequestBaseInfo,
+	permission enum.Permission,
+) (int, string) {
+	space, err := c.SpaceFinder.FindByRef(ctx, regInfo.ParentRef)
+	if err != nil {
+		return http.StatusBadRequest, "registry space not found"
+	}
+	permissionChecks := c.RegistryMetadataHelper.GetPermissionChecks(space, regInfo.RegistryIdentifier, permission)
+	if err = apiauth.CheckRegistry(ctx, c.Authorizer, session, permissionChecks...); err != nil {
+		return HandleAuthError(err)
+	}
+	return 0, ""
+}
+
+func (c *APIController) mapToReplicationRuleResponse(
+	ctx context.Context,
+	rule *registrytypes.ReplicationRule,
+) (*api.ReplicationRule, error) {
+	space, err := c.SpaceFinder.FindByID(ctx, rule.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of replication rule: %w", err)
+	}
+
+	sourcePath, err := c.getReplicationRegistryPath(ctx, rule.SourceRegistryID)
+	if err != nil {
+		return nil, err
+	}
+	source := api.ReplicationRegistry{}
+	if err = source.FromLocalReplicationRegistry(api.LocalReplicationRegistry{
+		RegistryIdentifier: sourcePath,
+	}); err != nil {
+		return nil, err
+	}
+
+	destination := api.ReplicationRegistry{}
+	if rule.DestinationRegistryID != nil {
+		destinationPath, err := c.getReplicationRegistryPath(ctx, *rule.DestinationRegistryID)
+		if err != nil {
+			return nil, err
+		}
+		err = destination.FromLocalReplicationRegistry(api.LocalReplicationRegistry{
+			RegistryIdentifier: destinationPath,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		remote := api.JfrogReplicationRegistry{
+			Url:       rule.DestinationConfig.URL,
+			Namespace: rule.DestinationConfig.Namespace,
+		}
+		if rule.DestinationConfig.Username != "" {
+			remote.Username = ptr.String(rule.DestinationConfig.Username)
+		}
+		if rule.DestinationConfig.SecretIdentifier != "" {
+			secretSpace, err := c.SpaceFinder.FindByID(ctx, rule.DestinationConfig.SecretSpaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space of secret: %w", err)
+			}
+			remote.PasswordSecretId = ptr.String(rule.DestinationConfig.SecretIdentifier)
+			remote.PasswordSecretSpaceId = ptr.String(secretSpace.Path)
+		}
+		if err = destination.FromJfrogReplicationRegistry(remote); err != nil {
+			return nil, err
+		}
+	}
+
+	return &api.ReplicationRule{
+		Identifier:      strconv.FormatInt(rule.ID, 10),
+		ParentRef:       space.Path,
+		SourceType:      api.ReplicationRuleSourceTypeLocal,
+		Source:          source,
+		DestinationType: api.ReplicationRuleDestinationType(rule.DestinationType),
+		Destination:     destination,
+		AllowedPatterns: nonNilPatterns(rule.AllowedPatterns),
+		BlockedPatterns: nonNilPatterns(rule.BlockedPatterns),
+		CreatedAt:       GetTimeInMs(rule.CreatedAt),
+		ModifiedAt:      GetTimeInMs(rule.UpdatedAt),
+	}, nil
+}
+
+func (c *APIController) getReplicationRegistryPath(ctx context.Context, registryID int64) (string, error) {
+	registry, err := c.RegistryRepository.Get(ctx, registryID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find registry %d: %w", registryID, err)
+	}
+	space, err := c.SpaceFinder.FindByID(ctx, registry.ParentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space of registry %d: %w", registryID, err)
+	}
+	return space.Path + "/" + registry.Name, nil
+}
+
+func mapToMigrationImage(artifact *registrytypes.ReplicationRunArtifact) api.MigrationImage {
+	progress := 0
+	switch {
+	case artifact.Status == registrytypes.ReplicationStatusRunning:
+		progress = 50
+	case artifact.Status.IsDone():
+		progress = 100
+	}
+	return api.MigrationImage{
+		ImageId:  ptr.String(strconv.FormatInt(artifact.ID, 10)),
+		ImageTag: ptr.String(artifact.Image + ":" + artifact.Tag),
+		Progress: &progress,
+		Status:   ptr.String(string(artifact.Status)),
+	}
+}
+
+func nonNilPatterns(patterns []string) []string {
+	if patterns == nil {
+		return []string{}
+	}
+	return patterns
+}
@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/scanning"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (c *APIController) GetScanPolicy(
+	ctx context.Context,
+	r api.GetScanPolicyRequestObject,
+) (api.GetScanPolicyResponseObject, error) {
+	regInfo, statusCode, message := c.checkRegistryAccess(ctx, string(r.RegistryRef),
+		enum.PermissionRegistryView)
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return api.GetScanPolicy404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.GetScanPolicy401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.GetScanPolicy403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.GetScanPolicy400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	}
+
+	policy, err := c.ScanPolicyRepository.Find(ctx, regInfo.RegistryID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		// registries without a policy aren't scanned.
+		policy = &registrytypes.ScanPolicy{RegistryID: regInfo.RegistryID}
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to find scan policy of registry %d", regInfo.RegistryID)
+		return api.GetScanPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to get scan policy"),
+			),
+		}, nil
+	}
+
+	return api.GetScanPolicy200JSONResponse{
+		ScanPolicyResponseJSONResponse: api.ScanPolicyResponseJSONResponse{
+			Data:   *mapToScanPolicyResponse(policy),
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func (c *APIController) UpdateScanPolicy(
+	ctx context.Context,
+	r api.UpdateScanPolicyRequestObject,
+) (api.UpdateScanPolicyResponseObject, error) {
+	if r.Body == nil {
+		return api.UpdateScanPolicy400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, "request body is required"),
+			),
+		}, nil
+	}
+
+	regInfo, statusCode, message := c.checkRegistryAccess(ctx, string(r.RegistryRef),
+		enum.PermissionRegistryEdit)
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return api.UpdateScanPolicy404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.UpdateScanPolicy401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.UpdateScanPolicy403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.UpdateScanPolicy400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	}
+
+	if r.Body.Enabled && !scanning.IsPackageTypeSupported(regInfo.PackageType) {
+		return api.UpdateScanPolicy400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest,
+					fmt.Sprintf("vulnerability scanning is not supported for %s registries", regInfo.PackageType)),
+			),
+		}, nil
+	}
+
+	var severity registrytypes.VulnerabilitySeverity
+	if r.Body.QuarantineSeverity != nil {
+		severity = registrytypes.VulnerabilitySeverity(*r.Body.QuarantineSeverity)
+		if !severity.IsValid() {
+			return api.UpdateScanPolicy400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse(
+					*GetErrorResponse(http.StatusBadRequest,
+						fmt.Sprintf("invalid quarantine severity %q", *r.Body.QuarantineSeverity)),
+				),
+			}, nil
+		}
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	policy := &registrytypes.ScanPolicy{
+		RegistryID:         regInfo.RegistryID,
+		Enabled:            r.Body.Enabled,
+		QuarantineSeverity: severity,
+		CreatedBy:          session.Principal.ID,
+		UpdatedBy:          session.Principal.ID,
+	}
+	if err := c.ScanPolicyRepository.Upsert(ctx, policy); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to update scan policy of registry %d", regInfo.RegistryID)
+		return api.UpdateScanPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+				*GetErrorResponse(http.StatusInternalServerError, "failed to update scan policy"),
+			),
+		}, nil
+	}
+
+	// the creation time of an existing policy is kept by the upsert.
+	if updated, err := c.ScanPolicyRepository.Find(ctx, regInfo.RegistryID); err == nil {
+		policy = updated
+	}
+
+	return api.UpdateScanPolicy200JSONResponse{
+		ScanPolicyResponseJSONResponse: api.ScanPolicyResponseJSONResponse{
+			Data:   *mapToScanPolicyResponse(policy),
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func (c *APIController) GetArtifactVersionVulnerabilities(
+	ctx context.Context,
+	r api.GetArtifactVersionVulnerabilitiesRequestObject,
+) (api.GetArtifactVersionVulnerabilitiesResponseObject, error) {
+	regInfo, statusCode, message := c.checkRegistryAccess(ctx, string(r.RegistryRef),
+		enum.PermissionRegistryView)
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return api.GetArtifactVersionVulnerabilities404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusUnauthorized:
+		return api.GetArtifactVersionVulnerabilities401JSONResponse{
+			UnauthenticatedJSONResponse: api.UnauthenticatedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	case http.StatusForbidden:
+		return api.GetArtifactVersionVulnerabilities403JSONResponse{
+			UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	default:
+		return api.GetArtifactVersionVulnerabilities400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse(*GetErrorResponse(statusCode, message)),
+		}, nil
+	}
+
+	var artifactType *api.ArtifactType
+	if r.Params.ArtifactType != nil {
+		var err error
+		artifactType, err = ValidateAndGetArtifactType(regInfo.PackageType, string(*r.Params.ArtifactType))
+		if err != nil {
+			return api.GetArtifactVersionVulnerabilities400JSONResponse{
+				BadRequestJSONResponse: api.BadRequestJSONResponse(
+					*GetErrorResponse(http.StatusBadRequest, err.Error()),
+				),
+			}, nil
+		}
+	}
+
+	img, err := c.ImageStore.GetByNameAndType(ctx, regInfo.RegistryID, string(r.Artifact), artifactType)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return api.GetArtifactVersionVulnerabilities404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, "artifact not found"),
+			),
+		}, nil
+	}
+	if err != nil {
+		return throwGetArtifactVersionVulnerabilities500Error(ctx, err), nil
+	}
+
+	art, err := c.ArtifactStore.GetByName(ctx, img.ID, string(r.Version))
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return api.GetArtifactVersionVulnerabilities404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, "artifact version not found"),
+			),
+		}, nil
+	}
+	if err != nil {
+		return throwGetArtifactVersionVulnerabilities500Error(ctx, err), nil
+	}
+
+	data := api.ArtifactVulnerabilities{
+		Status:          api.VulnerabilityScanStatusNOTSCANNED,
+		Vulnerabilities: []api.Vulnerability{},
+	}
+
+	scan, err := c.ArtifactScanRepository.FindByArtifact(ctx, art.ID)
+	if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return throwGetArtifactVersionVulnerabilities500Error(ctx, err), nil
+	}
+	if err == nil {
+		vulnerabilities, err := c.ArtifactScanRepository.ListVulnerabilities(ctx, art.ID)
+		if err != nil {
+			return throwGetArtifactVersionVulnerabilities500Error(ctx, err), nil
+		}
+		data = *mapToArtifactVulnerabilitiesResponse(scan, vulnerabilities)
+	}
+
+	return api.GetArtifactVersionVulnerabilities200JSONResponse{
+		ArtifactVulnerabilitiesResponseJSONResponse: api.ArtifactVulnerabilitiesResponseJSONResponse{
+			Data:   data,
+			Status: api.StatusSUCCESS,
+		},
+	}, nil
+}
+
+// checkRegistryAccess resolves the registry and checks the permission of the session on it.
+// It returns http.StatusOK and the registry info if the access is granted.
+func (c *APIController) checkRegistryAccess(
+	ctx context.Context,
+	registryRef string,
+	permission enum.Permission,
+) (*registrytypes.RegistryRequestBaseInfo, int, string) {
+	regInfo, err := c.RegistryMetadataHelper.GetRegistryRequestBaseInfo(ctx, "", registryRef)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, http.StatusNotFound, "registry not found"
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err.Error()
+	}
+
+	space, err := c.SpaceFinder.FindByRef(ctx, regInfo.ParentRef)
+	if err != nil {
+		return nil, http.StatusBadRequest, err.Error()
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	permissionChecks := c.RegistryMetadataHelper.GetPermissionChecks(space, regInfo.RegistryIdentifier, permission)
+	if err = apiauth.CheckRegistry(ctx, c.Authorizer, session, permissionChecks...); err != nil {
+		statusCode, message := HandleAuthError(err)
+		return nil, statusCode, message
+	}
+
+	return regInfo, http.StatusOK, ""
+}
+
+func throwGetArtifactVersionVulnerabilities500Error(
+	ctx context.Context,
+	err error,
+) api.GetArtifactVersionVulnerabilities500JSONResponse {
+	log.Ctx(ctx).Error().Err(err).Msg("failed to get artifact version vulnerabilities")
+	return api.GetArtifactVersionVulnerabilities500JSONResponse{
+		InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse(
+			*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+		),
+	}
+}
+
+func mapToScanPolicyResponse(policy *registrytypes.ScanPolicy) *api.ScanPolicy {
+	response := &api.ScanPolicy{
+		Enabled: policy.Enabled,
+	}
+	if policy.QuarantineSeverity != "" {
+		severity := api.VulnerabilitySeverity(policy.QuarantineSeverity)
+		response.QuarantineSeverity = &severity
+	}
+	if !policy.CreatedAt.IsZero() {
+		createdAt := GetTimeInMs(policy.CreatedAt)
+		modifiedAt := GetTimeInMs(policy.UpdatedAt)
+		response.CreatedAt = &createdAt
+		response.ModifiedAt = &modifiedAt
+	}
+	return response
+}
+
+func mapToArtifactVulnerabilitiesResponse(
+	scan *registrytypes.ArtifactScan,
+	vulnerabilities []registrytypes.Vulnerability,
+) *api.ArtifactVulnerabilities {
+	scannedAt := GetTimeInMs(scan.UpdatedAt)
+	response := &api.ArtifactVulnerabilities{
+		Status:          api.VulnerabilityScanStatus(scan.Status),
+		Scanner:         &scan.Scanner,
+		Critical:        &scan.Critical,
+		High:            &scan.High,
+		Medium:          &scan.Medium,
+		Low:             &scan.Low,
+		Unknown:         &scan.Unknown,
+		Quarantined:     scan.Quarantined,
+		ScannedAt:       &scannedAt,
+		Vulnerabilities: make([]api.Vulnerability, 0, len(vulnerabilities)),
+	}
+	if scan.Error != "" {
+		response.Error = &scan.Error
+	}
+	for _, v := range vulnerabilities {
+		response.Vulnerabilities = append(response.Vulnerabilities, api.Vulnerability{
+			Id:               v.VulnerabilityID,
+			PackageName:      v.PackageName,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     optionalString(v.FixedVersion),
+			Severity:         api.VulnerabilitySeverity(v.Severity),
+			Title:            optionalString(v.Title),
+			Target:           optionalString(v.Target),
+		})
+	}
+	return response
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
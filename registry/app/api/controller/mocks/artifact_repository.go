@@ -630,6 +630,36 @@ func (_m *ArtifactRepository) GetByRegistryIDAndImage(ctx context.Context, regis
 	return r0, r1
 }
 
+// ListVersionsByRegistryID provides a mock function with given fields: ctx, registryID
+func (_m *ArtifactRepository) ListVersionsByRegistryID(ctx context.Context, registryID int64) ([]types.PackageVersion, error) {
+	ret := _m.Called(ctx, registryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVersionsByRegistryID")
+	}
+
+	var r0 []types.PackageVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]types.PackageVersion, error)); ok {
+		return rf(ctx, registryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []types.PackageVersion); ok {
+		r0 = rf(ctx, registryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PackageVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, registryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByRegistryImageAndVersion provides a mock function with given fields: ctx, registryID, image, version
 func (_m *ArtifactRepository) GetByRegistryImageAndVersion(ctx context.Context, registryID int64, image string, version string) (*types.Artifact, error) {
 	ret := _m.Called(ctx, registryID, image, version)
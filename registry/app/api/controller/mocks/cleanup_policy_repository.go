@@ -245,6 +245,64 @@ func (_c *CleanupPolicyRepository_GetIDsByRegistryID_Call) RunAndReturn(run func
 	return _c
 }
 
+// GetRegistryIDs provides a mock function with given fields: ctx
+func (_m *CleanupPolicyRepository) GetRegistryIDs(ctx context.Context) ([]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegistryIDs")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CleanupPolicyRepository_GetRegistryIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRegistryIDs'
+type CleanupPolicyRepository_GetRegistryIDs_Call struct {
+	*mock.Call
+}
+
+// GetRegistryIDs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CleanupPolicyRepository_Expecter) GetRegistryIDs(ctx interface{}) *CleanupPolicyRepository_GetRegistryIDs_Call {
+	return &CleanupPolicyRepository_GetRegistryIDs_Call{Call: _e.mock.On("GetRegistryIDs", ctx)}
+}
+
+func (_c *CleanupPolicyRepository_GetRegistryIDs_Call) Run(run func(ctx context.Context)) *CleanupPolicyRepository_GetRegistryIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CleanupPolicyRepository_GetRegistryIDs_Call) Return(ids []int64, err error) *CleanupPolicyRepository_GetRegistryIDs_Call {
+	_c.Call.Return(ids, err)
+	return _c
+}
+
+func (_c *CleanupPolicyRepository_GetRegistryIDs_Call) RunAndReturn(run func(context.Context) ([]int64, error)) *CleanupPolicyRepository_GetRegistryIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ModifyCleanupPolicies provides a mock function with given fields: ctx, cleanupPolicies, ids
 func (_m *CleanupPolicyRepository) ModifyCleanupPolicies(ctx context.Context, cleanupPolicies *[]types.CleanupPolicy, ids []int64) error {
 	ret := _m.Called(ctx, cleanupPolicies, ids)
@@ -2223,6 +2223,74 @@ func (_c *MockTagRepository_HasTagsAfterName_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// ListTagVersionsByRegistryID provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) ListTagVersionsByRegistryID(ctx context.Context, registryID int64) ([]types.PackageVersion, error) {
+	ret := _mock.Called(ctx, registryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagVersionsByRegistryID")
+	}
+
+	var r0 []types.PackageVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]types.PackageVersion, error)); ok {
+		return returnFunc(ctx, registryID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []types.PackageVersion); ok {
+		r0 = returnFunc(ctx, registryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PackageVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, registryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepository_ListTagVersionsByRegistryID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagVersionsByRegistryID'
+type MockTagRepository_ListTagVersionsByRegistryID_Call struct {
+	*mock.Call
+}
+
+// ListTagVersionsByRegistryID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryID int64
+func (_e *MockTagRepository_Expecter) ListTagVersionsByRegistryID(ctx interface{}, registryID interface{}) *MockTagRepository_ListTagVersionsByRegistryID_Call {
+	return &MockTagRepository_ListTagVersionsByRegistryID_Call{Call: _e.mock.On("ListTagVersionsByRegistryID", ctx, registryID)}
+}
+
+func (_c *MockTagRepository_ListTagVersionsByRegistryID_Call) Run(run func(ctx context.Context, registryID int64)) *MockTagRepository_ListTagVersionsByRegistryID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTagRepository_ListTagVersionsByRegistryID_Call) Return(packageVersions []types.PackageVersion, err error) *MockTagRepository_ListTagVersionsByRegistryID_Call {
+	_c.Call.Return(packageVersions, err)
+	return _c
+}
+
+func (_c *MockTagRepository_ListTagVersionsByRegistryID_Call) RunAndReturn(run func(ctx context.Context, registryID int64) ([]types.PackageVersion, error)) *MockTagRepository_ListTagVersionsByRegistryID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LockTagByNameForUpdate provides a mock function for the type MockTagRepository
 func (_mock *MockTagRepository) LockTagByNameForUpdate(ctx context.Context, repoID int64, name string) (bool, error) {
 	ret := _mock.Called(ctx, repoID, name)
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/pkg/conda"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	condatype "github.com/harness/gitness/registry/app/pkg/types/conda"
+	"github.com/harness/gitness/registry/app/store"
+)
+
+type Controller interface {
+	UploadPackageFile(
+		ctx context.Context,
+		info condatype.ArtifactInfo,
+		file io.Reader,
+	) *PutArtifactResponse
+
+	DownloadPackageFile(
+		ctx context.Context,
+		info condatype.ArtifactInfo,
+	) *GetArtifactResponse
+
+	GetRepodata(
+		ctx context.Context,
+		info condatype.ArtifactInfo,
+	) *GetArtifactResponse
+}
+
+// Controller handles Conda package operations.
+type controller struct {
+	registryDao               store.RegistryRepository
+	local                     conda.LocalRegistry
+	proxy                     conda.Proxy
+	quarantineFinder          quarantine.Finder
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker
+}
+
+// NewController creates a new Conda controller.
+func NewController(
+	registryDao store.RegistryRepository,
+	local conda.LocalRegistry,
+	proxy conda.Proxy,
+	quarantineFinder quarantine.Finder,
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker,
+) Controller {
+	return &controller{
+		registryDao:               registryDao,
+		local:                     local,
+		proxy:                     proxy,
+		quarantineFinder:          quarantineFinder,
+		dependencyFirewallChecker: dependencyFirewallChecker,
+	}
+}
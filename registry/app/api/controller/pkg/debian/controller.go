@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/pkg/debian"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	"github.com/harness/gitness/registry/app/store"
+)
+
+type Controller interface {
+	UploadPackageFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+		file multipart.Part,
+	) *PutArtifactResponse
+
+	DownloadPackageFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetArtifactResponse
+
+	GetDistributionFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetArtifactResponse
+
+	GetPublicKey(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetPublicKeyResponse
+}
+
+// Controller handles Debian package operations.
+type controller struct {
+	registryDao               store.RegistryRepository
+	local                     debian.LocalRegistry
+	proxy                     debian.Proxy
+	quarantineFinder          quarantine.Finder
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker
+}
+
+// NewController creates a new Debian controller.
+func NewController(
+	registryDao store.RegistryRepository,
+	local debian.LocalRegistry,
+	proxy debian.Proxy,
+	quarantineFinder quarantine.Finder,
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker,
+) Controller {
+	return &controller{
+		registryDao:               registryDao,
+		local:                     local,
+		proxy:                     proxy,
+		quarantineFinder:          quarantineFinder,
+		dependencyFirewallChecker: dependencyFirewallChecker,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/debian"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// DownloadPackageFile serves a .deb file from the pool of the registry or of one of its upstreams.
+func (c *controller) DownloadPackageFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) *GetArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		debianRegistry, ok := a.(debian.Registry)
+		if !ok {
+			return getArtifactErrorResponse(fmt.Errorf("invalid registry type: expected debian.Registry"), nil)
+		}
+		headers, fileReader, readCloser, redirectURL, err := debianRegistry.DownloadPackageFile(ctx, info)
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	}
+
+	result, err := base.ProxyWrapperWithChecks(ctx, c.registryDao, c.quarantineFinder,
+		c.dependencyFirewallChecker, f, info, true, true)
+	return toGetArtifactResponse(result, err)
+}
+
+// GetDistributionFile serves a Release file or a Packages index. Distributions are resolved in registry
+// order: the first of the registry and its upstreams that has the requested file serves it.
+func (c *controller) GetDistributionFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) *GetArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		debianRegistry, ok := a.(debian.Registry)
+		if !ok {
+			return getArtifactErrorResponse(fmt.Errorf("invalid registry type: expected debian.Registry"), nil)
+		}
+		headers, fileReader, readCloser, redirectURL, err := debianRegistry.GetDistributionFile(ctx, info)
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	return toGetArtifactResponse(result, err)
+}
+
+func toGetArtifactResponse(result response.Response, err error) *GetArtifactResponse {
+	if err != nil {
+		return getArtifactErrorResponse(err, nil)
+	}
+	getResponse, ok := result.(*GetArtifactResponse)
+	if !ok {
+		return getArtifactErrorResponse(fmt.Errorf("invalid response type: expected GetArtifactResponse"), nil)
+	}
+	return getResponse
+}
+
+func getArtifactErrorResponse(err error, headers *commons.ResponseHeaders) *GetArtifactResponse {
+	return &GetArtifactResponse{
+		BaseResponse: BaseResponse{
+			err,
+			headers,
+		},
+		RedirectURL: "",
+		Body:        nil,
+		ReadCloser:  nil,
+	}
+}
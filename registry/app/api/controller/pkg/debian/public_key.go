@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/debian"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+)
+
+// GetPublicKey returns the key clients use to verify the Release files of the registry.
+func (c *controller) GetPublicKey(ctx context.Context, info debiantype.ArtifactInfo) *GetPublicKeyResponse {
+	a := base.GetArtifactRegistry(info.Registry)
+	debianRegistry, ok := a.(debian.Registry)
+	if !ok {
+		return &GetPublicKeyResponse{
+			BaseResponse{
+				fmt.Errorf("invalid registry type: expected debian.Registry"),
+				nil,
+			},
+			"",
+		}
+	}
+
+	publicKey, err := debianRegistry.GetPublicKey(ctx, info)
+	return &GetPublicKeyResponse{
+		BaseResponse{
+			err,
+			nil,
+		},
+		publicKey,
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/store"
+)
+
+type Controller interface {
+	GetServiceDiscovery(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetServiceDiscoveryResponse
+
+	UploadModule(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		file multipart.Part,
+	) *PutArtifactResponse
+
+	UploadProvider(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		file multipart.Part,
+	) *PutArtifactResponse
+
+	ListModuleVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *ListModuleVersionsResponse
+
+	GetModuleDownloadURL(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetModuleDownloadURLResponse
+
+	ListProviderVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *ListProviderVersionsResponse
+
+	GetProviderPackage(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetProviderPackageResponse
+
+	DownloadFile(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetArtifactResponse
+}
+
+// Controller handles Terraform module and provider registry operations.
+type controller struct {
+	registryDao               store.RegistryRepository
+	local                     terraform.LocalRegistry
+	quarantineFinder          quarantine.Finder
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker
+}
+
+// NewController creates a new Terraform controller.
+func NewController(
+	registryDao store.RegistryRepository,
+	local terraform.LocalRegistry,
+	quarantineFinder quarantine.Finder,
+	dependencyFirewallChecker interfaces.DependencyFirewallChecker,
+) Controller {
+	return &controller{
+		registryDao:               registryDao,
+		local:                     local,
+		quarantineFinder:          quarantineFinder,
+		dependencyFirewallChecker: dependencyFirewallChecker,
+	}
+}
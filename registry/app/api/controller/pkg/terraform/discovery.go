@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+)
+
+// GetServiceDiscovery returns the locations of the module and provider registry protocols of the registry.
+func (c *controller) GetServiceDiscovery(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetServiceDiscoveryResponse {
+	a := base.GetArtifactRegistry(info.Registry)
+	terraformRegistry, ok := a.(terraform.Registry)
+	if !ok {
+		return &GetServiceDiscoveryResponse{
+			BaseResponse{
+				fmt.Errorf("invalid registry type: expected terraform.Registry"),
+				nil,
+			},
+			nil,
+		}
+	}
+	return &GetServiceDiscoveryResponse{
+		BaseResponse{
+			nil,
+			nil,
+		},
+		terraformRegistry.GetServiceDiscovery(ctx, info),
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// DownloadFile serves a module archive or a provider package, checksums or signature file.
+func (c *controller) DownloadFile(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return getArtifactErrorResponse(fmt.Errorf("invalid registry type: expected terraform.Registry"), nil)
+		}
+		headers, fileReader, readCloser, redirectURL, err := terraformRegistry.DownloadFile(ctx, info)
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	}
+
+	result, err := base.ProxyWrapperWithChecks(ctx, c.registryDao, c.quarantineFinder,
+		c.dependencyFirewallChecker, f, info, true, false)
+	if err != nil {
+		return getArtifactErrorResponse(err, nil)
+	}
+	getResponse, ok := result.(*GetArtifactResponse)
+	if !ok {
+		return getArtifactErrorResponse(fmt.Errorf("invalid response type: expected GetArtifactResponse"), nil)
+	}
+	return getResponse
+}
+
+func getArtifactErrorResponse(err error, headers *commons.ResponseHeaders) *GetArtifactResponse {
+	return &GetArtifactResponse{
+		BaseResponse: BaseResponse{
+			err,
+			headers,
+		},
+		RedirectURL: "",
+		Body:        nil,
+		ReadCloser:  nil,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// ListModuleVersions lists the versions of a module, served by the first of the registry and its upstreams
+// that has the module.
+func (c *controller) ListModuleVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *ListModuleVersionsResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return &ListModuleVersionsResponse{
+				BaseResponse{fmt.Errorf("invalid registry type: expected terraform.Registry"), nil},
+				nil,
+			}
+		}
+		versions, err := terraformRegistry.ListModuleVersions(ctx, info)
+		return &ListModuleVersionsResponse{BaseResponse{err, nil}, versions}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	if err != nil {
+		return &ListModuleVersionsResponse{BaseResponse{err, nil}, nil}
+	}
+	rs, ok := result.(*ListModuleVersionsResponse)
+	if !ok {
+		return &ListModuleVersionsResponse{
+			BaseResponse{fmt.Errorf("invalid response type: expected ListModuleVersionsResponse"), nil},
+			nil,
+		}
+	}
+	return rs
+}
+
+// GetModuleDownloadURL returns the location of the archive of a module version.
+func (c *controller) GetModuleDownloadURL(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetModuleDownloadURLResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return &GetModuleDownloadURLResponse{
+				BaseResponse{fmt.Errorf("invalid registry type: expected terraform.Registry"), nil},
+				"",
+			}
+		}
+		downloadURL, err := terraformRegistry.GetModuleDownloadURL(ctx, info)
+		return &GetModuleDownloadURLResponse{BaseResponse{err, nil}, downloadURL}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	if err != nil {
+		return &GetModuleDownloadURLResponse{BaseResponse{err, nil}, ""}
+	}
+	rs, ok := result.(*GetModuleDownloadURLResponse)
+	if !ok {
+		return &GetModuleDownloadURLResponse{
+			BaseResponse{fmt.Errorf("invalid response type: expected GetModuleDownloadURLResponse"), nil},
+			"",
+		}
+	}
+	return rs
+}
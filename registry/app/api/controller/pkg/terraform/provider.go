@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// ListProviderVersions lists the versions of a provider with their protocols and platforms.
+func (c *controller) ListProviderVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *ListProviderVersionsResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return &ListProviderVersionsResponse{
+				BaseResponse{fmt.Errorf("invalid registry type: expected terraform.Registry"), nil},
+				nil,
+			}
+		}
+		versions, err := terraformRegistry.ListProviderVersions(ctx, info)
+		return &ListProviderVersionsResponse{BaseResponse{err, nil}, versions}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	if err != nil {
+		return &ListProviderVersionsResponse{BaseResponse{err, nil}, nil}
+	}
+	rs, ok := result.(*ListProviderVersionsResponse)
+	if !ok {
+		return &ListProviderVersionsResponse{
+			BaseResponse{fmt.Errorf("invalid response type: expected ListProviderVersionsResponse"), nil},
+			nil,
+		}
+	}
+	return rs
+}
+
+// GetProviderPackage returns the package of a provider version for one platform, including the key its
+// checksums are signed with.
+func (c *controller) GetProviderPackage(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetProviderPackageResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return &GetProviderPackageResponse{
+				BaseResponse{fmt.Errorf("invalid registry type: expected terraform.Registry"), nil},
+				nil,
+			}
+		}
+		providerPackage, err := terraformRegistry.GetProviderPackage(ctx, info)
+		return &GetProviderPackageResponse{BaseResponse{err, nil}, providerPackage}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	if err != nil {
+		return &GetProviderPackageResponse{BaseResponse{err, nil}, nil}
+	}
+	rs, ok := result.(*GetProviderPackageResponse)
+	if !ok {
+		return &GetProviderPackageResponse{
+			BaseResponse{fmt.Errorf("invalid response type: expected GetProviderPackageResponse"), nil},
+			nil,
+		}
+	}
+	return rs
+}
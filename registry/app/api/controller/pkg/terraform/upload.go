@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+type uploadFunc func(
+	r terraform.Registry,
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file io.Reader,
+) (*commons.ResponseHeaders, string, error)
+
+// UploadModule publishes a module version archive to the registry.
+func (c *controller) UploadModule(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file multipart.Part,
+) *PutArtifactResponse {
+	return c.upload(ctx, info, &file, terraform.Registry.UploadModule)
+}
+
+// UploadProvider publishes the package of a provider version for one platform to the registry.
+func (c *controller) UploadProvider(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file multipart.Part,
+) *PutArtifactResponse {
+	return c.upload(ctx, info, &file, terraform.Registry.UploadProvider)
+}
+
+func (c *controller) upload(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file io.Reader,
+	uploadFn uploadFunc,
+) *PutArtifactResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.RegIdentifier = registry.Name
+		info.RegistryID = registry.ID
+		info.Registry = registry
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return &PutArtifactResponse{
+				BaseResponse{
+					Error:           fmt.Errorf("invalid registry type: expected terraform.Registry"),
+					ResponseHeaders: nil,
+				},
+				"",
+			}
+		}
+		headers, sha256, err := uploadFn(terraformRegistry, ctx, info, file)
+		return &PutArtifactResponse{
+			BaseResponse{
+				Error:           err,
+				ResponseHeaders: headers,
+			},
+			sha256,
+		}
+	}
+
+	result, err := base.NoProxyWrapper(ctx, c.registryDao, f, info)
+	rs, ok := result.(*PutArtifactResponse)
+	if !ok {
+		return &PutArtifactResponse{
+			BaseResponse{
+				Error:           err,
+				ResponseHeaders: nil,
+			},
+			"",
+		}
+	}
+	return rs
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/conda"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (h *handler) DownloadPackageFile(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+	h.serveFile(w, r, info.FileName, h.controller.DownloadPackageFile(r.Context(), *info))
+}
+
+func (h *handler) GetRepodata(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+	h.serveFile(w, r, info.FileName, h.controller.GetRepodata(r.Context(), *info))
+}
+
+func (h *handler) serveFile(
+	w http.ResponseWriter,
+	r *http.Request,
+	fileName string,
+	response *conda.GetArtifactResponse,
+) {
+	ctx := r.Context()
+	defer func() {
+		if response.Body != nil {
+			err := response.Body.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+
+		if response.ReadCloser != nil {
+			err := response.ReadCloser.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close read closer: %v", err)
+			}
+		}
+	}()
+
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+
+	if response.RedirectURL != "" {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+
+	err := commons.ServeContent(w, r, response.Body, fileName, response.ReadCloser)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Failed to serve content: %v", err)
+		h.HandleError(ctx, w, err)
+		return
+	}
+	response.ResponseHeaders.WriteToResponse(w)
+}
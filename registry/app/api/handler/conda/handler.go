@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/conda"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	condatype "github.com/harness/gitness/registry/app/pkg/types/conda"
+	condautil "github.com/harness/gitness/registry/app/utils/conda"
+	"github.com/harness/gitness/registry/request"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetRepodata(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller conda.Controller
+}
+
+func NewHandler(
+	controller conda.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo supports the following paths:
+//   - upload: upload of a package, the name and version are read from the package itself.
+//   - {subdir}/repodata.json: repodata of a subdir.
+//   - {subdir}/{file}: download of a package.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	artifactInfo := &condatype.ArtifactInfo{ArtifactInfo: info}
+
+	subdir := r.PathValue("subdir")
+	if subdir == "" {
+		return artifactInfo, nil
+	}
+	if !condautil.IsValidSubdir(subdir) {
+		return nil, usererror.NotFoundf("subdir not found: %s", subdir)
+	}
+	artifactInfo.Subdir = subdir
+
+	fileName := r.PathValue("file")
+	if fileName == "" {
+		artifactInfo.FileName = condautil.RepodataFile
+		return artifactInfo, nil
+	}
+	name, version, _, _, ok := condautil.ParseFileName(fileName)
+	if !ok {
+		return nil, usererror.NotFoundf("package not found: %s", fileName)
+	}
+	artifactInfo.Image = name
+	artifactInfo.Version = version
+	artifactInfo.FileName = fileName
+	return artifactInfo, nil
+}
+
+func (h *handler) artifactInfo(w http.ResponseWriter, r *http.Request) (*condatype.ArtifactInfo, bool) {
+	info, ok := request.ArtifactInfoFrom(r.Context()).(*condatype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(r.Context(), []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return nil, false
+	}
+	return info, true
+}
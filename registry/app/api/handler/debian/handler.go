@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/debian"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	debianutil "github.com/harness/gitness/registry/app/utils/debian"
+	"github.com/harness/gitness/registry/request"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetDistributionFile(writer http.ResponseWriter, request *http.Request)
+	GetPublicKey(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller debian.Controller
+}
+
+func NewHandler(
+	controller debian.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo supports the following paths:
+//   - pool/{distribution}/{component}/upload: upload of a package into a distribution component.
+//   - dists/{distribution}/*: Release files and indices of a distribution.
+//   - pool/*: package files, either pool/{distribution}/{component}/{file} for packages published by
+//     the registry or the pool layout of an upstream.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	artifactInfo := &debiantype.ArtifactInfo{ArtifactInfo: info}
+
+	distribution := r.PathValue("distribution")
+	component := r.PathValue("component")
+	filePath, err := url.PathUnescape(r.PathValue("*"))
+	if err != nil {
+		return nil, usererror.BadRequestf("failed to decode path: %s", r.PathValue("*"))
+	}
+
+	switch {
+	case distribution != "" && component != "":
+		if !debianutil.IsValidSuite(distribution) || !debianutil.IsValidSuite(component) {
+			return nil, usererror.BadRequestf("invalid distribution [%s] or component [%s]", distribution, component)
+		}
+		artifactInfo.Distribution = distribution
+		artifactInfo.Component = component
+	case distribution != "":
+		if !debianutil.IsValidSuite(distribution) || !isValidFilePath(filePath) {
+			return nil, usererror.NotFoundf("path not found: %s/%s", distribution, filePath)
+		}
+		artifactInfo.Distribution = distribution
+		artifactInfo.PackagePath = debianutil.DistsPrefix + "/" + distribution + "/" + filePath
+		artifactInfo.FileName = path.Base(filePath)
+	case filePath != "":
+		if !isValidFilePath(filePath) {
+			return nil, usererror.NotFoundf("path not found: %s", filePath)
+		}
+		name, version, arch, err := debianutil.ParseFileName(filePath)
+		if err != nil {
+			return nil, usererror.NotFoundf("path not found: %s", filePath)
+		}
+		if segments := strings.Split(filePath, "/"); len(segments) == 3 &&
+			debianutil.IsValidSuite(segments[0]) && debianutil.IsValidSuite(segments[1]) {
+			artifactInfo.Distribution = segments[0]
+			artifactInfo.Component = segments[1]
+		}
+		artifactInfo.Image = name
+		artifactInfo.Version = version + "_" + arch
+		artifactInfo.Arch = arch
+		artifactInfo.FileName = path.Base(filePath)
+		artifactInfo.PackagePath = debianutil.PoolPrefix + "/" + filePath
+	}
+	return artifactInfo, nil
+}
+
+func isValidFilePath(filePath string) bool {
+	if filePath == "" || strings.HasSuffix(filePath, "/") {
+		return false
+	}
+	return path.Clean("/"+filePath) == "/"+filePath
+}
+
+func (h *handler) artifactInfo(w http.ResponseWriter, r *http.Request) (*debiantype.ArtifactInfo, bool) {
+	info, ok := request.ArtifactInfoFrom(r.Context()).(*debiantype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(r.Context(), []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return nil, false
+	}
+	return info, true
+}
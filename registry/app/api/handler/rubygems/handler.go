@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+	"github.com/harness/gitness/registry/request"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetIndexFile(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller rubygems.Controller
+}
+
+func NewHandler(
+	controller rubygems.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// indexFiles are the index files served from the registry root.
+var indexFiles = map[string]bool{
+	rubygemsutil.VersionsFile:        true,
+	rubygemsutil.NamesFile:           true,
+	rubygemsutil.SpecsFile:           true,
+	rubygemsutil.LatestSpecsFile:     true,
+	rubygemsutil.PrereleaseSpecsFile: true,
+}
+
+// GetPackageArtifactInfo supports the following paths:
+//   - api/v1/gems: gem push.
+//   - gems/{file}: download of a .gem file.
+//   - info/{name}: compact index info file of a gem.
+//   - versions, names and the specs files.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	artifactInfo := &rubygemstype.ArtifactInfo{ArtifactInfo: info}
+
+	fileName := r.PathValue("file")
+	name := r.PathValue("name")
+	switch {
+	case fileName != "":
+		image, version, ok := rubygemsutil.ParseFileName(fileName)
+		if !ok {
+			return nil, usererror.NotFoundf("gem not found: %s", fileName)
+		}
+		artifactInfo.Image = image
+		artifactInfo.Version = version
+		artifactInfo.FileName = fileName
+	case name != "":
+		if !rubygemsutil.IsValidName(name) {
+			return nil, usererror.NotFoundf("gem not found: %s", name)
+		}
+		artifactInfo.IndexPath = rubygemsutil.InfoPrefix + "/" + name
+		artifactInfo.FileName = name
+	case indexFiles[path.Base(r.URL.Path)]:
+		artifactInfo.IndexPath = path.Base(r.URL.Path)
+		artifactInfo.FileName = artifactInfo.IndexPath
+	}
+	return artifactInfo, nil
+}
+
+func (h *handler) artifactInfo(w http.ResponseWriter, r *http.Request) (*rubygemstype.ArtifactInfo, bool) {
+	info, ok := request.ArtifactInfoFrom(r.Context()).(*rubygemstype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(r.Context(), []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return nil, false
+	}
+	return info, true
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// UploadPackageFile handles gem push, which sends the .gem file as the request body.
+func (h *handler) UploadPackageFile(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+	defer r.Body.Close()
+
+	response := h.controller.UploadPackageFile(r.Context(), *info, r.Body)
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+
+	response.ResponseHeaders.WriteToResponse(w)
+	_, err := fmt.Fprintf(w, "Successfully registered gem.\nSha256: %s", response.Sha256)
+	if err != nil {
+		h.HandleError(r.Context(), w, err)
+		return
+	}
+}
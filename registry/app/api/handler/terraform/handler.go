@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/request"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	GetServiceDiscovery(writer http.ResponseWriter, request *http.Request)
+	UploadModule(writer http.ResponseWriter, request *http.Request)
+	ListModuleVersions(writer http.ResponseWriter, request *http.Request)
+	GetModuleDownloadURL(writer http.ResponseWriter, request *http.Request)
+	UploadProvider(writer http.ResponseWriter, request *http.Request)
+	ListProviderVersions(writer http.ResponseWriter, request *http.Request)
+	GetProviderPackage(writer http.ResponseWriter, request *http.Request)
+	DownloadFile(writer http.ResponseWriter, request *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller terraform.Controller
+}
+
+func NewHandler(
+	controller terraform.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo supports the following paths:
+//   - v1/modules/{namespace}/{name}/{system}[/{version}[/files/{file}]]: modules.
+//   - v1/providers/{namespace}/{type}[/{version}[/{os}/{arch}|/files/{file}]]: providers.
+//
+// Paths without any of these parameters, such as service discovery, only resolve the registry.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	artifactInfo := &terraformtype.ArtifactInfo{ArtifactInfo: info}
+
+	namespace := r.PathValue("namespace")
+	version := r.PathValue("version")
+	fileName := r.PathValue("file")
+	switch {
+	case r.PathValue("type") != "":
+		providerType := r.PathValue("type")
+		if !terraformutil.IsValidNamespace(namespace) || !terraformutil.IsValidType(providerType) {
+			return nil, usererror.BadRequestf("invalid provider namespace [%s] or type [%s]", namespace,
+				providerType)
+		}
+		artifactInfo.Kind = terraformmetadata.KindProvider
+		artifactInfo.Namespace = namespace
+		artifactInfo.Name = providerType
+		artifactInfo.Image = terraformutil.ProviderImage(namespace, providerType)
+
+		if os, arch := r.PathValue("os"), r.PathValue("arch"); os != "" || arch != "" {
+			if !terraformutil.IsValidPlatform(os, arch) {
+				return nil, usererror.BadRequestf("invalid platform [%s_%s]", os, arch)
+			}
+			artifactInfo.OS = os
+			artifactInfo.Arch = arch
+		}
+		protocols, err := terraformutil.ParseProtocols(r.URL.Query().Get("protocols"))
+		if err != nil {
+			return nil, usererror.BadRequest(err.Error())
+		}
+		artifactInfo.Protocols = protocols
+	case r.PathValue("name") != "":
+		name := r.PathValue("name")
+		system := r.PathValue("system")
+		if !terraformutil.IsValidNamespace(namespace) || !terraformutil.IsValidNamespace(name) ||
+			!terraformutil.IsValidSystem(system) {
+			return nil, usererror.BadRequestf("invalid module namespace [%s], name [%s] or system [%s]", namespace,
+				name, system)
+		}
+		artifactInfo.Kind = terraformmetadata.KindModule
+		artifactInfo.Namespace = namespace
+		artifactInfo.Name = name
+		artifactInfo.System = system
+		artifactInfo.Image = terraformutil.ModuleImage(namespace, name, system)
+	default:
+		return artifactInfo, nil
+	}
+
+	if version != "" {
+		if !terraformutil.IsValidVersion(version) {
+			return nil, usererror.BadRequestf("invalid version [%s], expected a semantic version", version)
+		}
+		artifactInfo.Version = version
+	}
+	if fileName != "" {
+		if !isValidFileName(artifactInfo, fileName) {
+			return nil, usererror.NotFoundf("file not found: %s", fileName)
+		}
+		artifactInfo.FileName = fileName
+	}
+	return artifactInfo, nil
+}
+
+// isValidFileName reports whether fileName is one of the files the registry stores for the version.
+func isValidFileName(info *terraformtype.ArtifactInfo, fileName string) bool {
+	if info.Kind == terraformmetadata.KindModule {
+		return fileName == terraformutil.ModuleFileName(info.Namespace, info.Name, info.System, info.Version)
+	}
+	if _, _, ok := terraformutil.ParseProviderFileName(info.Name, info.Version, fileName); ok {
+		return true
+	}
+	return fileName == terraformutil.ShasumsFileName(info.Name, info.Version) ||
+		fileName == terraformutil.ShasumsSignatureFileName(info.Name, info.Version)
+}
+
+func (h *handler) artifactInfo(w http.ResponseWriter, r *http.Request) (*terraformtype.ArtifactInfo, bool) {
+	info, ok := request.ArtifactInfoFrom(r.Context()).(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(r.Context(), []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return nil, false
+	}
+	return info, true
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.HandleErrors(r.Context(), []error{err}, w)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"net/http"
+)
+
+func (h *handler) ListModuleVersions(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+	response := h.controller.ListModuleVersions(r.Context(), *info)
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+	h.writeJSON(w, r, response.Versions)
+}
+
+// GetModuleDownloadURL answers with the location of the module archive in the X-Terraform-Get header, as
+// the module registry protocol requires.
+func (h *handler) GetModuleDownloadURL(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+	response := h.controller.GetModuleDownloadURL(r.Context(), *info)
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+	w.Header().Set("X-Terraform-Get", response.DownloadURL)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+)
+
+func (h *handler) UploadModule(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, false)
+}
+
+func (h *handler) UploadProvider(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, true)
+}
+
+func (h *handler) upload(w http.ResponseWriter, r *http.Request, provider bool) {
+	file, _, err := utils.GetFileReader(r, "file")
+	if err != nil {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage(fmt.Sprintf("failed to parse file: %s, "+
+			"please provide correct file path ", err.Error())), w)
+		return
+	}
+	defer file.Close()
+
+	info, ok := h.artifactInfo(w, r)
+	if !ok {
+		return
+	}
+
+	uploadFn := h.controller.UploadModule
+	if provider {
+		uploadFn = h.controller.UploadProvider
+	}
+	result := uploadFn(r.Context(), *info, *file)
+	if result.GetError() != nil {
+		h.HandleError(r.Context(), w, result.GetError())
+		return
+	}
+
+	result.ResponseHeaders.WriteToResponse(w)
+	_, err = fmt.Fprintf(w, "Pushed.\nSha256: %s", result.Sha256)
+	if err != nil {
+		h.HandleError(r.Context(), w, err)
+		return
+	}
+}
@@ -36,6 +36,7 @@ import (
 	maven2 "github.com/harness/gitness/registry/app/pkg/maven"
 	mavenutils "github.com/harness/gitness/registry/app/pkg/maven/utils"
 	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
 	"github.com/harness/gitness/registry/request"
 	"github.com/harness/gitness/registry/types"
 	"github.com/harness/gitness/store"
@@ -382,6 +383,33 @@ func TrackDownloadStatsForPythonPackage(
 	}
 }
 
+func TrackDownloadStatsForTerraformPackage(
+	h packages.Handler,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithoutCancel(r.Context())
+				sw := &StatusWriter{ResponseWriter: w}
+				next.ServeHTTP(sw, r)
+				if sw.StatusCode != http.StatusOK && sw.StatusCode != http.StatusTemporaryRedirect {
+					return
+				}
+				info := request.ArtifactInfoFrom(r.Context()) //nolint:contextcheck
+				if info == nil || !terraformutil.IsMainArtifactFile(info.GetFileName()) {
+					return
+				}
+				err := h.TrackDownloadStats(ctx, r)
+				if err != nil {
+					log.Ctx(ctx).Error().Stack().Str("middleware",
+						"TrackDownloadStatsForTerraformPackage").Err(err).Msg("error while putting download stat of terraform artifact")
+					return
+				}
+			},
+		)
+	}
+}
+
 func TrackDownloadStats(
 	packageHandler packages.Handler,
 ) func(next http.Handler) http.Handler {
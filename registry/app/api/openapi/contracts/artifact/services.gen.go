@@ -90,12 +90,18 @@ type ServerInterface interface {
 	// Get Artifact Version Summary
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/summary)
 	GetArtifactVersionSummary(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactVersionSummaryParams)
+	// Get Artifact Version Vulnerabilities
+	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/vulnerabilities)
+	GetArtifactVersionVulnerabilities(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactVersionVulnerabilitiesParams)
 	// List Artifact Versions
 	// (GET /registry/{registry_ref}/artifact/{artifact}/versions)
 	GetAllArtifactVersions(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, params GetAllArtifactVersionsParams)
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetAllArtifactsByRegistryParams)
+	// List versions deleted by cleanup policies
+	// (GET /registry/{registry_ref}/cleanup-policy/dry-run)
+	GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams)
@@ -105,6 +111,12 @@ type ServerInterface interface {
 	// quarantineFilePath
 	// (PUT /registry/{registry_ref}/quarantine)
 	QuarantineFilePath(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
+	// Get registry scan policy
+	// (GET /registry/{registry_ref}/scan-policy)
+	GetScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
+	// Update registry scan policy
+	// (PUT /registry/{registry_ref}/scan-policy)
+	UpdateScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
 	// ListWebhooks
 	// (GET /registry/{registry_ref}/webhooks)
 	ListWebhooks(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params ListWebhooksParams)
@@ -303,6 +315,12 @@ func (_ Unimplemented) GetArtifactVersionSummary(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get Artifact Version Vulnerabilities
+// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/vulnerabilities)
+func (_ Unimplemented) GetArtifactVersionVulnerabilities(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactVersionVulnerabilitiesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List Artifact Versions
 // (GET /registry/{registry_ref}/artifact/{artifact}/versions)
 func (_ Unimplemented) GetAllArtifactVersions(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, params GetAllArtifactVersionsParams) {
@@ -315,6 +333,12 @@ func (_ Unimplemented) GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List versions deleted by cleanup policies
+// (GET /registry/{registry_ref}/cleanup-policy/dry-run)
+func (_ Unimplemented) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Returns CLI Client Setup Details
 // (GET /registry/{registry_ref}/client-setup-details)
 func (_ Unimplemented) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
@@ -333,6 +357,18 @@ func (_ Unimplemented) QuarantineFilePath(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get registry scan policy
+// (GET /registry/{registry_ref}/scan-policy)
+func (_ Unimplemented) GetScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update registry scan policy
+// (PUT /registry/{registry_ref}/scan-policy)
+func (_ Unimplemented) UpdateScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ListWebhooks
 // (GET /registry/{registry_ref}/webhooks)
 func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params ListWebhooksParams) {
@@ -1593,6 +1629,60 @@ func (siw *ServerInterfaceWrapper) GetArtifactVersionSummary(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
+// GetArtifactVersionVulnerabilities operation middleware
+func (siw *ServerInterfaceWrapper) GetArtifactVersionVulnerabilities(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "artifact" -------------
+	var artifact ArtifactPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "artifact", chi.URLParam(r, "artifact"), &artifact, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "artifact", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version VersionPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", chi.URLParam(r, "version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtifactVersionVulnerabilitiesParams
+
+	// ------------- Optional query parameter "artifact_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artifact_type", r.URL.Query(), &params.ArtifactType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "artifact_type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetArtifactVersionVulnerabilities(w, r, registryRef, artifact, version, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetAllArtifactVersions operation middleware
 func (siw *ServerInterfaceWrapper) GetAllArtifactVersions(w http.ResponseWriter, r *http.Request) {
 
@@ -1770,6 +1860,31 @@ func (siw *ServerInterfaceWrapper) GetAllArtifactsByRegistry(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
+// GetCleanupPolicyDryRun operation middleware
+func (siw *ServerInterfaceWrapper) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCleanupPolicyDryRun(w, r, registryRef)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetClientSetupDetails operation middleware
 func (siw *ServerInterfaceWrapper) GetClientSetupDetails(w http.ResponseWriter, r *http.Request) {
 
@@ -1899,6 +2014,56 @@ func (siw *ServerInterfaceWrapper) QuarantineFilePath(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
+// GetScanPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetScanPolicy(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScanPolicy(w, r, registryRef)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateScanPolicy operation middleware
+func (siw *ServerInterfaceWrapper) UpdateScanPolicy(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateScanPolicy(w, r, registryRef)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListWebhooks operation middleware
 func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
 
@@ -2921,12 +3086,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/version/{version}/summary", wrapper.GetArtifactVersionSummary)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/version/{version}/vulnerabilities", wrapper.GetArtifactVersionVulnerabilities)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/versions", wrapper.GetAllArtifactVersions)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifacts", wrapper.GetAllArtifactsByRegistry)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/cleanup-policy/dry-run", wrapper.GetCleanupPolicyDryRun)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/client-setup-details", wrapper.GetClientSetupDetails)
 	})
@@ -2936,6 +3107,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/registry/{registry_ref}/quarantine", wrapper.QuarantineFilePath)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/scan-policy", wrapper.GetScanPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/registry/{registry_ref}/scan-policy", wrapper.UpdateScanPolicy)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/webhooks", wrapper.ListWebhooks)
 	})
@@ -3048,8 +3225,24 @@ type ArtifactVersionSummaryResponseJSONResponse struct {
 	Status Status `json:"status"`
 }
 
+type ArtifactVulnerabilitiesResponseJSONResponse struct {
+	// Data result of the latest vulnerability scan of an artifact version
+	Data ArtifactVulnerabilities `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type BadRequestJSONResponse Error
 
+type CleanupPolicyDryRunResponseJSONResponse struct {
+	// Data Artifact versions that the next cleanup run deletes
+	Data CleanupPolicyDryRun `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type ClientSetupDetailsResponseJSONResponse struct {
 	// Data Client Setup Details
 	Data ClientSetupDetails `json:"data"`
@@ -3219,6 +3412,14 @@ type ReplicationRuleResponseJSONResponse struct {
 	Status Status `json:"status"`
 }
 
+type ScanPolicyResponseJSONResponse struct {
+	// Data vulnerability scan policy of a registry
+	Data ScanPolicy `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type SuccessJSONResponse struct {
 	// Status Indicates if the request was successful or not
 	Status Status `json:"status"`
@@ -4729,6 +4930,75 @@ func (response GetArtifactVersionSummary500JSONResponse) VisitGetArtifactVersion
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetArtifactVersionVulnerabilitiesRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+	Artifact    ArtifactPathParam    `json:"artifact"`
+	Version     VersionPathParam     `json:"version"`
+	Params      GetArtifactVersionVulnerabilitiesParams
+}
+
+type GetArtifactVersionVulnerabilitiesResponseObject interface {
+	VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error
+}
+
+type GetArtifactVersionVulnerabilities200JSONResponse struct {
+	ArtifactVulnerabilitiesResponseJSONResponse
+}
+
+func (response GetArtifactVersionVulnerabilities200JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetArtifactVersionVulnerabilities400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetArtifactVersionVulnerabilities400JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetArtifactVersionVulnerabilities401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetArtifactVersionVulnerabilities401JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetArtifactVersionVulnerabilities403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetArtifactVersionVulnerabilities403JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetArtifactVersionVulnerabilities404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetArtifactVersionVulnerabilities404JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetArtifactVersionVulnerabilities500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetArtifactVersionVulnerabilities500JSONResponse) VisitGetArtifactVersionVulnerabilitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetAllArtifactVersionsRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Artifact    ArtifactPathParam    `json:"artifact"`
@@ -4864,6 +5134,72 @@ func (response GetAllArtifactsByRegistry500JSONResponse) VisitGetAllArtifactsByR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetCleanupPolicyDryRunRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+}
+
+type GetCleanupPolicyDryRunResponseObject interface {
+	VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error
+}
+
+type GetCleanupPolicyDryRun200JSONResponse struct {
+	CleanupPolicyDryRunResponseJSONResponse
+}
+
+func (response GetCleanupPolicyDryRun200JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetCleanupPolicyDryRun400JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetCleanupPolicyDryRun401JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetCleanupPolicyDryRun403JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetCleanupPolicyDryRun404JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCleanupPolicyDryRun500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetCleanupPolicyDryRun500JSONResponse) VisitGetCleanupPolicyDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetClientSetupDetailsRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Params      GetClientSetupDetailsParams
@@ -5045,6 +5381,135 @@ func (response QuarantineFilePath500JSONResponse) VisitQuarantineFilePathRespons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetScanPolicyRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+}
+
+type GetScanPolicyResponseObject interface {
+	VisitGetScanPolicyResponse(w http.ResponseWriter) error
+}
+
+type GetScanPolicy200JSONResponse struct{ ScanPolicyResponseJSONResponse }
+
+func (response GetScanPolicy200JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetScanPolicy400JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPolicy401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetScanPolicy401JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPolicy403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetScanPolicy403JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetScanPolicy404JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetScanPolicy500JSONResponse) VisitGetScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicyRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+	Body        *UpdateScanPolicyJSONRequestBody
+}
+
+type UpdateScanPolicyResponseObject interface {
+	VisitUpdateScanPolicyResponse(w http.ResponseWriter) error
+}
+
+type UpdateScanPolicy200JSONResponse struct{ ScanPolicyResponseJSONResponse }
+
+func (response UpdateScanPolicy200JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateScanPolicy400JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicy401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response UpdateScanPolicy401JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicy403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateScanPolicy403JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateScanPolicy404JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateScanPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UpdateScanPolicy500JSONResponse) VisitUpdateScanPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListWebhooksRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Params      ListWebhooksParams
@@ -6427,12 +6892,18 @@ type StrictServerInterface interface {
 	// Get Artifact Version Summary
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/summary)
 	GetArtifactVersionSummary(ctx context.Context, request GetArtifactVersionSummaryRequestObject) (GetArtifactVersionSummaryResponseObject, error)
+	// Get Artifact Version Vulnerabilities
+	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/vulnerabilities)
+	GetArtifactVersionVulnerabilities(ctx context.Context, request GetArtifactVersionVulnerabilitiesRequestObject) (GetArtifactVersionVulnerabilitiesResponseObject, error)
 	// List Artifact Versions
 	// (GET /registry/{registry_ref}/artifact/{artifact}/versions)
 	GetAllArtifactVersions(ctx context.Context, request GetAllArtifactVersionsRequestObject) (GetAllArtifactVersionsResponseObject, error)
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(ctx context.Context, request GetAllArtifactsByRegistryRequestObject) (GetAllArtifactsByRegistryResponseObject, error)
+	// List versions deleted by cleanup policies
+	// (GET /registry/{registry_ref}/cleanup-policy/dry-run)
+	GetCleanupPolicyDryRun(ctx context.Context, request GetCleanupPolicyDryRunRequestObject) (GetCleanupPolicyDryRunResponseObject, error)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(ctx context.Context, request GetClientSetupDetailsRequestObject) (GetClientSetupDetailsResponseObject, error)
@@ -6442,6 +6913,12 @@ type StrictServerInterface interface {
 	// quarantineFilePath
 	// (PUT /registry/{registry_ref}/quarantine)
 	QuarantineFilePath(ctx context.Context, request QuarantineFilePathRequestObject) (QuarantineFilePathResponseObject, error)
+	// Get registry scan policy
+	// (GET /registry/{registry_ref}/scan-policy)
+	GetScanPolicy(ctx context.Context, request GetScanPolicyRequestObject) (GetScanPolicyResponseObject, error)
+	// Update registry scan policy
+	// (PUT /registry/{registry_ref}/scan-policy)
+	UpdateScanPolicy(ctx context.Context, request UpdateScanPolicyRequestObject) (UpdateScanPolicyResponseObject, error)
 	// ListWebhooks
 	// (GET /registry/{registry_ref}/webhooks)
 	ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error)
@@ -7171,6 +7648,35 @@ func (sh *strictHandler) GetArtifactVersionSummary(w http.ResponseWriter, r *htt
 	}
 }
 
+// GetArtifactVersionVulnerabilities operation middleware
+func (sh *strictHandler) GetArtifactVersionVulnerabilities(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactVersionVulnerabilitiesParams) {
+	var request GetArtifactVersionVulnerabilitiesRequestObject
+
+	request.RegistryRef = registryRef
+	request.Artifact = artifact
+	request.Version = version
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetArtifactVersionVulnerabilities(ctx, request.(GetArtifactVersionVulnerabilitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetArtifactVersionVulnerabilities")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetArtifactVersionVulnerabilitiesResponseObject); ok {
+		if err := validResponse.VisitGetArtifactVersionVulnerabilitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetAllArtifactVersions operation middleware
 func (sh *strictHandler) GetAllArtifactVersions(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, params GetAllArtifactVersionsParams) {
 	var request GetAllArtifactVersionsRequestObject
@@ -7226,6 +7732,32 @@ func (sh *strictHandler) GetAllArtifactsByRegistry(w http.ResponseWriter, r *htt
 	}
 }
 
+// GetCleanupPolicyDryRun operation middleware
+func (sh *strictHandler) GetCleanupPolicyDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	var request GetCleanupPolicyDryRunRequestObject
+
+	request.RegistryRef = registryRef
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCleanupPolicyDryRun(ctx, request.(GetCleanupPolicyDryRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCleanupPolicyDryRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCleanupPolicyDryRunResponseObject); ok {
+		if err := validResponse.VisitGetCleanupPolicyDryRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetClientSetupDetails operation middleware
 func (sh *strictHandler) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
 	var request GetClientSetupDetailsRequestObject
@@ -7313,6 +7845,65 @@ func (sh *strictHandler) QuarantineFilePath(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// GetScanPolicy operation middleware
+func (sh *strictHandler) GetScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	var request GetScanPolicyRequestObject
+
+	request.RegistryRef = registryRef
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetScanPolicy(ctx, request.(GetScanPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetScanPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetScanPolicyResponseObject); ok {
+		if err := validResponse.VisitGetScanPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateScanPolicy operation middleware
+func (sh *strictHandler) UpdateScanPolicy(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	var request UpdateScanPolicyRequestObject
+
+	request.RegistryRef = registryRef
+
+	var body UpdateScanPolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateScanPolicy(ctx, request.(UpdateScanPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateScanPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateScanPolicyResponseObject); ok {
+		if err := validResponse.VisitUpdateScanPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListWebhooks operation middleware
 func (sh *strictHandler) ListWebhooks(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params ListWebhooksParams) {
 	var request ListWebhooksRequestObject
@@ -7879,139 +8470,155 @@ func (sh *strictHandler) GetAllRegistries(w http.ResponseWriter, r *http.Request
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x96XLkNpbuq+Dy3o6w1VnKstt3YkIT/UOlrdRWSdmplB2OdoUEkchMdDFJGgClkisU",
-	"Mb/mAWbesJ9kAgtJkARIMDelyvxjq5JYDg6+s+AAOPji+fEiiSMUMeodfPESSOACMUTEvy7gPQrpiP/G",
-	"/xkg6hOcMBxH3oH8uO8NPMz/9VuKyJM38CK4QN6BF/KP3sCj/hwtIK+MGVqIRtlTwktQRnA0854H2Q+Q",
-	"EPjkPT8PvDGaYcrI03mAIoanGBELCVlBUJS00EPQ7BbrhVYibPKUoDaSeBkLMUx+KkhAUbrwDv7h/XQ+",
-	"ntwcXngD72Z0PRmfHH7wPg6qdD0PPEgYnkKfWWg4FJ+ZpfescomCpj7Y3NLPJVwgEE9BVjQHQwLZ3Ngh",
-	"Qb+lmKDAO2AkRW4ENDA7KwJ47f2W8d5a2b6IAwHWADJIETPz3J/jMPgJEYrjyELOES8CHmQZgCMfUsGf",
-	"49j/hEjOJmqjVO+iZXYCFCKGTnHIrMIhP4JpTACNp+yNrBIALgQMI2ohQhUr9R+gKUxD5h146LMfpgFn",
-	"Y8a84hccZX/FUfhk5mKAZ4iyq8QG3WPx3cYgWbuNNaLQau13wekUh4hLgoOgHGZ4PcUhskgLb+5W/N2d",
-	"jAYSss+WkYteFSGNvZB4cQyZTSD5p31wGpMFZOAN+PBheHw8/OWXX36xdUviRUuPIWSIskwqDFaIfwbq",
-	"O5Cgt1slXvj2wS5i93EcIhiJnhPof4Iz5KLsR7Jok9JXrdW1UAf7k8AZukwX94gYlE9KCIoY4GVAJAvZ",
-	"KJkhs3h/N/CmYu68Aw9H7N9+8HIicMTQDJGcjGv8OzIAXfTLoS5GBRJEgOrORAnljRgp+f6tGykE+Smh",
-	"+ME2Qz/PEZsjAlgMQkwZIHLGMKIgrxo+7f8a/Rrt7R2jhCAfMhTs7+2BG4oAmyMQoUdwR/04QXcgd49k",
-	"DXCXN/JXLqF3APzrv/5blf4rjHxEWUzoXaXoFIYU3elFozhCd79GVudF1TTzSjQ3MCFYjfZpjKYNquEm",
-	"wr+lCHDpB4WPJAwHH/8URzDMGPcEcCR+vScw8uf7YDJH4AGGKQI+jMA9AgmJH3DA7QwWnIcUQDBNw/AJ",
-	"3Iwv3qDIj/lX0ds3aH+2PwB3MZnBCP8OOUF/+v40IfE/kc/+9P1p1uvdtyBWTSUhxJGsjqIARzPwiNkc",
-	"QMAIxCH/dxKmFFA8i8A3d3+++5ZXo4jPHIuJscuh6nCYdTf88923+8V0lBV0VuiWoGlHHZ2VvU6gj8Zo",
-	"+nc+z6vMCuUNlacEfJP1Isrm8+YTJAb77UbnbEsTVZ6fqlbhTFlidoQoWmZjb++af+WaTVMhSqvs7XEB",
-	"39vjUry3B/71n/8DfKWN5QRxfwh8owT2WwAAL52rB2OVvT3Onb09AMOQq538C1XVOX0oCmDEHBoQnmVe",
-	"/9fofAriBWYMBQNwJ5QPwBRAStMFCho4y3lgdKHzwXA3uqCMV40jZPYFKYLEn08QMfBbfgP8o82YyyK3",
-	"jNdvmdiYsFOMwsDQT/7J0klM2O1UFWjr44oEJstcfGroI1YFGvtQamNVXW7QGl+fUigr7aV1wgY19R9K",
-	"ETcxmcVrXFKwuKW3h8Y1fLH8NjX+4LQ4z3twXxCqbi1rwqLbLthVtRrWL9mqadIQPlGt2KMnx+dnJ9cT",
-	"b+BNDs/Miv4R3c/j+NPJZ+SnvOfzoF2DqToAZZU00bJwSVW5zavc4qAjy1QTeuDRlVBn8kphSHfilPuI",
-	"KHsXBxiJFWMGHxGKHcuv/Hc/jhiKxJ8wSULsS6H9J5Ur6KKT/8eF88D7v8MiCjyUX+nQ2PizDD7pfFBU",
-	"cWcoTQLIUB7oAiIKTD0tcrpuIqvtNtDH9bFwghGAUZDRmvnHksicjHEaovXTamx+CZLzdgBJQ8RJ/1mC",
-	"a90kV5rtTKrCPKfwtxQSGDEcrZ2v9ZabUVqUBzRBPp5iH0xxKC24WqfRJI5oWciOEYM4HKtPnahPSJwg",
-	"wpTUBpA5C5/slPOPMshS2lbvWpbSF5tcR6vKMsStKen4nltxM7/kODnDZogVMh0IioRQZ0SeYo7m1fkS",
-	"P0ZhDIMbEta1bfYRpCTU9xy8QT1utiZWaeR05dgcwaBgGQeXzi+lUbcKpOt0sYBSze0KkoR1ANlnnUG8",
-	"b7ptBvE+d4k9vClqZo+cyx5BtCApo1K5tC/DonLnO8CpoLzzmO9Naox7B4N1G+QTQmJiIu8dDADJbPTA",
-	"Owoxitg1Ymki7dy2ZL7e8UvOlXCdBEWAcpJ0Eyu3jl/EBTF1vYOQDnLCygR/gBGeIspehFtZ5zvIr4VG",
-	"miT6Aj4hQrfKJ9nlTvoknLCCN9lEbpc9ea+7yRru729VFV1gyopOd4kp3LUXPHmPwsWLqOl6xzvAnzkK",
-	"FyYVrRO7ZQVt6nrnOKUr5/OIIRLB8BqRB0SkT7VxDy3rFFDRK0Cy4MDjIvgS69davy/tqYljJIYIp07o",
-	"C/Bmp9hS5YdaF70AW7LtnF3gjlp80VL4SnHqA54RwYHzBZyhLTKq3PEL8Glc49MiIwlgTlMuXVc+zqZ1",
-	"Amd0i0yq9LwTaGJwRgGOprGAUwSujs5rqMp2R15AL1W73kn9VOwebZ0vO8EPffNLElfZodoiW0o974Qe",
-	"qu6z5YpI7YrRfEN7i4yq9f0S2kiwR+3t0WKLvhyt1ql9AQbthIA9asRcxuw0TqNg8078ZJ7vbKKAz0mc",
-	"Eh+BR0hBFDMwFVQ8D7xRCHE0QZ9tdoGhz2woTg/9B/DnkFDE/pqy6Zt/L9OIPsNFEnLWvEdhGA/AY0zC",
-	"4P/Ud+bqlB6qw0m8pxJ4tqyZd0Uryx30gYwxOB5S2BKDdko/V1WzYhQn6zr1fUTpCvxYx8BcRqQoBWMN",
-	"9zcRTNkcRQyLewib1xXVDnMaYoJ/3x4BqrfiJMu2bWu12xdAeP3Im64R86M422THjupD47GiEWTzLXGn",
-	"3OkLMEk7wiTO9RZAec7OEMqzS0LD/IierpFPEPsRPdUHDLMyxjtosNyCdtHaobS453IulEjrZS5zZcFf",
-	"U080G1ALRXm5brSUq1moqE6jgaSPzwPvMIqjp0Us4KGdVFDBesvtbZ8BVWDgBZh/X+AIMhkDXsAk4RQc",
-	"fPGODsdnV9Z9bkhmcbm/ozia4pk38I6vjn48GXfZ/M2rnp1cnozPj2x1z1CECPZtla3UntlIfX9y8cF9",
-	"+6OodnN2dn55dnp4dGKtnc5mOJqdQh9ZGvlw+NPJpa36B/iAIkvFy5GV5svERvLlzdnJxFotnSFmqTj6",
-	"ZfL+ykrn6InNYxuhYzuhYwuhz4NMhzxdlm63ivuvzwMvjtDV1Dv4R/cTBnkPXXe9HCs2gbOtrn2622o2",
-	"TEBb1ctkuYGOl6xnR1lbTbu2aZ2U5aq1Se/zx0HV1mk5JVwPdWWYlr5IcMiMdkZ9fWe2otlZ0qM4lR6J",
-	"gwnC9O+5lQ9MV9QH3iIOxBrfQpO8eGD4oEtrCxdGZcHWz1pD5UnVba66G1778FDc4m82pSLwfymTIOiX",
-	"X+QyWOUxSAk3jvpY6q5TYW5PIobZ0wfEYObhwSDA3ObCcKSBRN7DsJhk2QjIW2nor3onowxEtW/Y7fq/",
-	"ziHVQNOI9bFaxqMNZH2CojKISFBWIlN4gSiDiwTgCCxwGGKK/DgKKHicI3k3Lr+w/AhpKXGJ6aR3Jld0",
-	"zYLVfXp4Hco+KIE0Vlho8+HCzwpiNyPQSRqGR/FiASMz0U4CT2oJmxqLWZ16ouVXcg3ZZQPJ6t7cnB8b",
-	"G09THKymlfKsLLXRVtOU1HSVPkGKlArJTZIsz6bXpOk9JBGitLhFKMsNLPcqushIVidL8+FQhcUMhtcs",
-	"Jlp2EIdqadKpn+cmNqnzhA6MUiW35x/svFLMLa6J+mX0YYtzsrzKWlbSG3yM1cQzG0M1kDVFROSIKKUp",
-	"01Jntecdq91rcLDp2aXezdh2kSErB5ZdcXQC3xSHaO0WvLfGa7LGVjmz+vluAvhi5rRyTaeeckAema9J",
-	"1CasxhbtQrOKd5CpDS8bt+DBmdeVS6IpZXOz5j8sdtQ4bCpa/4YiMoKUPsaE92MI0eshY5NNsAda6lnh",
-	"xO9iL0XUqt/jrYJ54bhCru5RGDh0xIGTJqM4xL5BzNRnIL8LGmuO2jhPtlQjFH1OMEHH8ImaLVGbeh4R",
-	"NMWfu/k0WWaPzlXN7KndQjPwSNwLE4XAsW3KII7eIxjYt0Cav8pjRPpoHC/PXcu6rcEKjUCdHK3zj838",
-	"yTpq5k9WqnnH5Pzy4vzyxGV0DCV5lHxy+O7aVmcC76sV6hFy1ik0biajLSBqIqQWA50vixTmoPfVFEi9",
-	"X0EBs4XoKoNtm2VepDooXzpNy6FYcEs6XQaZn6/GkUpHOWfauKC5gS3MAFnRgSnIaDaYMEyRJRlTK10W",
-	"S9M6R5ShZOkJ6qxSc2ZbKC0VqtroawYZ9r2BJ7aLIEOT+BOKjMbYeE221aXM93YrMG6OIax/Ye+yybDy",
-	"QmljkYC25ZL2/d2TTP28+rLKfiTBuhwi4c5shzTsuTY5j+Zb13VXpHlGnlsJym/mtUpQXrLuDRVNNLM1",
-	"L2lnlLiofBIxp6iivNVss01dMFMhNGuhhU7aGgCVxawL2cAuIur2r6v2rnHPYFhjekh8h3M1iir74DMo",
-	"WL1o55lqVr927mxqH8rKotV2Ys0Mzic577ed5Q3MLopU2dxskhZ60x3AVkWBffm2nMI1MSO/lVuV+MDg",
-	"d0zmCMwZS+SlWiAKDbTT8z+8/cEYzreh+jBfnWfqGMD7OGUiMiQv7hpIXiBK4cxCHhFQUqElldgN4tAU",
-	"T6qpKDGarHUjsz4zAov1RSVRuzr8KgqBfIFY5usnyyHFBaSfUOCSFL3R9dXH80nEYWRh02C0dASmlzeQ",
-	"1cObI/8TTRcdN1PcHMMmX6ghHNLNnzFHcVUq/2J4dar0UahuTZxtOqHV5KLMZL12H6XUgpOPctY92na2",
-	"3VCbIQtEXTGjcNG6AsmvSDYcclrn8uQPsLr4OhYO1hOXTVJgygOyjkWDMZlHC+A3vWBoO43YyCdZdwp9",
-	"tFW18bcpiWf63a7solnNpeFqXuTztgiZ3M/ILgk4FNLO49twn6uPlGCTZ5RSRCxmrTJxEuLFGEzzV8qm",
-	"UXe25IVOLWc3tepO6lLdG7g5t7XTfAbvgDeUK926VydO5qg3gfK3eXTX0+XdH/nsT4deEpG7Qe/l7Vvn",
-	"fs6jAH029+NrDx3pzbs3bn67iLcd2d8v0pllfIioQFuBgzacXWQRWhtaDCtXcRKntqTaCgKWOQbUo8YR",
-	"NQ0nek0ZbRxUTJ5xxqqpfsoKdGytk+aqHl3qFdjrV2BNy98G7XUq0uVV0SiT6C3TjhMOy5kDe+jtNPQk",
-	"Fmywq2SraoBMLYmUMQi5GcVXz6nVg26nQVcwSp8arW99jIMMOjaQVrOFrWSpt4OU2J3kq6PzgmzGCztK",
-	"RZktvau4Al6r02VDor6cd7atDUfuerX1sjDIQ3e4o79UmlMnac2gY/faK5jUKGuD4w5GWaqk9YuVr2ix",
-	"Uk1e1YCbes6/Xge+5Oy/NSrBtGW9qE04GKdhF61XS3PWqPQ6Oo6ScBtM88yFDpq9UOhFjsEeqbtmrR8d",
-	"ZtQ8k05o1ZJ0NaI0b7cNeVpS0eUwqOUCNdzXcGm8tdEunCllc+vN+E6bcW2SjTCNfRg67VQ6XX80O6+l",
-	"zF0GIuwJgJo2dxe8Vvu2blbAsic6I3GanLtukNcDZYbol6Un8Y0v0Y2buCSeEZVf05A2LU+D50CjLbNR",
-	"Ey+jZLHVDXJ7NqRGKlPjk5kbpLMSXKkRV4raTOBMZI7vcv7XbZ9dHQZrOLE7Kh/HsV2ZV6dRtCsZKl9d",
-	"lpEtT3lWpKNTieKyLGoyBZvMbzZQefJE+rlyZjjTnY6GdF1N056Ialudd3uExxYDMFxfDsP4EQUjyBgi",
-	"Ubfd3fsw9j8tWdevXg91vBek1zI1m0+Uy4K/uK+3O/k7Gs8nDjzcfK8f01F6H2J/fZmNNnbCzna+rVP+",
-	"gNJ76uoeV/1Im8YX1f7HBnHqU2i1XV1owthLJdgqJ43YUka8rWXb2HJSjaq4OGREqOjUGnSv03tlLYt3",
-	"12MCfsKEpTAEMQE3CWUEwYVurJruct+Mrifjk0NrAtOsvfwa90/n48nN4YWtvCJlTZe4q601l67QWr+4",
-	"zTpnRXO9gF0L9rt7E+36r5Ni2SEzu5xi3LZtblWKK5x/b1NV17Zz62yJ/H2r+QLK7ldUmK78pEvQxQmw",
-	"Ju/sHebd9FNXgTpBERvz8q1XKgzuptVyFu02Aa1tMcwrys0ppd7wPgIPhdVMleUwGUuLActW1Zk9HBSm",
-	"1LQgtgTe3MygNXTXZhGttxOeP9YfwmkTU7qKnK73th6ijE+QkhvX/SjtDSCthQw72YQKZnvqZoc38M6O",
-	"RsYZbRHO1iWfXV4GnnxvasmxycrLDatJVBVRZfaXuqvzdVDDUB0YOjNKfNNhYJb+ykNOTtZmmzDeCaDu",
-	"Cpg2hR8jNJaIjI9HH7YaedQzPTXYLl+mBaIiLZBK95Vl2+lqrFTmLpWMywSW63z/ofqsdSDet6IAT0u3",
-	"28VCQb7DNU2FMY1ipicCujk6Orm+9gbe6eH5xc2Y934yHl+Njd3r+bcMCxh4r9IjUVN6pPn2c7TVJtWQ",
-	"QKxlGMDPltaVxSq8dye3xDc3QgmezUx5BDSvSRUpJvNwPDk/PTya3B6NTw4n52LzIP/t+OTiRPxmmtjK",
-	"2twih6k6cW7MZJg1MSLxZ9PZSpjK1ZabT1VKItnmRxXZJFtL1pNRCl8LarkuG+tn5YTyXMQM3ZDwOp2q",
-	"bImVPalEZa8QT1hRUQrAJEFRgAIxg0JQeSvgZnwh2MrmmOZ+8D44jQmQmzW5E0wHspDQvBTED4gQHOBo",
-	"JppT+SHA3ZDiRRKiO9l5SlEg2h89jc7f8IFBhu9DBHAUoM+I7oMLBEUjMAoAIxCH/B80hHSOKIAEiZc1",
-	"M3shSj3iMAT3/ANZwBD/joL9XyOv0cLle10iock8vfcG3lFKWcxd+8NHeuJzOItd6CMUMSKs2OhphD2x",
-	"m/o3jiqxY3lFuEQeEa7yuIGLOeqevNIlZSPQUw2laql3igl6hGH4QSU1cYlh2apb1/nVM30ZjmrWceB9",
-	"flOyG29UQo9iAaPJa8Mwqik75FewiAMExHtxEoOwQBZIeGv7ukK5uLj62Rt4Px+OuS55d3F19KNZf+ji",
-	"Wn9w0uVpNbrEi2rU4SG1lCJy6XaJOivJNUI5ttlBIWbRXpfz5mlFZ66YcDU7L2WNbo7RTJ09yop2yxBV",
-	"egTIKdrZHEFBEbwPbVsoqEin425p9Rw8pjNRLSGbiCI/Jciy7RNxjxaGtoAOQ5Tpz3qKPD3O57hUhRVe",
-	"PXpRMVPeSAefSLkvhllySAvi8o6GdalcZEvwCgxqs//RLltypvKVbJuYvZ9MRpmsgaxeVebu48Cc9mle",
-	"gN8xdcpzG+XF86EdSVcV10K79RRV9ulI2TCXx1HqItTgM9eeoDUuhcYnk/H54buLk1u5FOKLo8nhxa19",
-	"YVQ7j+mugsGJRotRGbsqW2WNHIujLLWbIXTm2AQpBMFZyckaonKBRXcVmT8XTJbXrwQpZXU1dR6oqsFV",
-	"hVn9qwIuiwhN8yk8OmriBvhbo2tflwn+o9q+qjXLmFQyXxYTZ7JmlQeea9qq8v5yy1lexxdwrPyzvHpB",
-	"XJ6AcexfuQ7uglZ2HkonTryBPv6czmY+28PfTQnpqmesagUa+drAQOcMa7C4aGgd57OQ22mcvUquRiOF",
-	"teFoxRsQoAcUcm5QhdkDb85YQg+Gw8fHx/25rLqPYyEqmIXNDR6OzrVEcAfed/tv99+K8ywJimCCvQPv",
-	"L+Inufkv+D8k+kn72OTXHQk7DGDeEV8jc6rlCfQgL6IfQYUELhATWsES6yqKDDOOCz0zRtO/p4g8jfh3",
-	"cU5GGdp3ytkyNVYUwajYzDbYWzHo799+Z29IldMaKczuD2/ftld8BwOt4x9c+rqJYPGoDApkvb+41osJ",
-	"/l1W+v8u9J2rhdw1Ig+IyBy34mH5LL10NuP6fItUAAf/8LTl/EdeKcfP8Ev21y1B02cJoxAxg7d9LH7X",
-	"AAWwPOADfT9OI6ZCgQjM8AOKgMzVWgacbGIFwGVzO+XqQ4daCSYO3LyW2wuvAR0/vP2hvdJlzE7jNFon",
-	"nGrzbcPTwJshgwIaI5aSiBZwUTmgu8PmDLFdwMxrVC0vBR7b5NsxlKQGDN0kgdggXEXpiIN4T5sA0Nrt",
-	"Ww/CtYKwjp4lTOIwcyaHxek3o767wJRVU0LWfa5aokm6JkQOWuslcIYuxbVQ19LiLKlDWYog8ecTRJZV",
-	"rTWu9PBuh7cJcBrAi2Qtjvim2YPWRnifIVZ503rfZKhLr2OfxmTNercdi1MSL44hQ84VWKwVXwq9pTH3",
-	"yG1Hbh1Lq+D2S/aXy/Ila33fsjjRchRtB68Z8UtVmjwlqF8GbWMZpOFiDUDVfIkGv7fdm5DlXsifWCty",
-	"O/rSFWdhBYe6dzuW8qrX6XhocrF+H2S3xaH3Vv643sqQFi8DOsBdFm4GfPGE4Nfvu1QG3SO5K5JzsKwD",
-	"y0xlKLaGRiioprsxK+9qouadxvKOh1QqvOxFxDGoYkqnvQ4hUZu8wy/qjy4LVqBSRLQtXItMEjssN2r8",
-	"/Zp3t7f+ohr6NiUIQ+1B1XZfqNhMsrpCRZHX5QptRnb8OQ6Dn7KKq/tckru9PXERJY7ie2QC74YkSdxT",
-	"chKo6vPtDXJleqKefn1GRiZJ7NrFqibJxNxeuDoIlxnImohVCqxV0opX+J0FLX/qvkXOiifxv2IxW0Fk",
-	"JH96UVlBVHKIbUNU9Fd9nYVFeyO4RVz014R7gWmyMRmnetFZQXQ0uG1TeOhS0kPdxecrNDhrddRyPvXS",
-	"swbp2bjtmeIQDb/w/95GcIGereLzz5Qy8ABDLDY40WdMGYp8VHoTkDfTFHc4ld/7oAMVfL+EC7TquWud",
-	"tb3EddzlUXjdTKghfyO4PWQ3VY8DNwpOH67b+LZSTNgVCdwa5oVPMQqDrWxYFS9C90K+TFwxk7DNiPoc",
-	"hQunmOJ7FC6cIoq84FcfT1yT31nnVS8jHWTEhElNUkqf1yguTtGOMm1NsQ4dBK810rEy+vvAxcr4N4Qt",
-	"NiABnQ63qa1Jp0NuquxrPeu2yfjgVcLWsdAqc7iXtI5LrgqY1+uStR2yg2Eo7sNWqbGclA7DyqR/jUuw",
-	"176cchB/cWDnFIcMkVU0gH4PUwGiF/+uNzE1SVpW8LtKOZXZxou7lk2STt89bf1Wprwk0kvs2k8zyqf0",
-	"yw/k9SLbUWRr4tM5SYDMqP9GZNR/0xYmyZJjHF2cA5kUXuVuzzKk3EOKAhBH2YurWW7+mlRrKeVfLoTS",
-	"1a1dHuz14fZQd8/FYoPbMngvcsQ1HWGXv4Pi4UkRpMyy85lOsRdFT7Mkca8E0G77Yf2J962kQgvsYMqg",
-	"riHYmn7oNxfgbgyyy9yLruduXOpSdDnV5h8wl95vztBp0pIqU267JyC8kXiap1e2bZvwcj9njX4FeYN2",
-	"dzdS5/QfEP8VoGXIz38SKtOYdzSDdBuUZbZK7UmHF9KYlVzUSyUdzdv4g+YcLWbRABQXBTn8ov66LRJC",
-	"uyUjLbo2uZPrhVe72slTo2eD6POUbumyYiMEWzKUtqmqM8RePZBeoYp6wc2cFjQZFwuuaJL5c3YOUL3Z",
-	"3P2kS5uxs8P8YZn2hUrt/Zc8Zsk9xqb1yknRyS5gfnPLnpWXG/pzXL1guC9USgjbkIAU3/PfbnHwvLzc",
-	"NDgbpTeWXoHAPFbIPg/W5LT0ArGU96LjZ7viMMzfnmoSDFnC+KRYWSTGSL091AtGLxgrbIPZUWQVj/wB",
-	"+yFJm+66iAAuDEOgVQGyiskn0l/GT5e590Jtb+4sd36gRE0PJsfjA6a5LnZS828NwdL8kaZqU9a3mkoz",
-	"tT7YdH7KooKYlV606NG33EtPRtiYAWjUZsMvynVtjbK2wjN72akFnpi3qnZR1Utr6p267PE2RlI08OSb",
-	"g6aH3voo6oZfe3KG1MB+rt8BMOJNp91ES6+Qljr/3gk6TVnvHdAjS24LQL1xfIX56ddiHIcLPJOwG+IF",
-	"nLUtAPLSQJZWr5LBCAjY1dcBH7IK57L1DSD4NZ7vWHolU+ZnLy2OC5kqbtchKcMv4v8iHBTGpeTgNU8g",
-	"n7aLeEZPYyJmb0PCYGpEEbp512IUQhxN0Of+iL6jU1Egk2NIHNOHCqWrgZQySJj99eRr/lnrvUmRi7I5",
-	"hPtFz+tBWGWWV0VUnDQBKk6c8RQnPZxeJZz0OW5EkwjE0eEX8f/O7zBmRQFVr9G0PsMo3mhfOlzYP2n0",
-	"Na7XqyDK0CqwQtuB6npJtSjfci91O/jMrvnpm3n9tVRVOoQM0fwNEadBihuK67jG2l9f7bhs0wXLVXhJ",
-	"ccPPTXqLCrYMEtqlwa0IcB1y7kLf542onIfwU0LxgztPqB+v78J6L+nOO82aiNVFnVcQDUihq560ya+1",
-	"pyT0DrwhTPDw4Tsxf6qtap3D0TkFLAa+2GgcgFTEVAcgrBGjViCaDuAgMrc2Q0w1oWsu1ULhBTQ2ANTl",
-	"ehBPgcyLbWqslkfYuc05ChemFisZsuztGVn2WFylUu3lJ02ePz7/bwAAAP//bZ11OfBDAQA=",
+	"H4sIAAAAAAAC/+x9+3LcttLnq2C5+1Ul/sYaJ192a8tb54+xbp4vsqQzGiWVOnHJEImZwTGHZABQ0sSl",
+	"qv1rH2D3Dc+TbOFCEiQBEpybxg7/SeQhLo3GrxuNRqPxxfPjZRJHKGLUe/vFSyCBS8QQEf+6gPcopNf8",
+	"N/7PAFGf4IThOPLeyo9H3sDD/F9/pIisvIEXwSXy3noh/+gNPOov0BLyypihpWiUrRJegjKCo7n3PMh+",
+	"gITAlff8PPAmaI4pI6txgCKGZxgRCwlZQVCUtNBD0PwO64U2Imy6SlAbSbyMhRgmPxUkoChdem//4f0y",
+	"nkxvRxfewLu9vplOTkcfvI+DKl3PAw8ShmfQZxYaRuIzs/SeVS5R0NQHW1j6uYRLBOIZyIrmYEggWxg7",
+	"JOiPFBMUeG8ZSZEbAQ3MzooAXvuoZbx3VrYv40CANYAMUsTMPPcXOAx+QYTiOLKQc8yLgAdZBuDIh1Tw",
+	"5yT2PyOSs4naKNW7aJmdAIWIoTMcMqtwyI9gFhNA4xl7LasEgAsBw4haiFDFSv0HaAbTkHlvPfTkh2nA",
+	"2Zgxr/gFR9lfcRSuzFwM8BxRdpXYoHsivtsYJGu3sUYU2qz9Ljid4RBxSXAQlFGG1zMcIou08ObuxN/d",
+	"yWggIftsGbnoVRHS2AuJlyeQ2QSSfzoCZzFZQgZegw8fhicnw99+++03W7ckXrb0GEKGKMukwrAK8c9A",
+	"fQcS9PZViRe+e7CL2H0chwhGoucE+p/hHLko+2tZtEnpq9bqWqjD+pPAObpMl/eIGJRPSgiKGOBlQCQL",
+	"2SiZI7N4/zDwZmLuvLcejtj/+MnLicARQ3NEcjJu8J/IAHTRL4e6GBVIEAGqOxMlFP9poeTHN26kEOSn",
+	"hOIH2wz9ukBsgQhgMQgxZYDIGcOIgrxquDr6Pfo9evXqBCUE+ZCh4OjVK3BLEWALBCL0CD5RP07QJ5Cb",
+	"R7IG+JQ38jcuoZ8A+Nf/+b+q9N9g5CPKYkI/VYrOYEjRJ71oFEfo0++R1XhRNc28Es0NTAhWo11N0KxB",
+	"NdxG+I8UAS79oLCRxMLBxz/DEQwzxq0AjsSv9wRG/uIITBcIPMAwRcCHEbhHICHxAw74OoMF5yEFEMzS",
+	"MFyB28nFaxT5Mf8qevsOHc2PBuBTTOYwwn9CTtC//XiWkPifyGf/9uNZ1uun70GsmkpCiCNZHUUBjubg",
+	"EbMFgIARiEP+7yRMKaB4HoHvPv37p+95NYr4zLGYGLscqg6HWXfDf//0/VExHWUFnRW6I2jWUUdnZW8S",
+	"6KMJmv2dz/Mms0J5Q+UpAd9lvYiy+bz5BInBfr/TOdvTRJXnp6pVOFPWmB0hipbZePXqhn/lmk1TIUqr",
+	"vHrFBfzVKy7Fr16Bf/3v/wd8pY3lBHF7CHynBPZ7AAAvnasHY5VXrzh3Xr0CMAy52sm/UFWd04eiAEbM",
+	"oQFhWeb1f4/GMxAvMWMoGIBPQvkATAGkNF2ioIGznAdGEzofjDfwNMp41ThCZluQIkj8xRQRA7/lN8A/",
+	"2hZzWeSO8fotExsTdoZRGBj6yT9ZOokJu5upAm19XJHAtDIXnxr6iFWBxj6U2thUlxu0xrenFMpKe22d",
+	"sENN/ZdSxE1MZvEWtxQsbuntoXEPX2y/TY0/OG3O8x7cN4SqW8uesOi2C3ZVrYb9S7Zrmja4T1Qrdu/J",
+	"yfj89GbqDbzp6Nys6B/R/SKOP58+IT/lPY+Ddg2m6gCUVdJEy8IlVeUur3KHg44sU03ojkdXQp3JK7kh",
+	"3YlT5iOi7F0cYCR2jBl8hCt2Ir/y3/04YigSf8IkCbEvhfafVO6gi07+GxfOt95/HRZe4KH8SofGxp+l",
+	"80nng6KKG0NpEkCGckcXEF5g6mme020TWW23gT6uj4URjACMgozWzD6WROZkTNIQbZ9WY/NrkJy3A0ga",
+	"Ik76jQ+j6zjE/tY5XG/ZCQN8maN8VUtEXeEmLjH7VykO2ya30mxn5iop5RT+kUICI4ajrSOh3nIzT4vy",
+	"gCbIxzPsA+6xE4u62lnSJI5oWS2cIAZxOFGfOlGfkDhBhCk9E0DmrC5kp5x/lEGW0lZ8yVL69pivKqqy",
+	"dMpry0p8z+0OM7/kODnD5ogVWigQFAk1lBHJ/a/b4Ev8GIUxDG5JWF8fso8gJaF+SuIN6p6+LbFKI6cr",
+	"xxYIBgXLOLh0fqk1YK9AukmXSyh1xaEgSaxnIPusM4j3TffNIN7nIbGHN0XN7JFz2SOIFiRlVCoj/GVY",
+	"VO78ADgVlM9K89NUE+PSMEIE3uNQnGfum3Pl3l+AddmAhSVTY9hDnb53MNi2JXNKSExMxL2DASCZcTPw",
+	"jkMEozSRhuQJWU3SaE/zZej5pefKlyRlpnFAVoBIqo5DjCJ2g1iaSDuK7o1L1Y5fUhdIJmHhzOYk6Sac",
+	"DKZ4ERPX1PUBqswgJ6xM8AcY4Rmi7EW4lXV+gPxaaqRJoi/gChG6Vz7JLg/S5uWEFbzJJnK/7Ml7PUzW",
+	"8P3kXlXRBaas6PSQmMK3joIn71G4fBE1Xe/4APizQOHSpKJ1YvesoE1dHxyndOU8jhgiEQxvEHlARJqe",
+	"Ozdks04BFb0CJAsOPC6CL+EfqfX70paaCKwy+Px1Ql+ANwfFlio/1L77Bdiiej4I7qi9Ki25RxWnPuA5",
+	"ERwYL+Ec7ZFR5Y5feq8o+LTMSAKY05RL15WPs2mdwjndI5MqPR8EmhicU4CjWSzgFIGr43ENVdl54Qvo",
+	"pWrXB6mfivPUvfPlIPihn1BK4ipntntkS6nng9BD1ZPnXBGpU1eah3jskVG1vl9CGwn2qLNjWgStlE9D",
+	"dGpfgEEHIWCPGjGXMTuL0yjYvRE/XeQn54j7pWmcEh+BR0hBFPNQAE7F88C7DiGOpujJti4w9MSGIp7u",
+	"fwF/AQlF7G8pm73+n2Ua0RNcJiFnzXsUhvEAPMYkDP5L/eS3TulIhevxnkrg2bNmPhStLCM0BtLH4Bi2",
+	"sycGHZR+rqpmxahqbNBeWFN0+PJcUbcftFAkwZLU9xGlG/BhG6NyGY6iFEw0VXAbwZQtUMSwuKy0e/VZ",
+	"7TCnISb4z/0RoHorgsf2bW5Uu30BeNfjYvVFIo9+2yc7DnSJMEby8XjePXGn3OkLMKkgQAb/F0B5zgKN",
+	"Zbig0DA/o9UN8gliP6NVfcAwK2O8qArLLWjZGBxKi8twY6FEWm98misL/pp6otmAWijKy3WjpVzNQkV1",
+	"Gg0kfeRBLVEcrZaxgIcW46LOLywpHnwGVIGBF2D+fYkjyKRbfAmThFPw9ot3PJqcX1mP/iGZx+X+juNo",
+	"hufewDu+ujwZWSvGUQAtFU9O341Hl9YzPXSPYWSrenX88+mkyxl8XvX89PJ0Mj621T1HESLYt1W2cujc",
+	"xp73pxcf3E+himq35+fjy/Oz0fGptXY6n+NofgZ9ZGnkw+iXUyuDP8AHZOPv5bWV5svERvLl7fnp1Fot",
+	"nSNmqXj92/T9lZXO6xVbxDZCJ3ZCJ1ZCJ7fvfjs//XBjrZner87RklqqT08nk9HZ1cTa8xQRArluMDbw",
+	"PMj05uqydO1fJAZ4HnhxhK5m3tt/dA80yXvoevjpWLFJONrq2uHWVrMBAG1VbWBtqzdZs54d5W017Rq2",
+	"dVLWq9amPdrqN+jntqpt8tEyN03S2cpm63r0/HFQtWe05EKuEZ+ZDEt7Mxgxoy2hvr4zW0pZiP5xnEqr",
+	"08HMwPTvuSUXmHKVDLxlHAjXloUmeQPN8EHXTi1cuC4rMv0KC1TWcq15qpKE1D48FOlcms0lcd51KbPh",
+	"6Lcg5bZeJbRJSeiVx1I3jwuT6jRimK0+IAYzKx4GAWY4jmB4rYFEXsizmF2yEZC30tBf9XJeGYjquLxb",
+	"HhidQ6qBphHrY7WMRxvI9gRFpZIasXrHU7xElMFlwq9EL3EYYor8OAooeFwgeUk6991wL62ewcob2OWK",
+	"blmwuk8Pr0PZByWQxgpLbT5c+FlB7G4EOknD8DheLmFkJtpJ4Ektc19jMevGjWiJ9lw91dlAsrq3t+MT",
+	"Y+NpioPNtFKenqs22mq+qpqu0idIkVIhuUmS5ZWfmjS9hyRClBbXyWW5geW6WhcZyepk+Z4cqrCYwfCG",
+	"xURLE+VQLU069fPcxCYVRuvAKFVyf/bBwSvFfMU1Ub+OPmwxTtZXWetKeoONsZl4ZmOoOitniIhkQaV8",
+	"ld6gQwLK2nUxhzVdldzR2i5SJebAsiuOTuDjwcNbX8H71XhLq7FVzqx2vpsAvthyWrn9WM89I2+K1CRq",
+	"F6vGHteFZhXvIFM73jbuwYIz7ys3RFPlxqVhIaBpyHh0Ip8yiezSPU11Ti3DF6s3Omto8wlm2Ieho65E",
+	"Wbx8jWsLPF84NhLGj44llyjA6dKx8B9tiON8iawmhPxqHpzboZs+dysev5Cdwg28NPocxY+R40ge6iDI",
+	"LSVnAlo3/flhoM64eudGtKZsYbZTRsUZP1dyFRvllvJsSJQ+xoR3ZTg01A+xTBaM3Q1aT2Yrfhenu6JW",
+	"PZlHVRiWjv6c6qmpgUPqxvAxjAIsYmjsJlZ22ZotIAOYgizH9v0KwMo13xrFrYaJ0kbmb7JNs/a0KlZn",
+	"9Zh1XVKO2TBUBxXT6qOdk9c5sZVZVgyS38Vs1zZokzzbZo2BKvP4NWQMkcikbudpCAlATwlBVF40WELm",
+	"L0TKOzlCEMEloiJsIL+MICYTEgQixC/8FAup+5YHPSWYoBO4ombj+DNCyQWkhrU+yjMoL2MRceujqHxR",
+	"AkF/kZNvpbXeZ5uVek3QDD9129oVme7cpyCegccF9hcgjhBYQLExEtMiVsXHRRwibcnrTkvnYTy3IVdl",
+	"DWhVAwo6Mmn0E8sVAEkjNTN1EPuZlnFfKmr6qW210PowS2nt7r9BVHkZIAqBE5sOhjh6j2Bgj7Jo/sr7",
+	"6sKGnOwbWbeVERqBOjla5y38yTpq5k9WqjkoY3x5Mb48dRkdQ0lxQD16Zz3bnsL7aoX6gTTrdBJtJqP1",
+	"ENBASO0IbrEuUpjDtkNNgdx2VFDAbCdElcG2zTIvUhNnuWdfD8WCW6K+Sb8tNuNIpaOcM21c0LwQLcwA",
+	"WdGB6YzLbJDAMEWWpLCtdFlMx9Y5ogwla0+Q6/JRZ7aF0lKhqtHNdyHY58FJKEIEMjSNP6PIbF1bT78b",
+	"rWtea6/WdUNsQROhstp+KTWle2n1Ealy9c1646HA9j31LlEDG3s+d+bab/N/at/freSjPpv7Se1xpFb/",
+	"JgkPJr6hIWisSbDM2YPqgtU8I+3yk2eYaJWgvGTdviyaaGZrXtLOKJFw5zRiTseEojC1rfZdMFM1zVUL",
+	"LXTS1hNNWczqmQ7sIqKy2LiuhzXuGUyVmI6I7xAMraiyDz6DgnVf4jxTzerXzp1dBZZYWbRZaJWZwfkk",
+	"5/22s7yB2UWRKpubl6Sl3nQHsFVRYN/8r6dwTczIs8tUJT4wWHL8eumCsUQmhwGi0EC7BfrTm5+M5/M2",
+	"VI9ywyVTxwDex6l0LYg+TAFnS0Sp8hfWyZNOO3VWpBJgQxyaDohqKkqMJmvdyKwnRmCxY6s8waVuLIlC",
+	"IN9yl/n62XKzZAnpZxS4PHfVuJnQx/NZuKplYdNgtLRapjcVkdXCWyD/M02XHaMj3AzDJluowbHXzZ4x",
+	"H8uKwgNteHWq9FGobk2cbQoxbzJR5rJeu41SasHJRjnvfiBxvt/TCEM2s7pi5imz2nYgGdFNUcvb3J78",
+	"BXYX38bGwXplpEkKTPnstrFpMCalawH8rjcMbdcpGvkk686gj/aqNv5zRuK5nqNAIaNu0ogTsAT6tjVE",
+	"HvlmNzsdCmmXKG24z9VHSrDJMkopIpZlrTJxEuLFGEzzV8oKVze2ZGIS7TUmatWd1KW6fmrlovh0lVe1",
+	"DnhDudKtW3Ui1BZE5VdXddPT5UVX+aBrh14SkYNM7+XNG+d+xlGAnsz9+NoTtnrz7o2bX6XlbUf2l2l1",
+	"ZhmfmC3QVuCgDWcXmc/bhhbDzlWE1ta2VHtBwDpxvT1qHFHTcEXHlJnRQcXkJ85WTfVLVqBja500VzUW",
+	"uVdgX78Ca9r+NmivM5H2uYpGmQx6nXaccFjOgN1D76ChJ7Fgg10l62oDZGrJUI1OyN0ovnpu2B50Bw26",
+	"glH61Gh962McZNCxgbSa9XajlXo/SIndSeb5cnOyGS/sKBVltvSm4gZ4rU6XDYn6dt55bW2Ipe3V1svC",
+	"IHfdYUTXn1Mnac2gY7faK5jUKGuD4wF6Waqk9ZuVb2izUk3C2oCbeu7qXge+5Oy/MbUrJ6ZhFrUJB5M0",
+	"7KL1aul6G5VeR8NREm6DaZ6B20GzFwq9yJXdI/XQVutHhxk1z6QTWrXMqo0ozdttQ56WHH89DGo57Q0X",
+	"sVwab220C2dKKXj7Zfygl3Ftko0wjX0YOp1UOuUzMBuveh0TEfYMhk2Hu0teq/1YNytgOROdkzhNxq4H",
+	"5HVHmcH7ZelJfONbdNPHhMRzopKi14FSXKN2oNGWmrGJl1Gy3OsBuT2dYyOVaemxwz3QWXGu1IgreW2m",
+	"cC5eQOoS/+t2zi5KDZoidq/L4Ti2HDjF1eLskotK+JultM1zxhb5fFWm3SwNrcxhKxPEDlRyY5G/t5xa",
+	"N89CrKd11TLEZumNTbdpGvKSNsEjEdX2ig+7J8jmKzDkLQnD+BEF2jVe91Pg+5DH6K5X16/eD+9y/7V4",
+	"YaLWbD5RLo6B4qbk4STuaoxjHHi4OaEPptfpfYj97aU03Fkkni0OrlPiIKwnDGIqeUst9E3ji2r/Y4M4",
+	"9bkz2644NGHspTJrlrNF7SkV7t7SbO05m1ZVXBxSIVV0ag26N+m9/JS9xuWLZfMXTFgKQxATcJtQRhBc",
+	"6otV0y362+ub6eR0ZM3XnrWXX6D/ZTyZ3o4urHl4JClbuj5fba25dIXW+pV51jkdquvV99qhgLs10a7/",
+	"OimWA1pm11OM+16bXfMIrRMn36aqbmzx7WyNxL2b2QJq3a+oMF35SZOgixFgzdrdG8yHaaduAnWCIjZB",
+	"M0M/FaSZzE3rylm02wS0tk0zrygPsZR6w0cIPBSrZqpWDtNiaVnAst13th4OiqXUtCG2OOjclkGri69t",
+	"RbTeYnj+WH/4sU1M6SZyut1bfYgyPkFKblzPrQqu6S1k2MkmVDDbUzdAuF/k+No4oy3C2brls8vLwJPv",
+	"q645Nll5vWE1iaoiqsz+Und1vg5qGKoDQ2dGiW86DMzSX3m41Gm12SeMDwKohwKmXeHHCI01POiT6w/7",
+	"9Tw2PdbTSKiquFdqtUdpa7QZcvDKbJd8zYX5qtsxqwSK4H245os9RQ6IG57YEbNVtwy2WaXaWZyi6WMj",
+	"h6yaqHFIL0KzluetwX7yZVIwKpKCqWR/Wa6trgaTytunUvGZFNZNflZWpmccBVxDIQrwrJSJQWxW5UO/",
+	"s1QYdFHM9DRgt8fHpzf8xOJsNL64nfDeTyeTq4mxez37nmETDe9VcjRqSo622H+GxtqkGtIHtgwD+Jl7",
+	"pzwaBu/dyS3xzY3QxsfOmlRgXhMs44A/1w2jACQkfsABInvVi1OC53NEmqSHqSIFIEeT6fhsdDy9O56c",
+	"jqZjcViX/3ZyenEqfjOBs+LjsvAoVTc8jCmBsyauSfxkimWGKVu4701Kea3b9iNFguvWkvX82GLPArX0",
+	"2431s3JCGS5jhm5JeJPOVG7byhlworLFiHd+qSgFYJKgKECBmEGhbHgr4HZyIdjKFpjmK9sROIsJkIee",
+	"+WaSDmQhYcFQED8gQnCAo7loTuVjAZ+GFPNIkE+y85SiQLR/vboev+YDgwzfhwhgHsKC6BG4QFA0whHP",
+	"CMQh/wcNIV0gKlMZxwxk+l+UesRhCO75B7KEIX8D/Oj3yGu0FPOzZZFAaJHe89PelLJ4yZH6SE994qmo",
+	"j2MUMSKswevVNfZE9MJ/Uk9FCFwR8Q4ugTJ573nMUbfySkkBvMIW8lQixrOYlHLlFDSmGnqVK+UME/QI",
+	"w/BDHLTi4ralutWPVo2tzfBVW1UH3tPr0pr4WiXWKRwEmhw3DKOaOkd+5QoPAfHYtsQmLBDHNeDT6khX",
+	"NBcXV796A+/X0YTrmHcXV8c/m/WKLsb11/pd3qWmazxHTR1eoU4pIpduyQyyklxTlM8OOihKVdHp3kda",
+	"0aUbps0uGXT1aZjhJxQ0nc5ZnvXAEWUwDJvrKhecNfsY3cwoHXgMkjkym+sMsxC5eA69Mp2GoWmEfmzj",
+	"sPZQRQ0fMmBLbmDq+xtNxC6vpnc3x6PLy9MTER1zeTm+PPcGBqPTJHdmbtWpUV8M9OhvTFz+fHn1Kxd0",
+	"KfUfTk/Gtzxm5/34/D1XrZPxdHw8ujBSksXMWk+uJmiu4k+zot32c6WXXZ1Ospq9442bKVSkVHO3YPU8",
+	"bKa42BZ3fESRnxJkJogPjEQwtDnrGaIsj8qdiJduOsTyqgobPGX7oipeWcgd9hqygmmWHFJDuTyOaHWD",
+	"FhlzvAKD2uybtE5ppnLfQJuYvZ9OrzNZA1m9qszdx4E59d+iAL9j+qznNsppEkcUrUG6qrgV2q2RtNmn",
+	"Y2U/ubx4WRehhn2cCrsvou6NLobJ6XQyHr27OL2TLgau/6ejizu7w6EWk++ugsGpRotRGbsqW2UJbfwY",
+	"FnbtkRSC4KzkZA1RucCic21VRVZfV78SpJTV1cx5oKoGVxVm9a8KuGxsNc2n8OioiRvgb/VXfltL8F91",
+	"7auuZhmTSsuXZYkzrWaFozobVFlbFd+FT6PlPofjs6ZW/ln2PMTlXU/XR+mk6eAuaLVNi9blQB9/Tmcz",
+	"n+1Hm01JSavxs7UCjXzdxjtosLhsbh3ns5DbWSzTQ0dMjUYKa0PY3GsQoAcUcm5Qhdm33oKxhL4dDh8f",
+	"H48WsuoRjrUtZkODo+uxlgz0rffD0ZujN7xqnKAIJth76/2H+EkGdgn+D4l+2yo22XXHYh0GMO+I+2c4",
+	"1fIWUpAX0a8XQAKXiAmtYPG/FkWGGceFnpmg2d9TxENSCVyKGEi10L5TxpapsaIIRkWgkmG9FYP+8c0P",
+	"9oZUOa2RYtn96c2b9orvYKB1/JNLX7cRLN5eRIGs9x+u9WKC/5SV/rsLfWO1kbtB5AERmeecY5hmTwxk",
+	"M67Pt0gH8/YfnuZK+sgr5fgZfsn+uiNo9ixhFCLT64kn4ncNUDyck7uUoe/HacSUexqBOebX62S+7jLg",
+	"ZBMbAC6b2xlXHzrUSjBx4OaNPLb7GtDBs9C3VrqM2VmcRtuEU22+bXgaeMq/VsbLBLGURLSAi3oHoDts",
+	"zhE7BMx8jarlpcBjm3w7hpLUgKHbRLx/uJHSEUHWq10AaOvrWw/CrYKwjp41lsRhZkwOi8hmo77jeRuq",
+	"aYHrNlct2TDdEiIHrfUSfnwhUgO4lhb3BBzKUgSJv5gisq5qrXGlh3c7vE2A0wA+KtIwueGbMsjs8D5H",
+	"Wmf89MoA7vPiar4ocRaTLevddizOSLw8gQw5V2CxVnwt9JbG3CO3Hbl1LG2C2y/ZXy7bl6z1I8vmRMtT",
+	"tx+8ZsSvVYm7WPpt0D62QRoutgBUzZZosHvbrQlZ7oXsia0it6MtXTEWNjCoe7NjLat6m4aHJhfbt0EO",
+	"Wxx6a+Wva60MafE6rAPcZeFmwBfPyH77tktl0D2SuyI5B8s2sMxUlnqra4SCasozs/KuJus/aCwfuEul",
+	"wsteRBydKqYnFbYhJOqQd/hF/dFlwwpUpHHbxrUISD5guVHj7/e8h330F9XQtytBGGqParfbQsVhktUU",
+	"Kop8XabQbmTHX+Awu6qwDZtLcrdfT1xEiaP4HpnAuyNJEnfnnARKXrNzkitZ9KuSrnUERSbK7drFpkuS",
+	"ibm9cHUQLjOQNRGrFNiqpIVwhUg3QbuQVVrlLC/3LYvZBiIj+dOLygaikkNsH6Kiv+zuLCzaO/Et4qKV",
+	"7AWmcY3JONWLzgaio8Ftn8JD15Ie6i4+3+CCs1VDLedTLz1bkJ6drz38usnwC//vXQSX6NkqPv9MKQMP",
+	"MMTigBM9YcpQ5KPSu7C8mSa/w5n83jsdqOA7T5ewady1ztpe4jqe8ii87sbVkL8T3+6yk0VbBKd31+38",
+	"WCkm7IoEiLgWPsMoDPZyYMUB0Ls+1vcrZhK2G1FfoHDp5FN8j8Klk0eRF/zm/YlbsjvrvOplpIOMmDCp",
+	"SUrp8xbFxcnbUaatydehg+Br9XRsjP7ecbEx/g1uix1IQKfgNnU06RTkpsp+rbFuu/QPXiVsGxutMod7",
+	"Seu45aqAeWcmmZ6OD7fsw2QmWZ5MiHsz+L9k6jdDjkFeAGqPYT7kkR9tIvlLhaA+BKlN0MoM6yVtTUmr",
+	"Ay+TuOqXteWuLbiVp8jlYlWlzXJDIQwrsvMtuj6+djeGw7IrAuXOcMgQ2UQh6PefFSB6ZdD1BrQmSesu",
+	"uF2lnMqHUYo7zk2STt+t9n4bWl7O6iV260s4x0P10eFeZDuKbE18OifnUC8rvpbv/gwDsnpN0qhFirkE",
+	"V81b/iuUdnKEnhggaZRZylmPQHUmHxnCiILHOA0DIBcBk/CXXnA8IatJGr1kAiMDOT1kHSGbw0Q9TAvu",
+	"63hYD8AYRey1eOLodZt/PcuqdHwxBvKVHvWYTpZa6x7ydzziCKhs9dljSQZk5m/8vJzvvetObRPkV4fb",
+	"A989iZcNbuvgvUgu2nT3Sf4OitfoxelWltbVdP2pKHqWZRf9SgDtFkjRX5XaSw7NwA6mDOoagq156/5w",
+	"Ae7OILtOQo160t+1smmUczT/BZOw/uEMnSYtyf2wyqZtdeu6v4JZswK0xzVfMher9oBlvyi7OEDzHYk2",
+	"241Oz8b0mhuhSDaxGyB1VGH1h1CfezjuKRtQZ0Q2aT/1wET7PkjszOJZ/iqJLdqIl/s1a/QbSLd5uEF8",
+	"Oqf/gqt/BWgZ/POfhCaOaQOk26Ask7xrL6G9kLKtPOGyVq7+vI2/aKr+YhYNQHFRkMMv6q+74h0Vtxz+",
+	"RdemzfR24dWudvIXhbJB9On995TjoxGCLYn921TVOWJfPZC+QhX1ghuTFjQl6QZokobmwQGqXzYPf3ey",
+	"m3V2mL/H2L5RqT2bmJ/YcIuxab9yWnRyCJjf3bZn4+2G/optLxjuG5USwnYkIMX3/Lc7HDyvLzcNxkbp",
+	"adKvQGAeK2SPgy0ZLb1ArGW96PjZrzgM8ydbmwRDljC+xFsWiQlST3b2gtELxgZBAHYUWcUjCflocRwN",
+	"Sdp0RVw4cHlQpVYFyComm2hSlJqk61wXp7anKtcL/ytR04PJMZTKNNdFHEn+rcFZmr9tWm3K+sRpaaa2",
+	"B5vOL8BVELPRQ3A9+tZ7INUIGzMAjdps+AUHbl7WVnhmD6K2wBPzVlUMiXqgWD3vnL15zEiKBp58qtv0",
+	"PnLvRd3xI6nOkBrYozkcACOeQj1MtPQKaa1Yjk7QaYricECPLLkvAPWL41cYyLGVxXG4xHMJuyFewnnb",
+	"BiAvDWRp9ZgvjAAOahjmNT5kFcay9R0g+GuM71h7J1PmZy8tjhuZKm63ISnDL+L/wh0UxqU3dWqWQD5t",
+	"F/Gcv7UqZm9HwmBqRBG6e9PiOoQ4mqKn/oado1FRIJNjSNyygwqlm4GUMkgEGM0b8xv+Weu9SZGLsjmE",
+	"+03P14Owyixviqg4aQJUnDjjKU56OH2VcIoTRzQJRxwdfhH/7/x8eVYUyKIOr5ff8H7Wdhf2L4F+i/v1",
+	"KogytAqs0HaguuaYKMq3pJXYDz6zW/r6YV6fVUKVlsm0sjfSnAYp7mdvIwtFn32i47ZNFyxX4SXF/WY3",
+	"6S0q2BJAaVem9yLAdci5C32f9qlUmiA/JRQ/uPOE+vH28s30ku580qyJWF3UeQXRgBS6aqRNfuMxJaH3",
+	"1hvCBA8ffhDzp9qq1hldjylgMfDFQeMApMKnOgBhjRi1A9F0wPPA1tocMdWErrlUC4UV0NgAUKlF+G05",
+	"+ZyMqbHa8xvObfJ8x6YWK4llnwedWPZYXKVS7eWRJs8fn///AKnK0bsFYwEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
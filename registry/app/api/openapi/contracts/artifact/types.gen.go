@@ -33,6 +33,8 @@ const (
 // Defines values for PackageType.
 const (
 	PackageTypeCARGO       PackageType = "CARGO"
+	PackageTypeCONDA       PackageType = "CONDA"
+	PackageTypeDEBIAN      PackageType = "DEBIAN"
 	PackageTypeDOCKER      PackageType = "DOCKER"
 	PackageTypeGENERIC     PackageType = "GENERIC"
 	PackageTypeGO          PackageType = "GO"
@@ -43,6 +45,8 @@ const (
 	PackageTypeNUGET       PackageType = "NUGET"
 	PackageTypePYTHON      PackageType = "PYTHON"
 	PackageTypeRPM         PackageType = "RPM"
+	PackageTypeRUBYGEMS    PackageType = "RUBYGEMS"
+	PackageTypeTERRAFORM   PackageType = "TERRAFORM"
 )
 
 // Defines values for RegistryType.
@@ -101,6 +105,7 @@ const (
 // Defines values for UpstreamConfigSource.
 const (
 	UpstreamConfigSourceAwsEcr       UpstreamConfigSource = "AwsEcr"
+	UpstreamConfigSourceCondaForge   UpstreamConfigSource = "CondaForge"
 	UpstreamConfigSourceCrates       UpstreamConfigSource = "Crates"
 	UpstreamConfigSourceCustom       UpstreamConfigSource = "Custom"
 	UpstreamConfigSourceDockerhub    UpstreamConfigSource = "Dockerhub"
@@ -110,6 +115,7 @@ const (
 	UpstreamConfigSourceNpmJs        UpstreamConfigSource = "NpmJs"
 	UpstreamConfigSourceNugetOrg     UpstreamConfigSource = "NugetOrg"
 	UpstreamConfigSourcePyPi         UpstreamConfigSource = "PyPi"
+	UpstreamConfigSourceRubyGems     UpstreamConfigSource = "RubyGems"
 )
 
 // Defines values for UpstreamProxyConfigFirewallMode.
@@ -119,6 +125,23 @@ const (
 	UpstreamProxyConfigFirewallModeWARN  UpstreamProxyConfigFirewallMode = "WARN"
 )
 
+// Defines values for VulnerabilityScanStatus.
+const (
+	VulnerabilityScanStatusFAILURE    VulnerabilityScanStatus = "FAILURE"
+	VulnerabilityScanStatusNOTSCANNED VulnerabilityScanStatus = "NOT_SCANNED"
+	VulnerabilityScanStatusRUNNING    VulnerabilityScanStatus = "RUNNING"
+	VulnerabilityScanStatusSUCCESS    VulnerabilityScanStatus = "SUCCESS"
+)
+
+// Defines values for VulnerabilitySeverity.
+const (
+	VulnerabilitySeverityCRITICAL VulnerabilitySeverity = "CRITICAL"
+	VulnerabilitySeverityHIGH     VulnerabilitySeverity = "HIGH"
+	VulnerabilitySeverityLOW      VulnerabilitySeverity = "LOW"
+	VulnerabilitySeverityMEDIUM   VulnerabilitySeverity = "MEDIUM"
+	VulnerabilitySeverityUNKNOWN  VulnerabilitySeverity = "UNKNOWN"
+)
+
 // Defines values for WebhookExecResult.
 const (
 	WebhookExecResultFATALERROR     WebhookExecResult = "FATAL_ERROR"
@@ -224,6 +247,12 @@ const (
 	GetArtifactVersionSummaryParamsArtifactTypeModel   GetArtifactVersionSummaryParamsArtifactType = "model"
 )
 
+// Defines values for GetArtifactVersionVulnerabilitiesParamsArtifactType.
+const (
+	GetArtifactVersionVulnerabilitiesParamsArtifactTypeDataset GetArtifactVersionVulnerabilitiesParamsArtifactType = "dataset"
+	GetArtifactVersionVulnerabilitiesParamsArtifactTypeModel   GetArtifactVersionVulnerabilitiesParamsArtifactType = "model"
+)
+
 // Defines values for GetAllArtifactVersionsParamsArtifactType.
 const (
 	GetAllArtifactVersionsParamsArtifactTypeDataset GetAllArtifactVersionsParamsArtifactType = "dataset"
@@ -406,6 +435,23 @@ type ArtifactVersionSummary struct {
 	Version          string      `json:"version"`
 }
 
+// ArtifactVulnerabilities result of the latest vulnerability scan of an artifact version
+type ArtifactVulnerabilities struct {
+	Critical    *int64  `json:"critical,omitempty"`
+	Error       *string `json:"error,omitempty"`
+	High        *int64  `json:"high,omitempty"`
+	Low         *int64  `json:"low,omitempty"`
+	Medium      *int64  `json:"medium,omitempty"`
+	Quarantined bool    `json:"quarantined"`
+	ScannedAt   *string `json:"scannedAt,omitempty"`
+	Scanner     *string `json:"scanner,omitempty"`
+
+	// Status status of a vulnerability scan
+	Status          VulnerabilityScanStatus `json:"status"`
+	Unknown         *int64                  `json:"unknown,omitempty"`
+	Vulnerabilities []Vulnerability         `json:"vulnerabilities"`
+}
+
 // AuthType Authentication type
 type AuthType string
 
@@ -414,12 +460,34 @@ type CargoArtifactDetailConfig struct {
 	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// CleanupCandidate Artifact version that is deleted by a cleanup policy
+type CleanupCandidate struct {
+	LastModified string `json:"lastModified"`
+	Package      string `json:"package"`
+	Policy       string `json:"policy"`
+	Reason       string `json:"reason"`
+	Version      string `json:"version"`
+}
+
 // CleanupPolicy Cleanup Policy for Harness Artifact Registries
 type CleanupPolicy struct {
-	ExpireDays    *int      `json:"expireDays,omitempty"`
+	// ExcludePattern regular expressions matching package names and versions that are never deleted
+	ExcludePattern *[]string `json:"excludePattern,omitempty"`
+	ExpireDays     *int      `json:"expireDays,omitempty"`
+
+	// KeepLast number of most recent versions of each package that are never deleted
+	KeepLast      *int      `json:"keepLast,omitempty"`
 	Name          *string   `json:"name,omitempty"`
 	PackagePrefix *[]string `json:"packagePrefix,omitempty"`
-	VersionPrefix *[]string `json:"versionPrefix,omitempty"`
+
+	// VersionPattern regular expressions of which one has to match the whole version
+	VersionPattern *[]string `json:"versionPattern,omitempty"`
+	VersionPrefix  *[]string `json:"versionPrefix,omitempty"`
+}
+
+// CleanupPolicyDryRun Artifact versions that the next cleanup run deletes
+type CleanupPolicyDryRun struct {
+	Candidates []CleanupCandidate `json:"candidates"`
 }
 
 // ClientSetupDetails Client Setup Details
@@ -462,6 +530,16 @@ type ClientSetupStepConfig struct {
 // ClientSetupStepType ClientSetupStepType type
 type ClientSetupStepType string
 
+// CondaArtifactDetailConfig Config for Conda artifact details
+type CondaArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// DebianArtifactDetailConfig Config for Debian artifact details
+type DebianArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // DockerArtifactDetail Docker Artifact Detail
 type DockerArtifactDetail struct {
 	CreatedAt      *string `json:"createdAt,omitempty"`
@@ -992,6 +1070,29 @@ type RpmArtifactDetailConfig struct {
 	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// RubyGemsArtifactDetailConfig Config for RubyGems artifact details
+type RubyGemsArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ScanPolicy vulnerability scan policy of a registry
+type ScanPolicy struct {
+	CreatedAt  *string `json:"createdAt,omitempty"`
+	Enabled    bool    `json:"enabled"`
+	ModifiedAt *string `json:"modifiedAt,omitempty"`
+
+	// QuarantineSeverity severity of a vulnerability
+	QuarantineSeverity *VulnerabilitySeverity `json:"quarantineSeverity,omitempty"`
+}
+
+// ScanPolicyRequest defines model for ScanPolicyRequest.
+type ScanPolicyRequest struct {
+	Enabled bool `json:"enabled"`
+
+	// QuarantineSeverity severity of a vulnerability
+	QuarantineSeverity *VulnerabilitySeverity `json:"quarantineSeverity,omitempty"`
+}
+
 // SectionType refers to client setup section type
 type SectionType string
 
@@ -1009,6 +1110,11 @@ type TabSetupStepConfig struct {
 	Tabs *[]TabSetupStep `json:"tabs,omitempty"`
 }
 
+// TerraformArtifactDetailConfig Config for Terraform module and provider artifact details
+type TerraformArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // Trigger refers to trigger
 type Trigger string
 
@@ -1052,6 +1158,25 @@ type VirtualConfig struct {
 	UpstreamProxies *[]string `json:"upstreamProxies,omitempty"`
 }
 
+// Vulnerability defines model for Vulnerability.
+type Vulnerability struct {
+	FixedVersion     *string `json:"fixedVersion,omitempty"`
+	Id               string  `json:"id"`
+	InstalledVersion string  `json:"installedVersion"`
+	PackageName      string  `json:"packageName"`
+
+	// Severity severity of a vulnerability
+	Severity VulnerabilitySeverity `json:"severity"`
+	Target   *string               `json:"target,omitempty"`
+	Title    *string               `json:"title,omitempty"`
+}
+
+// VulnerabilityScanStatus status of a vulnerability scan
+type VulnerabilityScanStatus string
+
+// VulnerabilitySeverity severity of a vulnerability
+type VulnerabilitySeverity string
+
 // Webhook Harness Regstries Webhook
 type Webhook struct {
 	CreatedAt    *string        `json:"createdAt,omitempty"`
@@ -1300,9 +1425,27 @@ type ArtifactVersionSummaryResponse struct {
 	Status Status `json:"status"`
 }
 
+// ArtifactVulnerabilitiesResponse defines model for ArtifactVulnerabilitiesResponse.
+type ArtifactVulnerabilitiesResponse struct {
+	// Data result of the latest vulnerability scan of an artifact version
+	Data ArtifactVulnerabilities `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest Error
 
+// CleanupPolicyDryRunResponse defines model for CleanupPolicyDryRunResponse.
+type CleanupPolicyDryRunResponse struct {
+	// Data Artifact versions that the next cleanup run deletes
+	Data CleanupPolicyDryRun `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // ClientSetupDetailsResponse defines model for ClientSetupDetailsResponse.
 type ClientSetupDetailsResponse struct {
 	// Data Client Setup Details
@@ -1488,6 +1631,15 @@ type ReplicationRuleResponse struct {
 	Status Status `json:"status"`
 }
 
+// ScanPolicyResponse defines model for ScanPolicyResponse.
+type ScanPolicyResponse struct {
+	// Data vulnerability scan policy of a registry
+	Data ScanPolicy `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // Success defines model for Success.
 type Success struct {
 	// Status Indicates if the request was successful or not
@@ -1710,6 +1862,15 @@ type GetArtifactVersionSummaryParams struct {
 // GetArtifactVersionSummaryParamsArtifactType defines parameters for GetArtifactVersionSummary.
 type GetArtifactVersionSummaryParamsArtifactType string
 
+// GetArtifactVersionVulnerabilitiesParams defines parameters for GetArtifactVersionVulnerabilities.
+type GetArtifactVersionVulnerabilitiesParams struct {
+	// ArtifactType artifact type.
+	ArtifactType *GetArtifactVersionVulnerabilitiesParamsArtifactType `form:"artifact_type,omitempty" json:"artifact_type,omitempty"`
+}
+
+// GetArtifactVersionVulnerabilitiesParamsArtifactType defines parameters for GetArtifactVersionVulnerabilities.
+type GetArtifactVersionVulnerabilitiesParamsArtifactType string
+
 // GetAllArtifactVersionsParams defines parameters for GetAllArtifactVersions.
 type GetAllArtifactVersionsParams struct {
 	// ArtifactType artifact type.
@@ -1939,6 +2100,9 @@ type UpdateArtifactLabelsJSONRequestBody ArtifactLabelRequest
 // QuarantineFilePathJSONRequestBody defines body for QuarantineFilePath for application/json ContentType.
 type QuarantineFilePathJSONRequestBody QuarantineRequest
 
+// UpdateScanPolicyJSONRequestBody defines body for UpdateScanPolicy for application/json ContentType.
+type UpdateScanPolicyJSONRequestBody ScanPolicyRequest
+
 // CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
 type CreateWebhookJSONRequestBody WebhookRequest
 
@@ -2281,6 +2445,126 @@ func (t *ArtifactDetail) MergeHuggingFaceArtifactDetailConfig(v HuggingFaceArtif
 	return err
 }
 
+// AsDebianArtifactDetailConfig returns the union data inside the ArtifactDetail as a DebianArtifactDetailConfig
+func (t ArtifactDetail) AsDebianArtifactDetailConfig() (DebianArtifactDetailConfig, error) {
+	var body DebianArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromDebianArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided DebianArtifactDetailConfig
+func (t *ArtifactDetail) FromDebianArtifactDetailConfig(v DebianArtifactDetailConfig) error {
+	t.PackageType = "DEBIAN"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeDebianArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided DebianArtifactDetailConfig
+func (t *ArtifactDetail) MergeDebianArtifactDetailConfig(v DebianArtifactDetailConfig) error {
+	t.PackageType = "DEBIAN"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTerraformArtifactDetailConfig returns the union data inside the ArtifactDetail as a TerraformArtifactDetailConfig
+func (t ArtifactDetail) AsTerraformArtifactDetailConfig() (TerraformArtifactDetailConfig, error) {
+	var body TerraformArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTerraformArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided TerraformArtifactDetailConfig
+func (t *ArtifactDetail) FromTerraformArtifactDetailConfig(v TerraformArtifactDetailConfig) error {
+	t.PackageType = "TERRAFORM"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTerraformArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided TerraformArtifactDetailConfig
+func (t *ArtifactDetail) MergeTerraformArtifactDetailConfig(v TerraformArtifactDetailConfig) error {
+	t.PackageType = "TERRAFORM"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRubyGemsArtifactDetailConfig returns the union data inside the ArtifactDetail as a RubyGemsArtifactDetailConfig
+func (t ArtifactDetail) AsRubyGemsArtifactDetailConfig() (RubyGemsArtifactDetailConfig, error) {
+	var body RubyGemsArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRubyGemsArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided RubyGemsArtifactDetailConfig
+func (t *ArtifactDetail) FromRubyGemsArtifactDetailConfig(v RubyGemsArtifactDetailConfig) error {
+	t.PackageType = "RUBYGEMS"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRubyGemsArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided RubyGemsArtifactDetailConfig
+func (t *ArtifactDetail) MergeRubyGemsArtifactDetailConfig(v RubyGemsArtifactDetailConfig) error {
+	t.PackageType = "RUBYGEMS"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsCondaArtifactDetailConfig returns the union data inside the ArtifactDetail as a CondaArtifactDetailConfig
+func (t ArtifactDetail) AsCondaArtifactDetailConfig() (CondaArtifactDetailConfig, error) {
+	var body CondaArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromCondaArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided CondaArtifactDetailConfig
+func (t *ArtifactDetail) FromCondaArtifactDetailConfig(v CondaArtifactDetailConfig) error {
+	t.PackageType = "CONDA"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeCondaArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided CondaArtifactDetailConfig
+func (t *ArtifactDetail) MergeCondaArtifactDetailConfig(v CondaArtifactDetailConfig) error {
+	t.PackageType = "CONDA"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ArtifactDetail) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"packageType"`
@@ -2297,6 +2581,10 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 	switch discriminator {
 	case "CARGO":
 		return t.AsCargoArtifactDetailConfig()
+	case "CONDA":
+		return t.AsCondaArtifactDetailConfig()
+	case "DEBIAN":
+		return t.AsDebianArtifactDetailConfig()
 	case "DOCKER":
 		return t.AsDockerArtifactDetailConfig()
 	case "GENERIC":
@@ -2317,6 +2605,10 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 		return t.AsPythonArtifactDetailConfig()
 	case "RPM":
 		return t.AsRpmArtifactDetailConfig()
+	case "RUBYGEMS":
+		return t.AsRubyGemsArtifactDetailConfig()
+	case "TERRAFORM":
+		return t.AsTerraformArtifactDetailConfig()
 	default:
 		return nil, errors.New("unknown discriminator value: " + discriminator)
 	}
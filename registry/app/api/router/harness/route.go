@@ -40,6 +40,8 @@ import (
 	"github.com/harness/gitness/registry/app/storage"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/services/cleanup"
+	"github.com/harness/gitness/registry/services/replication"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -101,6 +103,12 @@ func NewAPIHandler(
 	untaggedImagesEnabled func(ctx context.Context) bool,
 	deletionService *deletion.Service,
 	storageService *storage.Service,
+	replicationRuleRepository store.ReplicationRuleRepository,
+	replicationRunRepository store.ReplicationRunRepository,
+	replicationService *replication.Service,
+	scanPolicyRepository store.ScanPolicyRepository,
+	artifactScanRepository store.ArtifactScanRepository,
+	cleanupService *cleanup.Service,
 	app *docker.App,
 ) APIHandler {
 	r := chi.NewRouter()
@@ -144,6 +152,12 @@ func NewAPIHandler(
 		publicAccess,
 		deletionService,
 		storageService,
+		replicationRuleRepository,
+		replicationRunRepository,
+		replicationService,
+		scanPolicyRepository,
+		artifactScanRepository,
+		cleanupService,
 		app,
 	)
 
@@ -22,6 +22,8 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/conda"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	"github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -31,6 +33,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/middleware"
 	"github.com/harness/gitness/types/enum"
 
@@ -58,6 +62,10 @@ func NewRouter(
 	cargoHandler cargo.Handler,
 	gopackageHandler gopackage.Handler,
 	huggingfaceHandler huggingface.Handler,
+	debianHandler debian.Handler,
+	terraformHandler terraform.Handler,
+	rubygemsHandler rubygems.Handler,
+	condaHandler conda.Handler,
 	spaceFinder refcache.SpaceFinder,
 	publicAccessService publicaccess.Service,
 ) Handler {
@@ -310,6 +318,99 @@ func NewRouter(
 				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
 				Get("/package/{name}/{version}/{architecture}/{file}/*", rpmHandler.DownloadPackageFile)
 		})
+		r.Route("/debian", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/pool/{distribution}/{component}/upload", debianHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/pool/*", debianHandler.DownloadPackageFile)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/dists/{distribution}/*", debianHandler.GetDistributionFile)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/repository.key", debianHandler.GetPublicKey)
+		})
+		r.Route("/rubygems", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Post("/api/v1/gems", rubygemsHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/gems/{file}", rubygemsHandler.DownloadPackageFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/info/{name}", rubygemsHandler.GetIndexFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/versions", rubygemsHandler.GetIndexFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/names", rubygemsHandler.GetIndexFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/specs.4.8.gz", rubygemsHandler.GetIndexFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/latest_specs.4.8.gz", rubygemsHandler.GetIndexFile)
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/prerelease_specs.4.8.gz", rubygemsHandler.GetIndexFile)
+		})
+		r.Route("/conda", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(condaHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/upload", condaHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(condaHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/{subdir}/repodata.json", condaHandler.GetRepodata)
+			r.With(middleware.StoreArtifactInfo(condaHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/{subdir}/{file}", condaHandler.DownloadPackageFile)
+		})
+		r.Route("/terraform", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionRegistryView)).
+				Get("/.well-known/terraform.json", terraformHandler.GetServiceDiscovery)
+			r.Route("/v1/modules/{namespace}/{name}/{system}", func(r chi.Router) {
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/versions", terraformHandler.ListModuleVersions)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Put("/{version}", terraformHandler.UploadModule)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/{version}/download", terraformHandler.GetModuleDownloadURL)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.TrackDownloadStatsForTerraformPackage(packageHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/{version}/files/{file}", terraformHandler.DownloadFile)
+			})
+			r.Route("/v1/providers/{namespace}/{type}", func(r chi.Router) {
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/versions", terraformHandler.ListProviderVersions)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+					Put("/{version}/{os}/{arch}", terraformHandler.UploadProvider)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/{version}/download/{os}/{arch}", terraformHandler.GetProviderPackage)
+				r.With(middleware.StoreArtifactInfo(terraformHandler)).
+					With(middleware.TrackDownloadStatsForTerraformPackage(packageHandler)).
+					With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+					Get("/{version}/files/{file}", terraformHandler.DownloadFile)
+			})
+		})
 		r.Route("/cargo", func(r chi.Router) {
 			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
 			r.With(middleware.StoreArtifactInfo(cargoHandler)).
@@ -26,6 +26,8 @@ import (
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/conda"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	"github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -36,6 +38,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/interfaces"
 	generic2 "github.com/harness/gitness/registry/app/api/router/generic"
 	"github.com/harness/gitness/registry/app/api/router/harness"
@@ -53,6 +57,8 @@ import (
 	"github.com/harness/gitness/registry/app/storage"
 	"github.com/harness/gitness/registry/app/store"
 	cargoutils "github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/services/cleanup"
+	"github.com/harness/gitness/registry/services/replication"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -108,6 +114,12 @@ func APIHandlerProvider(
 	untaggedImagesEnabled func(ctx context.Context) bool,
 	deletionService *deletion.Service,
 	storageService *storage.Service,
+	replicationRuleRepository store.ReplicationRuleRepository,
+	replicationRunRepository store.ReplicationRunRepository,
+	replicationService *replication.Service,
+	scanPolicyRepository store.ScanPolicyRepository,
+	artifactScanRepository store.ArtifactScanRepository,
+	cleanupService *cleanup.Service,
 	app *docker.App,
 ) harness.APIHandler {
 	return harness.NewAPIHandler(
@@ -148,6 +160,12 @@ func APIHandlerProvider(
 		untaggedImagesEnabled,
 		deletionService,
 		storageService,
+		replicationRuleRepository,
+		replicationRunRepository,
+		replicationService,
+		scanPolicyRepository,
+		artifactScanRepository,
+		cleanupService,
 		app,
 	)
 }
@@ -175,6 +193,10 @@ func PackageHandlerProvider(
 	cargoHandler cargo.Handler,
 	gopackageHandler gopackage.Handler,
 	huggingfaceHandler huggingface.Handler,
+	debianHandler debian.Handler,
+	terraformHandler terraform.Handler,
+	rubygemsHandler rubygems.Handler,
+	condaHandler conda.Handler,
 	spaceFinder refcache.SpaceFinder,
 	publicAccessService publicaccess.CacheService,
 ) packagerrouter.Handler {
@@ -189,6 +211,10 @@ func PackageHandlerProvider(
 		cargoHandler,
 		gopackageHandler,
 		huggingfaceHandler,
+		debianHandler,
+		terraformHandler,
+		rubygemsHandler,
+		condaHandler,
 		spaceFinder,
 		publicAccessService,
 	)
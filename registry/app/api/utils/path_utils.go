@@ -68,6 +68,49 @@ func GetCargoFilePath(imageName string, version string) string {
 	return filePathPrefix
 }
 
+// GetDebianFilePath returns the storage path of a debian artifact whose version has the form {version}_{arch}.
+func GetDebianFilePath(imageName string, version string) string {
+	filePathPrefix := "/" + imageName
+	if version != "" {
+		if idx := strings.LastIndex(version, "_"); idx > 0 {
+			version = version[:idx] + "/" + version[idx+1:]
+		}
+		filePathPrefix += "/" + version
+	}
+	return filePathPrefix
+}
+
+// GetTerraformFilePath returns the storage path of a terraform module ({namespace}/{name}/{system}) or
+// provider ({namespace}/{type}).
+func GetTerraformFilePath(imageName string, version string) string {
+	filePathPrefix := "/providers/" + imageName
+	if strings.Count(imageName, "/") == 2 {
+		filePathPrefix = "/modules/" + imageName
+	}
+	if version != "" {
+		filePathPrefix += "/" + version
+	}
+	return filePathPrefix
+}
+
+func GetRubyGemsFilePath(imageName string, version string) string {
+	filePathPrefix := "/gems/" + imageName
+	if version != "" {
+		filePathPrefix += "/" + version
+	}
+	return filePathPrefix
+}
+
+// GetCondaFilePath returns the storage path of a conda artifact. The packages of a version are stored below it
+// in one directory per subdir.
+func GetCondaFilePath(imageName string, version string) string {
+	filePathPrefix := "/" + imageName
+	if version != "" {
+		filePathPrefix += "/" + version
+	}
+	return filePathPrefix
+}
+
 func GetGoFilePath(imageName string, version string) string {
 	filePathPrefix := "/" + imageName + "/@v"
 	if version != "" {
@@ -106,6 +149,14 @@ func GetFilePath(packageType artifact.PackageType, imageName string, version str
 		return GetCargoFilePath(imageName, version), nil
 	case artifact.PackageTypeGO:
 		return GetGoFilePath(imageName, version), nil
+	case artifact.PackageTypeDEBIAN:
+		return GetDebianFilePath(imageName, version), nil
+	case artifact.PackageTypeTERRAFORM:
+		return GetTerraformFilePath(imageName, version), nil
+	case artifact.PackageTypeRUBYGEMS:
+		return GetRubyGemsFilePath(imageName, version), nil
+	case artifact.PackageTypeCONDA:
+		return GetCondaFilePath(imageName, version), nil
 	default:
 		return "", fmt.Errorf("unsupported package type: %s", packageType)
 	}
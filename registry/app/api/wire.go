@@ -25,6 +25,8 @@ import (
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	cargo2 "github.com/harness/gitness/registry/app/api/controller/pkg/cargo"
+	conda2 "github.com/harness/gitness/registry/app/api/controller/pkg/conda"
+	debian2 "github.com/harness/gitness/registry/app/api/controller/pkg/debian"
 	generic3 "github.com/harness/gitness/registry/app/api/controller/pkg/generic"
 	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
 	"github.com/harness/gitness/registry/app/api/controller/pkg/huggingface"
@@ -32,7 +34,11 @@ import (
 	nuget2 "github.com/harness/gitness/registry/app/api/controller/pkg/nuget"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	rpm2 "github.com/harness/gitness/registry/app/api/controller/pkg/rpm"
+	rubygems2 "github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	terraform2 "github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/conda"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	hf2 "github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -43,6 +49,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	pypi2 "github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/interfaces"
 	"github.com/harness/gitness/registry/app/api/router"
 	storagedriver "github.com/harness/gitness/registry/app/driver"
@@ -54,6 +62,8 @@ import (
 	"github.com/harness/gitness/registry/app/pkg"
 	"github.com/harness/gitness/registry/app/pkg/base"
 	cargoregistry "github.com/harness/gitness/registry/app/pkg/cargo"
+	condaregistry "github.com/harness/gitness/registry/app/pkg/conda"
+	debianregistry "github.com/harness/gitness/registry/app/pkg/debian"
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
@@ -65,6 +75,8 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/pkg/quarantine"
 	rpmregistry "github.com/harness/gitness/registry/app/pkg/rpm"
+	rubygemsregistry "github.com/harness/gitness/registry/app/pkg/rubygems"
+	terraformregistry "github.com/harness/gitness/registry/app/pkg/terraform"
 	publicaccess2 "github.com/harness/gitness/registry/app/services/publicaccess"
 	refcache2 "github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
@@ -253,6 +265,34 @@ func NewCargoHandlerProvider(
 	return cargo.NewHandler(controller, packageHandler)
 }
 
+func NewDebianHandlerProvider(
+	controller debian2.Controller,
+	packageHandler packages.Handler,
+) debian.Handler {
+	return debian.NewHandler(controller, packageHandler)
+}
+
+func NewTerraformHandlerProvider(
+	controller terraform2.Controller,
+	packageHandler packages.Handler,
+) terraform.Handler {
+	return terraform.NewHandler(controller, packageHandler)
+}
+
+func NewRubyGemsHandlerProvider(
+	controller rubygems2.Controller,
+	packageHandler packages.Handler,
+) rubygems.Handler {
+	return rubygems.NewHandler(controller, packageHandler)
+}
+
+func NewCondaHandlerProvider(
+	controller conda2.Controller,
+	packageHandler packages.Handler,
+) conda.Handler {
+	return conda.NewHandler(controller, packageHandler)
+}
+
 func NewGoPackageHandlerProvider(
 	controller gopackage2.Controller,
 	packageHandler packages.Handler,
@@ -272,6 +312,10 @@ var WireSet = wire.NewSet(
 	NewRpmHandlerProvider,
 	NewCargoHandlerProvider,
 	NewGoPackageHandlerProvider,
+	NewDebianHandlerProvider,
+	NewTerraformHandlerProvider,
+	NewRubyGemsHandlerProvider,
+	NewCondaHandlerProvider,
 	database.WireSet,
 	cache.WireSet,
 	refcache2.WireSet,
@@ -297,6 +341,14 @@ var WireSet = wire.NewSet(
 	cargoregistry.WireSet,
 	gopackage2.ControllerSet,
 	gopackageregistry.WireSet,
+	debian2.ControllerSet,
+	debianregistry.WireSet,
+	terraform2.ControllerSet,
+	terraformregistry.WireSet,
+	rubygems2.ControllerSet,
+	rubygemsregistry.WireSet,
+	conda2.ControllerSet,
+	condaregistry.WireSet,
 	huggingface.WireSet,
 	hf2.WireSet,
 	hf3.WireSet,
@@ -135,6 +135,32 @@ func (r *Reporter) BuildPackageMetadataWithPrincipal(
 	}
 }
 
+func (r *Reporter) ScanArtifact(ctx context.Context, registryID int64, artifactID int64) {
+	session, _ := request.AuthSessionFrom(ctx)
+	principalID := session.Principal.ID
+	key := fmt.Sprintf("scan_artifact_%d", artifactID)
+	payload, err := json.Marshal(&types.ScanArtifactTaskPayload{
+		Key:         key,
+		RegistryID:  registryID,
+		ArtifactID:  artifactID,
+		PrincipalID: principalID,
+	})
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send execute async task event")
+	}
+	task := &types.Task{
+		Key:     key,
+		Kind:    types.TaskKindScanArtifact,
+		Payload: payload,
+	}
+
+	sources := []types.SourceRef{{Type: types.SourceTypeArtifact, ID: artifactID}}
+	err = r.UpsertAndSendEvent(ctx, task, sources)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send execute async task event")
+	}
+}
+
 func (r *Reporter) UpsertAndSendEvent(
 	ctx context.Context,
 	task *types.Task,
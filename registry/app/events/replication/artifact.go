@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ArtifactReplicationCategory is the event category used to drive artifact replication runs.
+// Unlike RegistryBlobsReplication, which mirrors raw blobs between storage buckets,
+// these events copy whole artifacts between registries according to replication rules.
+const ArtifactReplicationCategory = "registry-artifact-replication"
+
+const ArtifactReplicationRunRequestedEvent events.EventType = "run-requested"
+
+// ArtifactReplicationRunRequestedPayload is sent whenever a replication run was created and is ready to execute.
+type ArtifactReplicationRunRequestedPayload struct {
+	RunID int64 `json:"run_id"`
+}
+
+// ArtifactReporter is the event reporter for artifact replication.
+type ArtifactReporter struct {
+	innerReporter *events.GenericReporter
+}
+
+func NewArtifactReporter(eventsSystem *events.System) (*ArtifactReporter, error) {
+	innerReporter, err := events.NewReporter(eventsSystem, ArtifactReplicationCategory)
+	if err != nil {
+		return nil, errors.New("failed to create new GenericReporter for artifact replication from event system")
+	}
+
+	return &ArtifactReporter{
+		innerReporter: innerReporter,
+	}, nil
+}
+
+func (r *ArtifactReporter) RunRequested(ctx context.Context, payload *ArtifactReplicationRunRequestedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ArtifactReplicationRunRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send artifact replication run requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported artifact replication run requested event with id '%s'", eventID)
+}
+
+func NewArtifactReaderFactory(eventsSystem *events.System) (*events.ReaderFactory[*ArtifactReader], error) {
+	readerFactoryFunc := func(innerReader *events.GenericReader) (*ArtifactReader, error) {
+		return &ArtifactReader{
+			innerReader: innerReader,
+		}, nil
+	}
+
+	return events.NewReaderFactory(eventsSystem, ArtifactReplicationCategory, readerFactoryFunc)
+}
+
+// ArtifactReader is the event reader for artifact replication.
+type ArtifactReader struct {
+	innerReader *events.GenericReader
+}
+
+func (r *ArtifactReader) Configure(opts ...events.ReaderOption) {
+	r.innerReader.Configure(opts...)
+}
+
+func (r *ArtifactReader) RegisterRunRequested(
+	fn events.HandlerFunc[*ArtifactReplicationRunRequestedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ArtifactReplicationRunRequestedEvent, fn, opts...)
+}
@@ -31,3 +31,11 @@ func ProvideReplicationReporter(
 func ProvideNoOpReplicationReporter() (Reporter, error) {
 	return &Noop{}, nil
 }
+
+func ProvideArtifactReporter(eventsSystem *events.System) (*ArtifactReporter, error) {
+	return NewArtifactReporter(eventsSystem)
+}
+
+func ProvideArtifactReaderFactory(eventsSystem *events.System) (*events.ReaderFactory[*ArtifactReader], error) {
+	return NewArtifactReaderFactory(eventsSystem)
+}
@@ -0,0 +1,459 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/utils/conda"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+// condaNodePathRegex matches /{name}/{version}/{subdir}/{filename}.
+var condaNodePathRegex = regexp.MustCompile(`^/([^/]+)/([^/]+)/[^/]+/[^/]+$`)
+
+type CondaPackageType interface {
+	interfaces.PackageHelper
+}
+
+type condaPackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+	condaRegistryHelper  conda.RegistryHelper
+}
+
+func NewCondaPackageType(
+	registryHelper interfaces.RegistryHelper,
+	condaRegistryHelper conda.RegistryHelper,
+) CondaPackageType {
+	return &condaPackageType{
+		packageType:     string(artifact.PackageTypeCONDA),
+		pathPackageType: string(types.PathPackageTypeConda),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCondaForge),
+			string(artifact.UpstreamConfigSourceCustom),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCondaForge): {
+				urlRequired: false,
+			},
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+		},
+		condaRegistryHelper: condaRegistryHelper,
+	}
+}
+
+func (c *condaPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *condaPackageType) IsFileOperationSupported() bool {
+	return false
+}
+
+func (c *condaPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *condaPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *condaPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *condaPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *condaPackageType) GetPullCommand(_ string, image string, version string) string {
+	return "conda install " + image + "=" + version
+}
+
+func (c *condaPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifact string,
+	version string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/<SUBDIR>/<FILENAME>'" + authHeader + " -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *condaPackageType) DeleteVersion(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete conda artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *condaPackageType) ReportDeleteVersionEvent(
+	ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeCONDA,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *condaPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for conda, repodata is built per registry
+}
+
+func (c *condaPackageType) ReportBuildRegistryIndexEvent(
+	ctx context.Context,
+	registryID int64,
+	sources []types.SourceRef,
+) {
+	c.registryHelper.ReportBuildRegistryIndexEvent(ctx, registryID, sources)
+}
+
+func (c *condaPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	return conda.FilePath(artifactName, versionName)
+}
+
+func (c *condaPackageType) DeleteArtifact(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete conda artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *condaPackageType) GetPackageURL(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "conda")
+}
+
+func (c *condaPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *condaPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *condaPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := c.GetFilePath(artifactName, version) + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, auth.IsAnonymousSession(session))
+	// packages are stored below their subdir, which is also their location in the channel.
+	if subdir, fileName, ok := strings.Cut(filename, "/"); ok {
+		downloadCommand = strings.ReplaceAll(downloadCommand, "<SUBDIR>", subdir)
+		downloadCommand = strings.ReplaceAll(downloadCommand, "<FILENAME>", fileName)
+	}
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *condaPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromCondaArtifactDetailConfig(artifact.CondaArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *condaPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+	isAnonymous := auth.IsAnonymousSession(session)
+
+	var sections []artifact.ClientSetupSection
+	if !isAnonymous {
+		section := artifact.ClientSetupSection{
+			Header: registryutils.StringPtr("Configure Authentication"),
+		}
+		_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+			Steps: &[]artifact.ClientSetupStep{
+				{
+					Header: registryutils.StringPtr("Generate an identity token for authentication"),
+					Type:   &generateTokenType,
+				},
+				{
+					Header: registryutils.StringPtr("Add the credentials of the registry to conda:"),
+					Type:   &staticStepType,
+					Commands: &[]artifact.ClientSetupStepCommand{
+						{
+							Value: registryutils.StringPtr("conda config --set channel_settings " +
+								"'[{\"channel\": \"<REGISTRY_URL>\", \"auth\": \"http_basic\", " +
+								"\"username\": \"<USERNAME>\", \"password\": \"<token from step 1>\"}]'"),
+						},
+					},
+				},
+			},
+		})
+		sections = append(sections, section)
+	}
+
+	installSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = installSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Add the registry as a channel to your .condarc:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("channels:\n  - <REGISTRY_URL>"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Or install the package directly:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("conda install -c <REGISTRY_URL> <ARTIFACT_NAME>=<VERSION>"),
+					},
+				},
+			},
+		},
+	})
+
+	publishSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = publishSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Build your package and upload the .conda or .tar.bz2 file:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT '<REGISTRY_URL>/upload' \\\n" +
+							"--form 'file=@\"<FILE_PATH>\"' \\\n" +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+		},
+	})
+
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, publishSection)
+	}
+	sections = append(sections, installSection)
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Conda Client Setup",
+		SecHeader:  "Follow these instructions to install/use conda packages from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func (c *condaPackageType) BuildRegistryIndexAsync(
+	ctx context.Context,
+	registry *types.Registry,
+	payload types.BuildRegistryIndexTaskPayload,
+) error {
+	// upstream repodata is proxied as-is.
+	if registry.Type == artifact.RegistryTypeUPSTREAM {
+		return nil
+	}
+	err := c.condaRegistryHelper.BuildRegistryIndex(ctx, registry, payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to build CONDA registry index for registry [%d]: %w", registry.ID, err)
+	}
+	return nil
+}
+
+func (c *condaPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *condaPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return nil
+}
+
+func (c *condaPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, "")}, nil
+}
+
+func (c *condaPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, version)}, nil
+}
+
+func (c *condaPackageType) GetPkgDownloadURL(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+) (string, error) {
+	return "", nil
+}
+
+func (c *condaPackageType) GetPurlForArtifact(
+	packageName string,
+	version string,
+) (string, error) {
+	if packageName == "" {
+		return "", fmt.Errorf("packageName cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	return fmt.Sprintf("pkg:conda/%s@%s", packageName, version), nil
+}
+
+func (c *condaPackageType) GetPackageAndVersionFromNodePath(
+	nodePath string,
+) (string, string, string) {
+	// Format: /{name}/{version}/{subdir}/{filename}
+	matches := condaNodePathRegex.FindStringSubmatch(nodePath)
+	if len(matches) == 3 && matches[1] != conda.RepodataPrefix {
+		return matches[1], matches[2], ""
+	}
+	return "", "", ""
+}
+
+func (c *condaPackageType) IsArtifactMainFile(nodePath string) bool {
+	return conda.FileExtension(path.Base(nodePath)) != ""
+}
@@ -0,0 +1,498 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/utils/debian"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+// debianNodePathRegex matches /{packageName}/{version}/{arch}/ followed by the package file, which is
+// stored either directly or below {distribution}/{component} for published packages.
+var debianNodePathRegex = regexp.MustCompile(`^/([^/]+)/([^/]+)/([^/]+)/(?:[^/]+/[^/]+/)?[^/]+$`)
+
+type DebianPackageType interface {
+	interfaces.PackageHelper
+}
+
+type debianPackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+	debianRegistryHelper debian.RegistryHelper
+}
+
+func NewDebianPackageType(
+	registryHelper interfaces.RegistryHelper,
+	debianRegistryHelper debian.RegistryHelper,
+) DebianPackageType {
+	return &debianPackageType{
+		packageType:     string(artifact.PackageTypeDEBIAN),
+		pathPackageType: string(types.PathPackageTypeDebian),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCustom),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+		},
+		debianRegistryHelper: debianRegistryHelper,
+	}
+}
+
+func (c *debianPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *debianPackageType) IsFileOperationSupported() bool {
+	return false
+}
+
+func (c *debianPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *debianPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *debianPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *debianPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *debianPackageType) GetPullCommand(_ string, image string, version string) string {
+	fileVersion, arch := splitDebianVersion(version)
+	if arch == "" || arch == debian.ArchitectureAll {
+		return "sudo apt-get install " + image + "=" + fileVersion
+	}
+	return "sudo apt-get install " + image + ":" + arch + "=" + fileVersion
+}
+
+func (c *debianPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifact string,
+	version string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	fileVersion, arch := splitDebianVersion(version)
+	downloadCommand := "curl --location '<HOSTNAME>/pool/<DISTRIBUTION>/<COMPONENT>/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<FILENAME>":           debian.FileName(artifact, fileVersion, arch),
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *debianPackageType) DeleteVersion(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete debian artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *debianPackageType) ReportDeleteVersionEvent(
+	ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeDEBIAN,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *debianPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for debian, indices are built per registry
+}
+
+func (c *debianPackageType) ReportBuildRegistryIndexEvent(
+	ctx context.Context,
+	registryID int64,
+	sources []types.SourceRef,
+) {
+	c.registryHelper.ReportBuildRegistryIndexEvent(ctx, registryID, sources)
+}
+
+func (c *debianPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	filePathPrefix := "/" + artifactName
+	if versionName != "" {
+		fileVersion, arch := splitDebianVersion(versionName)
+		filePathPrefix += "/" + fileVersion + "/" + arch
+	}
+	return filePathPrefix
+}
+
+func (c *debianPackageType) DeleteArtifact(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete debian artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *debianPackageType) GetPackageURL(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "debian")
+}
+
+func (c *debianPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *debianPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *debianPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := c.GetFilePath(artifactName, version) + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, auth.IsAnonymousSession(session))
+	// published packages are stored below {distribution}/{component}, which is also their pool location.
+	if parts := strings.Split(filename, "/"); len(parts) == 3 {
+		downloadCommand = strings.ReplaceAll(downloadCommand, "<DISTRIBUTION>", parts[0])
+		downloadCommand = strings.ReplaceAll(downloadCommand, "<COMPONENT>", parts[1])
+	}
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *debianPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromDebianArtifactDetailConfig(artifact.DebianArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *debianPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+	isAnonymous := auth.IsAnonymousSession(session)
+
+	var sections []artifact.ClientSetupSection
+	if !isAnonymous {
+		section := artifact.ClientSetupSection{
+			Header: registryutils.StringPtr("Configure Authentication"),
+		}
+		_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+			Steps: &[]artifact.ClientSetupStep{
+				{
+					Header: registryutils.StringPtr("Generate an identity token for authentication"),
+					Type:   &generateTokenType,
+				},
+				{
+					Header: registryutils.StringPtr("Create /etc/apt/auth.conf.d/harness-<REGISTRY_NAME>.conf " +
+						"with the following content:"),
+					Type: &staticStepType,
+					Commands: &[]artifact.ClientSetupStepCommand{
+						{
+							Value: registryutils.StringPtr("machine <REGISTRY_URL>\n" +
+								"login <USERNAME>\n" +
+								"password <token from step 1>"),
+						},
+					},
+				},
+			},
+		})
+		sections = append(sections, section)
+	}
+
+	var keyAuthHeader string
+	if !isAnonymous {
+		keyAuthHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	installSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = installSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Import the key used to sign the repository:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl -fsSL '<REGISTRY_URL>/repository.key'" + keyAuthHeader +
+							" | sudo gpg --dearmor -o /etc/apt/keyrings/harness-<REGISTRY_NAME>.gpg"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Add the registry to your APT sources:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("echo \"deb [signed-by=/etc/apt/keyrings/harness-<REGISTRY_NAME>.gpg]" +
+							" <REGISTRY_URL> <DISTRIBUTION> <COMPONENT>\" | " +
+							"sudo tee /etc/apt/sources.list.d/harness-<REGISTRY_NAME>.list"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Update the package index and install the package:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("sudo apt-get update && sudo apt-get install <ARTIFACT_NAME>"),
+					},
+				},
+			},
+		},
+	})
+
+	publishSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = publishSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Upload your package to a distribution and component:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/pool/<DISTRIBUTION>/<COMPONENT>/upload' \\\n" +
+							"--form 'file=@\"<FILE_PATH>\"' \\\n" +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+		},
+	})
+
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, publishSection)
+	}
+	sections = append(sections, installSection)
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Debian Client Setup",
+		SecHeader:  "Follow these instructions to install/use Debian packages from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func (c *debianPackageType) BuildRegistryIndexAsync(
+	ctx context.Context,
+	registry *types.Registry,
+	payload types.BuildRegistryIndexTaskPayload,
+) error {
+	// upstream indices are proxied as-is so that their signatures stay valid.
+	if registry.Type == artifact.RegistryTypeUPSTREAM {
+		return nil
+	}
+	err := c.debianRegistryHelper.BuildRegistryIndex(ctx, registry, payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to build DEBIAN registry index for registry [%d]: %w", registry.ID, err)
+	}
+	return nil
+}
+
+func (c *debianPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *debianPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return nil
+}
+
+func (c *debianPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{"/" + packageName}, nil
+}
+
+func (c *debianPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, version)}, nil
+}
+
+func (c *debianPackageType) GetPkgDownloadURL(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+) (string, error) {
+	return "", nil
+}
+
+func (c *debianPackageType) GetPurlForArtifact(
+	packageName string,
+	version string,
+) (string, error) {
+	if packageName == "" {
+		return "", fmt.Errorf("packageName cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	fileVersion, arch := splitDebianVersion(version)
+	if arch == "" {
+		return fmt.Sprintf("pkg:deb/%s@%s", packageName, fileVersion), nil
+	}
+	return fmt.Sprintf("pkg:deb/%s@%s?arch=%s", packageName, fileVersion, arch), nil
+}
+
+func (c *debianPackageType) GetPackageAndVersionFromNodePath(
+	nodePath string,
+) (string, string, string) {
+	// Format: /{packageName}/{version}/{arch}/[{distribution}/{component}/]{filename} (excluding dists)
+	matches := debianNodePathRegex.FindStringSubmatch(nodePath)
+	if len(matches) == 4 && matches[1] != debian.DistsPrefix {
+		return matches[1], matches[2] + "_" + matches[3], ""
+	}
+	return "", "", ""
+}
+
+func (c *debianPackageType) IsArtifactMainFile(nodePath string) bool {
+	return getExtension(nodePath) == debian.DebFileExtension
+}
+
+// splitDebianVersion splits an artifact version of the form {version}_{arch} into its parts.
+func splitDebianVersion(version string) (string, string) {
+	idx := strings.LastIndex(version, "_")
+	if idx < 0 {
+		return version, ""
+	}
+	return version[:idx], version[idx+1:]
+}
@@ -818,3 +818,235 @@ func TestHuggingFacePackageType_GetNodePathsForArtifact_Updated(t *testing.T) {
 		})
 	}
 }
+
+func TestDebianPackageType_GetNodePathsForArtifact(t *testing.T) {
+	debianPackage := NewDebianPackageType(nil, nil)
+
+	tests := []struct {
+		name          string
+		packageName   string
+		version       string
+		expectedPaths []string
+	}{
+		{
+			name:          "arch specific package",
+			packageName:   "hello",
+			version:       "2.10-3_amd64",
+			expectedPaths: []string{"/hello/2.10-3/amd64"},
+		},
+		{
+			name:          "arch independent package",
+			packageName:   "python3-six",
+			version:       "1.16.0-4_all",
+			expectedPaths: []string{"/python3-six/1.16.0-4/all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := debianPackage.GetNodePathsForArtifact(nil, tt.packageName, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+func TestDebianPackageType_GetPackageAndVersionFromNodePath(t *testing.T) {
+	debianPackage := NewDebianPackageType(nil, nil)
+
+	tests := []struct {
+		name            string
+		nodePath        string
+		expectedPackage string
+		expectedVersion string
+	}{
+		{
+			name:            "published package",
+			nodePath:        "/hello/2.10-3/amd64/bookworm/main/hello_2.10-3_amd64.deb",
+			expectedPackage: "hello",
+			expectedVersion: "2.10-3_amd64",
+		},
+		{
+			name:            "cached upstream package",
+			nodePath:        "/hello/2.10-3/amd64/hello_2.10-3_amd64.deb",
+			expectedPackage: "hello",
+			expectedVersion: "2.10-3_amd64",
+		},
+		{
+			name:     "distribution index",
+			nodePath: "/dists/bookworm/main/binary-amd64/Packages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgName, version, _ := debianPackage.GetPackageAndVersionFromNodePath(tt.nodePath)
+			assert.Equal(t, tt.expectedPackage, pkgName)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func TestTerraformPackageType_GetNodePathsForArtifact(t *testing.T) {
+	terraformPackage := NewTerraformPackageType(nil)
+
+	tests := []struct {
+		name          string
+		packageName   string
+		version       string
+		expectedPaths []string
+	}{
+		{
+			name:          "module",
+			packageName:   "hashicorp/consul/aws",
+			version:       "0.11.0",
+			expectedPaths: []string{"/modules/hashicorp/consul/aws/0.11.0"},
+		},
+		{
+			name:          "provider",
+			packageName:   "hashicorp/aws",
+			version:       "5.31.0",
+			expectedPaths: []string{"/providers/hashicorp/aws/5.31.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := terraformPackage.GetNodePathsForArtifact(nil, tt.packageName, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+func TestTerraformPackageType_GetPackageAndVersionFromNodePath(t *testing.T) {
+	terraformPackage := NewTerraformPackageType(nil)
+
+	tests := []struct {
+		name            string
+		nodePath        string
+		expectedPackage string
+		expectedVersion string
+	}{
+		{
+			name:            "module archive",
+			nodePath:        "/modules/hashicorp/consul/aws/0.11.0/hashicorp-consul-aws-0.11.0.tar.gz",
+			expectedPackage: "hashicorp/consul/aws",
+			expectedVersion: "0.11.0",
+		},
+		{
+			name:            "provider package",
+			nodePath:        "/providers/hashicorp/aws/5.31.0/terraform-provider-aws_5.31.0_linux_amd64.zip",
+			expectedPackage: "hashicorp/aws",
+			expectedVersion: "5.31.0",
+		},
+		{
+			name:     "unknown layout",
+			nodePath: "/hashicorp/aws/5.31.0/terraform-provider-aws_5.31.0_linux_amd64.zip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgName, version, _ := terraformPackage.GetPackageAndVersionFromNodePath(tt.nodePath)
+			assert.Equal(t, tt.expectedPackage, pkgName)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func TestRubyGemsPackageType_GetPackageAndVersionFromNodePath(t *testing.T) {
+	rubygemsPackage := NewRubyGemsPackageType(nil, nil)
+
+	tests := []struct {
+		name            string
+		nodePath        string
+		expectedPackage string
+		expectedVersion string
+	}{
+		{
+			name:            "gem",
+			nodePath:        "/gems/rails/7.1.2/rails-7.1.2.gem",
+			expectedPackage: "rails",
+			expectedVersion: "7.1.2",
+		},
+		{
+			name:            "platform gem",
+			nodePath:        "/gems/nokogiri/1.15.5-x86_64-linux/nokogiri-1.15.5-x86_64-linux.gem",
+			expectedPackage: "nokogiri",
+			expectedVersion: "1.15.5-x86_64-linux",
+		},
+		{
+			name:     "index file",
+			nodePath: "/index/info/rails",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgName, version, _ := rubygemsPackage.GetPackageAndVersionFromNodePath(tt.nodePath)
+			assert.Equal(t, tt.expectedPackage, pkgName)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func TestRubyGemsPackageType_GetPurlForArtifact(t *testing.T) {
+	rubygemsPackage := NewRubyGemsPackageType(nil, nil)
+
+	purl, err := rubygemsPackage.GetPurlForArtifact("rails", "7.1.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "pkg:gem/rails@7.1.2", purl)
+
+	purl, err = rubygemsPackage.GetPurlForArtifact("nokogiri", "1.15.5-x86_64-linux")
+	assert.NoError(t, err)
+	assert.Equal(t, "pkg:gem/nokogiri@1.15.5?platform=x86_64-linux", purl)
+}
+
+func TestCondaPackageType_GetPackageAndVersionFromNodePath(t *testing.T) {
+	condaPackage := NewCondaPackageType(nil, nil)
+
+	tests := []struct {
+		name            string
+		nodePath        string
+		expectedPackage string
+		expectedVersion string
+	}{
+		{
+			name:            "conda package",
+			nodePath:        "/numpy/1.26.4/linux-64/numpy-1.26.4-py312h8753938_0.conda",
+			expectedPackage: "numpy",
+			expectedVersion: "1.26.4",
+		},
+		{
+			name:            "noarch tar.bz2 package",
+			nodePath:        "/requests/2.31.0/noarch/requests-2.31.0-pyhd8ed1ab_0.tar.bz2",
+			expectedPackage: "requests",
+			expectedVersion: "2.31.0",
+		},
+		{
+			name:     "repodata",
+			nodePath: "/repodata/linux-64/repodata.json",
+		},
+		{
+			name:     "version directory",
+			nodePath: "/numpy/1.26.4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgName, version, _ := condaPackage.GetPackageAndVersionFromNodePath(tt.nodePath)
+			assert.Equal(t, tt.expectedPackage, pkgName)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func TestCondaPackageType_GetPurlForArtifact(t *testing.T) {
+	condaPackage := NewCondaPackageType(nil, nil)
+
+	purl, err := condaPackage.GetPurlForArtifact("numpy", "1.26.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "pkg:conda/numpy@1.26.4", purl)
+}
@@ -0,0 +1,472 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/utils/rubygems"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+// rubygemsNodePathRegex matches /gems/{gemName}/{version}/{filename}.
+var rubygemsNodePathRegex = regexp.MustCompile(`^/gems/([^/]+)/([^/]+)/[^/]+$`)
+
+type RubyGemsPackageType interface {
+	interfaces.PackageHelper
+}
+
+type rubygemsPackageType struct {
+	packageType            string
+	registryHelper         interfaces.RegistryHelper
+	pathPackageType        string
+	validRepoTypes         []string
+	validUpstreamSources   []string
+	upstreamSourceConfig   map[string]UpstreamSourceConfig
+	rubygemsRegistryHelper rubygems.RegistryHelper
+}
+
+func NewRubyGemsPackageType(
+	registryHelper interfaces.RegistryHelper,
+	rubygemsRegistryHelper rubygems.RegistryHelper,
+) RubyGemsPackageType {
+	return &rubygemsPackageType{
+		packageType:     string(artifact.PackageTypeRUBYGEMS),
+		pathPackageType: string(types.PathPackageTypeRubyGems),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceRubyGems),
+			string(artifact.UpstreamConfigSourceCustom),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceRubyGems): {
+				urlRequired: false,
+			},
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+		},
+		rubygemsRegistryHelper: rubygemsRegistryHelper,
+	}
+}
+
+func (c *rubygemsPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *rubygemsPackageType) IsFileOperationSupported() bool {
+	return false
+}
+
+func (c *rubygemsPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *rubygemsPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *rubygemsPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *rubygemsPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *rubygemsPackageType) GetPullCommand(_ string, image string, version string) string {
+	gemVersion, platform := rubygems.SplitFullVersion(version)
+	if platform != rubygems.PlatformRuby {
+		return "gem install " + image + " --version " + gemVersion + " --platform " + platform
+	}
+	return "gem install " + image + " --version " + gemVersion
+}
+
+func (c *rubygemsPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifact string,
+	version string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/gems/<FILENAME>'" + authHeader + " -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<FILENAME>":           rubygems.FileName(artifact, version),
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *rubygemsPackageType) DeleteVersion(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete rubygems artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *rubygemsPackageType) ReportDeleteVersionEvent(
+	ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeRUBYGEMS,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *rubygemsPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for rubygems, indices are built per registry
+}
+
+func (c *rubygemsPackageType) ReportBuildRegistryIndexEvent(
+	ctx context.Context,
+	registryID int64,
+	sources []types.SourceRef,
+) {
+	c.registryHelper.ReportBuildRegistryIndexEvent(ctx, registryID, sources)
+}
+
+func (c *rubygemsPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	return rubygems.FilePath(artifactName, versionName)
+}
+
+func (c *rubygemsPackageType) DeleteArtifact(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete rubygems artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *rubygemsPackageType) GetPackageURL(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "rubygems")
+}
+
+func (c *rubygemsPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *rubygemsPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *rubygemsPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := c.GetFilePath(artifactName, version) + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, auth.IsAnonymousSession(session))
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *rubygemsPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromRubyGemsArtifactDetailConfig(artifact.RubyGemsArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *rubygemsPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+	isAnonymous := auth.IsAnonymousSession(session)
+
+	var sections []artifact.ClientSetupSection
+	if !isAnonymous {
+		section := artifact.ClientSetupSection{
+			Header: registryutils.StringPtr("Configure Authentication"),
+		}
+		_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+			Steps: &[]artifact.ClientSetupStep{
+				{
+					Header: registryutils.StringPtr("Generate an identity token for authentication"),
+					Type:   &generateTokenType,
+				},
+				{
+					Header: registryutils.StringPtr("Add the token to ~/.gem/credentials for gem push:"),
+					Type:   &staticStepType,
+					Commands: &[]artifact.ClientSetupStepCommand{
+						{
+							Value: registryutils.StringPtr("---\n<REGISTRY_URL>: <token from step 1>"),
+						},
+					},
+				},
+				{
+					Header: registryutils.StringPtr("Configure bundler to authenticate with the registry:"),
+					Type:   &staticStepType,
+					Commands: &[]artifact.ClientSetupStepCommand{
+						{
+							Value: registryutils.StringPtr("bundle config set --global <REGISTRY_URL>/ " +
+								"<USERNAME>:<token from step 1>"),
+						},
+					},
+				},
+			},
+		})
+		sections = append(sections, section)
+	}
+
+	installSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = installSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Add the registry as a source to your Gemfile:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("source \"<REGISTRY_URL>/\" do\n" +
+							"  gem \"<ARTIFACT_NAME>\", \"<VERSION>\"\n" +
+							"end"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Or install the gem directly:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("gem install <ARTIFACT_NAME> --version <VERSION> " +
+							"--source <REGISTRY_URL>/"),
+					},
+				},
+			},
+		},
+	})
+
+	publishSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = publishSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Build and push your gem:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("gem build <ARTIFACT_NAME>.gemspec\n" +
+							"gem push --host <REGISTRY_URL> <ARTIFACT_NAME>-<VERSION>.gem"),
+					},
+				},
+			},
+		},
+	})
+
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, publishSection)
+	}
+	sections = append(sections, installSection)
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "RubyGems Client Setup",
+		SecHeader:  "Follow these instructions to install/use gems from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func (c *rubygemsPackageType) BuildRegistryIndexAsync(
+	ctx context.Context,
+	registry *types.Registry,
+	payload types.BuildRegistryIndexTaskPayload,
+) error {
+	// upstream indices are proxied as-is.
+	if registry.Type == artifact.RegistryTypeUPSTREAM {
+		return nil
+	}
+	err := c.rubygemsRegistryHelper.BuildRegistryIndex(ctx, registry, payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to build RUBYGEMS registry index for registry [%d]: %w", registry.ID, err)
+	}
+	return nil
+}
+
+func (c *rubygemsPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *rubygemsPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return nil
+}
+
+func (c *rubygemsPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, "")}, nil
+}
+
+func (c *rubygemsPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, version)}, nil
+}
+
+func (c *rubygemsPackageType) GetPkgDownloadURL(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+) (string, error) {
+	return "", nil
+}
+
+func (c *rubygemsPackageType) GetPurlForArtifact(
+	packageName string,
+	version string,
+) (string, error) {
+	if packageName == "" {
+		return "", fmt.Errorf("packageName cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	gemVersion, platform := rubygems.SplitFullVersion(version)
+	if platform != rubygems.PlatformRuby {
+		return fmt.Sprintf("pkg:gem/%s@%s?platform=%s", packageName, gemVersion, platform), nil
+	}
+	return fmt.Sprintf("pkg:gem/%s@%s", packageName, gemVersion), nil
+}
+
+func (c *rubygemsPackageType) GetPackageAndVersionFromNodePath(
+	nodePath string,
+) (string, string, string) {
+	// Format: /gems/{gemName}/{version}/{filename}
+	matches := rubygemsNodePathRegex.FindStringSubmatch(nodePath)
+	if len(matches) == 3 {
+		return matches[1], matches[2], ""
+	}
+	return "", "", ""
+}
+
+func (c *rubygemsPackageType) IsArtifactMainFile(nodePath string) bool {
+	return getExtension(nodePath) == rubygems.GemFileExtension
+}
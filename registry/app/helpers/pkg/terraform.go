@@ -0,0 +1,454 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+// terraformNodePathRegex matches /modules/{namespace}/{name}/{system}/{version}/{filename} and
+// /providers/{namespace}/{type}/{version}/{filename}.
+var terraformNodePathRegex = regexp.MustCompile(
+	`^/(?:modules/([^/]+/[^/]+/[^/]+)|providers/([^/]+/[^/]+))/([^/]+)/[^/]+$`)
+
+type TerraformPackageType interface {
+	interfaces.PackageHelper
+}
+
+type terraformPackageType struct {
+	packageType     string
+	registryHelper  interfaces.RegistryHelper
+	pathPackageType string
+	validRepoTypes  []string
+}
+
+func NewTerraformPackageType(registryHelper interfaces.RegistryHelper) TerraformPackageType {
+	return &terraformPackageType{
+		packageType:     string(artifact.PackageTypeTERRAFORM),
+		pathPackageType: string(types.PathPackageTypeTerraform),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+	}
+}
+
+func (c *terraformPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *terraformPackageType) IsFileOperationSupported() bool {
+	return false
+}
+
+func (c *terraformPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *terraformPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *terraformPackageType) IsValidUpstreamSource(_ string) bool {
+	return false
+}
+
+func (c *terraformPackageType) IsURLRequiredForUpstreamSource(_ string) bool {
+	return true
+}
+
+func (c *terraformPackageType) GetPullCommand(_ string, _ string, _ string) string {
+	return ""
+}
+
+func (c *terraformPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifact string,
+	version string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/v1/<KIND>/<ARTIFACT>/<VERSION>/files/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<KIND>":               strings.TrimPrefix(terraform.PathPrefix(artifact), "/"),
+		"<VERSION>":            version,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+	// <KIND> already contains the artifact name.
+	return strings.ReplaceAll(downloadCommand, "/<ARTIFACT>", "")
+}
+
+func (c *terraformPackageType) DeleteVersion(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete terraform artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *terraformPackageType) ReportDeleteVersionEvent(
+	ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeTERRAFORM,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *terraformPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for terraform, versions are listed from the database
+}
+
+func (c *terraformPackageType) ReportBuildRegistryIndexEvent(_ context.Context, _ int64, _ []types.SourceRef) {
+	// no-op for terraform
+}
+
+func (c *terraformPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	return terraform.FilePath(artifactName, versionName)
+}
+
+func (c *terraformPackageType) DeleteArtifact(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete terraform artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *terraformPackageType) GetPackageURL(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "terraform")
+}
+
+func (c *terraformPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *terraformPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *terraformPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := c.GetFilePath(artifactName, version) + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, auth.IsAnonymousSession(session))
+	downloadCommand = strings.ReplaceAll(downloadCommand, "<FILENAME>", filename)
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *terraformPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromTerraformArtifactDetailConfig(artifact.TerraformArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+// GetClientSetupDetails documents a host block for the Terraform CLI configuration, since service discovery
+// is served per registry rather than from the root of the host.
+func (c *terraformPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	_ artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+	isAnonymous := auth.IsAnonymousSession(session)
+
+	cliConfig := "host \"<LOGIN_HOSTNAME>\" {\n" +
+		"  services = {\n" +
+		"    \"modules.v1\"   = \"<REGISTRY_URL>/v1/modules/\",\n" +
+		"    \"providers.v1\" = \"<REGISTRY_URL>/v1/providers/\"\n" +
+		"  }\n" +
+		"}"
+	configureSteps := []artifact.ClientSetupStep{}
+	if !isAnonymous {
+		cliConfig += "\n\ncredentials \"<LOGIN_HOSTNAME>\" {\n" +
+			"  token = \"<token from step 1>\"\n" +
+			"}"
+		configureSteps = append(configureSteps, artifact.ClientSetupStep{
+			Header: registryutils.StringPtr("Generate an identity token for authentication"),
+			Type:   &generateTokenType,
+		})
+	}
+	configureSteps = append(configureSteps, artifact.ClientSetupStep{
+		Header: registryutils.StringPtr("Add the following to your Terraform CLI configuration (~/.terraformrc):"),
+		Type:   &staticStepType,
+		Commands: &[]artifact.ClientSetupStepCommand{
+			{Value: registryutils.StringPtr(cliConfig)},
+		},
+	})
+	configureSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Terraform"),
+	}
+	_ = configureSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{Steps: &configureSteps})
+
+	useSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Use Modules and Providers"),
+	}
+	_ = useSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Reference a module from the registry:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("module \"<NAME>\" {\n" +
+							"  source  = \"<LOGIN_HOSTNAME>/<ARTIFACT_NAME>\"\n" +
+							"  version = \"<VERSION>\"\n" +
+							"}"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Require a provider from the registry:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("terraform {\n" +
+							"  required_providers {\n" +
+							"    <TYPE> = {\n" +
+							"      source  = \"<LOGIN_HOSTNAME>/<ARTIFACT_NAME>\"\n" +
+							"      version = \"<VERSION>\"\n" +
+							"    }\n" +
+							"  }\n" +
+							"}"),
+					},
+				},
+			},
+		},
+	})
+
+	publishSection := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Modules and Providers"),
+	}
+	_ = publishSection.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Upload a module archive (.tar.gz):"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/v1/modules/<NAMESPACE>/<NAME>/<SYSTEM>/<VERSION>' \\\n" +
+							"--form 'file=@\"<FILE_PATH>\"' \\\n" +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Upload a provider package (.zip) for one platform:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/v1/providers/<NAMESPACE>/<TYPE>/<VERSION>/<OS>/<ARCH>?protocols=5.0' \\\n" +
+							"--form 'file=@\"<FILE_PATH>\"' \\\n" +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+		},
+	})
+
+	sections := []artifact.ClientSetupSection{configureSection}
+	if !isAnonymous {
+		sections = append(sections, publishSection)
+	}
+	sections = append(sections, useSection)
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Terraform Client Setup",
+		SecHeader:  "Follow these instructions to install/use Terraform modules and providers from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", registryURL)
+
+	return &clientSetupDetails, nil
+}
+
+func (c *terraformPackageType) BuildRegistryIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildRegistryIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *terraformPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *terraformPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return nil
+}
+
+func (c *terraformPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, "")}, nil
+}
+
+func (c *terraformPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	return []string{c.GetFilePath(packageName, version)}, nil
+}
+
+func (c *terraformPackageType) GetPkgDownloadURL(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+) (string, error) {
+	return "", nil
+}
+
+func (c *terraformPackageType) GetPurlForArtifact(
+	packageName string,
+	version string,
+) (string, error) {
+	if packageName == "" {
+		return "", fmt.Errorf("packageName cannot be empty")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version cannot be empty")
+	}
+	return fmt.Sprintf("pkg:terraform/%s@%s", packageName, version), nil
+}
+
+func (c *terraformPackageType) GetPackageAndVersionFromNodePath(
+	nodePath string,
+) (string, string, string) {
+	// Format: /modules/{namespace}/{name}/{system}/{version}/{filename}
+	// or /providers/{namespace}/{type}/{version}/{filename}
+	matches := terraformNodePathRegex.FindStringSubmatch(nodePath)
+	if len(matches) != 4 {
+		return "", "", ""
+	}
+	if matches[1] != "" {
+		return matches[1], matches[3], ""
+	}
+	return matches[2], matches[3], ""
+}
+
+func (c *terraformPackageType) IsArtifactMainFile(nodePath string) bool {
+	return terraform.IsMainArtifactFile(nodePath)
+}
@@ -25,6 +25,9 @@ import (
 	"github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/app/utils/conda"
+	"github.com/harness/gitness/registry/app/utils/debian"
+	"github.com/harness/gitness/registry/app/utils/rubygems"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 
@@ -35,6 +38,9 @@ func ProvidePackageWrapperProvider(
 	registryHelper interfaces.RegistryHelper,
 	regFinder refcache.RegistryFinder,
 	cargoRegistryHelper cargo.RegistryHelper,
+	debianRegistryHelper debian.RegistryHelper,
+	rubygemsRegistryHelper rubygems.RegistryHelper,
+	condaRegistryHelper conda.RegistryHelper,
 ) interfaces.PackageWrapper {
 	// create package factory
 	packageFactory := factory.NewPackageFactory()
@@ -49,6 +55,10 @@ func ProvidePackageWrapperProvider(
 	packageFactory.Register(pkg.NewNPMPackageType(registryHelper, nil))
 	packageFactory.Register(pkg.NewGoPackageType(registryHelper))
 	packageFactory.Register(pkg.NewHuggingFacePackageType(registryHelper))
+	packageFactory.Register(pkg.NewDebianPackageType(registryHelper, debianRegistryHelper))
+	packageFactory.Register(pkg.NewTerraformPackageType(registryHelper))
+	packageFactory.Register(pkg.NewRubyGemsPackageType(registryHelper, rubygemsRegistryHelper))
+	packageFactory.Register(pkg.NewCondaPackageType(registryHelper, condaRegistryHelper))
 
 	return NewPackageWrapper(packageFactory, regFinder)
 }
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*CondaMetadata)(nil)
+
+// PackageRecord is the info/index.json of a package file, extended with the checksums and size of the file,
+// as listed in repodata.json.
+// Source: https://github.com/conda/conda/blob/main/conda/models/records.py
+type PackageRecord struct {
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	Build         string   `json:"build"`
+	BuildNumber   int64    `json:"build_number"`
+	Depends       []string `json:"depends"`
+	Constrains    []string `json:"constrains,omitempty"`
+	License       string   `json:"license,omitempty"`
+	LicenseFamily string   `json:"license_family,omitempty"`
+	Subdir        string   `json:"subdir"`
+	Arch          string   `json:"arch,omitempty"`
+	Platform      string   `json:"platform,omitempty"`
+	Noarch        string   `json:"noarch,omitempty"`
+	Features      string   `json:"features,omitempty"`
+	TrackFeatures string   `json:"track_features,omitempty"`
+	Timestamp     int64    `json:"timestamp,omitempty"`
+	MD5           string   `json:"md5"`
+	Sha256        string   `json:"sha256"`
+	Size          int64    `json:"size"`
+}
+
+// Metadata Source: info/about.json and info/index.json of the package files of a version.
+type Metadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	License     string `json:"license,omitempty"`
+	Home        string `json:"home,omitempty"`
+	DevURL      string `json:"dev_url,omitempty"`
+	DocURL      string `json:"doc_url,omitempty"`
+	// Packages holds the records of the package files of the version, keyed by <subdir>/<file name>.
+	Packages map[string]PackageRecord `json:"packages,omitempty"`
+}
+
+// CondaMetadata represents the metadata for a Conda package.
+//
+//nolint:revive
+type CondaMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *CondaMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *CondaMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
+
+func (p *CondaMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *CondaMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
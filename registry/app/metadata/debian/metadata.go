@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*DebianMetadata)(nil)
+
+type Metadata struct {
+	VersionMetadata VersionMetadata `json:"version_metadata"`
+	FileMetadata    FileMetadata    `json:"file_metadata"`
+}
+
+type VersionMetadata struct {
+	Maintainer  string `json:"maintainer,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	Section     string `json:"section,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type FileMetadata struct {
+	Package       string   `json:"package,omitempty"`
+	Version       string   `json:"version,omitempty"`
+	Architecture  string   `json:"architecture,omitempty"`
+	Source        string   `json:"source,omitempty"`
+	InstalledSize int64    `json:"installed_size,omitempty"`
+	Depends       []string `json:"depends,omitempty"`
+	PreDepends    []string `json:"pre_depends,omitempty"`
+	Recommends    []string `json:"recommends,omitempty"`
+	Provides      []string `json:"provides,omitempty"`
+	Conflicts     []string `json:"conflicts,omitempty"`
+
+	// Control is the control paragraph of the package without any file specific fields.
+	// It is used verbatim as the package stanza of the generated Packages indices.
+	Control string `json:"control,omitempty"`
+}
+
+// DebianMetadata represents the metadata for a Debian package.
+//
+//nolint:revive
+type DebianMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *DebianMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *DebianMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *DebianMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *DebianMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*RubyGemsMetadata)(nil)
+
+// Dependency is a gem dependency with its version requirements, e.g. ">= 1.0" and "< 2".
+type Dependency struct {
+	Name         string   `json:"name"`
+	Requirements []string `json:"requirements,omitempty"`
+}
+
+type Metadata struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Platform    string            `json:"platform"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Authors     []string          `json:"authors,omitempty"`
+	Licenses    []string          `json:"licenses,omitempty"`
+	Homepage    string            `json:"homepage,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	Dependencies            []Dependency `json:"dependencies,omitempty"`
+	DevelopmentDependencies []Dependency `json:"development_dependencies,omitempty"`
+	RequiredRubyVersion     []string     `json:"required_ruby_version,omitempty"`
+	RequiredRubygemsVersion []string     `json:"required_rubygems_version,omitempty"`
+}
+
+// RubyGemsMetadata represents the metadata for a gem.
+//
+//nolint:revive
+type RubyGemsMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *RubyGemsMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *RubyGemsMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *RubyGemsMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *RubyGemsMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
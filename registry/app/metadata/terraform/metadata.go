@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*TerraformMetadata)(nil)
+
+type Kind string
+
+const (
+	KindModule   Kind = "module"
+	KindProvider Kind = "provider"
+)
+
+type Metadata struct {
+	Kind      Kind   `json:"kind"`
+	Namespace string `json:"namespace"`
+	// Name is the module name or the provider type.
+	Name string `json:"name"`
+	// System is the target system of a module, e.g. aws. It is empty for providers.
+	System string `json:"system,omitempty"`
+	// Protocols are the Terraform plugin protocol versions a provider version supports.
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// TerraformMetadata represents the metadata for a Terraform module or provider version.
+//
+//nolint:revive
+type TerraformMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *TerraformMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *TerraformMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *TerraformMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *TerraformMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
+
+// ServiceDiscovery is the document served at /.well-known/terraform.json.
+type ServiceDiscovery struct {
+	ModulesV1   string `json:"modules.v1"`   //nolint:tagliatelle
+	ProvidersV1 string `json:"providers.v1"` //nolint:tagliatelle
+}
+
+type ModuleVersion struct {
+	Version string `json:"version"`
+}
+
+type ModuleVersions struct {
+	Versions []ModuleVersion `json:"versions"`
+}
+
+// ModuleVersionsResponse is the response of the module registry protocol's list versions endpoint.
+type ModuleVersionsResponse struct {
+	Modules []ModuleVersions `json:"modules"`
+}
+
+type Platform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type ProviderVersion struct {
+	Version   string     `json:"version"`
+	Protocols []string   `json:"protocols"`
+	Platforms []Platform `json:"platforms"`
+}
+
+// ProviderVersionsResponse is the response of the provider registry protocol's list versions endpoint.
+type ProviderVersionsResponse struct {
+	Versions []ProviderVersion `json:"versions"`
+}
+
+type GPGPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+type SigningKeys struct {
+	GPGPublicKeys []GPGPublicKey `json:"gpg_public_keys"`
+}
+
+// ProviderPackage is the response of the provider registry protocol's find package endpoint.
+type ProviderPackage struct {
+	Protocols           []string    `json:"protocols"`
+	OS                  string      `json:"os"`
+	Arch                string      `json:"arch"`
+	Filename            string      `json:"filename"`
+	DownloadURL         string      `json:"download_url"`
+	ShasumsURL          string      `json:"shasums_url"`
+	ShasumsSignatureURL string      `json:"shasums_signature_url"`
+	Shasum              string      `json:"shasum"`
+	SigningKeys         SigningKeys `json:"signing_keys"`
+}
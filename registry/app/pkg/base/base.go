@@ -33,6 +33,7 @@ import (
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
 	"github.com/harness/gitness/registry/app/metadata"
 	"github.com/harness/gitness/registry/app/pkg"
 	registryaudit "github.com/harness/gitness/registry/app/pkg/audit"
@@ -42,6 +43,7 @@ import (
 	"github.com/harness/gitness/registry/app/storage"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/enum"
 
@@ -119,6 +121,10 @@ type LocalBase interface {
 		imageUUID string, artifactUUID string,
 	)
 
+	// ScheduleScan enqueues a vulnerability scan of the artifact version when
+	// the registry has an enabled scan policy.
+	ScheduleScan(ctx context.Context, registryID int64, artifactID int64)
+
 	CheckIfVersionExists(
 		ctx context.Context,
 		info pkg.PackageArtifactInfo,
@@ -150,6 +156,8 @@ type localBase struct {
 	authorizer     authz.Authorizer
 	spaceFinder    refcache.SpaceFinder
 	auditService   audit.Service
+	scanPolicyDao  store.ScanPolicyRepository
+	reporter       *asyncprocessing.Reporter
 }
 
 func NewLocalBase(
@@ -164,6 +172,8 @@ func NewLocalBase(
 	authorizer authz.Authorizer,
 	spaceFinder refcache.SpaceFinder,
 	auditService audit.Service,
+	scanPolicyDao store.ScanPolicyRepository,
+	reporter *asyncprocessing.Reporter,
 ) LocalBase {
 	return &localBase{
 		registryDao:    registryDao,
@@ -177,6 +187,8 @@ func NewLocalBase(
 		authorizer:     authorizer,
 		spaceFinder:    spaceFinder,
 		auditService:   auditService,
+		scanPolicyDao:  scanPolicyDao,
+		reporter:       reporter,
 	}
 }
 
@@ -378,6 +390,7 @@ func (l *localBase) MoveMultipleTempFilesAndCreateArtifact(
 		}
 	}
 
+	var artifactID int64
 	var imageUUID string
 	var artifactUUID string
 	err := l.tx.WithTx(
@@ -428,7 +441,7 @@ func (l *localBase) MoveMultipleTempFilesAndCreateArtifact(
 				Metadata: metadataJSON,
 			}
 
-			_, err = l.artifactDao.CreateOrUpdate(ctx, newArtifact)
+			artifactID, err = l.artifactDao.CreateOrUpdate(ctx, newArtifact)
 			if err != nil {
 				log.Ctx(ctx).Error().Msgf("Failed to create artifact : [%s] with error: %v", info.Image, err)
 				return fmt.Errorf("failed to create artifact : [%s] with error: %w", info.Image, err)
@@ -445,6 +458,7 @@ func (l *localBase) MoveMultipleTempFilesAndCreateArtifact(
 
 	// Audit log for artifact push
 	l.AuditPush(ctx, *info, version, imageUUID, artifactUUID)
+	l.ScheduleScan(ctx, info.RegistryID, artifactID)
 
 	return nil
 }
@@ -600,7 +614,30 @@ func (l *localBase) postUploadArtifact(
 
 			return nil
 		})
-	return artifactID, err
+	if err != nil {
+		return artifactID, err
+	}
+	l.ScheduleScan(ctx, registry.ID, artifactID)
+	return artifactID, nil
+}
+
+// ScheduleScan enqueues a vulnerability scan of the artifact version when the
+// registry has an enabled scan policy. Failures are logged and never fail the upload.
+func (l *localBase) ScheduleScan(ctx context.Context, registryID int64, artifactID int64) {
+	if l.scanPolicyDao == nil || l.reporter == nil || artifactID == 0 {
+		return
+	}
+	policy, err := l.scanPolicyDao.Find(ctx, registryID)
+	if err != nil {
+		if !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to get scan policy for registry %d", registryID)
+		}
+		return
+	}
+	if !policy.Enabled {
+		return
+	}
+	l.reporter.ScanArtifact(ctx, registryID, artifactID)
 }
 
 func (l *localBase) Download(
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
+	condametadata "github.com/harness/gitness/registry/app/metadata/conda"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/conda"
+	condautil "github.com/harness/gitness/registry/app/utils/conda"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage parses and stores a package file. publish schedules a rebuild of the repodata of the
+	// registry, packages cached from an upstream are listed by the upstream's own repodata instead.
+	UploadPackage(
+		ctx context.Context,
+		info conda.ArtifactInfo,
+		file io.Reader,
+		publish bool,
+	) (*commons.ResponseHeaders, string, error)
+}
+
+type registryHelper struct {
+	localBase              base.LocalBase
+	fileManager            filemanager.FileManager
+	postProcessingReporter *asyncprocessing.Reporter
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *asyncprocessing.Reporter,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:              localBase,
+		fileManager:            fileManager,
+		postProcessingReporter: postProcessingReporter,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info conda.ArtifactInfo,
+	file io.Reader,
+	publish bool,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	fileInfo, err := c.fileManager.UploadFileNoDBUpdate(ctx, info.RootIdentifier, nil, file, info.RootParentID,
+		info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err := c.fileManager.DownloadFileByDigest(ctx, info.RootIdentifier, fileInfo, info.RootParentID, info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	p, err := condautil.ParsePackage(r)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to parse conda package")
+		return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+	if p.Record.Name == condautil.RepodataPrefix {
+		return nil, "", errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("package name %q is reserved", p.Record.Name))
+	}
+
+	record := p.Record
+	record.MD5 = fileInfo.MD5
+	record.Sha256 = fileInfo.Sha256
+	record.Size = fileInfo.Size
+
+	fileName := condautil.FileName(record.Name, record.Version, record.Build, p.Extension)
+	info.Image = record.Name
+	info.Version = record.Version
+	info.Subdir = record.Subdir
+	info.FileName = fileName
+	info.Metadata = condametadata.Metadata{
+		Name:        record.Name,
+		Version:     record.Version,
+		Summary:     p.About.Summary,
+		Description: p.About.Description,
+		License:     p.About.License,
+		Home:        p.About.Home,
+		DevURL:      p.About.DevURL,
+		DocURL:      p.About.DocURL,
+		// existing records of the version are merged in when the metadata is updated.
+		Packages: map[string]condametadata.PackageRecord{record.Subdir + "/" + fileName: record},
+	}
+
+	path := condautil.FilePath(info.Image, info.Version) + "/" + record.Subdir + "/" + fileName
+	fileInfo.Filename = record.Subdir + "/" + fileName
+	rs, sha256, artifactID, _, err := c.localBase.UpdateFileManagerAndCreateArtifact(ctx, info.ArtifactInfo,
+		info.Version, path, &condametadata.CondaMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, true)
+	if err != nil {
+		return rs, "", err
+	}
+
+	if publish {
+		sources := make([]types.SourceRef, 0)
+		sources = append(sources, types.SourceRef{Type: types.SourceTypeArtifact, ID: artifactID})
+		c.postProcessingReporter.BuildRegistryIndex(ctx, info.RegistryID, sources)
+	}
+	return rs, sha256, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	condatype "github.com/harness/gitness/registry/app/pkg/types/conda"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	condautil "github.com/harness/gitness/registry/app/utils/conda"
+)
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase      base.LocalBase
+	fileManager    filemanager.FileManager
+	artifactDao    store.ArtifactRepository
+	registryHelper RegistryHelper
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	registryHelper RegistryHelper,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:      localBase,
+		fileManager:    fileManager,
+		artifactDao:    artifactDao,
+		registryHelper: registryHelper,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeCONDA}
+}
+
+func (c *localRegistry) UploadPackageFile(
+	ctx context.Context,
+	info condatype.ArtifactInfo,
+	file io.Reader,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	return c.registryHelper.UploadPackage(ctx, info, file, true)
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info condatype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	return downloadPackageFile(ctx, info, c.fileManager, c.artifactDao)
+}
+
+// GetRepodata serves the repodata generated from the packages of the registry. Registries without
+// packages have no repodata, so that their upstreams serve it.
+func (c *localRegistry) GetRepodata(
+	ctx context.Context,
+	info condatype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	fileReader, _, redirectURL, err := c.fileManager.DownloadFileByPath(ctx, condautil.RepodataPath(info.Subdir),
+		info.RegistryID, info.RegIdentifier, info.RootIdentifier, true)
+	if err != nil {
+		return responseHeaders, nil, nil, "", err
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
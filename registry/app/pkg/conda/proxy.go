@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	condatype "github.com/harness/gitness/registry/app/pkg/types/conda"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	cfg "github.com/harness/gitness/registry/config"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+
+	_ "github.com/harness/gitness/registry/app/remote/adapter/conda" // This is required to init conda adapter
+)
+
+var _ pkg.Artifact = (*proxy)(nil)
+var _ Registry = (*proxy)(nil)
+
+type proxy struct {
+	proxyStore     store.UpstreamProxyConfigRepository
+	fileManager    filemanager.FileManager
+	artifactDao    store.ArtifactRepository
+	registryHelper RegistryHelper
+	spaceFinder    refcache.SpaceFinder
+	service        secret.Service
+}
+
+type Proxy interface {
+	Registry
+}
+
+func NewProxy(
+	proxyStore store.UpstreamProxyConfigRepository,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	registryHelper RegistryHelper,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) Proxy {
+	return &proxy{
+		proxyStore:     proxyStore,
+		fileManager:    fileManager,
+		artifactDao:    artifactDao,
+		registryHelper: registryHelper,
+		spaceFinder:    spaceFinder,
+		service:        service,
+	}
+}
+
+func (r *proxy) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeUPSTREAM
+}
+
+func (r *proxy) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeCONDA}
+}
+
+// DownloadPackageFile serves a package from the cache, or fetches it from the upstream channel and caches
+// it in the background.
+func (r *proxy) DownloadPackageFile(
+	ctx context.Context,
+	info condatype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, readcloser, redirect, err := downloadPackageFile(ctx, info, r.fileManager, r.artifactDao)
+	if err == nil {
+		return headers, fileReader, readcloser, redirect, err
+	}
+	log.Warn().Ctx(ctx).Msgf("failed to download from local, err: %v", err)
+
+	helper, err := r.remoteHelper(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	closer, err := helper.GetPackage(ctx, info.Subdir, info.FileName)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	go func() {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		closer2, err2 := helper.GetPackage(ctx2, info.Subdir, info.FileName)
+		if err2 != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err2).Msgf("error while putting file to localRegistry, %v", err2)
+			return
+		}
+		defer closer2.Close()
+		_, _, err2 = r.registryHelper.UploadPackage(ctx2, info, closer2, false)
+		if err2 != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err2).Msgf("error while putting file to localRegistry, %v", err2)
+			return
+		}
+		log.Ctx(ctx2).Info().Msgf("Successfully updated file: %s, registry: %s", info.FileName, info.RegIdentifier)
+	}()
+
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, closer, "", nil
+}
+
+// GetRepodata proxies the repodata of the upstream channel as it is.
+func (r *proxy) GetRepodata(
+	ctx context.Context,
+	info condatype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	helper, err := r.remoteHelper(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	closer, err := helper.GetRepodata(ctx, info.Subdir)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, closer, "", nil
+}
+
+// UploadPackageFile is not supported for upstream registries.
+func (r *proxy) UploadPackageFile(
+	ctx context.Context,
+	_ condatype.ArtifactInfo,
+	_ io.Reader,
+) (*commons.ResponseHeaders, string, error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
+
+func (r *proxy) remoteHelper(ctx context.Context, info condatype.ArtifactInfo) (RemoteRegistryHelper, error) {
+	upstream, err := r.proxyStore.Get(ctx, info.RegistryID)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteRegistryHelper(ctx, r.spaceFinder, *upstream, r.service)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/secret"
+
+	"github.com/google/wire"
+)
+
+func LocalRegistryProvider(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	registryHelper RegistryHelper,
+) LocalRegistry {
+	registry := NewLocalRegistry(localBase, fileManager, artifactDao, registryHelper)
+	base.Register(registry)
+	return registry
+}
+
+func RegistryHelperProvider(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *asyncprocessing.Reporter,
+) RegistryHelper {
+	return NewRegistryHelper(
+		localBase,
+		fileManager,
+		postProcessingReporter,
+	)
+}
+
+func ProxyProvider(
+	proxyStore store.UpstreamProxyConfigRepository,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	registryHelper RegistryHelper,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) Proxy {
+	proxy := NewProxy(
+		proxyStore,
+		fileManager,
+		artifactDao,
+		registryHelper,
+		spaceFinder,
+		service,
+	)
+	base.Register(proxy)
+	return proxy
+}
+
+var WireSet = wire.NewSet(LocalRegistryProvider, ProxyProvider, RegistryHelperProvider)
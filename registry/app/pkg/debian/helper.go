@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
+	debianmetadata "github.com/harness/gitness/registry/app/metadata/debian"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/debian"
+	debianutil "github.com/harness/gitness/registry/app/utils/debian"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage stores a .deb file. Files uploaded with a distribution and component are published in the
+	// indices of that distribution; files without (i.e. cached from an upstream) are only stored.
+	UploadPackage(ctx context.Context, info debian.ArtifactInfo, file io.Reader) (*commons.ResponseHeaders, string, error)
+}
+
+type registryHelper struct {
+	localBase              base.LocalBase
+	fileManager            filemanager.FileManager
+	postProcessingReporter *asyncprocessing.Reporter
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *asyncprocessing.Reporter,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:              localBase,
+		fileManager:            fileManager,
+		postProcessingReporter: postProcessingReporter,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info debian.ArtifactInfo,
+	file io.Reader,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	fileInfo, err := c.fileManager.UploadFileNoDBUpdate(ctx, info.RootIdentifier, nil, file, info.RootParentID,
+		info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err := c.fileManager.DownloadFileByDigest(ctx, info.RootIdentifier, fileInfo, info.RootParentID, info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	p, err := debianutil.ParsePackage(r)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to parse debian package")
+		return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	if p.Name == debianutil.DistsPrefix {
+		return nil, "", errcode.ErrCodeInvalidRequest.WithMessage(
+			fmt.Sprintf("package name %q is reserved", p.Name))
+	}
+
+	fileVersion := debianutil.FileVersion(p.Version)
+	info.Image = p.Name
+	info.Arch = p.Architecture
+	info.Version = fileVersion + "_" + p.Architecture
+	info.Metadata = debianmetadata.Metadata{
+		VersionMetadata: *p.VersionMetadata,
+		FileMetadata:    *p.FileMetadata,
+	}
+
+	fileName := debianutil.FileName(p.Name, p.Version, p.Architecture)
+	if info.Distribution != "" {
+		fileName = fmt.Sprintf("%s/%s/%s", info.Distribution, info.Component, fileName)
+	}
+	path := fmt.Sprintf("%s/%s/%s", p.Name, versionPath(info), fileName)
+	fileInfo.Filename = fileName
+	rs, sha256, artifactID, _, err := c.localBase.UpdateFileManagerAndCreateArtifact(ctx, info.ArtifactInfo,
+		info.Version, path, &debianmetadata.DebianMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, true)
+
+	if err != nil {
+		return rs, "", err
+	}
+
+	// a version already published elsewhere gains a file for this distribution, so always rebuild the indices.
+	if info.Distribution != "" {
+		sources := make([]types.SourceRef, 0)
+		sources = append(sources, types.SourceRef{Type: types.SourceTypeArtifact, ID: artifactID})
+		c.postProcessingReporter.BuildRegistryIndex(ctx, info.RegistryID, sources)
+	}
+	return rs, sha256, err
+}
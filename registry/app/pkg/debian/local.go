@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	debianutil "github.com/harness/gitness/registry/app/utils/debian"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase            base.LocalBase
+	fileManager          filemanager.FileManager
+	tx                   dbtx.Transactor
+	registryDao          store.RegistryRepository
+	artifactDao          store.ArtifactRepository
+	registryHelper       RegistryHelper
+	debianRegistryHelper debianutil.RegistryHelper
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	artifactDao store.ArtifactRepository,
+	registryHelper RegistryHelper,
+	debianRegistryHelper debianutil.RegistryHelper,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:            localBase,
+		fileManager:          fileManager,
+		tx:                   tx,
+		registryDao:          registryDao,
+		artifactDao:          artifactDao,
+		registryHelper:       registryHelper,
+		debianRegistryHelper: debianRegistryHelper,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeDEBIAN}
+}
+
+func (c *localRegistry) UploadPackageFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+	file io.Reader,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	return c.registryHelper.UploadPackage(ctx, info, file)
+}
+
+// DownloadPackageFile serves files of the pool published by this registry, which are addressed
+// as pool/<distribution>/<component>/<file>.
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	if info.Distribution == "" || info.Component == "" {
+		return nil, nil, nil, "", fmt.Errorf("file %s not found", info.PackagePath)
+	}
+	fileName := info.Distribution + "/" + info.Component + "/" + info.FileName
+	return downloadPackageFile(ctx, info, fileName, c.localBase, c.artifactDao)
+}
+
+func (c *localRegistry) GetDistributionFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	return getDistributionFile(ctx, info, c.fileManager)
+}
+
+func (c *localRegistry) GetPublicKey(ctx context.Context, info debiantype.ArtifactInfo) (string, error) {
+	return c.debianRegistryHelper.GetPublicKey(ctx, &info.Registry)
+}
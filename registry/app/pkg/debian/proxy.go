@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	cfg "github.com/harness/gitness/registry/config"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+
+	_ "github.com/harness/gitness/registry/app/remote/adapter/debian" // This is required to init debian adapter
+)
+
+var _ pkg.Artifact = (*proxy)(nil)
+var _ Registry = (*proxy)(nil)
+
+type proxy struct {
+	proxyStore     store.UpstreamProxyConfigRepository
+	artifactDao    store.ArtifactRepository
+	localBase      base.LocalBase
+	registryHelper RegistryHelper
+	spaceFinder    refcache.SpaceFinder
+	service        secret.Service
+}
+
+type Proxy interface {
+	Registry
+}
+
+func NewProxy(
+	proxyStore store.UpstreamProxyConfigRepository,
+	artifactDao store.ArtifactRepository,
+	localBase base.LocalBase,
+	registryHelper RegistryHelper,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) Proxy {
+	return &proxy{
+		proxyStore:     proxyStore,
+		artifactDao:    artifactDao,
+		localBase:      localBase,
+		registryHelper: registryHelper,
+		spaceFinder:    spaceFinder,
+		service:        service,
+	}
+}
+
+func (r *proxy) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeUPSTREAM
+}
+
+func (r *proxy) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeDEBIAN}
+}
+
+// DownloadPackageFile serves a .deb file from the cache, or fetches it from the upstream pool and
+// caches it in the background.
+func (r *proxy) DownloadPackageFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, readcloser, redirect, err := downloadPackageFile(
+		ctx, info, info.FileName, r.localBase, r.artifactDao,
+	)
+	if err == nil {
+		return headers, fileReader, readcloser, redirect, err
+	}
+	log.Warn().Ctx(ctx).Msgf("failed to download from local, err: %v", err)
+
+	helper, err := r.remoteHelper(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	closer, err := helper.GetPackage(ctx, info.PackagePath)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	go func() {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		closer2, err2 := helper.GetPackage(ctx2, info.PackagePath)
+		if err2 != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err2).Msgf("error while putting file to localRegistry, %v", err2)
+			return
+		}
+		defer closer2.Close()
+		// cached files are not published in any distribution, the upstream indices list them.
+		info.Distribution = ""
+		info.Component = ""
+		_, _, err2 = r.registryHelper.UploadPackage(ctx2, info, closer2)
+		if err2 != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err2).Msgf("error while putting file to localRegistry, %v", err2)
+			return
+		}
+		log.Ctx(ctx2).Info().Msgf("Successfully updated file: %s, registry: %s", info.FileName, info.RegIdentifier)
+	}()
+
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, closer, "", nil
+}
+
+// GetDistributionFile proxies Release files and indices as they are, so that clients can verify them
+// with the upstream's key.
+func (r *proxy) GetDistributionFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	helper, err := r.remoteHelper(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	closer, err := helper.GetMetadataFile(ctx, info.PackagePath)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, closer, "", nil
+}
+
+func (r *proxy) GetPublicKey(ctx context.Context, _ debiantype.ArtifactInfo) (string, error) {
+	log.Ctx(ctx).Debug().Msg("upstream registries serve the indices signed by the upstream")
+	return "", errcode.ErrCodeInvalidRequest.WithDetail(
+		fmt.Errorf("upstream registries do not sign indices, use the key of the upstream repository"))
+}
+
+// UploadPackageFile is not supported for upstream registries.
+func (r *proxy) UploadPackageFile(
+	ctx context.Context,
+	_ debiantype.ArtifactInfo,
+	_ io.Reader,
+) (*commons.ResponseHeaders, string, error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
+
+func (r *proxy) remoteHelper(ctx context.Context, info debiantype.ArtifactInfo) (RemoteRegistryHelper, error) {
+	if info.PackagePath == "" {
+		log.Ctx(ctx).Error().Msgf("Package path is empty for registry %s", info.RegIdentifier)
+		return nil, errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("package path is empty"))
+	}
+	upstream, err := r.proxyStore.Get(ctx, info.RegistryID)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteRegistryHelper(ctx, r.spaceFinder, *upstream, r.service)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/debian"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	UploadPackageFile(
+		ctx context.Context,
+		info debian.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, string, error)
+
+	DownloadPackageFile(ctx context.Context, info debian.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetDistributionFile returns a file of the dists tree, i.e. a Release file or a Packages index.
+	GetDistributionFile(ctx context.Context, info debian.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetPublicKey returns the ASCII armored key the registry signs its Release files with.
+	GetPublicKey(ctx context.Context, info debian.ArtifactInfo) (string, error)
+}
+
+// downloadPackageFile serves a .deb file stored under /<name>/<version>/<architecture>/<fileName>.
+func downloadPackageFile(
+	ctx context.Context,
+	info debian.ArtifactInfo,
+	fileName string,
+	localBase base.LocalBase,
+	artifactDao store.ArtifactRepository,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	// Check artifact exists and is NOT soft-deleted (LOCAL registry check)
+	_, err := artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("Artifact not found or soft-deleted in local registry")
+		return nil, nil, nil, "", fmt.Errorf("artifact not found or deleted: %w", err)
+	}
+
+	headers, fileReader, redirectURL, err := localBase.Download(
+		ctx, info.ArtifactInfo, versionPath(info), fileName,
+	)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+func getDistributionFile(
+	ctx context.Context,
+	info debian.ArtifactInfo,
+	fileManager filemanager.FileManager,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	fileReader, _, redirectURL, err := fileManager.DownloadFileByPath(
+		ctx, "/"+info.PackagePath, info.RegistryID, info.RegIdentifier, info.RootIdentifier, true,
+	)
+	if err != nil {
+		return responseHeaders, nil, nil, "", err
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+// versionPath returns the node path segment of a package version: <version>/<architecture>.
+func versionPath(info debian.ArtifactInfo) string {
+	return strings.TrimSuffix(info.Version, "_"+info.Arch) + "/" + info.Arch
+}
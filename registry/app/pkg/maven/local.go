@@ -164,6 +164,7 @@ func (r *LocalRegistry) PutArtifact(ctx context.Context, info pkg.MavenArtifactI
 		return responseHeaders, []error{errcode.ErrCodeUnknown.WithDetail(err)}
 	}
 	fileInfo.Filename = info.FileName
+	var artifactID int64
 	var imageUUID string
 	var artifactUUID string
 	err = r.tx.WithTx(
@@ -210,7 +211,7 @@ func (r *LocalRegistry) PutArtifact(ctx context.Context, info pkg.MavenArtifactI
 				Metadata: metadataJSON,
 			}
 
-			_, err2 = r.DBStore.ArtifactDao.CreateOrUpdate(ctx, newArtifact)
+			artifactID, err2 = r.DBStore.ArtifactDao.CreateOrUpdate(ctx, newArtifact)
 			if err2 != nil {
 				return err2
 			}
@@ -234,6 +235,7 @@ func (r *LocalRegistry) PutArtifact(ctx context.Context, info pkg.MavenArtifactI
 
 		// publish artifact created event
 		r.publishArtifactCreatedEvent(ctx, info)
+		r.localBase.ScheduleScan(ctx, info.RegistryID, artifactID)
 	}
 
 	responseHeaders = &commons.ResponseHeaders{
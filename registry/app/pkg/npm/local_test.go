@@ -216,6 +216,8 @@ func (m *mockLocalBase) AuditPush(
 	}
 }
 
+func (m *mockLocalBase) ScheduleScan(context.Context, int64, int64) {}
+
 func (m *mockLocalBase) UploadRawFile(
 	context.Context,
 	pkg.ArtifactInfo,
@@ -433,6 +435,9 @@ func (m *mockArtifactDAO) Get(
 ) (*types.Artifact, error) {
 	return m.get(ctx, id)
 }
+func (m *mockArtifactDAO) ListVersionsByRegistryID(context.Context, int64) ([]types.PackageVersion, error) {
+	return nil, nil
+}
 func (m *mockArtifactDAO) DeleteByImageNameAndRegistryID(context.Context, int64, string) error {
 	return nil //nolint:nilnil
 }
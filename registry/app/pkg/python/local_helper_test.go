@@ -219,6 +219,10 @@ func (m *MockLocalBase) AuditPush(
 	m.Called(ctx, info, version, imageUUID, artifactUUID)
 }
 
+func (m *MockLocalBase) ScheduleScan(ctx context.Context, registryID int64, artifactID int64) {
+	m.Called(ctx, registryID, artifactID)
+}
+
 type MockReadCloser struct {
 	mock.Mock
 }
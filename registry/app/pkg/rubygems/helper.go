@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage parses and stores a .gem file. publish schedules a rebuild of the registry indices,
+	// gems cached from an upstream are listed by the upstream's own indices instead.
+	UploadPackage(
+		ctx context.Context,
+		info rubygems.ArtifactInfo,
+		file io.Reader,
+		publish bool,
+	) (*commons.ResponseHeaders, string, error)
+}
+
+type registryHelper struct {
+	localBase              base.LocalBase
+	fileManager            filemanager.FileManager
+	postProcessingReporter *asyncprocessing.Reporter
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *asyncprocessing.Reporter,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:              localBase,
+		fileManager:            fileManager,
+		postProcessingReporter: postProcessingReporter,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info rubygems.ArtifactInfo,
+	file io.Reader,
+	publish bool,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	fileInfo, err := c.fileManager.UploadFileNoDBUpdate(ctx, info.RootIdentifier, nil, file, info.RootParentID,
+		info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err := c.fileManager.DownloadFileByDigest(ctx, info.RootIdentifier, fileInfo, info.RootParentID, info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	m, err := rubygemsutil.ParseGem(r)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to parse gem")
+		return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	info.Image = m.Name
+	info.Version = rubygemsutil.FullVersion(m.Version, m.Platform)
+	info.Metadata = *m
+
+	fileName := rubygemsutil.FileName(m.Name, info.Version)
+	path := rubygemsutil.FilePath(m.Name, info.Version) + "/" + fileName
+	fileInfo.Filename = fileName
+	rs, sha256, artifactID, _, err := c.localBase.UpdateFileManagerAndCreateArtifact(ctx, info.ArtifactInfo,
+		info.Version, path, &rubygemsmetadata.RubyGemsMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, true)
+	if err != nil {
+		return rs, "", err
+	}
+
+	if publish {
+		sources := make([]types.SourceRef, 0)
+		sources = append(sources, types.SourceRef{Type: types.SourceTypeArtifact, ID: artifactID})
+		c.postProcessingReporter.BuildRegistryIndex(ctx, info.RegistryID, sources)
+	}
+	return rs, sha256, nil
+}
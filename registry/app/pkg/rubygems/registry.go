@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// UploadPackageFile stores a pushed .gem file and schedules a rebuild of the registry indices.
+	UploadPackageFile(
+		ctx context.Context,
+		info rubygems.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, string, error)
+
+	DownloadPackageFile(ctx context.Context, info rubygems.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetIndexFile returns a file of the compact index (versions, names, info/<gem>) or a specs file.
+	GetIndexFile(ctx context.Context, info rubygems.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
+
+// downloadPackageFile serves a .gem file stored under /gems/<name>/<version>/<fileName>.
+func downloadPackageFile(
+	ctx context.Context,
+	info rubygems.ArtifactInfo,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	// Check artifact exists and is NOT soft-deleted
+	_, err := artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("Artifact not found or soft-deleted in local registry")
+		return responseHeaders, nil, nil, "", fmt.Errorf("artifact not found or deleted: %w", err)
+	}
+	filePath := rubygemsutil.FilePath(info.Image, info.Version) + "/" + info.FileName
+	fileReader, _, redirectURL, err := fileManager.DownloadFileByPath(ctx, filePath, info.RegistryID,
+		info.RegIdentifier, info.RootIdentifier, true)
+	if err != nil {
+		return responseHeaders, nil, nil, "", fmt.Errorf("failed to download file %s: %w", filePath, err)
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
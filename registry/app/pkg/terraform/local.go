@@ -0,0 +1,403 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/utils/signing"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+const versionFilesPageSize = 1000
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+	artifactDao store.ArtifactRepository
+	keyStore    signing.KeyStore
+	urlProvider urlprovider.Provider
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	keyStore signing.KeyStore,
+	urlProvider urlprovider.Provider,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:   localBase,
+		fileManager: fileManager,
+		artifactDao: artifactDao,
+		keyStore:    keyStore,
+		urlProvider: urlProvider,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeTERRAFORM}
+}
+
+func (c *localRegistry) GetServiceDiscovery(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *terraformmetadata.ServiceDiscovery {
+	packageURL := c.packageURL(ctx, info)
+	return &terraformmetadata.ServiceDiscovery{
+		ModulesV1:   packageURL + "/v1/modules/",
+		ProvidersV1: packageURL + "/v1/providers/",
+	}
+}
+
+func (c *localRegistry) UploadModule(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file io.Reader,
+) (*commons.ResponseHeaders, string, error) {
+	fileName := terraformutil.ModuleFileName(info.Namespace, info.Name, info.System, info.Version)
+	return c.upload(ctx, info, file, fileName, terraformutil.ValidateModuleArchive)
+}
+
+func (c *localRegistry) UploadProvider(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file io.Reader,
+) (*commons.ResponseHeaders, string, error) {
+	fileName := terraformutil.ProviderFileName(info.Name, info.Version, info.OS, info.Arch)
+	headers, sha256, err := c.upload(ctx, info, file, fileName, terraformutil.ValidateProviderArchive)
+	if err != nil {
+		return headers, "", err
+	}
+	if err = c.updateShasums(ctx, info); err != nil {
+		return headers, "", err
+	}
+	return headers, sha256, nil
+}
+
+func (c *localRegistry) upload(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	file io.Reader,
+	fileName string,
+	validate func(io.Reader) error,
+) (*commons.ResponseHeaders, string, error) {
+	fileInfo, err := c.fileManager.UploadFileNoDBUpdate(ctx, info.RootIdentifier, nil, file, info.RootParentID,
+		info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	r, err := c.fileManager.DownloadFileByDigest(ctx, info.RootIdentifier, fileInfo, info.RootParentID,
+		info.RegistryID)
+	if err != nil {
+		return nil, "", err
+	}
+	err = validate(r)
+	r.Close()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("rejected terraform %s %s %s", info.Kind, info.Image, info.Version)
+		return nil, "", errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	fileInfo.Filename = fileName
+	path := terraformutil.FilePath(info.Image, info.Version) + "/" + fileName
+	// the protocols of a provider version are taken from the upload that created it.
+	headers, sha256, _, _, err := c.localBase.UpdateFileManagerAndCreateArtifact(ctx, info.ArtifactInfo,
+		info.Version, path, &terraformmetadata.TerraformMetadata{
+			Metadata: terraformmetadata.Metadata{
+				Kind:      info.Kind,
+				Namespace: info.Namespace,
+				Name:      info.Name,
+				System:    info.System,
+				Protocols: info.Protocols,
+			},
+		}, fileInfo, true)
+	if err != nil {
+		return headers, "", err
+	}
+	return headers, sha256, nil
+}
+
+// updateShasums regenerates and signs the SHA256SUMS file of a provider version from its archives.
+func (c *localRegistry) updateShasums(ctx context.Context, info terraformtype.ArtifactInfo) error {
+	versionPath := terraformutil.FilePath(info.Image, info.Version)
+	checksums, err := c.providerChecksums(ctx, info, versionPath)
+	if err != nil {
+		return err
+	}
+	content := terraformutil.BuildShasums(checksums)
+
+	privateKey, _, err := c.keyStore.GetOrCreateKey(ctx, &info.Registry)
+	if err != nil {
+		return err
+	}
+	signature, err := signing.DetachSign(privateKey, content)
+	if err != nil {
+		return err
+	}
+
+	var principalID int64
+	if session, ok := request.AuthSessionFrom(ctx); ok {
+		principalID = session.Principal.ID
+	}
+	files := map[string][]byte{
+		terraformutil.ShasumsFileName(info.Name, info.Version):          content,
+		terraformutil.ShasumsSignatureFileName(info.Name, info.Version): signature,
+	}
+	for fileName, data := range files {
+		_, err = c.fileManager.UploadFile(ctx, versionPath+"/"+fileName, info.RegistryID, info.RootParentID,
+			info.RootIdentifier, nil, bytes.NewReader(data), principalID)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", fileName, err)
+		}
+	}
+	return nil
+}
+
+func (c *localRegistry) providerChecksums(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	versionPath string,
+) (map[string]string, error) {
+	files, err := c.fileManager.GetFilesMetadata(ctx, versionPath+"/%", info.RegistryID, "name", "ASC",
+		versionFilesPageSize, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s %s: %w", info.Image, info.Version, err)
+	}
+	checksums := make(map[string]string)
+	for _, f := range *files {
+		if _, _, ok := terraformutil.ParseProviderFileName(info.Name, info.Version, f.Name); ok {
+			checksums[f.Name] = f.Sha256
+		}
+	}
+	return checksums, nil
+}
+
+func (c *localRegistry) ListModuleVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) (*terraformmetadata.ModuleVersionsResponse, error) {
+	artifacts, err := c.listArtifacts(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]terraformmetadata.ModuleVersion, 0, len(artifacts))
+	for _, a := range artifacts {
+		versions = append(versions, terraformmetadata.ModuleVersion{Version: a.Version})
+	}
+	return &terraformmetadata.ModuleVersionsResponse{
+		Modules: []terraformmetadata.ModuleVersions{{Versions: versions}},
+	}, nil
+}
+
+func (c *localRegistry) GetModuleDownloadURL(ctx context.Context, info terraformtype.ArtifactInfo) (string, error) {
+	if _, err := c.artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image,
+		info.Version); err != nil {
+		return "", fmt.Errorf("module %s version %s not found: %w", info.Image, info.Version, err)
+	}
+	return fmt.Sprintf("%s/v1/modules/%s/%s/files/%s", c.packageURL(ctx, info), info.Image, info.Version,
+		terraformutil.ModuleFileName(info.Namespace, info.Name, info.System, info.Version)), nil
+}
+
+func (c *localRegistry) ListProviderVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) (*terraformmetadata.ProviderVersionsResponse, error) {
+	artifacts, err := c.listArtifacts(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]terraformmetadata.ProviderVersion, 0, len(artifacts))
+	for _, a := range artifacts {
+		var m terraformmetadata.TerraformMetadata
+		if err = json.Unmarshal(a.Metadata, &m); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata of %s %s: %w", info.Image, a.Version, err)
+		}
+		platforms := make([]terraformmetadata.Platform, 0, len(m.Files))
+		for _, f := range m.Files {
+			if os, arch, ok := terraformutil.ParseProviderFileName(info.Name, a.Version, f.Filename); ok {
+				platforms = append(platforms, terraformmetadata.Platform{OS: os, Arch: arch})
+			}
+		}
+		protocols := m.Protocols
+		if len(protocols) == 0 {
+			protocols = terraformutil.DefaultProtocols
+		}
+		versions = append(versions, terraformmetadata.ProviderVersion{
+			Version:   a.Version,
+			Protocols: protocols,
+			Platforms: platforms,
+		})
+	}
+	return &terraformmetadata.ProviderVersionsResponse{Versions: versions}, nil
+}
+
+func (c *localRegistry) GetProviderPackage(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) (*terraformmetadata.ProviderPackage, error) {
+	a, err := c.artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s version %s not found: %w", info.Image, info.Version, err)
+	}
+	var m terraformmetadata.TerraformMetadata
+	if err = json.Unmarshal(a.Metadata, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata of %s %s: %w", info.Image, info.Version, err)
+	}
+
+	versionPath := terraformutil.FilePath(info.Image, info.Version)
+	fileName := terraformutil.ProviderFileName(info.Name, info.Version, info.OS, info.Arch)
+	shasum, _, err := c.fileManager.HeadFile(ctx, versionPath+"/"+fileName, info.RegistryID)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s version %s has no package for %s_%s: %w", info.Image, info.Version,
+			info.OS, info.Arch, err)
+	}
+	if err = c.ensureShasums(ctx, info, versionPath, fileName); err != nil {
+		return nil, err
+	}
+
+	_, publicKey, err := c.keyStore.GetOrCreateKey(ctx, &info.Registry)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := signing.KeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	protocols := m.Protocols
+	if len(protocols) == 0 {
+		protocols = terraformutil.DefaultProtocols
+	}
+	filesURL := fmt.Sprintf("%s/v1/providers/%s/%s/files/", c.packageURL(ctx, info), info.Image, info.Version)
+	return &terraformmetadata.ProviderPackage{
+		Protocols:           protocols,
+		OS:                  info.OS,
+		Arch:                info.Arch,
+		Filename:            fileName,
+		DownloadURL:         filesURL + fileName,
+		ShasumsURL:          filesURL + terraformutil.ShasumsFileName(info.Name, info.Version),
+		ShasumsSignatureURL: filesURL + terraformutil.ShasumsSignatureFileName(info.Name, info.Version),
+		Shasum:              shasum,
+		SigningKeys: terraformmetadata.SigningKeys{
+			GPGPublicKeys: []terraformmetadata.GPGPublicKey{{KeyID: keyID, ASCIIArmor: publicKey}},
+		},
+	}, nil
+}
+
+// ensureShasums regenerates the SHA256SUMS of a provider version if it does not list fileName, which happens
+// when several platforms of a version are uploaded concurrently.
+func (c *localRegistry) ensureShasums(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	versionPath string,
+	fileName string,
+) error {
+	reader, _, _, err := c.fileManager.DownloadFileByPath(ctx,
+		versionPath+"/"+terraformutil.ShasumsFileName(info.Name, info.Version), info.RegistryID,
+		info.RegIdentifier, info.RootIdentifier, false)
+	if err == nil {
+		content, readErr := io.ReadAll(reader)
+		reader.Close()
+		if readErr == nil && bytes.Contains(content, []byte("  "+fileName+"\n")) {
+			return nil
+		}
+	}
+	log.Ctx(ctx).Info().Msgf("regenerating SHA256SUMS of terraform provider %s %s", info.Image, info.Version)
+	return c.updateShasums(ctx, info)
+}
+
+func (c *localRegistry) DownloadFile(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	// Check artifact exists and is NOT soft-deleted
+	_, err := c.artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		return responseHeaders, nil, nil, "", fmt.Errorf("artifact not found or deleted: %w", err)
+	}
+	filePath := terraformutil.FilePath(info.Image, info.Version) + "/" + info.FileName
+	fileReader, _, redirectURL, err := c.fileManager.DownloadFileByPath(ctx, filePath, info.RegistryID,
+		info.RegIdentifier, info.RootIdentifier, true)
+	if err != nil {
+		return responseHeaders, nil, nil, "", fmt.Errorf("failed to download file %s: %w", filePath, err)
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+// listArtifacts returns the versions of an image, newest semantic version first.
+func (c *localRegistry) listArtifacts(ctx context.Context, info terraformtype.ArtifactInfo) ([]types.Artifact, error) {
+	artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions of %s: %w", info.Image, err)
+	}
+	if artifacts == nil || len(*artifacts) == 0 {
+		return nil, fmt.Errorf("%s %s not found: %w", info.Kind, info.Image, gitnessstore.ErrResourceNotFound)
+	}
+	result := *artifacts
+	sort.SliceStable(result, func(i, j int) bool {
+		vi, erri := semver.NewVersion(result[i].Version)
+		vj, errj := semver.NewVersion(result[j].Version)
+		if erri != nil || errj != nil {
+			return strings.Compare(result[i].Version, result[j].Version) > 0
+		}
+		return vi.GreaterThan(vj)
+	})
+	return result, nil
+}
+
+func (c *localRegistry) packageURL(ctx context.Context, info terraformtype.ArtifactInfo) string {
+	return c.urlProvider.PackageURL(ctx, info.RootIdentifier+"/"+info.RegIdentifier, "terraform")
+}
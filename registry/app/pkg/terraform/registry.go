@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"io"
+
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	GetServiceDiscovery(ctx context.Context, info terraformtype.ArtifactInfo) *terraformmetadata.ServiceDiscovery
+
+	// UploadModule stores the gzipped tarball of a module version.
+	UploadModule(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, string, error)
+
+	// UploadProvider stores the zip archive of a provider version for one platform and re-signs the
+	// SHA256SUMS of the version.
+	UploadProvider(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, string, error)
+
+	ListModuleVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) (*terraformmetadata.ModuleVersionsResponse, error)
+
+	// GetModuleDownloadURL returns the location of a module archive, which the module registry protocol
+	// hands to clients in the X-Terraform-Get header.
+	GetModuleDownloadURL(ctx context.Context, info terraformtype.ArtifactInfo) (string, error)
+
+	ListProviderVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) (*terraformmetadata.ProviderVersionsResponse, error)
+
+	GetProviderPackage(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) (*terraformmetadata.ProviderPackage, error)
+
+	// DownloadFile serves a module archive or a provider archive, SHA256SUMS or SHA256SUMS.sig file.
+	DownloadFile(ctx context.Context, info terraformtype.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
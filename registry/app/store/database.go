@@ -55,6 +55,8 @@ type BlobRepository interface {
 type CleanupPolicyRepository interface {
 	// GetIDsByRegistryID the CleanupPolicy Ids specified by Registry Key
 	GetIDsByRegistryID(ctx context.Context, id int64) (ids []int64, err error)
+	// GetRegistryIDs the Ids of all registries with at least one CleanupPolicy
+	GetRegistryIDs(ctx context.Context) (ids []int64, err error)
 	// GetByRegistryID the CleanupPolicy specified by Registry Key
 	GetByRegistryID(
 		ctx context.Context,
@@ -304,6 +306,10 @@ type TagRepository interface {
 
 	DeleteTag(ctx context.Context, registryID int64, imageName string, name string) (err error)
 
+	// ListTagVersionsByRegistryID lists all tags of all images in a registry as versions, newest first per image.
+	// The ArtifactID of the returned versions holds the ID of the tag.
+	ListTagVersionsByRegistryID(ctx context.Context, registryID int64) ([]types.PackageVersion, error)
+
 	CountAllTagsByRepoAndImage(
 		ctx context.Context, parentID int64, repoKey string,
 		image string, search string,
@@ -615,6 +621,9 @@ type ArtifactRepository interface {
 		error,
 	)
 
+	// ListVersionsByRegistryID lists all versions of all packages in a registry, newest first per package.
+	ListVersionsByRegistryID(ctx context.Context, registryID int64) ([]types.PackageVersion, error)
+
 	// Hard delete methods
 	DeleteByImageNameAndRegistryID(ctx context.Context, regID int64, image string) (err error)
 	DeleteByVersionAndImageName(ctx context.Context, image string, version string, regID int64) (err error)
@@ -916,3 +925,88 @@ type QuarantineArtifactRepository interface {
 		artifactID *int64, imageID int64, nodeID *string,
 	) error
 }
+
+type ReplicationRuleRepository interface {
+	Find(ctx context.Context, id int64) (*types.ReplicationRule, error)
+	Create(ctx context.Context, rule *types.ReplicationRule) error
+	Update(ctx context.Context, rule *types.ReplicationRule) error
+	Delete(ctx context.Context, id int64) error
+
+	// ListBySpace lists the replication rules defined in a space.
+	ListBySpace(ctx context.Context, spaceID int64) ([]*types.ReplicationRule, error)
+
+	// ListBySourceRegistry lists the replication rules replicating from a registry.
+	ListBySourceRegistry(ctx context.Context, registryID int64) ([]*types.ReplicationRule, error)
+
+	// ListAll lists all replication rules.
+	ListAll(ctx context.Context) ([]*types.ReplicationRule, error)
+}
+
+type ReplicationRunRepository interface {
+	Find(ctx context.Context, id int64) (*types.ReplicationRun, error)
+
+	// FindLatestByRule returns the most recent run of a replication rule.
+	FindLatestByRule(ctx context.Context, ruleID int64) (*types.ReplicationRun, error)
+
+	// Create creates a new run. It fails with store.ErrDuplicate if the rule already has an unfinished
+	// run that wasn't triggered by a push.
+	Create(ctx context.Context, run *types.ReplicationRun) error
+	UpdateStatus(ctx context.Context, id int64, status types.ReplicationStatus) error
+
+	// Claim marks the run as running if it's pending, or if it's running but its last heartbeat
+	// is older than staleBefore (unix millis). It returns false if the run couldn't be claimed.
+	Claim(ctx context.Context, id int64, staleBefore int64) (bool, error)
+
+	// Heartbeat updates the last updated time of a running run.
+	Heartbeat(ctx context.Context, id int64) error
+
+	CreateArtifact(ctx context.Context, artifact *types.ReplicationRunArtifact) error
+	FindArtifact(ctx context.Context, id int64) (*types.ReplicationRunArtifact, error)
+	UpdateArtifact(ctx context.Context, artifact *types.ReplicationRunArtifact) error
+
+	// UpdateArtifactsStatus moves all artifacts of a run that are in status `from` to status `to`.
+	UpdateArtifactsStatus(
+		ctx context.Context, runID int64,
+		from types.ReplicationStatus, to types.ReplicationStatus,
+	) error
+
+	ListArtifacts(
+		ctx context.Context, runID int64,
+		sortByField string, sortByOrder string,
+		limit int, offset int,
+	) ([]*types.ReplicationRunArtifact, error)
+	CountArtifacts(ctx context.Context, runID int64) (int64, error)
+
+	// CountArtifactsByStatus returns the number of artifacts of a run in the given status.
+	CountArtifactsByStatus(ctx context.Context, runID int64, status types.ReplicationStatus) (int64, error)
+}
+
+type SigningKeyRepository interface {
+	// GetByRegistryID returns the signing key of a registry.
+	GetByRegistryID(ctx context.Context, registryID int64) (*types.SigningKey, error)
+
+	// Create stores a new signing key. It fails with store.ErrDuplicate if the registry already has one.
+	Create(ctx context.Context, key *types.SigningKey) error
+}
+
+type ScanPolicyRepository interface {
+	// Find returns the scan policy of a registry, store.ErrResourceNotFound if none was configured.
+	Find(ctx context.Context, registryID int64) (*types.ScanPolicy, error)
+
+	// Upsert creates the scan policy of a registry or replaces the existing one.
+	Upsert(ctx context.Context, policy *types.ScanPolicy) error
+}
+
+type ArtifactScanRepository interface {
+	// FindByArtifact returns the latest vulnerability scan of an artifact version.
+	FindByArtifact(ctx context.Context, artifactID int64) (*types.ArtifactScan, error)
+
+	// Upsert creates the scan of an artifact version or replaces the result of the previous scan.
+	Upsert(ctx context.Context, scan *types.ArtifactScan) error
+
+	// ReplaceVulnerabilities replaces the vulnerabilities recorded for an artifact version.
+	ReplaceVulnerabilities(ctx context.Context, artifactID int64, vulnerabilities []types.Vulnerability) error
+
+	// ListVulnerabilities lists the vulnerabilities of an artifact version, most severe first.
+	ListVulnerabilities(ctx context.Context, artifactID int64) ([]types.Vulnerability, error)
+}
@@ -233,6 +233,56 @@ func (a ArtifactDao) GetByRegistryIDAndImage(
 	return &artifacts, nil
 }
 
+func (a ArtifactDao) ListVersionsByRegistryID(
+	ctx context.Context, registryID int64,
+) ([]types.PackageVersion, error) {
+	q := databaseg.Builder.Select(
+		"a.artifact_id",
+		"i.image_name",
+		"a.artifact_version",
+		"a.artifact_created_at",
+		"a.artifact_updated_at",
+	).
+		From("artifacts a").
+		Join("images i ON a.artifact_image_id = i.image_id").
+		Where("i.image_registry_id = ? AND i.image_type IS NULL", registryID).
+		Where("a.artifact_deleted_at IS NULL").
+		OrderBy("i.image_name", "a.artifact_created_at DESC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	dst := []packageVersionDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to list versions of registry %d", registryID)
+	}
+
+	versions := make([]types.PackageVersion, len(dst))
+	for i, d := range dst {
+		versions[i] = types.PackageVersion{
+			ArtifactID: d.ArtifactID,
+			Package:    d.Package,
+			Version:    d.Version,
+			CreatedAt:  time.UnixMilli(d.CreatedAt),
+			UpdatedAt:  time.UnixMilli(d.UpdatedAt),
+		}
+	}
+
+	return versions, nil
+}
+
+type packageVersionDB struct {
+	ArtifactID int64  `db:"artifact_id"`
+	Package    string `db:"image_name"`
+	Version    string `db:"artifact_version"`
+	CreatedAt  int64  `db:"artifact_created_at"`
+	UpdatedAt  int64  `db:"artifact_updated_at"`
+}
+
 func (a ArtifactDao) GetLatestByImageID(
 	ctx context.Context, imageID int64,
 ) (*types.Artifact, error) {
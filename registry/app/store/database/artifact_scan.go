@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ArtifactScanRepository = (*ArtifactScanDao)(nil)
+
+var artifactScanFields = []string{
+	"artifact_scan_id",
+	"artifact_scan_registry_id",
+	"artifact_scan_artifact_id",
+	"artifact_scan_scanner",
+	"artifact_scan_status",
+	"artifact_scan_error",
+	"artifact_scan_critical",
+	"artifact_scan_high",
+	"artifact_scan_medium",
+	"artifact_scan_low",
+	"artifact_scan_unknown",
+	"artifact_scan_quarantined",
+	"artifact_scan_created",
+	"artifact_scan_updated",
+}
+
+var artifactVulnerabilityFields = []string{
+	"artifact_vulnerability_id",
+	"artifact_vulnerability_artifact_id",
+	"artifact_vulnerability_vulnerability_id",
+	"artifact_vulnerability_package_name",
+	"artifact_vulnerability_installed_version",
+	"artifact_vulnerability_fixed_version",
+	"artifact_vulnerability_severity",
+	"artifact_vulnerability_title",
+	"artifact_vulnerability_target",
+}
+
+func NewArtifactScanDao(db *sqlx.DB) store.ArtifactScanRepository {
+	return &ArtifactScanDao{
+		db: db,
+	}
+}
+
+type ArtifactScanDao struct {
+	db *sqlx.DB
+}
+
+type artifactScanDB struct {
+	ID          int64  `db:"artifact_scan_id"`
+	RegistryID  int64  `db:"artifact_scan_registry_id"`
+	ArtifactID  int64  `db:"artifact_scan_artifact_id"`
+	Scanner     string `db:"artifact_scan_scanner"`
+	Status      string `db:"artifact_scan_status"`
+	Error       string `db:"artifact_scan_error"`
+	Critical    int64  `db:"artifact_scan_critical"`
+	High        int64  `db:"artifact_scan_high"`
+	Medium      int64  `db:"artifact_scan_medium"`
+	Low         int64  `db:"artifact_scan_low"`
+	Unknown     int64  `db:"artifact_scan_unknown"`
+	Quarantined bool   `db:"artifact_scan_quarantined"`
+	Created     int64  `db:"artifact_scan_created"`
+	Updated     int64  `db:"artifact_scan_updated"`
+}
+
+type artifactVulnerabilityDB struct {
+	ID               int64  `db:"artifact_vulnerability_id"`
+	ArtifactID       int64  `db:"artifact_vulnerability_artifact_id"`
+	VulnerabilityID  string `db:"artifact_vulnerability_vulnerability_id"`
+	PackageName      string `db:"artifact_vulnerability_package_name"`
+	InstalledVersion string `db:"artifact_vulnerability_installed_version"`
+	FixedVersion     string `db:"artifact_vulnerability_fixed_version"`
+	Severity         string `db:"artifact_vulnerability_severity"`
+	Title            string `db:"artifact_vulnerability_title"`
+	Target           string `db:"artifact_vulnerability_target"`
+}
+
+func (a ArtifactScanDao) FindByArtifact(ctx context.Context, artifactID int64) (*types.ArtifactScan, error) {
+	stmt := database.Builder.Select(artifactScanFields...).
+		From("artifact_scans").
+		Where("artifact_scan_artifact_id = ?", artifactID)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	dst := new(artifactScanDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find artifact scan")
+	}
+
+	return mapToArtifactScan(dst), nil
+}
+
+func (a ArtifactScanDao) Upsert(ctx context.Context, scan *types.ArtifactScan) error {
+	const sqlQuery = `
+		INSERT INTO artifact_scans (
+			artifact_scan_registry_id
+			,artifact_scan_artifact_id
+			,artifact_scan_scanner
+			,artifact_scan_status
+			,artifact_scan_error
+			,artifact_scan_critical
+			,artifact_scan_high
+			,artifact_scan_medium
+			,artifact_scan_low
+			,artifact_scan_unknown
+			,artifact_scan_quarantined
+			,artifact_scan_created
+			,artifact_scan_updated
+		) values (
+			:artifact_scan_registry_id
+			,:artifact_scan_artifact_id
+			,:artifact_scan_scanner
+			,:artifact_scan_status
+			,:artifact_scan_error
+			,:artifact_scan_critical
+			,:artifact_scan_high
+			,:artifact_scan_medium
+			,:artifact_scan_low
+			,:artifact_scan_unknown
+			,:artifact_scan_quarantined
+			,:artifact_scan_created
+			,:artifact_scan_updated
+		)
+		ON CONFLICT (artifact_scan_artifact_id)
+		DO UPDATE SET
+			artifact_scan_scanner = :artifact_scan_scanner
+			,artifact_scan_status = :artifact_scan_status
+			,artifact_scan_error = :artifact_scan_error
+			,artifact_scan_critical = :artifact_scan_critical
+			,artifact_scan_high = :artifact_scan_high
+			,artifact_scan_medium = :artifact_scan_medium
+			,artifact_scan_low = :artifact_scan_low
+			,artifact_scan_unknown = :artifact_scan_unknown
+			,artifact_scan_quarantined = :artifact_scan_quarantined
+			,artifact_scan_updated = :artifact_scan_updated
+		RETURNING artifact_scan_id`
+
+	now := time.Now()
+	if scan.CreatedAt.IsZero() {
+		scan.CreatedAt = now
+	}
+	scan.UpdatedAt = now
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToArtifactScanDB(scan))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind artifact scan object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&scan.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func (a ArtifactScanDao) ReplaceVulnerabilities(
+	ctx context.Context,
+	artifactID int64,
+	vulnerabilities []types.Vulnerability,
+) error {
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	deleteQuery, args, err := database.Builder.Delete("artifact_vulnerabilities").
+		Where("artifact_vulnerability_artifact_id = ?", artifactID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete vulnerabilities query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, deleteQuery, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the delete vulnerabilities query failed")
+	}
+
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.Insert("artifact_vulnerabilities").
+		Columns(artifactVulnerabilityFields[1:]...)
+	for _, v := range vulnerabilities {
+		stmt = stmt.Values(
+			artifactID,
+			v.VulnerabilityID,
+			v.PackageName,
+			v.InstalledVersion,
+			v.FixedVersion,
+			string(v.Severity),
+			v.Title,
+			v.Target,
+		)
+	}
+
+	insertQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert insert vulnerabilities query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, insertQuery, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the insert vulnerabilities query failed")
+	}
+
+	return nil
+}
+
+func (a ArtifactScanDao) ListVulnerabilities(ctx context.Context, artifactID int64) ([]types.Vulnerability, error) {
+	stmt := database.Builder.Select(artifactVulnerabilityFields...).
+		From("artifact_vulnerabilities").
+		Where("artifact_vulnerability_artifact_id = ?", artifactID).
+		OrderBy("artifact_vulnerability_id")
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	dst := []*artifactVulnerabilityDB{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list artifact vulnerabilities")
+	}
+
+	vulnerabilities := make([]types.Vulnerability, 0, len(dst))
+	for _, d := range dst {
+		vulnerabilities = append(vulnerabilities, types.Vulnerability{
+			ID:               d.ID,
+			ArtifactID:       d.ArtifactID,
+			VulnerabilityID:  d.VulnerabilityID,
+			PackageName:      d.PackageName,
+			InstalledVersion: d.InstalledVersion,
+			FixedVersion:     d.FixedVersion,
+			Severity:         types.VulnerabilitySeverity(d.Severity),
+			Title:            d.Title,
+			Target:           d.Target,
+		})
+	}
+	sort.SliceStable(vulnerabilities, func(i, j int) bool {
+		return vulnerabilities[i].Severity != vulnerabilities[j].Severity &&
+			vulnerabilities[i].Severity.AtLeast(vulnerabilities[j].Severity)
+	})
+	return vulnerabilities, nil
+}
+
+func mapToArtifactScanDB(scan *types.ArtifactScan) *artifactScanDB {
+	return &artifactScanDB{
+		ID:          scan.ID,
+		RegistryID:  scan.RegistryID,
+		ArtifactID:  scan.ArtifactID,
+		Scanner:     scan.Scanner,
+		Status:      string(scan.Status),
+		Error:       scan.Error,
+		Critical:    scan.Critical,
+		High:        scan.High,
+		Medium:      scan.Medium,
+		Low:         scan.Low,
+		Unknown:     scan.Unknown,
+		Quarantined: scan.Quarantined,
+		Created:     scan.CreatedAt.UnixMilli(),
+		Updated:     scan.UpdatedAt.UnixMilli(),
+	}
+}
+
+func mapToArtifactScan(dst *artifactScanDB) *types.ArtifactScan {
+	return &types.ArtifactScan{
+		ID:          dst.ID,
+		RegistryID:  dst.RegistryID,
+		ArtifactID:  dst.ArtifactID,
+		Scanner:     dst.Scanner,
+		Status:      types.ScanStatus(dst.Status),
+		Error:       dst.Error,
+		Critical:    dst.Critical,
+		High:        dst.High,
+		Medium:      dst.Medium,
+		Low:         dst.Low,
+		Unknown:     dst.Unknown,
+		Quarantined: dst.Quarantined,
+		CreatedAt:   time.UnixMilli(dst.Created),
+		UpdatedAt:   time.UnixMilli(dst.Updated),
+	}
+}
@@ -42,6 +42,7 @@ type CleanupPolicyDB struct {
 	RegistryID     int64  `db:"cp_registry_id"`
 	Name           string `db:"cp_name"`
 	ExpiryTimeInMs int64  `db:"cp_expiry_time_ms"`
+	KeepLast       int    `db:"cp_keep_last"`
 	CreatedAt      int64  `db:"cp_created_at"`
 	UpdatedAt      int64  `db:"cp_updated_at"`
 	CreatedBy      int64  `db:"cp_created_by"`
@@ -88,6 +89,24 @@ func (c CleanupPolicyDao) GetIDsByRegistryID(ctx context.Context, id int64) (ids
 	return res, nil
 }
 
+func (c CleanupPolicyDao) GetRegistryIDs(ctx context.Context) (ids []int64, err error) {
+	stmt := databaseg.Builder.Select("DISTINCT cp_registry_id").From("cleanup_policies").
+		OrderBy("cp_registry_id")
+	db := dbtx.GetAccessor(ctx, c.db)
+	var res []int64
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.SelectContext(ctx, &res, query, args...); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to get registry ids with cleanup policies")
+		}
+	}
+
+	return res, nil
+}
+
 func (c CleanupPolicyDao) GetByRegistryID(
 	ctx context.Context,
 	id int64,
@@ -97,17 +116,19 @@ func (c CleanupPolicyDao) GetByRegistryID(
 		"cp_registry_id",
 		"cp_name",
 		"cp_expiry_time_ms",
+		"cp_keep_last",
 		"cp_created_at",
 		"cp_updated_at",
 		"cp_created_by",
 		"cp_updated_by",
-		"cpp_id",
-		"cpp_cleanup_policy_id",
-		"cpp_prefix",
-		"cpp_prefix_type",
+		"COALESCE(cpp_id, 0) AS cpp_id",
+		"COALESCE(cpp_cleanup_policy_id, 0) AS cpp_cleanup_policy_id",
+		"COALESCE(cpp_prefix, '') AS cpp_prefix",
+		"COALESCE(cpp_prefix_type, '') AS cpp_prefix_type",
 	).
 		From("cleanup_policies").
-		Join("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
+		// policies without prefixes or patterns don't have any mappings.
+		LeftJoin("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
 		Where("cp_registry_id = ?", id)
 
 	db := dbtx.GetAccessor(ctx, c.db)
@@ -142,6 +163,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			cp_registry_id
 			,cp_name
 			,cp_expiry_time_ms
+			,cp_keep_last
 			,cp_created_at
 			,cp_updated_at
 			,cp_created_by
@@ -150,6 +172,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			:cp_registry_id
 			,:cp_name
 			,:cp_expiry_time_ms
+			,:cp_keep_last
 			,:cp_created_at
 			,:cp_updated_at
 			,:cp_created_by
@@ -320,6 +343,24 @@ func (c CleanupPolicyDao) mapToInternalCleanupPolicyMapping(
 			)
 		}
 	}
+	for _, pattern := range cp.VersionPattern {
+		result = append(
+			result, CleanupPolicyPrefixMappingDB{
+				CleanupPolicyID: cp.ID,
+				Prefix:          pattern,
+				PrefixType:      enum.PrefixTypeVersionPattern,
+			},
+		)
+	}
+	for _, pattern := range cp.ExcludePattern {
+		result = append(
+			result, CleanupPolicyPrefixMappingDB{
+				CleanupPolicyID: cp.ID,
+				Prefix:          pattern,
+				PrefixType:      enum.PrefixTypeExcludePattern,
+			},
+		)
+	}
 	return &result
 }
 
@@ -344,6 +385,7 @@ func (c CleanupPolicyDao) mapToInternalCleanupPolicy(
 		RegistryID:     cp.RegistryID,
 		Name:           cp.Name,
 		ExpiryTimeInMs: cp.ExpiryTime,
+		KeepLast:       cp.KeepLast,
 		CreatedAt:      cp.CreatedAt.UnixMilli(),
 		UpdatedAt:      cp.UpdatedAt.UnixMilli(),
 		CreatedBy:      cp.CreatedBy,
@@ -366,23 +408,32 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 
 		if _, exists := cleanupPolicies[cp.ID]; !exists {
 			cleanupPolicies[cp.ID] = &types.CleanupPolicy{
-				ID:            cp.ID,
-				RegistryID:    cp.RegistryID,
-				Name:          cp.Name,
-				ExpiryTime:    cp.ExpiryTimeInMs,
-				CreatedAt:     time.UnixMilli(cp.CreatedAt),
-				UpdatedAt:     time.UnixMilli(cp.UpdatedAt),
-				PackagePrefix: make([]string, 0),
-				VersionPrefix: make([]string, 0),
+				ID:             cp.ID,
+				RegistryID:     cp.RegistryID,
+				Name:           cp.Name,
+				ExpiryTime:     cp.ExpiryTimeInMs,
+				KeepLast:       cp.KeepLast,
+				CreatedAt:      time.UnixMilli(cp.CreatedAt),
+				UpdatedAt:      time.UnixMilli(cp.UpdatedAt),
+				CreatedBy:      cp.CreatedBy,
+				UpdatedBy:      cp.UpdatedBy,
+				PackagePrefix:  make([]string, 0),
+				VersionPrefix:  make([]string, 0),
+				VersionPattern: make([]string, 0),
+				ExcludePattern: make([]string, 0),
 			}
 		}
 
-		if cp.PrefixType == enum.PrefixTypePackage {
-			cleanupPolicies[cp.ID].PackagePrefix = append(cleanupPolicies[cp.ID].PackagePrefix, cp.Prefix)
-		}
-
-		if cp.PrefixType == enum.PrefixTypeVersion {
-			cleanupPolicies[cp.ID].VersionPrefix = append(cleanupPolicies[cp.ID].VersionPrefix, cp.Prefix)
+		policy := cleanupPolicies[cp.ID]
+		switch cp.PrefixType {
+		case enum.PrefixTypePackage:
+			policy.PackagePrefix = append(policy.PackagePrefix, cp.Prefix)
+		case enum.PrefixTypeVersion:
+			policy.VersionPrefix = append(policy.VersionPrefix, cp.Prefix)
+		case enum.PrefixTypeVersionPattern:
+			policy.VersionPattern = append(policy.VersionPattern, cp.Prefix)
+		case enum.PrefixTypeExcludePattern:
+			policy.ExcludePattern = append(policy.ExcludePattern, cp.Prefix)
 		}
 	}
 	var result []types.CleanupPolicy
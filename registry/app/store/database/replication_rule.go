@@ -0,0 +1,312 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ReplicationRuleRepository = (*ReplicationRuleDao)(nil)
+
+var replicationRuleFields = []string{
+	"registry_replication_rule_id",
+	"registry_replication_rule_space_id",
+	"registry_replication_rule_source_registry_id",
+	"registry_replication_rule_destination_type",
+	"registry_replication_rule_destination_registry_id",
+	"registry_replication_rule_destination_config",
+	"registry_replication_rule_allowed_patterns",
+	"registry_replication_rule_blocked_patterns",
+	"registry_replication_rule_created_by",
+	"registry_replication_rule_created",
+	"registry_replication_rule_updated",
+}
+
+func NewReplicationRuleDao(db *sqlx.DB) store.ReplicationRuleRepository {
+	return &ReplicationRuleDao{
+		db: db,
+	}
+}
+
+type ReplicationRuleDao struct {
+	db *sqlx.DB
+}
+
+type replicationRuleDB struct {
+	ID                    int64    `db:"registry_replication_rule_id"`
+	SpaceID               int64    `db:"registry_replication_rule_space_id"`
+	SourceRegistryID      int64    `db:"registry_replication_rule_source_registry_id"`
+	DestinationType       string   `db:"registry_replication_rule_destination_type"`
+	DestinationRegistryID null.Int `db:"registry_replication_rule_destination_registry_id"`
+	DestinationConfig     string   `db:"registry_replication_rule_destination_config"`
+	AllowedPatterns       string   `db:"registry_replication_rule_allowed_patterns"`
+	BlockedPatterns       string   `db:"registry_replication_rule_blocked_patterns"`
+	CreatedBy             int64    `db:"registry_replication_rule_created_by"`
+	Created               int64    `db:"registry_replication_rule_created"`
+	Updated               int64    `db:"registry_replication_rule_updated"`
+}
+
+func (r ReplicationRuleDao) Find(ctx context.Context, id int64) (*types.ReplicationRule, error) {
+	stmt := database.Builder.Select(replicationRuleFields...).
+		From("registry_replication_rules").
+		Where("registry_replication_rule_id = ?", id)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := new(replicationRuleDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find replication rule")
+	}
+
+	return mapToReplicationRule(dst)
+}
+
+func (r ReplicationRuleDao) Create(ctx context.Context, rule *types.ReplicationRule) error {
+	const sqlQuery = `
+		INSERT INTO registry_replication_rules (
+			registry_replication_rule_space_id
+			,registry_replication_rule_source_registry_id
+			,registry_replication_rule_destination_type
+			,registry_replication_rule_destination_registry_id
+			,registry_replication_rule_destination_config
+			,registry_replication_rule_allowed_patterns
+			,registry_replication_rule_blocked_patterns
+			,registry_replication_rule_created_by
+			,registry_replication_rule_created
+			,registry_replication_rule_updated
+		) values (
+			:registry_replication_rule_space_id
+			,:registry_replication_rule_source_registry_id
+			,:registry_replication_rule_destination_type
+			,:registry_replication_rule_destination_registry_id
+			,:registry_replication_rule_destination_config
+			,:registry_replication_rule_allowed_patterns
+			,:registry_replication_rule_blocked_patterns
+			,:registry_replication_rule_created_by
+			,:registry_replication_rule_created
+			,:registry_replication_rule_updated
+		) RETURNING registry_replication_rule_id`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	dbRule, err := mapToReplicationRuleDB(rule)
+	if err != nil {
+		return fmt.Errorf("failed to map replication rule to internal db type: %w", err)
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRule)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication rule object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&rule.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) Update(ctx context.Context, rule *types.ReplicationRule) error {
+	const sqlQuery = `
+		UPDATE registry_replication_rules SET
+			registry_replication_rule_source_registry_id = :registry_replication_rule_source_registry_id
+			,registry_replication_rule_destination_type = :registry_replication_rule_destination_type
+			,registry_replication_rule_destination_registry_id = :registry_replication_rule_destination_registry_id
+			,registry_replication_rule_destination_config = :registry_replication_rule_destination_config
+			,registry_replication_rule_allowed_patterns = :registry_replication_rule_allowed_patterns
+			,registry_replication_rule_blocked_patterns = :registry_replication_rule_blocked_patterns
+			,registry_replication_rule_updated = :registry_replication_rule_updated
+		WHERE registry_replication_rule_id = :registry_replication_rule_id`
+
+	rule.UpdatedAt = time.Now()
+
+	dbRule, err := mapToReplicationRuleDB(rule)
+	if err != nil {
+		return fmt.Errorf("failed to map replication rule to internal db type: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRule)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication rule object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update replication rule")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitnessstore.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) Delete(ctx context.Context, id int64) error {
+	stmt := database.Builder.Delete("registry_replication_rules").
+		Where("registry_replication_rule_id = ?", id)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete replication rule query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "the delete replication rule query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) ListBySpace(ctx context.Context, spaceID int64) ([]*types.ReplicationRule, error) {
+	return r.list(ctx, squirrel.Eq{"registry_replication_rule_space_id": spaceID})
+}
+
+func (r ReplicationRuleDao) ListBySourceRegistry(
+	ctx context.Context,
+	registryID int64,
+) ([]*types.ReplicationRule, error) {
+	return r.list(ctx, squirrel.Eq{"registry_replication_rule_source_registry_id": registryID})
+}
+
+func (r ReplicationRuleDao) ListAll(ctx context.Context) ([]*types.ReplicationRule, error) {
+	return r.list(ctx, nil)
+}
+
+func (r ReplicationRuleDao) list(ctx context.Context, pred squirrel.Sqlizer) ([]*types.ReplicationRule, error) {
+	stmt := database.Builder.Select(replicationRuleFields...).
+		From("registry_replication_rules").
+		OrderBy("registry_replication_rule_id")
+	if pred != nil {
+		stmt = stmt.Where(pred)
+	}
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := []*replicationRuleDB{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list replication rules")
+	}
+
+	rules := make([]*types.ReplicationRule, 0, len(dst))
+	for _, d := range dst {
+		rule, err := mapToReplicationRule(d)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func mapToReplicationRuleDB(rule *types.ReplicationRule) (*replicationRuleDB, error) {
+	destinationConfig, err := json.Marshal(rule.DestinationConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal destination config: %w", err)
+	}
+	allowedPatterns, err := marshalPatterns(rule.AllowedPatterns)
+	if err != nil {
+		return nil, err
+	}
+	blockedPatterns, err := marshalPatterns(rule.BlockedPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicationRuleDB{
+		ID:                    rule.ID,
+		SpaceID:               rule.SpaceID,
+		SourceRegistryID:      rule.SourceRegistryID,
+		DestinationType:       string(rule.DestinationType),
+		DestinationRegistryID: null.IntFromPtr(rule.DestinationRegistryID),
+		DestinationConfig:     string(destinationConfig),
+		AllowedPatterns:       allowedPatterns,
+		BlockedPatterns:       blockedPatterns,
+		CreatedBy:             rule.CreatedBy,
+		Created:               rule.CreatedAt.UnixMilli(),
+		Updated:               rule.UpdatedAt.UnixMilli(),
+	}, nil
+}
+
+func mapToReplicationRule(dst *replicationRuleDB) (*types.ReplicationRule, error) {
+	rule := &types.ReplicationRule{
+		ID:                    dst.ID,
+		SpaceID:               dst.SpaceID,
+		SourceRegistryID:      dst.SourceRegistryID,
+		DestinationType:       types.ReplicationDestinationType(dst.DestinationType),
+		DestinationRegistryID: dst.DestinationRegistryID.Ptr(),
+		CreatedBy:             dst.CreatedBy,
+		CreatedAt:             time.UnixMilli(dst.Created),
+		UpdatedAt:             time.UnixMilli(dst.Updated),
+	}
+	if err := json.Unmarshal([]byte(dst.DestinationConfig), &rule.DestinationConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal destination config: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dst.AllowedPatterns), &rule.AllowedPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal allowed patterns: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dst.BlockedPatterns), &rule.BlockedPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blocked patterns: %w", err)
+	}
+	return rule, nil
+}
+
+func marshalPatterns(patterns []string) (string, error) {
+	if patterns == nil {
+		patterns = []string{}
+	}
+	b, err := json.Marshal(patterns)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal patterns: %w", err)
+	}
+	return string(b), nil
+}
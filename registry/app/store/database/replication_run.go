@@ -0,0 +1,465 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/store/database/util"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ReplicationRunRepository = (*ReplicationRunDao)(nil)
+
+var replicationRunFields = []string{
+	"registry_replication_run_id",
+	"registry_replication_run_rule_id",
+	"registry_replication_run_trigger",
+	"registry_replication_run_status",
+	"registry_replication_run_created",
+	"registry_replication_run_updated",
+}
+
+var replicationRunArtifactFields = []string{
+	"registry_replication_run_artifact_id",
+	"registry_replication_run_artifact_run_id",
+	"registry_replication_run_artifact_image",
+	"registry_replication_run_artifact_tag",
+	"registry_replication_run_artifact_status",
+	"registry_replication_run_artifact_log",
+	"registry_replication_run_artifact_created",
+	"registry_replication_run_artifact_updated",
+}
+
+var replicationRunArtifactSortMap = map[string]string{
+	"id":     "registry_replication_run_artifact_id",
+	"image":  "registry_replication_run_artifact_image",
+	"status": "registry_replication_run_artifact_status",
+}
+
+func NewReplicationRunDao(db *sqlx.DB) store.ReplicationRunRepository {
+	return &ReplicationRunDao{
+		db: db,
+	}
+}
+
+type ReplicationRunDao struct {
+	db *sqlx.DB
+}
+
+type replicationRunDB struct {
+	ID      int64  `db:"registry_replication_run_id"`
+	RuleID  int64  `db:"registry_replication_run_rule_id"`
+	Trigger string `db:"registry_replication_run_trigger"`
+	Status  string `db:"registry_replication_run_status"`
+	Created int64  `db:"registry_replication_run_created"`
+	Updated int64  `db:"registry_replication_run_updated"`
+}
+
+type replicationRunArtifactDB struct {
+	ID      int64  `db:"registry_replication_run_artifact_id"`
+	RunID   int64  `db:"registry_replication_run_artifact_run_id"`
+	Image   string `db:"registry_replication_run_artifact_image"`
+	Tag     string `db:"registry_replication_run_artifact_tag"`
+	Status  string `db:"registry_replication_run_artifact_status"`
+	Log     string `db:"registry_replication_run_artifact_log"`
+	Created int64  `db:"registry_replication_run_artifact_created"`
+	Updated int64  `db:"registry_replication_run_artifact_updated"`
+}
+
+func (r ReplicationRunDao) Find(ctx context.Context, id int64) (*types.ReplicationRun, error) {
+	stmt := database.Builder.Select(replicationRunFields...).
+		From("registry_replication_runs").
+		Where("registry_replication_run_id = ?", id)
+
+	return r.get(ctx, stmt.ToSql)
+}
+
+func (r ReplicationRunDao) FindLatestByRule(ctx context.Context, ruleID int64) (*types.ReplicationRun, error) {
+	stmt := database.Builder.Select(replicationRunFields...).
+		From("registry_replication_runs").
+		Where("registry_replication_run_rule_id = ?", ruleID).
+		OrderBy("registry_replication_run_id DESC").
+		Limit(1)
+
+	return r.get(ctx, stmt.ToSql)
+}
+
+func (r ReplicationRunDao) get(
+	ctx context.Context,
+	toSQL func() (string, []any, error),
+) (*types.ReplicationRun, error) {
+	sqlQuery, args, err := toSQL()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := new(replicationRunDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find replication run")
+	}
+
+	return mapToReplicationRun(dst), nil
+}
+
+func (r ReplicationRunDao) Create(ctx context.Context, run *types.ReplicationRun) error {
+	const sqlQuery = `
+		INSERT INTO registry_replication_runs (
+			registry_replication_run_rule_id
+			,registry_replication_run_trigger
+			,registry_replication_run_status
+			,registry_replication_run_created
+			,registry_replication_run_updated
+		) values (
+			:registry_replication_run_rule_id
+			,:registry_replication_run_trigger
+			,:registry_replication_run_status
+			,:registry_replication_run_created
+			,:registry_replication_run_updated
+		) RETURNING registry_replication_run_id`
+
+	now := time.Now()
+	run.CreatedAt = now
+	run.UpdatedAt = now
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToReplicationRunDB(run))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication run object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&run.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) UpdateStatus(ctx context.Context, id int64, status types.ReplicationStatus) error {
+	stmt := database.Builder.Update("registry_replication_runs").
+		Set("registry_replication_run_status", string(status)).
+		Set("registry_replication_run_updated", time.Now().UnixMilli()).
+		Where("registry_replication_run_id = ?", id)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update replication run status")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) Claim(ctx context.Context, id int64, staleBefore int64) (bool, error) {
+	stmt := database.Builder.Update("registry_replication_runs").
+		Set("registry_replication_run_status", string(types.ReplicationStatusRunning)).
+		Set("registry_replication_run_updated", time.Now().UnixMilli()).
+		Where("registry_replication_run_id = ?", id).
+		Where(sq.Or{
+			sq.Eq{"registry_replication_run_status": string(types.ReplicationStatusPending)},
+			sq.And{
+				sq.Eq{"registry_replication_run_status": string(types.ReplicationStatusRunning)},
+				sq.Lt{"registry_replication_run_updated": staleBefore},
+			},
+		})
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to claim replication run")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of claimed replication runs")
+	}
+
+	return count > 0, nil
+}
+
+func (r ReplicationRunDao) Heartbeat(ctx context.Context, id int64) error {
+	stmt := database.Builder.Update("registry_replication_runs").
+		Set("registry_replication_run_updated", time.Now().UnixMilli()).
+		Where("registry_replication_run_id = ?", id).
+		Where("registry_replication_run_status = ?", string(types.ReplicationStatusRunning))
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update replication run heartbeat")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) CreateArtifact(ctx context.Context, artifact *types.ReplicationRunArtifact) error {
+	const sqlQuery = `
+		INSERT INTO registry_replication_run_artifacts (
+			registry_replication_run_artifact_run_id
+			,registry_replication_run_artifact_image
+			,registry_replication_run_artifact_tag
+			,registry_replication_run_artifact_status
+			,registry_replication_run_artifact_log
+			,registry_replication_run_artifact_created
+			,registry_replication_run_artifact_updated
+		) values (
+			:registry_replication_run_artifact_run_id
+			,:registry_replication_run_artifact_image
+			,:registry_replication_run_artifact_tag
+			,:registry_replication_run_artifact_status
+			,:registry_replication_run_artifact_log
+			,:registry_replication_run_artifact_created
+			,:registry_replication_run_artifact_updated
+		) RETURNING registry_replication_run_artifact_id`
+
+	now := time.Now()
+	artifact.CreatedAt = now
+	artifact.UpdatedAt = now
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToReplicationRunArtifactDB(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind replication run artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) FindArtifact(ctx context.Context, id int64) (*types.ReplicationRunArtifact, error) {
+	stmt := database.Builder.Select(replicationRunArtifactFields...).
+		From("registry_replication_run_artifacts").
+		Where("registry_replication_run_artifact_id = ?", id)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := new(replicationRunArtifactDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find replication run artifact")
+	}
+
+	return mapToReplicationRunArtifact(dst), nil
+}
+
+func (r ReplicationRunDao) UpdateArtifact(ctx context.Context, artifact *types.ReplicationRunArtifact) error {
+	artifact.UpdatedAt = time.Now()
+
+	stmt := database.Builder.Update("registry_replication_run_artifacts").
+		Set("registry_replication_run_artifact_status", string(artifact.Status)).
+		Set("registry_replication_run_artifact_log", artifact.Log).
+		Set("registry_replication_run_artifact_updated", artifact.UpdatedAt.UnixMilli()).
+		Where("registry_replication_run_artifact_id = ?", artifact.ID)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update replication run artifact")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) UpdateArtifactsStatus(
+	ctx context.Context,
+	runID int64,
+	from types.ReplicationStatus,
+	to types.ReplicationStatus,
+) error {
+	stmt := database.Builder.Update("registry_replication_run_artifacts").
+		Set("registry_replication_run_artifact_status", string(to)).
+		Set("registry_replication_run_artifact_updated", time.Now().UnixMilli()).
+		Where("registry_replication_run_artifact_run_id = ?", runID).
+		Where("registry_replication_run_artifact_status = ?", string(from))
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update replication run artifacts status")
+	}
+
+	return nil
+}
+
+func (r ReplicationRunDao) ListArtifacts(
+	ctx context.Context,
+	runID int64,
+	sortByField string,
+	sortByOrder string,
+	limit int,
+	offset int,
+) ([]*types.ReplicationRunArtifact, error) {
+	sortField, ok := replicationRunArtifactSortMap[sortByField]
+	if !ok {
+		sortField = replicationRunArtifactSortMap["id"]
+	}
+	if sortByOrder != "DESC" {
+		sortByOrder = "ASC"
+	}
+
+	stmt := database.Builder.Select(replicationRunArtifactFields...).
+		From("registry_replication_run_artifacts").
+		Where("registry_replication_run_artifact_run_id = ?", runID).
+		OrderBy(sortField + " " + sortByOrder).
+		Limit(util.SafeIntToUInt64(limit)).
+		Offset(util.SafeIntToUInt64(offset))
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := []*replicationRunArtifactDB{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list replication run artifacts")
+	}
+
+	artifacts := make([]*types.ReplicationRunArtifact, len(dst))
+	for i, d := range dst {
+		artifacts[i] = mapToReplicationRunArtifact(d)
+	}
+	return artifacts, nil
+}
+
+func (r ReplicationRunDao) CountArtifacts(ctx context.Context, runID int64) (int64, error) {
+	stmt := database.Builder.Select("COUNT(*)").
+		From("registry_replication_run_artifacts").
+		Where("registry_replication_run_artifact_run_id = ?", runID)
+
+	return r.count(ctx, stmt.ToSql)
+}
+
+func (r ReplicationRunDao) CountArtifactsByStatus(
+	ctx context.Context,
+	runID int64,
+	status types.ReplicationStatus,
+) (int64, error) {
+	stmt := database.Builder.Select("COUNT(*)").
+		From("registry_replication_run_artifacts").
+		Where("registry_replication_run_artifact_run_id = ?", runID).
+		Where("registry_replication_run_artifact_status = ?", string(status))
+
+	return r.count(ctx, stmt.ToSql)
+}
+
+func (r ReplicationRunDao) count(ctx context.Context, toSQL func() (string, []any, error)) (int64, error) {
+	sqlQuery, args, err := toSQL()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count replication run artifacts")
+	}
+	return count, nil
+}
+
+func mapToReplicationRunDB(run *types.ReplicationRun) *replicationRunDB {
+	return &replicationRunDB{
+		ID:      run.ID,
+		RuleID:  run.RuleID,
+		Trigger: string(run.Trigger),
+		Status:  string(run.Status),
+		Created: run.CreatedAt.UnixMilli(),
+		Updated: run.UpdatedAt.UnixMilli(),
+	}
+}
+
+func mapToReplicationRun(dst *replicationRunDB) *types.ReplicationRun {
+	return &types.ReplicationRun{
+		ID:        dst.ID,
+		RuleID:    dst.RuleID,
+		Trigger:   types.ReplicationTrigger(dst.Trigger),
+		Status:    types.ReplicationStatus(dst.Status),
+		CreatedAt: time.UnixMilli(dst.Created),
+		UpdatedAt: time.UnixMilli(dst.Updated),
+	}
+}
+
+func mapToReplicationRunArtifactDB(artifact *types.ReplicationRunArtifact) *replicationRunArtifactDB {
+	return &replicationRunArtifactDB{
+		ID:      artifact.ID,
+		RunID:   artifact.RunID,
+		Image:   artifact.Image,
+		Tag:     artifact.Tag,
+		Status:  string(artifact.Status),
+		Log:     artifact.Log,
+		Created: artifact.CreatedAt.UnixMilli(),
+		Updated: artifact.UpdatedAt.UnixMilli(),
+	}
+}
+
+func mapToReplicationRunArtifact(dst *replicationRunArtifactDB) *types.ReplicationRunArtifact {
+	return &types.ReplicationRunArtifact{
+		ID:        dst.ID,
+		RunID:     dst.RunID,
+		Image:     dst.Image,
+		Tag:       dst.Tag,
+		Status:    types.ReplicationStatus(dst.Status),
+		Log:       dst.Log,
+		CreatedAt: time.UnixMilli(dst.Created),
+		UpdatedAt: time.UnixMilli(dst.Updated),
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ScanPolicyRepository = (*ScanPolicyDao)(nil)
+
+func NewScanPolicyDao(db *sqlx.DB) store.ScanPolicyRepository {
+	return &ScanPolicyDao{
+		db: db,
+	}
+}
+
+type ScanPolicyDao struct {
+	db *sqlx.DB
+}
+
+type scanPolicyDB struct {
+	RegistryID         int64  `db:"registry_scan_policy_registry_id"`
+	Enabled            bool   `db:"registry_scan_policy_enabled"`
+	QuarantineSeverity string `db:"registry_scan_policy_quarantine_severity"`
+	CreatedBy          int64  `db:"registry_scan_policy_created_by"`
+	UpdatedBy          int64  `db:"registry_scan_policy_updated_by"`
+	Created            int64  `db:"registry_scan_policy_created"`
+	Updated            int64  `db:"registry_scan_policy_updated"`
+}
+
+func (s ScanPolicyDao) Find(ctx context.Context, registryID int64) (*types.ScanPolicy, error) {
+	stmt := database.Builder.Select(
+		"registry_scan_policy_registry_id",
+		"registry_scan_policy_enabled",
+		"registry_scan_policy_quarantine_severity",
+		"registry_scan_policy_created_by",
+		"registry_scan_policy_updated_by",
+		"registry_scan_policy_created",
+		"registry_scan_policy_updated",
+	).
+		From("registry_scan_policies").
+		Where("registry_scan_policy_registry_id = ?", registryID)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(scanPolicyDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find scan policy")
+	}
+
+	return &types.ScanPolicy{
+		RegistryID:         dst.RegistryID,
+		Enabled:            dst.Enabled,
+		QuarantineSeverity: types.VulnerabilitySeverity(dst.QuarantineSeverity),
+		CreatedBy:          dst.CreatedBy,
+		UpdatedBy:          dst.UpdatedBy,
+		CreatedAt:          time.UnixMilli(dst.Created),
+		UpdatedAt:          time.UnixMilli(dst.Updated),
+	}, nil
+}
+
+func (s ScanPolicyDao) Upsert(ctx context.Context, policy *types.ScanPolicy) error {
+	const sqlQuery = `
+		INSERT INTO registry_scan_policies (
+			registry_scan_policy_registry_id
+			,registry_scan_policy_enabled
+			,registry_scan_policy_quarantine_severity
+			,registry_scan_policy_created_by
+			,registry_scan_policy_updated_by
+			,registry_scan_policy_created
+			,registry_scan_policy_updated
+		) values (
+			:registry_scan_policy_registry_id
+			,:registry_scan_policy_enabled
+			,:registry_scan_policy_quarantine_severity
+			,:registry_scan_policy_created_by
+			,:registry_scan_policy_updated_by
+			,:registry_scan_policy_created
+			,:registry_scan_policy_updated
+		)
+		ON CONFLICT (registry_scan_policy_registry_id)
+		DO UPDATE SET
+			registry_scan_policy_enabled = :registry_scan_policy_enabled
+			,registry_scan_policy_quarantine_severity = :registry_scan_policy_quarantine_severity
+			,registry_scan_policy_updated_by = :registry_scan_policy_updated_by
+			,registry_scan_policy_updated = :registry_scan_policy_updated`
+
+	now := time.Now()
+	if policy.CreatedAt.IsZero() {
+		policy.CreatedAt = now
+	}
+	policy.UpdatedAt = now
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &scanPolicyDB{
+		RegistryID:         policy.RegistryID,
+		Enabled:            policy.Enabled,
+		QuarantineSeverity: string(policy.QuarantineSeverity),
+		CreatedBy:          policy.CreatedBy,
+		UpdatedBy:          policy.UpdatedBy,
+		Created:            policy.CreatedAt.UnixMilli(),
+		Updated:            policy.UpdatedAt.UnixMilli(),
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind scan policy object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
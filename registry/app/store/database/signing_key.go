@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.SigningKeyRepository = (*SigningKeyDao)(nil)
+
+var signingKeyFields = []string{
+	"registry_signing_key_id",
+	"registry_signing_key_registry_id",
+	"registry_signing_key_private_key",
+	"registry_signing_key_public_key",
+	"registry_signing_key_created",
+}
+
+func NewSigningKeyDao(db *sqlx.DB) store.SigningKeyRepository {
+	return &SigningKeyDao{
+		db: db,
+	}
+}
+
+type SigningKeyDao struct {
+	db *sqlx.DB
+}
+
+type signingKeyDB struct {
+	ID         int64  `db:"registry_signing_key_id"`
+	RegistryID int64  `db:"registry_signing_key_registry_id"`
+	PrivateKey []byte `db:"registry_signing_key_private_key"`
+	PublicKey  string `db:"registry_signing_key_public_key"`
+	Created    int64  `db:"registry_signing_key_created"`
+}
+
+func (s SigningKeyDao) GetByRegistryID(ctx context.Context, registryID int64) (*types.SigningKey, error) {
+	stmt := database.Builder.Select(signingKeyFields...).
+		From("registry_signing_keys").
+		Where("registry_signing_key_registry_id = ?", registryID)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(signingKeyDB)
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find signing key")
+	}
+
+	return mapToSigningKey(dst), nil
+}
+
+func (s SigningKeyDao) Create(ctx context.Context, key *types.SigningKey) error {
+	const sqlQuery = `
+		INSERT INTO registry_signing_keys (
+			registry_signing_key_registry_id
+			,registry_signing_key_private_key
+			,registry_signing_key_public_key
+			,registry_signing_key_created
+		) values (
+			:registry_signing_key_registry_id
+			,:registry_signing_key_private_key
+			,:registry_signing_key_public_key
+			,:registry_signing_key_created
+		) RETURNING registry_signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	key.CreatedAt = time.Now()
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToSigningKeyDB(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind signing key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func mapToSigningKeyDB(key *types.SigningKey) *signingKeyDB {
+	return &signingKeyDB{
+		ID:         key.ID,
+		RegistryID: key.RegistryID,
+		PrivateKey: key.PrivateKey,
+		PublicKey:  key.PublicKey,
+		Created:    key.CreatedAt.UnixMilli(),
+	}
+}
+
+func mapToSigningKey(dst *signingKeyDB) *types.SigningKey {
+	return &types.SigningKey{
+		ID:         dst.ID,
+		RegistryID: dst.RegistryID,
+		PrivateKey: dst.PrivateKey,
+		PublicKey:  dst.PublicKey,
+		CreatedAt:  time.UnixMilli(dst.Created),
+	}
+}
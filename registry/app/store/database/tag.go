@@ -323,6 +323,41 @@ func (t tagDao) TagsPaginated(
 	return t.mapToTagList(ctx, dst)
 }
 
+func (t tagDao) ListTagVersionsByRegistryID(
+	ctx context.Context, registryID int64,
+) ([]types.PackageVersion, error) {
+	stmt := databaseg.Builder.
+		Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(tagDB{}), ",")).
+		From("tags").
+		Where("tag_registry_id = ?", registryID).
+		OrderBy("tag_image_name", "tag_created_at DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, t.db)
+
+	dst := []*tagDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to list tags of registry %d", registryID)
+	}
+
+	versions := make([]types.PackageVersion, len(dst))
+	for i, d := range dst {
+		versions[i] = types.PackageVersion{
+			ArtifactID: d.ID,
+			Package:    d.ImageName,
+			Version:    d.Name,
+			CreatedAt:  time.UnixMilli(d.CreatedAt),
+			UpdatedAt:  time.UnixMilli(d.UpdatedAt),
+		}
+	}
+
+	return versions, nil
+}
+
 func (t tagDao) HasTagsAfterName(
 	ctx context.Context, repoID int64,
 	filters types.FilterParams,
@@ -112,6 +112,26 @@ func ProvideRegistryDao(
 ) store.RegistryRepository {
 	return NewRegistryDao(db, mtRepository, downloadCountFinder)
 }
+func ProvideReplicationRuleDao(db *sqlx.DB) store.ReplicationRuleRepository {
+	return NewReplicationRuleDao(db)
+}
+
+func ProvideReplicationRunDao(db *sqlx.DB) store.ReplicationRunRepository {
+	return NewReplicationRunDao(db)
+}
+
+func ProvideSigningKeyDao(db *sqlx.DB) store.SigningKeyRepository {
+	return NewSigningKeyDao(db)
+}
+
+func ProvideScanPolicyDao(db *sqlx.DB) store.ScanPolicyRepository {
+	return NewScanPolicyDao(db)
+}
+
+func ProvideArtifactScanDao(db *sqlx.DB) store.ArtifactScanRepository {
+	return NewArtifactScanDao(db)
+}
+
 func ProvideTaskRepository(db *sqlx.DB, tx dbtx.Transactor) store.TaskRepository {
 	return NewTaskStore(db, tx)
 }
@@ -143,6 +163,11 @@ var WireSet = wire.NewSet(
 	ProvideGenericBlobDao,
 	ProvideWebhookDao,
 	ProvideWebhookExecutionDao,
+	ProvideReplicationRuleDao,
+	ProvideReplicationRunDao,
+	ProvideSigningKeyDao,
+	ProvideScanPolicyDao,
+	ProvideArtifactScanDao,
 	ProvidePackageTagDao,
 	ProvideTaskRepository,
 	ProvideTaskSourceRepository,
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// RepodataPrefix is the storage prefix of the generated repodata.json files, which makes it a reserved
+	// package name.
+	RepodataPrefix = "repodata"
+	RepodataFile   = "repodata.json"
+	NoarchSubdir   = "noarch"
+
+	CondaFileExtension  = ".conda"
+	TarBz2FileExtension = ".tar.bz2"
+)
+
+var (
+	namePattern    = regexp.MustCompile(`^[a-z0-9_][a-z0-9_.-]*$`)
+	versionPattern = regexp.MustCompile(`^[0-9A-Za-z_.+!]+$`)
+	buildPattern   = regexp.MustCompile(`^[0-9A-Za-z_.+]+$`)
+	subdirPattern  = regexp.MustCompile(`^(?:noarch|[a-z0-9]+-[a-z0-9_]+)$`)
+)
+
+// IsValidName validates a package name, which conda requires to be lowercase.
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsValidVersion validates a package version. Versions may not contain "-" as it separates the name,
+// version and build string of file names.
+func IsValidVersion(version string) bool {
+	return versionPattern.MatchString(version)
+}
+
+func IsValidBuild(build string) bool {
+	return buildPattern.MatchString(build)
+}
+
+// IsValidSubdir validates a platform subdirectory of a channel, e.g. noarch, linux-64 or osx-arm64.
+func IsValidSubdir(subdir string) bool {
+	return subdirPattern.MatchString(subdir)
+}
+
+// FilePath returns the storage path of the versions of a package, or of the files of a single version.
+func FilePath(name, version string) string {
+	filePath := "/" + name
+	if version != "" {
+		filePath += "/" + version
+	}
+	return filePath
+}
+
+// RepodataPath returns the storage path of the repodata.json of a subdir.
+func RepodataPath(subdir string) string {
+	return "/" + RepodataPrefix + "/" + subdir + "/" + RepodataFile
+}
+
+func FileName(name, version, build, extension string) string {
+	return name + "-" + version + "-" + build + extension
+}
+
+// FileExtension returns the package format extension of a file name, or "" if it is not a package.
+func FileExtension(fileName string) string {
+	switch {
+	case strings.HasSuffix(fileName, CondaFileExtension):
+		return CondaFileExtension
+	case strings.HasSuffix(fileName, TarBz2FileExtension):
+		return TarBz2FileExtension
+	default:
+		return ""
+	}
+}
+
+// ParseFileName splits a package file name of the form <name>-<version>-<build>.<extension>.
+func ParseFileName(fileName string) (name, version, build, extension string, ok bool) {
+	extension = FileExtension(fileName)
+	if extension == "" {
+		return "", "", "", "", false
+	}
+	base := strings.TrimSuffix(fileName, extension)
+	i := strings.LastIndex(base, "-")
+	if i < 0 {
+		return "", "", "", "", false
+	}
+	j := strings.LastIndex(base[:i], "-")
+	if j < 0 {
+		return "", "", "", "", false
+	}
+	name, version, build = base[:j], base[j+1:i], base[i+1:]
+	if !IsValidName(name) || !IsValidVersion(version) || !IsValidBuild(build) {
+		return "", "", "", "", false
+	}
+	return name, version, build, extension, true
+}
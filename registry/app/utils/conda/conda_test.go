@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"hash/crc32"
+	"testing"
+
+	condametadata "github.com/harness/gitness/registry/app/metadata/conda"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIndex = `{
+  "name": "example",
+  "version": "1.2.0",
+  "build": "py_0",
+  "build_number": 0,
+  "depends": ["python >=3.8"],
+  "license": "MIT",
+  "noarch": "python",
+  "subdir": "noarch",
+  "timestamp": 1704067200000
+}`
+
+const testAbout = `{"summary": "An example package", "home": "https://example.com", "license": "MIT"}`
+
+func buildTar(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func buildConda(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var info bytes.Buffer
+	zw, err := zstd.NewWriter(&info)
+	require.NoError(t, err)
+	_, err = zw.Write(buildTar(t, files))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	members := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "metadata.json", content: []byte(`{"conda_pkg_format_version": 2}`)},
+		{name: "pkg-example-1.2.0-py_0.tar.zst", content: []byte("payload")},
+		{name: "info-example-1.2.0-py_0.tar.zst", content: info.Bytes()},
+	}
+	for _, m := range members {
+		// members are stored with known sizes, as conda writes them.
+		fw, err := w.CreateRaw(&zip.FileHeader{
+			Name:               m.name,
+			Method:             zip.Store,
+			CRC32:              crc32.ChecksumIEEE(m.content),
+			CompressedSize64:   uint64(len(m.content)),
+			UncompressedSize64: uint64(len(m.content)),
+		})
+		require.NoError(t, err)
+		_, err = fw.Write(m.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName  string
+		name      string
+		version   string
+		build     string
+		extension string
+		ok        bool
+	}{
+		{"numpy-1.26.4-py312h8753938_0.conda", "numpy", "1.26.4", "py312h8753938_0", CondaFileExtension, true},
+		{"python-dateutil-2.8.2-pyhd8ed1ab_0.tar.bz2", "python-dateutil", "2.8.2", "pyhd8ed1ab_0",
+			TarBz2FileExtension, true},
+		{"numpy-1.26.4.conda", "", "", "", "", false},
+		{"numpy-1.26.4-py312_0.whl", "", "", "", "", false},
+		{"NumPy-1.26.4-py312_0.conda", "", "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			name, version, build, extension, ok := ParseFileName(tt.fileName)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.build, build)
+			assert.Equal(t, tt.extension, extension)
+		})
+	}
+}
+
+func TestParsePackage(t *testing.T) {
+	data := buildConda(t, map[string]string{
+		"info/index.json": testIndex,
+		"info/about.json": testAbout,
+		"info/paths.json": `{"paths": []}`,
+	})
+
+	p, err := ParsePackage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, CondaFileExtension, p.Extension)
+	assert.Equal(t, "example", p.Record.Name)
+	assert.Equal(t, "1.2.0", p.Record.Version)
+	assert.Equal(t, "py_0", p.Record.Build)
+	assert.Equal(t, NoarchSubdir, p.Record.Subdir)
+	assert.Equal(t, "python", p.Record.Noarch)
+	assert.Equal(t, []string{"python >=3.8"}, p.Record.Depends)
+	assert.Equal(t, int64(1704067200000), p.Record.Timestamp)
+	assert.Equal(t, "An example package", p.About.Summary)
+	assert.Equal(t, "https://example.com", p.About.Home)
+}
+
+func TestParsePackageInvalid(t *testing.T) {
+	_, err := ParsePackage(bytes.NewReader([]byte("not a package")))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+
+	data := buildConda(t, map[string]string{
+		"info/index.json": `{"name": "Example", "version": "1.2.0", "build": "py_0", "subdir": "noarch"}`,
+	})
+	_, err = ParsePackage(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+
+	data = buildConda(t, map[string]string{"info/about.json": testAbout})
+	_, err = ParsePackage(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+}
+
+func TestRepodata(t *testing.T) {
+	repodata := NewRepodata(NoarchSubdir)
+	record := condametadata.PackageRecord{
+		Name:    "example",
+		Version: "1.2.0",
+		Build:   "py_0",
+		Depends: []string{},
+		Subdir:  NoarchSubdir,
+		Sha256:  "abc",
+	}
+	repodata.Add("example-1.2.0-py_0.conda", record)
+	repodata.Add("example-1.2.0-py_0.tar.bz2", record)
+
+	data, err := repodata.Bytes()
+	require.NoError(t, err)
+
+	var decoded map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.JSONEq(t, `{"subdir": "noarch"}`, string(decoded["info"]))
+	assert.JSONEq(t, `1`, string(decoded["repodata_version"]))
+	assert.JSONEq(t, `[]`, string(decoded["removed"]))
+
+	var packages, packagesConda map[string]condametadata.PackageRecord
+	require.NoError(t, json.Unmarshal(decoded["packages"], &packages))
+	require.NoError(t, json.Unmarshal(decoded["packages.conda"], &packagesConda))
+	assert.Contains(t, packages, "example-1.2.0-py_0.tar.bz2")
+	assert.Contains(t, packagesConda, "example-1.2.0-py_0.conda")
+	assert.Equal(t, "abc", packagesConda["example-1.2.0-py_0.conda"].Sha256)
+}
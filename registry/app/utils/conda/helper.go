@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/harness/gitness/app/services/refcache"
+	condametadata "github.com/harness/gitness/registry/app/metadata/conda"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	artifactBatchLimit = 50
+	repodataPageSize   = 100
+)
+
+type RegistryHelper interface {
+	// BuildRegistryIndex regenerates the repodata.json of every subdir of a registry from its packages.
+	BuildRegistryIndex(ctx context.Context, registry *types.Registry, principalID int64) error
+}
+
+type registryHelper struct {
+	fileManager filemanager.FileManager
+	artifactDao store.ArtifactRepository
+	spaceFinder refcache.SpaceFinder
+}
+
+func NewRegistryHelper(
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	spaceFinder refcache.SpaceFinder,
+) RegistryHelper {
+	return &registryHelper{
+		fileManager: fileManager,
+		artifactDao: artifactDao,
+		spaceFinder: spaceFinder,
+	}
+}
+
+func (h *registryHelper) BuildRegistryIndex(
+	ctx context.Context,
+	registry *types.Registry,
+	principalID int64,
+) error {
+	rootSpace, err := h.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space by ID: %w", err)
+	}
+	subdirs, err := h.collectPackages(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	// conda requires a noarch index in every channel, even if it has no noarch packages.
+	if _, ok := subdirs[NoarchSubdir]; !ok {
+		subdirs[NoarchSubdir] = NewRepodata(NoarchSubdir)
+	}
+
+	generated := make(map[string]bool)
+	for subdir, repodata := range subdirs {
+		content, err := repodata.Bytes()
+		if err != nil {
+			return fmt.Errorf("failed to build repodata for subdir %s: %w", subdir, err)
+		}
+		filePath := RepodataPath(subdir)
+		generated[filePath] = true
+
+		checksum := sha256.Sum256(content)
+		if existing, _, err := h.fileManager.HeadFile(ctx, filePath, registry.ID); err == nil &&
+			existing == hex.EncodeToString(checksum[:]) {
+			continue
+		}
+		_, err = h.fileManager.UploadFile(ctx, filePath, registry.ID, registry.RootParentID,
+			rootSpace.Identifier, nil, bytes.NewReader(content), principalID)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", filePath, err)
+		}
+	}
+	return h.removeStaleFiles(ctx, registry.ID, generated)
+}
+
+// collectPackages returns the repodata of every subdir that has packages. Files are listed from the
+// metadata of the versions, which keeps the record of every uploaded file.
+func (h *registryHelper) collectPackages(ctx context.Context, registryID int64) (map[string]*Repodata, error) {
+	subdirs := make(map[string]*Repodata)
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := h.artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+		for _, a := range *artifacts {
+			metadata := condametadata.CondaMetadata{}
+			if err := json.Unmarshal(a.Metadata, &metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata for artifact %s: %w", a.Name, err)
+			}
+			for _, f := range metadata.GetFiles() {
+				record, ok := metadata.Packages[f.Filename]
+				if !ok {
+					continue
+				}
+				subdir := path.Dir(f.Filename)
+				if subdirs[subdir] == nil {
+					subdirs[subdir] = NewRepodata(subdir)
+				}
+				subdirs[subdir].Add(path.Base(f.Filename), record)
+			}
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			break
+		}
+	}
+	return subdirs, nil
+}
+
+// removeStaleFiles deletes the repodata of subdirs that no longer have any package.
+func (h *registryHelper) removeStaleFiles(ctx context.Context, registryID int64, generated map[string]bool) error {
+	var stale []string
+	for offset := 0; ; offset += repodataPageSize {
+		files, err := h.fileManager.GetFilesMetadata(ctx, "/"+RepodataPrefix+"/%", registryID,
+			"name", "ASC", repodataPageSize, offset, "")
+		if err != nil {
+			return fmt.Errorf("failed to list repodata files: %w", err)
+		}
+		for _, f := range *files {
+			if !generated[f.Path] {
+				stale = append(stale, f.Path)
+			}
+		}
+		if len(*files) < repodataPageSize {
+			break
+		}
+	}
+	for _, filePath := range stale {
+		if err := h.fileManager.DeleteFile(ctx, registryID, filePath); err != nil {
+			return err
+		}
+		log.Ctx(ctx).Info().Msgf("removed stale conda repodata file %s from registry %d", filePath, registryID)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"encoding/json"
+
+	condametadata "github.com/harness/gitness/registry/app/metadata/conda"
+)
+
+const repodataVersion = 1
+
+// RepodataInfo describes the subdir a repodata.json belongs to.
+type RepodataInfo struct {
+	Subdir string `json:"subdir"`
+}
+
+// Repodata is the repodata.json index of a subdir.
+// Source: https://github.com/conda/conda-index
+type Repodata struct {
+	Info            RepodataInfo                           `json:"info"`
+	Packages        map[string]condametadata.PackageRecord `json:"packages"`
+	PackagesConda   map[string]condametadata.PackageRecord `json:"packages.conda"`
+	Removed         []string                               `json:"removed"`
+	RepodataVersion int                                    `json:"repodata_version"`
+}
+
+// NewRepodata returns an empty repodata.json index of a subdir.
+func NewRepodata(subdir string) *Repodata {
+	return &Repodata{
+		Info:            RepodataInfo{Subdir: subdir},
+		Packages:        make(map[string]condametadata.PackageRecord),
+		PackagesConda:   make(map[string]condametadata.PackageRecord),
+		Removed:         []string{},
+		RepodataVersion: repodataVersion,
+	}
+}
+
+// Add lists a package file in the index of its format.
+func (r *Repodata) Add(fileName string, record condametadata.PackageRecord) {
+	if FileExtension(fileName) == CondaFileExtension {
+		r.PackagesConda[fileName] = record
+		return
+	}
+	r.Packages[fileName] = record
+}
+
+// Bytes returns the JSON encoding of the index. Map keys are sorted, so unchanged indices encode identically.
+func (r *Repodata) Bytes() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
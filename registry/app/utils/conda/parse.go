@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conda
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	condametadata "github.com/harness/gitness/registry/app/metadata/conda"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	indexFileName = "info/index.json"
+	aboutFileName = "info/about.json"
+
+	maxInfoFileSize = 1 << 20
+
+	zipLocalFileHeaderSignature = 0x04034b50
+	zipDataDescriptorFlag       = 0x08
+	zipMethodStore              = 0
+	zipMethodDeflate            = 8
+	zip64ExtraID                = 0x0001
+)
+
+var (
+	ErrInvalidPackage = errors.New("invalid conda package")
+
+	bzip2Magic = []byte("BZh")
+	zipMagic   = []byte("PK\x03\x04")
+)
+
+// Package is the metadata read from the info files of a package.
+type Package struct {
+	Extension string
+	Record    condametadata.PackageRecord
+	About     About
+}
+
+// About is the subset of info/about.json kept as version metadata.
+type About struct {
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Home        string `json:"home"`
+	License     string `json:"license"`
+	DevURL      string `json:"dev_url"`
+	DocURL      string `json:"doc_url"`
+}
+
+type indexJSON struct {
+	Name          string          `json:"name"`
+	Version       string          `json:"version"`
+	Build         string          `json:"build"`
+	BuildNumber   int64           `json:"build_number"`
+	Depends       []string        `json:"depends"`
+	Constrains    []string        `json:"constrains"`
+	License       string          `json:"license"`
+	LicenseFamily string          `json:"license_family"`
+	Subdir        string          `json:"subdir"`
+	Arch          string          `json:"arch"`
+	Platform      string          `json:"platform"`
+	Noarch        json.RawMessage `json:"noarch"`
+	Features      string          `json:"features"`
+	TrackFeatures json.RawMessage `json:"track_features"`
+	Timestamp     int64           `json:"timestamp"`
+}
+
+// ParsePackage reads info/index.json and info/about.json from a .conda or .tar.bz2 package. The format is
+// detected from the content.
+func ParsePackage(r io.Reader) (*Package, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(zipMagic))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+	}
+
+	files := make(map[string][]byte)
+	var extension string
+	switch {
+	case bytes.Equal(magic, zipMagic):
+		extension = CondaFileExtension
+		err = readCondaInfo(br, files)
+	case bytes.HasPrefix(magic, bzip2Magic):
+		extension = TarBz2FileExtension
+		err = readInfoTar(bzip2.NewReader(br), files)
+	default:
+		return nil, fmt.Errorf("%w: expected a .conda or .tar.bz2 package", ErrInvalidPackage)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index, ok := files[indexFileName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s not found", ErrInvalidPackage, indexFileName)
+	}
+	record, err := parseIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	p := &Package{Extension: extension, Record: *record}
+	if about, ok := files[aboutFileName]; ok {
+		// about.json is informational only, packages with a malformed one are still accepted.
+		_ = json.Unmarshal(about, &p.About)
+	}
+	return p, nil
+}
+
+func parseIndex(data []byte) (*condametadata.PackageRecord, error) {
+	var index indexJSON
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidPackage, indexFileName, err)
+	}
+	switch {
+	case !IsValidName(index.Name):
+		return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidPackage, index.Name)
+	case !IsValidVersion(index.Version):
+		return nil, fmt.Errorf("%w: invalid version %q", ErrInvalidPackage, index.Version)
+	case !IsValidBuild(index.Build):
+		return nil, fmt.Errorf("%w: invalid build %q", ErrInvalidPackage, index.Build)
+	case !IsValidSubdir(index.Subdir):
+		return nil, fmt.Errorf("%w: invalid subdir %q", ErrInvalidPackage, index.Subdir)
+	}
+
+	record := &condametadata.PackageRecord{
+		Name:          index.Name,
+		Version:       index.Version,
+		Build:         index.Build,
+		BuildNumber:   index.BuildNumber,
+		Depends:       index.Depends,
+		Constrains:    index.Constrains,
+		License:       index.License,
+		LicenseFamily: index.LicenseFamily,
+		Subdir:        index.Subdir,
+		Arch:          index.Arch,
+		Platform:      index.Platform,
+		Noarch:        parseNoarch(index.Noarch),
+		Features:      index.Features,
+		TrackFeatures: parseTrackFeatures(index.TrackFeatures),
+		Timestamp:     index.Timestamp,
+	}
+	if record.Depends == nil {
+		record.Depends = []string{}
+	}
+	return record, nil
+}
+
+// parseNoarch accepts the noarch type, e.g. "python", as well as the legacy boolean form.
+func parseNoarch(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil && b {
+		return "generic"
+	}
+	return ""
+}
+
+// parseTrackFeatures accepts both the space separated string and the list form.
+func parseTrackFeatures(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, " ")
+	}
+	return ""
+}
+
+// readInfoTar collects the info files of a tar stream.
+func readInfoTar(r io.Reader, files map[string][]byte) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		name := strings.TrimPrefix(hdr.Name, "./")
+		if name != indexFileName && name != aboutFileName {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxInfoFileSize+1))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		if len(data) > maxInfoFileSize {
+			return fmt.Errorf("%w: %s too large", ErrInvalidPackage, name)
+		}
+		files[name] = data
+		if len(files) == 2 {
+			return nil
+		}
+	}
+}
+
+// readCondaInfo reads the info-*.tar.zst member of a .conda zip. The zip is read as a stream of local file
+// headers, which conda writes with known sizes, so that packages do not have to be buffered.
+func readCondaInfo(r io.Reader, files map[string][]byte) error {
+	for {
+		var header struct {
+			Signature        uint32
+			Version          uint16
+			Flags            uint16
+			Method           uint16
+			ModTime          uint16
+			ModDate          uint16
+			CRC32            uint32
+			CompressedSize   uint32
+			UncompressedSize uint32
+			NameLength       uint16
+			ExtraLength      uint16
+		}
+		if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		if header.Signature != zipLocalFileHeaderSignature {
+			// the central directory follows the last member.
+			return fmt.Errorf("%w: info archive not found", ErrInvalidPackage)
+		}
+		if header.Flags&zipDataDescriptorFlag != 0 {
+			return fmt.Errorf("%w: zip members without sizes are not supported", ErrInvalidPackage)
+		}
+		nameAndExtra := make([]byte, int(header.NameLength)+int(header.ExtraLength))
+		if _, err := io.ReadFull(r, nameAndExtra); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		name := string(nameAndExtra[:header.NameLength])
+		size := int64(header.CompressedSize)
+		if header.CompressedSize == 0xFFFFFFFF {
+			size = zip64CompressedSize(nameAndExtra[header.NameLength:])
+			if size < 0 {
+				return fmt.Errorf("%w: invalid zip64 member %s", ErrInvalidPackage, name)
+			}
+		}
+		member := io.LimitReader(r, size)
+
+		if !strings.HasPrefix(name, "info-") || !strings.HasSuffix(name, ".tar.zst") {
+			if _, err := io.Copy(io.Discard, member); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+			}
+			continue
+		}
+
+		var content io.Reader
+		switch header.Method {
+		case zipMethodStore:
+			content = member
+		case zipMethodDeflate:
+			fr := flate.NewReader(member)
+			defer fr.Close()
+			content = fr
+		default:
+			return fmt.Errorf("%w: unsupported zip compression method %d", ErrInvalidPackage, header.Method)
+		}
+		zr, err := zstd.NewReader(content)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		defer zr.Close()
+		return readInfoTar(zr, files)
+	}
+}
+
+// zip64CompressedSize returns the compressed size from the zip64 extended information of a local file header.
+func zip64CompressedSize(extra []byte) int64 {
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+		if size > len(extra) {
+			break
+		}
+		// the local header zip64 record holds the uncompressed and then the compressed size.
+		if id == zip64ExtraID && size >= 16 {
+			return int64(binary.LittleEndian.Uint64(extra[8:]))
+		}
+		extra = extra[size:]
+	}
+	return -1
+}
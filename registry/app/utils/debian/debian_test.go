@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testControl = `Package: hello
+Version: 1:2.10-3
+Architecture: amd64
+Maintainer: Jane Doe <jane@example.com>
+Installed-Size: 280
+Depends: libc6 (>= 2.34), dpkg
+Section: devel
+Priority: optional
+Homepage: https://www.gnu.org/software/hello/
+Description: example package based on GNU hello
+ The GNU hello program produces a familiar, friendly greeting.
+ .
+ It is used for testing.
+`
+
+func buildDeb(t *testing.T, control string) []byte {
+	t.Helper()
+
+	var controlTar bytes.Buffer
+	gz := gzip.NewWriter(&controlTar)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: "./control", Mode: 0o644, Size: int64(len(control)), Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write([]byte(control))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	var deb bytes.Buffer
+	deb.WriteString(arMagic)
+	for _, member := range []struct {
+		name string
+		data []byte
+	}{
+		{"debian-binary", []byte("2.0\n")},
+		{"control.tar.gz", controlTar.Bytes()},
+		{"data.tar.gz", []byte{}},
+	} {
+		fmt.Fprintf(&deb, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", member.name, 0, 0, 0, "100644", len(member.data))
+		deb.Write(member.data)
+		if len(member.data)%2 == 1 {
+			deb.WriteByte('\n')
+		}
+	}
+	return deb.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	p, err := ParsePackage(bytes.NewReader(buildDeb(t, testControl)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", p.Name)
+	assert.Equal(t, "1:2.10-3", p.Version)
+	assert.Equal(t, "amd64", p.Architecture)
+	assert.Equal(t, int64(280), p.FileMetadata.InstalledSize)
+	assert.Equal(t, []string{"libc6 (>= 2.34)", "dpkg"}, p.FileMetadata.Depends)
+	assert.Equal(t, "https://www.gnu.org/software/hello/", p.VersionMetadata.Homepage)
+	assert.True(t, strings.HasPrefix(p.VersionMetadata.Description, "example package based on GNU hello\n The GNU"))
+	assert.Equal(t, testControl, p.FileMetadata.Control)
+}
+
+func TestParsePackageDropsFileFields(t *testing.T) {
+	p, err := ParsePackage(bytes.NewReader(buildDeb(t, testControl+"Filename: pool/x.deb\nSHA256: abc\n")))
+	require.NoError(t, err)
+	assert.Equal(t, testControl, p.FileMetadata.Control)
+}
+
+func TestParsePackageInvalid(t *testing.T) {
+	_, err := ParsePackage(strings.NewReader("not a deb"))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+
+	_, err = ParsePackage(bytes.NewReader(buildDeb(t, "Package: Hello\nVersion: 1.0\nArchitecture: all\n")))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+
+	_, err = ParsePackage(bytes.NewReader(buildDeb(t, "Package: hello\nVersion: 1.0\n")))
+	assert.ErrorIs(t, err, ErrInvalidPackage)
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "hello_2.10-3_amd64.deb", FileName("hello", "1:2.10-3", "amd64"))
+
+	name, version, arch, err := ParseFileName("pool/main/h/hello/hello_2.10-3_amd64.deb")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "2.10-3", "amd64"}, []string{name, version, arch})
+
+	_, _, _, err = ParseFileName("hello-2.10.deb")
+	assert.Error(t, err)
+	_, _, _, err = ParseFileName("hello_2.10_amd64.rpm")
+	assert.Error(t, err)
+}
+
+func TestBuildComponentIndices(t *testing.T) {
+	entries := map[string][]IndexEntry{
+		"amd64": {{Control: "Package: b\n", Filename: "pool/stable/main/b_1_amd64.deb", Size: 2, SHA256: "bb"}},
+		"all":   {{Control: "Package: a\n", Filename: "pool/stable/main/a_1_all.deb", Size: 1, SHA256: "aa"}},
+	}
+	files, err := BuildComponentIndices("main", entries)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	assert.Equal(t, "main/binary-all/Packages", files[0].Path)
+	assert.Equal(t, "Package: a\nFilename: pool/stable/main/a_1_all.deb\nSize: 1\nSHA256: aa\n",
+		string(files[0].Content))
+
+	assert.Equal(t, "main/binary-amd64/Packages", files[2].Path)
+	assert.Equal(t,
+		"Package: a\nFilename: pool/stable/main/a_1_all.deb\nSize: 1\nSHA256: aa\n\n"+
+			"Package: b\nFilename: pool/stable/main/b_1_amd64.deb\nSize: 2\nSHA256: bb\n",
+		string(files[2].Content))
+
+	assert.Equal(t, "main/binary-amd64/Packages.gz", files[3].Path)
+	gz, err := gzip.NewReader(bytes.NewReader(files[3].Content))
+	require.NoError(t, err)
+	var unzipped bytes.Buffer
+	_, err = unzipped.ReadFrom(gz)
+	require.NoError(t, err)
+	assert.Equal(t, files[2].Content, unzipped.Bytes())
+}
+
+func TestReleaseBytes(t *testing.T) {
+	release := Release{
+		Origin:        "debs",
+		Label:         "debs",
+		Suite:         "stable",
+		Date:          time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Architectures: []string{"all", "amd64"},
+		Components:    []string{"main"},
+		Files:         []IndexFile{{Path: "main/binary-amd64/Packages", Content: []byte("")}},
+	}
+	assert.Equal(t, "Origin: debs\nLabel: debs\nSuite: stable\nCodename: stable\n"+
+		"Date: Wed, 01 May 2024 10:00:00 UTC\nArchitectures: all amd64\nComponents: main\nSHA256:\n"+
+		" e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 0 main/binary-amd64/Packages\n",
+		string(release.Bytes()))
+}
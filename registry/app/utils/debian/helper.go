@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	debianmetadata "github.com/harness/gitness/registry/app/metadata/debian"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/utils/signing"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	artifactBatchLimit = 50
+	distsPageSize      = 100
+)
+
+type RegistryHelper interface {
+	// BuildRegistryIndex regenerates and signs the dists tree of a registry from its packages.
+	BuildRegistryIndex(ctx context.Context, registry *types.Registry, principalID int64) error
+
+	// GetPublicKey returns the ASCII armored public key the registry signs its indices with.
+	GetPublicKey(ctx context.Context, registry *types.Registry) (string, error)
+}
+
+type registryHelper struct {
+	fileManager filemanager.FileManager
+	artifactDao store.ArtifactRepository
+	keyStore    signing.KeyStore
+	spaceFinder refcache.SpaceFinder
+}
+
+func NewRegistryHelper(
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	keyStore signing.KeyStore,
+	spaceFinder refcache.SpaceFinder,
+) RegistryHelper {
+	return &registryHelper{
+		fileManager: fileManager,
+		artifactDao: artifactDao,
+		keyStore:    keyStore,
+		spaceFinder: spaceFinder,
+	}
+}
+
+// distributions maps distribution -> component -> architecture -> packages.
+type distributions map[string]map[string]map[string][]IndexEntry
+
+func (h *registryHelper) BuildRegistryIndex(
+	ctx context.Context,
+	registry *types.Registry,
+	principalID int64,
+) error {
+	rootSpace, err := h.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space by ID: %w", err)
+	}
+	dists, err := h.collectPackages(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	privateKey, _, err := h.keyStore.GetOrCreateKey(ctx, registry)
+	if err != nil {
+		return err
+	}
+
+	generated := make(map[string]bool)
+	for dist, components := range dists {
+		files, err := buildDistribution(registry.Name, dist, components, privateKey)
+		if err != nil {
+			return fmt.Errorf("failed to build index for distribution %s: %w", dist, err)
+		}
+		for _, f := range files {
+			filePath := "/" + DistsPrefix + "/" + dist + "/" + f.Path
+			_, err = h.fileManager.UploadFile(ctx, filePath, registry.ID, registry.RootParentID,
+				rootSpace.Identifier, nil, bytes.NewReader(f.Content), principalID)
+			if err != nil {
+				return fmt.Errorf("failed to upload %s: %w", filePath, err)
+			}
+			generated[filePath] = true
+		}
+	}
+	return h.removeStaleFiles(ctx, registry.ID, generated)
+}
+
+func (h *registryHelper) GetPublicKey(ctx context.Context, registry *types.Registry) (string, error) {
+	_, publicKey, err := h.keyStore.GetOrCreateKey(ctx, registry)
+	return publicKey, err
+}
+
+func (h *registryHelper) collectPackages(ctx context.Context, registryID int64) (distributions, error) {
+	dists := make(distributions)
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := h.artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+		for _, a := range *artifacts {
+			metadata := debianmetadata.DebianMetadata{}
+			if err := json.Unmarshal(a.Metadata, &metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata for artifact %s: %w", a.Name, err)
+			}
+			arch := metadata.FileMetadata.Architecture
+			for _, f := range metadata.GetFiles() {
+				// only uploaded files carry a <distribution>/<component>/ prefix; files cached from an
+				// upstream are listed by the upstream's own indices.
+				parts := strings.Split(f.Filename, "/")
+				if len(parts) != 3 {
+					continue
+				}
+				dist, component := parts[0], parts[1]
+				if dists[dist] == nil {
+					dists[dist] = make(map[string]map[string][]IndexEntry)
+				}
+				if dists[dist][component] == nil {
+					dists[dist][component] = make(map[string][]IndexEntry)
+				}
+				dists[dist][component][arch] = append(dists[dist][component][arch], IndexEntry{
+					Control:  metadata.FileMetadata.Control,
+					Filename: PoolPrefix + "/" + f.Filename,
+					Size:     f.Size,
+					SHA256:   f.Sha256,
+				})
+			}
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			break
+		}
+	}
+	return dists, nil
+}
+
+func buildDistribution(
+	registryName string,
+	dist string,
+	components map[string]map[string][]IndexEntry,
+	privateKey string,
+) ([]IndexFile, error) {
+	release := Release{
+		Origin: registryName,
+		Label:  registryName,
+		Suite:  dist,
+		Date:   time.Now(),
+	}
+	architectures := make(map[string]bool)
+	for component, entries := range components {
+		release.Components = append(release.Components, component)
+		for arch := range entries {
+			architectures[arch] = true
+		}
+		files, err := BuildComponentIndices(component, entries)
+		if err != nil {
+			return nil, err
+		}
+		release.Files = append(release.Files, files...)
+	}
+	for arch := range architectures {
+		release.Architectures = append(release.Architectures, arch)
+	}
+	sort.Strings(release.Components)
+	sort.Strings(release.Architectures)
+	sort.Slice(release.Files, func(i, j int) bool {
+		return release.Files[i].Path < release.Files[j].Path
+	})
+
+	content := release.Bytes()
+	inRelease, err := signing.ClearSign(privateKey, content)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signing.ArmoredDetachSign(privateKey, content)
+	if err != nil {
+		return nil, err
+	}
+	return append(release.Files,
+		IndexFile{Path: ReleaseFile, Content: content},
+		IndexFile{Path: InReleaseFile, Content: inRelease},
+		IndexFile{Path: ReleaseGpgFile, Content: signature},
+	), nil
+}
+
+// removeStaleFiles deletes index files of distributions, components or architectures that no longer
+// have any package.
+func (h *registryHelper) removeStaleFiles(ctx context.Context, registryID int64, generated map[string]bool) error {
+	var stale []string
+	for offset := 0; ; offset += distsPageSize {
+		files, err := h.fileManager.GetFilesMetadata(ctx, "/"+DistsPrefix+"/%", registryID,
+			"name", "ASC", distsPageSize, offset, "")
+		if err != nil {
+			return fmt.Errorf("failed to list index files: %w", err)
+		}
+		for _, f := range *files {
+			if !generated[f.Path] {
+				stale = append(stale, f.Path)
+			}
+		}
+		if len(*files) < distsPageSize {
+			break
+		}
+	}
+	for _, filePath := range stale {
+		if err := h.fileManager.DeleteFile(ctx, registryID, filePath); err != nil {
+			return err
+		}
+		log.Ctx(ctx).Info().Msgf("removed stale debian index file %s from registry %d", filePath, registryID)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DistsPrefix     = "dists"
+	PoolPrefix      = "pool"
+	PackagesFile    = "Packages"
+	PackagesGzFile  = "Packages.gz"
+	ReleaseFile     = "Release"
+	InReleaseFile   = "InRelease"
+	ReleaseGpgFile  = "Release.gpg"
+	ArchitectureAll = "all"
+)
+
+// IndexEntry is a package as it is listed in a Packages index.
+type IndexEntry struct {
+	Control  string
+	Filename string
+	Size     int64
+	SHA256   string
+}
+
+// IndexFile is a generated index file, relative to the distribution directory.
+type IndexFile struct {
+	Path    string
+	Content []byte
+}
+
+// Release describes the Release file of a single distribution.
+type Release struct {
+	Origin        string
+	Label         string
+	Suite         string
+	Date          time.Time
+	Architectures []string
+	Components    []string
+	Files         []IndexFile
+}
+
+// BuildPackagesIndex renders the entries as a Packages index, ordered by file name.
+func BuildPackagesIndex(entries []IndexEntry) []byte {
+	sorted := make([]IndexEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Filename < sorted[j].Filename
+	})
+
+	var b bytes.Buffer
+	for i, e := range sorted {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.TrimRight(e.Control, "\n"))
+		fmt.Fprintf(&b, "\nFilename: %s\nSize: %d\nSHA256: %s\n", e.Filename, e.Size, e.SHA256)
+	}
+	return b.Bytes()
+}
+
+// BuildComponentIndices groups the entries of one component by architecture and returns the
+// Packages and Packages.gz files for each of them. Architecture independent packages are listed
+// in every architecture specific index as well as in binary-all.
+func BuildComponentIndices(component string, entries map[string][]IndexEntry) ([]IndexFile, error) {
+	all := entries[ArchitectureAll]
+	architectures := make([]string, 0, len(entries))
+	for arch := range entries {
+		architectures = append(architectures, arch)
+	}
+	sort.Strings(architectures)
+
+	var files []IndexFile
+	for _, arch := range architectures {
+		archEntries := entries[arch]
+		if arch != ArchitectureAll {
+			archEntries = append(archEntries[:len(archEntries):len(archEntries)], all...)
+		}
+		content := BuildPackagesIndex(archEntries)
+		compressed, err := gzipBytes(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress %s index for %s: %w", component, arch, err)
+		}
+		dir := component + "/binary-" + arch + "/"
+		files = append(files,
+			IndexFile{Path: dir + PackagesFile, Content: content},
+			IndexFile{Path: dir + PackagesGzFile, Content: compressed},
+		)
+	}
+	return files, nil
+}
+
+// Bytes renders the Release file.
+func (r Release) Bytes() []byte {
+	var b bytes.Buffer
+	if r.Origin != "" {
+		fmt.Fprintf(&b, "Origin: %s\n", r.Origin)
+	}
+	if r.Label != "" {
+		fmt.Fprintf(&b, "Label: %s\n", r.Label)
+	}
+	fmt.Fprintf(&b, "Suite: %s\n", r.Suite)
+	fmt.Fprintf(&b, "Codename: %s\n", r.Suite)
+	fmt.Fprintf(&b, "Date: %s\n", r.Date.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&b, "Architectures: %s\n", strings.Join(r.Architectures, " "))
+	fmt.Fprintf(&b, "Components: %s\n", strings.Join(r.Components, " "))
+	b.WriteString("SHA256:\n")
+	for _, f := range r.Files {
+		sum := sha256.Sum256(f.Content)
+		fmt.Fprintf(&b, " %s %d %s\n", hex.EncodeToString(sum[:]), len(f.Content), f.Path)
+	}
+	return b.Bytes()
+}
+
+func gzipBytes(content []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
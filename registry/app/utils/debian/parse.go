@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	debianmetadata "github.com/harness/gitness/registry/app/metadata/debian"
+	"github.com/harness/gitness/registry/validation"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	arMagic          = "!<arch>\n"
+	arHeaderSize     = 60
+	controlTarPrefix = "control.tar"
+	controlFileName  = "control"
+	maxControlSize   = 1 << 20
+
+	DebFileExtension = ".deb"
+)
+
+var (
+	ErrInvalidPackage     = errors.New("invalid debian package")
+	ErrMissingControlFile = errors.New("control file not found in debian package")
+
+	namePattern         = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]+$`)
+	versionPattern      = regexp.MustCompile(`^(?:[0-9]+:)?[0-9][A-Za-z0-9.+~-]*$`)
+	architecturePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	suitePattern        = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+	// fileFields are control fields that describe a single .deb file rather than the package itself.
+	// They are dropped from the stored control paragraph and regenerated for every index.
+	fileFields = map[string]bool{
+		"filename": true,
+		"size":     true,
+		"md5sum":   true,
+		"sha1":     true,
+		"sha256":   true,
+		"sha512":   true,
+	}
+)
+
+type Package struct {
+	Name            string
+	Version         string
+	Architecture    string
+	VersionMetadata *debianmetadata.VersionMetadata
+	FileMetadata    *debianmetadata.FileMetadata
+}
+
+type controlField struct {
+	Name  string
+	Value string
+}
+
+// ParsePackage reads a .deb archive and extracts the metadata from its control file.
+func ParsePackage(r io.Reader) (*Package, error) {
+	control, err := readControlFile(r)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseControl(control)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	kept := make([]controlField, 0, len(fields))
+	for _, f := range fields {
+		key := strings.ToLower(f.Name)
+		values[key] = f.Value
+		if !fileFields[key] {
+			kept = append(kept, f)
+		}
+	}
+
+	p := &Package{
+		Name:         values["package"],
+		Version:      values["version"],
+		Architecture: values["architecture"],
+		VersionMetadata: &debianmetadata.VersionMetadata{
+			Maintainer:  values["maintainer"],
+			Homepage:    values["homepage"],
+			Section:     values["section"],
+			Priority:    values["priority"],
+			Description: values["description"],
+		},
+		FileMetadata: &debianmetadata.FileMetadata{
+			Package:      values["package"],
+			Version:      values["version"],
+			Architecture: values["architecture"],
+			Source:       values["source"],
+			Depends:      splitRelations(values["depends"]),
+			PreDepends:   splitRelations(values["pre-depends"]),
+			Recommends:   splitRelations(values["recommends"]),
+			Provides:     splitRelations(values["provides"]),
+			Conflicts:    splitRelations(values["conflicts"]),
+			Control:      formatControl(kept),
+		},
+	}
+	if s := values["installed-size"]; s != "" {
+		size, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid Installed-Size %q", ErrInvalidPackage, s)
+		}
+		p.FileMetadata.InstalledSize = size
+	}
+
+	if !namePattern.MatchString(p.Name) {
+		return nil, fmt.Errorf("%w: invalid package name %q", ErrInvalidPackage, p.Name)
+	}
+	if !versionPattern.MatchString(p.Version) {
+		return nil, fmt.Errorf("%w: invalid version %q", ErrInvalidPackage, p.Version)
+	}
+	if !architecturePattern.MatchString(p.Architecture) {
+		return nil, fmt.Errorf("%w: invalid architecture %q", ErrInvalidPackage, p.Architecture)
+	}
+	if !validation.IsValidURL(p.VersionMetadata.Homepage) {
+		p.VersionMetadata.Homepage = ""
+	}
+	return p, nil
+}
+
+// IsValidSuite reports whether s can be used as a distribution or component name.
+func IsValidSuite(s string) bool {
+	return suitePattern.MatchString(s) && !strings.Contains(s, "..")
+}
+
+// FileVersion returns the version as it appears in .deb file names, which is the version without its epoch.
+func FileVersion(version string) string {
+	if i := strings.Index(version, ":"); i != -1 {
+		return version[i+1:]
+	}
+	return version
+}
+
+// FileName returns the canonical file name of a package: <name>_<version>_<architecture>.deb.
+func FileName(name, version, architecture string) string {
+	return fmt.Sprintf("%s_%s_%s%s", name, FileVersion(version), architecture, DebFileExtension)
+}
+
+// ParseFileName splits a canonical .deb file name into package name, version and architecture.
+func ParseFileName(fileName string) (name, version, architecture string, err error) {
+	base := path.Base(fileName)
+	if !strings.HasSuffix(base, DebFileExtension) {
+		return "", "", "", fmt.Errorf("%w: file name %q must end with %s", ErrInvalidPackage, base, DebFileExtension)
+	}
+	parts := strings.Split(strings.TrimSuffix(base, DebFileExtension), "_")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("%w: file name %q must be <name>_<version>_<architecture>%s",
+			ErrInvalidPackage, base, DebFileExtension)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func readControlFile(r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != arMagic {
+		return nil, fmt.Errorf("%w: not an ar archive", ErrInvalidPackage)
+	}
+
+	header := make([]byte, arHeaderSize)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrMissingControlFile
+			}
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		name := strings.TrimSuffix(strings.TrimSpace(string(header[0:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("%w: invalid ar member size", ErrInvalidPackage)
+		}
+
+		if strings.HasPrefix(name, controlTarPrefix) {
+			return readControlTar(io.LimitReader(br, size), strings.TrimPrefix(name, controlTarPrefix))
+		}
+
+		// ar members are aligned to an even offset.
+		if _, err := io.CopyN(io.Discard, br, size+size%2); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+	}
+}
+
+func readControlTar(r io.Reader, compression string) ([]byte, error) {
+	var tr *tar.Reader
+	switch compression {
+	case "":
+		tr = tar.NewReader(r)
+	case ".gz":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		defer gz.Close()
+		tr = tar.NewReader(gz)
+	case ".xz":
+		xzr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		tr = tar.NewReader(xzr)
+	case ".zst":
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		defer zr.Close()
+		tr = tar.NewReader(zr)
+	default:
+		return nil, fmt.Errorf("%w: unsupported control archive compression %q", ErrInvalidPackage, compression)
+	}
+
+	for {
+		hd, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrMissingControlFile
+			}
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		if hd.Typeflag != tar.TypeReg || path.Clean(hd.Name) != controlFileName {
+			continue
+		}
+		if hd.Size > maxControlSize {
+			return nil, fmt.Errorf("%w: control file too large", ErrInvalidPackage)
+		}
+		return io.ReadAll(io.LimitReader(tr, maxControlSize))
+	}
+}
+
+// parseControl parses a single deb822 paragraph, keeping field order and multi-line values.
+func parseControl(data []byte) ([]controlField, error) {
+	var fields []controlField
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(fields) > 0 {
+				// only the first paragraph is relevant for a binary package.
+				return fields, nil
+			}
+		case line[0] == ' ' || line[0] == '\t':
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("%w: unexpected continuation line in control file", ErrInvalidPackage)
+			}
+			fields[len(fields)-1].Value += "\n" + line
+		case line[0] == '#':
+			continue
+		default:
+			name, value, ok := strings.Cut(line, ":")
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("%w: malformed control line %q", ErrInvalidPackage, line)
+			}
+			fields = append(fields, controlField{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty control file", ErrInvalidPackage)
+	}
+	return fields, nil
+}
+
+func formatControl(fields []controlField) string {
+	var b bytes.Buffer
+	for _, f := range fields {
+		b.WriteString(f.Name)
+		b.WriteString(":")
+		if f.Value != "" && !strings.HasPrefix(f.Value, "\n") {
+			b.WriteString(" ")
+		}
+		b.WriteString(f.Value)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func splitRelations(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var relations []string
+	for _, rel := range strings.Split(value, ",") {
+		if rel = strings.TrimSpace(rel); rel != "" {
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	artifactBatchLimit = 50
+	indexPageSize      = 100
+)
+
+type RegistryHelper interface {
+	// BuildRegistryIndex regenerates the compact index and the specs files of a registry from its gems.
+	BuildRegistryIndex(ctx context.Context, registry *types.Registry, principalID int64) error
+}
+
+type registryHelper struct {
+	fileManager filemanager.FileManager
+	artifactDao store.ArtifactRepository
+	spaceFinder refcache.SpaceFinder
+}
+
+func NewRegistryHelper(
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	spaceFinder refcache.SpaceFinder,
+) RegistryHelper {
+	return &registryHelper{
+		fileManager: fileManager,
+		artifactDao: artifactDao,
+		spaceFinder: spaceFinder,
+	}
+}
+
+// IndexPath returns the storage path of a generated index file, e.g. versions or info/rails.
+func IndexPath(name string) string {
+	return "/" + IndexPrefix + "/" + name
+}
+
+func (h *registryHelper) BuildRegistryIndex(
+	ctx context.Context,
+	registry *types.Registry,
+	principalID int64,
+) error {
+	rootSpace, err := h.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space by ID: %w", err)
+	}
+	entries, err := h.collectGems(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	SortEntries(entries)
+
+	upload := func(name string, content []byte) error {
+		filePath := IndexPath(name)
+		checksum := sha256.Sum256(content)
+		// info files are fetched with ETags by clients, skip rewriting those that did not change.
+		if existing, _, err := h.fileManager.HeadFile(ctx, filePath, registry.ID); err == nil &&
+			existing == hex.EncodeToString(checksum[:]) {
+			return nil
+		}
+		_, err := h.fileManager.UploadFile(ctx, filePath, registry.ID, registry.RootParentID,
+			rootSpace.Identifier, nil, bytes.NewReader(content), principalID)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", filePath, err)
+		}
+		return nil
+	}
+
+	generated := make(map[string]bool)
+	var gems []VersionsEntry
+	var names []string
+	for start := 0; start < len(entries); {
+		end := start
+		for end < len(entries) && entries[end].Name == entries[start].Name {
+			end++
+		}
+		name := entries[start].Name
+		info := BuildInfo(entries[start:end])
+		infoName := InfoPrefix + "/" + name
+		if err := upload(infoName, info); err != nil {
+			return err
+		}
+		generated[IndexPath(infoName)] = true
+
+		versions := make([]string, 0, end-start)
+		for _, e := range entries[start:end] {
+			versions = append(versions, e.fullVersion())
+		}
+		gems = append(gems, VersionsEntry{Name: name, Versions: versions, Info: info})
+		names = append(names, name)
+		start = end
+	}
+
+	if err := upload(VersionsFile, BuildVersions(time.Now(), gems)); err != nil {
+		return err
+	}
+	if err := upload(NamesFile, BuildNames(names)); err != nil {
+		return err
+	}
+	specs := map[string][]IndexEntry{
+		SpecsFile:           FilterEntries(entries, false),
+		LatestSpecsFile:     LatestEntries(entries),
+		PrereleaseSpecsFile: FilterEntries(entries, true),
+	}
+	for name, list := range specs {
+		content, err := BuildSpecs(list)
+		if err != nil {
+			return fmt.Errorf("failed to build %s: %w", name, err)
+		}
+		if err := upload(name, content); err != nil {
+			return err
+		}
+	}
+	return h.removeStaleInfoFiles(ctx, registry.ID, generated)
+}
+
+func (h *registryHelper) collectGems(ctx context.Context, registryID int64) ([]IndexEntry, error) {
+	var entries []IndexEntry
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := h.artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+		for _, a := range *artifacts {
+			metadata := rubygemsmetadata.RubyGemsMetadata{}
+			if err := json.Unmarshal(a.Metadata, &metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata for artifact %s: %w", a.Name, err)
+			}
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+			files := metadata.GetFiles()
+			if len(files) == 0 {
+				continue
+			}
+			entries = append(entries, IndexEntry{
+				Name:                    metadata.Name,
+				Version:                 metadata.Version,
+				Platform:                metadata.Platform,
+				Dependencies:            metadata.Dependencies,
+				RequiredRubyVersion:     metadata.RequiredRubyVersion,
+				RequiredRubygemsVersion: metadata.RequiredRubygemsVersion,
+				Sha256:                  files[0].Sha256,
+			})
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// removeStaleInfoFiles deletes the info files of gems that no longer have any version.
+func (h *registryHelper) removeStaleInfoFiles(
+	ctx context.Context,
+	registryID int64,
+	generated map[string]bool,
+) error {
+	var stale []string
+	for offset := 0; ; offset += indexPageSize {
+		files, err := h.fileManager.GetFilesMetadata(ctx, IndexPath(InfoPrefix)+"/%", registryID,
+			"name", "ASC", indexPageSize, offset, "")
+		if err != nil {
+			return fmt.Errorf("failed to list index files: %w", err)
+		}
+		for _, f := range *files {
+			if !generated[f.Path] {
+				stale = append(stale, f.Path)
+			}
+		}
+		if len(*files) < indexPageSize {
+			break
+		}
+	}
+	for _, filePath := range stale {
+		if err := h.fileManager.DeleteFile(ctx, registryID, filePath); err != nil {
+			return err
+		}
+		log.Ctx(ctx).Info().Msgf("removed stale rubygems index file %s from registry %d", filePath, registryID)
+	}
+	return nil
+}
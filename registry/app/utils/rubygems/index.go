@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5" //nolint:gosec // the compact index identifies info files by their MD5.
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+)
+
+// IndexEntry is a gem version as listed in the indices.
+type IndexEntry struct {
+	Name                    string
+	Version                 string
+	Platform                string
+	Dependencies            []rubygemsmetadata.Dependency
+	RequiredRubyVersion     []string
+	RequiredRubygemsVersion []string
+	Sha256                  string
+}
+
+func (e IndexEntry) fullVersion() string {
+	return FullVersion(e.Version, e.Platform)
+}
+
+// SortEntries orders entries by name and then by version, oldest first.
+func SortEntries(entries []IndexEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		if c := CompareVersions(entries[i].Version, entries[j].Version); c != 0 {
+			return c < 0
+		}
+		return entries[i].Platform < entries[j].Platform
+	})
+}
+
+// BuildInfo returns the compact index info file of a gem from its versions, which must be sorted.
+func BuildInfo(entries []IndexEntry) []byte {
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	for _, e := range entries {
+		dependencies := make([]string, 0, len(e.Dependencies))
+		for _, d := range e.Dependencies {
+			dependencies = append(dependencies, d.Name+":"+joinRequirements(d.Requirements))
+		}
+		requirements := []string{"checksum:" + e.Sha256}
+		if !isUnconstrained(e.RequiredRubyVersion) {
+			requirements = append(requirements, "ruby:"+joinRequirements(e.RequiredRubyVersion))
+		}
+		if !isUnconstrained(e.RequiredRubygemsVersion) {
+			requirements = append(requirements, "rubygems:"+joinRequirements(e.RequiredRubygemsVersion))
+		}
+		fmt.Fprintf(&b, "%s %s|%s\n", e.fullVersion(), strings.Join(dependencies, ","),
+			strings.Join(requirements, ","))
+	}
+	return b.Bytes()
+}
+
+func joinRequirements(requirements []string) string {
+	if len(requirements) == 0 {
+		return ">= 0"
+	}
+	return strings.Join(requirements, "&")
+}
+
+func isUnconstrained(requirements []string) bool {
+	for _, r := range requirements {
+		if r != ">= 0" {
+			return false
+		}
+	}
+	return true
+}
+
+// VersionsEntry is a line of the compact index versions file.
+type VersionsEntry struct {
+	Name     string
+	Versions []string
+	// Info is the content of the info file of the gem.
+	Info []byte
+}
+
+// BuildVersions returns the compact index versions file.
+func BuildVersions(createdAt time.Time, gems []VersionsEntry) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "created_at: %s\n---\n", createdAt.UTC().Format(time.RFC3339))
+	for _, g := range gems {
+		checksum := md5.Sum(g.Info) //nolint:gosec
+		fmt.Fprintf(&b, "%s %s %s\n", g.Name, strings.Join(g.Versions, ","), hex.EncodeToString(checksum[:]))
+	}
+	return b.Bytes()
+}
+
+// BuildNames returns the compact index names file.
+func BuildNames(names []string) []byte {
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// BuildSpecs returns a gzipped, marshaled specs index of [name, Gem::Version, platform] tuples.
+func BuildSpecs(entries []IndexEntry) ([]byte, error) {
+	specs := make([]any, 0, len(entries))
+	for _, e := range entries {
+		specs = append(specs, []any{
+			e.Name,
+			UserMarshal{Class: "Gem::Version", Value: []any{e.Version}},
+			e.Platform,
+		})
+	}
+	data, err := MarshalRuby(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err = gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err = gz.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// LatestEntries returns the newest release of every gem and platform, as listed by latest_specs.4.8.gz.
+// Entries must be sorted.
+func LatestEntries(entries []IndexEntry) []IndexEntry {
+	latest := make(map[string]int)
+	var result []IndexEntry
+	for _, e := range entries {
+		if IsPrerelease(e.Version) {
+			continue
+		}
+		key := e.Name + "\x00" + e.Platform
+		if i, ok := latest[key]; ok {
+			result[i] = e
+			continue
+		}
+		latest[key] = len(result)
+		result = append(result, e)
+	}
+	return result
+}
+
+// FilterEntries returns the entries that are, or are not, prereleases.
+func FilterEntries(entries []IndexEntry, prerelease bool) []IndexEntry {
+	var result []IndexEntry
+	for _, e := range entries {
+		if IsPrerelease(e.Version) == prerelease {
+			result = append(result, e)
+		}
+	}
+	return result
+}
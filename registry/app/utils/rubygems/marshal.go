@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	marshalMajorVersion = 4
+	marshalMinorVersion = 8
+)
+
+// UserMarshal is a Ruby object serialized through its marshal_dump method, such as Gem::Version.
+type UserMarshal struct {
+	Class string
+	Value any
+}
+
+// MarshalRuby serializes v in the Ruby Marshal 4.8 format. It supports the subset the specs indices need:
+// nil, booleans, integers, UTF-8 strings, arrays and UserMarshal objects.
+func MarshalRuby(v any) ([]byte, error) {
+	e := &marshalEncoder{symbols: make(map[string]int)}
+	e.buf.WriteByte(marshalMajorVersion)
+	e.buf.WriteByte(marshalMinorVersion)
+	if err := e.marshal(v); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+type marshalEncoder struct {
+	buf     bytes.Buffer
+	symbols map[string]int
+}
+
+func (e *marshalEncoder) marshal(v any) error {
+	switch v := v.(type) {
+	case nil:
+		e.buf.WriteByte('0')
+	case bool:
+		if v {
+			e.buf.WriteByte('T')
+		} else {
+			e.buf.WriteByte('F')
+		}
+	case int:
+		e.buf.WriteByte('i')
+		e.writeInt(v)
+	case string:
+		// strings carry their encoding as the instance variable E, true meaning UTF-8.
+		e.buf.WriteByte('I')
+		e.buf.WriteByte('"')
+		e.writeBytes(v)
+		e.writeInt(1)
+		e.writeSymbol("E")
+		e.buf.WriteByte('T')
+	case []any:
+		e.buf.WriteByte('[')
+		e.writeInt(len(v))
+		for _, item := range v {
+			if err := e.marshal(item); err != nil {
+				return err
+			}
+		}
+	case UserMarshal:
+		e.buf.WriteByte('U')
+		e.writeSymbol(v.Class)
+		return e.marshal(v.Value)
+	default:
+		return fmt.Errorf("unsupported type %T", v)
+	}
+	return nil
+}
+
+// writeSymbol writes a symbol, or a link to it if it was written before.
+func (e *marshalEncoder) writeSymbol(s string) {
+	if index, ok := e.symbols[s]; ok {
+		e.buf.WriteByte(';')
+		e.writeInt(index)
+		return
+	}
+	e.symbols[s] = len(e.symbols)
+	e.buf.WriteByte(':')
+	e.writeBytes(s)
+}
+
+func (e *marshalEncoder) writeBytes(s string) {
+	e.writeInt(len(s))
+	e.buf.WriteString(s)
+}
+
+// writeInt writes an integer in the packed format of Ruby's w_long.
+func (e *marshalEncoder) writeInt(n int) {
+	switch {
+	case n == 0:
+		e.buf.WriteByte(0)
+	case n > 0 && n < 123:
+		e.buf.WriteByte(byte(n + 5))
+	case n < 0 && n > -124:
+		e.buf.WriteByte(byte(n - 5))
+	default:
+		var b [4]byte
+		for i := 1; i <= len(b); i++ {
+			b[i-1] = byte(n)
+			n >>= 8
+			if n == 0 {
+				e.buf.WriteByte(byte(i))
+				e.buf.Write(b[:i])
+				return
+			}
+			if n == -1 {
+				e.buf.WriteByte(byte(-i))
+				e.buf.Write(b[:i])
+				return
+			}
+		}
+	}
+}
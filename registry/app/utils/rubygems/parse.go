@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	metadataFileName = "metadata.gz"
+	// maxMetadataSize limits the size of the uncompressed gem specification.
+	maxMetadataSize = 4 << 20
+)
+
+var ErrInvalidGem = errors.New("invalid gem")
+
+// gemSpec is the YAML serialization of a Gem::Specification as found in the metadata.gz of a gem.
+type gemSpec struct {
+	Name                    string            `yaml:"name"`
+	Version                 gemVersion        `yaml:"version"`
+	Platform                string            `yaml:"platform"`
+	Summary                 string            `yaml:"summary"`
+	Description             string            `yaml:"description"`
+	Authors                 []string          `yaml:"authors"`
+	Licenses                []string          `yaml:"licenses"`
+	Homepage                string            `yaml:"homepage"`
+	Metadata                map[string]string `yaml:"metadata"`
+	Dependencies            []gemDependency   `yaml:"dependencies"`
+	RequiredRubyVersion     gemRequirement    `yaml:"required_ruby_version"`
+	RequiredRubygemsVersion gemRequirement    `yaml:"required_rubygems_version"`
+}
+
+type gemVersion struct {
+	Version string `yaml:"version"`
+}
+
+type gemDependency struct {
+	Name        string         `yaml:"name"`
+	Requirement gemRequirement `yaml:"requirement"`
+	Type        string         `yaml:"type"`
+}
+
+type gemRequirement struct {
+	Requirements []gemConstraint `yaml:"requirements"`
+}
+
+// gemConstraint is a serialized [operator, Gem::Version] pair.
+type gemConstraint struct {
+	Operator string
+	Version  string
+}
+
+func (c *gemConstraint) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode || len(node.Content) != 2 {
+		return fmt.Errorf("invalid requirement at line %d", node.Line)
+	}
+	var version gemVersion
+	if err := node.Content[1].Decode(&version); err != nil {
+		return err
+	}
+	c.Operator = node.Content[0].Value
+	c.Version = version.Version
+	return nil
+}
+
+func (r gemRequirement) strings() []string {
+	result := make([]string, 0, len(r.Requirements))
+	for _, c := range r.Requirements {
+		result = append(result, c.Operator+" "+c.Version)
+	}
+	return result
+}
+
+// ParseGem reads the specification of a gem, which is a tar archive with a gzipped YAML specification.
+func ParseGem(r io.Reader) (*rubygemsmetadata.Metadata, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s not found", ErrInvalidGem, metadataFileName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidGem, err)
+		}
+		if hdr.Name == metadataFileName {
+			return parseMetadata(tr)
+		}
+	}
+}
+
+func parseMetadata(r io.Reader) (*rubygemsmetadata.Metadata, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGem, err)
+	}
+	defer gz.Close()
+
+	data, err := io.ReadAll(io.LimitReader(gz, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGem, err)
+	}
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("%w: specification too large", ErrInvalidGem)
+	}
+
+	var spec gemSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse specification: %w", ErrInvalidGem, err)
+	}
+	if spec.Platform == "" {
+		spec.Platform = PlatformRuby
+	}
+	switch {
+	case !IsValidName(spec.Name):
+		return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidGem, spec.Name)
+	case !IsValidVersion(spec.Version.Version):
+		return nil, fmt.Errorf("%w: invalid version %q", ErrInvalidGem, spec.Version.Version)
+	case !IsValidPlatform(spec.Platform):
+		return nil, fmt.Errorf("%w: invalid platform %q", ErrInvalidGem, spec.Platform)
+	}
+
+	m := &rubygemsmetadata.Metadata{
+		Name:                    spec.Name,
+		Version:                 spec.Version.Version,
+		Platform:                spec.Platform,
+		Summary:                 spec.Summary,
+		Description:             spec.Description,
+		Authors:                 spec.Authors,
+		Licenses:                spec.Licenses,
+		Homepage:                spec.Homepage,
+		Metadata:                spec.Metadata,
+		RequiredRubyVersion:     spec.RequiredRubyVersion.strings(),
+		RequiredRubygemsVersion: spec.RequiredRubygemsVersion.strings(),
+	}
+	for _, d := range spec.Dependencies {
+		dependency := rubygemsmetadata.Dependency{Name: d.Name, Requirements: d.Requirement.strings()}
+		if strings.TrimPrefix(d.Type, ":") == "development" {
+			m.DevelopmentDependencies = append(m.DevelopmentDependencies, dependency)
+		} else {
+			m.Dependencies = append(m.Dependencies, dependency)
+		}
+	}
+	return m, nil
+}
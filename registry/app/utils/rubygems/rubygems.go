@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// GemsPrefix is the node path prefix of gem files and also the path clients download them from.
+	GemsPrefix = "gems"
+	// IndexPrefix is the node path prefix of the generated compact index and specs files.
+	IndexPrefix = "index"
+
+	GemFileExtension = ".gem"
+	PlatformRuby     = "ruby"
+
+	VersionsFile        = "versions"
+	NamesFile           = "names"
+	InfoPrefix          = "info"
+	SpecsFile           = "specs.4.8.gz"
+	LatestSpecsFile     = "latest_specs.4.8.gz"
+	PrereleaseSpecsFile = "prerelease_specs.4.8.gz"
+)
+
+var (
+	namePattern     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	versionPattern  = regexp.MustCompile(`^[0-9]+(\.[0-9A-Za-z]+)*$`)
+	platformPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	segmentPattern  = regexp.MustCompile(`[0-9]+|[A-Za-z]+`)
+)
+
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsValidVersion validates a gem version. Versions with a "-" are rejected as they cannot be told apart from
+// the platform in file names.
+func IsValidVersion(version string) bool {
+	return versionPattern.MatchString(version)
+}
+
+func IsValidPlatform(platform string) bool {
+	return platformPattern.MatchString(platform)
+}
+
+// IsPrerelease reports whether a version is a prerelease, which in RubyGems means it contains a letter.
+func IsPrerelease(version string) bool {
+	return strings.IndexFunc(version, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	}) >= 0
+}
+
+// FullVersion returns the artifact version of a gem: its version, followed by its platform unless the gem is
+// platform independent, e.g. 1.15.5 or 1.15.5-x86_64-linux.
+func FullVersion(version, platform string) string {
+	if platform == "" || platform == PlatformRuby {
+		return version
+	}
+	return version + "-" + platform
+}
+
+// SplitFullVersion splits an artifact version into the gem version and platform.
+func SplitFullVersion(fullVersion string) (version string, platform string) {
+	version, platform, ok := strings.Cut(fullVersion, "-")
+	if !ok {
+		return fullVersion, PlatformRuby
+	}
+	return version, platform
+}
+
+// FilePath returns the storage path of the versions of a gem, or of the files of a single version.
+func FilePath(name, fullVersion string) string {
+	filePath := "/" + GemsPrefix + "/" + name
+	if fullVersion != "" {
+		filePath += "/" + fullVersion
+	}
+	return filePath
+}
+
+func FileName(name, fullVersion string) string {
+	return name + "-" + fullVersion + GemFileExtension
+}
+
+// ParseFileName returns the name and artifact version of a gem file name. Names may contain "-", so the
+// name ends at the first "-" that is followed by a valid version.
+func ParseFileName(fileName string) (name string, fullVersion string, ok bool) {
+	base, found := strings.CutSuffix(fileName, GemFileExtension)
+	if !found {
+		return "", "", false
+	}
+	for i := strings.Index(base, "-"); i >= 0; {
+		name, fullVersion = base[:i], base[i+1:]
+		version, platform := SplitFullVersion(fullVersion)
+		if IsValidName(name) && IsValidVersion(version) && IsValidPlatform(platform) {
+			return name, fullVersion, true
+		}
+		next := strings.Index(base[i+1:], "-")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return "", "", false
+}
+
+// CompareVersions compares two gem versions the way Gem::Version does: numeric segments are compared as
+// numbers, missing segments count as 0 and letter segments sort before numeric ones.
+func CompareVersions(a, b string) int {
+	sa := segmentPattern.FindAllString(a, -1)
+	sb := segmentPattern.FindAllString(b, -1)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		x, y := "0", "0"
+		if i < len(sa) {
+			x = sa[i]
+		}
+		if i < len(sb) {
+			y = sb[i]
+		}
+		if c := compareSegments(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareSegments(x, y string) int {
+	nx, errx := strconv.ParseUint(x, 10, 64)
+	ny, erry := strconv.ParseUint(y, 10, 64)
+	switch {
+	case errx == nil && erry == nil:
+		if nx == ny {
+			return 0
+		}
+		if nx < ny {
+			return -1
+		}
+		return 1
+	case errx == nil:
+		return 1
+	case erry == nil:
+		return -1
+	default:
+		return strings.Compare(x, y)
+	}
+}
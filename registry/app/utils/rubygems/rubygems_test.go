@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `--- !ruby/object:Gem::Specification
+name: example
+version: !ruby/object:Gem::Version
+  version: 1.2.0
+platform: ruby
+authors:
+- Jane Doe
+date: 2024-01-01 00:00:00.000000000 Z
+dependencies:
+- !ruby/object:Gem::Dependency
+  name: rack
+  requirement: !ruby/object:Gem::Requirement
+    requirements:
+    - - ">="
+      - !ruby/object:Gem::Version
+        version: '2.0'
+    - - "<"
+      - !ruby/object:Gem::Version
+        version: '4'
+  type: :runtime
+  prerelease: false
+- !ruby/object:Gem::Dependency
+  name: rspec
+  requirement: !ruby/object:Gem::Requirement
+    requirements:
+    - - "~>"
+      - !ruby/object:Gem::Version
+        version: '3.12'
+  type: :development
+  prerelease: false
+required_ruby_version: !ruby/object:Gem::Requirement
+  requirements:
+  - - ">="
+    - !ruby/object:Gem::Version
+      version: 3.0.0
+required_rubygems_version: !ruby/object:Gem::Requirement
+  requirements:
+  - - ">="
+    - !ruby/object:Gem::Version
+      version: '0'
+summary: An example gem
+licenses:
+- MIT
+homepage: https://example.com
+metadata:
+  source_code_uri: https://example.com/src
+`
+
+func buildGem(t *testing.T, spec string) []byte {
+	t.Helper()
+
+	var metadata bytes.Buffer
+	gz := gzip.NewWriter(&metadata)
+	_, err := gz.Write([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	var gem bytes.Buffer
+	tw := tar.NewWriter(&gem)
+	for name, content := range map[string][]byte{"data.tar.gz": {}, "metadata.gz": metadata.Bytes()} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o444, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return gem.Bytes()
+}
+
+func TestParseGem(t *testing.T) {
+	m, err := ParseGem(bytes.NewReader(buildGem(t, testSpec)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "example", m.Name)
+	assert.Equal(t, "1.2.0", m.Version)
+	assert.Equal(t, PlatformRuby, m.Platform)
+	assert.Equal(t, "An example gem", m.Summary)
+	assert.Equal(t, []string{"Jane Doe"}, m.Authors)
+	assert.Equal(t, []string{"MIT"}, m.Licenses)
+	assert.Equal(t, "https://example.com/src", m.Metadata["source_code_uri"])
+	assert.Equal(t, []rubygemsmetadata.Dependency{{Name: "rack", Requirements: []string{">= 2.0", "< 4"}}},
+		m.Dependencies)
+	assert.Equal(t, []rubygemsmetadata.Dependency{{Name: "rspec", Requirements: []string{"~> 3.12"}}},
+		m.DevelopmentDependencies)
+	assert.Equal(t, []string{">= 3.0.0"}, m.RequiredRubyVersion)
+	assert.Equal(t, []string{">= 0"}, m.RequiredRubygemsVersion)
+}
+
+func TestParseGemInvalid(t *testing.T) {
+	_, err := ParseGem(bytes.NewReader([]byte("not a gem")))
+	assert.ErrorIs(t, err, ErrInvalidGem)
+
+	_, err = ParseGem(bytes.NewReader(buildGem(t, "name: ../evil\nversion:\n  version: 1.0\n")))
+	assert.ErrorIs(t, err, ErrInvalidGem)
+}
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName    string
+		name        string
+		fullVersion string
+		ok          bool
+	}{
+		{"rails-7.1.2.gem", "rails", "7.1.2", true},
+		{"net-http-persistent-4.0.2.gem", "net-http-persistent", "4.0.2", true},
+		{"nokogiri-1.15.5-x86_64-linux.gem", "nokogiri", "1.15.5-x86_64-linux", true},
+		{"rack-3.0.0.beta1.gem", "rack", "3.0.0.beta1", true},
+		{"rails.gem", "", "", false},
+		{"rails-7.1.2.tar.gz", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			name, fullVersion, ok := ParseFileName(tt.fileName)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.fullVersion, fullVersion)
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("1.0", "1.0.0"))
+	assert.Equal(t, -1, CompareVersions("1.9", "1.10"))
+	assert.Equal(t, -1, CompareVersions("2.0.0.rc1", "2.0.0"))
+	assert.Equal(t, -1, CompareVersions("2.0.0.beta", "2.0.0.rc1"))
+	assert.Equal(t, 1, CompareVersions("2.0.1", "2.0.0"))
+}
+
+func TestMarshalRuby(t *testing.T) {
+	data, err := MarshalRuby([]any{
+		[]any{"a", UserMarshal{Class: "Gem::Version", Value: []any{"1.0"}}, "ruby"},
+	})
+	require.NoError(t, err)
+
+	// Marshal.dump([["a", Gem::Version.new("1.0"), "ruby"]])
+	expected := []byte("\x04\x08[\x06[\x08I\"\x06a\x06:\x06ETU:\x11Gem::Version[\x06I\"\x081.0\x06;\x00T" +
+		"I\"\x09ruby\x06;\x00T")
+	assert.Equal(t, expected, data)
+}
+
+func TestBuildCompactIndex(t *testing.T) {
+	entries := []IndexEntry{
+		{Name: "example", Version: "1.2.0", Platform: PlatformRuby, Sha256: "bbb",
+			Dependencies:        []rubygemsmetadata.Dependency{{Name: "rack", Requirements: []string{">= 2.0", "< 4"}}},
+			RequiredRubyVersion: []string{">= 3.0.0"}, RequiredRubygemsVersion: []string{">= 0"}},
+		{Name: "example", Version: "1.0.0", Platform: PlatformRuby, Sha256: "aaa"},
+		{Name: "example", Version: "2.0.0.rc1", Platform: "java", Sha256: "ccc"},
+	}
+	SortEntries(entries)
+
+	info := BuildInfo(entries)
+	assert.Equal(t, "---\n"+
+		"1.0.0 |checksum:aaa\n"+
+		"1.2.0 rack:>= 2.0&< 4|checksum:bbb,ruby:>= 3.0.0\n"+
+		"2.0.0.rc1-java |checksum:ccc\n", string(info))
+
+	versions := BuildVersions(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []VersionsEntry{
+		{Name: "example", Versions: []string{"1.0.0", "1.2.0", "2.0.0.rc1-java"}, Info: []byte("---\n")},
+	})
+	assert.Equal(t, "created_at: 2024-01-01T00:00:00Z\n---\n"+
+		"example 1.0.0,1.2.0,2.0.0.rc1-java 6105347ebb9825ac754615ca55ff3b0c\n", string(versions))
+
+	assert.Equal(t, "---\nexample\n", string(BuildNames([]string{"example"})))
+
+	latest := LatestEntries(entries)
+	require.Len(t, latest, 1)
+	assert.Equal(t, "1.2.0", latest[0].Version)
+	assert.Len(t, FilterEntries(entries, true), 1)
+
+	specs, err := BuildSpecs(latest)
+	require.NoError(t, err)
+	gz, err := gzip.NewReader(bytes.NewReader(specs))
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("\x04\x08[\x06[\x08I\"\x0cexample\x06:\x06ETU:\x11Gem::Version[\x06I\"\x0a1.2.0\x06;\x00T"+
+		"I\"\x09ruby\x06;\x00T"), data)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+)
+
+const keyEmail = "registry@harness.io"
+
+// KeyStore manages the OpenPGP keys registries sign their metadata with.
+type KeyStore interface {
+	// GetOrCreateKey returns the ASCII armored private and public key of a registry, generating the key pair
+	// on first use.
+	GetOrCreateKey(ctx context.Context, registry *types.Registry) (privateKey string, publicKey string, err error)
+}
+
+type keyStore struct {
+	signingKeyDao store.SigningKeyRepository
+	encrypter     encrypt.Encrypter
+}
+
+func NewKeyStore(signingKeyDao store.SigningKeyRepository, encrypter encrypt.Encrypter) KeyStore {
+	return &keyStore{
+		signingKeyDao: signingKeyDao,
+		encrypter:     encrypter,
+	}
+}
+
+func (s *keyStore) GetOrCreateKey(
+	ctx context.Context,
+	registry *types.Registry,
+) (privateKey string, publicKey string, err error) {
+	key, err := s.signingKeyDao.GetByRegistryID(ctx, registry.ID)
+	if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return "", "", fmt.Errorf("failed to find signing key: %w", err)
+	}
+	if key == nil {
+		privateKey, publicKey, err = GenerateKey(registry.Name, keyEmail)
+		if err != nil {
+			return "", "", err
+		}
+		encrypted, err := s.encrypter.Encrypt(privateKey)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to encrypt signing key: %w", err)
+		}
+		key = &types.SigningKey{
+			RegistryID: registry.ID,
+			PrivateKey: encrypted,
+			PublicKey:  publicKey,
+		}
+		err = s.signingKeyDao.Create(ctx, key)
+		if err == nil {
+			return privateKey, publicKey, nil
+		}
+		if !errors.Is(err, gitnessstore.ErrDuplicate) {
+			return "", "", fmt.Errorf("failed to store signing key: %w", err)
+		}
+		// another request created the key concurrently, use that one.
+		key, err = s.signingKeyDao.GetByRegistryID(ctx, registry.ID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find signing key: %w", err)
+		}
+	}
+	privateKey, err = s.encrypter.Decrypt(key.PrivateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt signing key: %w", err)
+	}
+	return privateKey, key.PublicKey, nil
+}
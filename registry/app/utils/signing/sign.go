@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+const signingKeyBits = 3072
+
+var signingConfig = &packet.Config{
+	Algorithm:     packet.PubKeyAlgoRSA,
+	RSABits:       signingKeyBits,
+	DefaultHash:   crypto.SHA256,
+	DefaultCipher: packet.CipherAES256,
+}
+
+// GenerateKey creates a new OpenPGP key pair and returns both keys ASCII armored.
+func GenerateKey(name, email string) (privateKey string, publicKey string, err error) {
+	entity, err := openpgp.NewEntity(name, "", email, signingConfig)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	var priv bytes.Buffer
+	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err = entity.SerializePrivate(w, signingConfig); err != nil {
+		return "", "", fmt.Errorf("failed to serialize private key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", "", err
+	}
+
+	var pub bytes.Buffer
+	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err = entity.Serialize(w); err != nil {
+		return "", "", fmt.Errorf("failed to serialize public key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", "", err
+	}
+	return priv.String(), pub.String(), nil
+}
+
+// ClearSign returns data wrapped in a clear-signed message, e.g. a Debian InRelease file.
+func ClearSign(privateKey string, data []byte) ([]byte, error) {
+	entity, err := readSigningEntity(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	w, err := clearsign.Encode(&b, entity.PrivateKey, signingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear-sign: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ArmoredDetachSign returns an ASCII armored detached signature of data, e.g. a Debian Release.gpg file.
+func ArmoredDetachSign(privateKey string, data []byte) ([]byte, error) {
+	entity, err := readSigningEntity(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err = openpgp.ArmoredDetachSign(&b, entity, bytes.NewReader(data), signingConfig); err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// DetachSign returns a binary detached signature of data, e.g. a Terraform provider SHA256SUMS.sig file.
+func DetachSign(privateKey string, data []byte) ([]byte, error) {
+	entity, err := readSigningEntity(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err = openpgp.DetachSign(&b, entity, bytes.NewReader(data), signingConfig); err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// KeyID returns the upper case hex ID of the primary key of an ASCII armored public key.
+func KeyID(publicKey string) (string, error) {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to read public key: %w", err)
+	}
+	if len(keyRing) == 0 {
+		return "", fmt.Errorf("public key is empty")
+	}
+	return keyRing[0].PrimaryKey.KeyIdString(), nil
+}
+
+func readSigningEntity(privateKey string) (*openpgp.Entity, error) {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	if len(keyRing) == 0 || keyRing[0].PrivateKey == nil {
+		return nil, fmt.Errorf("signing key has no private key")
+	}
+	return keyRing[0], nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigning(t *testing.T) {
+	privateKey, publicKey, err := GenerateKey("debs", "registry@example.com")
+	require.NoError(t, err)
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	require.NoError(t, err)
+
+	data := []byte("Suite: stable\nSHA256:\n")
+
+	inRelease, err := ClearSign(privateKey, data)
+	require.NoError(t, err)
+	block, _ := clearsign.Decode(inRelease)
+	require.NotNil(t, block)
+	assert.Equal(t, data, block.Plaintext)
+	_, err = block.VerifySignature(keyRing, nil)
+	assert.NoError(t, err)
+
+	armored, err := ArmoredDetachSign(privateKey, data)
+	require.NoError(t, err)
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(armored), nil)
+	assert.NoError(t, err)
+
+	signature, err := DetachSign(privateKey, data)
+	require.NoError(t, err)
+	_, err = openpgp.CheckDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	assert.NoError(t, err)
+
+	keyID, err := KeyID(publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, keyRing[0].PrimaryKey.KeyIdString(), keyID)
+	assert.Len(t, keyID, 16)
+}
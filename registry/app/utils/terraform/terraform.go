@@ -0,0 +1,227 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	ModulesPrefix   = "modules"
+	ProvidersPrefix = "providers"
+
+	ModuleFileExtension   = ".tar.gz"
+	ProviderFileExtension = ".zip"
+)
+
+// DefaultProtocols are the plugin protocol versions assumed for providers uploaded without explicit protocols.
+var DefaultProtocols = []string{"5.0"}
+
+var (
+	ErrInvalidArchive = errors.New("invalid archive")
+
+	namespacePattern = regexp.MustCompile(`^[0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?$`)
+	systemPattern    = regexp.MustCompile(`^[0-9a-z]{1,64}$`)
+	typePattern      = regexp.MustCompile(`^[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?$`)
+	platformPattern  = regexp.MustCompile(`^[0-9a-z]{1,32}$`)
+	protocolPattern  = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+	zipMagic         = []byte("PK\x03\x04")
+)
+
+// IsValidNamespace validates a module or provider namespace, which is also used for module names.
+func IsValidNamespace(namespace string) bool {
+	return namespacePattern.MatchString(namespace)
+}
+
+// IsValidSystem validates the target system of a module, e.g. aws.
+func IsValidSystem(system string) bool {
+	return systemPattern.MatchString(system)
+}
+
+// IsValidType validates a provider type, e.g. aws or google-beta.
+func IsValidType(providerType string) bool {
+	return typePattern.MatchString(providerType)
+}
+
+// IsValidPlatform validates the os and arch of a provider package.
+func IsValidPlatform(os, arch string) bool {
+	return platformPattern.MatchString(os) && platformPattern.MatchString(arch)
+}
+
+// IsValidVersion reports whether version is a semantic version without a "v" prefix, as Terraform requires.
+func IsValidVersion(version string) bool {
+	_, err := semver.StrictNewVersion(version)
+	return err == nil
+}
+
+// ParseProtocols parses a comma separated list of plugin protocol versions, defaulting to DefaultProtocols.
+func ParseProtocols(protocols string) ([]string, error) {
+	if strings.TrimSpace(protocols) == "" {
+		return DefaultProtocols, nil
+	}
+	var result []string
+	for _, p := range strings.Split(protocols, ",") {
+		p = strings.TrimSpace(p)
+		if !protocolPattern.MatchString(p) {
+			return nil, fmt.Errorf("invalid protocol version %q", p)
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func ModuleImage(namespace, name, system string) string {
+	return namespace + "/" + name + "/" + system
+}
+
+func ProviderImage(namespace, providerType string) string {
+	return namespace + "/" + providerType
+}
+
+// PathPrefix returns the storage prefix of an image. Modules are named <namespace>/<name>/<system> and
+// providers <namespace>/<type>.
+func PathPrefix(image string) string {
+	if strings.Count(image, "/") == 2 {
+		return "/" + ModulesPrefix + "/" + image
+	}
+	return "/" + ProvidersPrefix + "/" + image
+}
+
+// FilePath returns the storage path of the files of an image version.
+func FilePath(image, version string) string {
+	filePath := PathPrefix(image)
+	if version != "" {
+		filePath += "/" + version
+	}
+	return filePath
+}
+
+func ModuleFileName(namespace, name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s-%s%s", namespace, name, system, version, ModuleFileExtension)
+}
+
+func ProviderFileName(providerType, version, os, arch string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s%s", providerType, version, os, arch, ProviderFileExtension)
+}
+
+func ShasumsFileName(providerType, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_SHA256SUMS", providerType, version)
+}
+
+func ShasumsSignatureFileName(providerType, version string) string {
+	return ShasumsFileName(providerType, version) + ".sig"
+}
+
+// ParseProviderFileName returns the platform of a provider package file name.
+func ParseProviderFileName(providerType, version, fileName string) (os string, arch string, ok bool) {
+	prefix := fmt.Sprintf("terraform-provider-%s_%s_", providerType, version)
+	if !strings.HasPrefix(fileName, prefix) || !strings.HasSuffix(fileName, ProviderFileExtension) {
+		return "", "", false
+	}
+	platform := strings.TrimSuffix(strings.TrimPrefix(fileName, prefix), ProviderFileExtension)
+	os, arch, ok = strings.Cut(platform, "_")
+	if !ok || !IsValidPlatform(os, arch) {
+		return "", "", false
+	}
+	return os, arch, true
+}
+
+// IsMainArtifactFile reports whether fileName is a module archive or a provider package, as opposed to the
+// checksums of a provider version.
+func IsMainArtifactFile(fileName string) bool {
+	return strings.HasSuffix(fileName, ModuleFileExtension) || strings.HasSuffix(fileName, ProviderFileExtension)
+}
+
+// BuildShasums returns the content of a SHA256SUMS file for the given file name to sha256 mapping.
+func BuildShasums(checksums map[string]string) []byte {
+	fileNames := make([]string, 0, len(checksums))
+	for fileName := range checksums {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	var b bytes.Buffer
+	for _, fileName := range fileNames {
+		fmt.Fprintf(&b, "%s  %s\n", checksums[fileName], fileName)
+	}
+	return b.Bytes()
+}
+
+// ValidateModuleArchive checks that r is a gzipped tarball with at least one file and no entries that would
+// be extracted outside of the module directory.
+func ValidateModuleArchive(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	files := 0
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		if escapesDirectory(hdr.Name) {
+			return fmt.Errorf("%w: entry %q escapes the module directory", ErrInvalidArchive, hdr.Name)
+		}
+		// hard link targets are relative to the archive root, symbolic link targets to the link itself.
+		if (hdr.Typeflag == tar.TypeLink && escapesDirectory(hdr.Linkname)) ||
+			(hdr.Typeflag == tar.TypeSymlink && (path.IsAbs(hdr.Linkname) ||
+				escapesDirectory(path.Join(path.Dir(hdr.Name), hdr.Linkname)))) {
+			return fmt.Errorf("%w: link %q escapes the module directory", ErrInvalidArchive, hdr.Name)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			files++
+		}
+	}
+	if files == 0 {
+		return fmt.Errorf("%w: archive contains no files", ErrInvalidArchive)
+	}
+	return nil
+}
+
+func escapesDirectory(name string) bool {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) {
+		return true
+	}
+	name = path.Clean(name)
+	return name == ".." || strings.HasPrefix(name, "../")
+}
+
+// ValidateProviderArchive checks that r starts with a zip file header.
+func ValidateProviderArchive(r io.Reader) error {
+	header := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header, zipMagic) {
+		return fmt.Errorf("%w: provider package must be a zip archive", ErrInvalidArchive)
+	}
+	return nil
+}
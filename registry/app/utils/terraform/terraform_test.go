@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func buildModule(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: e.typeflag, Linkname: e.linkname,
+		}))
+		_, err := tw.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return b.Bytes()
+}
+
+func TestValidateModuleArchive(t *testing.T) {
+	mainTF := tarEntry{name: "main.tf", typeflag: tar.TypeReg, content: "variable \"name\" {}\n"}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr bool
+	}{
+		{
+			name:    "valid module",
+			archive: buildModule(t, tarEntry{name: "modules/", typeflag: tar.TypeDir}, mainTF),
+		},
+		{
+			name: "symbolic link inside the module",
+			archive: buildModule(t, mainTF,
+				tarEntry{name: "modules/main.tf", typeflag: tar.TypeSymlink, linkname: "../main.tf"}),
+		},
+		{
+			name:    "no files",
+			archive: buildModule(t, tarEntry{name: "modules/", typeflag: tar.TypeDir}),
+			wantErr: true,
+		},
+		{
+			name: "path traversal",
+			archive: buildModule(t, mainTF,
+				tarEntry{name: "../outside.tf", typeflag: tar.TypeReg, content: "x"}),
+			wantErr: true,
+		},
+		{
+			name: "symbolic link outside the module",
+			archive: buildModule(t, mainTF,
+				tarEntry{name: "passwd", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}),
+			wantErr: true,
+		},
+		{
+			name:    "not gzipped",
+			archive: []byte("main.tf"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateModuleArchive(bytes.NewReader(tt.archive))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidArchive)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateProviderArchive(t *testing.T) {
+	assert.NoError(t, ValidateProviderArchive(bytes.NewReader([]byte("PK\x03\x04rest"))))
+	assert.ErrorIs(t, ValidateProviderArchive(bytes.NewReader([]byte("PK"))), ErrInvalidArchive)
+	assert.ErrorIs(t, ValidateProviderArchive(bytes.NewReader([]byte("\x1f\x8b\x08\x00"))), ErrInvalidArchive)
+}
+
+func TestProviderFileName(t *testing.T) {
+	fileName := ProviderFileName("google-beta", "5.1.0", "linux", "amd64")
+	assert.Equal(t, "terraform-provider-google-beta_5.1.0_linux_amd64.zip", fileName)
+
+	os, arch, ok := ParseProviderFileName("google-beta", "5.1.0", fileName)
+	assert.True(t, ok)
+	assert.Equal(t, "linux", os)
+	assert.Equal(t, "amd64", arch)
+
+	_, _, ok = ParseProviderFileName("google", "5.1.0", fileName)
+	assert.False(t, ok)
+	_, _, ok = ParseProviderFileName("google-beta", "5.1.0", ShasumsFileName("google-beta", "5.1.0"))
+	assert.False(t, ok)
+}
+
+func TestPathPrefix(t *testing.T) {
+	assert.Equal(t, "/modules/hashicorp/consul/aws/0.1.0", FilePath(ModuleImage("hashicorp", "consul", "aws"),
+		"0.1.0"))
+	assert.Equal(t, "/providers/hashicorp/aws", FilePath(ProviderImage("hashicorp", "aws"), ""))
+}
+
+func TestBuildShasums(t *testing.T) {
+	shasums := BuildShasums(map[string]string{
+		"terraform-provider-aws_1.0.0_linux_amd64.zip":  "bbb",
+		"terraform-provider-aws_1.0.0_darwin_arm64.zip": "aaa",
+	})
+	assert.Equal(t, "aaa  terraform-provider-aws_1.0.0_darwin_arm64.zip\n"+
+		"bbb  terraform-provider-aws_1.0.0_linux_amd64.zip\n", string(shasums))
+}
+
+func TestValidation(t *testing.T) {
+	assert.True(t, IsValidVersion("1.2.3"))
+	assert.True(t, IsValidVersion("1.2.3-beta.1"))
+	assert.False(t, IsValidVersion("v1.2.3"))
+	assert.False(t, IsValidVersion("1.2"))
+
+	assert.True(t, IsValidNamespace("hashicorp"))
+	assert.False(t, IsValidNamespace("-hashicorp"))
+	assert.True(t, IsValidType("google-beta"))
+	assert.False(t, IsValidType("Google"))
+	assert.True(t, IsValidSystem("aws"))
+	assert.False(t, IsValidSystem("aws-east"))
+
+	protocols, err := ParseProtocols("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProtocols, protocols)
+	protocols, err = ParseProtocols("5.0, 6.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+	_, err = ParseProtocols("5")
+	assert.Error(t, err)
+}
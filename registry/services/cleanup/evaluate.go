@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// ValidatePatterns verifies that all patterns are well-formed regular expressions.
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("pattern can't be empty")
+		}
+		if _, err := compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the versions that are deleted by the policies, in the order of the versions.
+// Versions are expected to be grouped by package. Within a package the versions selected by a policy
+// are ordered newest first, the KeepLast newest of them are kept and the rest is deleted if it wasn't
+// updated within the expiry time of the policy. A policy without KeepLast and ExpiryTime deletes nothing.
+// A version selected by multiple policies is reported once, for the first policy that deletes it.
+func Evaluate(
+	policies []types.CleanupPolicy,
+	versions []types.PackageVersion,
+	now time.Time,
+) ([]types.CleanupCandidate, error) {
+	matched := make(map[int64]types.CleanupCandidate)
+	for i := range policies {
+		policy := &policies[i]
+		if policy.KeepLast <= 0 && policy.ExpiryTime <= 0 {
+			continue
+		}
+
+		m, err := newMatcher(policy)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, selected := range groupByPackage(versions, m) {
+			sort.SliceStable(selected, func(a, b int) bool {
+				return selected[a].CreatedAt.After(selected[b].CreatedAt)
+			})
+
+			for pos, version := range selected {
+				if pos < policy.KeepLast {
+					continue
+				}
+				if _, ok := matched[version.ArtifactID]; ok {
+					continue
+				}
+				age := now.Sub(version.UpdatedAt)
+				if policy.ExpiryTime > 0 && age.Milliseconds() <= policy.ExpiryTime {
+					continue
+				}
+				matched[version.ArtifactID] = types.CleanupCandidate{
+					ArtifactID:  version.ArtifactID,
+					Package:     version.Package,
+					Version:     version.Version,
+					Policy:      policy.Name,
+					Reason:      reason(policy),
+					LastUpdated: version.UpdatedAt,
+				}
+			}
+		}
+	}
+
+	candidates := make([]types.CleanupCandidate, 0, len(matched))
+	for _, version := range versions {
+		if candidate, ok := matched[version.ArtifactID]; ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// groupByPackage returns the versions selected by the matcher, grouped by package.
+func groupByPackage(versions []types.PackageVersion, m *matcher) [][]types.PackageVersion {
+	var groups [][]types.PackageVersion
+	index := make(map[string]int)
+	for _, version := range versions {
+		if !m.matches(version.Package, version.Version) {
+			continue
+		}
+		i, ok := index[version.Package]
+		if !ok {
+			i = len(groups)
+			index[version.Package] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], version)
+	}
+	return groups
+}
+
+func reason(policy *types.CleanupPolicy) string {
+	var reasons []string
+	if policy.KeepLast > 0 {
+		reasons = append(reasons, fmt.Sprintf("not among the %d most recent versions", policy.KeepLast))
+	}
+	if policy.ExpiryTime > 0 {
+		days := time.Duration(policy.ExpiryTime) * time.Millisecond / (24 * time.Hour)
+		reasons = append(reasons, fmt.Sprintf("not updated in the last %d days", days))
+	}
+	return strings.Join(reasons, " and ")
+}
+
+type matcher struct {
+	packagePrefix  []string
+	versionPrefix  []string
+	versionPattern []*regexp.Regexp
+	excludePattern []*regexp.Regexp
+}
+
+func newMatcher(policy *types.CleanupPolicy) (*matcher, error) {
+	versionPattern, err := compileAll(policy.VersionPattern)
+	if err != nil {
+		return nil, fmt.Errorf("cleanup policy %q: %w", policy.Name, err)
+	}
+	excludePattern, err := compileAll(policy.ExcludePattern)
+	if err != nil {
+		return nil, fmt.Errorf("cleanup policy %q: %w", policy.Name, err)
+	}
+	return &matcher{
+		packagePrefix:  policy.PackagePrefix,
+		versionPrefix:  policy.VersionPrefix,
+		versionPattern: versionPattern,
+		excludePattern: excludePattern,
+	}, nil
+}
+
+// matches returns true if the version is selected by the policy. Exclude patterns are matched against
+// both the package name and the version and take precedence over all other rules.
+func (m *matcher) matches(pkg, version string) bool {
+	for _, re := range m.excludePattern {
+		if re.MatchString(pkg) || re.MatchString(version) {
+			return false
+		}
+	}
+	if len(m.packagePrefix) > 0 && !hasAnyPrefix(pkg, m.packagePrefix) {
+		return false
+	}
+	if len(m.versionPrefix) > 0 && !hasAnyPrefix(version, m.versionPrefix) {
+		return false
+	}
+	if len(m.versionPattern) == 0 {
+		return true
+	}
+	for _, re := range m.versionPattern {
+		if re.MatchString(version) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compile compiles the pattern so that it has to match the whole subject.
+func compile(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+func compileAll(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		res[i] = re
+	}
+	return res, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	version := func(id int64, pkg, v string, age time.Duration) types.PackageVersion {
+		return types.PackageVersion{
+			ArtifactID: id, Package: pkg, Version: v,
+			CreatedAt: now.Add(-age), UpdatedAt: now.Add(-age),
+		}
+	}
+	versions := []types.PackageVersion{
+		version(1, "app", "1.0.3", 1*day),
+		version(2, "app", "1.0.2", 10*day),
+		version(3, "app", "1.0.1", 20*day),
+		version(4, "app", "1.0.0-rc1", 30*day),
+		version(5, "lib", "2.0.0", 40*day),
+		version(6, "lib", "release-1", 50*day),
+	}
+	days := func(n int) int64 { return (time.Duration(n) * day).Milliseconds() }
+
+	tests := []struct {
+		name   string
+		policy types.CleanupPolicy
+		want   []int64
+	}{
+		{name: "no limits", policy: types.CleanupPolicy{}, want: nil},
+		{name: "keep last", policy: types.CleanupPolicy{KeepLast: 2}, want: []int64{3, 4}},
+		{name: "older than", policy: types.CleanupPolicy{ExpiryTime: days(15)}, want: []int64{3, 4, 5, 6}},
+		{
+			name:   "keep last and older than",
+			policy: types.CleanupPolicy{KeepLast: 3, ExpiryTime: days(15)},
+			want:   []int64{4},
+		},
+		{
+			name:   "package prefix",
+			policy: types.CleanupPolicy{PackagePrefix: []string{"li"}, ExpiryTime: days(1)},
+			want:   []int64{5, 6},
+		},
+		{
+			name:   "version prefix",
+			policy: types.CleanupPolicy{VersionPrefix: []string{"1.0."}, KeepLast: 3},
+			want:   []int64{4},
+		},
+		{
+			name:   "version pattern matches whole version",
+			policy: types.CleanupPolicy{VersionPattern: []string{`\d+\.\d+\.\d+`}, ExpiryTime: days(5)},
+			want:   []int64{2, 3, 5},
+		},
+		{
+			name:   "keep last counts selected versions only",
+			policy: types.CleanupPolicy{VersionPattern: []string{`.*-rc\d+`}, KeepLast: 1},
+			want:   nil,
+		},
+		{
+			name:   "exclude version",
+			policy: types.CleanupPolicy{ExcludePattern: []string{"release-.*"}, ExpiryTime: days(15)},
+			want:   []int64{3, 4, 5},
+		},
+		{
+			name:   "exclude package",
+			policy: types.CleanupPolicy{ExcludePattern: []string{"app"}, ExpiryTime: days(15)},
+			want:   []int64{5, 6},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			candidates, err := Evaluate([]types.CleanupPolicy{test.policy}, versions, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []int64
+			for _, candidate := range candidates {
+				got = append(got, candidate.ArtifactID)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Evaluate() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateMultiplePolicies(t *testing.T) {
+	now := time.Now()
+	versions := []types.PackageVersion{
+		{ArtifactID: 1, Package: "app", Version: "2", CreatedAt: now, UpdatedAt: now},
+		{ArtifactID: 2, Package: "app", Version: "1", CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)},
+	}
+	policies := []types.CleanupPolicy{
+		{Name: "first", KeepLast: 1},
+		{Name: "second", KeepLast: 0, ExpiryTime: time.Minute.Milliseconds()},
+	}
+
+	candidates, err := Evaluate(policies, versions, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].ArtifactID != 2 || candidates[0].Policy != "first" {
+		t.Errorf("expected version 1 to be deleted once by the first policy, got %+v", candidates)
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if err := ValidatePatterns([]string{`v\d+`, "release-.*"}); err != nil {
+		t.Errorf("expected patterns to be valid, got %v", err)
+	}
+	if err := ValidatePatterns([]string{"v(["}); err == nil {
+		t.Error("expected malformed pattern to be rejected")
+	}
+	if err := ValidatePatterns([]string{" "}); err == nil {
+		t.Error("expected empty pattern to be rejected")
+	}
+}
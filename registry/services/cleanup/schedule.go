@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScheduledCleanup = "gitness:registry:cleanup-policy:scheduled"
+	scheduledJobMaxDuration = 2 * time.Hour
+)
+
+// Register registers the scheduled cleanup job and schedules it.
+func (s *Service) Register(
+	ctx context.Context,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+	cron string,
+) error {
+	if err := executor.Register(jobTypeScheduledCleanup, s); err != nil {
+		return fmt.Errorf("failed to register scheduled cleanup job: %w", err)
+	}
+
+	if cron == "" {
+		return nil
+	}
+
+	err := scheduler.AddRecurring(ctx, jobTypeScheduledCleanup, jobTypeScheduledCleanup,
+		cron, scheduledJobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule cleanup job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle enforces the cleanup policies of every registry that has any.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	registryIDs, err := s.cleanupPolicyStore.GetRegistryIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list registries with cleanup policies: %w", err)
+	}
+
+	deleted := 0
+	for _, registryID := range registryIDs {
+		registry, err := s.registryStore.Get(ctx, registryID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("registry_id", registryID).
+				Msg("failed to get registry for cleanup")
+			continue
+		}
+
+		n, err := s.Execute(ctx, registry)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("registry_id", registryID).
+				Msg("failed to enforce cleanup policies")
+		}
+		deleted += n
+	}
+
+	return fmt.Sprintf("deleted %d versions in %d registries", deleted, len(registryIDs)), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/services/deletion"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	// Cron schedules the enforcement of the cleanup policies of all registries, empty disables it.
+	Cron string
+}
+
+// Service enforces the cleanup policies of registries by deleting the versions they select.
+// Versions are deleted the same way as through the API, blobs that are no longer referenced
+// are left for the garbage collector. The versions of OCI registries are the tags of their
+// images, so rules match and delete tags and leave untagged manifests to the garbage collector.
+type Service struct {
+	registryStore      store.RegistryRepository
+	cleanupPolicyStore store.CleanupPolicyRepository
+	artifactStore      store.ArtifactRepository
+	tagStore           store.TagRepository
+	spaceFinder        refcache.SpaceFinder
+	deletionService    *deletion.Service
+}
+
+func NewService(
+	registryStore store.RegistryRepository,
+	cleanupPolicyStore store.CleanupPolicyRepository,
+	artifactStore store.ArtifactRepository,
+	tagStore store.TagRepository,
+	spaceFinder refcache.SpaceFinder,
+	deletionService *deletion.Service,
+) *Service {
+	return &Service{
+		registryStore:      registryStore,
+		cleanupPolicyStore: cleanupPolicyStore,
+		artifactStore:      artifactStore,
+		tagStore:           tagStore,
+		spaceFinder:        spaceFinder,
+		deletionService:    deletionService,
+	}
+}
+
+// Preview returns the versions of the registry that the next cleanup run would delete.
+func (s *Service) Preview(
+	ctx context.Context,
+	registryID int64,
+	packageType artifact.PackageType,
+) ([]types.CleanupCandidate, error) {
+	policies, err := s.cleanupPolicyStore.GetByRegistryID(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup policies: %w", err)
+	}
+	if policies == nil || len(*policies) == 0 {
+		return []types.CleanupCandidate{}, nil
+	}
+
+	versions, err := s.listVersions(ctx, registryID, packageType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	return Evaluate(*policies, versions, time.Now())
+}
+
+// Execute deletes the versions of the registry selected by its cleanup policies and
+// returns the number of deleted versions. Failing deletions are logged and skipped.
+func (s *Service) Execute(ctx context.Context, registry *types.Registry) (int, error) {
+	candidates, err := s.Preview(ctx, registry.ID, registry.PackageType)
+	if err != nil {
+		return 0, err
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	regInfo, err := s.getRegistryBaseInfo(ctx, registry)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, candidate := range candidates {
+		if err := s.deleteVersion(ctx, regInfo, candidate); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("registry_id", registry.ID).
+				Str("package", candidate.Package).
+				Str("version", candidate.Version).
+				Msg("failed to delete version selected by cleanup policy")
+			continue
+		}
+		deleted++
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("registry_id", registry.ID).
+		Msgf("cleanup policies deleted %d of %d versions", deleted, len(candidates))
+
+	return deleted, nil
+}
+
+func (s *Service) listVersions(
+	ctx context.Context,
+	registryID int64,
+	packageType artifact.PackageType,
+) ([]types.PackageVersion, error) {
+	if isOCIPackageType(packageType) {
+		return s.tagStore.ListTagVersionsByRegistryID(ctx, registryID)
+	}
+	return s.artifactStore.ListVersionsByRegistryID(ctx, registryID)
+}
+
+// deleteVersion deletes a version selected by the cleanup policies. Tags of OCI registries
+// are deleted directly, since the deletion service expects digests when untagged images are enabled.
+func (s *Service) deleteVersion(
+	ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	candidate types.CleanupCandidate,
+) error {
+	if isOCIPackageType(regInfo.PackageType) {
+		return s.tagStore.DeleteTag(ctx, regInfo.RegistryID, candidate.Package, candidate.Version)
+	}
+	return s.deletionService.DeleteArtifactVersionByPackageType(
+		ctx, regInfo, candidate.Package, candidate.Version, nil, "",
+	)
+}
+
+func isOCIPackageType(packageType artifact.PackageType) bool {
+	return packageType == artifact.PackageTypeDOCKER || packageType == artifact.PackageTypeHELM
+}
+
+func (s *Service) getRegistryBaseInfo(
+	ctx context.Context,
+	registry *types.Registry,
+) (*types.RegistryRequestBaseInfo, error) {
+	parentSpace, err := s.spaceFinder.FindByID(ctx, registry.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space: %w", err)
+	}
+	rootSpace, err := s.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root space: %w", err)
+	}
+
+	return &types.RegistryRequestBaseInfo{
+		RootIdentifier:     rootSpace.Identifier,
+		RootIdentifierID:   rootSpace.ID,
+		RegistryRef:        paths.Concatenate(parentSpace.Path, registry.Name),
+		RegistryIdentifier: registry.Name,
+		RegistryUUID:       registry.UUID,
+		RegistryID:         registry.ID,
+		ParentRef:          parentSpace.Path,
+		ParentID:           parentSpace.ID,
+		RegistryType:       registry.Type,
+		PackageType:        registry.PackageType,
+	}, nil
+}
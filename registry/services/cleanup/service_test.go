@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+)
+
+type cleanupPolicyStoreStub struct {
+	store.CleanupPolicyRepository
+	policies []types.CleanupPolicy
+}
+
+func (s *cleanupPolicyStoreStub) GetByRegistryID(context.Context, int64) (*[]types.CleanupPolicy, error) {
+	return &s.policies, nil
+}
+
+type artifactStoreStub struct {
+	store.ArtifactRepository
+	versions []types.PackageVersion
+}
+
+func (s *artifactStoreStub) ListVersionsByRegistryID(context.Context, int64) ([]types.PackageVersion, error) {
+	return s.versions, nil
+}
+
+type tagStoreStub struct {
+	store.TagRepository
+	tags    []types.PackageVersion
+	deleted []string
+}
+
+func (s *tagStoreStub) ListTagVersionsByRegistryID(context.Context, int64) ([]types.PackageVersion, error) {
+	return s.tags, nil
+}
+
+func (s *tagStoreStub) DeleteTag(_ context.Context, _ int64, imageName string, name string) error {
+	s.deleted = append(s.deleted, imageName+":"+name)
+	return nil
+}
+
+func TestPreviewMatchesTagsOfOCIRegistries(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	policies := &cleanupPolicyStoreStub{
+		policies: []types.CleanupPolicy{{
+			Name: "drop-dev", VersionPattern: []string{"dev-.*"}, ExpiryTime: (24 * time.Hour).Milliseconds(),
+		}},
+	}
+	artifacts := &artifactStoreStub{versions: []types.PackageVersion{
+		{ArtifactID: 1, Package: "app", Version: "sha256:aaa", CreatedAt: created, UpdatedAt: created},
+	}}
+	tags := &tagStoreStub{tags: []types.PackageVersion{
+		{ArtifactID: 10, Package: "app", Version: "dev-1", CreatedAt: created, UpdatedAt: created},
+		{ArtifactID: 11, Package: "app", Version: "v1.0.0", CreatedAt: created, UpdatedAt: created},
+	}}
+	service := NewService(nil, policies, artifacts, tags, refcache.SpaceFinder{}, nil)
+
+	for _, packageType := range []artifact.PackageType{artifact.PackageTypeDOCKER, artifact.PackageTypeHELM} {
+		candidates, err := service.Preview(context.Background(), 1, packageType)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(candidates) != 1 || candidates[0].ArtifactID != 10 || candidates[0].Version != "dev-1" {
+			t.Errorf("%s: expected tag dev-1 to be selected, got %+v", packageType, candidates)
+		}
+	}
+
+	policies.policies[0].VersionPattern = []string{"sha256:.*"}
+	candidates, err := service.Preview(context.Background(), 1, artifact.PackageTypeDOCKER)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected digests not to be matched for OCI registries, got %+v", candidates)
+	}
+}
+
+func TestDeleteVersionDeletesTagsOfOCIRegistries(t *testing.T) {
+	tags := &tagStoreStub{}
+	service := NewService(nil, nil, nil, tags, refcache.SpaceFinder{}, nil)
+	regInfo := &types.RegistryRequestBaseInfo{RegistryID: 1, PackageType: artifact.PackageTypeDOCKER}
+
+	err := service.deleteVersion(context.Background(), regInfo, types.CleanupCandidate{Package: "app", Version: "dev-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"app:dev-1"}; !reflect.DeepEqual(tags.deleted, want) {
+		t.Errorf("deleted tags = %v, want %v", tags.deleted, want)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/services/deletion"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideConfig,
+	ProvideService,
+)
+
+func ProvideConfig(config *types.Config) Config {
+	return Config{
+		Cron: config.Registry.CleanupPolicy.Cron,
+	}
+}
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	registryStore store.RegistryRepository,
+	cleanupPolicyStore store.CleanupPolicyRepository,
+	artifactStore store.ArtifactRepository,
+	tagStore store.TagRepository,
+	spaceFinder refcache.SpaceFinder,
+	deletionService *deletion.Service,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	service := NewService(
+		registryStore, cleanupPolicyStore, artifactStore, tagStore, spaceFinder, deletionService,
+	)
+
+	if err := service.Register(ctx, executor, scheduler, config.Cron); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/registry/types"
+	coretypes "github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"oras.land/oras-go/v2/registry/remote"
+	orasauth "oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// endpoint is a location artifacts are replicated from or to.
+type endpoint struct {
+	host       string
+	plainHTTP  bool
+	prefix     string
+	client     *http.Client
+	credential func() (orasauth.Credential, error)
+}
+
+func (e *endpoint) String() string {
+	return path.Join(e.host, e.prefix)
+}
+
+func (e *endpoint) repository(_ context.Context, image string) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(path.Join(e.host, e.prefix, image))
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository reference: %w", err)
+	}
+
+	cred, err := e.credential()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.PlainHTTP = e.plainHTTP
+	repo.Client = &orasauth.Client{
+		Client:     e.client,
+		Cache:      orasauth.NewCache(),
+		Credential: orasauth.StaticCredential(e.host, cred),
+	}
+	return repo, nil
+}
+
+// endpoints returns the source and the destination endpoint of the rule.
+func (s *Service) endpoints(ctx context.Context, rule *types.ReplicationRule) (*endpoint, *endpoint, error) {
+	principal, err := s.principalStore.Find(ctx, rule.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find creator of replication rule: %w", err)
+	}
+
+	src, err := s.localEndpoint(ctx, principal, rule.SourceRegistryID, enum.PermissionArtifactsDownload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid source: %w", err)
+	}
+
+	var dst *endpoint
+	switch rule.DestinationType {
+	case types.ReplicationDestinationLocal:
+		if rule.DestinationRegistryID == nil {
+			return nil, nil, fmt.Errorf("destination registry is missing")
+		}
+		dst, err = s.localEndpoint(ctx, principal, *rule.DestinationRegistryID,
+			enum.PermissionArtifactsDownload, enum.PermissionArtifactsUpload)
+	case types.ReplicationDestinationJfrog, types.ReplicationDestinationGCP:
+		dst, err = s.remoteEndpoint(ctx, rule.DestinationConfig)
+	default:
+		err = fmt.Errorf("unsupported destination type %q", rule.DestinationType)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid destination: %w", err)
+	}
+
+	return src, dst, nil
+}
+
+// localEndpoint returns an endpoint for a local registry, which is accessed through the OCI API of this instance.
+// Runs act on behalf of the creator of the rule, so the creator has to be allowed to access the registry.
+func (s *Service) localEndpoint(
+	ctx context.Context,
+	principal *coretypes.Principal,
+	registryID int64,
+	permissions ...enum.Permission,
+) (*endpoint, error) {
+	registry, err := s.registryStore.Get(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry: %w", err)
+	}
+	space, err := s.spaceFinder.FindByID(ctx, registry.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space of registry: %w", err)
+	}
+	rootSpace, err := s.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root space of registry: %w", err)
+	}
+
+	checks := make([]coretypes.PermissionCheck, len(permissions))
+	for i, permission := range permissions {
+		checks[i] = coretypes.PermissionCheck{
+			Scope:      coretypes.Scope{SpacePath: space.Path},
+			Resource:   coretypes.Resource{Type: enum.ResourceTypeRegistry, Identifier: registry.Name},
+			Permission: permission,
+		}
+	}
+	session := &auth.Session{Principal: *principal}
+	if err = apiauth.CheckRegistry(ctx, s.authorizer, session, checks...); err != nil {
+		return nil, fmt.Errorf("principal %q isn't allowed to access registry %q: %w",
+			principal.UID, registry.Name, err)
+	}
+
+	base, err := url.Parse(s.urlProvider.GetInternalAPIURL(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse internal url: %w", err)
+	}
+
+	accessPermissions := &jwt.SubClaimsAccessPermissions{
+		Source: jwt.OciSource,
+		Permissions: []jwt.AccessPermissions{
+			{SpaceID: registry.ParentID, Permissions: permissions},
+		},
+	}
+
+	return &endpoint{
+		host:      base.Host,
+		plainHTTP: base.Scheme == "http",
+		prefix:    path.Join(strings.ToLower(rootSpace.Identifier), registry.Name),
+		client:    s.httpClient,
+		credential: func() (orasauth.Credential, error) {
+			token, err := jwt.GenerateForTokenWithAccessPermissions(
+				principal.ID, ptr.Duration(tokenLifetime), principal.Salt, accessPermissions,
+			)
+			if err != nil {
+				return orasauth.EmptyCredential, fmt.Errorf("failed to generate registry token: %w", err)
+			}
+			return orasauth.Credential{AccessToken: token}, nil
+		},
+	}, nil
+}
+
+// remoteEndpoint returns an endpoint for a remote OCI registry.
+func (s *Service) remoteEndpoint(
+	ctx context.Context,
+	config types.ReplicationDestinationConfig,
+) (*endpoint, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	cred := orasauth.EmptyCredential
+	if config.Username != "" {
+		cred.Username = config.Username
+	}
+	if config.SecretIdentifier != "" {
+		space, err := s.spaceFinder.FindByID(ctx, config.SecretSpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space of password secret: %w", err)
+		}
+		password, err := s.secretService.DecryptSecret(ctx, space.Path, config.SecretIdentifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt password secret: %w", err)
+		}
+		cred.Password = password
+	}
+
+	return &endpoint{
+		host:      u.Host,
+		plainHTTP: u.Scheme == "http",
+		prefix:    strings.Trim(path.Join(u.Path, config.Namespace), "/"),
+		client:    s.httpClient,
+		credential: func() (orasauth.Credential, error) {
+			return cred, nil
+		},
+	}, nil
+}
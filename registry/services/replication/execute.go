@@ -0,0 +1,302 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/events"
+	replicationevents "github.com/harness/gitness/registry/app/events/replication"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rs/zerolog/log"
+	"oras.land/oras-go/v2"
+)
+
+const (
+	// runHeartbeatInterval is how often a running run records that it's still being executed.
+	runHeartbeatInterval = time.Minute
+
+	// runStaleAfter is the time without a heartbeat after which a running run is considered interrupted,
+	// e.g. by a restart of the instance, and is resumed when its event is redelivered.
+	runStaleAfter = 10 * runHeartbeatInterval
+)
+
+func (s *Service) handleEventRunRequested(
+	ctx context.Context,
+	event *events.Event[*replicationevents.ArtifactReplicationRunRequestedPayload],
+) error {
+	run, err := s.runStore.Find(ctx, event.Payload.RunID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		// the rule got deleted in the meantime
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find replication run: %w", err)
+	}
+
+	// the run is finished or it was stopped before it started.
+	if run.Status.IsDone() {
+		return nil
+	}
+
+	rule, err := s.ruleStore.Find(ctx, run.RuleID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find replication rule: %w", err)
+	}
+
+	claimed, err := s.runStore.Claim(ctx, run.ID, time.Now().Add(-runStaleAfter).UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to mark replication run as running: %w", err)
+	}
+	if !claimed {
+		// the event is redelivered for a run that is still being executed.
+		return nil
+	}
+
+	if run.Status == types.ReplicationStatusRunning {
+		// the execution of the run got interrupted, artifacts that were being copied are replicated again.
+		err = s.runStore.UpdateArtifactsStatus(
+			ctx, run.ID, types.ReplicationStatusRunning, types.ReplicationStatusPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reset interrupted replication run artifacts: %w", err)
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("replication_rule_id", rule.ID).
+			Int64("replication_run_id", run.ID).
+			Msg("resuming interrupted replication run")
+	}
+
+	stopHeartbeat := s.startHeartbeat(ctx, run.ID)
+	status := s.executeRun(ctx, rule, run)
+	stopHeartbeat()
+
+	// a stopped run keeps its status.
+	if status != types.ReplicationStatusStopped {
+		if err = s.runStore.UpdateStatus(ctx, run.ID, status); err != nil {
+			return fmt.Errorf("failed to complete replication run: %w", err)
+		}
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("replication_rule_id", rule.ID).
+		Int64("replication_run_id", run.ID).
+		Msgf("replication run finished with status %s", status)
+
+	return nil
+}
+
+// startHeartbeat periodically updates the run while it's being executed, so that it isn't considered interrupted.
+// The returned function stops the heartbeat.
+func (s *Service) startHeartbeat(ctx context.Context, runID int64) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(runHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.runStore.Heartbeat(ctx, runID); err != nil {
+					log.Ctx(ctx).Warn().Err(err).Int64("replication_run_id", runID).
+						Msg("failed to update replication run heartbeat")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// executeRun replicates all pending artifacts of the run and returns the resulting status of the run.
+func (s *Service) executeRun(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	run *types.ReplicationRun,
+) types.ReplicationStatus {
+	src, dst, err := s.endpoints(ctx, rule)
+	if err != nil {
+		s.failPendingArtifacts(ctx, run, err)
+		return types.ReplicationStatusFailure
+	}
+
+	return s.replicateArtifacts(ctx, run, src, dst)
+}
+
+// replicateArtifacts replicates all pending artifacts of the run from the source to the destination endpoint.
+func (s *Service) replicateArtifacts(
+	ctx context.Context,
+	run *types.ReplicationRun,
+	src *endpoint,
+	dst *endpoint,
+) types.ReplicationStatus {
+	failed := false
+	for offset := 0; ; offset += listPageSize {
+		artifacts, err := s.runStore.ListArtifacts(ctx, run.ID, "id", "ASC", listPageSize, offset)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("replication_run_id", run.ID).
+				Msg("failed to list replication run artifacts")
+			return types.ReplicationStatusFailure
+		}
+
+		for _, artifact := range artifacts {
+			if artifact.Status != types.ReplicationStatusPending {
+				continue
+			}
+
+			if s.isStopped(ctx, run.ID) {
+				return types.ReplicationStatusStopped
+			}
+
+			s.replicateArtifact(ctx, src, dst, artifact)
+			if artifact.Status == types.ReplicationStatusFailure {
+				failed = true
+			}
+		}
+
+		if len(artifacts) < listPageSize {
+			break
+		}
+	}
+
+	if failed {
+		return types.ReplicationStatusFailure
+	}
+	return types.ReplicationStatusSuccess
+}
+
+func (s *Service) isStopped(ctx context.Context, runID int64) bool {
+	run, err := s.runStore.Find(ctx, runID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("replication_run_id", runID).Msg("failed to find replication run")
+		return false
+	}
+	return run.Status == types.ReplicationStatusStopped
+}
+
+func (s *Service) replicateArtifact(
+	ctx context.Context,
+	src *endpoint,
+	dst *endpoint,
+	artifact *types.ReplicationRunArtifact,
+) {
+	logs := &runLog{}
+
+	artifact.Status = types.ReplicationStatusRunning
+	logs.Printf("replicating %s:%s from %s to %s", artifact.Image, artifact.Tag, src, dst)
+	artifact.Log = logs.String()
+	s.saveArtifact(ctx, artifact)
+
+	desc, err := s.copy(ctx, src, dst, artifact.Image, artifact.Tag)
+	if err != nil {
+		artifact.Status = types.ReplicationStatusFailure
+		logs.Printf("replication failed: %s", err)
+	} else {
+		artifact.Status = types.ReplicationStatusSuccess
+		logs.Printf("replicated %s (%s, %d bytes)", desc.Digest, desc.MediaType, desc.Size)
+	}
+
+	artifact.Log = logs.String()
+	s.saveArtifact(ctx, artifact)
+}
+
+func (s *Service) copy(
+	ctx context.Context,
+	src *endpoint,
+	dst *endpoint,
+	image string,
+	tag string,
+) (ocispec.Descriptor, error) {
+	srcRepo, err := src.repository(ctx, image)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to access source: %w", err)
+	}
+	dstRepo, err := dst.repository(ctx, image)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to access destination: %w", err)
+	}
+
+	return oras.Copy(ctx, srcRepo, tag, dstRepo, tag, oras.DefaultCopyOptions)
+}
+
+func (s *Service) saveArtifact(ctx context.Context, artifact *types.ReplicationRunArtifact) {
+	if err := s.runStore.UpdateArtifact(ctx, artifact); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("replication_run_artifact_id", artifact.ID).
+			Msg("failed to update replication run artifact")
+	}
+}
+
+// failPendingArtifacts marks all pending artifacts of the run as failed with the provided error.
+func (s *Service) failPendingArtifacts(ctx context.Context, run *types.ReplicationRun, cause error) {
+	for offset := 0; ; offset += listPageSize {
+		artifacts, err := s.runStore.ListArtifacts(ctx, run.ID, "id", "ASC", listPageSize, offset)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("replication_run_id", run.ID).
+				Msg("failed to list replication run artifacts")
+			return
+		}
+
+		for _, artifact := range artifacts {
+			if artifact.Status != types.ReplicationStatusPending {
+				continue
+			}
+			logs := &runLog{}
+			logs.Printf("replication failed: %s", cause)
+			artifact.Status = types.ReplicationStatusFailure
+			artifact.Log = logs.String()
+			s.saveArtifact(ctx, artifact)
+		}
+
+		if len(artifacts) < listPageSize {
+			return
+		}
+	}
+}
+
+// runLog collects the timestamped log lines of a replicated artifact.
+type runLog struct {
+	b strings.Builder
+}
+
+func (l *runLog) Printf(format string, args ...any) {
+	l.b.WriteString(time.Now().UTC().Format(time.RFC3339))
+	l.b.WriteByte(' ')
+	l.b.WriteString(fmt.Sprintf(format, args...))
+	l.b.WriteByte('\n')
+}
+
+func (l *runLog) String() string {
+	return l.b.String()
+}
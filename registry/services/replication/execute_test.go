@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	replicationevents "github.com/harness/gitness/registry/app/events/replication"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	coretypes "github.com/harness/gitness/types"
+
+	orasauth "oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// runStoreStub keeps a single run and its artifacts in memory.
+type runStoreStub struct {
+	store.ReplicationRunRepository
+	run       *types.ReplicationRun
+	artifacts []*types.ReplicationRunArtifact
+}
+
+func (s *runStoreStub) Find(_ context.Context, id int64) (*types.ReplicationRun, error) {
+	if s.run == nil || s.run.ID != id {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	run := *s.run
+	return &run, nil
+}
+
+func (s *runStoreStub) UpdateStatus(_ context.Context, _ int64, status types.ReplicationStatus) error {
+	s.run.Status = status
+	s.run.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *runStoreStub) Claim(_ context.Context, _ int64, staleBefore int64) (bool, error) {
+	stale := s.run.Status == types.ReplicationStatusRunning && s.run.UpdatedAt.UnixMilli() < staleBefore
+	if s.run.Status != types.ReplicationStatusPending && !stale {
+		return false, nil
+	}
+	s.run.Status = types.ReplicationStatusRunning
+	s.run.UpdatedAt = time.Now()
+	return true, nil
+}
+
+func (s *runStoreStub) Heartbeat(context.Context, int64) error {
+	return nil
+}
+
+func (s *runStoreStub) UpdateArtifact(_ context.Context, artifact *types.ReplicationRunArtifact) error {
+	for i, a := range s.artifacts {
+		if a.ID == artifact.ID {
+			updated := *artifact
+			s.artifacts[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (s *runStoreStub) UpdateArtifactsStatus(
+	_ context.Context,
+	_ int64,
+	from types.ReplicationStatus,
+	to types.ReplicationStatus,
+) error {
+	for _, a := range s.artifacts {
+		if a.Status == from {
+			a.Status = to
+		}
+	}
+	return nil
+}
+
+func (s *runStoreStub) ListArtifacts(
+	_ context.Context,
+	_ int64,
+	_ string,
+	_ string,
+	limit int,
+	offset int,
+) ([]*types.ReplicationRunArtifact, error) {
+	var artifacts []*types.ReplicationRunArtifact
+	for i := offset; i < len(s.artifacts) && i < offset+limit; i++ {
+		artifact := *s.artifacts[i]
+		artifacts = append(artifacts, &artifact)
+	}
+	return artifacts, nil
+}
+
+type ruleStoreStub struct {
+	store.ReplicationRuleRepository
+	rules []*types.ReplicationRule
+}
+
+func (s *ruleStoreStub) Find(_ context.Context, id int64) (*types.ReplicationRule, error) {
+	for _, rule := range s.rules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+func (s *ruleStoreStub) ListBySourceRegistry(_ context.Context, registryID int64) ([]*types.ReplicationRule, error) {
+	var rules []*types.ReplicationRule
+	for _, rule := range s.rules {
+		if rule.SourceRegistryID == registryID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+type principalStoreStub struct {
+	corestore.PrincipalStore
+}
+
+func (principalStoreStub) Find(context.Context, int64) (*coretypes.Principal, error) {
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+func newRunStore(status types.ReplicationStatus, artifactStatuses ...types.ReplicationStatus) *runStoreStub {
+	runStore := &runStoreStub{
+		run: &types.ReplicationRun{ID: 1, RuleID: 1, Status: status, UpdatedAt: time.Now()},
+	}
+	for i, artifactStatus := range artifactStatuses {
+		runStore.artifacts = append(runStore.artifacts, &types.ReplicationRunArtifact{
+			ID:     int64(i + 1),
+			RunID:  1,
+			Image:  "image",
+			Tag:    "v" + strconv.Itoa(i+1),
+			Status: artifactStatus,
+		})
+	}
+	return runStore
+}
+
+func newRemoteEndpoint(t *testing.T, serverURL string) *endpoint {
+	t.Helper()
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+
+	return &endpoint{
+		host:      u.Host,
+		plainHTTP: true,
+		client:    http.DefaultClient,
+		credential: func() (orasauth.Credential, error) {
+			return orasauth.EmptyCredential, nil
+		},
+	}
+}
+
+func TestExecuteRun_InvalidEndpoints(t *testing.T) {
+	runStore := newRunStore(types.ReplicationStatusRunning,
+		types.ReplicationStatusSuccess, types.ReplicationStatusPending)
+	s := &Service{runStore: runStore, principalStore: principalStoreStub{}}
+
+	status := s.executeRun(context.Background(), &types.ReplicationRule{ID: 1, CreatedBy: 1}, runStore.run)
+	if status != types.ReplicationStatusFailure {
+		t.Errorf("expected failure, got %s", status)
+	}
+
+	if got := runStore.artifacts[0].Status; got != types.ReplicationStatusSuccess {
+		t.Errorf("expected replicated artifact to keep its status, got %s", got)
+	}
+	if got := runStore.artifacts[1]; got.Status != types.ReplicationStatusFailure ||
+		!strings.Contains(got.Log, "replication failed") {
+		t.Errorf("expected pending artifact to fail, got %s with log %q", got.Status, got.Log)
+	}
+}
+
+func TestReplicateArtifacts_CopyFails(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	runStore := newRunStore(types.ReplicationStatusRunning,
+		types.ReplicationStatusPending, types.ReplicationStatusPending)
+	s := &Service{runStore: runStore}
+
+	src := newRemoteEndpoint(t, server.URL)
+	dst := newRemoteEndpoint(t, server.URL)
+
+	status := s.replicateArtifacts(context.Background(), runStore.run, src, dst)
+	if status != types.ReplicationStatusFailure {
+		t.Errorf("expected failure, got %s", status)
+	}
+
+	for _, artifact := range runStore.artifacts {
+		if artifact.Status != types.ReplicationStatusFailure || !strings.Contains(artifact.Log, "replication failed") {
+			t.Errorf("expected artifact %s to fail, got %s with log %q", artifact.Tag, artifact.Status, artifact.Log)
+		}
+	}
+}
+
+func TestReplicateArtifacts_Stopped(t *testing.T) {
+	runStore := newRunStore(types.ReplicationStatusStopped, types.ReplicationStatusPending)
+	s := &Service{runStore: runStore}
+
+	// the endpoints are never used, the run is stopped before the first artifact is copied.
+	status := s.replicateArtifacts(context.Background(), runStore.run, nil, nil)
+	if status != types.ReplicationStatusStopped {
+		t.Errorf("expected stopped, got %s", status)
+	}
+
+	if got := runStore.artifacts[0].Status; got != types.ReplicationStatusPending {
+		t.Errorf("expected artifact to stay pending, got %s", got)
+	}
+}
+
+func TestHandleEventRunRequested_ResumesStaleRun(t *testing.T) {
+	runStore := newRunStore(types.ReplicationStatusRunning,
+		types.ReplicationStatusSuccess, types.ReplicationStatusRunning)
+	runStore.run.UpdatedAt = time.Now().Add(-2 * runStaleAfter)
+
+	s := &Service{
+		runStore:       runStore,
+		ruleStore:      &ruleStoreStub{rules: []*types.ReplicationRule{{ID: 1, CreatedBy: 1}}},
+		principalStore: principalStoreStub{},
+	}
+
+	err := s.handleEventRunRequested(context.Background(), runRequestedEvent(runStore.run.ID))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if runStore.run.Status != types.ReplicationStatusFailure {
+		t.Errorf("expected the resumed run to complete, got %s", runStore.run.Status)
+	}
+	if got := runStore.artifacts[0].Status; got != types.ReplicationStatusSuccess {
+		t.Errorf("expected replicated artifact to keep its status, got %s", got)
+	}
+	if got := runStore.artifacts[1].Status; got != types.ReplicationStatusFailure {
+		t.Errorf("expected interrupted artifact to be processed again, got %s", got)
+	}
+}
+
+func TestHandleEventRunRequested_SkipsActiveRun(t *testing.T) {
+	runStore := newRunStore(types.ReplicationStatusRunning, types.ReplicationStatusRunning)
+
+	s := &Service{
+		runStore:       runStore,
+		ruleStore:      &ruleStoreStub{rules: []*types.ReplicationRule{{ID: 1, CreatedBy: 1}}},
+		principalStore: principalStoreStub{},
+	}
+
+	err := s.handleEventRunRequested(context.Background(), runRequestedEvent(runStore.run.ID))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if runStore.run.Status != types.ReplicationStatusRunning {
+		t.Errorf("expected the active run to be left alone, got %s", runStore.run.Status)
+	}
+	if got := runStore.artifacts[0].Status; got != types.ReplicationStatusRunning {
+		t.Errorf("expected the artifact of the active run to be left alone, got %s", got)
+	}
+}
+
+func runRequestedEvent(runID int64) *events.Event[*replicationevents.ArtifactReplicationRunRequestedPayload] {
+	return &events.Event[*replicationevents.ArtifactReplicationRunRequestedPayload]{
+		Payload: &replicationevents.ArtifactReplicationRunRequestedPayload{RunID: runID},
+	}
+}
+
+func TestValidateNoCycle(t *testing.T) {
+	registryID := func(id int64) *int64 { return &id }
+
+	// registries: 1 -> 2 -> 3, and 4 replicates to a remote registry.
+	rules := []*types.ReplicationRule{
+		{ID: 1, SourceRegistryID: 1, DestinationRegistryID: registryID(2)},
+		{ID: 2, SourceRegistryID: 2, DestinationRegistryID: registryID(3)},
+		{ID: 3, SourceRegistryID: 4},
+	}
+
+	tests := []struct {
+		name        string
+		ruleID      int64
+		source      int64
+		destination int64
+		wantErr     bool
+	}{
+		{name: "new_rule_without_cycle", source: 3, destination: 4},
+		{name: "direct_cycle", source: 2, destination: 1, wantErr: true},
+		{name: "transitive_cycle", source: 3, destination: 1, wantErr: true},
+		{name: "self", source: 1, destination: 1, wantErr: true},
+		{name: "updated_rule_ignores_itself", ruleID: 2, source: 3, destination: 2},
+		{name: "updated_rule_without_cycle", ruleID: 1, source: 1, destination: 4},
+	}
+
+	s := &Service{ruleStore: &ruleStoreStub{rules: rules}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.ValidateNoCycle(context.Background(), test.ruleID, test.source, test.destination)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ValidateNoCycle() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
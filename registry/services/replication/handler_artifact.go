@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+)
+
+// handleEventArtifactCreated starts a push replication run for every rule of the registry
+// that selects the pushed tag.
+func (s *Service) handleEventArtifactCreated(
+	ctx context.Context,
+	event *events.Event[*artifactevents.ArtifactCreatedPayload],
+) error {
+	image, tag, ok := ociImageTag(event.Payload)
+	if !ok {
+		return nil
+	}
+
+	rules, err := s.ruleStore.ListBySourceRegistry(ctx, event.Payload.RegistryID)
+	if err != nil {
+		return fmt.Errorf("failed to list replication rules of registry: %w", err)
+	}
+
+	for _, rule := range rules {
+		if Matches(rule, image, tag) {
+			s.startPushRun(ctx, rule, image, tag)
+		}
+	}
+
+	return nil
+}
+
+// ociImageTag returns the image name and tag of a pushed OCI artifact.
+// Only tagged docker images and helm charts can be replicated.
+func ociImageTag(payload *artifactevents.ArtifactCreatedPayload) (string, string, bool) {
+	var image, tag string
+	switch a := payload.Artifact.(type) {
+	case *artifactevents.DockerArtifact:
+		image, tag = a.Name, a.Tag
+	case *artifactevents.HelmArtifact:
+		image, tag = a.Name, a.Tag
+	default:
+		return "", "", false
+	}
+
+	if payload.ArtifactType != artifact.PackageTypeDOCKER && payload.ArtifactType != artifact.PackageTypeHELM {
+		return "", "", false
+	}
+
+	return image, tag, tag != ""
+}
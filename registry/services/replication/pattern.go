@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// ValidatePatterns verifies that all patterns are well-formed.
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("pattern can't be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the image tag is selected by the patterns of the rule.
+// Patterns are shell globs as supported by path.Match. A pattern that contains a colon is matched
+// against "image:tag", otherwise only against the image name. Blocked patterns take precedence over
+// allowed patterns, and a rule without allowed patterns selects every image.
+func Matches(rule *types.ReplicationRule, image, tag string) bool {
+	if matchesAny(rule.BlockedPatterns, image, tag) {
+		return false
+	}
+	if len(rule.AllowedPatterns) == 0 {
+		return true
+	}
+	return matchesAny(rule.AllowedPatterns, image, tag)
+}
+
+func matchesAny(patterns []string, image, tag string) bool {
+	for _, pattern := range patterns {
+		subject := image
+		if strings.Contains(pattern, ":") {
+			subject = image + ":" + tag
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		blocked []string
+		image   string
+		tag     string
+		want    bool
+	}{
+		{name: "no patterns", image: "app", tag: "v1", want: true},
+		{name: "allowed image", allowed: []string{"app*"}, image: "app-web", tag: "v1", want: true},
+		{name: "not allowed image", allowed: []string{"app*"}, image: "lib", tag: "v1", want: false},
+		{name: "allowed tag", allowed: []string{"*:v*"}, image: "lib", tag: "v1", want: true},
+		{name: "not allowed tag", allowed: []string{"*:v*"}, image: "lib", tag: "latest", want: false},
+		{name: "glob doesn't cross slash", allowed: []string{"team/*"}, image: "team/a/b", tag: "v1", want: false},
+		{
+			name: "blocked tag wins", allowed: []string{"app"}, blocked: []string{"app:*-rc*"},
+			image: "app", tag: "v1-rc1", want: false,
+		},
+		{name: "blocked image", blocked: []string{"internal/*"}, image: "internal/db", tag: "v1", want: false},
+		{name: "other image not blocked", blocked: []string{"internal/*"}, image: "public/db", tag: "v1", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := &types.ReplicationRule{AllowedPatterns: test.allowed, BlockedPatterns: test.blocked}
+			if got := Matches(rule, test.image, test.tag); got != test.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", test.image, test.tag, got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if err := ValidatePatterns([]string{"app/*", "*:v[0-9]*"}); err != nil {
+		t.Errorf("expected patterns to be valid, got %v", err)
+	}
+	if err := ValidatePatterns([]string{"app["}); err == nil {
+		t.Error("expected malformed pattern to be rejected")
+	}
+	if err := ValidatePatterns([]string{" "}); err == nil {
+		t.Error("expected empty pattern to be rejected")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	replicationevents "github.com/harness/gitness/registry/app/events/replication"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StartRun creates a run that replicates every tag of the source registry selected by the rule.
+// The run is executed asynchronously. It fails with ErrRunInProgress if the rule has an unfinished run,
+// which is also enforced by the database for concurrent requests.
+func (s *Service) StartRun(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	trigger types.ReplicationTrigger,
+) (*types.ReplicationRun, error) {
+	latest, err := s.runStore.FindLatestByRule(ctx, rule.ID)
+	if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find latest replication run: %w", err)
+	}
+	if latest != nil && !latest.Status.IsDone() {
+		return nil, ErrRunInProgress
+	}
+
+	artifacts, err := s.listMatchingTags(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createRun(ctx, rule, trigger, artifacts)
+}
+
+// StopRun stops the unfinished run of the rule. Artifacts that are being copied at this moment
+// are completed, all pending artifacts are skipped.
+func (s *Service) StopRun(ctx context.Context, rule *types.ReplicationRule) error {
+	run, err := s.runStore.FindLatestByRule(ctx, rule.ID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return ErrNoRunInProgress
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find latest replication run: %w", err)
+	}
+	if run.Status.IsDone() {
+		return ErrNoRunInProgress
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.runStore.UpdateStatus(ctx, run.ID, types.ReplicationStatusStopped); err != nil {
+			return fmt.Errorf("failed to stop replication run: %w", err)
+		}
+		err := s.runStore.UpdateArtifactsStatus(
+			ctx, run.ID, types.ReplicationStatusPending, types.ReplicationStatusStopped,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to stop pending replication run artifacts: %w", err)
+		}
+		return nil
+	})
+}
+
+// ValidateNoCycle verifies that replicating from the source to the destination registry doesn't
+// replicate artifacts back to the source through other local replication rules, as every replicated
+// artifact triggers the push based replication of the destination again.
+func (s *Service) ValidateNoCycle(ctx context.Context, ruleID, sourceRegistryID, destinationRegistryID int64) error {
+	visited := map[int64]bool{}
+	queue := []int64{destinationRegistryID}
+	for len(queue) > 0 {
+		registryID := queue[0]
+		queue = queue[1:]
+
+		if registryID == sourceRegistryID {
+			return fmt.Errorf("replication rule would replicate artifacts back to the source registry")
+		}
+		if visited[registryID] {
+			continue
+		}
+		visited[registryID] = true
+
+		rules, err := s.ruleStore.ListBySourceRegistry(ctx, registryID)
+		if err != nil {
+			return fmt.Errorf("failed to list replication rules of registry: %w", err)
+		}
+		for _, rule := range rules {
+			if rule.ID == ruleID || rule.DestinationRegistryID == nil {
+				continue
+			}
+			queue = append(queue, *rule.DestinationRegistryID)
+		}
+	}
+	return nil
+}
+
+func (s *Service) createRun(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	trigger types.ReplicationTrigger,
+	artifacts []*types.ReplicationRunArtifact,
+) (*types.ReplicationRun, error) {
+	run := &types.ReplicationRun{
+		RuleID:  rule.ID,
+		Trigger: trigger,
+		Status:  types.ReplicationStatusPending,
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := s.runStore.Create(ctx, run)
+		if errors.Is(err, gitnessstore.ErrDuplicate) {
+			// another manual or scheduled run of the rule has been created concurrently.
+			return ErrRunInProgress
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create replication run: %w", err)
+		}
+		for _, artifact := range artifacts {
+			artifact.RunID = run.ID
+			artifact.Status = types.ReplicationStatusPending
+			if err := s.runStore.CreateArtifact(ctx, artifact); err != nil {
+				return fmt.Errorf("failed to create replication run artifact: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.reporter.RunRequested(ctx, &replicationevents.ArtifactReplicationRunRequestedPayload{RunID: run.ID})
+
+	return run, nil
+}
+
+// listMatchingTags returns all tags of the source registry that are selected by the rule.
+func (s *Service) listMatchingTags(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+) ([]*types.ReplicationRunArtifact, error) {
+	registry, err := s.registryStore.Get(ctx, rule.SourceRegistryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source registry: %w", err)
+	}
+
+	var artifacts []*types.ReplicationRunArtifact
+	for offset := 0; ; offset += listPageSize {
+		images, err := s.tagStore.GetAllArtifactsByRepo(
+			ctx, registry.ParentID, registry.Name, "image_name", "ASC", listPageSize, offset, "", nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images of source registry: %w", err)
+		}
+
+		for _, image := range *images {
+			tags, err := s.listImageTags(ctx, registry, image.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range tags {
+				if Matches(rule, image.Name, tag) {
+					artifacts = append(artifacts, &types.ReplicationRunArtifact{Image: image.Name, Tag: tag})
+				}
+			}
+		}
+
+		if len(*images) < listPageSize {
+			return artifacts, nil
+		}
+	}
+}
+
+func (s *Service) listImageTags(ctx context.Context, registry *types.Registry, image string) ([]string, error) {
+	var tags []string
+	for offset := 0; ; offset += listPageSize {
+		versions, err := s.tagStore.GetAllTagsByRepoAndImage(
+			ctx, registry.ParentID, registry.Name, image, "name", "ASC", listPageSize, offset, "",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of image %q: %w", image, err)
+		}
+		for _, version := range *versions {
+			tags = append(tags, version.Name)
+		}
+		if len(*versions) < listPageSize {
+			return tags, nil
+		}
+	}
+}
+
+func (s *Service) startPushRun(ctx context.Context, rule *types.ReplicationRule, image, tag string) {
+	artifacts := []*types.ReplicationRunArtifact{{Image: image, Tag: tag}}
+	if _, err := s.createRun(ctx, rule, types.ReplicationTriggerPush, artifacts); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("replication_rule_id", rule.ID).
+			Msgf("failed to start push replication of %s:%s", image, tag)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScheduledReplication = "gitness:registry:replication:scheduled"
+	scheduledJobMaxDuration     = 30 * time.Minute
+)
+
+// Register registers the scheduled replication job and schedules it.
+func (s *Service) Register(
+	ctx context.Context,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+	cron string,
+) error {
+	if err := executor.Register(jobTypeScheduledReplication, s); err != nil {
+		return fmt.Errorf("failed to register scheduled replication job: %w", err)
+	}
+
+	if cron == "" {
+		return nil
+	}
+
+	err := scheduler.AddRecurring(ctx, jobTypeScheduledReplication, jobTypeScheduledReplication,
+		cron, scheduledJobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule replication job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle starts a full replication run for every rule, skipping rules that still have a run in progress.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	rules, err := s.ruleStore.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list replication rules: %w", err)
+	}
+
+	started := 0
+	for _, rule := range rules {
+		_, err := s.StartRun(ctx, rule, types.ReplicationTriggerSchedule)
+		if errors.Is(err, ErrRunInProgress) {
+			continue
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("replication_rule_id", rule.ID).
+				Msg("failed to start scheduled replication run")
+			continue
+		}
+		started++
+	}
+
+	return fmt.Sprintf("started %d of %d replication runs", started, len(rules)), nil
+}
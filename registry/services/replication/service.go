@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+	replicationevents "github.com/harness/gitness/registry/app/events/replication"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	eventsReaderGroupName = "gitness:registry:replication"
+
+	// tokenLifetime is the lifetime of the tokens used to access local registries during a run.
+	// A new token is generated for every artifact, so it only has to outlive a single copy.
+	tokenLifetime = time.Hour
+
+	// listPageSize is the page size used when iterating over tags and run artifacts.
+	listPageSize = 100
+)
+
+var (
+	// ErrRunInProgress is returned when a replication rule already has a run that isn't finished.
+	ErrRunInProgress = errors.New("replication rule already has a run in progress")
+
+	// ErrNoRunInProgress is returned when stopping a replication rule that has no unfinished run.
+	ErrNoRunInProgress = errors.New("replication rule has no run in progress")
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	Cron            string
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("Config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("Config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("Config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service replicates artifacts of local registries to other local registries or to remote OCI registries
+// according to replication rules. Runs are started by artifact pushes, by schedule or manually; every run
+// is executed asynchronously and records the status of each replicated artifact.
+type Service struct {
+	tx             dbtx.Transactor
+	ruleStore      store.ReplicationRuleRepository
+	runStore       store.ReplicationRunRepository
+	registryStore  store.RegistryRepository
+	tagStore       store.TagRepository
+	principalStore corestore.PrincipalStore
+	spaceFinder    refcache.SpaceFinder
+	authorizer     authz.Authorizer
+	secretService  secret.Service
+	urlProvider    url.Provider
+	reporter       *replicationevents.ArtifactReporter
+	httpClient     *http.Client
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	tx dbtx.Transactor,
+	artifactsReaderFactory *events.ReaderFactory[*artifactevents.Reader],
+	replicationReaderFactory *events.ReaderFactory[*replicationevents.ArtifactReader],
+	reporter *replicationevents.ArtifactReporter,
+	ruleStore store.ReplicationRuleRepository,
+	runStore store.ReplicationRunRepository,
+	registryStore store.RegistryRepository,
+	tagStore store.TagRepository,
+	principalStore corestore.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
+	authorizer authz.Authorizer,
+	secretService secret.Service,
+	urlProvider url.Provider,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided replication service config is invalid: %w", err)
+	}
+
+	s := &Service{
+		tx:             tx,
+		ruleStore:      ruleStore,
+		runStore:       runStore,
+		registryStore:  registryStore,
+		tagStore:       tagStore,
+		principalStore: principalStore,
+		spaceFinder:    spaceFinder,
+		authorizer:     authorizer,
+		secretService:  secretService,
+		urlProvider:    urlProvider,
+		reporter:       reporter,
+		httpClient:     retry.DefaultClient,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := artifactsReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *artifactevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterArtifactCreated(s.handleEventArtifactCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch artifact event reader for replication: %w", err)
+	}
+
+	_, err = replicationReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *replicationevents.ArtifactReader) error {
+			// unacknowledged events are redelivered after the idle timeout,
+			// runs copy whole artifacts so they get a lot more time than regular events.
+			const runTimeout = 6 * time.Hour
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(runTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterRunRequested(s.handleEventRunRequested)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch replication event reader: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"encoding/gob"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+	replicationevents "github.com/harness/gitness/registry/app/events/replication"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideConfig,
+	ProvideService,
+	replicationevents.ProvideArtifactReporter,
+	replicationevents.ProvideArtifactReaderFactory,
+)
+
+func ProvideConfig(config *types.Config) Config {
+	return Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Registry.Replication.Concurrency,
+		MaxRetries:      config.Registry.Replication.MaxRetries,
+		Cron:            config.Registry.Replication.Cron,
+	}
+}
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	tx dbtx.Transactor,
+	artifactsReaderFactory *events.ReaderFactory[*artifactevents.Reader],
+	replicationReaderFactory *events.ReaderFactory[*replicationevents.ArtifactReader],
+	reporter *replicationevents.ArtifactReporter,
+	ruleStore store.ReplicationRuleRepository,
+	runStore store.ReplicationRunRepository,
+	registryStore store.RegistryRepository,
+	tagStore store.TagRepository,
+	principalStore corestore.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
+	authorizer authz.Authorizer,
+	secretService secret.Service,
+	urlProvider url.Provider,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	gob.Register(&artifactevents.DockerArtifact{})
+	gob.Register(&artifactevents.HelmArtifact{})
+
+	service, err := NewService(
+		ctx,
+		config,
+		tx,
+		artifactsReaderFactory,
+		replicationReaderFactory,
+		reporter,
+		ruleStore,
+		runStore,
+		registryStore,
+		tagStore,
+		principalStore,
+		spaceFinder,
+		authorizer,
+		secretService,
+		urlProvider,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = service.Register(ctx, executor, scheduler, config.Cron); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
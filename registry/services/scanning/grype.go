@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// grypeScanner runs the grype directory scanner, see https://github.com/anchore/grype.
+type grypeScanner struct {
+	path    string
+	offline bool
+}
+
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Locations []struct {
+				Path string `json:"path"`
+			} `json:"locations"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func (s *grypeScanner) Name() string {
+	return ScannerGrype
+}
+
+func (s *grypeScanner) Scan(ctx context.Context, dir string) ([]types.Vulnerability, error) {
+	var env []string
+	if s.offline {
+		env = append(env, "GRYPE_DB_AUTO_UPDATE=false", "GRYPE_CHECK_FOR_APP_UPDATE=false")
+	}
+
+	out, err := run(ctx, env, s.path, "dir:"+dir, "--output", "json", "--quiet")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGrypeReport(out)
+}
+
+func parseGrypeReport(data []byte) ([]types.Vulnerability, error) {
+	var report grypeReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse grype report: %w", err)
+	}
+
+	vulnerabilities := make([]types.Vulnerability, 0, len(report.Matches))
+	for _, m := range report.Matches {
+		var target string
+		if len(m.Artifact.Locations) > 0 {
+			target = strings.TrimPrefix(m.Artifact.Locations[0].Path, "/")
+		}
+		vulnerabilities = append(vulnerabilities, types.Vulnerability{
+			VulnerabilityID:  m.Vulnerability.ID,
+			PackageName:      m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity:         types.ParseVulnerabilitySeverity(m.Vulnerability.Severity),
+			Title:            m.Vulnerability.Description,
+			Target:           target,
+		})
+	}
+
+	return vulnerabilities, nil
+}
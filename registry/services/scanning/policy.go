@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// violations returns the vulnerabilities that are at or above the quarantine severity of the policy.
+// It returns nil if the policy doesn't quarantine artifacts.
+func violations(policy *types.ScanPolicy, vulnerabilities []types.Vulnerability) []types.Vulnerability {
+	if policy == nil || !policy.QuarantineSeverity.IsValid() {
+		return nil
+	}
+
+	var result []types.Vulnerability
+	for _, v := range vulnerabilities {
+		if v.Severity.AtLeast(policy.QuarantineSeverity) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// quarantineReason returns the reason recorded for an artifact version quarantined by a scan.
+func quarantineReason(scanner string, severity types.VulnerabilitySeverity, count int) string {
+	noun := "vulnerabilities"
+	if count == 1 {
+		noun = "vulnerability"
+	}
+	return fmt.Sprintf("%d %s of severity %s or higher found by %s", count, noun, severity, scanner)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+func TestViolations(t *testing.T) {
+	vulnerabilities := []types.Vulnerability{
+		{VulnerabilityID: "a", Severity: types.VulnerabilitySeverityCritical},
+		{VulnerabilityID: "b", Severity: types.VulnerabilitySeverityHigh},
+		{VulnerabilityID: "c", Severity: types.VulnerabilitySeverityLow},
+		{VulnerabilityID: "d", Severity: types.VulnerabilitySeverityUnknown},
+	}
+
+	tests := []struct {
+		name     string
+		severity types.VulnerabilitySeverity
+		want     int
+	}{
+		{name: "no quarantine", severity: "", want: 0},
+		{name: "critical", severity: types.VulnerabilitySeverityCritical, want: 1},
+		{name: "high", severity: types.VulnerabilitySeverityHigh, want: 2},
+		{name: "medium", severity: types.VulnerabilitySeverityMedium, want: 2},
+		{name: "unknown", severity: types.VulnerabilitySeverityUnknown, want: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &types.ScanPolicy{Enabled: true, QuarantineSeverity: test.severity}
+			if got := violations(policy, vulnerabilities); len(got) != test.want {
+				t.Errorf("violations() returned %d vulnerabilities, want %d", len(got), test.want)
+			}
+		})
+	}
+}
+
+func TestQuarantineReason(t *testing.T) {
+	if got := quarantineReason(ScannerTrivy, types.VulnerabilitySeverityHigh, 1); got !=
+		"1 vulnerability of severity HIGH or higher found by trivy" {
+		t.Errorf("unexpected reason %q", got)
+	}
+	if got := quarantineReason(ScannerGrype, types.VulnerabilitySeverityCritical, 3); got !=
+		"3 vulnerabilities of severity CRITICAL or higher found by grype" {
+		t.Errorf("unexpected reason %q", got)
+	}
+}
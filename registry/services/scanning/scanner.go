@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+const (
+	ScannerTrivy = "trivy"
+	ScannerGrype = "grype"
+)
+
+// Scanner finds known vulnerabilities in the files of an artifact version.
+// Implementations must not modify the scanned directory.
+type Scanner interface {
+	// Name returns the name of the scanner, it's stored with the scan results.
+	Name() string
+
+	// Scan scans all files below dir and returns the vulnerabilities found.
+	Scan(ctx context.Context, dir string) ([]types.Vulnerability, error)
+}
+
+// NewScanner returns the scanner configured in the config, or nil if scanning is disabled.
+func NewScanner(config Config) (Scanner, error) {
+	switch strings.ToLower(config.Scanner) {
+	case "":
+		return nil, nil //nolint:nilnil
+	case ScannerTrivy:
+		return &trivyScanner{path: binaryPath(config.Path, ScannerTrivy), offline: config.Offline}, nil
+	case ScannerGrype:
+		return &grypeScanner{path: binaryPath(config.Path, ScannerGrype), offline: config.Offline}, nil
+	default:
+		return nil, fmt.Errorf("unsupported vulnerability scanner %q", config.Scanner)
+	}
+}
+
+func binaryPath(path string, scanner string) string {
+	if path != "" {
+		return path
+	}
+	return scanner
+}
+
+// run executes the scanner binary and returns its standard output.
+func run(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(cmd.Environ(), env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = msg[len(msg)-512:]
+		}
+		return nil, fmt.Errorf("failed to run %s: %w: %s", name, err, msg)
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+const trivyReportJSON = `{
+  "SchemaVersion": 2,
+  "Results": [
+    {
+      "Target": "app/package-lock.json",
+      "Class": "lang-pkgs",
+      "Type": "npm",
+      "Vulnerabilities": [
+        {
+          "VulnerabilityID": "CVE-2021-23337",
+          "PkgName": "lodash",
+          "InstalledVersion": "4.17.20",
+          "FixedVersion": "4.17.21",
+          "Severity": "HIGH",
+          "Title": "lodash: command injection via template"
+        },
+        {
+          "VulnerabilityID": "CVE-2020-28500",
+          "PkgName": "lodash",
+          "InstalledVersion": "4.17.20",
+          "FixedVersion": "4.17.21",
+          "Severity": "MEDIUM",
+          "Title": "lodash: ReDoS via the toNumber, trim and trimEnd functions"
+        }
+      ]
+    },
+    {
+      "Target": "lib/app.jar",
+      "Class": "lang-pkgs",
+      "Type": "jar"
+    }
+  ]
+}`
+
+const grypeReportJSON = `{
+  "matches": [
+    {
+      "vulnerability": {
+        "id": "GHSA-jfh8-c2jp-5v3q",
+        "severity": "Critical",
+        "description": "Remote code injection in Log4j",
+        "fix": {"versions": ["2.15.0"], "state": "fixed"}
+      },
+      "artifact": {
+        "name": "log4j-core",
+        "version": "2.14.1",
+        "locations": [{"path": "/lib/app.jar"}]
+      }
+    },
+    {
+      "vulnerability": {
+        "id": "CVE-2005-2541",
+        "severity": "Negligible",
+        "fix": {"versions": [], "state": "not-fixed"}
+      },
+      "artifact": {
+        "name": "tar",
+        "version": "1.34",
+        "locations": []
+      }
+    }
+  ]
+}`
+
+func TestParseTrivyReport(t *testing.T) {
+	vulnerabilities, err := parseTrivyReport([]byte(trivyReportJSON))
+	if err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	want := []types.Vulnerability{
+		{
+			VulnerabilityID:  "CVE-2021-23337",
+			PackageName:      "lodash",
+			InstalledVersion: "4.17.20",
+			FixedVersion:     "4.17.21",
+			Severity:         types.VulnerabilitySeverityHigh,
+			Title:            "lodash: command injection via template",
+			Target:           "app/package-lock.json",
+		},
+		{
+			VulnerabilityID:  "CVE-2020-28500",
+			PackageName:      "lodash",
+			InstalledVersion: "4.17.20",
+			FixedVersion:     "4.17.21",
+			Severity:         types.VulnerabilitySeverityMedium,
+			Title:            "lodash: ReDoS via the toNumber, trim and trimEnd functions",
+			Target:           "app/package-lock.json",
+		},
+	}
+	if len(vulnerabilities) != len(want) {
+		t.Fatalf("got %d vulnerabilities, want %d", len(vulnerabilities), len(want))
+	}
+	for i := range want {
+		if vulnerabilities[i] != want[i] {
+			t.Errorf("vulnerability %d = %+v, want %+v", i, vulnerabilities[i], want[i])
+		}
+	}
+}
+
+func TestParseGrypeReport(t *testing.T) {
+	vulnerabilities, err := parseGrypeReport([]byte(grypeReportJSON))
+	if err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	want := []types.Vulnerability{
+		{
+			VulnerabilityID:  "GHSA-jfh8-c2jp-5v3q",
+			PackageName:      "log4j-core",
+			InstalledVersion: "2.14.1",
+			FixedVersion:     "2.15.0",
+			Severity:         types.VulnerabilitySeverityCritical,
+			Title:            "Remote code injection in Log4j",
+			Target:           "lib/app.jar",
+		},
+		{
+			VulnerabilityID:  "CVE-2005-2541",
+			PackageName:      "tar",
+			InstalledVersion: "1.34",
+			Severity:         types.VulnerabilitySeverityUnknown,
+		},
+	}
+	if len(vulnerabilities) != len(want) {
+		t.Fatalf("got %d vulnerabilities, want %d", len(vulnerabilities), len(want))
+	}
+	for i := range want {
+		if vulnerabilities[i] != want[i] {
+			t.Errorf("vulnerability %d = %+v, want %+v", i, vulnerabilities[i], want[i])
+		}
+	}
+}
+
+func TestParseReportInvalid(t *testing.T) {
+	if _, err := parseTrivyReport([]byte("not json")); err == nil {
+		t.Error("expected an error for an invalid trivy report")
+	}
+	if _, err := parseGrypeReport([]byte("not json")); err == nil {
+		t.Error("expected an error for an invalid grype report")
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	scanner, err := NewScanner(Config{})
+	if err != nil || scanner != nil {
+		t.Errorf("expected no scanner without configuration, got %v, %v", scanner, err)
+	}
+
+	scanner, err = NewScanner(Config{Scanner: "Trivy", Path: "/opt/trivy"})
+	if err != nil {
+		t.Fatalf("failed to create trivy scanner: %v", err)
+	}
+	if scanner.Name() != ScannerTrivy || scanner.(*trivyScanner).path != "/opt/trivy" {
+		t.Errorf("unexpected trivy scanner %+v", scanner)
+	}
+
+	scanner, err = NewScanner(Config{Scanner: ScannerGrype})
+	if err != nil {
+		t.Fatalf("failed to create grype scanner: %v", err)
+	}
+	if scanner.Name() != ScannerGrype || scanner.(*grypeScanner).path != ScannerGrype {
+		t.Errorf("unexpected grype scanner %+v", scanner)
+	}
+
+	if _, err = NewScanner(Config{Scanner: "clair"}); err == nil {
+		t.Error("expected an error for an unsupported scanner")
+	}
+}
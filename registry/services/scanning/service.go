@@ -0,0 +1,371 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/api/utils"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	coretypes "github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrUnsupportedPackageType is returned for artifacts that can't be scanned by downloading their files.
+var ErrUnsupportedPackageType = errors.New("package type is not supported by vulnerability scanning")
+
+// IsPackageTypeSupported returns true if artifacts of the package type can be scanned.
+// OCI artifacts (docker images and helm charts) are stored as manifests and blobs instead of files,
+// so scan policies can't be enabled for OCI registries.
+func IsPackageTypeSupported(packageType artifact.PackageType) bool {
+	//nolint:exhaustive
+	switch packageType {
+	case artifact.PackageTypeDOCKER, artifact.PackageTypeHELM:
+		return false
+	default:
+		return true
+	}
+}
+
+type Config struct {
+	Scanner string
+	Path    string
+	Offline bool
+	Timeout time.Duration
+}
+
+// Service scans artifact versions for known vulnerabilities after they are uploaded to a registry with
+// an enabled scan policy. The findings are stored per artifact version, and versions with findings at or
+// above the quarantine severity of the policy are quarantined.
+type Service struct {
+	config            Config
+	scanner           Scanner
+	tx                dbtx.Transactor
+	scanPolicyDao     store.ScanPolicyRepository
+	artifactScanDao   store.ArtifactScanRepository
+	registryDao       store.RegistryRepository
+	imageDao          store.ImageRepository
+	artifactDao       store.ArtifactRepository
+	nodesDao          store.NodesRepository
+	quarantineDao     store.QuarantineArtifactRepository
+	quarantineService *quarantine.Service
+	quarantineFinder  quarantine.Finder
+	fileManager       filemanager.FileManager
+	spaceFinder       refcache.SpaceFinder
+}
+
+func NewService(
+	config Config,
+	scanner Scanner,
+	tx dbtx.Transactor,
+	scanPolicyDao store.ScanPolicyRepository,
+	artifactScanDao store.ArtifactScanRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	nodesDao store.NodesRepository,
+	quarantineDao store.QuarantineArtifactRepository,
+	quarantineService *quarantine.Service,
+	quarantineFinder quarantine.Finder,
+	fileManager filemanager.FileManager,
+	spaceFinder refcache.SpaceFinder,
+) *Service {
+	return &Service{
+		config:            config,
+		scanner:           scanner,
+		tx:                tx,
+		scanPolicyDao:     scanPolicyDao,
+		artifactScanDao:   artifactScanDao,
+		registryDao:       registryDao,
+		imageDao:          imageDao,
+		artifactDao:       artifactDao,
+		nodesDao:          nodesDao,
+		quarantineDao:     quarantineDao,
+		quarantineService: quarantineService,
+		quarantineFinder:  quarantineFinder,
+		fileManager:       fileManager,
+		spaceFinder:       spaceFinder,
+	}
+}
+
+func (s *Service) handleScanArtifact(ctx context.Context, task *types.Task, eventID string) error {
+	var payload types.ScanArtifactTaskPayload
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		log.Ctx(ctx).Error().Msgf("failed to unmarshal task payload for task [%s]: %v", task.Key, err)
+		return fmt.Errorf("failed to unmarshal task payload: %w", err)
+	}
+
+	// quarantine entries are created by the principal that uploaded the artifact.
+	ctx = request.WithAuthSession(ctx, &auth.Session{
+		Principal: coretypes.Principal{
+			ID: payload.PrincipalID,
+		},
+	})
+
+	policy, err := s.scanPolicyDao.Find(ctx, payload.RegistryID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find scan policy: %w", err)
+	}
+	if !policy.Enabled {
+		return nil
+	}
+
+	a, err := s.artifactDao.Get(ctx, payload.ArtifactID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		// the version was deleted before it could be scanned.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	image, err := s.imageDao.Get(ctx, a.ImageID)
+	if err != nil {
+		return fmt.Errorf("failed to find image: %w", err)
+	}
+
+	registry, err := s.registryDao.Get(ctx, payload.RegistryID)
+	if err != nil {
+		return fmt.Errorf("failed to find registry: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("scanning artifact %s:%s of registry %s for event %s",
+		image.Name, a.Version, registry.Name, eventID)
+
+	scan := &types.ArtifactScan{
+		RegistryID: registry.ID,
+		ArtifactID: a.ID,
+		Status:     types.ScanStatusRunning,
+	}
+
+	if s.scanner == nil {
+		return s.fail(ctx, scan, errors.New("no vulnerability scanner is configured"))
+	}
+	scan.Scanner = s.scanner.Name()
+
+	if err = s.artifactScanDao.Upsert(ctx, scan); err != nil {
+		return fmt.Errorf("failed to save artifact scan: %w", err)
+	}
+
+	vulnerabilities, err := s.scan(ctx, registry, image, a)
+	if err != nil {
+		return s.fail(ctx, scan, err)
+	}
+
+	return s.saveResult(ctx, policy, registry, image, a, scan, vulnerabilities)
+}
+
+func (s *Service) fail(ctx context.Context, scan *types.ArtifactScan, scanErr error) error {
+	scan.Status = types.ScanStatusFailure
+	scan.Error = scanErr.Error()
+	if err := s.artifactScanDao.Upsert(ctx, scan); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to save failed scan of artifact %d", scan.ArtifactID)
+	}
+	return fmt.Errorf("failed to scan artifact %d: %w", scan.ArtifactID, scanErr)
+}
+
+// scan downloads the files of the artifact version to a temporary directory and runs the scanner on it.
+func (s *Service) scan(
+	ctx context.Context,
+	registry *types.Registry,
+	image *types.Image,
+	a *types.Artifact,
+) ([]types.Vulnerability, error) {
+	if s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeout)
+		defer cancel()
+	}
+
+	dir, err := os.MkdirTemp("", "gitness-scan-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove scan directory %s", dir)
+		}
+	}()
+
+	if err = s.download(ctx, registry, image, a.Version, dir); err != nil {
+		return nil, err
+	}
+
+	return s.scanner.Scan(ctx, dir)
+}
+
+func (s *Service) download(
+	ctx context.Context,
+	registry *types.Registry,
+	image *types.Image,
+	version string,
+	dir string,
+) error {
+	var filePath string
+	var err error
+	if !IsPackageTypeSupported(registry.PackageType) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedPackageType, registry.PackageType)
+	}
+
+	//nolint:exhaustive
+	switch registry.PackageType {
+	case artifact.PackageTypeHUGGINGFACE:
+		filePath, err = utils.GetFilePathWithArtifactType(registry.PackageType, image.Name, version, image.ArtifactType)
+	default:
+		filePath, err = utils.GetFilePath(registry.PackageType, image.Name, version)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnsupportedPackageType, err)
+	}
+
+	rootSpace, err := s.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space: %w", err)
+	}
+
+	nodes, err := s.nodesDao.GetAllFileNodesByPathPrefixAndRegistryID(ctx, registry.ID, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	for _, node := range *nodes {
+		rel := path.Clean("/" + strings.TrimPrefix(node.NodePath, filePath))
+		if rel == "/" {
+			rel = "/" + node.Name
+		}
+		if err = s.downloadFile(ctx, registry, rootSpace.Identifier, node.NodePath,
+			filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) downloadFile(
+	ctx context.Context,
+	registry *types.Registry,
+	rootIdentifier string,
+	nodePath string,
+	dst string,
+) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", nodePath, err)
+	}
+
+	reader, _, _, err := s.fileManager.DownloadFileByPath(ctx, nodePath, registry.ID, registry.Name,
+		rootIdentifier, false)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", nodePath, err)
+	}
+	defer reader.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create file for %s: %w", nodePath, err)
+	}
+
+	if _, err = io.Copy(f, reader); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", nodePath, err)
+	}
+
+	return f.Close()
+}
+
+func (s *Service) saveResult(
+	ctx context.Context,
+	policy *types.ScanPolicy,
+	registry *types.Registry,
+	image *types.Image,
+	a *types.Artifact,
+	scan *types.ArtifactScan,
+	vulnerabilities []types.Vulnerability,
+) error {
+	scan.Status = types.ScanStatusSuccess
+	scan.Count(vulnerabilities)
+
+	found := violations(policy, vulnerabilities)
+	scan.Quarantined = len(found) > 0
+
+	quarantined := false
+	if scan.Quarantined {
+		var err error
+		quarantined, err = s.quarantineService.CheckArtifactQuarantineStatus(
+			ctx, registry.ID, image.Name, a.Version, image.ArtifactType)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.artifactScanDao.Upsert(ctx, scan); err != nil {
+			return fmt.Errorf("failed to save artifact scan: %w", err)
+		}
+
+		if err := s.artifactScanDao.ReplaceVulnerabilities(ctx, a.ID, vulnerabilities); err != nil {
+			return fmt.Errorf("failed to save vulnerabilities: %w", err)
+		}
+
+		if !scan.Quarantined || quarantined {
+			return nil
+		}
+
+		err := s.quarantineDao.Create(ctx, &types.QuarantineArtifact{
+			Reason:     quarantineReason(scan.Scanner, policy.QuarantineSeverity, len(found)),
+			RegistryID: registry.ID,
+			ArtifactID: a.ID,
+			ImageID:    image.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to quarantine artifact: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if scan.Quarantined && !quarantined {
+		s.quarantineFinder.EvictCache(ctx, registry.ID, image.Name, a.Version, image.ArtifactType)
+		log.Ctx(ctx).Info().Msgf("quarantined artifact %s:%s of registry %s: %d vulnerabilities at or above %s",
+			image.Name, a.Version, registry.Name, len(found), policy.QuarantineSeverity)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+)
+
+func TestIsPackageTypeSupported(t *testing.T) {
+	tests := []struct {
+		packageType artifact.PackageType
+		want        bool
+	}{
+		{packageType: artifact.PackageTypeDOCKER, want: false},
+		{packageType: artifact.PackageTypeHELM, want: false},
+		{packageType: artifact.PackageTypeNPM, want: true},
+		{packageType: artifact.PackageTypePYTHON, want: true},
+		{packageType: artifact.PackageTypeHUGGINGFACE, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.packageType), func(t *testing.T) {
+			if got := IsPackageTypeSupported(test.packageType); got != test.want {
+				t.Errorf("IsPackageTypeSupported(%s) = %t, want %t", test.packageType, got, test.want)
+			}
+		})
+	}
+}
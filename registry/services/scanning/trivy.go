@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// trivyScanner runs the trivy filesystem scanner, see https://trivy.dev.
+type trivyScanner struct {
+	path    string
+	offline bool
+}
+
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func (s *trivyScanner) Name() string {
+	return ScannerTrivy
+}
+
+func (s *trivyScanner) Scan(ctx context.Context, dir string) ([]types.Vulnerability, error) {
+	args := []string{"fs", "--quiet", "--format", "json", "--scanners", "vuln"}
+	if s.offline {
+		args = append(args, "--skip-db-update", "--offline-scan")
+	}
+	args = append(args, dir)
+
+	out, err := run(ctx, nil, s.path, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTrivyReport(out)
+}
+
+func parseTrivyReport(data []byte) ([]types.Vulnerability, error) {
+	var report trivyReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse trivy report: %w", err)
+	}
+
+	var vulnerabilities []types.Vulnerability
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, types.Vulnerability{
+				VulnerabilityID:  v.VulnerabilityID,
+				PackageName:      v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         types.ParseVulnerabilitySeverity(v.Severity),
+				Title:            v.Title,
+				Target:           result.Target,
+			})
+		}
+	}
+
+	return vulnerabilities, nil
+}
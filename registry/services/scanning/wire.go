@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	"github.com/harness/gitness/registry/app/store"
+	registryasyncprocessing "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideConfig,
+	ProvideService,
+)
+
+func ProvideConfig(config *types.Config) Config {
+	return Config{
+		Scanner: config.Registry.Scanning.Scanner,
+		Path:    config.Registry.Scanning.Path,
+		Offline: config.Registry.Scanning.Offline,
+		Timeout: config.Registry.Scanning.Timeout,
+	}
+}
+
+func ProvideService(
+	config Config,
+	asyncProcessingService *registryasyncprocessing.Service,
+	tx dbtx.Transactor,
+	scanPolicyDao store.ScanPolicyRepository,
+	artifactScanDao store.ArtifactScanRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	nodesDao store.NodesRepository,
+	quarantineDao store.QuarantineArtifactRepository,
+	quarantineService *quarantine.Service,
+	quarantineFinder quarantine.Finder,
+	fileManager filemanager.FileManager,
+	spaceFinder refcache.SpaceFinder,
+) (*Service, error) {
+	scanner, err := NewScanner(config)
+	if err != nil {
+		return nil, fmt.Errorf("provided vulnerability scanning config is invalid: %w", err)
+	}
+
+	service := NewService(
+		config,
+		scanner,
+		tx,
+		scanPolicyDao,
+		artifactScanDao,
+		registryDao,
+		imageDao,
+		artifactDao,
+		nodesDao,
+		quarantineDao,
+		quarantineService,
+		quarantineFinder,
+		fileManager,
+		spaceFinder,
+	)
+
+	asyncProcessingService.RegisterTaskHandler(registrytypes.TaskKindScanArtifact, service.handleScanArtifact)
+
+	return service, nil
+}
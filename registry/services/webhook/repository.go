@@ -53,13 +53,9 @@ func (s *RegistryWebhookExecutorStore) CreateWebhookExecution(
 
 func (s *RegistryWebhookExecutorStore) UpdateOptLock(
 	ctx context.Context, hook *types.WebhookCore,
-	execution *types.WebhookExecutionCore,
+	mutateFn func(hook *types.WebhookCore) error,
 ) (*types.WebhookCore, error) {
-	fn := func(hook *types.WebhookCore) error {
-		hook.LatestExecutionResult = &execution.Result
-		return nil
-	}
-	return s.webhookStore.UpdateOptLock(ctx, hook, fn)
+	return s.webhookStore.UpdateOptLock(ctx, hook, mutateFn)
 }
 
 func (s *RegistryWebhookExecutorStore) FindWebhook(
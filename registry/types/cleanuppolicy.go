@@ -27,11 +27,18 @@ type CleanupPolicy struct {
 	Name          string
 	VersionPrefix []string
 	PackagePrefix []string
-	ExpiryTime    int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	CreatedBy     int64
-	UpdatedBy     int64
+	// VersionPattern are regular expressions of which one has to match the whole version, if any are set.
+	VersionPattern []string
+	// ExcludePattern are regular expressions matching the package names and versions that are never deleted.
+	ExcludePattern []string
+	// ExpiryTime is the time in milliseconds after its last update that a version is deleted, 0 for no limit.
+	ExpiryTime int64
+	// KeepLast is the number of most recent matching versions of a package that are never deleted.
+	KeepLast  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy int64
+	UpdatedBy int64
 }
 
 // CleanupPolicyPrefix DTO object.
@@ -41,3 +48,23 @@ type CleanupPolicyPrefix struct {
 	Prefix          string
 	PrefixType      enum.PrefixType
 }
+
+// CleanupCandidate is an artifact version that is deleted by the cleanup policies of its registry.
+type CleanupCandidate struct {
+	ArtifactID  int64
+	Package     string
+	Version     string
+	Policy      string
+	Reason      string
+	LastUpdated time.Time
+}
+
+// PackageVersion is an artifact version together with the name of its package.
+// For OCI registries the versions are the tags of the images and ArtifactID holds the tag ID.
+type PackageVersion struct {
+	ArtifactID int64
+	Package    string
+	Version    string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
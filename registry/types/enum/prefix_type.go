@@ -19,4 +19,7 @@ type PrefixType string
 const (
 	PrefixTypeVersion PrefixType = "version"
 	PrefixTypePackage PrefixType = "package"
+	// PrefixTypeVersionPattern and PrefixTypeExcludePattern store regular expressions instead of prefixes.
+	PrefixTypeVersionPattern PrefixType = "version_pattern"
+	PrefixTypeExcludePattern PrefixType = "exclude_pattern"
 )
@@ -28,4 +28,8 @@ const (
 	PathPackageTypeCargo       PathPackageType = "cargo"
 	PathPackageTypeGo          PathPackageType = "go"
 	PathPackageTypeHuggingFace PathPackageType = "huggingface"
+	PathPackageTypeDebian      PathPackageType = "debian"
+	PathPackageTypeTerraform   PathPackageType = "terraform"
+	PathPackageTypeRubyGems    PathPackageType = "rubygems"
+	PathPackageTypeConda       PathPackageType = "conda"
 )
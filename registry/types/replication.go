@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"time"
+)
+
+// ReplicationDestinationType identifies where a replication rule copies artifacts to.
+type ReplicationDestinationType string
+
+const (
+	ReplicationDestinationLocal ReplicationDestinationType = "Local"
+	ReplicationDestinationJfrog ReplicationDestinationType = "Jfrog"
+	ReplicationDestinationGCP   ReplicationDestinationType = "GCP"
+)
+
+// ReplicationTrigger describes what started a replication run.
+type ReplicationTrigger string
+
+const (
+	ReplicationTriggerPush     ReplicationTrigger = "push"
+	ReplicationTriggerSchedule ReplicationTrigger = "schedule"
+	ReplicationTriggerManual   ReplicationTrigger = "manual"
+)
+
+// ReplicationStatus is the status of a replication run or of a single artifact within a run.
+type ReplicationStatus string
+
+const (
+	ReplicationStatusPending ReplicationStatus = "pending"
+	ReplicationStatusRunning ReplicationStatus = "running"
+	ReplicationStatusSuccess ReplicationStatus = "success"
+	ReplicationStatusFailure ReplicationStatus = "failure"
+	ReplicationStatusStopped ReplicationStatus = "stopped"
+)
+
+// IsDone returns true if the status is final.
+func (s ReplicationStatus) IsDone() bool {
+	return s == ReplicationStatusSuccess || s == ReplicationStatusFailure || s == ReplicationStatusStopped
+}
+
+// ReplicationRule DTO object.
+type ReplicationRule struct {
+	ID                    int64
+	SpaceID               int64
+	SourceRegistryID      int64
+	DestinationType       ReplicationDestinationType
+	DestinationRegistryID *int64
+	DestinationConfig     ReplicationDestinationConfig
+	AllowedPatterns       []string
+	BlockedPatterns       []string
+	CreatedBy             int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// ReplicationDestinationConfig holds the connection details of a remote replication destination.
+type ReplicationDestinationConfig struct {
+	URL              string `json:"url,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	Username         string `json:"username,omitempty"`
+	SecretIdentifier string `json:"secret_identifier,omitempty"`
+	SecretSpaceID    int64  `json:"secret_space_id,omitempty"`
+}
+
+// ReplicationRun DTO object.
+type ReplicationRun struct {
+	ID        int64
+	RuleID    int64
+	Trigger   ReplicationTrigger
+	Status    ReplicationStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReplicationRunArtifact tracks the replication of a single image tag within a run.
+type ReplicationRunArtifact struct {
+	ID        int64
+	RunID     int64
+	Image     string
+	Tag       string
+	Status    ReplicationStatus
+	Log       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// SigningKey is the OpenPGP key pair a registry uses to sign the package indices it generates.
+// The private key is stored encrypted.
+type SigningKey struct {
+	ID         int64
+	RegistryID int64
+	PrivateKey []byte
+	PublicKey  string
+	CreatedAt  time.Time
+}
@@ -34,6 +34,7 @@ const (
 	TaskKindBuildRegistryIndex   TaskKind = "build_registry_index"
 	TaskKindBuildPackageIndex    TaskKind = "build_package_index"
 	TaskKindBuildPackageMetadata TaskKind = "build_package_metadata"
+	TaskKindScanArtifact         TaskKind = "scan_artifact"
 )
 
 type SourceType string
@@ -95,3 +96,10 @@ type BuildPackageMetadataTaskPayload struct {
 	Version     string `json:"version"`
 	PrincipalID int64  `json:"principal_id"` //nolint:tagliatelle // TODO: setting service principal ID to run the task
 }
+
+type ScanArtifactTaskPayload struct {
+	Key         string `json:"key"`
+	RegistryID  int64  `json:"registry_id"`  //nolint:tagliatelle
+	ArtifactID  int64  `json:"artifact_id"`  //nolint:tagliatelle
+	PrincipalID int64  `json:"principal_id"` //nolint:tagliatelle
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// VulnerabilitySeverity is the severity of a vulnerability, ordered from Unknown to Critical.
+type VulnerabilitySeverity string
+
+const (
+	VulnerabilitySeverityUnknown  VulnerabilitySeverity = "UNKNOWN"
+	VulnerabilitySeverityLow      VulnerabilitySeverity = "LOW"
+	VulnerabilitySeverityMedium   VulnerabilitySeverity = "MEDIUM"
+	VulnerabilitySeverityHigh     VulnerabilitySeverity = "HIGH"
+	VulnerabilitySeverityCritical VulnerabilitySeverity = "CRITICAL"
+)
+
+var vulnerabilitySeverityRanks = map[VulnerabilitySeverity]int{
+	VulnerabilitySeverityUnknown:  0,
+	VulnerabilitySeverityLow:      1,
+	VulnerabilitySeverityMedium:   2,
+	VulnerabilitySeverityHigh:     3,
+	VulnerabilitySeverityCritical: 4,
+}
+
+// ParseVulnerabilitySeverity parses a severity case-insensitively. Severities that are not known,
+// e.g. "Negligible" as reported by some scanners, map to VulnerabilitySeverityUnknown.
+func ParseVulnerabilitySeverity(s string) VulnerabilitySeverity {
+	severity := VulnerabilitySeverity(strings.ToUpper(strings.TrimSpace(s)))
+	if _, ok := vulnerabilitySeverityRanks[severity]; !ok {
+		return VulnerabilitySeverityUnknown
+	}
+	return severity
+}
+
+// IsValid returns true if the severity is one of the known severities.
+func (s VulnerabilitySeverity) IsValid() bool {
+	_, ok := vulnerabilitySeverityRanks[s]
+	return ok
+}
+
+// AtLeast returns true if the severity is the same as or more severe than the other severity.
+func (s VulnerabilitySeverity) AtLeast(other VulnerabilitySeverity) bool {
+	return vulnerabilitySeverityRanks[s] >= vulnerabilitySeverityRanks[other]
+}
+
+// ScanPolicy configures vulnerability scanning of the artifacts pushed to a registry.
+// An empty QuarantineSeverity disables the automatic quarantine, findings are recorded only.
+type ScanPolicy struct {
+	RegistryID         int64
+	Enabled            bool
+	QuarantineSeverity VulnerabilitySeverity
+	CreatedBy          int64
+	UpdatedBy          int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// ScanStatus is the status of the vulnerability scan of an artifact version.
+type ScanStatus string
+
+const (
+	ScanStatusRunning ScanStatus = "RUNNING"
+	ScanStatusSuccess ScanStatus = "SUCCESS"
+	ScanStatusFailure ScanStatus = "FAILURE"
+)
+
+// ArtifactScan is the result of the latest vulnerability scan of an artifact version.
+type ArtifactScan struct {
+	ID          int64
+	RegistryID  int64
+	ArtifactID  int64
+	Scanner     string
+	Status      ScanStatus
+	Error       string
+	Critical    int64
+	High        int64
+	Medium      int64
+	Low         int64
+	Unknown     int64
+	Quarantined bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Vulnerability is a single vulnerability found in an artifact version.
+type Vulnerability struct {
+	ID               int64
+	ArtifactID       int64
+	VulnerabilityID  string
+	PackageName      string
+	InstalledVersion string
+	FixedVersion     string
+	Severity         VulnerabilitySeverity
+	Title            string
+	// Target is the file or lock file of the artifact the vulnerable package was found in.
+	Target string
+}
+
+// Count adds the vulnerabilities to the per severity counters of the scan.
+func (s *ArtifactScan) Count(vulnerabilities []Vulnerability) {
+	for _, v := range vulnerabilities {
+		//nolint:exhaustive
+		switch v.Severity {
+		case VulnerabilitySeverityCritical:
+			s.Critical++
+		case VulnerabilitySeverityHigh:
+			s.High++
+		case VulnerabilitySeverityMedium:
+			s.Medium++
+		case VulnerabilitySeverityLow:
+			s.Low++
+		default:
+			s.Unknown++
+		}
+	}
+}
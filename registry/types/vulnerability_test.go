@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "testing"
+
+func TestParseVulnerabilitySeverity(t *testing.T) {
+	tests := map[string]VulnerabilitySeverity{
+		"CRITICAL":   VulnerabilitySeverityCritical,
+		"high":       VulnerabilitySeverityHigh,
+		" Medium ":   VulnerabilitySeverityMedium,
+		"Low":        VulnerabilitySeverityLow,
+		"Negligible": VulnerabilitySeverityUnknown,
+		"":           VulnerabilitySeverityUnknown,
+	}
+	for input, want := range tests {
+		if got := ParseVulnerabilitySeverity(input); got != want {
+			t.Errorf("ParseVulnerabilitySeverity(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestVulnerabilitySeverityAtLeast(t *testing.T) {
+	if !VulnerabilitySeverityCritical.AtLeast(VulnerabilitySeverityHigh) {
+		t.Error("expected CRITICAL to be at least HIGH")
+	}
+	if !VulnerabilitySeverityHigh.AtLeast(VulnerabilitySeverityHigh) {
+		t.Error("expected HIGH to be at least HIGH")
+	}
+	if VulnerabilitySeverityLow.AtLeast(VulnerabilitySeverityMedium) {
+		t.Error("expected LOW not to be at least MEDIUM")
+	}
+}
+
+func TestArtifactScanCount(t *testing.T) {
+	scan := &ArtifactScan{}
+	scan.Count([]Vulnerability{
+		{Severity: VulnerabilitySeverityCritical},
+		{Severity: VulnerabilitySeverityHigh},
+		{Severity: VulnerabilitySeverityHigh},
+		{Severity: VulnerabilitySeverityLow},
+		{Severity: ""},
+	})
+	if scan.Critical != 1 || scan.High != 2 || scan.Medium != 0 || scan.Low != 1 || scan.Unknown != 1 {
+		t.Errorf("unexpected counts %+v", scan)
+	}
+}
@@ -108,6 +108,9 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// CommitSigningKeyPath (optional) points to an unencrypted SSH or PGP private key
+		// used to sign merge, squash and rebase commits created by the server.
+		CommitSigningKeyPath string `envconfig:"GITNESS_GIT_COMMIT_SIGNING_KEY_PATH"`
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -174,6 +177,15 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// ArtifactMaxSize is the maximum size in bytes of a single artifact published by a pipeline step
+		// (0 for no limit).
+		ArtifactMaxSize int64 `envconfig:"GITNESS_CI_ARTIFACT_MAX_SIZE" default:"104857600"` // 100 MiB
+		// ArtifactRetentionTime is the duration after which execution artifacts are purged (0 keeps them forever).
+		ArtifactRetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION_TIME" default:"720h"` // 30 days
+		// ArtifactRetentionCount is the number of latest executions per pipeline whose artifacts are kept
+		// (0 keeps the artifacts of all executions).
+		ArtifactRetentionCount int64 `envconfig:"GITNESS_CI_ARTIFACT_RETENTION_COUNT" default:"0"`
 	}
 
 	// Database defines the database configuration parameters.
@@ -214,6 +226,70 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OIDC defines the configuration of the single sign-on via an external OpenID Connect provider.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED" default:"false"`
+
+		// Issuer is the issuer URL of the provider, used for the discovery of its endpoints and keys.
+		Issuer       string `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+
+		// RedirectURL is the callback URL registered with the provider.
+		// Defaults to the OIDC callback endpoint of the API (e.g. http://localhost:3000/api/v1/oidc/callback).
+		RedirectURL string   `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+		Scopes      []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,profile,email"`
+
+		// GroupsClaim is the name of the ID token claim containing the groups of the user.
+		GroupsClaim string `envconfig:"GITNESS_OIDC_GROUPS_CLAIM" default:"groups"`
+
+		// GroupMapping maps the groups of the ID token to user groups,
+		// e.g. "developers:acme/developers,admins:acme/platform/admins".
+		// The memberships of a user in mapped user groups are synced on every login.
+		GroupMapping map[string]string `envconfig:"GITNESS_OIDC_GROUP_MAPPING"`
+
+		// DisableLocalLogin disables the login and sign-up with a local password once single sign-on is enabled.
+		DisableLocalLogin bool `envconfig:"GITNESS_OIDC_DISABLE_LOCAL_LOGIN" default:"false"`
+	}
+
+	// LDAP defines the configuration of the authentication against an LDAP or Active Directory server.
+	LDAP struct {
+		Enabled bool `envconfig:"GITNESS_LDAP_ENABLED" default:"false"`
+
+		// URL is the address of the server, e.g. ldap://ldap.example.com:389 or ldaps://ldap.example.com:636.
+		URL                string `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool   `envconfig:"GITNESS_LDAP_START_TLS" default:"false"`
+		InsecureSkipVerify bool   `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+
+		// BindDN and BindPassword are the credentials of the service account used to search the directory.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+		// UserFilter is the filter used to find a user, %s is replaced with the login identifier.
+		// For Active Directory use e.g. (&(objectClass=user)(sAMAccountName=%s)).
+		UserFilter           string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(&(objectClass=person)(uid=%s))"`
+		UsernameAttribute    string `envconfig:"GITNESS_LDAP_USERNAME_ATTRIBUTE" default:"uid"`
+		EmailAttribute       string `envconfig:"GITNESS_LDAP_EMAIL_ATTRIBUTE" default:"mail"`
+		DisplayNameAttribute string `envconfig:"GITNESS_LDAP_DISPLAY_NAME_ATTRIBUTE" default:"cn"`
+
+		GroupBaseDN          string `envconfig:"GITNESS_LDAP_GROUP_BASE_DN"`
+		GroupFilter          string `envconfig:"GITNESS_LDAP_GROUP_FILTER" default:"(objectClass=groupOfNames)"`
+		GroupNameAttribute   string `envconfig:"GITNESS_LDAP_GROUP_NAME_ATTRIBUTE" default:"cn"`
+		GroupMemberAttribute string `envconfig:"GITNESS_LDAP_GROUP_MEMBER_ATTRIBUTE" default:"member"`
+
+		// GroupMapping maps LDAP groups to user groups, e.g. "developers:acme/developers".
+		GroupMapping map[string]string `envconfig:"GITNESS_LDAP_GROUP_MAPPING"`
+		// SpaceRoleMapping maps LDAP groups to space memberships, e.g. "developers:acme=contributor".
+		// If a user is in multiple groups mapped to the same space, the highest role is granted.
+		SpaceRoleMapping map[string]string `envconfig:"GITNESS_LDAP_SPACE_ROLE_MAPPING"`
+
+		// SyncCron defines when the users and groups are synced with the directory.
+		// Users removed from the directory are blocked.
+		SyncCron        string        `envconfig:"GITNESS_LDAP_SYNC_CRON" default:"0 * * * *"`
+		SyncMaxDuration time.Duration `envconfig:"GITNESS_LDAP_SYNC_MAX_DURATION" default:"10m"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -352,6 +428,15 @@ type Config struct {
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime  time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 		InternalSecret string        `envconfig:"GITNESS_WEBHOOK_INTERNAL_SECRET"`
+
+		// RetryMaxAttempts is the maximum number of delivery attempts for 5xx responses and network errors.
+		RetryMaxAttempts int `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_ATTEMPTS" default:"5"`
+		// RetryBackoffBase is the delay before the first retry, it doubles with every further attempt.
+		RetryBackoffBase time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF_BASE" default:"1m"`
+		RetryBackoffMax  time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF_MAX" default:"1h"`
+		// CircuitBreakerThreshold is the number of consecutive permanently failed deliveries
+		// after which a webhook gets disabled (0 disables the circuit breaker).
+		CircuitBreakerThreshold int64 `envconfig:"GITNESS_WEBHOOK_CIRCUIT_BREAKER_THRESHOLD" default:"10"`
 	}
 
 	Trigger struct {
@@ -374,6 +459,8 @@ type Config struct {
 		MergeabilityConcurrency int `envconfig:"GITNESS_PULLREQ_MERGEABILITY_CONCURRENCY" default:"3"`
 		// CodeCommentsConcurrency controls the number of concurrent workers processing code comment updates.
 		CodeCommentsConcurrency int `envconfig:"GITNESS_PULLREQ_CODE_COMMENTS_CONCURRENCY" default:"3"`
+		// StackConcurrency controls the number of concurrent workers retargeting stacked pull requests.
+		StackConcurrency int `envconfig:"GITNESS_PULLREQ_STACK_CONCURRENCY" default:"1"`
 	}
 
 	AutoMerge struct {
@@ -447,11 +534,30 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		// DigestHourlyCron is the cron schedule on which the hourly notification digests are sent.
+		DigestHourlyCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_HOURLY_CRON" default:"0 * * * *"`
+		// DigestDailyCron is the cron schedule on which the daily notification digests are sent.
+		DigestDailyCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_DAILY_CRON" default:"0 8 * * *"`
+
+		Chat struct {
+			AllowPrivateNetwork bool `envconfig:"GITNESS_NOTIFICATION_CHAT_ALLOW_PRIVATE_NETWORK" default:"false"`
+			AllowLoopback       bool `envconfig:"GITNESS_NOTIFICATION_CHAT_ALLOW_LOOPBACK" default:"false"`
+			AllowLinkLocal      bool `envconfig:"GITNESS_NOTIFICATION_CHAT_ALLOW_LINK_LOCAL" default:"false"`
+		}
 	}
 
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexRoot is the directory of the local search index. Defaults to a directory in the git root.
+		IndexRoot string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_ROOT"`
+		// MaxFileSize is the size of the largest file (in bytes) that gets indexed.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1 MiB
+		// IndexCacheSize is the number of decoded repository indexes kept in memory.
+		IndexCacheSize int `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_CACHE_SIZE" default:"16"`
+		// IndexCacheMaxBytes is the estimated memory size (in bytes) of all decoded repository indexes kept in memory.
+		IndexCacheMaxBytes int64 `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_CACHE_MAX_BYTES" default:"268435456"` // 256 MiB
 	}
 
 	Repos struct {
@@ -632,6 +738,29 @@ type Config struct {
 			MaxRetries    int  `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_MAX_RETRIES" default:"3"`
 			AllowLoopback bool `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_ALLOW_LOOPBACK" default:"false"`
 		}
+
+		Replication struct {
+			Concurrency int `envconfig:"GITNESS_REGISTRY_REPLICATION_CONCURRENCY" default:"2"`
+			MaxRetries  int `envconfig:"GITNESS_REGISTRY_REPLICATION_MAX_RETRIES" default:"3"`
+			// Cron schedules full replication runs of all replication rules, leave empty to disable scheduled runs.
+			Cron string `envconfig:"GITNESS_REGISTRY_REPLICATION_CRON"`
+		}
+
+		Scanning struct {
+			// Scanner defines the vulnerability scanner to run on uploaded artifacts.
+			// Options are: `trivy`, `grype`. Leave empty to disable scanning.
+			Scanner string `envconfig:"GITNESS_REGISTRY_SCANNING_SCANNER"`
+			// Path is the path of the scanner binary, defaults to the scanner name looked up in PATH.
+			Path string `envconfig:"GITNESS_REGISTRY_SCANNING_PATH"`
+			// Offline prevents the scanner from updating its vulnerability database.
+			Offline bool          `envconfig:"GITNESS_REGISTRY_SCANNING_OFFLINE" default:"false"`
+			Timeout time.Duration `envconfig:"GITNESS_REGISTRY_SCANNING_TIMEOUT" default:"10m"`
+		}
+
+		CleanupPolicy struct {
+			// Cron schedules the enforcement of the cleanup policies of all registries, leave empty to disable it.
+			Cron string `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICY_CRON" default:"15 3 * * *"`
+		}
 	}
 
 	Auth struct {
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ChatChannelType defines the flavor of the incoming-webhook a chat notification is posted to.
+type ChatChannelType string
+
+func (ChatChannelType) Enum() []interface{} { return toInterfaceSlice(ChatChannelTypes) }
+func (t ChatChannelType) Sanitize() (ChatChannelType, bool) {
+	return Sanitize(t, GetAllChatChannelTypes)
+}
+func GetAllChatChannelTypes() ([]ChatChannelType, ChatChannelType) { return ChatChannelTypes, "" }
+
+const (
+	// ChatChannelTypeSlack posts Slack incoming-webhook messages.
+	ChatChannelTypeSlack ChatChannelType = "slack"
+	// ChatChannelTypeTeams posts Microsoft Teams incoming-webhook message cards.
+	ChatChannelTypeTeams ChatChannelType = "teams"
+	// ChatChannelTypeGeneric posts a plain JSON document describing the event.
+	ChatChannelTypeGeneric ChatChannelType = "generic"
+)
+
+var ChatChannelTypes = sortEnum([]ChatChannelType{
+	ChatChannelTypeSlack,
+	ChatChannelTypeTeams,
+	ChatChannelTypeGeneric,
+})
@@ -52,7 +52,7 @@ func GetAllCIStatuses() ([]CIStatus, CIStatus) {
 }
 
 func (status CIStatus) ConvertToCheckStatus() CheckStatus {
-	if status == CIStatusPending || status == CIStatusWaitingOnDeps {
+	if status == CIStatusPending || status == CIStatusWaitingOnDeps || status == CIStatusBlocked {
 		return CheckStatusPending
 	}
 	if status == CIStatusSuccess || status == CIStatusSkipped {
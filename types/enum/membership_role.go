@@ -23,6 +23,12 @@ func (MembershipRole) Enum() []any                              { return toInter
 func (m MembershipRole) Sanitize() (MembershipRole, bool)       { return Sanitize(m, GetAllMembershipRoles) }
 func GetAllMembershipRoles() ([]MembershipRole, MembershipRole) { return MembershipRoles, "" }
 
+// IsBuiltIn returns true for the predefined roles. All other roles are custom roles defined in a space.
+func (m MembershipRole) IsBuiltIn() bool {
+	_, found := slices.BinarySearch(MembershipRoles, m)
+	return found
+}
+
 var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleReader,
 	MembershipRoleExecutor,
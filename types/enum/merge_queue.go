@@ -37,6 +37,9 @@ const (
 	// MergeQueueEntryStateMergeGroup indicates the entry is part of the active merge group
 	// and is waiting to be fast-forwarded onto the target branch.
 	MergeQueueEntryStateMergeGroup MergeQueueEntryState = "merge_group"
+	// MergeQueueEntryStateBisectPending indicates the entry is part of a merge group that failed checks
+	// and is waiting for the group to be bisected to find the pull request that caused the failure.
+	MergeQueueEntryStateBisectPending MergeQueueEntryState = "bisect_pending"
 )
 
 var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
@@ -44,6 +47,7 @@ var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
 	MergeQueueEntryStateChecksPending,
 	MergeQueueEntryStateChecksInProgress,
 	MergeQueueEntryStateMergeGroup,
+	MergeQueueEntryStateBisectPending,
 })
 
 // MergeQueueRemovalReason defines the reason a pull request was removed from the merge queue.
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationEvent defines the kind of pull request event a principal can get notified about.
+type NotificationEvent string
+
+func (NotificationEvent) Enum() []any { return toInterfaceSlice(NotificationEvents) }
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return NotificationEvents, ""
+}
+
+const (
+	NotificationEventComment         NotificationEvent = "comment"
+	NotificationEventReviewerAdded   NotificationEvent = "reviewer_added"
+	NotificationEventBranchUpdated   NotificationEvent = "branch_updated"
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	NotificationEventStateChanged    NotificationEvent = "state_changed"
+)
+
+var NotificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventComment,
+	NotificationEventReviewerAdded,
+	NotificationEventBranchUpdated,
+	NotificationEventReviewSubmitted,
+	NotificationEventStateChanged,
+})
+
+// NotificationMode defines how a principal gets notified about an event.
+type NotificationMode string
+
+func (NotificationMode) Enum() []any { return toInterfaceSlice(NotificationModes) }
+func (m NotificationMode) Sanitize() (NotificationMode, bool) {
+	return Sanitize(m, GetAllNotificationModes)
+}
+func GetAllNotificationModes() ([]NotificationMode, NotificationMode) {
+	return NotificationModes, NotificationModeImmediate
+}
+
+const (
+	// NotificationModeOff disables notifications.
+	NotificationModeOff NotificationMode = "off"
+	// NotificationModeImmediate sends a notification as soon as the event happens.
+	NotificationModeImmediate NotificationMode = "immediate"
+	// NotificationModeHourly collects the notifications and sends them in an hourly digest.
+	NotificationModeHourly NotificationMode = "hourly"
+	// NotificationModeDaily collects the notifications and sends them in a daily digest.
+	NotificationModeDaily NotificationMode = "daily"
+)
+
+var NotificationModes = sortEnum([]NotificationMode{
+	NotificationModeOff,
+	NotificationModeImmediate,
+	NotificationModeHourly,
+	NotificationModeDaily,
+})
+
+// IsDigest returns true if notifications are collected into a digest.
+func (m NotificationMode) IsDigest() bool {
+	return m == NotificationModeHourly || m == NotificationModeDaily
+}
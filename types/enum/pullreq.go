@@ -120,6 +120,8 @@ const (
 	PullReqActivityTypeMergeQueueAdd                   PullReqActivityType = "merge-queue-added"
 	PullReqActivityTypeMergeQueueRemove                PullReqActivityType = "merge-queue-removed"
 	PullReqActivityTypeTargetBranchDeleted             PullReqActivityType = "target-branch-deleted"
+	PullReqActivityTypeStackParentChange               PullReqActivityType = "stack-parent-change"
+	PullReqActivityTypeStackParentMerged               PullReqActivityType = "stack-parent-merged"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -143,6 +145,8 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeMergeQueueAdd,
 	PullReqActivityTypeMergeQueueRemove,
 	PullReqActivityTypeTargetBranchDeleted,
+	PullReqActivityTypeStackParentChange,
+	PullReqActivityTypeStackParentMerged,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -268,6 +272,7 @@ const (
 	MergeMethodSquash      = MergeMethod(gitenum.MergeMethodSquash)
 	MergeMethodRebase      = MergeMethod(gitenum.MergeMethodRebase)
 	MergeMethodFastForward = MergeMethod(gitenum.MergeMethodFastForward)
+	MergeMethodSemiLinear  = MergeMethod(gitenum.MergeMethodSemiLinear)
 )
 
 var MergeMethods = sortEnum([]MergeMethod{
@@ -275,6 +280,7 @@ var MergeMethods = sortEnum([]MergeMethod{
 	MergeMethodSquash,
 	MergeMethodRebase,
 	MergeMethodFastForward,
+	MergeMethodSemiLinear,
 })
 
 func (MergeMethod) Enum() []any { return toInterfaceSlice(MergeMethods) }
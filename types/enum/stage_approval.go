@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// StageApprovalDecision defines the decision made on the approval gate of a pipeline stage.
+type StageApprovalDecision string
+
+func (StageApprovalDecision) Enum() []any { return toInterfaceSlice(stageApprovalDecisions) }
+func (s StageApprovalDecision) Sanitize() (StageApprovalDecision, bool) {
+	return Sanitize(s, GetAllStageApprovalDecisions)
+}
+func GetAllStageApprovalDecisions() ([]StageApprovalDecision, StageApprovalDecision) {
+	return stageApprovalDecisions, StageApprovalDecisionPending
+}
+
+// StageApprovalDecision enumeration.
+const (
+	StageApprovalDecisionPending  StageApprovalDecision = "pending"
+	StageApprovalDecisionApproved StageApprovalDecision = "approved"
+	StageApprovalDecisionRejected StageApprovalDecision = "rejected"
+	StageApprovalDecisionExpired  StageApprovalDecision = "expired"
+)
+
+var stageApprovalDecisions = sortEnum([]StageApprovalDecision{
+	StageApprovalDecisionPending,
+	StageApprovalDecisionApproved,
+	StageApprovalDecisionRejected,
+	StageApprovalDecisionExpired,
+})
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TestStatus defines the outcome of a test case of a test report.
+type TestStatus string
+
+func (TestStatus) Enum() []any                    { return toInterfaceSlice(testStatuses) }
+func (s TestStatus) Sanitize() (TestStatus, bool) { return Sanitize(s, GetAllTestStatuses) }
+func GetAllTestStatuses() ([]TestStatus, TestStatus) {
+	return testStatuses, ""
+}
+
+// TestStatus enumeration.
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+var testStatuses = sortEnum([]TestStatus{
+	TestStatusPassed,
+	TestStatusFailed,
+	TestStatusSkipped,
+})
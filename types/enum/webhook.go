@@ -232,3 +232,54 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerArtifactCreated,
 	WebhookTriggerArtifactDeleted,
 })
+
+// WebhookContentType defines the content type of the webhook request body.
+type WebhookContentType string
+
+func (WebhookContentType) Enum() []any { return toInterfaceSlice(webhookContentTypes) }
+func (s WebhookContentType) Sanitize() (WebhookContentType, bool) {
+	return Sanitize(s, GetAllWebhookContentTypes)
+}
+
+func GetAllWebhookContentTypes() ([]WebhookContentType, WebhookContentType) {
+	return webhookContentTypes, WebhookContentTypeJSON
+}
+
+const (
+	// WebhookContentTypeJSON sends the payload as JSON document.
+	WebhookContentTypeJSON WebhookContentType = "json"
+	// WebhookContentTypeForm sends the payload URL encoded as form.
+	WebhookContentTypeForm WebhookContentType = "form"
+)
+
+var webhookContentTypes = sortEnum([]WebhookContentType{
+	WebhookContentTypeJSON,
+	WebhookContentTypeForm,
+})
+
+// WebhookPayloadTemplateType defines how the webhook request body is rendered from the webhook payload.
+type WebhookPayloadTemplateType string
+
+func (WebhookPayloadTemplateType) Enum() []any { return toInterfaceSlice(webhookPayloadTemplateTypes) }
+func (s WebhookPayloadTemplateType) Sanitize() (WebhookPayloadTemplateType, bool) {
+	return Sanitize(s, GetAllWebhookPayloadTemplateTypes)
+}
+
+func GetAllWebhookPayloadTemplateTypes() ([]WebhookPayloadTemplateType, WebhookPayloadTemplateType) {
+	return webhookPayloadTemplateTypes, WebhookPayloadTemplateTypeNone
+}
+
+const (
+	// WebhookPayloadTemplateTypeNone sends the webhook payload as is.
+	WebhookPayloadTemplateTypeNone WebhookPayloadTemplateType = "none"
+	// WebhookPayloadTemplateTypeGoTemplate renders the body with a Go text/template.
+	WebhookPayloadTemplateTypeGoTemplate WebhookPayloadTemplateType = "go_template"
+	// WebhookPayloadTemplateTypeJSONPath builds the body from a JSON object of field names and JSONPath expressions.
+	WebhookPayloadTemplateTypeJSONPath WebhookPayloadTemplateType = "jsonpath"
+)
+
+var webhookPayloadTemplateTypes = sortEnum([]WebhookPayloadTemplateType{
+	WebhookPayloadTemplateTypeNone,
+	WebhookPayloadTemplateTypeGoTemplate,
+	WebhookPayloadTemplateTypeJSONPath,
+})
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ExecutionArtifact is a file published by a pipeline step and stored in the blob store.
+type ExecutionArtifact struct {
+	ID          int64  `json:"id"`
+	ExecutionID int64  `json:"execution_id"`
+	StageID     int64  `json:"stage_id"`
+	StepID      int64  `json:"step_id"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	BlobPath    string `json:"-"`
+	Created     int64  `json:"created"`
+}
+
+// ExecutionArtifactRetention defines which execution artifacts are expired.
+type ExecutionArtifactRetention struct {
+	// CreatedBefore expires artifacts created before the given time (unix milliseconds), if set.
+	CreatedBefore int64
+	// KeepExecutions expires artifacts of all but the latest executions of a pipeline, if set.
+	KeepExecutions int64
+}
@@ -0,0 +1,26 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ExternalIdentity links a user principal to an identity of an external identity provider.
+type ExternalIdentity struct {
+	// Provider identifies the identity provider, e.g. the issuer of an OpenID Connect provider.
+	Provider string `json:"provider"`
+	// Subject is the stable identifier of the user within the identity provider.
+	Subject     string `json:"subject"`
+	PrincipalID int64  `json:"-"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// NotificationPreference defines how a principal gets notified about an event.
+type NotificationPreference struct {
+	PrincipalID int64                  `json:"-"`
+	Event       enum.NotificationEvent `json:"event"`
+	Mode        enum.NotificationMode  `json:"mode"`
+	Created     int64                  `json:"created"`
+	Updated     int64                  `json:"updated"`
+}
+
+// NotificationWatch overrides whether a principal gets notified about pull requests of a repository
+// or about a single pull request, regardless of the principal's involvement in the pull request.
+// A pull request watch takes precedence over the watch of its repository.
+type NotificationWatch struct {
+	PrincipalID int64 `json:"-"`
+	RepoID      int64 `json:"repo_id"`
+	// PullReqID is zero for the watch of the whole repository.
+	PullReqID int64 `json:"pullreq_id,omitempty"`
+	// Watching is true if the principal wants to get notified, false if the principal doesn't.
+	Watching bool  `json:"watching"`
+	Created  int64 `json:"created"`
+	Updated  int64 `json:"updated"`
+}
+
+// NotificationWatchInput is used to watch or unwatch a repository or a pull request.
+type NotificationWatchInput struct {
+	Watching bool `json:"watching"`
+}
+
+// NotificationDigestItem is a single notification waiting to be sent in a digest.
+type NotificationDigestItem struct {
+	ID            int64                  `json:"id"`
+	EventID       string                 `json:"event_id"`
+	PrincipalID   int64                  `json:"principal_id"`
+	Mode          enum.NotificationMode  `json:"mode"`
+	Event         enum.NotificationEvent `json:"event"`
+	RepoID        int64                  `json:"repo_id"`
+	RepoPath      string                 `json:"repo_path"`
+	PullReqID     int64                  `json:"pullreq_id"`
+	PullReqNumber int64                  `json:"pullreq_number"`
+	PullReqTitle  string                 `json:"pullreq_title"`
+	PullReqURL    string                 `json:"pullreq_url"`
+	Summary       string                 `json:"summary"`
+	Created       int64                  `json:"created"`
+}
@@ -78,6 +78,10 @@ type PullReq struct {
 	Type *enum.PullReqType `json:"pullreq_type,omitempty"`
 
 	SourceRepo *RepositoryCore `json:"source_repo,omitempty"`
+
+	// StackParentID is the ID of the pull request this pull request depends on.
+	// The stack order is returned by the pull request stack API.
+	StackParentID *int64 `json:"-"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -90,7 +94,8 @@ func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []s
 			pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
 			pr.MergeConflicts = nil
 		}
-	case enum.MergeMethodRebase:
+	case enum.MergeMethodRebase, enum.MergeMethodSemiLinear:
+		// semi-linear merge can only conflict while rebasing, the merge commit itself never conflicts.
 		if len(conflictFiles) > 0 {
 			pr.RebaseCheckStatus = enum.MergeCheckStatusConflict
 			pr.RebaseConflicts = conflictFiles
@@ -192,6 +197,20 @@ type PullReqFilter struct {
 	SpaceIDs        []int64
 	RepoIDs         []int64
 	RepoIDBlacklist []int64
+	StackParentID   int64
+}
+
+// PullReqStackEntry is a pull request in a stack of pull requests depending on each other.
+type PullReqStackEntry struct {
+	Number       int64             `json:"number"`
+	Title        string            `json:"title"`
+	State        enum.PullReqState `json:"state"`
+	IsDraft      bool              `json:"is_draft"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
+	ParentNumber *int64            `json:"parent_number,omitempty"`
+	Depth        int               `json:"depth"`
+	Current      bool              `json:"current"`
 }
 
 type PullReqMetadataOptions struct {
@@ -350,6 +369,7 @@ type MergeVerifyOutput struct {
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
 	RequiresBypassMessage            bool `json:"requires_bypass_message,omitempty"`
 	RequiresMergeQueue               bool `json:"requires_merge_queue,omitempty"`
+	RequiresSignedCommits            bool `json:"requires_signed_commits,omitempty"`
 }
 
 type MergeViolations struct {
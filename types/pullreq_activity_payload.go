@@ -68,6 +68,8 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchChangeTarget{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetBranchDeleted{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadStackParentChange{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadStackParentMerged{} },
 	func() PullReqActivityPayload { return &PullRequestActivityLabel{} },
 	func() PullReqActivityPayload { return &PullRequestActivityLabels{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadNonUniqueMergeBase{} },
@@ -230,6 +232,30 @@ func (a *PullRequestActivityPayloadTargetBranchDeleted) ActivityType() enum.Pull
 	return enum.PullReqActivityTypeTargetBranchDeleted
 }
 
+type PullRequestActivityPayloadStackParentChange struct {
+	Old *int64 `json:"old,omitempty"`
+	New *int64 `json:"new,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadStackParentChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeStackParentChange
+}
+
+type PullRequestActivityPayloadStackParentMerged struct {
+	ParentNumber    int64    `json:"parent_number"`
+	OldTargetBranch string   `json:"old_target_branch"`
+	NewTargetBranch string   `json:"new_target_branch"`
+	OldMergeBaseSHA string   `json:"old_merge_base_sha"`
+	NewMergeBaseSHA string   `json:"new_merge_base_sha"`
+	Rebased         bool     `json:"rebased"`
+	RebaseSHA       string   `json:"rebase_sha,omitempty"`
+	RebaseConflicts []string `json:"rebase_conflicts,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadStackParentMerged) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeStackParentMerged
+}
+
 type PullRequestActivityLabelBase struct {
 	Label         string           `json:"label"`
 	LabelColor    enum.LabelColor  `json:"label_color"`
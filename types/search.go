@@ -30,6 +30,9 @@ type (
 		// EnableRegex enables regex search on the query
 		EnableRegex bool `json:"enable_regex"`
 
+		// CaseSensitive makes the search distinguish between upper and lower case
+		CaseSensitive bool `json:"case_sensitive"`
+
 		// Search all the repos in a space and its subspaces recursively.
 		// Valid only when spacePaths is set.
 		Recursive bool `json:"recursive"`
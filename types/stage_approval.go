@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// StageTypeApproval is the type of pipeline stages that wait for a manual approval instead of running steps.
+const StageTypeApproval = "approval"
+
+// StageApproval is the manual approval gate of a pipeline stage.
+type StageApproval struct {
+	ID          int64 `json:"id"`
+	ExecutionID int64 `json:"execution_id"`
+	StageID     int64 `json:"stage_id"`
+
+	// Approvers are the UIDs of the principals allowed to decide, in addition to the user groups.
+	Approvers []string `json:"approvers,omitempty"`
+	// UserGroups are the identifiers of the user groups whose members are allowed to decide.
+	UserGroups []string `json:"user_groups,omitempty"`
+	// Permission is the pipeline permission required to decide.
+	Permission enum.Permission `json:"permission"`
+	// Timeout is the duration in milliseconds after which the approval expires (0 for none).
+	Timeout int64 `json:"timeout,omitempty"`
+	// Deadline is the time the approval expires, it's set once the stage starts waiting.
+	Deadline int64 `json:"deadline,omitempty"`
+
+	Decision  enum.StageApprovalDecision `json:"decision"`
+	DecidedBy *int64                     `json:"-"`
+	Decider   *PrincipalInfo             `json:"decider,omitempty"`
+	Decided   int64                      `json:"decided,omitempty"`
+	Comment   string                     `json:"comment,omitempty"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+	Version int64 `json:"-"`
+}
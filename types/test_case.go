@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// TestCase is the result of a single test case parsed from a test report uploaded by a pipeline step.
+type TestCase struct {
+	ID          int64           `json:"-"`
+	ExecutionID int64           `json:"execution_id"`
+	StageID     int64           `json:"stage_id"`
+	StepID      int64           `json:"step_id"`
+	Suite       string          `json:"suite"`
+	ClassName   string          `json:"class_name"`
+	Name        string          `json:"name"`
+	Status      enum.TestStatus `json:"status"`
+	Duration    int64           `json:"duration"` // in milliseconds
+	Message     string          `json:"message,omitempty"`
+	Details     string          `json:"details,omitempty"`
+	Created     int64           `json:"created"`
+}
+
+// TestSummary contains the number of test cases of an execution by status.
+type TestSummary struct {
+	Total    int64 `json:"total"`
+	Passed   int64 `json:"passed"`
+	Failed   int64 `json:"failed"`
+	Skipped  int64 `json:"skipped"`
+	Duration int64 `json:"duration"` // in milliseconds
+}
+
+// TestResults contains the test cases of an execution together with their summary.
+type TestResults struct {
+	Summary TestSummary `json:"summary"`
+	Cases   []*TestCase `json:"cases"`
+}
+
+// TestCaseFilter stores test case query parameters.
+type TestCaseFilter struct {
+	Pagination
+	Status []enum.TestStatus `json:"status"`
+}
@@ -44,3 +44,10 @@ func (u *UserGroup) ToUserGroupInfo() *UserGroupInfo {
 		Scope:       u.Scope,
 	}
 }
+
+// UserGroupMember represents the membership of a user principal in a user group.
+type UserGroupMember struct {
+	UserGroupID int64 `json:"usergroup_id"`
+	PrincipalID int64 `json:"principal_id"`
+	Created     int64 `json:"created"`
+}
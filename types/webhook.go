@@ -47,6 +47,13 @@ type Webhook struct {
 	Triggers              []enum.WebhookTrigger        `json:"triggers" yaml:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty" yaml:"-"`
 	ExtraHeaders          []ExtraHeader                `json:"extra_headers,omitempty" yaml:"-"`
+
+	ContentType         enum.WebhookContentType         `json:"content_type" yaml:"content_type"`
+	PayloadTemplateType enum.WebhookPayloadTemplateType `json:"payload_template_type" yaml:"payload_template_type"`
+	PayloadTemplate     string                          `json:"payload_template,omitempty" yaml:"payload_template"`
+
+	// ConsecutiveFailures is the number of deliveries that failed permanently since the last successful one.
+	ConsecutiveFailures int64 `json:"consecutive_failures" yaml:"-"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -107,6 +114,10 @@ type WebhookCreateInput struct {
 	Insecure     bool                  `json:"insecure"`
 	Triggers     []enum.WebhookTrigger `json:"triggers"`
 	ExtraHeaders []ExtraHeader         `json:"extra_headers,omitempty"`
+
+	ContentType         enum.WebhookContentType         `json:"content_type"`
+	PayloadTemplateType enum.WebhookPayloadTemplateType `json:"payload_template_type"`
+	PayloadTemplate     string                          `json:"payload_template"`
 }
 
 type WebhookSignatureMetadata struct {
@@ -127,6 +138,10 @@ type WebhookUpdateInput struct {
 	Insecure     *bool                 `json:"insecure"`
 	Triggers     []enum.WebhookTrigger `json:"triggers"`
 	ExtraHeaders []ExtraHeader         `json:"extra_headers,omitempty"`
+
+	ContentType         *enum.WebhookContentType         `json:"content_type"`
+	PayloadTemplateType *enum.WebhookPayloadTemplateType `json:"payload_template_type"`
+	PayloadTemplate     *string                          `json:"payload_template"`
 }
 
 // WebhookExecution represents a single execution of a webhook.
@@ -143,6 +158,13 @@ type WebhookExecution struct {
 	Error         string                      `json:"error,omitempty"`
 	Request       WebhookExecutionRequest     `json:"request"`
 	Response      WebhookExecutionResponse    `json:"response"`
+
+	// Attempt is the number of the delivery attempt, starting at 1.
+	Attempt int `json:"attempt"`
+	// RetryAt is the time at which the delivery gets retried automatically.
+	RetryAt *int64 `json:"retry_at,omitempty"`
+	// DeadLetter marks the delivery as permanently failed.
+	DeadLetter bool `json:"dead_letter"`
 }
 
 // WebhookExecutionRequest represents the request of a webhook execution.
@@ -170,6 +192,17 @@ type WebhookFilter struct {
 	SkipInternal bool             `json:"-"`
 }
 
+// WebhookExecutionsRetriggerInput holds the permanently failed executions of a webhook to retrigger.
+// If no execution IDs are provided, all permanently failed executions of the webhook get retriggered.
+type WebhookExecutionsRetriggerInput struct {
+	ExecutionIDs []int64 `json:"execution_ids"`
+}
+
+// WebhookExecutionsRetriggerOutput holds the number of executions queued for retriggering.
+type WebhookExecutionsRetriggerOutput struct {
+	Queued int `json:"queued"`
+}
+
 // WebhookExecutionFilter stores WebhookExecution query parameters for listing.
 type WebhookExecutionFilter struct {
 	Page int `json:"page"`
@@ -204,6 +237,10 @@ type WebhookCore struct {
 	SecretIdentifier      string
 	SecretSpaceID         int64
 	ExtraHeaders          []ExtraHeader
+	ConsecutiveFailures   int64
+	ContentType           enum.WebhookContentType
+	PayloadTemplateType   enum.WebhookPayloadTemplateType
+	PayloadTemplate       string
 }
 
 // WebhookExecutionCore represents a webhook execution DTO object.
@@ -220,6 +257,9 @@ type WebhookExecutionCore struct {
 	Error         string
 	Request       WebhookExecutionRequest
 	Response      WebhookExecutionResponse
+	Attempt       int
+	RetryAt       *int64
+	DeadLetter    bool
 }
 
 type ExtraHeader struct {